@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"net/http"
@@ -55,12 +56,12 @@ func main() {
 
 	// Handle health check flag
 	if *healthCheck {
-		performHealthCheck(cfg.Server.Addr)
+		performHealthCheck(cfg.Server.Addr, cfg.Security.TLS.Enabled)
 		return
 	}
 
 	// Initialize logger
-	logger, err := logging.NewLogger(cfg.Logging.Level, cfg.Logging.Format, cfg.Logging.File)
+	logger, logLevel, err := logging.NewLoggerWithLevel(cfg.Logging.Level, cfg.Logging.Format, cfg.Logging.File)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
@@ -82,6 +83,7 @@ func main() {
 	if err != nil {
 		logger.Fatal("Failed to create API server", zap.Error(err))
 	}
+	apiServer.EnableConfigReload(*configFile, logLevel)
 
 	// Start server components
 	ctx, cancel := context.WithCancel(context.Background())
@@ -92,20 +94,82 @@ func main() {
 	}
 	defer apiServer.Stop()
 
+	// Periodically persist the timeseries store so history survives restarts
+	if interval := apiServer.TimeSeriesSnapshotInterval(); interval > 0 {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := apiServer.SaveTimeSeriesSnapshot(); err != nil {
+						logger.Error("Failed to save timeseries snapshot", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
+
 	// Create HTTP server
 	server := &http.Server{
 		Addr:    cfg.Server.Addr,
 		Handler: apiServer.Handler(),
 	}
 
+	// Serve HTTPS directly when TLS is configured, optionally redirecting plain HTTP
+	var redirectServer *http.Server
+	if cfg.Security.TLS.Enabled {
+		tlsConfig, err := api.NewTLSConfig(cfg.Security.TLS, logger)
+		if err != nil {
+			logger.Fatal("Failed to configure TLS", zap.Error(err))
+		}
+		server.TLSConfig = tlsConfig
+
+		if cfg.Security.TLS.RedirectHTTPAddr != "" {
+			redirectServer = &http.Server{
+				Addr:    cfg.Security.TLS.RedirectHTTPAddr,
+				Handler: api.HTTPSRedirectHandler(cfg.Server.Addr),
+			}
+			go func() {
+				logger.Info("HTTP redirect starting", zap.String("addr", redirectServer.Addr))
+				if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					logger.Fatal("HTTP redirect failed to start", zap.Error(err))
+				}
+			}()
+		}
+	}
+
 	// Start server in goroutine
 	go func() {
-		logger.Info("Server starting", zap.String("addr", cfg.Server.Addr))
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.Info("Server starting",
+			zap.String("addr", cfg.Server.Addr),
+			zap.Bool("tls", cfg.Security.TLS.Enabled))
+		var err error
+		if server.TLSConfig != nil {
+			// The certificate comes from TLSConfig.GetCertificate
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			logger.Fatal("Server failed to start", zap.Error(err))
 		}
 	}()
 
+	// Reload hot-reloadable configuration on SIGHUP
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			logger.Info("Received SIGHUP, reloading configuration")
+			if _, err := apiServer.ReloadConfig(); err != nil {
+				logger.Error("Configuration reload failed", zap.Error(err))
+			}
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -117,23 +181,47 @@ func main() {
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
 
-	if err := server.Shutdown(shutdownCtx); err != nil {
-		logger.Error("Server forced to shutdown", zap.Error(err))
-		os.Exit(1)
+	if redirectServer != nil {
+		if err := redirectServer.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("HTTP redirect forced to shutdown", zap.Error(err))
+		}
+	}
+	shutdownErr := server.Shutdown(shutdownCtx)
+	if shutdownErr != nil {
+		logger.Error("Server forced to shutdown", zap.Error(shutdownErr))
+	}
+
+	// Persist the timeseries store even when shutdown was forced
+	if err := apiServer.SaveTimeSeriesSnapshot(); err != nil {
+		logger.Error("Failed to save timeseries snapshot", zap.Error(err))
 	}
 
+	if shutdownErr != nil {
+		os.Exit(1)
+	}
 	logger.Info("Server exited")
 }
 
 // performHealthCheck performs a health check against the server's healthz endpoint
-func performHealthCheck(addr string) {
+func performHealthCheck(addr string, useTLS bool) {
 	// Build the health check URL
-	url := fmt.Sprintf("http://%s/healthz", addr)
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+	url := fmt.Sprintf("%s://%s/healthz", scheme, addr)
 
 	// Create HTTP client with timeout
 	client := &http.Client{
 		Timeout: 10 * time.Second,
 	}
+	if useTLS {
+		// The check targets the local listener, whose certificate is usually
+		// issued for the public host name
+		client.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
 
 	fmt.Printf("Performing health check against %s...\n", url)
 
@@ -104,6 +104,24 @@ func main() {
 	}
 	fmt.Println()
 
+	// Example 4: Node-scoped series, keyed by node name
+	nodeNames := []string{"ip-10-0-0-1.ec2.internal", "worker-1"}
+	for i, nodeName := range nodeNames {
+		nodeCPU := store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeCPUUsageBase, nodeName))
+		nodeCPU.Add(timeseries.NewPointWithEntity(now, 1.5+float64(i), map[string]string{"node": nodeName}))
+	}
+	fmt.Println("GET /api/v1/timeseries/nodes?series=node.cpu.usage.cores (entities lists nodes with data)")
+	fmt.Printf("  entities: %v\n", nodeNames)
+	fmt.Println("GET /api/v1/timeseries/nodes/ip-10-0-0-1.ec2.internal?series=node.cpu.usage.cores&res=hi&since=5m")
+	nodeKey := timeseries.GenerateNodeSeriesKey(timeseries.NodeCPUUsageBase, nodeNames[0])
+	if series, ok := store.Get(nodeKey); ok {
+		for _, point := range series.GetSince(now.Add(-5*time.Minute), timeseries.Hi) {
+			fmt.Printf("  %s: {\"t\": %d, \"v\": %.2f, \"entity\": {\"node\": %q}}\n", nodeKey, point.T.UnixMilli(), point.V, point.Entity["node"])
+		}
+	}
+	fmt.Println("GET /api/v1/timeseries/nodes/unknown-node -> 404")
+	fmt.Println()
+
 	// Show capabilities
 	fmt.Println("🔧 Capabilities Detection:")
 	capabilities := map[string]bool{
@@ -11,12 +11,15 @@ export interface SearchResult {
 	labels?: Record<string, string>;
 	creationTimestamp?: string;
 	age?: string;
+	matchField?: 'name' | 'labels';
+	match?: 'exact' | 'prefix' | 'substring' | 'label';
 }
 
 export interface SearchResponse {
 	results: SearchResult[];
 	total: number;
 	query: string;
+	incomplete?: string[];
 }
 
 export interface GroupedSearchResults {
@@ -17,6 +17,7 @@ require (
 	k8s.io/apimachinery v0.30.6
 	k8s.io/client-go v0.30.6
 	k8s.io/metrics v0.30.6
+	sigs.k8s.io/yaml v1.3.0
 )
 
 require (
@@ -66,5 +67,4 @@ require (
 	k8s.io/utils v0.0.0-20230726121419-3b25d923346b // indirect
 	sigs.k8s.io/json v0.0.0-20221116044647-bc3834ca7abd // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.4.1 // indirect
-	sigs.k8s.io/yaml v1.3.0 // indirect
 )
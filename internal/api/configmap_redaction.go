@@ -0,0 +1,237 @@
+package api
+
+import (
+	"encoding/base64"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/analysis"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// redactedValue replaces the value of a redacted ConfigMap key
+const redactedValue = "[REDACTED]"
+
+// lastAppliedAnnotation holds the object kubectl last applied, including the
+// data of a ConfigMap
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// configMapRedactor hides the values of ConfigMap keys that look sensitive.
+// A nil redactor reveals everything.
+type configMapRedactor struct {
+	patterns []*regexp.Regexp
+}
+
+// configMapRedactor builds the redactor for the configured key patterns. The
+// patterns are validated when the configuration is loaded.
+func (s *Server) configMapRedactor() *configMapRedactor {
+	redactor := &configMapRedactor{}
+	if s.config == nil {
+		return redactor
+	}
+	for _, pattern := range s.config.Features.ConfigMapRedactKeys {
+		if re, err := regexp.Compile(pattern); err == nil {
+			redactor.patterns = append(redactor.patterns, re)
+		}
+	}
+	return redactor
+}
+
+// sensitive reports whether the value of a key is redacted
+func (c *configMapRedactor) sensitive(key string) bool {
+	if c == nil {
+		return false
+	}
+	for _, re := range c.patterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactedKeys returns the sorted keys whose values are redacted
+func (c *configMapRedactor) redactedKeys(keys []string) []string {
+	redacted := []string{}
+	for _, key := range keys {
+		if c.sensitive(key) {
+			redacted = append(redacted, key)
+		}
+	}
+	sort.Strings(redacted)
+	return redacted
+}
+
+// redactData returns a copy of ConfigMap data with sensitive values replaced
+func (c *configMapRedactor) redactData(data map[string]interface{}) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		if c.sensitive(key) {
+			value = redactedValue
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// redactAnnotations returns a copy of annotations without the last-applied
+// configuration when it would repeat redacted values
+func (c *configMapRedactor) redactAnnotations(annotations map[string]string, redactedKeys []string) map[string]string {
+	if len(redactedKeys) == 0 || annotations[lastAppliedAnnotation] == "" {
+		return annotations
+	}
+	redacted := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		redacted[key] = value
+	}
+	redacted[lastAppliedAnnotation] = redactedValue
+	return redacted
+}
+
+// redactMetadata returns a copy of unstructured ConfigMap metadata without the
+// last-applied configuration when it would repeat redacted values
+func (c *configMapRedactor) redactMetadata(metadata map[string]interface{}, redactedKeys []string) map[string]interface{} {
+	annotations, ok := metadata["annotations"].(map[string]interface{})
+	if len(redactedKeys) == 0 || !ok || annotations[lastAppliedAnnotation] == nil {
+		return metadata
+	}
+	redactedAnnotations := make(map[string]interface{}, len(annotations))
+	for key, value := range annotations {
+		redactedAnnotations[key] = value
+	}
+	redactedAnnotations[lastAppliedAnnotation] = redactedValue
+
+	redacted := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		redacted[key] = value
+	}
+	redacted["annotations"] = redactedAnnotations
+	return redacted
+}
+
+// redactObject hides the sensitive values of a ConfigMap, typed or
+// unstructured, in place. Other objects are left alone.
+func (c *configMapRedactor) redactObject(object runtime.Object) {
+	switch configMap := object.(type) {
+	case *v1.ConfigMap:
+		keys := make([]string, 0, len(configMap.Data)+len(configMap.BinaryData))
+		for key := range configMap.Data {
+			keys = append(keys, key)
+		}
+		for key := range configMap.BinaryData {
+			keys = append(keys, key)
+		}
+		redactedKeys := c.redactedKeys(keys)
+		if len(redactedKeys) == 0 {
+			return
+		}
+		for _, key := range redactedKeys {
+			if _, ok := configMap.Data[key]; ok {
+				configMap.Data[key] = redactedValue
+			}
+			if _, ok := configMap.BinaryData[key]; ok {
+				configMap.BinaryData[key] = []byte(redactedValue)
+			}
+		}
+		configMap.Annotations = c.redactAnnotations(configMap.Annotations, redactedKeys)
+	case *unstructured.Unstructured:
+		// List items may come without their kind
+		if kind := configMap.GetKind(); kind != "" && kind != "ConfigMap" {
+			return
+		}
+		data, _ := configMap.Object["data"].(map[string]interface{})
+		binaryData, _ := configMap.Object["binaryData"].(map[string]interface{})
+		keys := make([]string, 0, len(data)+len(binaryData))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		for key := range binaryData {
+			keys = append(keys, key)
+		}
+		redactedKeys := c.redactedKeys(keys)
+		if len(redactedKeys) == 0 {
+			return
+		}
+		if data != nil {
+			configMap.Object["data"] = c.redactData(data)
+		}
+		if binaryData != nil {
+			// binaryData holds base64, so the marker is encoded to stay decodable
+			redacted := c.redactData(binaryData)
+			for _, key := range redactedKeys {
+				if _, ok := redacted[key]; ok {
+					redacted[key] = base64.StdEncoding.EncodeToString([]byte(redactedValue))
+				}
+			}
+			configMap.Object["binaryData"] = redacted
+		}
+		if metadata, ok := configMap.Object["metadata"].(map[string]interface{}); ok {
+			configMap.Object["metadata"] = c.redactMetadata(metadata, redactedKeys)
+		}
+	}
+}
+
+// redactFieldValue returns the value of a ConfigMap field path in a
+// comparison or diff with sensitive values replaced. A whole data map, as
+// reported when it was removed, is redacted key by key.
+func (c *configMapRedactor) redactFieldValue(path string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if path == "data" || path == "binaryData" {
+		if data, ok := value.(map[string]interface{}); ok {
+			return c.redactData(data)
+		}
+		return value
+	}
+	if key, ok := analysis.ConfigMapDataKey(path); ok && c.sensitive(key) {
+		return redactedValue
+	}
+	return value
+}
+
+// redactComparison hides the sensitive values of a ConfigMap comparison.
+// Whether a field differs is still reported.
+func (c *configMapRedactor) redactComparison(comparison *analysis.Comparison) {
+	for _, field := range comparison.Fields {
+		for namespace, value := range field.Values {
+			field.Values[namespace] = c.redactFieldValue(field.Path, value)
+		}
+	}
+}
+
+// redactLastAppliedDiff hides the sensitive values of a ConfigMap diff against
+// its last-applied configuration
+func (c *configMapRedactor) redactLastAppliedDiff(diff *analysis.LastAppliedDiff) {
+	for i := range diff.Changes {
+		change := &diff.Changes[i]
+		change.Applied = c.redactFieldValue(change.Path, change.Applied)
+		change.Live = c.redactFieldValue(change.Path, change.Live)
+	}
+}
+
+// configMapRedactorFor returns the redactor for a ConfigMap request: nil when
+// the caller passed reveal=true and may read secrets in the namespace, since
+// redacted keys are treated as secret material. ok is false when the caller
+// asked to reveal without that permission and an error has been written.
+func (s *Server) configMapRedactorFor(w http.ResponseWriter, r *http.Request, namespace string) (*configMapRedactor, bool) {
+	if r.URL.Query().Get("reveal") != "true" {
+		return s.configMapRedactor(), true
+	}
+	if !s.requireSecretAccess(w, r, namespace) {
+		return nil, false
+	}
+	return nil, true
+}
+
+// requireSecretAccess reports whether the caller may read secrets in the
+// namespace, writing an error when they may not
+func (s *Server) requireSecretAccess(w http.ResponseWriter, r *http.Request, namespace string) bool {
+	return s.requireResourcePermission(w, r, "get", "", "secrets", namespace)
+}
@@ -0,0 +1,270 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/auth"
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s"
+	"github.com/aaronlmathis/kaptn/internal/k8s/analysis"
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func redactionConfigMap() *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-config",
+			Namespace: "shop",
+			Annotations: map[string]string{
+				lastAppliedAnnotation: `{"data":{"DB_PASSWORD":"hunter2"}}`,
+				"team":                "payments",
+			},
+		},
+		Data: map[string]string{
+			"DB_PASSWORD": "hunter2",
+			"api_key":     "abc123",
+			"LOG_LEVEL":   "info",
+		},
+	}
+}
+
+// userWithSecretAccess returns a request context for a user who may read
+// secrets only when allowed is true
+func userWithSecretAccess(ctx context.Context, allowed bool) context.Context {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = allowed && attrs.Verb == "get" && attrs.Resource == "secrets" && attrs.Namespace == "shop"
+		return true, review, nil
+	})
+	ctx = auth.WithUser(ctx, &auth.User{ID: "dev", Email: "dev@example.com"})
+	return k8s.WithImpersonatedClients(ctx, &k8s.ImpersonatedClients{Clientset: client})
+}
+
+func configMapRequest(ctx context.Context, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/config-maps/shop/app-config?"+query, nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("namespace", "shop")
+	routeCtx.URLParams.Add("name", "app-config")
+	return req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, routeCtx))
+}
+
+func TestHandleGetConfigMapRedaction(t *testing.T) {
+	client := fake.NewSimpleClientset(redactionConfigMap())
+	cfg := &config.Config{}
+	cfg.Security.AuthMode = "header"
+	cfg.Features.ConfigMapRedactKeys = []string{`(?i)password`, `^api_key$`}
+	s := &Server{
+		logger:           zap.NewNop(),
+		config:           cfg,
+		kubeClient:       client,
+		resourceManager:  resources.NewResourceManager(zap.NewNop(), client, nil),
+		impersonationMgr: k8s.NewImpersonationManager(nil, zap.NewNop()),
+	}
+
+	type detail struct {
+		Data struct {
+			Summary struct {
+				RedactedKeys []string          `json:"redactedKeys"`
+				DataKeys     []string          `json:"dataKeys"`
+				Annotations  map[string]string `json:"annotations"`
+			} `json:"summary"`
+			Spec     map[string]string `json:"spec"`
+			Metadata struct {
+				Annotations map[string]string `json:"annotations"`
+			} `json:"metadata"`
+		} `json:"data"`
+	}
+	get := func(req *http.Request) (*httptest.ResponseRecorder, detail) {
+		rec := httptest.NewRecorder()
+		s.handleGetConfigMap(rec, req)
+		var body detail
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		}
+		return rec, body
+	}
+
+	// Matching keys are redacted by default, along with the last-applied copy
+	rec, body := get(configMapRequest(context.Background(), ""))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, []string{"DB_PASSWORD", "api_key"}, body.Data.Summary.RedactedKeys)
+	assert.Equal(t, map[string]string{"DB_PASSWORD": redactedValue, "api_key": redactedValue, "LOG_LEVEL": "info"}, body.Data.Spec)
+	assert.Equal(t, redactedValue, body.Data.Metadata.Annotations[lastAppliedAnnotation])
+	assert.Equal(t, "payments", body.Data.Metadata.Annotations["team"])
+	assert.NotContains(t, rec.Body.String(), "hunter2")
+
+	// Revealing needs permission to read secrets in the namespace
+	rec, _ = get(configMapRequest(userWithSecretAccess(context.Background(), false), "reveal=true"))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "hunter2")
+
+	rec, body = get(configMapRequest(userWithSecretAccess(context.Background(), true), "reveal=true"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Empty(t, body.Data.Summary.RedactedKeys)
+	assert.Equal(t, "hunter2", body.Data.Spec["DB_PASSWORD"])
+	assert.Equal(t, "abc123", body.Data.Spec["api_key"])
+	assert.Contains(t, body.Data.Metadata.Annotations[lastAppliedAnnotation], "hunter2")
+}
+
+func TestConfigMapToResponseRedactsAnnotations(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Features.ConfigMapRedactKeys = []string{`(?i)password`}
+	s := &Server{config: cfg}
+
+	response := s.configMapToResponse(*redactionConfigMap(), s.configMapRedactor())
+	assert.Equal(t, []string{"DB_PASSWORD"}, response["redactedKeys"])
+	annotations := response["annotations"].(map[string]string)
+	assert.Equal(t, redactedValue, annotations[lastAppliedAnnotation])
+	assert.Equal(t, "payments", annotations["team"])
+
+	// Revealing leaves the ConfigMap untouched
+	response = s.configMapToResponse(*redactionConfigMap(), nil)
+	assert.Empty(t, response["redactedKeys"])
+	assert.Contains(t, response["annotations"].(map[string]string)[lastAppliedAnnotation], "hunter2")
+}
+
+func redactingServer(client *fake.Clientset) *Server {
+	cfg := &config.Config{}
+	cfg.Security.AuthMode = "none"
+	cfg.Features.ConfigMapRedactKeys = []string{`(?i)password`, `^api_key$`}
+	return &Server{logger: zap.NewNop(), config: cfg, kubeClient: client}
+}
+
+func TestHandleKubeStyleConfigMapRedaction(t *testing.T) {
+	client := fake.NewSimpleClientset(redactionConfigMap())
+	manager := informers.NewManager(zap.NewNop(), client, nil)
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	s := redactingServer(client)
+	s.informerManager = manager
+	router := chi.NewRouter()
+	router.Get("/api/v1/resources/{group}/{version}/{resource}", s.handleKubeStyleResource)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/api/v1/resources/core/v1/configmaps?namespace=shop")
+	require.NoError(t, err)
+	var list struct {
+		Items []v1.ConfigMap `json:"items"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	resp.Body.Close()
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, map[string]string{"DB_PASSWORD": redactedValue, "api_key": redactedValue, "LOG_LEVEL": "info"}, list.Items[0].Data)
+	assert.Equal(t, redactedValue, list.Items[0].Annotations[lastAppliedAnnotation])
+
+	resp, err = http.Get(server.URL + "/api/v1/resources/core/v1/configmaps?watch=true&namespace=shop&timeoutSeconds=10")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var event struct {
+		Type   string       `json:"type"`
+		Object v1.ConfigMap `json:"object"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&event))
+	assert.Equal(t, "ADDED", event.Type)
+	assert.Equal(t, redactedValue, event.Object.Data["DB_PASSWORD"])
+	assert.Equal(t, "info", event.Object.Data["LOG_LEVEL"])
+	assert.Equal(t, redactedValue, event.Object.Annotations[lastAppliedAnnotation])
+
+	// The informer's own copy is left untouched
+	cached, exists, err := manager.GetConfigMapLister().GetByKey("shop/app-config")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, "hunter2", cached.(*v1.ConfigMap).Data["DB_PASSWORD"])
+}
+
+func TestHandleCompareAcrossNamespacesRedaction(t *testing.T) {
+	prod := redactionConfigMap()
+	prod.Namespace = "prod"
+	prod.Data["DB_PASSWORD"] = "correct-horse"
+	s := redactingServer(fake.NewSimpleClientset(redactionConfigMap(), prod))
+
+	rec := httptest.NewRecorder()
+	s.handleCompareAcrossNamespaces(rec, httptest.NewRequest(http.MethodGet, "/api/v1/compare?kind=configmap&name=app-config&namespaces=shop,prod", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "hunter2")
+	assert.NotContains(t, rec.Body.String(), "correct-horse")
+
+	var response struct {
+		Data analysis.Comparison `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	fields := make(map[string]analysis.ComparedField)
+	for _, field := range response.Data.Fields {
+		fields[field.Path] = field
+	}
+	assert.True(t, fields["data.DB_PASSWORD"].Differs, "redacted fields still report drift")
+	assert.Equal(t, map[string]interface{}{"shop": redactedValue, "prod": redactedValue}, fields["data.DB_PASSWORD"].Values)
+	assert.Equal(t, map[string]interface{}{"shop": "info", "prod": "info"}, fields["data.LOG_LEVEL"].Values)
+}
+
+func TestHandleDiffLastAppliedRedaction(t *testing.T) {
+	configMap := redactionConfigMap()
+	configMap.Annotations[lastAppliedAnnotation] = `{"apiVersion":"v1","kind":"ConfigMap","data":{"DB_PASSWORD":"old-secret","LOG_LEVEL":"debug"}}`
+	s := redactingServer(fake.NewSimpleClientset(configMap))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/configmaps/shop/app-config/diff-last-applied", nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("kind", "configmaps")
+	routeCtx.URLParams.Add("namespace", "shop")
+	routeCtx.URLParams.Add("name", "app-config")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+	rec := httptest.NewRecorder()
+	s.handleDiffLastApplied(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "hunter2")
+	assert.NotContains(t, rec.Body.String(), "old-secret")
+
+	var response struct {
+		Data analysis.LastAppliedDiff `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	changes := make(map[string]analysis.LastAppliedChange)
+	for _, change := range response.Data.Changes {
+		changes[change.Path] = change
+	}
+	assert.Equal(t, redactedValue, changes["data.DB_PASSWORD"].Applied)
+	assert.Equal(t, redactedValue, changes["data.DB_PASSWORD"].Live)
+	assert.Equal(t, "debug", changes["data.LOG_LEVEL"].Applied)
+	assert.Equal(t, "info", changes["data.LOG_LEVEL"].Live)
+}
+
+func TestHandleNamespaceBackupRedaction(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}}, redactionConfigMap())
+	s := redactingServer(client)
+	s.resourceManager = resources.NewResourceManager(zap.NewNop(), client, nil)
+
+	rec := httptest.NewRecorder()
+	s.handleNamespaceBackup(rec, namespaceBackupRequest(context.Background(), "shop", ""))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	configMaps := readArchiveFiles(t, rec.Body)["shop/configmaps.yaml"]
+	assert.NotContains(t, configMaps, "hunter2")
+	assert.NotContains(t, configMaps, "abc123")
+	assert.Contains(t, configMaps, "LOG_LEVEL: info")
+	assert.Contains(t, configMaps, lastAppliedAnnotation+": '"+redactedValue+"'")
+
+	// Without authentication anyone may reveal
+	rec = httptest.NewRecorder()
+	s.handleNamespaceBackup(rec, namespaceBackupRequest(context.Background(), "shop", "?reveal=true"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, readArchiveFiles(t, rec.Body)["shop/configmaps.yaml"], "DB_PASSWORD: hunter2")
+}
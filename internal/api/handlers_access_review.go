@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"go.uber.org/zap"
+)
+
+// handleAccessReview handles GET /api/v1/rbac/who-can
+// @Summary Review who can perform an action
+// @Description Answers questions like "who can delete pods in namespace X". The API server evaluates the access with a SubjectAccessReview for the given subject, or a SelfSubjectAccessReview for the caller without one. The RoleBindings and ClusterRoleBindings whose role grants the verb are listed with their roles and subjects; with a subject only the bindings naming it, or a group of a ServiceAccount subject, are listed. Without a namespace RoleBindings of every namespace are scanned.
+// @Tags RBAC
+// @Produce json
+// @Param verb query string true "Verb, e.g. get, list, delete"
+// @Param resource query string true "Resource, e.g. pods; a subresource is written after it, as in pods/exec"
+// @Param group query string false "API group (empty for the core group)"
+// @Param namespace query string false "Namespace (empty for every namespace)"
+// @Param subjectKind query string false "User, Group or ServiceAccount"
+// @Param subjectName query string false "Subject name"
+// @Param subjectNamespace query string false "Namespace of a ServiceAccount subject"
+// @Success 200 {object} resources.AccessReviewResult "Access review and matching bindings"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/rbac/who-can [get]
+func (s *Server) handleAccessReview(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	verb := query.Get("verb")
+	resource := query.Get("resource")
+	if verb == "" || resource == "" {
+		writeJSONError(w, http.StatusBadRequest, "verb and resource are required")
+		return
+	}
+
+	var subject *resources.AccessReviewSubject
+	if query.Get("subjectKind") != "" || query.Get("subjectName") != "" {
+		subject = &resources.AccessReviewSubject{
+			Kind:      query.Get("subjectKind"),
+			Name:      query.Get("subjectName"),
+			Namespace: query.Get("subjectNamespace"),
+		}
+		if err := subject.Validate(); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// Reviews and the binding scan run as the caller, so RBAC applies to them
+	result, err := s.callerResourceManager(r).AccessReview(r.Context(), verb, query.Get("group"), resource, query.Get("namespace"), subject)
+	if err != nil {
+		s.requestLogger(r).Error("Failed to review access",
+			zap.String("verb", verb),
+			zap.String("resource", resource),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   result,
+		"status": "success",
+	})
+}
+
+// handleEffectiveRBAC handles GET /api/v1/rbac/effective
+// @Summary Resolve the effective RBAC of a subject
+// @Description Lists the RoleBindings and ClusterRoleBindings naming a User, Group or ServiceAccount, resolves the Roles and ClusterRoles they reference and aggregates the granted rules by namespace. ClusterRoleBinding rules apply in every namespace. Groups a User belongs to are not known to the API server, so bindings of those groups are not included; ServiceAccounts include the bindings of their system:serviceaccounts groups.
+// @Tags RBAC
+// @Produce json
+// @Param subject query string true "Username, group name, or system:serviceaccount:<namespace>:<name>"
+// @Param kind query string false "User or Group, for subjects that are not ServiceAccounts (default: User)"
+// @Param namespace query string false "Only scan RoleBindings of this namespace (empty for every namespace)"
+// @Success 200 {object} resources.EffectiveRBAC "Bindings and aggregated rules"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/rbac/effective [get]
+func (s *Server) handleEffectiveRBAC(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("subject") == "" {
+		writeJSONError(w, http.StatusBadRequest, "subject is required")
+		return
+	}
+	subject, err := resources.ParseRBACSubject(query.Get("subject"), query.Get("kind"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := s.callerResourceManager(r).EffectiveRBAC(r.Context(), subject, query.Get("namespace"))
+	if err != nil {
+		s.requestLogger(r).Error("Failed to resolve effective RBAC",
+			zap.String("subject", query.Get("subject")),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   result,
+		"status": "success",
+	})
+}
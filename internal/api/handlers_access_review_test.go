@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHandleAccessReview(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "edit"}, Rules: []rbacv1.PolicyRule{
+			{Verbs: []string{"delete"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+		}},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "editors", Namespace: "shop"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "developers"}},
+		},
+	)
+	s := &Server{
+		logger:          zap.NewNop(),
+		config:          &config.Config{},
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleAccessReview(rec, httptest.NewRequest(http.MethodGet, "/api/v1/rbac/who-can?verb=delete&resource=pods&namespace=shop&subjectKind=Group&subjectName=developers", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response struct {
+		Data resources.AccessReviewResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, &resources.AccessReviewSubject{Kind: "Group", Name: "developers"}, response.Data.Subject)
+	require.Len(t, response.Data.Bindings, 1)
+	assert.Equal(t, "editors", response.Data.Bindings[0].Name)
+	assert.Equal(t, "edit", response.Data.Bindings[0].RoleName)
+
+	for _, target := range []string{
+		"/api/v1/rbac/who-can?resource=pods",
+		"/api/v1/rbac/who-can?verb=delete&resource=pods&subjectKind=ServiceAccount&subjectName=cleanup",
+		"/api/v1/rbac/who-can?verb=delete&resource=pods&subjectName=alice",
+	} {
+		rec := httptest.NewRecorder()
+		s.handleAccessReview(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
+
+func TestHandleEffectiveRBAC(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "edit"}, Rules: []rbacv1.PolicyRule{
+			{Verbs: []string{"delete"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+		}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}, Rules: []rbacv1.PolicyRule{
+			{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+		}},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewers"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "editors", Namespace: "shop"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		},
+	)
+	s := &Server{
+		logger:          zap.NewNop(),
+		config:          &config.Config{},
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleEffectiveRBAC(rec, httptest.NewRequest(http.MethodGet, "/api/v1/rbac/effective?subject=alice", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response struct {
+		Data struct {
+			Bindings     []resources.EffectiveRBACBinding `json:"bindings"`
+			ClusterRules []struct {
+				Verbs    []string `json:"verbs"`
+				Bindings []string `json:"bindings"`
+			} `json:"clusterRules"`
+			NamespaceRules map[string][]struct {
+				Verbs []string `json:"verbs"`
+			} `json:"namespaceRules"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Len(t, response.Data.Bindings, 2)
+	require.Len(t, response.Data.ClusterRules, 1)
+	assert.Equal(t, []string{"get"}, response.Data.ClusterRules[0].Verbs)
+	assert.Equal(t, []string{"ClusterRoleBinding//viewers"}, response.Data.ClusterRules[0].Bindings)
+	require.Len(t, response.Data.NamespaceRules["shop"], 1)
+	assert.Equal(t, []string{"delete"}, response.Data.NamespaceRules["shop"][0].Verbs)
+
+	for _, target := range []string{
+		"/api/v1/rbac/effective",
+		"/api/v1/rbac/effective?subject=system:serviceaccount:shop",
+		"/api/v1/rbac/effective?subject=alice&kind=ServiceAccount",
+	} {
+		rec := httptest.NewRecorder()
+		s.handleEffectiveRBAC(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
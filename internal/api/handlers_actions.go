@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,9 +13,11 @@ import (
 	"github.com/aaronlmathis/kaptn/internal/k8s"
 	"github.com/aaronlmathis/kaptn/internal/k8s/actions"
 	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/aaronlmathis/kaptn/internal/logging"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // Action handlers - Node operations, resource management, etc.
@@ -30,14 +33,14 @@ func (s *Server) handleCordonNode(w http.ResponseWriter, r *http.Request) {
 		userStr = user.Email // or user.Subject, depending on what you want to log
 	}
 
-	s.logger.Info("Received cordon request",
+	s.requestLogger(r).Info("Received cordon request",
 		zap.String("requestId", requestID),
 		zap.String("user", userStr),
 		zap.String("node", nodeName))
 
 	err := s.actionsService.CordonNode(r.Context(), requestID, userStr, nodeName)
 	if err != nil {
-		s.logger.Error("Failed to cordon node",
+		s.requestLogger(r).Error("Failed to cordon node",
 			zap.String("node", nodeName),
 			zap.Error(err))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -58,14 +61,14 @@ func (s *Server) handleUncordonNode(w http.ResponseWriter, r *http.Request) {
 		userStr = user.Email // or user.Subject, depending on what you want to log
 	}
 
-	s.logger.Info("Received uncordon request",
+	s.requestLogger(r).Info("Received uncordon request",
 		zap.String("requestId", requestID),
 		zap.String("user", userStr),
 		zap.String("node", nodeName))
 
 	err := s.actionsService.UncordonNode(r.Context(), requestID, userStr, nodeName)
 	if err != nil {
-		s.logger.Error("Failed to uncordon node",
+		s.requestLogger(r).Error("Failed to uncordon node",
 			zap.String("node", nodeName),
 			zap.Error(err))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -75,6 +78,17 @@ func (s *Server) handleUncordonNode(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleDrainNode handles POST /api/v1/nodes/{nodeName}/drain
+// @Summary Drain a node
+// @Description Cordons the node, evicts its pods through the Eviction API and waits for them to terminate, as a background job. The job details carry the per-pod drain state under "drain" so clients can show which pods are still evicting. The drain is refused, after cordoning, when DaemonSet, emptyDir or unmanaged pods would block it and the matching option is not set.
+// @Tags Nodes
+// @Accept json
+// @Produce json
+// @Param nodeName path string true "Node name"
+// @Param options body resources.DrainNodeOptions false "Drain options; deleteLocalData is accepted as an alias of deleteEmptyDir"
+// @Success 202 {object} map[string]interface{} "Drain job started"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Router /api/v1/nodes/{nodeName}/drain [post]
 func (s *Server) handleDrainNode(w http.ResponseWriter, r *http.Request) {
 	nodeName := chi.URLParam(r, "nodeName")
 	requestID := middleware.GetReqID(r.Context())
@@ -86,33 +100,110 @@ func (s *Server) handleDrainNode(w http.ResponseWriter, r *http.Request) {
 		userStr = user.Email // or user.Subject, depending on what you want to log
 	}
 
-	s.logger.Info("Received drain request",
+	s.requestLogger(r).Info("Received drain request",
 		zap.String("requestId", requestID),
 		zap.String("user", userStr),
 		zap.String("node", nodeName))
 
 	// Parse drain options from request body
-	var opts actions.DrainOptions
+	var req struct {
+		resources.DrainNodeOptions
+		DeleteLocalData bool `json:"deleteLocalData,omitempty"`
+	}
 	if r.Body != nil {
-		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
-			s.logger.Error("Failed to parse drain options", zap.Error(err))
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			s.requestLogger(r).Error("Failed to parse drain options", zap.Error(err))
 			http.Error(w, "Invalid request body", http.StatusBadRequest)
 			return
 		}
 	}
+	opts := req.DrainNodeOptions
+	opts.DeleteEmptyDir = opts.DeleteEmptyDir || req.DeleteLocalData
+
+	// The drain outlives the request
+	ctx := context.WithoutCancel(s.mutationContext(r))
+	jobID := s.actionsService.RunJob(fmt.Sprintf("drain-node-%s", nodeName), "drain", func(job *actions.Job) error {
+		result, err := s.resourceManager.DrainNode(ctx, nodeName, opts, func(progress resources.DrainNodeResult) {
+			evicted := 0
+			for _, pod := range progress.Pods {
+				if pod.Status == resources.DrainPodEvicted {
+					evicted++
+				}
+			}
+			job.SetDetail("drain", progress, fmt.Sprintf("%d pods evicted, %d still evicting", evicted, progress.Evicting))
+		})
+		if err != nil {
+			s.requestLogger(r).Error("Failed to drain node",
+				zap.String("requestId", requestID),
+				zap.String("user", userStr),
+				zap.String("node", nodeName),
+				zap.Error(err))
+			return err
+		}
+		if !result.Complete {
+			return fmt.Errorf("node %s was cordoned but not every pod could be evicted", nodeName)
+		}
+		s.requestLogger(r).Info("Drained node",
+			zap.String("requestId", requestID),
+			zap.String("user", userStr),
+			zap.String("node", nodeName),
+			zap.Int("pods", len(result.Pods)))
+		return nil
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{
+		"jobId":   jobID,
+		"message": fmt.Sprintf("Draining node %s", nodeName),
+		"status":  "accepted",
+	})
+}
 
-	jobID, err := s.actionsService.DrainNode(r.Context(), requestID, userStr, nodeName, opts)
+// handleGetDrainSimulation handles GET /api/v1/nodes/{name}/drain-simulation
+// @Summary Simulate a node drain
+// @Description Reports where each evictable pod on the node would reschedule, using a first-fit placement over the other nodes' latest schedulable, allocatable and requested series. Nothing is evicted.
+// @Tags Nodes
+// @Produce json
+// @Param name path string true "Node name"
+// @Param force query bool false "Include DaemonSet pods, as a forced drain would"
+// @Success 200 {object} actions.DrainSimulation "Drain simulation plan"
+// @Failure 404 {object} map[string]interface{} "Node not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 503 {object} map[string]interface{} "Time series store not available"
+// @Router /api/v1/nodes/{name}/drain-simulation [get]
+func (s *Server) handleGetDrainSimulation(w http.ResponseWriter, r *http.Request) {
+	nodeName := chi.URLParam(r, "name")
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+
+	simulation, err := s.actionsService.SimulateDrain(r.Context(), nodeName, actions.DrainSimulationOptions{Force: force})
+	if apierrors.IsNotFound(err) {
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, actions.ErrNodeCapacityUnavailable) {
+		writeJSONError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
 	if err != nil {
-		s.logger.Error("Failed to start drain operation",
+		s.requestLogger(r).Error("Failed to simulate drain",
 			zap.String("node", nodeName),
 			zap.Error(err))
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":  err.Error(),
+			"status": "error",
+		})
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusAccepted)
-	json.NewEncoder(w).Encode(map[string]string{"jobId": jobID})
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   simulation,
+		"status": "success",
+	})
 }
 
 func (s *Server) handleListActionJobs(w http.ResponseWriter, r *http.Request) {
@@ -157,7 +248,7 @@ func (s *Server) handleApplyYAML(w http.ResponseWriter, r *http.Request) {
 	dryRun := r.URL.Query().Get("dryRun") == "true"
 	force := r.URL.Query().Get("force") == "true"
 
-	s.logger.Info("Received apply request",
+	s.requestLogger(r).Info("Received apply request",
 		zap.String("requestId", requestID),
 		zap.String("user", userStr),
 		zap.String("namespace", namespace),
@@ -167,7 +258,7 @@ func (s *Server) handleApplyYAML(w http.ResponseWriter, r *http.Request) {
 	// Get impersonated clients for this user
 	clients, err := s.GetImpersonatedClients(r)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated clients", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get impersonated clients", zap.Error(err))
 		http.Error(w, "Failed to get user permissions", http.StatusInternalServerError)
 		return
 	}
@@ -175,14 +266,14 @@ func (s *Server) handleApplyYAML(w http.ResponseWriter, r *http.Request) {
 	// Read YAML content from request body
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		s.logger.Error("Failed to read request body", zap.Error(err))
+		s.requestLogger(r).Error("Failed to read request body", zap.Error(err))
 		http.Error(w, "Failed to read request body", http.StatusBadRequest)
 		return
 	}
 
 	yamlContent := string(body)
 	if yamlContent == "" {
-		s.logger.Error("Empty YAML content")
+		s.requestLogger(r).Error("Empty YAML content")
 		http.Error(w, "Empty YAML content", http.StatusBadRequest)
 		return
 	}
@@ -190,7 +281,7 @@ func (s *Server) handleApplyYAML(w http.ResponseWriter, r *http.Request) {
 	// Validate content type
 	contentType := r.Header.Get("Content-Type")
 	if contentType != "application/yaml" && contentType != "text/yaml" {
-		s.logger.Warn("Unexpected content type", zap.String("contentType", contentType))
+		s.requestLogger(r).Warn("Unexpected content type", zap.String("contentType", contentType))
 	}
 
 	// Create apply options
@@ -211,7 +302,7 @@ func (s *Server) handleApplyYAML(w http.ResponseWriter, r *http.Request) {
 	// Apply the YAML using impersonated clients
 	result, err := impersonatedApplyService.ApplyYAML(r.Context(), requestID, userStr, yamlContent, opts)
 	if err != nil {
-		s.logger.Error("Failed to apply YAML",
+		s.requestLogger(r).Error("Failed to apply YAML",
 			zap.String("requestId", requestID),
 			zap.Error(err))
 
@@ -260,9 +351,9 @@ func (s *Server) handleScaleResource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := s.resourceManager.ScaleResource(r.Context(), req)
+	err := s.resourceManager.ScaleResource(s.mutationContext(r), req)
 	if err != nil {
-		s.logger.Error("Failed to scale resource",
+		s.requestLogger(r).Error("Failed to scale resource",
 			zap.String("namespace", req.Namespace),
 			zap.String("name", req.Name),
 			zap.String("kind", req.Kind),
@@ -279,6 +370,51 @@ func (s *Server) handleScaleResource(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"success": "true"})
 }
 
+// handleRolloutRestart handles POST /api/v1/{kind}/{namespace}/{name}/restart
+// @Summary Restart a workload rollout
+// @Description Triggers a rolling restart of a Deployment, StatefulSet or DaemonSet, equivalent to kubectl rollout restart, by setting the kubectl.kubernetes.io/restartedAt pod template annotation. The response carries the new generation so clients can poll rollout status until it is observed.
+// @Tags Workloads
+// @Produce json
+// @Param kind path string true "Kind: deployments, statefulsets or daemonsets"
+// @Param namespace path string true "Namespace"
+// @Param name path string true "Workload name"
+// @Success 200 {object} resources.RolloutRestartResult "Restarted workload"
+// @Failure 400 {object} map[string]interface{} "Unsupported kind"
+// @Failure 404 {object} map[string]interface{} "Workload not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/{kind}/{namespace}/{name}/restart [post]
+func (s *Server) handleRolloutRestart(w http.ResponseWriter, r *http.Request) {
+	kind, err := resources.NormalizeRolloutRestartKind(chi.URLParam(r, "kind"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	result, err := s.resourceManager.RolloutRestart(s.mutationContext(r), namespace, name, kind)
+	if apierrors.IsNotFound(err) {
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		s.requestLogger(r).Error("Failed to restart rollout",
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.String("kind", kind),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   result,
+		"status": "success",
+	})
+}
+
 func (s *Server) handleDeleteResource(w http.ResponseWriter, r *http.Request) {
 	var req resources.DeleteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -292,7 +428,7 @@ func (s *Server) handleDeleteResource(w http.ResponseWriter, r *http.Request) {
 	secCtx, err := s.getSecurityContext(r)
 	if err != nil {
 		if secErr, ok := err.(*SecurityError); ok {
-			s.writeSecurityError(w, secErr, nil)
+			s.writeSecurityError(w, r, secErr, nil)
 		} else {
 			http.Error(w, "Security context error", http.StatusInternalServerError)
 		}
@@ -303,7 +439,7 @@ func (s *Server) handleDeleteResource(w http.ResponseWriter, r *http.Request) {
 	resourceName := strings.ToLower(req.Kind) + "s" // e.g., "Pod" -> "pods"
 	if err := s.checkResourcePermission(r.Context(), secCtx, "delete", resourceName, req.Namespace, req.Name); err != nil {
 		if secErr, ok := err.(*SecurityError); ok {
-			s.writeSecurityError(w, secErr, secCtx.User)
+			s.writeSecurityError(w, r, secErr, secCtx.User)
 		} else {
 			http.Error(w, "Permission check failed", http.StatusInternalServerError)
 		}
@@ -312,7 +448,7 @@ func (s *Server) handleDeleteResource(w http.ResponseWriter, r *http.Request) {
 
 	err = s.resourceManager.DeleteResource(r.Context(), req)
 	if err != nil {
-		s.logger.Error("Failed to delete resource",
+		s.requestLogger(r).Error("Failed to delete resource",
 			zap.String("namespace", req.Namespace),
 			zap.String("name", req.Name),
 			zap.String("kind", req.Kind),
@@ -325,7 +461,7 @@ func (s *Server) handleDeleteResource(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log successful deletion for audit
-	s.logger.Info("Resource deleted successfully",
+	s.requestLogger(r).Info("Resource deleted successfully",
 		zap.String("user", secCtx.User.Email),
 		zap.String("user_sub", secCtx.User.Sub),
 		zap.Strings("user_groups", secCtx.User.Groups),
@@ -349,7 +485,7 @@ func (s *Server) handleCreateNamespace(w http.ResponseWriter, r *http.Request) {
 
 	err := s.resourceManager.CreateNamespace(r.Context(), req)
 	if err != nil {
-		s.logger.Error("Failed to create namespace",
+		s.requestLogger(r).Error("Failed to create namespace",
 			zap.String("name", req.Name),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -374,7 +510,7 @@ func (s *Server) handleDeleteNamespace(w http.ResponseWriter, r *http.Request) {
 
 	err := s.resourceManager.DeleteNamespace(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to delete namespace",
+		s.requestLogger(r).Error("Failed to delete namespace",
 			zap.String("namespace", namespace),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -502,14 +638,14 @@ func (s *Server) handleApplyConfig(w http.ResponseWriter, r *http.Request) {
 		userStr = user.Email
 	}
 
-	s.logger.Info("Received enhanced apply request",
+	s.requestLogger(r).Info("Received enhanced apply request",
 		zap.String("requestId", requestID),
 		zap.String("user", userStr))
 
 	// Parse request body
 	var req ApplyConfigRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		s.logger.Error("Failed to parse apply request", zap.Error(err))
+		s.requestLogger(r).Error("Failed to parse apply request", zap.Error(err))
 		s.respondWithError(w, http.StatusBadRequest, "Invalid request body", err)
 		return
 	}
@@ -527,7 +663,7 @@ func (s *Server) handleApplyConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.logger.Info("Processing apply request",
+	s.requestLogger(r).Info("Processing apply request",
 		zap.String("requestId", requestID),
 		zap.Bool("dryRun", req.DryRun),
 		zap.Bool("force", req.Force),
@@ -538,7 +674,7 @@ func (s *Server) handleApplyConfig(w http.ResponseWriter, r *http.Request) {
 	// Get impersonated clients for this user
 	clients, err := s.GetImpersonatedClients(r)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated clients", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get impersonated clients", zap.Error(err))
 		response := &ApplyConfigResponse{
 			Success: false,
 			Errors: []ValidationError{{
@@ -630,6 +766,7 @@ func (s *Server) validateApplyRequest(req *ApplyConfigRequest) []ValidationError
 
 // processApplyConfig processes the apply operation
 func (s *Server) processApplyConfigWithClients(ctx context.Context, requestID, user string, req *ApplyConfigRequest, clients *k8s.ImpersonatedClients) *ApplyConfigResponse {
+	logger := logging.FromContext(ctx, s.logger)
 	response := &ApplyConfigResponse{
 		Success:   true,
 		Resources: []EnhancedResourceResult{},
@@ -670,7 +807,7 @@ func (s *Server) processApplyConfigWithClients(ctx context.Context, requestID, u
 
 	// Process each YAML source
 	for _, yamlSource := range yamlSources {
-		s.logger.Info("Processing YAML source",
+		logger.Info("Processing YAML source",
 			zap.String("source", yamlSource.source),
 			zap.String("requestId", requestID))
 
@@ -692,7 +829,7 @@ func (s *Server) processApplyConfigWithClients(ctx context.Context, requestID, u
 		// Apply using impersonated service
 		result, err := impersonatedApplyService.ApplyYAML(ctx, requestID, user, yamlSource.content, opts)
 		if err != nil {
-			s.logger.Error("Failed to apply YAML source",
+			logger.Error("Failed to apply YAML source",
 				zap.String("source", yamlSource.source),
 				zap.Error(err))
 
@@ -18,9 +18,9 @@ func (s *Server) handleBindingsReload(w http.ResponseWriter, r *http.Request) {
 	secCtx, err := s.getSecurityContext(r)
 	if err != nil {
 		if secErr, ok := err.(*SecurityError); ok {
-			s.writeSecurityError(w, secErr, nil)
+			s.writeSecurityError(w, r, secErr, nil)
 		} else {
-			s.writeSecurityError(w, &SecurityError{
+			s.writeSecurityError(w, r, &SecurityError{
 				Code:    "INTERNAL_ERROR",
 				Message: "Internal server error",
 				Status:  http.StatusInternalServerError,
@@ -30,7 +30,7 @@ func (s *Server) handleBindingsReload(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log the reload attempt
-	s.logger.Info("User bindings reload requested",
+	s.requestLogger(r).Info("User bindings reload requested",
 		zap.String("user_sub", secCtx.User.Sub),
 		zap.String("user_email", secCtx.User.Email),
 		zap.String("request_path", r.URL.Path),
@@ -45,7 +45,7 @@ func (s *Server) handleBindingsReload(w http.ResponseWriter, r *http.Request) {
 		"note":      "This endpoint is ready for integration with actual bindings store",
 	}
 
-	s.logger.Info("User bindings reload completed",
+	s.requestLogger(r).Info("User bindings reload completed",
 		zap.String("user_email", secCtx.User.Email),
 		zap.String("authz_mode", s.config.Authz.Mode))
 
@@ -59,9 +59,9 @@ func (s *Server) handleGenericSAR(w http.ResponseWriter, r *http.Request) {
 	secCtx, err := s.getSecurityContext(r)
 	if err != nil {
 		if secErr, ok := err.(*SecurityError); ok {
-			s.writeSecurityError(w, secErr, nil)
+			s.writeSecurityError(w, r, secErr, nil)
 		} else {
-			s.writeSecurityError(w, &SecurityError{
+			s.writeSecurityError(w, r, &SecurityError{
 				Code:    "INTERNAL_ERROR",
 				Message: "Internal server error",
 				Status:  http.StatusInternalServerError,
@@ -86,7 +86,7 @@ func (s *Server) handleGenericSAR(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log the SAR check request
-	s.logger.Info("Generic SAR check requested",
+	s.requestLogger(r).Info("Generic SAR check requested",
 		zap.String("user_sub", secCtx.User.Sub),
 		zap.String("user_email", secCtx.User.Email),
 		zap.String("verb", verb),
@@ -108,7 +108,7 @@ func (s *Server) handleGenericSAR(w http.ResponseWriter, r *http.Request) {
 	)
 
 	if err != nil {
-		s.logger.Error("Generic SAR check failed",
+		s.requestLogger(r).Error("Generic SAR check failed",
 			zap.Error(err),
 			zap.String("user_email", secCtx.User.Email),
 			zap.String("verb", verb),
@@ -126,7 +126,7 @@ func (s *Server) handleGenericSAR(w http.ResponseWriter, r *http.Request) {
 
 	// Log the result for audit trail
 	if allowed {
-		s.logger.Info("Generic SAR check - ALLOWED",
+		s.requestLogger(r).Info("Generic SAR check - ALLOWED",
 			zap.String("user_sub", secCtx.User.Sub),
 			zap.String("user_email", secCtx.User.Email),
 			zap.Strings("user_groups", secCtx.User.Groups),
@@ -137,7 +137,7 @@ func (s *Server) handleGenericSAR(w http.ResponseWriter, r *http.Request) {
 			zap.String("name", name),
 			zap.Bool("allowed", allowed))
 	} else {
-		s.logger.Warn("Generic SAR check - DENIED",
+		s.requestLogger(r).Warn("Generic SAR check - DENIED",
 			zap.String("user_sub", secCtx.User.Sub),
 			zap.String("user_email", secCtx.User.Email),
 			zap.Strings("user_groups", secCtx.User.Groups),
@@ -193,7 +193,7 @@ func (s *Server) handlePermissionsCheck(w http.ResponseWriter, r *http.Request)
 	// Get impersonated clients
 	clients, err := s.GetImpersonatedClients(r)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated clients", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get impersonated clients", zap.Error(err))
 		http.Error(w, "Impersonated clients not available", http.StatusInternalServerError)
 		return
 	}
@@ -216,7 +216,7 @@ func (s *Server) handlePermissionsCheck(w http.ResponseWriter, r *http.Request)
 	ssarHelper := s.impersonationMgr.SSARHelper()
 	results, err := ssarHelper.CheckMultiplePermissions(r.Context(), clients.Client(), checks)
 	if err != nil {
-		s.logger.Error("Failed to check permissions",
+		s.requestLogger(r).Error("Failed to check permissions",
 			zap.Error(err),
 			zap.String("userEmail", user.Email))
 		http.Error(w, "Failed to check permissions", http.StatusInternalServerError)
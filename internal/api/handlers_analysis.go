@@ -0,0 +1,281 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/analysis"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// handleGetOrphans handles GET /api/v1/analysis/orphans
+// @Summary Find orphaned resources
+// @Description Lists ConfigMaps, Secrets, Services and PersistentVolumeClaims that have no owner and are not referenced by any pod, workload template, service account or ingress. Objects are only flagged; nothing is deleted.
+// @Tags Analysis
+// @Produce json
+// @Param kinds query string false "Comma-separated kinds to scan: configmaps, secrets, services, persistentvolumeclaims (default all)"
+// @Param namespace query string false "Limit the scan to a namespace"
+// @Success 200 {object} analysis.OrphanReport "Orphan report"
+// @Failure 400 {object} map[string]interface{} "Unsupported kind"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/analysis/orphans [get]
+func (s *Server) handleGetOrphans(w http.ResponseWriter, r *http.Request) {
+	kinds, err := analysis.ParseOrphanKinds(r.URL.Query().Get("kinds"))
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":  err.Error(),
+			"status": "error",
+		})
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	report, err := s.orphanFinder.FindOrphans(r.Context(), namespace, kinds)
+	if err != nil {
+		s.requestLogger(r).Error("Failed to find orphaned resources",
+			zap.String("namespace", namespace),
+			zap.Strings("kinds", kinds),
+			zap.Error(err))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":  err.Error(),
+			"status": "error",
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   report,
+		"status": "success",
+	})
+}
+
+// handleGetAgeDistribution handles GET /api/v1/analysis/age-distribution
+// @Summary Object age distribution
+// @Description Histogram of object ages (<1h, 1-24h, 1-7d, >7d) computed from creation timestamps in the informer cache, with the oldest object. Useful to spot pods pending or running far longer than expected.
+// @Tags Analysis
+// @Produce json
+// @Param kind query string false "Kind to analyze, e.g. pod, deployment, configmap (default pods)"
+// @Param namespace query string false "Limit the histogram to a namespace"
+// @Success 200 {object} analysis.AgeDistribution "Age distribution"
+// @Failure 400 {object} map[string]interface{} "Unsupported kind"
+// @Failure 503 {object} map[string]interface{} "Informer cache not ready"
+// @Router /api/v1/analysis/age-distribution [get]
+func (s *Server) handleGetAgeDistribution(w http.ResponseWriter, r *http.Request) {
+	kind, err := analysis.ParseAgeKind(r.URL.Query().Get("kind"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if s.informerManager == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Informer cache not available")
+		return
+	}
+	objects, ok := s.informerManager.ListResource(kind)
+	if !ok {
+		writeJSONError(w, http.StatusServiceUnavailable, "Informer cache for "+kind+" has not synced yet")
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   analysis.ComputeAgeDistribution(kind, namespace, objects, time.Now()),
+		"status": "success",
+	})
+}
+
+// handleCompareAcrossNamespaces handles GET /api/v1/compare
+// @Summary Compare an object across namespaces
+// @Description Fetches the object of the same name from each namespace and returns a field-level matrix of labels, annotations and configuration (ConfigMap data, Deployment spec), flagging the fields whose values differ. Namespaces without the object are listed as missing. Values of ConfigMap keys matching the configured redaction patterns are replaced with "[REDACTED]" unless reveal=true is passed by a caller allowed to read secrets in every namespace.
+// @Tags Analysis
+// @Produce json
+// @Param kind query string true "Kind to compare: configmap or deployment"
+// @Param name query string true "Object name"
+// @Param namespaces query string true "Comma-separated namespaces to compare (2 to 20)"
+// @Param reveal query bool false "Include values of redacted ConfigMap keys"
+// @Success 200 {object} analysis.Comparison "Comparison matrix"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Not allowed to reveal redacted keys"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/compare [get]
+func (s *Server) handleCompareAcrossNamespaces(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	kind, err := analysis.ParseCompareKind(query.Get("kind"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	name := strings.TrimSpace(query.Get("name"))
+	if name == "" {
+		writeJSONError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	namespaces, err := analysis.ParseCompareNamespaces(query.Get("namespaces"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Revealing redacted ConfigMap values needs access to secrets in every
+	// compared namespace
+	var redactor *configMapRedactor
+	if kind == "configmaps" {
+		for _, namespace := range namespaces {
+			var ok bool
+			if redactor, ok = s.configMapRedactorFor(w, r, namespace); !ok {
+				return
+			}
+		}
+	}
+
+	comparison, err := analysis.CompareAcrossNamespaces(r.Context(), s.kubeClient, kind, name, namespaces, time.Now())
+	if err != nil {
+		s.requestLogger(r).Error("Failed to compare object across namespaces",
+			zap.String("kind", kind),
+			zap.String("name", name),
+			zap.Strings("namespaces", namespaces),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	redactor.redactComparison(comparison)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   comparison,
+		"status": "success",
+	})
+}
+
+// handleDiffLastApplied handles GET /api/v1/{kind}/{namespace}/{name}/diff-last-applied
+// @Summary Diff an object against its last-applied configuration
+// @Description Compares the live object with its kubectl.kubernetes.io/last-applied-configuration annotation and lists the declared fields that drifted, e.g. after kubectl edit or scale. Only fields the applied manifest declares are compared, so server-side defaults are not reported. Objects without the annotation return hasLastApplied false with a message. Values of ConfigMap keys matching the configured redaction patterns are replaced with "[REDACTED]" unless reveal=true is passed by a caller allowed to read secrets in the namespace.
+// @Tags Analysis
+// @Produce json
+// @Param kind path string true "Kind: deployments, statefulsets, daemonsets, services, configmaps, ingresses, jobs or cronjobs"
+// @Param namespace path string true "Namespace"
+// @Param name path string true "Object name"
+// @Param reveal query bool false "Include values of redacted ConfigMap keys"
+// @Success 200 {object} analysis.LastAppliedDiff "Drift from the last-applied configuration"
+// @Failure 400 {object} map[string]interface{} "Unsupported kind"
+// @Failure 403 {object} map[string]interface{} "Not allowed to reveal redacted keys"
+// @Failure 404 {object} map[string]interface{} "Object not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/{kind}/{namespace}/{name}/diff-last-applied [get]
+func (s *Server) handleDiffLastApplied(w http.ResponseWriter, r *http.Request) {
+	kind, err := analysis.ParseLastAppliedKind(chi.URLParam(r, "kind"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	var redactor *configMapRedactor
+	if kind == "configmaps" {
+		var ok bool
+		if redactor, ok = s.configMapRedactorFor(w, r, namespace); !ok {
+			return
+		}
+	}
+
+	diff, err := analysis.DiffLastApplied(r.Context(), s.kubeClient, kind, namespace, name, time.Now())
+	if apierrors.IsNotFound(err) {
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		s.requestLogger(r).Error("Failed to diff object against last-applied configuration",
+			zap.String("kind", kind),
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	redactor.redactLastAppliedDiff(diff)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   diff,
+		"status": "success",
+	})
+}
+
+// handleGetRelationships handles GET /api/v1/{kind}/{namespace}/{name}/relationships
+// @Summary Relationship graph of an object
+// @Description Returns the owners (up the ownerReference chain), children (objects owned by it and their descendants) and references (ConfigMaps, Secrets, PVCs and Services linked in the spec) of an object as a graph of nodes and edges. Edges point from the owner, referencing object or selecting Service. Built from the informer caches; nodes the cache does not hold are marked missing.
+// @Tags Analysis
+// @Produce json
+// @Param kind path string true "Kind: pods, deployments, statefulsets, daemonsets, replicasets, jobs, cronjobs, services, configmaps, secrets, ingresses or persistentvolumeclaims"
+// @Param namespace path string true "Namespace"
+// @Param name path string true "Object name"
+// @Param depth query int false "Owner and child levels to follow (default: 3, max: 5)"
+// @Success 200 {object} analysis.RelationshipGraph "Relationship graph"
+// @Failure 400 {object} map[string]interface{} "Unsupported kind or invalid depth"
+// @Failure 404 {object} map[string]interface{} "Object not found"
+// @Failure 503 {object} map[string]interface{} "Informer cache not ready"
+// @Router /api/v1/{kind}/{namespace}/{name}/relationships [get]
+func (s *Server) handleGetRelationships(w http.ResponseWriter, r *http.Request) {
+	kind, err := analysis.ParseRelationshipKind(chi.URLParam(r, "kind"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	depth := analysis.DefaultRelationshipDepth
+	if depthParam := r.URL.Query().Get("depth"); depthParam != "" {
+		depth, err = strconv.Atoi(depthParam)
+		if err != nil || depth < 1 || depth > analysis.MaxRelationshipDepth {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("depth must be between 1 and %d", analysis.MaxRelationshipDepth))
+			return
+		}
+	}
+	if s.informerManager == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Informer cache not available")
+		return
+	}
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	graph, err := analysis.BuildRelationshipGraph(s.informerManager, kind, namespace, name, depth)
+	switch {
+	case apierrors.IsNotFound(err):
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, analysis.ErrCacheNotSynced):
+		writeJSONError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	case err != nil:
+		s.requestLogger(r).Error("Failed to build relationship graph",
+			zap.String("kind", kind),
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   graph,
+		"status": "success",
+	})
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/analysis"
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHandleGetRelationships(t *testing.T) {
+	controller := true
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", UID: "deploy-uid"}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-7d9f", Namespace: "shop", UID: "rs-uid",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "deploy-uid", Controller: &controller}},
+		}},
+	)
+	manager := informers.NewManager(zap.NewNop(), client, nil)
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	s := &Server{logger: zap.NewNop(), informerManager: manager}
+	router := chi.NewRouter()
+	router.Get("/api/v1/{kind}/{namespace}/{name}/relationships", s.handleGetRelationships)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/deploy/shop/web/relationships", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response struct {
+		Data analysis.RelationshipGraph `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "Deployment/shop/web", response.Data.Root)
+	assert.Equal(t, []analysis.RelationshipEdge{
+		{From: "Deployment/shop/web", To: "ReplicaSet/shop/web-7d9f", Type: analysis.RelationshipOwns},
+	}, response.Data.Edges)
+
+	for target, status := range map[string]int{
+		"/api/v1/deployments/shop/missing/relationships":       http.StatusNotFound,
+		"/api/v1/nodes/shop/web/relationships":                 http.StatusBadRequest,
+		"/api/v1/deployments/shop/web/relationships?depth=9":   http.StatusBadRequest,
+		"/api/v1/deployments/shop/web/relationships?depth=two": http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, status, rec.Code, target)
+	}
+}
@@ -34,7 +34,7 @@ func (s *Server) handleGetVisitors(w http.ResponseWriter, r *http.Request) {
 	// Get visitors data from analytics service
 	visitors, err := s.analyticsService.GetVisitors(r.Context(), window, step)
 	if err != nil {
-		s.logger.Error("Failed to get visitors analytics",
+		s.requestLogger(r).Error("Failed to get visitors analytics",
 			zap.String("window", window),
 			zap.String("step", step),
 			zap.Error(err))
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newAPIResourcesTestServer() *Server {
+	client := fake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Namespaced: true},
+			{Name: "nodes", Kind: "Node"},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true},
+		}},
+	}
+	return &Server{logger: zap.NewNop(), kubeClient: client, resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil)}
+}
+
+func listAPIResourceNames(t *testing.T, rec *httptest.ResponseRecorder) []string {
+	t.Helper()
+
+	var resp struct {
+		Data struct {
+			Items []map[string]interface{} `json:"items"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	var names []string
+	for _, item := range resp.Data.Items {
+		names = append(names, item["name"].(string))
+	}
+	return names
+}
+
+func TestListAPIResourcesETagRevalidation(t *testing.T) {
+	s := newAPIResourcesTestServer()
+
+	rec := httptest.NewRecorder()
+	s.handleListAPIResources(rec, httptest.NewRequest(http.MethodGet, "/api/v1/api-resources", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Contains(t, rec.Header().Get("Cache-Control"), "must-revalidate")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/api-resources", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	s.handleListAPIResources(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/api-resources", nil)
+	req.Header.Set("If-None-Match", `"stale"`)
+	rec = httptest.NewRecorder()
+	s.handleListAPIResources(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestListAPIResourcesFilters(t *testing.T) {
+	s := newAPIResourcesTestServer()
+
+	rec := httptest.NewRecorder()
+	s.handleListAPIResources(rec, httptest.NewRequest(http.MethodGet, "/api/v1/api-resources?group=apps", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"deployments"}, listAPIResourceNames(t, rec))
+
+	rec = httptest.NewRecorder()
+	s.handleListAPIResources(rec, httptest.NewRequest(http.MethodGet, "/api/v1/api-resources?group=&namespaced=false", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"nodes"}, listAPIResourceNames(t, rec))
+
+	rec = httptest.NewRecorder()
+	s.handleListAPIResources(rec, httptest.NewRequest(http.MethodGet, "/api/v1/api-resources?namespaced=maybe", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestETagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`W/"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`"x", "abc"`, `"abc"`))
+	assert.True(t, etagMatches(`*`, `"abc"`))
+	assert.False(t, etagMatches(``, `"abc"`))
+	assert.False(t, etagMatches(`"x"`, `"abc"`))
+}
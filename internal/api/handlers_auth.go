@@ -45,7 +45,7 @@ func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
 	// Generate PKCE parameters for security
 	pkceParams, err := auth.GeneratePKCEParams()
 	if err != nil {
-		s.logger.Error("Failed to generate PKCE parameters", zap.Error(err))
+		s.requestLogger(r).Error("Failed to generate PKCE parameters", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -60,7 +60,7 @@ func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
 	// Get authorization URL with PKCE
 	authURL := s.oidcClient.GetAuthURL(pkceParams.State, pkceParams)
 
-	s.logger.Info("Generated login URL",
+	s.requestLogger(r).Info("Generated login URL",
 		zap.String("state", pkceParams.State),
 		zap.String("requestId", middleware.GetReqID(r.Context())))
 
@@ -99,7 +99,7 @@ func (s *Server) handleAuthCallback(w http.ResponseWriter, r *http.Request) {
 	// Retrieve and validate PKCE parameters
 	pkceParams, exists := auth.GetPKCEParams(state)
 	if !exists {
-		s.logger.Error("Invalid or expired state parameter", zap.String("state", state))
+		s.requestLogger(r).Error("Invalid or expired state parameter", zap.String("state", state))
 		s.logAuthEvent(r, "", "callback_failed", "Invalid or expired login session", nil)
 		http.Error(w, "Invalid or expired login session", http.StatusBadRequest)
 		return
@@ -108,7 +108,7 @@ func (s *Server) handleAuthCallback(w http.ResponseWriter, r *http.Request) {
 	// Exchange code for tokens with PKCE
 	token, err := s.oidcClient.ExchangeCodeWithPKCE(r.Context(), code, pkceParams.CodeVerifier)
 	if err != nil {
-		s.logger.Error("Failed to exchange code for token", zap.Error(err))
+		s.requestLogger(r).Error("Failed to exchange code for token", zap.Error(err))
 		s.logAuthEvent(r, "", "token_exchange_failed", err.Error(), err)
 		http.Error(w, "Failed to exchange code", http.StatusBadRequest)
 		return
@@ -125,13 +125,13 @@ func (s *Server) handleAuthCallback(w http.ResponseWriter, r *http.Request) {
 	// Verify the ID token and get user info
 	user, err := s.oidcClient.VerifyToken(r.Context(), idToken)
 	if err != nil {
-		s.logger.Error("Failed to verify ID token", zap.Error(err))
+		s.requestLogger(r).Error("Failed to verify ID token", zap.Error(err))
 		s.logAuthEvent(r, "", "token_verification_failed", err.Error(), err)
 		http.Error(w, "Invalid token", http.StatusUnauthorized)
 		return
 	}
 
-	s.logger.Info("User after ID token verification",
+	s.requestLogger(r).Info("User after ID token verification",
 		zap.String("id", user.ID),
 		zap.String("email", user.Email),
 		zap.String("name", user.Name),
@@ -139,12 +139,12 @@ func (s *Server) handleAuthCallback(w http.ResponseWriter, r *http.Request) {
 
 	// Also fetch user info from userinfo endpoint to get additional claims like picture
 	if token.AccessToken != "" {
-		s.logger.Info("Fetching additional user info from userinfo endpoint")
+		s.requestLogger(r).Info("Fetching additional user info from userinfo endpoint")
 		userInfoUser, err := s.oidcClient.GetUserInfo(r.Context(), token.AccessToken)
 		if err != nil {
-			s.logger.Warn("Failed to fetch userinfo (continuing with ID token claims)", zap.Error(err))
+			s.requestLogger(r).Warn("Failed to fetch userinfo (continuing with ID token claims)", zap.Error(err))
 		} else {
-			s.logger.Info("User info from userinfo endpoint",
+			s.requestLogger(r).Info("User info from userinfo endpoint",
 				zap.String("id", userInfoUser.ID),
 				zap.String("email", userInfoUser.Email),
 				zap.String("name", userInfoUser.Name),
@@ -152,7 +152,7 @@ func (s *Server) handleAuthCallback(w http.ResponseWriter, r *http.Request) {
 
 			// Merge userinfo claims into user object (userinfo takes precedence for profile data)
 			if userInfoUser.Picture != "" {
-				s.logger.Info("Updating user picture from userinfo",
+				s.requestLogger(r).Info("Updating user picture from userinfo",
 					zap.String("old_picture", user.Picture),
 					zap.String("new_picture", userInfoUser.Picture))
 				user.Picture = userInfoUser.Picture
@@ -165,10 +165,10 @@ func (s *Server) handleAuthCallback(w http.ResponseWriter, r *http.Request) {
 			}
 			// Also merge groups if they are present in userinfo and not in ID token
 			if len(userInfoUser.Groups) > 0 && len(user.Groups) == 0 {
-				s.logger.Info("Updating user groups from userinfo endpoint")
+				s.requestLogger(r).Info("Updating user groups from userinfo endpoint")
 				user.Groups = userInfoUser.Groups
 			}
-			s.logger.Info("Final user profile after merging",
+			s.requestLogger(r).Info("Final user profile after merging",
 				zap.String("id", user.ID),
 				zap.String("email", user.Email),
 				zap.String("name", user.Name),
@@ -176,7 +176,7 @@ func (s *Server) handleAuthCallback(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	s.logger.Info("User authenticated via OIDC",
+	s.requestLogger(r).Info("User authenticated via OIDC",
 		zap.String("userId", user.ID),
 		zap.String("email", user.Email),
 		zap.Strings("groups", user.Groups))
@@ -187,13 +187,13 @@ func (s *Server) handleAuthCallback(w http.ResponseWriter, r *http.Request) {
 	// Resolve authorization if authz resolver is available
 	// TODO: We'll need to access the authz resolver from the middleware or create a direct reference
 	// For now, the middleware will handle authorization resolution on subsequent requests
-	s.logger.Debug("User groups will be resolved by middleware on subsequent requests")
+	s.requestLogger(r).Debug("User groups will be resolved by middleware on subsequent requests")
 
 	// Create dual token session (enhanced for Phase 3)
 	if s.sessionManager != nil {
 		accessToken, refreshToken, err := s.sessionManager.CreateDualTokenSession(user, r)
 		if err != nil {
-			s.logger.Error("Failed to create session", zap.Error(err))
+			s.requestLogger(r).Error("Failed to create session", zap.Error(err))
 			s.logAuthEvent(r, user.ID, "session_creation_failed", err.Error(), err)
 			http.Error(w, "Failed to create session", http.StatusInternalServerError)
 			return
@@ -233,7 +233,7 @@ func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
 	// Attempt to refresh tokens using refresh token from cookies
 	newAccessToken, newRefreshToken, userID, err := s.sessionManager.RefreshSessionFromToken(r)
 	if err != nil {
-		s.logger.Warn("Token refresh failed", zap.Error(err))
+		s.requestLogger(r).Warn("Token refresh failed", zap.Error(err))
 		s.logAuthEvent(r, userID, "refresh_failed", err.Error(), err)
 
 		// Clear cookies and return 401 to force re-authentication
@@ -252,7 +252,7 @@ func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
 	// Set new cookies
 	s.sessionManager.SetDualTokenCookies(w, newAccessToken, newRefreshToken, r.TLS != nil)
 
-	s.logger.Info("Tokens refreshed successfully",
+	s.requestLogger(r).Info("Tokens refreshed successfully",
 		zap.String("user_id", userID))
 	s.logAuthEvent(r, userID, "refresh_success", "Tokens refreshed successfully", nil)
 
@@ -281,7 +281,7 @@ func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
 			clientHash := s.sessionManager.GetTokenManager().GenerateClientHash(r)
 			if claims, family, err := s.sessionManager.GetTokenManager().ValidateRefreshToken(refreshToken, clientHash); err == nil {
 				s.sessionManager.GetTokenManager().InvalidateRefreshFamily(family.FamilyID)
-				s.logger.Info("Refresh token family invalidated on logout",
+				s.requestLogger(r).Info("Refresh token family invalidated on logout",
 					zap.String("user_id", userID),
 					zap.String("family_id", family.FamilyID),
 					zap.String("token_id", claims.TokenID))
@@ -294,7 +294,7 @@ func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
 		// Invalidate all user sessions if we have user context
 		if userOk && user != nil {
 			s.sessionManager.InvalidateUserSessions(user.ID)
-			s.logger.Info("User sessions invalidated on logout",
+			s.requestLogger(r).Info("User sessions invalidated on logout",
 				zap.String("user_id", user.ID))
 			s.logAuthEvent(r, user.ID, "logout_success", "All user sessions invalidated", nil)
 		} else {
@@ -332,7 +332,7 @@ func (s *Server) handleMe(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	// Debug: Log what we're sending to frontend
-	s.logger.Info("Sending user data to frontend via /me endpoint",
+	s.requestLogger(r).Info("Sending user data to frontend via /me endpoint",
 		zap.String("id", user.ID),
 		zap.String("email", user.Email),
 		zap.String("name", user.Name),
@@ -753,7 +753,7 @@ func (s *Server) handleRevokeUserSessions(w http.ResponseWriter, r *http.Request
 	secCtx, err := s.getSecurityContext(r)
 	if err != nil {
 		if secErr, ok := err.(*SecurityError); ok {
-			s.writeSecurityError(w, secErr, currentUser)
+			s.writeSecurityError(w, r, secErr, currentUser)
 		} else {
 			http.Error(w, "Security context error", http.StatusInternalServerError)
 		}
@@ -764,7 +764,7 @@ func (s *Server) handleRevokeUserSessions(w http.ResponseWriter, r *http.Request
 	// We check if they can 'update' the user bindings ConfigMap as a proxy for this admin permission.
 	if err := s.checkResourcePermission(r.Context(), secCtx, "update", "configmaps", s.config.Bindings.ConfigMap.Namespace, s.config.Bindings.ConfigMap.Name); err != nil {
 		if secErr, ok := err.(*SecurityError); ok {
-			s.writeSecurityError(w, secErr, currentUser)
+			s.writeSecurityError(w, r, secErr, currentUser)
 		} else {
 			http.Error(w, "Permission check failed", http.StatusInternalServerError)
 		}
@@ -793,7 +793,7 @@ func (s *Server) handleRevokeUserSessions(w http.ResponseWriter, r *http.Request
 	// Revoke all sessions for the specified user
 	s.sessionManager.InvalidateUserSessions(requestBody.UserID)
 
-	s.logger.Info("Admin revoked user sessions",
+	s.requestLogger(r).Info("Admin revoked user sessions",
 		zap.String("admin_user_id", currentUser.ID),
 		zap.String("target_user_id", requestBody.UserID))
 
@@ -837,7 +837,7 @@ func (s *Server) handleJWKS(w http.ResponseWriter, r *http.Request) {
 	// Get public key in PEM format
 	publicKeyPEM, err := tokenManager.GetPublicKeyPEM()
 	if err != nil {
-		s.logger.Error("Failed to get public key", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get public key", zap.Error(err))
 		http.Error(w, "Failed to get public key", http.StatusInternalServerError)
 		return
 	}
@@ -889,8 +889,8 @@ func (s *Server) logAuthEvent(r *http.Request, userID, eventType, message string
 
 	if err != nil {
 		auditFields = append(auditFields, zap.Error(err))
-		s.logger.Error("Authentication event", auditFields...)
+		s.requestLogger(r).Error("Authentication event", auditFields...)
 	} else {
-		s.logger.Info("Authentication event", auditFields...)
+		s.requestLogger(r).Info("Authentication event", auditFields...)
 	}
 }
@@ -14,7 +14,7 @@ func (s *Server) handleAuthzCapabilities(w http.ResponseWriter, r *http.Request)
 	// Get user from session
 	user, ok := auth.UserFromContext(r.Context())
 	if !ok || user == nil {
-		s.logger.Error("User not found in context for authz capabilities")
+		s.requestLogger(r).Error("User not found in context for authz capabilities")
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
@@ -22,7 +22,7 @@ func (s *Server) handleAuthzCapabilities(w http.ResponseWriter, r *http.Request)
 	// Parse request body
 	var req authz.CapabilityRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		s.logger.Error("Failed to decode capability request", zap.Error(err))
+		s.requestLogger(r).Error("Failed to decode capability request", zap.Error(err))
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
@@ -41,7 +41,7 @@ func (s *Server) handleAuthzCapabilities(w http.ResponseWriter, r *http.Request)
 
 	impersonatedClients, err := s.impersonationMgr.BuildClientsFromUser(user, usernameFormat)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated clients",
+		s.requestLogger(r).Error("Failed to get impersonated clients",
 			zap.Error(err),
 			zap.String("user_id", user.ID))
 		http.Error(w, "Failed to create impersonated client", http.StatusInternalServerError)
@@ -57,7 +57,7 @@ func (s *Server) handleAuthzCapabilities(w http.ResponseWriter, r *http.Request)
 		user.Groups,
 	)
 	if err != nil {
-		s.logger.Error("Failed to check capabilities",
+		s.requestLogger(r).Error("Failed to check capabilities",
 			zap.Error(err),
 			zap.String("user_id", user.ID),
 			zap.Strings("features", req.Features))
@@ -71,12 +71,12 @@ func (s *Server) handleAuthzCapabilities(w http.ResponseWriter, r *http.Request)
 
 	// Encode and send response
 	if err := json.NewEncoder(w).Encode(result); err != nil {
-		s.logger.Error("Failed to encode capability response", zap.Error(err))
+		s.requestLogger(r).Error("Failed to encode capability response", zap.Error(err))
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	s.logger.Debug("Capability check completed successfully",
+	s.requestLogger(r).Debug("Capability check completed successfully",
 		zap.String("user_id", user.ID),
 		zap.Int("features_requested", len(req.Features)),
 		zap.Int("features_allowed", s.countAllowedCapabilities(result.Caps)),
@@ -106,7 +106,7 @@ func (s *Server) handleAuthzCapabilitiesRegistry(w http.ResponseWriter, r *http.
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		s.logger.Error("Failed to encode capabilities registry response", zap.Error(err))
+		s.requestLogger(r).Error("Failed to encode capabilities registry response", zap.Error(err))
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -119,7 +119,7 @@ func (s *Server) handleAuthzCapabilitiesStats(w http.ResponseWriter, r *http.Req
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(stats); err != nil {
-		s.logger.Error("Failed to encode capability stats response", zap.Error(err))
+		s.requestLogger(r).Error("Failed to encode capability stats response", zap.Error(err))
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
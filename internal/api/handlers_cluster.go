@@ -6,10 +6,12 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
 	"github.com/aaronlmathis/kaptn/internal/k8s/selectors"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -24,7 +26,7 @@ import (
 func (s *Server) handleGetOverview(w http.ResponseWriter, r *http.Request) {
 	overview, err := s.overviewService.GetOverview(r.Context())
 	if err != nil {
-		s.logger.Error("Failed to get cluster overview", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get cluster overview", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -53,7 +55,7 @@ func (s *Server) handleGetOverview(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleListNamespaces(w http.ResponseWriter, r *http.Request) {
 	namespaces, err := s.kubeClient.CoreV1().Namespaces().List(r.Context(), metav1.ListOptions{})
 	if err != nil {
-		s.logger.Error("Failed to list namespaces", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list namespaces", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -99,7 +101,7 @@ func (s *Server) handleGetNamespace(w http.ResponseWriter, r *http.Request) {
 	secCtx, err := s.getSecurityContext(r)
 	if err != nil {
 		if secErr, ok := err.(*SecurityError); ok {
-			s.writeSecurityError(w, secErr, nil)
+			s.writeSecurityError(w, r, secErr, nil)
 		} else {
 			http.Error(w, "Security context error", http.StatusInternalServerError)
 		}
@@ -109,7 +111,7 @@ func (s *Server) handleGetNamespace(w http.ResponseWriter, r *http.Request) {
 	// Phase 7: Check permission to get this specific namespace
 	if err := s.checkResourcePermission(r.Context(), secCtx, "get", "namespaces", "", name); err != nil {
 		if secErr, ok := err.(*SecurityError); ok {
-			s.writeSecurityError(w, secErr, secCtx.User)
+			s.writeSecurityError(w, r, secErr, secCtx.User)
 		} else {
 			http.Error(w, "Permission check failed", http.StatusInternalServerError)
 		}
@@ -118,7 +120,7 @@ func (s *Server) handleGetNamespace(w http.ResponseWriter, r *http.Request) {
 
 	namespace, err := secCtx.Client.CoreV1().Namespaces().Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get namespace",
+		s.requestLogger(r).Error("Failed to get namespace",
 			zap.String("name", name),
 			zap.String("user", secCtx.User.Email),
 			zap.Error(err))
@@ -132,7 +134,7 @@ func (s *Server) handleGetNamespace(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log successful operation for audit
-	s.logger.Info("Namespace retrieved successfully",
+	s.requestLogger(r).Info("Namespace retrieved successfully",
 		zap.String("user", secCtx.User.Email),
 		zap.String("user_sub", secCtx.User.Sub),
 		zap.Strings("user_groups", secCtx.User.Groups),
@@ -165,19 +167,31 @@ func (s *Server) handleGetNamespace(w http.ResponseWriter, r *http.Request) {
 // @Tags Nodes
 // @Produce json
 // @Param search query string false "Search term for node name or labels"
-// @Param sortBy query string false "Sort by field (default: name)"
+// @Param sort query string false "Sort by field (default: name); sortBy is accepted as an alias"
 // @Param page query int false "Page number (default: 1)"
 // @Param pageSize query int false "Page size (default: 50, max: 100)"
 // @Param labelSelector query string false "Label selector to filter nodes"
 // @Param fieldSelector query string false "Field selector to filter nodes"
+// @Param view query string false "Response shape: full (default) or table for name, roles, ready, version, age and schedulable only"
 // @Success 200 {object} map[string]interface{} "Paginated list of nodes"
 // @Failure 400 {string} string "Bad request"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/nodes [get]
 func (s *Server) handleListNodes(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
+	view := r.URL.Query().Get("view")
+	toResponse := s.nodeToEnrichedResponse
+	switch view {
+	case "", "full":
+	case "table":
+		toResponse = s.nodeToTableResponse
+	default:
+		writeJSONError(w, http.StatusBadRequest, "view must be 'table' or 'full'")
+		return
+	}
+
 	search := r.URL.Query().Get("search")
-	sortBy := r.URL.Query().Get("sortBy")
+	sortBy := getSortParam(r)
 	if sortBy == "" {
 		sortBy = "name"
 	}
@@ -225,15 +239,15 @@ func (s *Server) handleListNodes(w http.ResponseWriter, r *http.Request) {
 
 	filteredNodes, err := selectors.FilterNodes(nodes, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter nodes", zap.Error(err))
-		http.Error(w, "Failed to filter nodes", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter nodes", zap.Error(err))
+		http.Error(w, "Failed to filter nodes: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	// Convert to enriched response format
+	// Convert to the requested response format
 	var responseItems []map[string]interface{}
 	for _, node := range filteredNodes {
-		responseItems = append(responseItems, s.nodeToEnrichedResponse(&node))
+		responseItems = append(responseItems, toResponse(&node))
 	}
 
 	response := map[string]interface{}{
@@ -274,10 +288,16 @@ func (s *Server) handleGetNode(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get node from Kubernetes API
-	node, err := s.kubeClient.CoreV1().Nodes().Get(r.Context(), name, metav1.GetOptions{})
+	// Get node from the informer cache, or the Kubernetes API when not cached
+	var node *v1.Node
+	var err error
+	if s.informerManager != nil {
+		node, err = s.informerManager.GetNode(r.Context(), name)
+	} else {
+		node, err = s.kubeClient.CoreV1().Nodes().Get(r.Context(), name, metav1.GetOptions{})
+	}
 	if err != nil {
-		s.logger.Error("Failed to get node",
+		s.requestLogger(r).Error("Failed to get node",
 			zap.String("name", name),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -320,13 +340,14 @@ func (s *Server) handleGetNode(w http.ResponseWriter, r *http.Request) {
 // @Param fieldSelector query string false "Field selector to filter resource quotas"
 // @Param page query int false "Page number (default: 1)"
 // @Param pageSize query int false "Page size (default: 25)"
-// @Param sort query string false "Sort by field"
+// @Param sort query string false "Sort by field; sortBy is accepted as an alias"
 // @Param order query string false "Sort order (asc/desc)"
 // @Param search query string false "Search term"
 // @Success 200 {object} map[string]interface{} "Paginated list of resource quotas"
 // @Failure 400 {string} string "Bad request"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/resource-quotas [get]
+// @Router /api/v1/resourcequotas [get]
 func (s *Server) handleListResourceQuotas(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
 	namespace := r.URL.Query().Get("namespace")
@@ -334,7 +355,7 @@ func (s *Server) handleListResourceQuotas(w http.ResponseWriter, r *http.Request
 	fieldSelector := r.URL.Query().Get("fieldSelector")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pageSize")
-	sort := r.URL.Query().Get("sort")
+	sort := getSortParam(r)
 	order := r.URL.Query().Get("order")
 	search := r.URL.Query().Get("search")
 
@@ -352,7 +373,7 @@ func (s *Server) handleListResourceQuotas(w http.ResponseWriter, r *http.Request
 	// Get resource quotas from resource manager
 	resourceQuotas, err := s.resourceManager.ListResourceQuotas(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list resource quotas", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list resource quotas", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -385,8 +406,8 @@ func (s *Server) handleListResourceQuotas(w http.ResponseWriter, r *http.Request
 
 	filteredResourceQuotas, err := selectors.FilterResourceQuotas(resourceQuotas, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter resource quotas", zap.Error(err))
-		http.Error(w, "Failed to filter resource quotas", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter resource quotas", zap.Error(err))
+		http.Error(w, "Failed to filter resource quotas: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -423,6 +444,7 @@ func (s *Server) handleListResourceQuotas(w http.ResponseWriter, r *http.Request
 // @Failure 400 {object} map[string]interface{} "Bad request"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/resource-quotas/{namespace}/{name} [get]
+// @Router /api/v1/resourcequotas/{namespace}/{name} [get]
 func (s *Server) handleGetResourceQuota(w http.ResponseWriter, r *http.Request) {
 	namespace := chi.URLParam(r, "namespace")
 	name := chi.URLParam(r, "name")
@@ -440,7 +462,7 @@ func (s *Server) handleGetResourceQuota(w http.ResponseWriter, r *http.Request)
 	// Get resource quota from Kubernetes API
 	resourceQuota, err := s.kubeClient.CoreV1().ResourceQuotas(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get resource quota",
+		s.requestLogger(r).Error("Failed to get resource quota",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -502,7 +524,7 @@ func (s *Server) handleDeleteResourceQuota(w http.ResponseWriter, r *http.Reques
 	// Delete the resource quota
 	err := s.resourceManager.DeleteResourceQuota(r.Context(), namespace, name, metav1.DeleteOptions{})
 	if err != nil {
-		s.logger.Error("Failed to delete resource quota",
+		s.requestLogger(r).Error("Failed to delete resource quota",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -515,7 +537,7 @@ func (s *Server) handleDeleteResourceQuota(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	s.logger.Info("Resource quota deleted successfully",
+	s.requestLogger(r).Info("Resource quota deleted successfully",
 		zap.String("namespace", namespace),
 		zap.String("name", name))
 
@@ -527,15 +549,132 @@ func (s *Server) handleDeleteResourceQuota(w http.ResponseWriter, r *http.Reques
 	})
 }
 
+// handleListLimitRanges handles GET /api/v1/limit-ranges
+// @Summary List limit ranges
+// @Description Lists all limit ranges in the cluster or a specific namespace, with filtering, sorting, and pagination.
+// @Tags LimitRanges
+// @Produce json
+// @Param namespace query string false "Namespace to filter by"
+// @Param labelSelector query string false "Label selector to filter limit ranges"
+// @Param fieldSelector query string false "Field selector to filter limit ranges"
+// @Param page query int false "Page number (default: 1)"
+// @Param pageSize query int false "Page size (default: 25)"
+// @Param sort query string false "Sort by field; sortBy is accepted as an alias"
+// @Param order query string false "Sort order (asc/desc)"
+// @Param search query string false "Search term"
+// @Success 200 {object} map[string]interface{} "Paginated list of limit ranges"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/limit-ranges [get]
+// @Router /api/v1/limitranges [get]
+func (s *Server) handleListLimitRanges(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if pageSize <= 0 {
+		pageSize = 25
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	limitRanges, err := s.resourceManager.ListLimitRanges(r.Context(), namespace)
+	if err != nil {
+		s.requestLogger(r).Error("Failed to list limit ranges", zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filtered, err := selectors.FilterLimitRanges(limitRanges, selectors.LimitRangeFilterOptions{
+		Namespace:     namespace,
+		LabelSelector: r.URL.Query().Get("labelSelector"),
+		FieldSelector: r.URL.Query().Get("fieldSelector"),
+		Search:        r.URL.Query().Get("search"),
+		Sort:          getSortParam(r),
+		Order:         r.URL.Query().Get("order"),
+		Page:          page,
+		PageSize:      pageSize,
+	})
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Failed to filter limit ranges: "+err.Error())
+		return
+	}
+
+	responses := []map[string]interface{}{}
+	for _, limitRange := range filtered {
+		responses = append(responses, s.limitRangeToResponse(limitRange))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{
+			"items":    responses,
+			"page":     page,
+			"pageSize": pageSize,
+			"total":    len(limitRanges),
+		},
+		"status": "success",
+	})
+}
+
+// handleGetLimitRange handles GET /api/v1/limit-ranges/{namespace}/{name}
+// @Summary Get limit range details
+// @Description Get details and summary for a specific limit range.
+// @Tags LimitRanges
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param name path string true "LimitRange name"
+// @Success 200 {object} map[string]interface{} "LimitRange details"
+// @Failure 404 {object} map[string]interface{} "Limit range not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/limit-ranges/{namespace}/{name} [get]
+// @Router /api/v1/limitranges/{namespace}/{name} [get]
+func (s *Server) handleGetLimitRange(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	limitRange, err := s.resourceManager.GetLimitRange(r.Context(), namespace, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			writeJSONError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		s.requestLogger(r).Error("Failed to get limit range",
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{
+			"summary":    s.limitRangeToResponse(*limitRange),
+			"spec":       limitRange.Spec,
+			"metadata":   limitRange.ObjectMeta,
+			"kind":       "LimitRange",
+			"apiVersion": "v1",
+		},
+		"status": "success",
+	})
+}
+
 // handleListAPIResources handles GET /api/v1/api-resources
 // @Summary List API resources
-// @Description Lists all API resources available in the cluster, with optional search and pagination.
+// @Description Lists all API resources available in the cluster, with optional group/scope filters, search and pagination. Responses carry an ETag derived from the discovery results; a matching If-None-Match returns 304.
 // @Tags APIResources
 // @Produce json
 // @Param page query int false "Page number (default: 1)"
 // @Param pageSize query int false "Page size (default: 25)"
 // @Param search query string false "Search term"
+// @Param group query string false "API group to include (empty for the core group)"
+// @Param namespaced query bool false "Only namespaced (true) or cluster-scoped (false) resources"
 // @Success 200 {object} map[string]interface{} "Paginated list of API resources"
+// @Success 304 "Not modified"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/api-resources [get]
 func (s *Server) handleListAPIResources(w http.ResponseWriter, r *http.Request) {
@@ -544,6 +683,22 @@ func (s *Server) handleListAPIResources(w http.ResponseWriter, r *http.Request)
 	pageSizeStr := r.URL.Query().Get("pageSize")
 	search := r.URL.Query().Get("search")
 
+	// An explicit empty group selects the core group
+	var groupFilter *string
+	if r.URL.Query().Has("group") {
+		group := r.URL.Query().Get("group")
+		groupFilter = &group
+	}
+	var namespacedFilter *bool
+	if namespacedStr := r.URL.Query().Get("namespaced"); namespacedStr != "" {
+		namespaced, err := strconv.ParseBool(namespacedStr)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "namespaced must be true or false")
+			return
+		}
+		namespacedFilter = &namespaced
+	}
+
 	page, _ := strconv.Atoi(pageStr)
 	pageSize, _ := strconv.Atoi(pageSizeStr)
 
@@ -556,9 +711,9 @@ func (s *Server) handleListAPIResources(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Get API resources from resource manager
-	apiResources, err := s.resourceManager.ListAPIResources(r.Context())
+	catalog, err := s.resourceManager.APIResourceCatalog(r.Context())
 	if err != nil {
-		s.logger.Error("Failed to list API resources", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list API resources", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -574,6 +729,16 @@ func (s *Server) handleListAPIResources(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Discovery rarely changes, so let clients revalidate against its hash
+	w.Header().Set("ETag", catalog.ETag)
+	w.Header().Set("Cache-Control", "private, max-age=60, must-revalidate")
+	if etagMatches(r.Header.Get("If-None-Match"), catalog.ETag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	apiResources := resources.FilterAPIResources(catalog.Resources, groupFilter, namespacedFilter)
+
 	// Store total count before filtering
 	totalBeforeFilter := len(apiResources)
 
@@ -678,7 +843,7 @@ func (s *Server) handleGetAPIResource(w http.ResponseWriter, r *http.Request) {
 	// Get API resource from resource manager
 	apiResource, err := s.resourceManager.GetAPIResource(r.Context(), name, group)
 	if err != nil {
-		s.logger.Error("Failed to get API resource",
+		s.requestLogger(r).Error("Failed to get API resource",
 			zap.String("name", name),
 			zap.String("group", group),
 			zap.Error(err))
@@ -222,7 +222,7 @@ func transformClusterRoleBindingToResponse(clusterRoleBinding *rbacv1.ClusterRol
 func (s *Server) handleListClusterRoles(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
 	search := r.URL.Query().Get("search")
-	sortBy := r.URL.Query().Get("sortBy")
+	sortBy := getSortParam(r)
 	if sortBy == "" {
 		sortBy = "name"
 	}
@@ -244,7 +244,7 @@ func (s *Server) handleListClusterRoles(w http.ResponseWriter, r *http.Request)
 	// Get cluster roles from Kubernetes
 	clusterRoles, err := s.resourceManager.ListClusterRoles(r.Context())
 	if err != nil {
-		s.logger.Error("Failed to list cluster roles", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list cluster roles", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -343,7 +343,7 @@ func (s *Server) handleGetClusterRole(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleListClusterRoleBindings(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
 	search := r.URL.Query().Get("search")
-	sortBy := r.URL.Query().Get("sortBy")
+	sortBy := getSortParam(r)
 	if sortBy == "" {
 		sortBy = "name"
 	}
@@ -365,7 +365,7 @@ func (s *Server) handleListClusterRoleBindings(w http.ResponseWriter, r *http.Re
 	// Get cluster role bindings from Kubernetes
 	clusterRoleBindings, err := s.resourceManager.ListClusterRoleBindings(r.Context())
 	if err != nil {
-		s.logger.Error("Failed to list cluster role bindings", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list cluster role bindings", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -528,11 +528,12 @@ func (s *Server) clusterRoleToResponse(clusterRole interface{}) map[string]inter
 	}
 
 	// Extract metadata
-	metadata := clusterRoleObj["metadata"].(map[string]interface{})
-	name := metadata["name"].(string)
+	metadata, _ := clusterRoleObj["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
 
 	// Calculate age
-	creationTime, _ := time.Parse(time.RFC3339, metadata["creationTimestamp"].(string))
+	creationTimestamp, _ := metadata["creationTimestamp"].(string)
+	creationTime, _ := time.Parse(time.RFC3339, creationTimestamp)
 	age := time.Since(creationTime)
 
 	var ageStr string
@@ -547,7 +548,7 @@ func (s *Server) clusterRoleToResponse(clusterRole interface{}) map[string]inter
 	}
 
 	// Extract rules information
-	rules := clusterRoleObj["rules"].([]interface{})
+	rules, _ := clusterRoleObj["rules"].([]interface{})
 	ruleCount := len(rules)
 
 	// Count unique verbs and resources across all rules
@@ -555,17 +556,21 @@ func (s *Server) clusterRoleToResponse(clusterRole interface{}) map[string]inter
 	resourceSet := make(map[string]bool)
 
 	for _, rule := range rules {
-		ruleMap := rule.(map[string]interface{})
+		ruleMap, _ := rule.(map[string]interface{})
 
 		if verbs, ok := ruleMap["verbs"].([]interface{}); ok {
 			for _, verb := range verbs {
-				verbSet[verb.(string)] = true
+				if v, ok := verb.(string); ok {
+					verbSet[v] = true
+				}
 			}
 		}
 
 		if resources, ok := ruleMap["resources"].([]interface{}); ok {
 			for _, resource := range resources {
-				resourceSet[resource.(string)] = true
+				if r, ok := resource.(string); ok {
+					resourceSet[r] = true
+				}
 			}
 		}
 	}
@@ -637,11 +642,12 @@ func (s *Server) clusterRoleBindingToResponse(clusterRoleBinding interface{}) ma
 	}
 
 	// Extract metadata
-	metadata := clusterRoleBindingObj["metadata"].(map[string]interface{})
-	name := metadata["name"].(string)
+	metadata, _ := clusterRoleBindingObj["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
 
 	// Calculate age
-	creationTime, _ := time.Parse(time.RFC3339, metadata["creationTimestamp"].(string))
+	creationTimestamp, _ := metadata["creationTimestamp"].(string)
+	creationTime, _ := time.Parse(time.RFC3339, creationTimestamp)
 	age := time.Since(creationTime)
 
 	var ageStr string
@@ -656,14 +662,14 @@ func (s *Server) clusterRoleBindingToResponse(clusterRoleBinding interface{}) ma
 	}
 
 	// Extract role reference
-	roleRef := clusterRoleBindingObj["roleRef"].(map[string]interface{})
-	roleName := roleRef["name"].(string)
-	roleKind := roleRef["kind"].(string)
+	roleRef, _ := clusterRoleBindingObj["roleRef"].(map[string]interface{})
+	roleName, _ := roleRef["name"].(string)
+	roleKind, _ := roleRef["kind"].(string)
 
 	// Extract subjects information
 	var subjects []interface{}
 	if subjectsInterface := clusterRoleBindingObj["subjects"]; subjectsInterface != nil {
-		subjects = subjectsInterface.([]interface{})
+		subjects, _ = subjectsInterface.([]interface{})
 	}
 	subjectCount := len(subjects)
 
@@ -674,9 +680,9 @@ func (s *Server) clusterRoleBindingToResponse(clusterRoleBinding interface{}) ma
 	var subjectsDisplayList []string
 
 	for _, subject := range subjects {
-		subjectMap := subject.(map[string]interface{})
-		kind := subjectMap["kind"].(string)
-		name := subjectMap["name"].(string)
+		subjectMap, _ := subject.(map[string]interface{})
+		kind, _ := subjectMap["kind"].(string)
+		name, _ := subjectMap["name"].(string)
 
 		switch kind {
 		case "User":
@@ -689,7 +695,7 @@ func (s *Server) clusterRoleBindingToResponse(clusterRoleBinding interface{}) ma
 			serviceAccountCount++
 			namespace := ""
 			if ns, ok := subjectMap["namespace"]; ok {
-				namespace = ns.(string)
+				namespace, _ = ns.(string)
 			}
 			if namespace != "" {
 				subjectsDisplayList = append(subjectsDisplayList, fmt.Sprintf("SA:%s/%s", namespace, name))
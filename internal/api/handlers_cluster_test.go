@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newQuotaServer() *Server {
+	client := fake.NewSimpleClientset(
+		&v1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "shop"},
+			Spec: v1.ResourceQuotaSpec{Hard: v1.ResourceList{
+				v1.ResourceLimitsCPU: resource.MustParse("4"),
+				v1.ResourcePods:      resource.MustParse("10"),
+			}},
+			Status: v1.ResourceQuotaStatus{Used: v1.ResourceList{
+				v1.ResourceLimitsCPU: resource.MustParse("1500m"),
+			}},
+		},
+		&v1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "shop"},
+			Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{{
+				Type:    v1.LimitTypeContainer,
+				Max:     v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+				Default: v1.ResourceList{v1.ResourceMemory: resource.MustParse("256Mi")},
+			}}},
+		},
+		&v1.LimitRange{ObjectMeta: metav1.ObjectMeta{Name: "storage", Namespace: "data"}},
+	)
+	return &Server{
+		logger:          zap.NewNop(),
+		config:          &config.Config{},
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+}
+
+func withNameParams(req *http.Request, namespace, name string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("namespace", namespace)
+	rctx.URLParams.Add("name", name)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandleListResourceQuotasReportsUtilization(t *testing.T) {
+	s := newQuotaServer()
+	rec := httptest.NewRecorder()
+	s.handleListResourceQuotas(rec, httptest.NewRequest(http.MethodGet, "/api/v1/resourcequotas?namespace=shop", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Data struct {
+			Items []struct {
+				HardLimits []struct {
+					Name        string   `json:"name"`
+					Limit       string   `json:"limit"`
+					Used        string   `json:"used"`
+					UsedPercent *float64 `json:"usedPercent"`
+				} `json:"hardLimits"`
+			} `json:"items"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Data.Items, 1)
+	limits := response.Data.Items[0].HardLimits
+	require.Len(t, limits, 2)
+
+	// Sorted by resource name
+	assert.Equal(t, "limits.cpu", limits[0].Name)
+	assert.Equal(t, "1500m", limits[0].Used)
+	require.NotNil(t, limits[0].UsedPercent)
+	assert.InDelta(t, 37.5, *limits[0].UsedPercent, 0.001)
+	assert.Equal(t, "pods", limits[1].Name)
+	assert.Equal(t, "0", limits[1].Used)
+	require.NotNil(t, limits[1].UsedPercent)
+	assert.Zero(t, *limits[1].UsedPercent)
+}
+
+func TestHandleListLimitRanges(t *testing.T) {
+	s := newQuotaServer()
+
+	tests := []struct {
+		name   string
+		target string
+		names  []string
+	}{
+		{"all namespaces", "/api/v1/limitranges", []string{"defaults", "storage"}},
+		{"namespace", "/api/v1/limitranges?namespace=data", []string{"storage"}},
+		{"search", "/api/v1/limitranges?search=def", []string{"defaults"}},
+		{"descending", "/api/v1/limitranges?sort=name&order=desc", []string{"storage", "defaults"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.handleListLimitRanges(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var response struct {
+				Data struct {
+					Items []map[string]interface{} `json:"items"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			names := []string{}
+			for _, item := range response.Data.Items {
+				names = append(names, item["name"].(string))
+			}
+			assert.Equal(t, tt.names, names)
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleListLimitRanges(rec, httptest.NewRequest(http.MethodGet, "/api/v1/limitranges?labelSelector=app%20in", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandleGetLimitRange(t *testing.T) {
+	s := newQuotaServer()
+
+	rec := httptest.NewRecorder()
+	s.handleGetLimitRange(rec, withNameParams(httptest.NewRequest(http.MethodGet, "/api/v1/limitranges/shop/defaults", nil), "shop", "defaults"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Data struct {
+			Summary struct {
+				Types  []string `json:"types"`
+				Limits []struct {
+					Max     map[string]string `json:"max"`
+					Default map[string]string `json:"default"`
+				} `json:"limits"`
+			} `json:"summary"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, []string{"Container"}, response.Data.Summary.Types)
+	require.Len(t, response.Data.Summary.Limits, 1)
+	assert.Equal(t, "2", response.Data.Summary.Limits[0].Max["cpu"])
+	assert.Equal(t, "256Mi", response.Data.Summary.Limits[0].Default["memory"])
+
+	rec = httptest.NewRecorder()
+	s.handleGetLimitRange(rec, withNameParams(httptest.NewRequest(http.MethodGet, "/api/v1/limitranges/shop/missing", nil), "shop", "missing"))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
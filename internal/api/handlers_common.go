@@ -3,16 +3,21 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aaronlmathis/kaptn/internal/auth"
 	"github.com/aaronlmathis/kaptn/internal/k8s"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -62,7 +67,7 @@ func (s *Server) handleExportResource(w http.ResponseWriter, r *http.Request) {
 
 	export, err := s.resourceManager.ExportResource(r.Context(), namespace, name, kind)
 	if err != nil {
-		s.logger.Error("Failed to export resource",
+		s.requestLogger(r).Error("Failed to export resource",
 			zap.String("namespace", namespace),
 			zap.String("kind", kind),
 			zap.String("name", name),
@@ -103,7 +108,7 @@ func (s *Server) handleExportClusterScopedResource(w http.ResponseWriter, r *htt
 	// This endpoint is specifically for cluster-scoped resources, so pass empty namespace
 	export, err := s.resourceManager.ExportResource(r.Context(), "", name, kind)
 	if err != nil {
-		s.logger.Error("Failed to export cluster-scoped resource",
+		s.requestLogger(r).Error("Failed to export cluster-scoped resource",
 			zap.String("kind", kind),
 			zap.String("name", name),
 			zap.Error(err))
@@ -118,17 +123,79 @@ func (s *Server) handleExportClusterScopedResource(w http.ResponseWriter, r *htt
 	json.NewEncoder(w).Encode(export)
 }
 
+// handleExportResources handles POST /api/v1/export
+// @Summary Export multiple resources
+// @Description Export several resources as a single multi-document YAML stream.
+// @Tags Resources
+// @Accept json
+// @Produce application/yaml
+// @Param request body object true "List of resource references"
+// @Success 200 {string} string "Multi-document YAML"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/export [post]
+func (s *Server) handleExportResources(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Resources []resources.ResourceRef `json:"resources"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
+		return
+	}
+
+	if len(req.Resources) == 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "at least one resource is required"})
+		return
+	}
+
+	for _, ref := range req.Resources {
+		if ref.Kind == "" || ref.Name == "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "kind and name are required for every resource"})
+			return
+		}
+	}
+
+	data, err := s.resourceManager.ExportResources(r.Context(), req.Resources)
+	if err != nil {
+		s.requestLogger(r).Error("Failed to export resources",
+			zap.Int("count", len(req.Resources)),
+			zap.Error(err))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
 // handleGetPodLogs handles GET /api/v1/namespaces/{namespace}/pods/{podName}/logs
 // @Summary Get pod logs
-// @Description Get logs for a specific pod and (optionally) container.
+// @Description Get logs for a specific pod and (optionally) container. With previous=true the logs of the container's last terminated instance are returned. With sinceLastRestart=true only the logs since the container last started are returned, computed from its state.running.startedAt, or with previous=true from its lastState.terminated startedAt to finishedAt; the window is returned in the X-Log-Since and X-Log-Until headers. With allContainers=true the logs of every started container, init containers included, are returned as JSON keyed by container name.
 // @Tags Pods
 // @Produce plain
+// @Produce json
 // @Param namespace path string true "Namespace"
 // @Param podName path string true "Pod name"
 // @Param container query string false "Container name (optional)"
 // @Param tailLines query int false "Number of lines from the end of the logs"
+// @Param previous query bool false "Return the logs of the previous, terminated container instance"
+// @Param sinceLastRestart query bool false "Only return the logs since the container last started"
+// @Param allContainers query bool false "Return the logs of all containers as JSON"
 // @Success 200 {string} string "Pod logs"
+// @Header 200 {string} X-Log-Since "Start of the sinceLastRestart window (RFC 3339)"
+// @Header 200 {string} X-Log-Until "End of the sinceLastRestart window with previous=true (RFC 3339)"
 // @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "The container has no previous instance"
+// @Failure 409 {object} map[string]string "sinceLastRestart was requested but the container is not running"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/v1/namespaces/{namespace}/pods/{podName}/logs [get]
 func (s *Server) handleGetPodLogs(w http.ResponseWriter, r *http.Request) {
@@ -143,6 +210,17 @@ func (s *Server) handleGetPodLogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if containerName != "" {
+		if pod, err := s.kubeClient.CoreV1().Pods(namespace).Get(r.Context(), podName, metav1.GetOptions{}); err == nil {
+			if err := ambiguousContainerError(pod, containerName); err != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+		}
+	}
+
 	var tailLines *int64
 	if tail := r.URL.Query().Get("tailLines"); tail != "" {
 		if lines, err := strconv.ParseInt(tail, 10, 64); err == nil {
@@ -150,9 +228,32 @@ func (s *Server) handleGetPodLogs(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	logs, err := s.resourceManager.GetPodLogs(r.Context(), namespace, podName, containerName, tailLines)
+	previous := r.URL.Query().Get("previous") == "true"
+	sinceLastRestart := r.URL.Query().Get("sinceLastRestart") == "true"
+	if r.URL.Query().Get("allContainers") == "true" {
+		if previous || containerName != "" || sinceLastRestart {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "allContainers cannot be combined with container, previous or sinceLastRestart"})
+			return
+		}
+		s.writeAllContainerLogs(w, r, namespace, podName, tailLines)
+		return
+	}
+	if sinceLastRestart {
+		s.writePodLogsSinceLastRestart(w, r, namespace, podName, containerName, tailLines, previous)
+		return
+	}
+
+	logs, err := s.resourceManager.GetPodLogs(r.Context(), namespace, podName, containerName, tailLines, previous)
+	if errors.Is(err, resources.ErrNoPreviousLogs) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
 	if err != nil {
-		s.logger.Error("Failed to get pod logs",
+		s.requestLogger(r).Error("Failed to get pod logs",
 			zap.String("namespace", namespace),
 			zap.String("pod", podName),
 			zap.String("container", containerName),
@@ -168,6 +269,91 @@ func (s *Server) handleGetPodLogs(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(logs))
 }
 
+// restartLogWindowStatus maps a RestartLogWindow error to an HTTP status
+func restartLogWindowStatus(err error) int {
+	switch {
+	case errors.Is(err, resources.ErrNoPreviousLogs):
+		return http.StatusNotFound
+	case errors.Is(err, resources.ErrContainerNotRunning):
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
+
+// setLogWindowHeaders reports the window of logs since a restart
+func setLogWindowHeaders(header http.Header, window resources.LogWindow) {
+	header.Set("X-Log-Container", window.Container)
+	header.Set("X-Log-Since", window.Start.UTC().Format(time.RFC3339))
+	if window.End != nil {
+		header.Set("X-Log-Until", window.End.UTC().Format(time.RFC3339))
+	}
+}
+
+// writePodLogsSinceLastRestart writes the logs a container wrote since it
+// last started, or with previous those of its last terminated instance
+func (s *Server) writePodLogsSinceLastRestart(w http.ResponseWriter, r *http.Request, namespace, podName, containerName string, tailLines *int64, previous bool) {
+	pod, err := s.kubeClient.CoreV1().Pods(namespace).Get(r.Context(), podName, metav1.GetOptions{})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if apierrors.IsNotFound(err) {
+			status = http.StatusNotFound
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	window, err := resources.RestartLogWindow(pod, containerName, previous)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(restartLogWindowStatus(err))
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	logs, err := s.resourceManager.GetPodLogsSince(r.Context(), namespace, podName, window.Container, tailLines, previous, window.Start)
+	if err != nil {
+		s.requestLogger(r).Error("Failed to get pod logs",
+			zap.String("namespace", namespace),
+			zap.String("pod", podName),
+			zap.String("container", window.Container),
+			zap.Error(err))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	setLogWindowHeaders(w.Header(), window)
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(logs))
+}
+
+// writeAllContainerLogs writes the logs of every started container of a pod
+// as JSON keyed by container name
+func (s *Server) writeAllContainerLogs(w http.ResponseWriter, r *http.Request, namespace, podName string, tailLines *int64) {
+	logs, err := s.resourceManager.GetAllContainerLogs(r.Context(), namespace, podName, tailLines)
+	if err != nil {
+		s.requestLogger(r).Error("Failed to get pod logs",
+			zap.String("namespace", namespace),
+			zap.String("pod", podName),
+			zap.Error(err))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   map[string]interface{}{"containers": logs},
+		"status": "success",
+	})
+}
+
 // Phase 7: Secure Handler Patterns with SSAR checks and impersonated clients
 
 // SecurityContext holds information for secure operations
@@ -194,7 +380,7 @@ func (s *Server) getSecurityContext(r *http.Request) (*SecurityContext, error) {
 	// Get impersonated client
 	client, err := s.GetImpersonatedClient(r)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated client",
+		s.requestLogger(r).Error("Failed to get impersonated client",
 			zap.Error(err),
 			zap.String("user", user.Email))
 		return nil, &SecurityError{
@@ -225,17 +411,17 @@ func (e *SecurityError) Error() string {
 }
 
 // writeSecurityError writes a structured security error response
-func (s *Server) writeSecurityError(w http.ResponseWriter, err *SecurityError, user *auth.User) {
+func (s *Server) writeSecurityError(w http.ResponseWriter, r *http.Request, err *SecurityError, user *auth.User) {
 	// Log security event
 	if user != nil {
-		s.logger.Warn("Security error",
+		s.requestLogger(r).Warn("Security error",
 			zap.String("user_sub", user.Sub),
 			zap.String("user_email", user.Email),
 			zap.String("error_code", err.Code),
 			zap.String("error_message", err.Message),
 			zap.Int("status", err.Status))
 	} else {
-		s.logger.Warn("Security error - no user context",
+		s.requestLogger(r).Warn("Security error - no user context",
 			zap.String("error_code", err.Code),
 			zap.String("error_message", err.Message),
 			zap.Int("status", err.Status))
@@ -252,6 +438,12 @@ func (s *Server) writeSecurityError(w http.ResponseWriter, err *SecurityError, u
 
 // checkResourcePermission performs SSAR check for a specific resource operation
 func (s *Server) checkResourcePermission(ctx context.Context, secCtx *SecurityContext, verb, resource, namespace, name string) error {
+	return s.checkGroupResourcePermission(ctx, secCtx, verb, "", resource, namespace, name)
+}
+
+// checkGroupResourcePermission performs SSAR check for an operation on a
+// resource of an API group; the core group is empty
+func (s *Server) checkGroupResourcePermission(ctx context.Context, secCtx *SecurityContext, verb, group, resource, namespace, name string) error {
 	if secCtx.SSARHelper == nil {
 		return &SecurityError{
 			Code:    "SSAR_UNAVAILABLE",
@@ -265,7 +457,7 @@ func (s *Server) checkResourcePermission(ctx context.Context, secCtx *SecurityCo
 		ctx,
 		secCtx.Client,
 		verb,
-		"", // group - empty for core resources
+		group,
 		resource,
 		namespace,
 		name,
@@ -332,6 +524,34 @@ func (s *Server) checkResourcePermission(ctx context.Context, secCtx *SecurityCo
 	return nil
 }
 
+// requireResourcePermission reports whether the caller may perform an
+// operation on a resource, writing an error when they may not. Without
+// authentication everyone may.
+func (s *Server) requireResourcePermission(w http.ResponseWriter, r *http.Request, verb, group, resource, namespace string) bool {
+	if s.config == nil || s.config.Security.AuthMode == "none" {
+		return true
+	}
+
+	secCtx, err := s.getSecurityContext(r)
+	if err != nil {
+		if secErr, ok := err.(*SecurityError); ok {
+			s.writeSecurityError(w, r, secErr, nil)
+		} else {
+			http.Error(w, "Security context error", http.StatusInternalServerError)
+		}
+		return false
+	}
+	if err := s.checkGroupResourcePermission(r.Context(), secCtx, verb, group, resource, namespace, ""); err != nil {
+		if secErr, ok := err.(*SecurityError); ok {
+			s.writeSecurityError(w, r, secErr, secCtx.User)
+		} else {
+			http.Error(w, "Permission check failed", http.StatusInternalServerError)
+		}
+		return false
+	}
+	return true
+}
+
 // Phase 8: Enhanced audit logging for observability
 
 // logAuditEvent logs a structured audit event for a resource operation
@@ -365,13 +585,13 @@ func (s *Server) logAuditEvent(r *http.Request, user *auth.User, verb, resource,
 	// Log at appropriate level based on decision
 	switch strings.ToUpper(decision) {
 	case "ALLOWED", "SUCCESS":
-		s.logger.Info("Audit event", logFields...)
+		s.requestLogger(r).Info("Audit event", logFields...)
 	case "DENIED", "FORBIDDEN":
-		s.logger.Warn("Audit event - access denied", logFields...)
+		s.requestLogger(r).Warn("Audit event - access denied", logFields...)
 	case "ERROR", "FAILED":
-		s.logger.Error("Audit event - error", logFields...)
+		s.requestLogger(r).Error("Audit event - error", logFields...)
 	default:
-		s.logger.Info("Audit event", logFields...)
+		s.requestLogger(r).Info("Audit event", logFields...)
 	}
 }
 
@@ -406,5 +626,5 @@ func (s *Server) logSecurityEvent(r *http.Request, user *auth.User, eventType, m
 		}
 	}
 
-	s.logger.Warn("Security event", logFields...)
+	s.requestLogger(r).Warn("Security event", logFields...)
 }
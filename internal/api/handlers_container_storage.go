@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// containerStorage reports a container's ephemeral storage usage as collected
+// from the kubelet Summary API. Ephemeral usage is the writable layer plus logs.
+type containerStorage struct {
+	Namespace             string     `json:"namespace"`
+	Pod                   string     `json:"pod"`
+	Container             string     `json:"container"`
+	Node                  string     `json:"node"`
+	Available             bool       `json:"available"`
+	RootfsUsedBytes       int64      `json:"rootfsUsedBytes"`
+	LogsUsedBytes         int64      `json:"logsUsedBytes"`
+	EphemeralUsedBytes    int64      `json:"ephemeralUsedBytes"`
+	EphemeralLimitBytes   *int64     `json:"ephemeralLimitBytes,omitempty"`
+	EphemeralLimitPercent *float64   `json:"ephemeralLimitPercent,omitempty"`
+	CollectedAt           *time.Time `json:"collectedAt,omitempty"`
+}
+
+// findPodContainer returns the regular or init container with the given name
+func findPodContainer(pod *v1.Pod, name string) (*v1.Container, bool) {
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == name {
+			return &pod.Spec.Containers[i], true
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		if pod.Spec.InitContainers[i].Name == name {
+			return &pod.Spec.InitContainers[i], true
+		}
+	}
+	return nil, false
+}
+
+// latestSeriesPoint returns the most recent high-resolution point of a series
+func (s *Server) latestSeriesPoint(key string) (timeseries.Point, bool) {
+	if s.timeSeriesStore == nil {
+		return timeseries.Point{}, false
+	}
+	series, ok := s.timeSeriesStore.Get(key)
+	if !ok {
+		return timeseries.Point{}, false
+	}
+	points := series.GetAll(timeseries.Hi)
+	if len(points) == 0 {
+		return timeseries.Point{}, false
+	}
+	return points[len(points)-1], true
+}
+
+// buildContainerStorage combines the latest rootfs and log usage samples of a
+// container with its ephemeral-storage limit
+func (s *Server) buildContainerStorage(pod *v1.Pod, container *v1.Container) containerStorage {
+	storage := containerStorage{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Container: container.Name,
+		Node:      pod.Spec.NodeName,
+	}
+
+	rootfs, hasRootfs := s.latestSeriesPoint(timeseries.GenerateContainerSeriesKey(timeseries.ContainerRootFsUsedBase, pod.Namespace, pod.Name, container.Name))
+	logs, hasLogs := s.latestSeriesPoint(timeseries.GenerateContainerSeriesKey(timeseries.ContainerLogsUsedBase, pod.Namespace, pod.Name, container.Name))
+	if hasRootfs {
+		storage.RootfsUsedBytes = int64(rootfs.V)
+		collectedAt := rootfs.T
+		storage.CollectedAt = &collectedAt
+	}
+	if hasLogs {
+		storage.LogsUsedBytes = int64(logs.V)
+		if storage.CollectedAt == nil || logs.T.After(*storage.CollectedAt) {
+			collectedAt := logs.T
+			storage.CollectedAt = &collectedAt
+		}
+	}
+	storage.Available = hasRootfs || hasLogs
+	storage.EphemeralUsedBytes = storage.RootfsUsedBytes + storage.LogsUsedBytes
+
+	if limit, ok := container.Resources.Limits[v1.ResourceEphemeralStorage]; ok && limit.Value() > 0 {
+		limitBytes := limit.Value()
+		storage.EphemeralLimitBytes = &limitBytes
+		if storage.Available {
+			percent := float64(storage.EphemeralUsedBytes) / float64(limitBytes) * 100
+			storage.EphemeralLimitPercent = &percent
+		}
+	}
+
+	return storage
+}
+
+// handleGetContainerStorage handles GET /api/v1/pods/{namespace}/{name}/containers/{container}/storage
+// @Summary Get container storage usage
+// @Description Returns a container's writable layer (rootfs) and log usage as reported by the kubelet Summary API, and its ephemeral storage usage against any ephemeral-storage limit. available is false until the Summary API has been scraped for the container.
+// @Tags Pods
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param name path string true "Pod name"
+// @Param container path string true "Container name"
+// @Success 200 {object} map[string]interface{} "Container storage usage"
+// @Failure 404 {object} map[string]interface{} "Pod or container not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/pods/{namespace}/{name}/containers/{container}/storage [get]
+func (s *Server) handleGetContainerStorage(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+	containerName := chi.URLParam(r, "container")
+
+	writeError := func(status int, message string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":  message,
+			"status": "error",
+		})
+	}
+
+	pod, err := s.kubeClient.CoreV1().Pods(namespace).Get(r.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			writeError(http.StatusNotFound, err.Error())
+			return
+		}
+		s.requestLogger(r).Error("Failed to get pod for container storage",
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+		writeError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	container, ok := findPodContainer(pod, containerName)
+	if !ok {
+		writeError(http.StatusNotFound, "container "+containerName+" not found in pod "+namespace+"/"+name)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   s.buildContainerStorage(pod, container),
+		"status": "success",
+	})
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func containerStorageRequest(namespace, name, container string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods/"+namespace+"/"+name+"/containers/"+container+"/storage", nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("namespace", namespace)
+	routeCtx.URLParams.Add("name", name)
+	routeCtx.URLParams.Add("container", container)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+}
+
+func TestHandleGetContainerStorage(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+		Spec: v1.PodSpec{
+			NodeName: "node-a",
+			Containers: []v1.Container{{
+				Name: "app",
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse("10Mi")},
+				},
+			}},
+		},
+	}
+
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	now := time.Now()
+	store.Upsert(timeseries.GenerateContainerSeriesKey(timeseries.ContainerRootFsUsedBase, "shop", "web-0", "app")).Add(timeseries.NewPoint(now, 4<<20))
+	store.Upsert(timeseries.GenerateContainerSeriesKey(timeseries.ContainerLogsUsedBase, "shop", "web-0", "app")).Add(timeseries.NewPoint(now, 1<<20))
+
+	s := &Server{logger: zap.NewNop(), kubeClient: fake.NewSimpleClientset(pod), timeSeriesStore: store}
+
+	rec := httptest.NewRecorder()
+	s.handleGetContainerStorage(rec, containerStorageRequest("shop", "web-0", "app"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response struct {
+		Data containerStorage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	storage := response.Data
+	assert.True(t, storage.Available)
+	assert.Equal(t, "node-a", storage.Node)
+	assert.Equal(t, int64(4<<20), storage.RootfsUsedBytes)
+	assert.Equal(t, int64(1<<20), storage.LogsUsedBytes)
+	assert.Equal(t, int64(5<<20), storage.EphemeralUsedBytes)
+	require.NotNil(t, storage.EphemeralLimitBytes)
+	assert.Equal(t, int64(10<<20), *storage.EphemeralLimitBytes)
+	require.NotNil(t, storage.EphemeralLimitPercent)
+	assert.InDelta(t, 50.0, *storage.EphemeralLimitPercent, 0.001)
+
+	rec = httptest.NewRecorder()
+	s.handleGetContainerStorage(rec, containerStorageRequest("shop", "web-0", "missing"))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
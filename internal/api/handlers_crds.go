@@ -18,7 +18,7 @@ import (
 // @Tags CustomResourceDefinitions
 // @Produce json
 // @Param search query string false "Search term for CRD name"
-// @Param sortBy query string false "Sort by field (default: name)"
+// @Param sort query string false "Sort by field (default: name); sortBy is accepted as an alias"
 // @Param page query int false "Page number (default: 1)"
 // @Param pageSize query int false "Page size (default: 50, max: 100)"
 // @Param group query string false "Filter by API group"
@@ -34,7 +34,7 @@ func (s *Server) handleListCustomResourceDefinitions(w http.ResponseWriter, r *h
 	group := r.URL.Query().Get("group")
 	version := r.URL.Query().Get("version")
 	scope := r.URL.Query().Get("scope")
-	sortBy := r.URL.Query().Get("sortBy")
+	sortBy := getSortParam(r)
 	if sortBy == "" {
 		sortBy = "name"
 	}
@@ -56,7 +56,7 @@ func (s *Server) handleListCustomResourceDefinitions(w http.ResponseWriter, r *h
 	// List CRDs from Kubernetes API
 	crds, err := s.resourceManager.ListCustomResourceDefinitions(r.Context())
 	if err != nil {
-		s.logger.Error("Failed to list custom resource definitions", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list custom resource definitions", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -167,7 +167,7 @@ func (s *Server) handleGetCustomResourceDefinition(w http.ResponseWriter, r *htt
 	// Get CRD from Kubernetes API
 	crd, err := s.resourceManager.GetCustomResourceDefinition(r.Context(), name)
 	if err != nil {
-		s.logger.Error("Failed to get custom resource definition",
+		s.requestLogger(r).Error("Failed to get custom resource definition",
 			zap.String("name", name),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -179,11 +179,19 @@ func (s *Server) handleGetCustomResourceDefinition(w http.ResponseWriter, r *htt
 		return
 	}
 
+	crdMap, err := unstructuredObject(crd, "CustomResourceDefinition", "metadata", "spec", "status")
+	if err != nil {
+		s.requestLogger(r).Error("Malformed custom resource definition",
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	// Convert to enhanced summary
-	summary := s.crdToResponse(crd)
+	summary := s.crdToResponse(crdMap)
 
 	// Add full CRD spec for detailed view
-	crdMap := crd.(map[string]interface{})
 	fullDetails := map[string]interface{}{
 		"summary":    summary,
 		"spec":       crdMap["spec"],
@@ -203,14 +211,17 @@ func (s *Server) handleGetCustomResourceDefinition(w http.ResponseWriter, r *htt
 
 // crdToResponse converts a CRD object to response format
 func (s *Server) crdToResponse(crdObj interface{}) map[string]interface{} {
-	crd := crdObj.(map[string]interface{})
+	// Fields are read with checked assertions so a malformed CRD yields empty
+	// values rather than a panic
+	crd, _ := crdObj.(map[string]interface{})
 
 	// Extract metadata
-	metadata := crd["metadata"].(map[string]interface{})
-	name := metadata["name"].(string)
+	metadata, _ := crd["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
 
 	// Calculate age
-	creationTime, _ := time.Parse(time.RFC3339, metadata["creationTimestamp"].(string))
+	creationTimestamp, _ := metadata["creationTimestamp"].(string)
+	creationTime, _ := time.Parse(time.RFC3339, creationTimestamp)
 	age := time.Since(creationTime)
 
 	var ageStr string
@@ -225,24 +236,26 @@ func (s *Server) crdToResponse(crdObj interface{}) map[string]interface{} {
 	}
 
 	// Extract spec information
-	spec := crd["spec"].(map[string]interface{})
-	group := spec["group"].(string)
-	scope := spec["scope"].(string)
+	spec, _ := crd["spec"].(map[string]interface{})
+	group, _ := spec["group"].(string)
+	scope, _ := spec["scope"].(string)
 
 	// Extract kind information
-	names := spec["names"].(map[string]interface{})
-	kind := names["kind"].(string)
-	plural := names["plural"].(string)
-	singular := names["singular"].(string)
+	names, _ := spec["names"].(map[string]interface{})
+	kind, _ := names["kind"].(string)
+	plural, _ := names["plural"].(string)
+	singular, _ := names["singular"].(string)
 
 	// Extract versions
 	var versions []string
 	var storedVersions []string
-	if versionsInterface, ok := spec["versions"]; ok {
-		versionsList := versionsInterface.([]interface{})
+	if versionsList, ok := spec["versions"].([]interface{}); ok {
 		for _, v := range versionsList {
-			versionMap := v.(map[string]interface{})
-			versionName := versionMap["name"].(string)
+			versionMap, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			versionName, _ := versionMap["name"].(string)
 			versions = append(versions, versionName)
 			if stored, ok := versionMap["storage"].(bool); ok && stored {
 				storedVersions = append(storedVersions, versionName)
@@ -252,14 +265,15 @@ func (s *Server) crdToResponse(crdObj interface{}) map[string]interface{} {
 
 	// Extract status information
 	var establishedCondition, namesAcceptedCondition bool
-	if status, ok := crd["status"]; ok && status != nil {
-		statusMap := status.(map[string]interface{})
-		if conditions, ok := statusMap["conditions"]; ok && conditions != nil {
-			conditionsList := conditions.([]interface{})
+	if statusMap, ok := crd["status"].(map[string]interface{}); ok {
+		if conditionsList, ok := statusMap["conditions"].([]interface{}); ok {
 			for _, c := range conditionsList {
-				conditionMap := c.(map[string]interface{})
-				condType := conditionMap["type"].(string)
-				condStatus := conditionMap["status"].(string)
+				conditionMap, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				condType, _ := conditionMap["type"].(string)
+				condStatus, _ := conditionMap["status"].(string)
 
 				if condType == "Established" && condStatus == "True" {
 					establishedCondition = true
@@ -30,7 +30,7 @@ func (s *Server) handleCSRFToken(w http.ResponseWriter, r *http.Request) {
 	// Verify user is authenticated
 	user, ok := auth.UserFromContext(r.Context())
 	if !ok || user == nil {
-		s.logger.Warn("CSRF token request without authentication")
+		s.requestLogger(r).Warn("CSRF token request without authentication")
 		http.Error(w, "Authentication required", http.StatusUnauthorized)
 		return
 	}
@@ -38,7 +38,7 @@ func (s *Server) handleCSRFToken(w http.ResponseWriter, r *http.Request) {
 	// Generate new CSRF token
 	token, err := generateCSRFToken()
 	if err != nil {
-		s.logger.Error("Failed to generate CSRF token", zap.Error(err))
+		s.requestLogger(r).Error("Failed to generate CSRF token", zap.Error(err))
 		http.Error(w, "Failed to generate token", http.StatusInternalServerError)
 		return
 	}
@@ -55,12 +55,12 @@ func (s *Server) handleCSRFToken(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		s.logger.Error("Failed to encode CSRF token response", zap.Error(err))
+		s.requestLogger(r).Error("Failed to encode CSRF token response", zap.Error(err))
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		return
 	}
 
-	s.logger.Debug("CSRF token generated",
+	s.requestLogger(r).Debug("CSRF token generated",
 		zap.String("userId", user.ID),
 		zap.String("tokenPrefix", token[:8]+"..."))
 }
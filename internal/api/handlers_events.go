@@ -8,11 +8,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aaronlmathis/kaptn/internal/k8s/analysis"
 	"github.com/aaronlmathis/kaptn/internal/k8s/selectors"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 )
 
 // handleGetEvent handles GET /api/v1/namespaces/{namespace}/events/{name}
@@ -43,7 +46,7 @@ func (s *Server) handleGetEvent(w http.ResponseWriter, r *http.Request) {
 	// Get event from Kubernetes API
 	event, err := s.kubeClient.CoreV1().Events(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get event",
+		s.requestLogger(r).Error("Failed to get event",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -87,23 +90,30 @@ func (s *Server) handleGetEvent(w http.ResponseWriter, r *http.Request) {
 
 // handleListEvents handles GET /api/v1/events
 // @Summary List Events
-// @Description Lists all Events in the cluster or a specific namespace, with optional filtering, sorting, and pagination.
+// @Description Lists all Events in the cluster or a specific namespace, with optional filtering, sorting, and pagination. The type, reason, involvedObject and fieldSelector filters are combined into one field selector evaluated by the API server.
 // @Tags Events
 // @Produce json
 // @Param namespace query string false "Namespace to filter by (empty for all namespaces)"
 // @Param search query string false "Search term for Event name or message"
-// @Param sortBy query string false "Sort by field (default: lastTimestamp)"
+// @Param type query string false "Event type: Normal or Warning"
+// @Param reason query string false "Event reason, e.g. BackOff"
+// @Param involvedObject query string false "Object the events are about, written as kind/name, e.g. Pod/web-0"
+// @Param fieldSelector query string false "Field selector on Event fields, e.g. involvedObject.uid=..."
+// @Param sort query string false "Sort by field (default: lastTimestamp); sortBy is accepted as an alias"
 // @Param sortOrder query string false "Sort order: asc or desc (default: desc)"
 // @Param page query int false "Page number (default: 1)"
 // @Param pageSize query int false "Page size (default: 50, max: 100)"
+// @Param limit query int false "Server-side page size (max: 1000); lists one page from the API server instead of all events"
+// @Param continue query string false "Continue token returned with the previous server-side page"
 // @Success 200 {object} map[string]interface{} "Paginated list of Events"
 // @Failure 400 {string} string "Bad request"
+// @Failure 410 {object} map[string]interface{} "Continue token expired"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/events [get]
 func (s *Server) handleListEvents(w http.ResponseWriter, r *http.Request) {
 	namespace := r.URL.Query().Get("namespace")
 	search := strings.TrimSpace(r.URL.Query().Get("search"))
-	sortBy := r.URL.Query().Get("sortBy")
+	sortBy := getSortParam(r)
 	sortOrder := r.URL.Query().Get("sortOrder")
 
 	// Parse pagination parameters
@@ -130,10 +140,36 @@ func (s *Server) handleListEvents(w http.ResponseWriter, r *http.Request) {
 		sortOrder = "desc"
 	}
 
-	// Get events from ResourceManager
-	events, err := s.resourceManager.ListEvents(r.Context(), namespace)
+	fieldSelector, err := eventFieldSelector(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, continueToken, paged, err := listPageParams(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if paged {
+		s.writeEventPage(w, r, namespace, fieldSelector, limit, continueToken, selectors.EventFilterOptions{
+			Namespace:     namespace,
+			FieldSelector: fieldSelector,
+			Search:        search,
+			Sort:          sortBy,
+			SortOrder:     sortOrder,
+		})
+		return
+	}
+
+	// Get events from ResourceManager, narrowed by the API server
+	eventPage, err := s.resourceManager.ListEventsMatching(r.Context(), namespace, fieldSelector, 0, "")
+	if apierrors.IsBadRequest(err) {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
-		s.logger.Error("Failed to list events", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list events", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -149,23 +185,27 @@ func (s *Server) handleListEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	events := eventPage.Items
+
 	// Store total count before filtering
 	totalBeforeFilter := len(events)
 
-	// Apply filtering and pagination
+	// Apply filtering and pagination. The field selector is applied again in
+	// case the API server ignored it.
 	filterOptions := selectors.EventFilterOptions{
-		Namespace: namespace,
-		Search:    search,
-		Sort:      sortBy,
-		SortOrder: sortOrder,
-		Page:      page,
-		PageSize:  pageSize,
+		Namespace:     namespace,
+		FieldSelector: fieldSelector,
+		Search:        search,
+		Sort:          sortBy,
+		SortOrder:     sortOrder,
+		Page:          page,
+		PageSize:      pageSize,
 	}
 
 	filteredEvents, err := selectors.FilterEvents(events, filterOptions)
 	if err != nil {
-		s.logger.Error("Failed to filter events", zap.Error(err))
-		http.Error(w, "Failed to filter events", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter events", zap.Error(err))
+		http.Error(w, "Failed to filter events: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -196,8 +236,11 @@ func (s *Server) handleListEvents(w http.ResponseWriter, r *http.Request) {
 // @Tags Events
 // @Produce json
 // @Param namespace path string true "Namespace"
+// @Param limit query int false "Server-side page size (max: 1000)"
+// @Param continue query string false "Continue token returned with the previous page"
 // @Success 200 {array} map[string]interface{} "List of Events"
 // @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 410 {object} map[string]interface{} "Continue token expired"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/namespaces/{namespace}/events [get]
 func (s *Server) handleListEventsInNamespace(w http.ResponseWriter, r *http.Request) {
@@ -215,9 +258,19 @@ func (s *Server) handleListEventsInNamespace(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	limit, continueToken, paged, err := listPageParams(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if paged {
+		s.writeEventPage(w, r, namespace, "", limit, continueToken, selectors.EventFilterOptions{})
+		return
+	}
+
 	events, err := s.resourceManager.ListEvents(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list events",
+		s.requestLogger(r).Error("Failed to list events",
 			zap.String("namespace", namespace),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -248,6 +301,136 @@ func (s *Server) handleListEventsInNamespace(w http.ResponseWriter, r *http.Requ
 	})
 }
 
+// eventFieldSelector combines the type, reason and involvedObject filters of
+// an events request with its fieldSelector parameter, so the API server can
+// select the events instead of returning all of them
+func eventFieldSelector(r *http.Request) (string, error) {
+	query := r.URL.Query()
+	set := fields.Set{}
+	if eventType := query.Get("type"); eventType != "" {
+		if eventType != v1.EventTypeNormal && eventType != v1.EventTypeWarning {
+			return "", fmt.Errorf("type must be %s or %s", v1.EventTypeNormal, v1.EventTypeWarning)
+		}
+		set["type"] = eventType
+	}
+	if reason := query.Get("reason"); reason != "" {
+		set["reason"] = reason
+	}
+	if involvedObject := query.Get("involvedObject"); involvedObject != "" {
+		kind, name, ok := strings.Cut(involvedObject, "/")
+		if !ok || kind == "" || name == "" || strings.Contains(name, "/") {
+			return "", fmt.Errorf("involvedObject must be written as kind/name, got %q", involvedObject)
+		}
+		set["involvedObject.kind"] = kind
+		set["involvedObject.name"] = name
+	}
+
+	var terms []string
+	if len(set) > 0 {
+		terms = append(terms, set.AsSelector().String())
+	}
+	if selector := strings.TrimSpace(query.Get("fieldSelector")); selector != "" {
+		terms = append(terms, selector)
+	}
+	return strings.Join(terms, ","), nil
+}
+
+// writeEventPage lists one server-side page of events matching fieldSelector
+// and writes it with the token for the next page. Filtering and sorting apply
+// within the page only.
+func (s *Server) writeEventPage(w http.ResponseWriter, r *http.Request, namespace, fieldSelector string, limit int64, continueToken string, filterOptions selectors.EventFilterOptions) {
+	page, err := s.resourceManager.ListEventsMatching(r.Context(), namespace, fieldSelector, limit, continueToken)
+	if err != nil {
+		s.writeListPageError(w, r, "events", namespace, err)
+		return
+	}
+
+	events, err := selectors.FilterEvents(page.Items, filterOptions)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Failed to filter events: "+err.Error())
+		return
+	}
+
+	responseItems := []map[string]interface{}{}
+	for _, event := range events {
+		responseItems = append(responseItems, s.eventToResponse(event))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   listPageData(responseItems, limit, page.Continue, page.RemainingItemCount),
+		"status": "success",
+	})
+}
+
+// handleGetObjectEvents handles GET /api/v1/{kind}/{namespace}/{name}/events
+// @Summary Get events of an object
+// @Description Lists the Events whose involvedObject is the given object, most recent first. Each event carries its count and first and last timestamps.
+// @Tags Events
+// @Produce json
+// @Param kind path string true "Resource kind, e.g. pods or deployments"
+// @Param namespace path string true "Namespace"
+// @Param name path string true "Object name"
+// @Success 200 {object} map[string]interface{} "Events of the object"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/{kind}/{namespace}/{name}/events [get]
+func (s *Server) handleGetObjectEvents(w http.ResponseWriter, r *http.Request) {
+	resource, err := analysis.ParseRelationshipKind(chi.URLParam(r, "kind"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	kind := analysis.RelationshipResourceKind(resource)
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	fieldSelector := fields.Set{
+		"involvedObject.kind":      kind,
+		"involvedObject.namespace": namespace,
+		"involvedObject.name":      name,
+	}.AsSelector().String()
+	page, err := s.resourceManager.ListEventsMatching(r.Context(), namespace, fieldSelector, 0, "")
+	if err != nil {
+		s.requestLogger(r).Error("Failed to list events of object",
+			zap.String("kind", kind),
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	events, err := selectors.FilterEvents(page.Items, selectors.EventFilterOptions{
+		FieldSelector: fieldSelector,
+		Sort:          "lastTimestamp",
+		SortOrder:     "desc",
+	})
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responseItems := []map[string]interface{}{}
+	for _, event := range events {
+		responseItems = append(responseItems, s.eventToResponse(event))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{
+			"kind":      kind,
+			"namespace": namespace,
+			"name":      name,
+			"items":     responseItems,
+			"total":     len(responseItems),
+		},
+		"status": "success",
+	})
+}
+
 // eventToResponse converts a Kubernetes Event to the response format
 func (s *Server) eventToResponse(event v1.Event) map[string]interface{} {
 	age := ""
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newEventsServer returns a server whose event listing answers with list,
+// or with err when it is set
+func newEventsServer(list *v1.EventList, err error) *Server {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if err != nil {
+			return true, nil, err
+		}
+		return true, list, nil
+	})
+	return &Server{
+		logger:          zap.NewNop(),
+		config:          &config.Config{},
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+}
+
+func TestHandleListEventsPaged(t *testing.T) {
+	remaining := int64(40)
+	list := &v1.EventList{
+		ListMeta: metav1.ListMeta{Continue: "next-page", RemainingItemCount: &remaining},
+		Items: []v1.Event{
+			{ObjectMeta: metav1.ObjectMeta{Name: "pulled", Namespace: "shop"}, Reason: "Pulled"},
+			{ObjectMeta: metav1.ObjectMeta{Name: "backoff", Namespace: "shop"}, Reason: "BackOff"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		handler func(*Server) http.HandlerFunc
+	}{
+		{"cluster", "/api/v1/events?limit=2", func(s *Server) http.HandlerFunc { return s.handleListEvents }},
+		{"namespace", "/api/v1/namespaces/shop/events?limit=2&continue=abc", func(s *Server) http.HandlerFunc { return s.handleListEventsInNamespace }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newEventsServer(list, nil)
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("namespace", "shop")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+			tt.handler(s)(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			var response struct {
+				Data struct {
+					Items              []map[string]interface{} `json:"items"`
+					Continue           string                   `json:"continue"`
+					RemainingItemCount int64                    `json:"remainingItemCount"`
+					Limit              int64                    `json:"limit"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Len(t, response.Data.Items, 2)
+			assert.Equal(t, "next-page", response.Data.Continue)
+			assert.Equal(t, int64(40), response.Data.RemainingItemCount)
+			assert.Equal(t, int64(2), response.Data.Limit)
+		})
+	}
+}
+
+func TestHandleListEventsPagedErrors(t *testing.T) {
+	s := newEventsServer(nil, nil)
+	for _, target := range []string{"/api/v1/events?limit=0", "/api/v1/events?limit=5000", "/api/v1/events?limit=ten"} {
+		rec := httptest.NewRecorder()
+		s.handleListEvents(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+
+	s = newEventsServer(nil, apierrors.NewResourceExpired("continue token too old"))
+	rec := httptest.NewRecorder()
+	s.handleListEvents(rec, httptest.NewRequest(http.MethodGet, "/api/v1/events?continue=stale", nil))
+	assert.Equal(t, http.StatusGone, rec.Code)
+	assert.Contains(t, rec.Body.String(), "continue token expired")
+}
+
+func TestHandleListEventsWithoutLimitListsAll(t *testing.T) {
+	s := newEventsServer(&v1.EventList{Items: []v1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "shop"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "shop"}},
+	}}, nil)
+	rec := httptest.NewRecorder()
+	s.handleListEvents(rec, httptest.NewRequest(http.MethodGet, "/api/v1/events", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"total":2`)
+	assert.NotContains(t, rec.Body.String(), `"continue"`)
+}
+
+func TestHandleListEventsFilters(t *testing.T) {
+	now := time.Now()
+	events := []v1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-0.backoff", Namespace: "shop"}, Type: v1.EventTypeWarning, Reason: "BackOff",
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "web-0"}, LastTimestamp: metav1.NewTime(now)},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-0.pulled", Namespace: "shop"}, Type: v1.EventTypeNormal, Reason: "Pulled",
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "web-0"}, LastTimestamp: metav1.NewTime(now)},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1.backoff", Namespace: "shop"}, Type: v1.EventTypeWarning, Reason: "BackOff",
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "web-1"}, LastTimestamp: metav1.NewTime(now)},
+	}
+
+	tests := []struct {
+		query    string
+		selector string
+		names    []string
+	}{
+		{"type=Warning", "type=Warning", []string{"web-0.backoff", "web-1.backoff"}},
+		{"reason=Pulled", "reason=Pulled", []string{"web-0.pulled"}},
+		{"type=Warning&involvedObject=Pod/web-0", "involvedObject.kind=Pod,involvedObject.name=web-0,type=Warning", []string{"web-0.backoff"}},
+		{"involvedObject=Pod/web-1&fieldSelector=reason%3DBackOff", "involvedObject.kind=Pod,involvedObject.name=web-1,reason=BackOff", []string{"web-1.backoff"}},
+		{"limit=10&reason=BackOff", "reason=BackOff", []string{"web-0.backoff", "web-1.backoff"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var selector string
+			client := fake.NewSimpleClientset()
+			// The fake client ignores field selectors, so every event is returned
+			client.PrependReactor("list", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				selector = action.(k8stesting.ListAction).GetListRestrictions().Fields.String()
+				return true, &v1.EventList{Items: events}, nil
+			})
+			s := &Server{logger: zap.NewNop(), config: &config.Config{}, kubeClient: client, resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil)}
+
+			rec := httptest.NewRecorder()
+			s.handleListEvents(rec, httptest.NewRequest(http.MethodGet, "/api/v1/events?sort=name&sortOrder=asc&"+tt.query, nil))
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.Equal(t, tt.selector, selector)
+
+			var response struct {
+				Data struct {
+					Items []struct {
+						Name string `json:"name"`
+					} `json:"items"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			names := []string{}
+			for _, item := range response.Data.Items {
+				names = append(names, item.Name)
+			}
+			assert.Equal(t, tt.names, names)
+		})
+	}
+
+	s := newEventsServer(&v1.EventList{}, nil)
+	for _, query := range []string{"type=Error", "involvedObject=web-0", "involvedObject=Pod/", "fieldSelector=spec.foo%3Dbar"} {
+		rec := httptest.NewRecorder()
+		s.handleListEvents(rec, httptest.NewRequest(http.MethodGet, "/api/v1/events?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func TestHandleGetObjectEvents(t *testing.T) {
+	now := time.Now()
+	s := newEventsServer(&v1.EventList{Items: []v1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "older", Namespace: "shop"}, Reason: "ScalingReplicaSet", Count: 1,
+			InvolvedObject: v1.ObjectReference{Kind: "Deployment", Namespace: "shop", Name: "web"},
+			FirstTimestamp: metav1.NewTime(now.Add(-time.Hour)), LastTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+		{ObjectMeta: metav1.ObjectMeta{Name: "newer", Namespace: "shop"}, Reason: "ScalingReplicaSet", Count: 3,
+			InvolvedObject: v1.ObjectReference{Kind: "Deployment", Namespace: "shop", Name: "web"},
+			FirstTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)), LastTimestamp: metav1.NewTime(now)},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "shop"}, Reason: "Pulled",
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "web"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "shop"}, Reason: "ScalingReplicaSet",
+			InvolvedObject: v1.ObjectReference{Kind: "Deployment", Namespace: "shop", Name: "api"}},
+	}}, nil)
+	router := chi.NewRouter()
+	router.Get("/api/v1/{kind}/{namespace}/{name}/events", s.handleGetObjectEvents)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/deploy/shop/web/events", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response struct {
+		Data struct {
+			Kind  string `json:"kind"`
+			Total int    `json:"total"`
+			Items []struct {
+				Name           string    `json:"name"`
+				Count          int32     `json:"count"`
+				FirstTimestamp time.Time `json:"firstTimestamp"`
+				LastTimestamp  time.Time `json:"lastTimestamp"`
+			} `json:"items"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "Deployment", response.Data.Kind)
+	assert.Equal(t, 2, response.Data.Total)
+	require.Len(t, response.Data.Items, 2)
+	assert.Equal(t, "newer", response.Data.Items[0].Name)
+	assert.Equal(t, int32(3), response.Data.Items[0].Count)
+	assert.WithinDuration(t, now.Add(-2*time.Hour), response.Data.Items[0].FirstTimestamp, time.Second)
+	assert.WithinDuration(t, now, response.Data.Items[0].LastTimestamp, time.Second)
+	assert.Equal(t, "older", response.Data.Items[1].Name)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/widgets/shop/web/events", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
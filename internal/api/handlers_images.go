@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/images"
+	v1 "k8s.io/api/core/v1"
+)
+
+// handleListImages handles GET /api/v1/images
+// @Summary List container images in use
+// @Description Returns a deduplicated inventory of every container image referenced by pods in the cluster, with pod/container counts, namespaces and resolved digests from container statuses. Results are built from the pod informer and cached briefly.
+// @Tags Images
+// @Produce json
+// @Param search query string false "Case-insensitive image substring"
+// @Success 200 {object} map[string]interface{} "Image inventory"
+// @Failure 503 {object} map[string]interface{} "Pod informer unavailable"
+// @Router /api/v1/images [get]
+func (s *Server) handleListImages(w http.ResponseWriter, r *http.Request) {
+	if s.informerManager == nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":  "pod informer is not available",
+			"status": "error",
+		})
+		return
+	}
+
+	var inventory []images.ImageUsage
+	if s.imageInventory != nil {
+		inventory = s.imageInventory.Get(s.listInformerPods)
+	} else {
+		inventory = images.BuildInventory(s.listInformerPods())
+	}
+	inventory = images.FilterInventory(inventory, r.URL.Query().Get("search"))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{
+			"items": inventory,
+			"total": len(inventory),
+		},
+		"status": "success",
+	})
+}
+
+// listInformerPods returns every pod in the informer cache
+func (s *Server) listInformerPods() []*v1.Pod {
+	objs := s.informerManager.GetPodLister().List()
+	pods := make([]*v1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		if pod, ok := obj.(*v1.Pod); ok {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/images"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListImagesAggregatesAcrossPods(t *testing.T) {
+	s := newPodListTestServer(t,
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "nginx", Image: "nginx:1.25"}}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "staging"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "nginx", Image: "nginx:1.25"}, {Name: "redis", Image: "redis:7"}}},
+		},
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/images?search=nginx", nil)
+	rec := httptest.NewRecorder()
+	s.handleListImages(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp struct {
+		Data struct {
+			Items []images.ImageUsage `json:"items"`
+			Total int                 `json:"total"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, 1, resp.Data.Total)
+	assert.Equal(t, "nginx:1.25", resp.Data.Items[0].Image)
+	assert.Equal(t, 2, resp.Data.Items[0].Pods)
+	assert.Equal(t, []string{"default", "staging"}, resp.Data.Items[0].Namespaces)
+}
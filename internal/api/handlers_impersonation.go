@@ -22,7 +22,7 @@ func (s *Server) handleSSARTest(w http.ResponseWriter, r *http.Request) {
 	// Get impersonated clients
 	clients, err := s.GetImpersonatedClients(r)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated clients", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get impersonated clients", zap.Error(err))
 		http.Error(w, "Impersonated clients not available", http.StatusInternalServerError)
 		return
 	}
@@ -40,7 +40,7 @@ func (s *Server) handleSSARTest(w http.ResponseWriter, r *http.Request) {
 
 	results, err := ssarHelper.CheckMultiplePermissions(r.Context(), clients.Client(), permissions)
 	if err != nil {
-		s.logger.Error("Failed to check permissions", zap.Error(err))
+		s.requestLogger(r).Error("Failed to check permissions", zap.Error(err))
 		http.Error(w, "Failed to check permissions", http.StatusInternalServerError)
 		return
 	}
@@ -165,7 +165,7 @@ func (s *Server) handleListVirtualServices(w http.ResponseWriter, r *http.Reques
 	}
 
 	if err != nil {
-		s.handleIstioError(w, "Failed to list VirtualServices", err)
+		s.handleIstioError(w, r, "Failed to list VirtualServices", err)
 		return
 	}
 
@@ -216,7 +216,7 @@ func (s *Server) handleGetVirtualService(w http.ResponseWriter, r *http.Request)
 
 	obj, err := s.dynamicClient.Resource(virtualServiceGVR).Namespace(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.handleIstioError(w, "Failed to get VirtualService", err)
+		s.handleIstioError(w, r, "Failed to get VirtualService", err)
 		return
 	}
 
@@ -269,13 +269,13 @@ func (s *Server) handleGetVirtualServiceYAML(w http.ResponseWriter, r *http.Requ
 
 	obj, err := s.dynamicClient.Resource(virtualServiceGVR).Namespace(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.handleIstioError(w, "Failed to get VirtualService", err)
+		s.handleIstioError(w, r, "Failed to get VirtualService", err)
 		return
 	}
 
 	yamlBytes, err := yaml.Marshal(obj.Object)
 	if err != nil {
-		s.logger.Error("Failed to marshal VirtualService to YAML",
+		s.requestLogger(r).Error("Failed to marshal VirtualService to YAML",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -329,7 +329,7 @@ func (s *Server) handleListGateways(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		s.handleIstioError(w, "Failed to list Gateways", err)
+		s.handleIstioError(w, r, "Failed to list Gateways", err)
 		return
 	}
 
@@ -380,7 +380,7 @@ func (s *Server) handleGetGateway(w http.ResponseWriter, r *http.Request) {
 
 	obj, err := s.dynamicClient.Resource(gatewayGVR).Namespace(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.handleIstioError(w, "Failed to get Gateway", err)
+		s.handleIstioError(w, r, "Failed to get Gateway", err)
 		return
 	}
 
@@ -433,13 +433,13 @@ func (s *Server) handleGetGatewayYAML(w http.ResponseWriter, r *http.Request) {
 
 	obj, err := s.dynamicClient.Resource(gatewayGVR).Namespace(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.handleIstioError(w, "Failed to get Gateway", err)
+		s.handleIstioError(w, r, "Failed to get Gateway", err)
 		return
 	}
 
 	yamlBytes, err := yaml.Marshal(obj.Object)
 	if err != nil {
-		s.logger.Error("Failed to marshal Gateway to YAML",
+		s.requestLogger(r).Error("Failed to marshal Gateway to YAML",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -564,8 +564,8 @@ func (s *Server) gatewayToResponse(obj *unstructured.Unstructured) map[string]in
 }
 
 // handleIstioError handles Istio-related errors and sends appropriate HTTP responses
-func (s *Server) handleIstioError(w http.ResponseWriter, message string, err error) {
-	s.logger.Error(message, zap.Error(err))
+func (s *Server) handleIstioError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	s.requestLogger(r).Error(message, zap.Error(err))
 
 	status := http.StatusInternalServerError
 	errorMessage := err.Error()
@@ -0,0 +1,361 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	apimiddleware "github.com/aaronlmathis/kaptn/internal/middleware"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// kubeWatchBuffer is how many events may queue for a Kubernetes-style watch
+// before the client is considered too slow and the stream is ended. Clients
+// such as kubectl and client-go reflectors re-establish ended watches.
+const kubeWatchBuffer = 256
+
+// cachedResourceKinds maps the resources served from the informer cache to
+// their group, version and kind, keyed by the lower-case plural resource name
+var cachedResourceKinds = map[string]schema.GroupVersionKind{
+	"pods":                   {Version: "v1", Kind: "Pod"},
+	"nodes":                  {Version: "v1", Kind: "Node"},
+	"namespaces":             {Version: "v1", Kind: "Namespace"},
+	"services":               {Version: "v1", Kind: "Service"},
+	"configmaps":             {Version: "v1", Kind: "ConfigMap"},
+	"secrets":                {Version: "v1", Kind: "Secret"},
+	"persistentvolumeclaims": {Version: "v1", Kind: "PersistentVolumeClaim"},
+	"deployments":            {Group: "apps", Version: "v1", Kind: "Deployment"},
+	"statefulsets":           {Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	"daemonsets":             {Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	"replicasets":            {Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+	"jobs":                   {Group: "batch", Version: "v1", Kind: "Job"},
+	"cronjobs":               {Group: "batch", Version: "v1", Kind: "CronJob"},
+	"ingresses":              {Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+}
+
+// cachedResourceKind resolves the group, version and resource of a request
+// path to a cached resource. The core group is written as "core".
+func cachedResourceKind(group, version, resource string) (schema.GroupVersionKind, bool) {
+	if group == "core" {
+		group = ""
+	}
+	gvk, ok := cachedResourceKinds[resource]
+	if !ok || gvk.Group != group || gvk.Version != version {
+		return schema.GroupVersionKind{}, false
+	}
+	return gvk, true
+}
+
+// withTypeMeta returns a copy of an informer object with its apiVersion and
+// kind set, since typed informers strip them. ok is false for tombstones and
+// other objects that are not API objects.
+func withTypeMeta(obj interface{}, gvk schema.GroupVersionKind) (runtime.Object, bool) {
+	if tombstone, isTombstone := obj.(cache.DeletedFinalStateUnknown); isTombstone {
+		obj = tombstone.Obj
+	}
+	object, ok := obj.(runtime.Object)
+	if !ok {
+		return nil, false
+	}
+	object = object.DeepCopyObject()
+	object.GetObjectKind().SetGroupVersionKind(gvk)
+	return object, true
+}
+
+// stripSecretValues removes the values of a Secret, typed or unstructured, in
+// place, as the secret endpoints do without includeData. The last-applied
+// configuration goes too since it repeats them.
+func stripSecretValues(object runtime.Object) {
+	switch secret := object.(type) {
+	case *v1.Secret:
+		secret.Data = nil
+		secret.StringData = nil
+		if _, ok := secret.Annotations[lastAppliedAnnotation]; ok {
+			annotations := make(map[string]string, len(secret.Annotations))
+			for key, value := range secret.Annotations {
+				if key != lastAppliedAnnotation {
+					annotations[key] = value
+				}
+			}
+			secret.Annotations = annotations
+		}
+	case *unstructured.Unstructured:
+		delete(secret.Object, "data")
+		delete(secret.Object, "stringData")
+		unstructured.RemoveNestedField(secret.Object, "metadata", "annotations", lastAppliedAnnotation)
+	}
+}
+
+// kubeWatchFilter selects the objects of a list or watch by namespace and
+// label selector
+type kubeWatchFilter struct {
+	namespace string
+	selector  labels.Selector
+}
+
+func (f kubeWatchFilter) matches(obj runtime.Object) bool {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	if f.namespace != "" && accessor.GetNamespace() != f.namespace {
+		return false
+	}
+	return f.selector.Matches(labels.Set(accessor.GetLabels()))
+}
+
+// handleKubeStyleResource handles GET /api/v1/resources/{group}/{version}/{resource}
+// @Summary List or watch a resource, Kubernetes-style
+// @Description Lists a cached resource as a Kubernetes List object. Resources without an informer, such as custom resources, and requests with limit or continue are listed from the API server in chunks instead; the token for the next chunk is returned in metadata.continue. With watch=true the response is instead a stream of newline-delimited metav1.WatchEvent objects ({"type":"ADDED|MODIFIED|DELETED","object":{...}}) from the informer cache, so Kubernetes-aware clients can consume it. Serving from the cache requires the caller's list or watch permission on the resource. Secrets are returned without their values. A watch starts with an ADDED event per existing object unless a non-zero resourceVersion is given. Use "core" as the group of core resources such as pods.
+// @Tags Resources
+// @Produce json
+// @Param group path string true "API group, or core"
+// @Param version path string true "API version"
+// @Param resource path string true "Lower-case plural resource name, e.g. pods"
+// @Param namespace query string false "Limit to a namespace"
+// @Param labelSelector query string false "Label selector"
+// @Param limit query int false "List at most this many objects from the API server (max: 1000)"
+// @Param continue query string false "Continue token returned in metadata.continue of the previous chunk"
+// @Param watch query bool false "Stream watch events instead of listing"
+// @Param resourceVersion query string false "With watch, a non-zero value skips the initial ADDED events"
+// @Param timeoutSeconds query int false "With watch, end the stream after this many seconds"
+// @Success 200 {object} map[string]interface{} "List, or a stream of watch events"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters"
+// @Failure 403 {object} map[string]interface{} "Listing forbidden for the caller"
+// @Failure 404 {object} map[string]interface{} "Resource not served from the cache"
+// @Failure 410 {object} map[string]interface{} "Continue token expired"
+// @Failure 503 {object} map[string]interface{} "Informer cache not ready"
+// @Router /api/v1/resources/{group}/{version}/{resource} [get]
+func (s *Server) handleKubeStyleResource(w http.ResponseWriter, r *http.Request) {
+	group := chi.URLParam(r, "group")
+	version := chi.URLParam(r, "version")
+	resource := chi.URLParam(r, "resource")
+
+	query := r.URL.Query()
+	watching := apimiddleware.IsWatchRequest(r)
+	gvk, cached := cachedResourceKind(group, version, resource)
+
+	// ConfigMaps are redacted as on the ConfigMap endpoints, and Secrets are
+	// listed without their values
+	sanitize := func(runtime.Object) {}
+	if group == "core" && resource == "configmaps" {
+		redactor, ok := s.configMapRedactorFor(w, r, query.Get("namespace"))
+		if !ok {
+			return
+		}
+		sanitize = redactor.redactObject
+	}
+	if group == "core" && resource == "secrets" {
+		sanitize = stripSecretValues
+	}
+
+	// The informer cache can neither page nor hold every resource, so those
+	// lists are chunked by the API server
+	limit, continueToken, paged, err := listPageParams(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !watching && (paged || !cached) && s.resourceManager != nil {
+		if group == "core" {
+			group = ""
+		}
+		gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+		s.writeResourcePage(w, r, gvr, query.Get("namespace"), query.Get("labelSelector"), limit, continueToken, sanitize)
+		return
+	}
+
+	if !cached {
+		writeJSONError(w, http.StatusNotFound, fmt.Sprintf("resource %s/%s/%s is not served from the cache", group, version, resource))
+		return
+	}
+
+	selector, err := labels.Parse(query.Get("labelSelector"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("Invalid label selector: %v", err))
+		return
+	}
+	filter := kubeWatchFilter{namespace: query.Get("namespace"), selector: selector}
+
+	// The cache is read with the server's identity, so the caller's own
+	// permission is checked first
+	verb := "list"
+	if watching {
+		verb = "watch"
+	}
+	if !s.requireResourcePermission(w, r, verb, gvk.Group, resource, filter.namespace) {
+		return
+	}
+
+	if s.informerManager == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Informer cache not available")
+		return
+	}
+	informer, ok := s.informerManager.ResourceInformer(resource)
+	if !ok {
+		writeJSONError(w, http.StatusServiceUnavailable, "Informer cache for "+resource+" has not synced yet")
+		return
+	}
+
+	if !watching {
+		s.writeKubeStyleList(w, informer, gvk, filter, sanitize)
+		return
+	}
+
+	var timeout <-chan time.Time
+	if raw := query.Get("timeoutSeconds"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds <= 0 {
+			writeJSONError(w, http.StatusBadRequest, "Invalid timeoutSeconds parameter. Must be a positive integer")
+			return
+		}
+		timer := time.NewTimer(time.Duration(seconds) * time.Second)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	skipInitial := query.Get("resourceVersion") != "" && query.Get("resourceVersion") != "0"
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeJSONError(w, http.StatusInternalServerError, "Streaming not supported")
+		return
+	}
+
+	events := make(chan metav1.WatchEvent, kubeWatchBuffer)
+	overflow := make(chan struct{})
+	var overflowOnce sync.Once
+	enqueue := func(eventType watch.EventType, obj interface{}) {
+		object, ok := withTypeMeta(obj, gvk)
+		if !ok || !filter.matches(object) {
+			return
+		}
+		sanitize(object)
+		select {
+		case events <- metav1.WatchEvent{Type: string(eventType), Object: runtime.RawExtension{Object: object}}:
+		default:
+			overflowOnce.Do(func() { close(overflow) })
+		}
+	}
+
+	// Registering replays the cached objects as initial adds
+	registration, err := informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if isInInitialList && skipInitial {
+				return
+			}
+			enqueue(watch.Added, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			enqueue(watch.Modified, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			enqueue(watch.Deleted, obj)
+		},
+	})
+	if err != nil {
+		s.requestLogger(r).Error("Failed to register watch handler", zap.String("resource", resource), zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, "Failed to start watch")
+		return
+	}
+	defer func() {
+		if err := informer.RemoveEventHandler(registration); err != nil {
+			s.requestLogger(r).Warn("Failed to remove watch handler", zap.String("resource", resource), zap.Error(err))
+		}
+	}()
+
+	w.Header().Set("Content-Type", "application/json;stream=watch")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-timeout:
+			return
+		case <-overflow:
+			s.requestLogger(r).Warn("Ending watch of slow client", zap.String("resource", resource))
+			return
+		case event := <-events:
+			if err := encoder.Encode(event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeKubeStyleList writes the cached objects of a resource as a Kubernetes
+// List object, such as a PodList
+func (s *Server) writeKubeStyleList(w http.ResponseWriter, informer cache.SharedIndexInformer, gvk schema.GroupVersionKind, filter kubeWatchFilter, sanitize func(runtime.Object)) {
+	items := make([]runtime.Object, 0)
+	for _, obj := range informer.GetStore().List() {
+		object, ok := withTypeMeta(obj, gvk)
+		if ok && filter.matches(object) {
+			sanitize(object)
+			items = append(items, object)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"apiVersion": gvk.GroupVersion().String(),
+		"kind":       gvk.Kind + "List",
+		"metadata":   map[string]interface{}{"resourceVersion": informer.LastSyncResourceVersion()},
+		"items":      items,
+	})
+}
+
+// writeResourcePage lists one chunk of a resource from the API server as the
+// caller and writes it as a Kubernetes List object, whose metadata.continue
+// requests the next chunk
+func (s *Server) writeResourcePage(w http.ResponseWriter, r *http.Request, gvr schema.GroupVersionResource, namespace, labelSelector string, limit int64, continueToken string, sanitize func(runtime.Object)) {
+	list, err := s.callerResourceManager(r).ListResourcePage(r.Context(), gvr, namespace, labelSelector, limit, continueToken)
+	switch {
+	case apierrors.IsResourceExpired(err):
+		writeJSONError(w, http.StatusGone, "continue token expired, restart the listing without it")
+		return
+	case apierrors.IsNotFound(err):
+		writeJSONError(w, http.StatusNotFound, fmt.Sprintf("resource %s is not served by the API server", gvr.String()))
+		return
+	case apierrors.IsBadRequest(err):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	case apierrors.IsForbidden(err):
+		writeJSONError(w, http.StatusForbidden, err.Error())
+		return
+	case err != nil:
+		s.requestLogger(r).Error("Failed to list resource",
+			zap.String("resource", gvr.String()),
+			zap.String("namespace", namespace),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	for i := range list.Items {
+		sanitize(&list.Items[i])
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(list)
+}
@@ -0,0 +1,406 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/auth"
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s"
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newKubeWatchTestServer(t *testing.T) (*httptest.Server, *fake.Clientset) {
+	t.Helper()
+
+	client := fake.NewSimpleClientset(watchPod("web-0", "shop", "web"), watchPod("web-0", "prod", "web"))
+	manager := informers.NewManager(zap.NewNop(), client, nil)
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	s := &Server{logger: zap.NewNop(), informerManager: manager}
+	router := chi.NewRouter()
+	router.Get("/api/v1/resources/{group}/{version}/{resource}", s.handleKubeStyleResource)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, client
+}
+
+type testWatchEvent struct {
+	Type   string `json:"type"`
+	Object struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+		Metadata   struct {
+			Name      string            `json:"name"`
+			Namespace string            `json:"namespace"`
+			Labels    map[string]string `json:"labels"`
+		} `json:"metadata"`
+	} `json:"object"`
+}
+
+func TestHandleKubeStyleWatch(t *testing.T) {
+	server, client := newKubeWatchTestServer(t)
+
+	resp, err := http.Get(server.URL + "/api/v1/resources/core/v1/pods?watch=true&namespace=shop&timeoutSeconds=10")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json;stream=watch", resp.Header.Get("Content-Type"))
+
+	events := make(chan testWatchEvent)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			var event testWatchEvent
+			if json.Unmarshal(scanner.Bytes(), &event) == nil {
+				events <- event
+			}
+		}
+	}()
+	next := func() testWatchEvent {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a watch event")
+			return testWatchEvent{}
+		}
+	}
+
+	// The existing pod of the namespace is replayed first
+	event := next()
+	assert.Equal(t, "ADDED", event.Type)
+	assert.Equal(t, "v1", event.Object.APIVersion)
+	assert.Equal(t, "Pod", event.Object.Kind)
+	assert.Equal(t, "web-0", event.Object.Metadata.Name)
+	assert.Equal(t, "shop", event.Object.Metadata.Namespace)
+
+	ctx := context.Background()
+	_, err = client.CoreV1().Pods("prod").Create(ctx, watchPod("web-1", "prod", "web"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.CoreV1().Pods("shop").Create(ctx, watchPod("web-1", "shop", "web"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	event = next()
+	assert.Equal(t, "ADDED", event.Type)
+	assert.Equal(t, "web-1", event.Object.Metadata.Name, "pods of other namespaces are filtered out")
+
+	_, err = client.CoreV1().Pods("shop").Update(ctx, watchPod("web-1", "shop", "web-v2"), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	event = next()
+	assert.Equal(t, "MODIFIED", event.Type)
+	assert.Equal(t, "web-v2", event.Object.Metadata.Labels["app"])
+
+	require.NoError(t, client.CoreV1().Pods("shop").Delete(ctx, "web-1", metav1.DeleteOptions{}))
+	event = next()
+	assert.Equal(t, "DELETED", event.Type)
+	assert.Equal(t, "web-1", event.Object.Metadata.Name)
+	assert.Equal(t, "Pod", event.Object.Kind)
+}
+
+func TestHandleKubeStyleWatchSkipsInitialEventsWithResourceVersion(t *testing.T) {
+	server, client := newKubeWatchTestServer(t)
+
+	resp, err := http.Get(server.URL + "/api/v1/resources/core/v1/pods?watch=1&resourceVersion=42&timeoutSeconds=10")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	_, err = client.CoreV1().Pods("shop").Create(context.Background(), watchPod("web-2", "shop", "web"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	var event testWatchEvent
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&event))
+	assert.Equal(t, "ADDED", event.Type)
+	assert.Equal(t, "web-2", event.Object.Metadata.Name)
+}
+
+// serveThroughMiddleware serves routes behind the server's full middleware
+// stack, with a short request timeout and authentication disabled
+func serveThroughMiddleware(t *testing.T, s *Server, routes func(r chi.Router)) *httptest.Server {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.Security.AuthMode = "none"
+	s.config = cfg
+	s.router = chi.NewRouter()
+	s.authMiddleware = auth.NewMiddleware(zap.NewNop(), auth.AuthModeNone, nil, nil, nil, "")
+	s.requestTimeout = 200 * time.Millisecond
+	s.setupMiddleware()
+	routes(s.router)
+
+	server := httptest.NewServer(s.router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHandleKubeStyleWatchThroughMiddleware(t *testing.T) {
+	client := fake.NewSimpleClientset(watchPod("web-0", "shop", "web"))
+	manager := informers.NewManager(zap.NewNop(), client, nil)
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	s := &Server{logger: zap.NewNop(), informerManager: manager}
+	server := serveThroughMiddleware(t, s, func(r chi.Router) {
+		r.Get("/api/v1/resources/{group}/{version}/{resource}", s.handleKubeStyleResource)
+		r.Get("/api/v1/slow", func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		})
+	})
+
+	// Ordinary requests are still cut off by the request timeout
+	resp, err := http.Get(server.URL + "/api/v1/slow")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/api/v1/resources/core/v1/pods?watch=true&namespace=shop&timeoutSeconds=10")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	decoder := json.NewDecoder(resp.Body)
+	var event testWatchEvent
+	require.NoError(t, decoder.Decode(&event))
+	assert.Equal(t, "web-0", event.Object.Metadata.Name)
+
+	// Events keep flowing after the request timeout has passed
+	time.Sleep(3 * s.requestTimeout)
+	_, err = client.CoreV1().Pods("shop").Create(context.Background(), watchPod("web-1", "shop", "web"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, decoder.Decode(&event))
+	assert.Equal(t, "ADDED", event.Type)
+	assert.Equal(t, "web-1", event.Object.Metadata.Name)
+}
+
+func TestHandleKubeStyleList(t *testing.T) {
+	server, _ := newKubeWatchTestServer(t)
+
+	resp, err := http.Get(server.URL + "/api/v1/resources/core/v1/pods?labelSelector=app%3Dweb&namespace=prod")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+		Items      []struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	assert.Equal(t, "v1", list.APIVersion)
+	assert.Equal(t, "PodList", list.Kind)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "Pod", list.Items[0].Kind)
+	assert.Equal(t, "prod", list.Items[0].Metadata.Namespace)
+
+	for path, expected := range map[string]int{
+		"/api/v1/resources/apps/v1/pods":                             http.StatusNotFound,
+		"/api/v1/resources/core/v1/widgets":                          http.StatusNotFound,
+		"/api/v1/resources/core/v1/pods?labelSelector=app+in":        http.StatusBadRequest,
+		"/api/v1/resources/core/v1/pods?watch=true&timeoutSeconds=x": http.StatusBadRequest,
+	} {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, expected, resp.StatusCode, path)
+	}
+}
+
+func TestHandleKubeStyleListChunksUncachedResources(t *testing.T) {
+	widgets := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	gadgets := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "gadgets"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{widgets: "WidgetList", gadgets: "GadgetList"})
+	// The fake dynamic client drops continue tokens; paging is covered by the
+	// resources package
+	var listed []k8stesting.ListAction
+	dynamicClient.PrependReactor("list", "widgets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		listed = append(listed, action.(k8stesting.ListAction))
+		if action.GetNamespace() == "gone" {
+			return true, nil, apierrors.NewResourceExpired("continue token too old")
+		}
+		list := &unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": "example.com/v1", "kind": "WidgetList"}}
+		list.Items = []unstructured.Unstructured{{Object: map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Widget",
+			"metadata":   map[string]interface{}{"name": "w-0", "namespace": "shop", "labels": map[string]interface{}{"tier": "web"}},
+		}}}
+		return true, list, nil
+	})
+	dynamicClient.PrependReactor("list", "gadgets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(gadgets.GroupResource(), "")
+	})
+
+	s := &Server{logger: zap.NewNop(), resourceManager: resources.NewResourceManager(zap.NewNop(), fake.NewSimpleClientset(), dynamicClient)}
+	router := chi.NewRouter()
+	router.Get("/api/v1/resources/{group}/{version}/{resource}", s.handleKubeStyleResource)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/resources/example.com/v1/widgets?namespace=shop&labelSelector=tier%3Dweb&limit=1", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var list struct {
+		Kind  string `json:"kind"`
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Equal(t, "WidgetList", list.Kind)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "w-0", list.Items[0].Metadata.Name)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "shop", listed[0].GetNamespace())
+	assert.Equal(t, "tier=web", listed[0].GetListRestrictions().Labels.String())
+
+	for path, expected := range map[string]int{
+		"/api/v1/resources/example.com/v1/widgets?continue=stale&namespace=gone": http.StatusGone,
+		"/api/v1/resources/example.com/v1/gadgets":                               http.StatusNotFound,
+		"/api/v1/resources/example.com/v1/widgets?limit=0":                       http.StatusBadRequest,
+		"/api/v1/resources/example.com/v1/widgets?watch=true":                    http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, expected, rec.Code, path)
+	}
+}
+
+func TestHandleKubeStyleSecretsOmitValues(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "db",
+			Namespace:   "shop",
+			Annotations: map[string]string{lastAppliedAnnotation: `{"data":{"password":"aHVudGVyMg=="}}`, "team": "payments"},
+		},
+		Data: map[string][]byte{"password": []byte("hunter2")},
+	}
+	client := fake.NewSimpleClientset(secret)
+	manager := informers.NewManager(zap.NewNop(), client, nil)
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Version: "v1", Resource: "secrets"}: "SecretList"})
+	dynamicClient.PrependReactor("list", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(secret)
+		require.NoError(t, err)
+		list := &unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": "v1", "kind": "SecretList"}}
+		list.Items = []unstructured.Unstructured{{Object: object}}
+		return true, list, nil
+	})
+
+	s := &Server{
+		logger:          zap.NewNop(),
+		informerManager: manager,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, dynamicClient),
+	}
+	router := chi.NewRouter()
+	router.Get("/api/v1/resources/{group}/{version}/{resource}", s.handleKubeStyleResource)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	get := func(path string) string {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		reader := bufio.NewReader(resp.Body)
+		// A watch is read up to its first event
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		return line
+	}
+
+	for _, path := range []string{
+		"/api/v1/resources/core/v1/secrets?namespace=shop",
+		"/api/v1/resources/core/v1/secrets?namespace=shop&limit=10",
+		"/api/v1/resources/core/v1/secrets?namespace=shop&watch=true&timeoutSeconds=10",
+	} {
+		body := get(path)
+		assert.Contains(t, body, `"db"`, path)
+		assert.Contains(t, body, "payments", path)
+		assert.NotContains(t, body, "aHVudGVyMg==", path)
+		assert.NotContains(t, body, "hunter2", path)
+		assert.NotContains(t, body, lastAppliedAnnotation, path)
+	}
+
+	// The informer's own copy is left untouched
+	cached, exists, err := manager.GetSecretLister().GetByKey("shop/db")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, []byte("hunter2"), cached.(*v1.Secret).Data["password"])
+}
+
+func TestHandleKubeStyleResourceChecksCallerPermission(t *testing.T) {
+	client := fake.NewSimpleClientset(watchPod("web-0", "shop", "web"))
+	manager := informers.NewManager(zap.NewNop(), client, nil)
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	cfg := &config.Config{}
+	cfg.Security.AuthMode = "header"
+	s := &Server{
+		logger:           zap.NewNop(),
+		config:           cfg,
+		informerManager:  manager,
+		impersonationMgr: k8s.NewImpersonationManager(nil, zap.NewNop()),
+	}
+	router := chi.NewRouter()
+	router.Get("/api/v1/resources/{group}/{version}/{resource}", s.handleKubeStyleResource)
+
+	// The caller may list pods and deployments in shop only
+	callerClient := fake.NewSimpleClientset()
+	callerClient.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = attrs.Verb == "list" && attrs.Namespace == "shop" &&
+			((attrs.Group == "" && attrs.Resource == "pods") || (attrs.Group == "apps" && attrs.Resource == "deployments"))
+		return true, review, nil
+	})
+	ctx := auth.WithUser(context.Background(), &auth.User{ID: "dev", Email: "dev@example.com"})
+	ctx = k8s.WithImpersonatedClients(ctx, &k8s.ImpersonatedClients{Clientset: callerClient})
+
+	for path, expected := range map[string]int{
+		"/api/v1/resources/core/v1/pods?namespace=shop":                             http.StatusOK,
+		"/api/v1/resources/apps/v1/deployments?namespace=shop":                      http.StatusOK,
+		"/api/v1/resources/core/v1/pods?namespace=prod":                             http.StatusForbidden,
+		"/api/v1/resources/core/v1/pods":                                            http.StatusForbidden,
+		"/api/v1/resources/core/v1/pods?namespace=shop&watch=true&timeoutSeconds=1": http.StatusForbidden,
+		"/api/v1/resources/core/v1/secrets?namespace=shop":                          http.StatusForbidden,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx))
+		assert.Equal(t, expected, rec.Code, path)
+		if expected == http.StatusForbidden {
+			assert.NotContains(t, rec.Body.String(), "web-0", path)
+		}
+	}
+}
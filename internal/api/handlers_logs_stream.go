@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/logs"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	apimiddleware "github.com/aaronlmathis/kaptn/internal/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// logStreamWriteWait is the time allowed to write a frame to a log stream
+	// client. A client that cannot take a frame in time is disconnected, which
+	// cancels the log stream behind it.
+	logStreamWriteWait = 10 * time.Second
+
+	// logStreamPingPeriod is how often log stream clients are pinged
+	logStreamPingPeriod = 30 * time.Second
+)
+
+// Frame types sent to log stream clients
+const (
+	logStreamFrameLog   = "log"
+	logStreamFrameError = "error"
+	logStreamFrameEnd   = "end"
+)
+
+// logStreamFrame is one message sent to a log stream client: a log line, an
+// error from one of the pod's containers, or the end of the stream
+type logStreamFrame struct {
+	Type  string         `json:"type"`
+	Data  *logs.LogEntry `json:"data,omitempty"`
+	Error string         `json:"error,omitempty"`
+}
+
+// parseLogStreamFilter reads the follow-mode log options from the query
+func parseLogStreamFilter(r *http.Request) (logs.LogFilter, error) {
+	query := r.URL.Query()
+	filter := logs.LogFilter{
+		Container:  query.Get("container"),
+		Follow:     true,
+		Timestamps: query.Get("timestamps") == "true",
+		Reconnect:  query.Get("reconnect") == "true",
+	}
+
+	if raw := query.Get("tailLines"); raw != "" {
+		lines, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || lines < 0 {
+			return filter, fmt.Errorf("Invalid tailLines parameter. Must be a non-negative integer")
+		}
+		filter.TailLines = &lines
+	}
+	if raw := query.Get("sinceSeconds"); raw != "" {
+		seconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || seconds <= 0 {
+			return filter, fmt.Errorf("Invalid sinceSeconds parameter. Must be a positive integer")
+		}
+		filter.SinceSeconds = &seconds
+	}
+	if query.Get("sinceLastRestart") == "true" && filter.SinceSeconds != nil {
+		return filter, fmt.Errorf("sinceLastRestart cannot be combined with sinceSeconds")
+	}
+	return filter, nil
+}
+
+// handlePodLogsStream handles GET /api/v1/pods/{namespace}/{podName}/logs/stream
+// @Summary Stream pod logs
+// @Description Upgrades to a WebSocket and follows the pod's logs, sending each line as a {"type":"log","data":{...}} frame as it is written. Without a container every container of the pod is followed. Errors are sent as "error" frames and an "end" frame is sent when the logs end. With reconnect=true the stream is reopened when a container restarts, until the pod finishes. Closing the socket stops the stream.
+// @Tags Pods
+// @Param namespace path string true "Namespace"
+// @Param podName path string true "Pod name"
+// @Param container query string false "Container to follow (default all containers)"
+// @Param tailLines query int false "Number of lines from the end of the logs to start with"
+// @Param sinceSeconds query int false "Only return logs newer than this many seconds"
+// @Param sinceLastRestart query bool false "Start at the container's last start (state.running.startedAt); the start is returned in the X-Log-Since header"
+// @Param timestamps query bool false "Prefix each line with its timestamp"
+// @Param reconnect query bool false "Reopen the stream when a container restarts"
+// @Success 101 {string} string "Switching to the WebSocket protocol"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters"
+// @Failure 404 {object} map[string]interface{} "Pod not found"
+// @Failure 409 {object} map[string]interface{} "sinceLastRestart was requested but the container is not running"
+// @Router /api/v1/pods/{namespace}/{podName}/logs/stream [get]
+func (s *Server) handlePodLogsStream(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	podName := chi.URLParam(r, "podName")
+
+	filter, err := parseLogStreamFilter(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pod, err := s.kubeClient.CoreV1().Pods(namespace).Get(r.Context(), podName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			writeJSONError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if filter.Container != "" {
+		if err := ambiguousContainerError(pod, filter.Container); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	// Following every container would need one start time per container, so
+	// the window needs a container unless the pod has only one
+	var responseHeader http.Header
+	if r.URL.Query().Get("sinceLastRestart") == "true" {
+		window, err := resources.RestartLogWindow(pod, filter.Container, false)
+		if err != nil {
+			writeJSONError(w, restartLogWindowStatus(err), err.Error())
+			return
+		}
+		filter.Container = window.Container
+		filter.SinceTime = &window.Start
+		responseHeader = http.Header{}
+		setLogWindowHeaders(responseHeader, window)
+	}
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			return true // Allow all origins for now
+		},
+	}
+	conn, err := upgrader.Upgrade(w, r, responseHeader)
+	if err != nil {
+		s.requestLogger(r).Error("Failed to upgrade log stream connection", zap.Error(err))
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	streamID := "ws-" + uuid.New().String()
+	stream, err := s.logsService.StartStream(ctx, streamID, namespace, podName, filter)
+	if err != nil {
+		s.requestLogger(r).Error("Failed to start log stream", zap.Error(err))
+		return
+	}
+	defer s.logsService.StopStream(streamID)
+
+	// The client only sends control frames; a read error means it went away
+	go func() {
+		defer apimiddleware.RecoverGoroutine(s.logger, "pod-logs-stream-reader")
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(frame logStreamFrame) bool {
+		data, err := json.Marshal(frame)
+		if err != nil {
+			return false
+		}
+		conn.SetWriteDeadline(time.Now().Add(logStreamWriteWait))
+		return conn.WriteMessage(websocket.TextMessage, data) == nil
+	}
+
+	ticker := time.NewTicker(logStreamPingPeriod)
+	defer ticker.Stop()
+
+	errs := stream.Errors()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case entry, ok := <-stream.Events():
+			if !ok {
+				// Report errors still queued when the last container ended
+				if errs != nil {
+					for err := range errs {
+						write(logStreamFrame{Type: logStreamFrameError, Error: err.Error()})
+					}
+				}
+				write(logStreamFrame{Type: logStreamFrameEnd})
+				conn.SetWriteDeadline(time.Now().Add(logStreamWriteWait))
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "log stream ended"))
+				return
+			}
+			if !write(logStreamFrame{Type: logStreamFrameLog, Data: &entry}) {
+				return
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if !write(logStreamFrame{Type: logStreamFrameError, Error: err.Error()}) {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(logStreamWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/logs"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newLogStreamTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	client := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "web"}, {Name: "web-sidecar"}}},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	})
+	s := &Server{
+		logger:      zap.NewNop(),
+		kubeClient:  client,
+		logsService: logs.NewStreamManager(zap.NewNop(), client),
+	}
+
+	router := chi.NewRouter()
+	router.Get("/api/v1/pods/{namespace}/{podName}/logs/stream", s.handlePodLogsStream)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHandlePodLogsStreamValidation(t *testing.T) {
+	server := newLogStreamTestServer(t)
+
+	for path, expected := range map[string]int{
+		"/api/v1/pods/shop/web-0/logs/stream?tailLines=-1":       http.StatusBadRequest,
+		"/api/v1/pods/shop/web-0/logs/stream?sinceSeconds=soon":  http.StatusBadRequest,
+		"/api/v1/pods/shop/web-0/logs/stream?container=we":       http.StatusBadRequest,
+		"/api/v1/pods/shop/missing/logs/stream":                  http.StatusNotFound,
+		"/api/v1/pods/shop/web-0/logs/stream?container=web-side": http.StatusBadRequest,
+		// Both containers would need their own start time
+		"/api/v1/pods/shop/web-0/logs/stream?sinceLastRestart=true":                http.StatusBadRequest,
+		"/api/v1/pods/shop/web-0/logs/stream?sinceLastRestart=true&sinceSeconds=5": http.StatusBadRequest,
+	} {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, expected, resp.StatusCode, path)
+	}
+}
+
+func TestHandlePodLogsStream(t *testing.T) {
+	server := newLogStreamTestServer(t)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/pods/shop/web-0/logs/stream?container=web&tailLines=10"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var frame logStreamFrame
+	require.NoError(t, conn.ReadJSON(&frame))
+	assert.Equal(t, logStreamFrameLog, frame.Type)
+	require.NotNil(t, frame.Data)
+	assert.Equal(t, "fake logs", frame.Data.Line)
+	assert.Equal(t, "web", frame.Data.Container)
+	assert.Equal(t, "web-0", frame.Data.Pod)
+
+	// The fake log stream ends after one line, which ends the socket
+	require.NoError(t, conn.ReadJSON(&frame))
+	assert.Equal(t, logStreamFrameEnd, frame.Type)
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), err)
+}
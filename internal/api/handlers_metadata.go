@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// metadataPatchRequest is the body of a label and annotation update
+type metadataPatchRequest struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// handlePatchMetadata handles PATCH /api/v1/{kind}/{namespace}/{name}/metadata
+// @Summary Edit labels and annotations
+// @Description Sets the labels and/or annotations of any object, including custom resources, without re-applying its YAML. By default the given keys are merged into the existing ones; to remove a key while merging, send it with a "-" suffix and an empty value, e.g. {"labels":{"app-":""}} removes the app label, as kubectl label app- does. With merge=false the given map replaces the existing one entirely and keys not listed are removed; the replacement fails with 409 if the object changed meanwhile. A map left out of the body is not changed. Cluster-scoped objects ignore the namespace, e.g. /api/v1/nodes/_/node-1/metadata.
+// @Tags Resources
+// @Accept json
+// @Produce json
+// @Param kind path string true "Resource as kubectl names it, e.g. deployments, deploy or widgets.example.com"
+// @Param namespace path string true "Namespace"
+// @Param name path string true "Object name"
+// @Param merge query bool false "Merge into the existing keys (default: true) or replace them"
+// @Param body body metadataPatchRequest true "Labels and annotations to set"
+// @Success 200 {object} map[string]interface{} "Labels and annotations after the update"
+// @Failure 400 {object} map[string]interface{} "Invalid body, key or value, or unknown kind"
+// @Failure 404 {object} map[string]interface{} "Object not found"
+// @Failure 409 {object} map[string]interface{} "Object changed while replacing"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/{kind}/{namespace}/{name}/metadata [patch]
+func (s *Server) handlePatchMetadata(w http.ResponseWriter, r *http.Request) {
+	merge := true
+	if raw := r.URL.Query().Get("merge"); raw != "" {
+		var err error
+		merge, err = strconv.ParseBool(raw)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "merge must be true or false")
+			return
+		}
+	}
+
+	var req metadataPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON body: "+err.Error())
+		return
+	}
+	if req.Labels == nil && req.Annotations == nil {
+		writeJSONError(w, http.StatusBadRequest, "labels or annotations are required")
+		return
+	}
+
+	rm := s.callerResourceManager(r)
+	resource := chi.URLParam(r, "kind")
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+	gvk, err := rm.ResolveKind(resource)
+	if err != nil {
+		writeJSONError(w, applyErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx := s.mutationContext(r)
+	data := map[string]interface{}{
+		"kind":      gvk.Kind,
+		"namespace": namespace,
+		"name":      name,
+	}
+	if req.Labels != nil {
+		data["labels"], err = rm.UpdateLabels(ctx, gvk, namespace, name, req.Labels, merge)
+	}
+	if err == nil && req.Annotations != nil {
+		data["annotations"], err = rm.UpdateAnnotations(ctx, gvk, namespace, name, req.Annotations, merge)
+	}
+	if err != nil {
+		status := applyErrorStatus(err)
+		if errors.Is(err, resources.ErrInvalidMetadata) {
+			status = http.StatusBadRequest
+		}
+		if status == http.StatusInternalServerError {
+			s.requestLogger(r).Error("Failed to update metadata",
+				zap.String("kind", gvk.Kind),
+				zap.String("namespace", namespace),
+				zap.String("name", name),
+				zap.Error(err))
+		}
+		writeJSONError(w, status, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   data,
+		"status": "success",
+	})
+}
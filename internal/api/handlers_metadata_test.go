@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newMetadataTestRouter() http.Handler {
+	client := fake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "example.com/v1", APIResources: []metav1.APIResource{
+			{Name: "widgets", Kind: "Widget", Namespaced: true},
+		}},
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata": map[string]interface{}{
+			"name": "gear", "namespace": "shop",
+			"labels":      map[string]interface{}{"app": "gear", "tier": "web"},
+			"annotations": map[string]interface{}{"note": "spare"},
+		},
+	}})
+
+	s := &Server{
+		logger:          zap.NewNop(),
+		config:          &config.Config{},
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, dynamicClient),
+	}
+	router := chi.NewRouter()
+	router.Patch("/api/v1/{kind}/{namespace}/{name}/metadata", s.handlePatchMetadata)
+	return router
+}
+
+func TestHandlePatchMetadata(t *testing.T) {
+	router := newMetadataTestRouter()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/api/v1/widgets.example.com/shop/gear/metadata",
+		strings.NewReader(`{"labels":{"tier":"db","app-":""},"annotations":{"owner":"alice"}}`)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response struct {
+		Data struct {
+			Kind        string            `json:"kind"`
+			Labels      map[string]string `json:"labels"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "Widget", response.Data.Kind)
+	assert.Equal(t, map[string]string{"tier": "db"}, response.Data.Labels)
+	assert.Equal(t, map[string]string{"note": "spare", "owner": "alice"}, response.Data.Annotations)
+
+	// Replacing drops the keys not given and leaves annotations alone
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/api/v1/widgets/shop/gear/metadata?merge=false",
+		strings.NewReader(`{"labels":{"team":"payments"}}`)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	response.Data.Labels, response.Data.Annotations = nil, nil
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, map[string]string{"team": "payments"}, response.Data.Labels)
+	assert.Nil(t, response.Data.Annotations)
+}
+
+func TestHandlePatchMetadataErrors(t *testing.T) {
+	router := newMetadataTestRouter()
+
+	for _, tt := range []struct {
+		target string
+		body   string
+		status int
+	}{
+		{"/api/v1/widgets/shop/gear/metadata", `{`, http.StatusBadRequest},
+		{"/api/v1/widgets/shop/gear/metadata", `{}`, http.StatusBadRequest},
+		{"/api/v1/widgets/shop/gear/metadata?merge=maybe", `{"labels":{}}`, http.StatusBadRequest},
+		{"/api/v1/widgets/shop/gear/metadata", `{"labels":{"bad key":"x"}}`, http.StatusBadRequest},
+		{"/api/v1/gadgets/shop/gear/metadata", `{"labels":{"a":"b"}}`, http.StatusBadRequest},
+		{"/api/v1/widgets/shop/missing/metadata", `{"labels":{"a":"b"}}`, http.StatusNotFound},
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, tt.target, strings.NewReader(tt.body)))
+		assert.Equal(t, tt.status, rec.Code, "%s %s: %s", tt.target, tt.body, rec.Body.String())
+	}
+}
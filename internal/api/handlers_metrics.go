@@ -4,16 +4,26 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/aaronlmathis/kaptn/internal/k8s/metrics"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
 // Metrics related handlers
 
+// metricsFreshness reports how old the metrics-server usage data is, treating
+// a missing metrics service as never collected
+func (s *Server) metricsFreshness() metrics.MetricsFreshness {
+	if s.metricsService == nil {
+		return metrics.MetricsFreshness{AgeSeconds: -1, Stale: true}
+	}
+	return s.metricsService.Freshness()
+}
+
 func (s *Server) handleGetMetrics(w http.ResponseWriter, r *http.Request) {
 	metrics, err := s.metricsService.GetClusterMetrics(r.Context())
 	if err != nil {
-		s.logger.Error("Failed to get cluster metrics", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get cluster metrics", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
@@ -36,7 +46,7 @@ func (s *Server) handleGetNamespaceMetrics(w http.ResponseWriter, r *http.Reques
 
 	metrics, err := s.metricsService.GetNamespaceMetrics(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to get namespace metrics",
+		s.requestLogger(r).Error("Failed to get namespace metrics",
 			zap.String("namespace", namespace),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
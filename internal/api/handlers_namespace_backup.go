@@ -0,0 +1,93 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// attachmentWriter sends the download headers with the first archive bytes, so
+// errors raised before anything is written can still be returned as JSON
+type attachmentWriter struct {
+	w           http.ResponseWriter
+	filename    string
+	contentType string
+	started     bool
+}
+
+func (a *attachmentWriter) Write(p []byte) (int, error) {
+	if !a.started {
+		a.w.Header().Set("Content-Type", a.contentType)
+		a.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", a.filename))
+		a.w.WriteHeader(http.StatusOK)
+		a.started = true
+	}
+	n, err := a.w.Write(p)
+	if flusher, ok := a.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
+
+// handleNamespaceBackup handles GET /api/v1/namespaces/{name}/backup
+// @Summary Download a namespace backup
+// @Description Streams a tar.gz archive with one multi-document YAML file per resource kind in the namespace, listed with the caller's permissions. Kinds that cannot be listed or have no objects are omitted. Secrets are exported with their keys but empty values unless includeSecretData=true is passed by a caller allowed to read secrets in the namespace. Values of ConfigMap keys matching the configured redaction patterns are replaced with "[REDACTED]" unless reveal=true is passed by such a caller.
+// @Tags Namespaces
+// @Produce application/gzip
+// @Param name path string true "Namespace name"
+// @Param includeSecretData query bool false "Include secret values in the archive (default: false)"
+// @Param reveal query bool false "Include redacted ConfigMap values in the archive (default: false)"
+// @Success 200 {file} file "Namespace backup archive"
+// @Failure 403 {object} map[string]interface{} "Not allowed to read secret or redacted values"
+// @Failure 404 {object} map[string]interface{} "Namespace not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/namespaces/{name}/backup [get]
+func (s *Server) handleNamespaceBackup(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+
+	opts := resources.NamespaceBackupOptions{
+		IncludeSecretData: r.URL.Query().Get("includeSecretData") == "true",
+	}
+	if opts.IncludeSecretData && !s.requireSecretAccess(w, r, name) {
+		return
+	}
+	redactor, ok := s.configMapRedactorFor(w, r, name)
+	if !ok {
+		return
+	}
+	if redactor != nil {
+		opts.Redact = redactor.redactObject
+	}
+
+	out := &attachmentWriter{
+		w:           w,
+		filename:    fmt.Sprintf("%s-backup.tar.gz", name),
+		contentType: "application/gzip",
+	}
+
+	files, err := s.callerResourceManager(r).WriteNamespaceBackup(r.Context(), name, out, opts)
+	if err != nil {
+		s.requestLogger(r).Error("Failed to write namespace backup",
+			zap.String("namespace", name),
+			zap.Bool("partial", out.started),
+			zap.Error(err))
+		if out.started {
+			// The archive is already streaming; the client sees a truncated download
+			return
+		}
+		status := http.StatusInternalServerError
+		if errors.IsNotFound(err) {
+			status = http.StatusNotFound
+		}
+		writeJSONError(w, status, err.Error())
+		return
+	}
+
+	s.requestLogger(r).Info("Namespace backup written",
+		zap.String("namespace", name),
+		zap.Int("files", len(files)))
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/auth"
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func namespaceBackupRequest(ctx context.Context, name, query string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("name", name)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/"+name+"/backup"+query, nil)
+	return req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+}
+
+func serveNamespaceBackup(t *testing.T, client *fake.Clientset, name string) *httptest.ResponseRecorder {
+	t.Helper()
+	s := &Server{
+		logger:          zap.NewNop(),
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+	req := namespaceBackupRequest(context.Background(), name, "")
+
+	rec := httptest.NewRecorder()
+	s.handleNamespaceBackup(rec, req)
+	return rec
+}
+
+func TestHandleNamespaceBackupStreamsArchive(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "team-a"}},
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+	)
+
+	rec := serveNamespaceBackup(t, client, "team-a")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/gzip", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="team-a-backup.tar.gz"`, rec.Header().Get("Content-Disposition"))
+
+	gz, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, header.Name)
+	}
+	assert.ElementsMatch(t, []string{"team-a/configmaps.yaml", "team-a/services.yaml"}, names)
+}
+
+// readArchiveFiles returns the file contents of a tar.gz archive keyed by name
+func readArchiveFiles(t *testing.T, body io.Reader) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(body)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+func TestHandleNamespaceBackupSecretData(t *testing.T) {
+	// The caller's own client holds the objects and answers the secrets check
+	callerClient := func(allowed bool) *fake.Clientset {
+		client := fake.NewSimpleClientset(
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+			&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"},
+				Data:       map[string][]byte{"password": []byte("hunter2")},
+			},
+		)
+		client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+			attrs := review.Spec.ResourceAttributes
+			review.Status.Allowed = allowed && attrs.Verb == "get" && attrs.Resource == "secrets" && attrs.Namespace == "shop"
+			return true, review, nil
+		})
+		return client
+	}
+	cfg := &config.Config{}
+	cfg.Security.AuthMode = "header"
+	// The server's own manager sees nothing, so the archive must come from the caller
+	serverClient := fake.NewSimpleClientset()
+	s := &Server{
+		logger:           zap.NewNop(),
+		config:           cfg,
+		kubeClient:       serverClient,
+		resourceManager:  resources.NewResourceManager(zap.NewNop(), serverClient, nil),
+		impersonationMgr: k8s.NewImpersonationManager(nil, zap.NewNop()),
+	}
+	serve := func(allowed bool, query string) *httptest.ResponseRecorder {
+		ctx := auth.WithUser(context.Background(), &auth.User{ID: "dev", Email: "dev@example.com"})
+		ctx = k8s.WithImpersonatedClients(ctx, &k8s.ImpersonatedClients{Clientset: callerClient(allowed)})
+		rec := httptest.NewRecorder()
+		s.handleNamespaceBackup(rec, namespaceBackupRequest(ctx, "shop", query))
+		return rec
+	}
+
+	// Secret values are never in the archive by default
+	rec := serve(true, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	secrets := readArchiveFiles(t, rec.Body)["shop/secrets.yaml"]
+	assert.Contains(t, secrets, "password")
+	assert.NotContains(t, secrets, base64.StdEncoding.EncodeToString([]byte("hunter2")))
+
+	// Including them needs permission to read secrets in the namespace
+	rec = serve(false, "?includeSecretData=true")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Disposition"))
+
+	rec = serve(true, "?includeSecretData=true")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, readArchiveFiles(t, rec.Body)["shop/secrets.yaml"], base64.StdEncoding.EncodeToString([]byte("hunter2")))
+}
+
+func TestHandleNamespaceBackupNotFound(t *testing.T) {
+	rec := serveNamespaceBackup(t, fake.NewSimpleClientset(), "missing")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Header().Get("Content-Disposition"))
+}
+
+func TestHandleNamespaceBackupThroughMiddleware(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "team-a"}},
+	)
+	s := &Server{logger: zap.NewNop(), resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil)}
+	server := serveThroughMiddleware(t, s, func(r chi.Router) {
+		r.Get("/api/v1/namespaces/{name}/backup", func(w http.ResponseWriter, r *http.Request) {
+			// Archiving a large namespace may outlast the request timeout
+			_, hasDeadline := r.Context().Deadline()
+			assert.False(t, hasDeadline, "backups must not be cut off by the request timeout")
+			s.handleNamespaceBackup(w, r)
+		})
+	})
+
+	resp, err := http.Get(server.URL + "/api/v1/namespaces/team-a/backup")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	gz, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	header, err := tar.NewReader(gz).Next()
+	require.NoError(t, err)
+	assert.Equal(t, "team-a/configmaps.yaml", header.Name)
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/go-chi/chi/v5"
+)
+
+// namespaceUsageMetrics maps the metric query parameter to the namespace
+// used/request/limit series bases and their unit
+var namespaceUsageMetrics = map[string]struct {
+	used, request, limit, unit string
+}{
+	"cpu":    {timeseries.NamespaceCPUUsedBase, timeseries.NamespaceCPURequestBase, timeseries.NamespaceCPULimitBase, "cores"},
+	"memory": {timeseries.NamespaceMemUsedBase, timeseries.NamespaceMemRequestBase, timeseries.NamespaceMemLimitBase, "bytes"},
+}
+
+// namespaceUsagePoint is one timestamp of the aligned usage, request and limit
+// series; a value is null when that series has no sample at the timestamp
+type namespaceUsagePoint struct {
+	T       int64    `json:"t"`
+	Usage   *float64 `json:"usage"`
+	Request *float64 `json:"request"`
+	Limit   *float64 `json:"limit"`
+}
+
+// namespaceUsageHistory is the response body of the usage-history endpoint
+type namespaceUsageHistory struct {
+	Namespace  string                `json:"namespace"`
+	Metric     string                `json:"metric"`
+	Unit       string                `json:"unit"`
+	Resolution string                `json:"resolution"`
+	Since      string                `json:"since"`
+	Points     []namespaceUsagePoint `json:"points"`
+}
+
+// alignNamespaceUsage merges the usage, request and limit series on the union
+// of their timestamps, in ascending order
+func alignNamespaceUsage(usage, request, limit []timeseries.Point) []namespaceUsagePoint {
+	byTime := make(map[int64]*namespaceUsagePoint)
+	add := func(points []timeseries.Point, set func(*namespaceUsagePoint, *float64)) {
+		for _, point := range points {
+			t := point.T.UnixMilli()
+			aligned, ok := byTime[t]
+			if !ok {
+				aligned = &namespaceUsagePoint{T: t}
+				byTime[t] = aligned
+			}
+			value := point.V
+			set(aligned, &value)
+		}
+	}
+
+	add(usage, func(p *namespaceUsagePoint, v *float64) { p.Usage = v })
+	add(request, func(p *namespaceUsagePoint, v *float64) { p.Request = v })
+	add(limit, func(p *namespaceUsagePoint, v *float64) { p.Limit = v })
+
+	points := make([]namespaceUsagePoint, 0, len(byTime))
+	for _, point := range byTime {
+		points = append(points, *point)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].T < points[j].T })
+	return points
+}
+
+// handleGetNamespaceUsageHistory handles GET /api/v1/namespaces/{name}/usage-history
+// @Summary Get namespace usage history
+// @Description Returns a namespace's usage, request and limit series for one resource, aligned by timestamp, so capacity trends can be charted from a single call
+// @Tags Namespaces
+// @Produce json
+// @Param name path string true "Namespace name"
+// @Param metric query string false "Resource to report: cpu or memory (default cpu)"
+// @Param since query string false "Time window, e.g. 1h (default from timeseries query defaults)"
+// @Param res query string false "Resolution: hi, med or lo (default from timeseries query defaults)"
+// @Success 200 {object} map[string]interface{} "Aligned usage history"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters"
+// @Failure 503 {object} map[string]interface{} "Time series store not available"
+// @Router /api/v1/namespaces/{name}/usage-history [get]
+func (s *Server) handleGetNamespaceUsageHistory(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "name")
+	metric := r.URL.Query().Get("metric")
+	sinceParam := r.URL.Query().Get("since")
+	resParam := r.URL.Query().Get("res")
+
+	if metric == "" {
+		metric = "cpu"
+	}
+
+	writeError := func(status int, message string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":  message,
+			"status": "error",
+		})
+	}
+
+	bases, ok := namespaceUsageMetrics[metric]
+	if !ok {
+		writeError(http.StatusBadRequest, "Invalid metric parameter. Must be 'cpu' or 'memory'")
+		return
+	}
+	resParam, sinceParam = s.timeSeriesQueryDefaults(resParam, sinceParam, "", []string{bases.used, bases.request, bases.limit})
+
+	resolution, ok := timeseries.ParseResolution(resParam)
+	if !ok {
+		writeError(http.StatusBadRequest, "Invalid resolution parameter. Must be 'hi', 'med' or 'lo'")
+		return
+	}
+
+	since, err := time.ParseDuration(sinceParam)
+	if err != nil || since <= 0 {
+		writeError(http.StatusBadRequest, "Invalid since parameter. Must be a valid duration (e.g., '60m', '1h')")
+		return
+	}
+
+	if s.timeSeriesStore == nil {
+		writeError(http.StatusServiceUnavailable, "TimeSeries service not available")
+		return
+	}
+
+	threshold := time.Now().Add(-since)
+	pointsFor := func(base string) []timeseries.Point {
+		series, ok := s.timeSeriesStore.Get(timeseries.GenerateNamespaceSeriesKey(base, namespace))
+		if !ok {
+			return nil
+		}
+		return series.GetSince(threshold, resolution)
+	}
+
+	history := namespaceUsageHistory{
+		Namespace:  namespace,
+		Metric:     metric,
+		Unit:       bases.unit,
+		Resolution: resParam,
+		Since:      sinceParam,
+		Points:     alignNamespaceUsage(pointsFor(bases.used), pointsFor(bases.request), pointsFor(bases.limit)),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   history,
+		"status": "success",
+	})
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func namespaceUsageRequest(namespace, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/"+namespace+"/usage-history?"+query, nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("name", namespace)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+}
+
+func TestNamespaceUsageHistoryAlignsSeries(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	start := time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+
+	for i := 0; i < 3; i++ {
+		ts := start.Add(time.Duration(i) * time.Minute)
+		store.Upsert(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespaceCPUUsedBase, "team-a")).Add(timeseries.NewPoint(ts, 0.5+float64(i)))
+		store.Upsert(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespaceCPURequestBase, "team-a")).Add(timeseries.NewPoint(ts, 2))
+		// The limit series misses its last sample
+		if i < 2 {
+			store.Upsert(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespaceCPULimitBase, "team-a")).Add(timeseries.NewPoint(ts, 4))
+		}
+	}
+	// Another namespace's series must not leak into the response
+	store.Upsert(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespaceCPUUsedBase, "team-b")).Add(timeseries.NewPoint(start, 9))
+
+	s := &Server{logger: zap.NewNop(), timeSeriesStore: store}
+	rec := httptest.NewRecorder()
+	s.handleGetNamespaceUsageHistory(rec, namespaceUsageRequest("team-a", "metric=cpu&since=1h&res=hi"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response struct {
+		Data namespaceUsageHistory `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	history := response.Data
+	assert.Equal(t, "cores", history.Unit)
+	require.Len(t, history.Points, 3)
+	for i, point := range history.Points {
+		assert.Equal(t, start.Add(time.Duration(i)*time.Minute).UnixMilli(), point.T)
+		require.NotNil(t, point.Usage)
+		require.NotNil(t, point.Request)
+		assert.Equal(t, 0.5+float64(i), *point.Usage)
+		assert.Equal(t, 2.0, *point.Request)
+	}
+	require.NotNil(t, history.Points[0].Limit)
+	assert.Equal(t, 4.0, *history.Points[0].Limit)
+	assert.Nil(t, history.Points[2].Limit)
+}
+
+func TestNamespaceUsageHistoryRejectsUnknownMetric(t *testing.T) {
+	s := &Server{logger: zap.NewNop(), timeSeriesStore: timeseries.NewMemStore(timeseries.DefaultConfig())}
+	rec := httptest.NewRecorder()
+	s.handleGetNamespaceUsageHistory(rec, namespaceUsageRequest("team-a", "metric=disk"))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestNamespaceUsageHistoryRollupResolution(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	start := time.Now().Add(-10 * time.Minute).Truncate(time.Minute)
+	key := timeseries.GenerateNamespaceSeriesKey(timeseries.NamespaceCPUUsedBase, "team-a")
+	for _, offset := range []time.Duration{0, 30 * time.Second, time.Minute, 2 * time.Minute} {
+		store.Upsert(key).Add(timeseries.NewPoint(start.Add(offset), 1+offset.Minutes()))
+	}
+
+	s := &Server{logger: zap.NewNop(), timeSeriesStore: store}
+	rec := httptest.NewRecorder()
+	s.handleGetNamespaceUsageHistory(rec, namespaceUsageRequest("team-a", "metric=cpu&since=1h&res=med"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response struct {
+		Data namespaceUsageHistory `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	// The minute still being collected is not reported yet
+	require.Len(t, response.Data.Points, 2)
+	assert.Equal(t, "med", response.Data.Resolution)
+	assert.Equal(t, start.UnixMilli(), response.Data.Points[0].T)
+	require.NotNil(t, response.Data.Points[0].Usage)
+	assert.Equal(t, 1.25, *response.Data.Points[0].Usage)
+
+	rec = httptest.NewRecorder()
+	s.handleGetNamespaceUsageHistory(rec, namespaceUsageRequest("team-a", "metric=cpu&res=daily"))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func viewTestNode(name string, ready v1.ConditionStatus) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Labels:            map[string]string{"node-role.kubernetes.io/control-plane": ""},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-48 * time.Hour)),
+		},
+		Spec: v1.NodeSpec{
+			Unschedulable: true,
+			Taints:        []v1.Taint{{Key: "node.kubernetes.io/unschedulable", Effect: v1.TaintEffectNoSchedule}},
+		},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: ready, Reason: "KubeletReady"},
+				{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse},
+				{Type: v1.NodeDiskPressure, Status: v1.ConditionFalse},
+				{Type: v1.NodePIDPressure, Status: v1.ConditionFalse},
+			},
+			Addresses: []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: "10.0.0.1"}},
+			NodeInfo:  v1.NodeSystemInfo{KubeletVersion: "v1.30.2", OSImage: "Ubuntu"},
+			Capacity: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("3800m"),
+				v1.ResourceMemory: resource.MustParse("15Gi"),
+			},
+		},
+	}
+}
+
+func newNodeListTestServer(t *testing.T, nodes ...*v1.Node) *Server {
+	t.Helper()
+	manager := informers.NewManager(zap.NewNop(), fake.NewSimpleClientset(), nil)
+	for _, node := range nodes {
+		require.NoError(t, manager.NodesInformer.GetIndexer().Add(node))
+	}
+	return &Server{logger: zap.NewNop(), informerManager: manager}
+}
+
+func TestListNodesTableView(t *testing.T) {
+	s := newNodeListTestServer(t, viewTestNode("cp-1", v1.ConditionTrue))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/nodes?view=table", nil)
+	rec := httptest.NewRecorder()
+	s.handleListNodes(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp struct {
+		Data struct {
+			Items []map[string]interface{} `json:"items"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Items, 1)
+	assert.Equal(t, map[string]interface{}{
+		"name":        "cp-1",
+		"roles":       []interface{}{"control-plane"},
+		"ready":       true,
+		"version":     "v1.30.2",
+		"age":         "2d",
+		"schedulable": false,
+	}, resp.Data.Items[0])
+}
+
+func TestListNodesDefaultsToFullView(t *testing.T) {
+	s := newNodeListTestServer(t, viewTestNode("cp-1", v1.ConditionFalse))
+
+	rec := httptest.NewRecorder()
+	s.handleListNodes(rec, httptest.NewRequest(http.MethodGet, "/api/v1/nodes", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct {
+		Data struct {
+			Items []map[string]interface{} `json:"items"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Items, 1)
+	assert.Contains(t, resp.Data.Items[0], "alerts")
+	assert.Contains(t, resp.Data.Items[0], "nodeInfo")
+}
+
+func TestListNodesRejectsUnknownView(t *testing.T) {
+	s := newNodeListTestServer(t)
+
+	rec := httptest.NewRecorder()
+	s.handleListNodes(rec, httptest.NewRequest(http.MethodGet, "/api/v1/nodes?view=compact", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func BenchmarkNodeResponseViews(b *testing.B) {
+	s := &Server{logger: zap.NewNop()}
+	nodes := make([]*v1.Node, 500)
+	for i := range nodes {
+		nodes[i] = viewTestNode(fmt.Sprintf("node-%d", i), v1.ConditionTrue)
+	}
+
+	for _, view := range []struct {
+		name       string
+		toResponse func(*v1.Node) map[string]interface{}
+	}{
+		{"full", s.nodeToEnrichedResponse},
+		{"table", s.nodeToTableResponse},
+	} {
+		b.Run(view.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, node := range nodes {
+					_ = view.toResponse(node)
+				}
+			}
+		})
+	}
+}
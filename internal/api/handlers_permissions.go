@@ -36,7 +36,7 @@ func (s *Server) handleCheckPermission(w http.ResponseWriter, r *http.Request) {
 	// Get impersonated clients
 	clients, err := s.GetImpersonatedClients(r)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated clients", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get impersonated clients", zap.Error(err))
 		http.Error(w, "Impersonated clients not available", http.StatusInternalServerError)
 		return
 	}
@@ -45,7 +45,7 @@ func (s *Server) handleCheckPermission(w http.ResponseWriter, r *http.Request) {
 	permissionHelper := s.impersonationMgr.PermissionHelper()
 	allowed, err := permissionHelper.Can(r.Context(), clients.Client(), verb, resource, namespace, name)
 	if err != nil {
-		s.logger.Error("Failed to check permission",
+		s.requestLogger(r).Error("Failed to check permission",
 			zap.Error(err),
 			zap.String("verb", verb),
 			zap.String("resource", resource),
@@ -84,7 +84,7 @@ func (s *Server) handleGetActionPermissions(w http.ResponseWriter, r *http.Reque
 	// Get impersonated clients
 	clients, err := s.GetImpersonatedClients(r)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated clients", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get impersonated clients", zap.Error(err))
 		http.Error(w, "Impersonated clients not available", http.StatusInternalServerError)
 		return
 	}
@@ -93,7 +93,7 @@ func (s *Server) handleGetActionPermissions(w http.ResponseWriter, r *http.Reque
 	permissionHelper := s.impersonationMgr.PermissionHelper()
 	permissions, err := permissionHelper.GetActionPermissions(r.Context(), clients.Client(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to get action permissions",
+		s.requestLogger(r).Error("Failed to get action permissions",
 			zap.Error(err),
 			zap.String("namespace", namespace))
 		http.Error(w, "Failed to get permissions", http.StatusInternalServerError)
@@ -129,7 +129,7 @@ func (s *Server) handleCheckPageAccess(w http.ResponseWriter, r *http.Request) {
 	// Get impersonated clients
 	clients, err := s.GetImpersonatedClients(r)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated clients", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get impersonated clients", zap.Error(err))
 		http.Error(w, "Impersonated clients not available", http.StatusInternalServerError)
 		return
 	}
@@ -138,7 +138,7 @@ func (s *Server) handleCheckPageAccess(w http.ResponseWriter, r *http.Request) {
 	permissionHelper := s.impersonationMgr.PermissionHelper()
 	allowed, err := permissionHelper.CheckPageAccess(r.Context(), clients.Client(), resource, namespace)
 	if err != nil {
-		s.logger.Error("Failed to check page access",
+		s.requestLogger(r).Error("Failed to check page access",
 			zap.Error(err),
 			zap.String("resource", resource),
 			zap.String("namespace", namespace))
@@ -182,7 +182,7 @@ func (s *Server) handleBulkPermissionCheck(w http.ResponseWriter, r *http.Reques
 	// Get impersonated clients
 	clients, err := s.GetImpersonatedClients(r)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated clients", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get impersonated clients", zap.Error(err))
 		http.Error(w, "Impersonated clients not available", http.StatusInternalServerError)
 		return
 	}
@@ -191,7 +191,7 @@ func (s *Server) handleBulkPermissionCheck(w http.ResponseWriter, r *http.Reques
 	permissionHelper := s.impersonationMgr.PermissionHelper()
 	results, err := permissionHelper.CheckMultipleActions(r.Context(), clients.Client(), req.Checks)
 	if err != nil {
-		s.logger.Error("Failed to check bulk permissions", zap.Error(err))
+		s.requestLogger(r).Error("Failed to check bulk permissions", zap.Error(err))
 		http.Error(w, "Failed to check permissions", http.StatusInternalServerError)
 		return
 	}
@@ -223,7 +223,7 @@ func (s *Server) handlePhase6TestPage(w http.ResponseWriter, r *http.Request) {
 	// Get impersonated clients
 	clients, err := s.GetImpersonatedClients(r)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated clients", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get impersonated clients", zap.Error(err))
 		http.Error(w, "Impersonated clients not available", http.StatusInternalServerError)
 		return
 	}
@@ -232,7 +232,7 @@ func (s *Server) handlePhase6TestPage(w http.ResponseWriter, r *http.Request) {
 	permissionHelper := s.impersonationMgr.PermissionHelper()
 	permissions, err := permissionHelper.GetActionPermissions(r.Context(), clients.Client(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to get action permissions", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get action permissions", zap.Error(err))
 		http.Error(w, "Failed to get permissions", http.StatusInternalServerError)
 		return
 	}
@@ -412,7 +412,7 @@ func (s *Server) handleGetUserNamespacePermissions(w http.ResponseWriter, r *htt
 	// Get impersonated clients
 	clients, err := s.GetImpersonatedClients(r)
 	if err != nil {
-		s.logger.Error("Failed to get impersonated clients", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get impersonated clients", zap.Error(err))
 		http.Error(w, "Impersonated clients not available", http.StatusInternalServerError)
 		return
 	}
@@ -420,7 +420,7 @@ func (s *Server) handleGetUserNamespacePermissions(w http.ResponseWriter, r *htt
 	// Get user's namespace permissions using the impersonated username
 	clientset, ok := clients.Client().(*kubernetes.Clientset)
 	if !ok {
-		s.logger.Error("Failed to cast client to Clientset")
+		s.requestLogger(r).Error("Failed to cast client to Clientset")
 		http.Error(w, "Client type error", http.StatusInternalServerError)
 		return
 	}
@@ -433,7 +433,7 @@ func (s *Server) handleGetUserNamespacePermissions(w http.ResponseWriter, r *htt
 
 	permissions, err := GetUserNamespacePermissions(r.Context(), clientset, username)
 	if err != nil {
-		s.logger.Error("Failed to get user namespace permissions",
+		s.requestLogger(r).Error("Failed to get user namespace permissions",
 			zap.Error(err),
 			zap.String("user", user.Email))
 		http.Error(w, "Failed to get permissions", http.StatusInternalServerError)
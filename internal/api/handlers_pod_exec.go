@@ -0,0 +1,130 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/exec"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultExecCommand is run when a pod exec request names no command
+var defaultExecCommand = []string{"/bin/sh"}
+
+// defaultContainerAnnotation names the container kubectl execs into when none
+// is given
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// execCommandError reports why a command may not be run in an exec session,
+// or nil when the configured allowlist admits it
+func (s *Server) execCommandError(command []string) error {
+	allowlist := s.config.Features.ExecAllowedCommands
+	if len(allowlist) == 0 {
+		return fmt.Errorf("pod exec is disabled: no commands are allowed")
+	}
+	if !exec.CommandAllowed(command, allowlist) {
+		return fmt.Errorf("command %q is not on the exec allowlist", command[0])
+	}
+	return nil
+}
+
+// handlePodExecWebSocket handles GET /api/v1/pods/{namespace}/{podName}/exec
+// @Summary Open a terminal in a pod
+// @Description Upgrades to a WebSocket and runs a command (default /bin/sh) in a container of the pod through the API server. Frames are JSON messages: the client sends {"type":"stdin","data":"..."} and {"type":"resize","cols":120,"rows":40}; the server sends "stdout", "stderr" and "error" messages. The executable must be on the configured exec allowlist and the caller needs create permission on pods/exec. Closing the socket ends the command's session.
+// @Tags Pods
+// @Param namespace path string true "Namespace"
+// @Param podName path string true "Pod name"
+// @Param container query string false "Container (default the pod's default container, else its first)"
+// @Param command query []string false "Command and arguments, one query parameter each (default /bin/sh)"
+// @Param tty query bool false "Allocate a terminal (default true)"
+// @Success 101 {string} string "Switching to the WebSocket protocol"
+// @Failure 400 {object} map[string]interface{} "Invalid container"
+// @Failure 403 {object} map[string]interface{} "Command not allowed or permission denied"
+// @Failure 404 {object} map[string]interface{} "Pod not found"
+// @Router /api/v1/pods/{namespace}/{podName}/exec [get]
+func (s *Server) handlePodExecWebSocket(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	podName := chi.URLParam(r, "podName")
+	query := r.URL.Query()
+
+	command := query["command"]
+	if len(command) == 0 {
+		command = defaultExecCommand
+	}
+	if err := s.execCommandError(command); err != nil {
+		writeJSONError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if s.config.Security.AuthMode != "none" {
+		secCtx, err := s.getSecurityContext(r)
+		if err != nil {
+			if secErr, ok := err.(*SecurityError); ok {
+				s.writeSecurityError(w, r, secErr, nil)
+			} else {
+				http.Error(w, "Security context error", http.StatusInternalServerError)
+			}
+			return
+		}
+		if err := s.checkResourcePermission(r.Context(), secCtx, "create", "pods/exec", namespace, podName); err != nil {
+			if secErr, ok := err.(*SecurityError); ok {
+				s.writeSecurityError(w, r, secErr, secCtx.User)
+			} else {
+				http.Error(w, "Permission check failed", http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+
+	pod, err := s.kubeClient.CoreV1().Pods(namespace).Get(r.Context(), podName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			writeJSONError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	container := query.Get("container")
+	if container == "" {
+		container = pod.Annotations[defaultContainerAnnotation]
+	}
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+	if err := ambiguousContainerError(pod, container); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if podContainerNameCounts(pod)[container] == 0 {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("container %q not found in pod %s/%s", container, namespace, podName))
+		return
+	}
+
+	sessionID := "exec-" + uuid.New().String()
+	execReq := exec.ExecRequest{
+		Namespace: namespace,
+		Pod:       podName,
+		Container: container,
+		Command:   command,
+		TTY:       query.Get("tty") != "false",
+	}
+	s.requestLogger(r).Info("Starting pod exec session",
+		zap.String("sessionID", sessionID),
+		zap.String("namespace", namespace),
+		zap.String("pod", podName),
+		zap.String("container", container),
+		zap.String("command", strings.Join(command, " ")))
+
+	if err := s.execService.StartExecSession(w, r, sessionID, execReq); err != nil {
+		s.requestLogger(r).Error("Failed to start exec session",
+			zap.String("sessionID", sessionID),
+			zap.Error(err))
+	}
+}
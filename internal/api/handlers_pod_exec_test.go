@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func podExecRequest(pod, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods/shop/"+pod+"/exec?"+query, nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("namespace", "shop")
+	routeCtx.URLParams.Add("podName", pod)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+}
+
+func TestHandlePodExecWebSocketValidation(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Security.AuthMode = "none"
+	cfg.Features.ExecAllowedCommands = []string{"/bin/sh", "bash"}
+	s := &Server{
+		logger:     zap.NewNop(),
+		config:     cfg,
+		kubeClient: fake.NewSimpleClientset(collidingPod()),
+	}
+
+	tests := []struct {
+		name   string
+		pod    string
+		query  string
+		status int
+		body   string
+	}{
+		{"command not on allowlist", "web-0", "command=python3", http.StatusForbidden, "is not on the exec allowlist"},
+		{"missing pod", "api-0", "", http.StatusNotFound, "not found"},
+		{"ambiguous container", "web-0", "container=app&command=bash", http.StatusBadRequest, "it matches 2 containers"},
+		{"default container is ambiguous", "web-0", "", http.StatusBadRequest, "it matches 2 containers"},
+		{"unknown container", "web-0", "container=db", http.StatusBadRequest, "not found in pod shop/web-0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.handlePodExecWebSocket(rec, podExecRequest(tt.pod, tt.query))
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.body)
+		})
+	}
+
+	// An empty allowlist disables exec entirely
+	cfg.Features.ExecAllowedCommands = nil
+	rec := httptest.NewRecorder()
+	s.handlePodExecWebSocket(rec, podExecRequest("web-0", "container=sidecar"))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "pod exec is disabled")
+}
+
+func TestHandleExecWebSocketShellAllowlist(t *testing.T) {
+	cfg := &config.Config{}
+	s := &Server{
+		logger:     zap.NewNop(),
+		config:     cfg,
+		kubeClient: fake.NewSimpleClientset(collidingPod()),
+	}
+	execRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/exec/session-1?namespace=shop&pod=web-0&container=sidecar", nil)
+		routeCtx := chi.NewRouteContext()
+		routeCtx.URLParams.Add("sessionId", "session-1")
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+	}
+
+	// Without a command the session detects a shell, which must be allowed too
+	rec := httptest.NewRecorder()
+	s.handleExecWebSocket(rec, execRequest())
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "pod exec is disabled")
+
+	cfg.Features.ExecAllowedCommands = []string{"python3"}
+	rec = httptest.NewRecorder()
+	s.handleExecWebSocket(rec, execRequest())
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "no shell is on the exec allowlist")
+}
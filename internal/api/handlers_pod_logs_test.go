@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func podLogsRequest(query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods/shop/web-0/logs?"+query, nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("namespace", "shop")
+	routeCtx.URLParams.Add("podName", "web-0")
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+}
+
+func TestHandleGetPodLogsPreviousAndAllContainers(t *testing.T) {
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	client := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "migrate"}},
+			Containers:     []v1.Container{{Name: "web"}},
+		},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{{Name: "migrate", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{}}}},
+			ContainerStatuses:     []v1.ContainerStatus{{Name: "web", State: running}},
+		},
+	})
+	s := &Server{
+		logger:          zap.NewNop(),
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+
+	// The only container has never restarted
+	rec := httptest.NewRecorder()
+	s.handleGetPodLogs(rec, podLogsRequest("previous=true"))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "container web of pod shop/web-0 has not restarted")
+
+	rec = httptest.NewRecorder()
+	s.handleGetPodLogs(rec, podLogsRequest("allContainers=true&tailLines=10"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response struct {
+		Data struct {
+			Containers map[string]string `json:"containers"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, map[string]string{"migrate": "fake logs", "web": "fake logs"}, response.Data.Containers)
+
+	rec = httptest.NewRecorder()
+	s.handleGetPodLogs(rec, podLogsRequest("allContainers=true&previous=true"))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandleGetPodLogsSinceLastRestart(t *testing.T) {
+	started := metav1.NewTime(time.Date(2026, 3, 1, 10, 5, 0, 0, time.UTC))
+	lastStarted := metav1.NewTime(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	lastFinished := metav1.NewTime(time.Date(2026, 3, 1, 10, 4, 30, 0, time.UTC))
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "web"}}},
+		Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+			Name:                 "web",
+			State:                v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: started}},
+			RestartCount:         2,
+			LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{StartedAt: lastStarted, FinishedAt: lastFinished}},
+		}}},
+	}
+	client := fake.NewSimpleClientset(pod)
+	s := &Server{
+		logger:          zap.NewNop(),
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleGetPodLogs(rec, podLogsRequest("sinceLastRestart=true"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "fake logs", rec.Body.String())
+	assert.Equal(t, "web", rec.Header().Get("X-Log-Container"))
+	assert.Equal(t, "2026-03-01T10:05:00Z", rec.Header().Get("X-Log-Since"))
+	assert.Empty(t, rec.Header().Get("X-Log-Until"))
+
+	rec = httptest.NewRecorder()
+	s.handleGetPodLogs(rec, podLogsRequest("sinceLastRestart=true&previous=true&container=web"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "2026-03-01T10:00:00Z", rec.Header().Get("X-Log-Since"))
+	assert.Equal(t, "2026-03-01T10:04:30Z", rec.Header().Get("X-Log-Until"))
+
+	rec = httptest.NewRecorder()
+	s.handleGetPodLogs(rec, podLogsRequest("sinceLastRestart=true&allContainers=true"))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// While crash-looping only the previous instance has logs
+	pod.Status.ContainerStatuses[0].State = v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+	_, err := client.CoreV1().Pods("shop").UpdateStatus(context.Background(), pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	rec = httptest.NewRecorder()
+	s.handleGetPodLogs(rec, podLogsRequest("sinceLastRestart=true"))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "use previous")
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// podRecommendation is the sizing advice for a pod's CPU and memory
+type podRecommendation struct {
+	Namespace  string                 `json:"namespace"`
+	Pod        string                 `json:"pod"`
+	Resolution string                 `json:"resolution"`
+	CPU        resourceRecommendation `json:"cpu"`
+	Memory     resourceRecommendation `json:"memory"`
+}
+
+// podResourceTotal sums a request or limit over the pod's containers. It is nil
+// unless every container sets it, as a partial total would understate the pod.
+func podResourceTotal(pod *v1.Pod, name v1.ResourceName, limits bool) *float64 {
+	if len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+	var total float64
+	for _, container := range pod.Spec.Containers {
+		list := container.Resources.Requests
+		if limits {
+			list = container.Resources.Limits
+		}
+		quantity, ok := list[name]
+		if !ok {
+			return nil
+		}
+		total += quantity.AsApproximateFloat64()
+	}
+	return &total
+}
+
+// seriesPoints returns every point of a series at res, or nil when it is missing
+func (s *Server) seriesPoints(key string, res timeseries.Resolution) []timeseries.Point {
+	if s.timeSeriesStore == nil {
+		return nil
+	}
+	series, ok := s.timeSeriesStore.Get(key)
+	if !ok {
+		return nil
+	}
+	return series.GetAll(res)
+}
+
+// buildPodRecommendation sizes the pod's CPU from its usage series and its
+// memory from its working set series
+func (s *Server) buildPodRecommendation(pod *v1.Pod, res timeseries.Resolution, resName string) podRecommendation {
+	cpu := s.seriesPoints(timeseries.GeneratePodSeriesKey(timeseries.PodCPUUsageBase, pod.Namespace, pod.Name), res)
+	memory := s.seriesPoints(timeseries.GeneratePodSeriesKey(timeseries.PodMemWorkingSetBase, pod.Namespace, pod.Name), res)
+	return podRecommendation{
+		Namespace:  pod.Namespace,
+		Pod:        pod.Name,
+		Resolution: resName,
+		CPU: recommend(cpu,
+			podResourceTotal(pod, v1.ResourceCPU, false),
+			podResourceTotal(pod, v1.ResourceCPU, true)),
+		Memory: recommend(memory,
+			podResourceTotal(pod, v1.ResourceMemory, false),
+			podResourceTotal(pod, v1.ResourceMemory, true)),
+	}
+}
+
+// handleGetPodRecommendation handles GET /api/v1/pods/{namespace}/{name}/recommendation
+// @Summary Get pod resource recommendation
+// @Description Suggests CPU and memory requests and limits from the p95 of the pod's usage history plus headroom, and flags the current requests as ok, over-provisioned or under-provisioned. A resource with too little history has the verdict "insufficient data".
+// @Tags Pods
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param name path string true "Pod name"
+// @Param res query string false "Resolution of the usage history (hi, med, lo)" default(med)
+// @Success 200 {object} map[string]interface{} "Pod resource recommendation"
+// @Failure 400 {object} map[string]interface{} "Invalid resolution"
+// @Failure 404 {object} map[string]interface{} "Pod not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/pods/{namespace}/{name}/recommendation [get]
+func (s *Server) handleGetPodRecommendation(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	writeError := func(status int, message string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":  message,
+			"status": "error",
+		})
+	}
+
+	resName := r.URL.Query().Get("res")
+	if resName == "" {
+		resName = "med"
+	}
+	res, ok := timeseries.ParseResolution(resName)
+	if !ok {
+		writeError(http.StatusBadRequest, "Invalid resolution. Must be 'hi', 'med' or 'lo'")
+		return
+	}
+
+	pod, err := s.callerResourceManager(r).GetPod(r.Context(), namespace, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			writeError(http.StatusNotFound, err.Error())
+			return
+		}
+		s.requestLogger(r).Error("Failed to get pod for recommendation",
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+		writeError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   s.buildPodRecommendation(pod, res, resName),
+		"status": "success",
+	})
+}
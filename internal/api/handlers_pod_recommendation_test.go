@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func podRecommendationRequest(namespace, name, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods/"+namespace+"/"+name+"/recommendation"+query, nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("namespace", namespace)
+	routeCtx.URLParams.Add("name", name)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+}
+
+// addUsage records values one second apart, ending now
+func addUsage(store timeseries.Store, key string, values func(i int) float64, count int) {
+	series := store.Upsert(key)
+	start := time.Now().Add(-time.Duration(count) * time.Second)
+	for i := 0; i < count; i++ {
+		series.Add(timeseries.NewPoint(start.Add(time.Duration(i)*time.Second), values(i)))
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := make([]float64, 0, 101)
+	for i := 100; i >= 0; i-- {
+		values = append(values, float64(i))
+	}
+	assert.Equal(t, 95.0, percentile(values, 95))
+	assert.Equal(t, 50.0, percentile(values, 50))
+	assert.Equal(t, 3.0, percentile([]float64{3}, 95))
+	assert.InDelta(t, 1.5, percentile([]float64{2, 1}, 50), 1e-9)
+}
+
+func TestRecommend(t *testing.T) {
+	points := make([]timeseries.Point, 101)
+	for i := range points {
+		points[i] = timeseries.NewPoint(time.Unix(int64(i), 0), float64(i)/100)
+	}
+	value := func(v float64) *float64 { return &v }
+
+	rec := recommend(points, value(1), nil)
+	require.NotNil(t, rec.P95Usage)
+	assert.InDelta(t, 0.95, *rec.P95Usage, 1e-9)
+	assert.InDelta(t, 1.0, *rec.MaxUsage, 1e-9)
+	assert.InDelta(t, 0.95*1.15, *rec.RecommendedRequest, 1e-9)
+	assert.InDelta(t, 0.95*1.5, *rec.RecommendedLimit, 1e-9)
+	assert.Equal(t, verdictOK, rec.Verdict)
+
+	assert.Equal(t, verdictUnderProvisioned, recommend(points, value(0.5), nil).Verdict)
+	assert.Equal(t, verdictUnderProvisioned, recommend(points, value(1), value(1)).Verdict)
+	assert.Equal(t, verdictOverProvisioned, recommend(points, value(4), nil).Verdict)
+	assert.Equal(t, verdictNoRequest, recommend(points, nil, nil).Verdict)
+
+	short := recommend(points[:minRecommendationSamples-1], value(1), nil)
+	assert.Equal(t, verdictInsufficientData, short.Verdict)
+	assert.Equal(t, minRecommendationSamples-1, short.Samples)
+	assert.Nil(t, short.RecommendedRequest)
+}
+
+func TestHandleGetPodRecommendation(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "app",
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("1500m"),
+							v1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+					},
+				},
+				{
+					Name: "sidecar",
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("500m"),
+							v1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	// CPU usage ramps from 0 to 0.5 cores, so p95 is 0.475 against a 2 core request
+	addUsage(store, timeseries.GeneratePodSeriesKey(timeseries.PodCPUUsageBase, "shop", "web-0"),
+		func(i int) float64 { return float64(i) / 200 }, 101)
+	// Memory only has a few samples
+	addUsage(store, timeseries.GeneratePodSeriesKey(timeseries.PodMemWorkingSetBase, "shop", "web-0"),
+		func(i int) float64 { return 100 << 20 }, 5)
+
+	client := fake.NewSimpleClientset(pod)
+	s := &Server{logger: zap.NewNop(), kubeClient: client, resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil), timeSeriesStore: store}
+
+	rec := httptest.NewRecorder()
+	s.handleGetPodRecommendation(rec, podRecommendationRequest("shop", "web-0", "?res=hi"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response struct {
+		Data podRecommendation `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	recommendation := response.Data
+	assert.Equal(t, "hi", recommendation.Resolution)
+
+	cpu := recommendation.CPU
+	assert.Equal(t, 101, cpu.Samples)
+	require.NotNil(t, cpu.CurrentRequest)
+	assert.InDelta(t, 2.0, *cpu.CurrentRequest, 1e-9)
+	assert.Nil(t, cpu.CurrentLimit)
+	require.NotNil(t, cpu.RecommendedRequest)
+	assert.InDelta(t, 0.475*1.15, *cpu.RecommendedRequest, 1e-9)
+	assert.Equal(t, verdictOverProvisioned, cpu.Verdict)
+
+	memory := recommendation.Memory
+	assert.Equal(t, 5, memory.Samples)
+	assert.Equal(t, verdictInsufficientData, memory.Verdict)
+	require.NotNil(t, memory.CurrentRequest)
+	assert.InDelta(t, float64(128<<20), *memory.CurrentRequest, 1)
+
+	rec = httptest.NewRecorder()
+	s.handleGetPodRecommendation(rec, podRecommendationRequest("shop", "web-0", "?res=bogus"))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	s.handleGetPodRecommendation(rec, podRecommendationRequest("shop", "missing", ""))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
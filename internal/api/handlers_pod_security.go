@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// handleSetNamespacePodSecurity handles POST /api/v1/namespaces/{name}/pod-security
+// @Summary Set namespace Pod Security Admission levels
+// @Description Sets the enforce, audit and warn Pod Security Admission levels and versions of a namespace by patching its pod-security.kubernetes.io labels. Omitted modes are left unchanged; a mode with an empty level is removed.
+// @Tags Namespaces
+// @Accept json
+// @Produce json
+// @Param name path string true "Namespace name"
+// @Param body body resources.PodSecurityLabels true "Pod security settings"
+// @Success 200 {object} map[string]interface{} "Updated pod security settings"
+// @Failure 400 {object} map[string]interface{} "Invalid level or version"
+// @Failure 404 {object} map[string]interface{} "Namespace not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/namespaces/{name}/pod-security [post]
+func (s *Server) handleSetNamespacePodSecurity(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+
+	var req resources.PodSecurityLabels
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	settings, err := s.resourceManager.SetNamespacePodSecurity(s.mutationContext(r), name, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.IsNotFound(err) {
+			status = http.StatusNotFound
+		}
+		s.requestLogger(r).Error("Failed to set namespace pod security",
+			zap.String("namespace", name),
+			zap.Error(err))
+		writeJSONError(w, status, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   settings,
+		"status": "success",
+	})
+}
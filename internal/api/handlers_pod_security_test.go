@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newPodSecurityRequest(name, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/"+name+"/pod-security", strings.NewReader(body))
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("name", name)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+}
+
+func TestFormatNamespaceSummaryIncludesPodSecurity(t *testing.T) {
+	summary := formatNamespaceSummary(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name: "payments",
+		Labels: map[string]string{
+			"pod-security.kubernetes.io/enforce":         "restricted",
+			"pod-security.kubernetes.io/enforce-version": "v1.30",
+			"pod-security.kubernetes.io/audit":           "baseline",
+		},
+	}})
+
+	podSecurity, ok := summary["podSecurity"].(resources.PodSecurityLabels)
+	require.True(t, ok)
+	assert.Equal(t, &resources.PodSecuritySetting{Level: "restricted", Version: "v1.30"}, podSecurity.Enforce)
+	assert.Equal(t, &resources.PodSecuritySetting{Level: "baseline"}, podSecurity.Audit)
+	assert.Nil(t, podSecurity.Warn)
+}
+
+func TestSetNamespacePodSecurity(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}})
+	s := &Server{logger: zap.NewNop(), kubeClient: client, resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil)}
+
+	rec := httptest.NewRecorder()
+	s.handleSetNamespacePodSecurity(rec, newPodSecurityRequest("payments", `{"enforce":{"level":"baseline","version":"latest"}}`))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp struct {
+		Data resources.PodSecurityLabels `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, &resources.PodSecuritySetting{Level: "baseline", Version: "latest"}, resp.Data.Enforce)
+
+	namespace, err := client.CoreV1().Namespaces().Get(context.Background(), "payments", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "baseline", namespace.Labels["pod-security.kubernetes.io/enforce"])
+}
+
+func TestSetNamespacePodSecurityRejectsInvalidLevel(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}})
+	s := &Server{logger: zap.NewNop(), kubeClient: client, resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil)}
+
+	rec := httptest.NewRecorder()
+	s.handleSetNamespacePodSecurity(rec, newPodSecurityRequest("payments", `{"warn":{"level":"paranoid"}}`))
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `warn: invalid level \"paranoid\"`)
+
+	namespace, err := client.CoreV1().Namespaces().Get(context.Background(), "payments", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, namespace.Labels)
+}
+
+func TestSetNamespacePodSecurityNotFound(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	s := &Server{logger: zap.NewNop(), kubeClient: client, resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil)}
+
+	rec := httptest.NewRecorder()
+	s.handleSetNamespacePodSecurity(rec, newPodSecurityRequest("missing", `{"enforce":{"level":"restricted"}}`))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"go.uber.org/zap"
+)
+
+// handleDeletePodsBySelector handles POST /api/v1/pods/delete-by-selector
+// @Summary Delete pods matching a label selector
+// @Description Deletes the pods of a namespace that match a label selector, as listed by the pod informer. Deletions run concurrently and report per-pod results. Requests matching more pods than the safety cap are rejected; dryRun returns the targeted pods without deleting them.
+// @Tags Pods
+// @Accept json
+// @Produce json
+// @Param body body resources.PodSelectorDeleteRequest true "Selector delete request"
+// @Success 200 {object} map[string]interface{} "Targeted and deleted pods"
+// @Failure 400 {object} map[string]interface{} "Invalid request or safety cap exceeded"
+// @Failure 503 {object} map[string]interface{} "Pod informer unavailable"
+// @Router /api/v1/pods/delete-by-selector [post]
+func (s *Server) handleDeletePodsBySelector(w http.ResponseWriter, r *http.Request) {
+	var req resources.PodSelectorDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if s.informerManager == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "pod informer is not available")
+		return
+	}
+
+	result, err := s.resourceManager.DeletePodsBySelector(s.mutationContext(r), s.listInformerPods(), req, resources.DefaultMaxSelectorPodDeletes)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.requestLogger(r).Info("Pods deleted by selector",
+		zap.String("namespace", req.Namespace),
+		zap.String("labelSelector", req.LabelSelector),
+		zap.Int("targeted", len(result.Targeted)),
+		zap.Int("deleted", len(result.Deleted)),
+		zap.Bool("dryRun", req.DryRun))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   result,
+		"status": "success",
+	})
+}
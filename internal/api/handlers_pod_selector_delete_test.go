@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeletePodsBySelectorLeavesNonMatchingPods(t *testing.T) {
+	pods := []*v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "stuck-1", Namespace: "batch", Labels: map[string]string{"job": "nightly"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "stuck-2", Namespace: "batch", Labels: map[string]string{"job": "nightly"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "keeper", Namespace: "batch", Labels: map[string]string{"job": "hourly"}}},
+	}
+	client := fake.NewSimpleClientset(pods[0], pods[1], pods[2])
+	manager := informers.NewManager(zap.NewNop(), client, nil)
+	for _, pod := range pods {
+		require.NoError(t, manager.PodsInformer.GetIndexer().Add(pod))
+	}
+	s := &Server{
+		logger:          zap.NewNop(),
+		kubeClient:      client,
+		informerManager: manager,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+
+	body := `{"namespace":"batch","labelSelector":"job=nightly","gracePeriodSeconds":0}`
+	rec := httptest.NewRecorder()
+	s.handleDeletePodsBySelector(rec, httptest.NewRequest(http.MethodPost, "/api/v1/pods/delete-by-selector", strings.NewReader(body)))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp struct {
+		Data resources.PodSelectorDeleteResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"stuck-1", "stuck-2"}, resp.Data.Targeted)
+	assert.Equal(t, []string{"stuck-1", "stuck-2"}, resp.Data.Deleted)
+
+	remaining, err := client.CoreV1().Pods("batch").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, remaining.Items, 1)
+	assert.Equal(t, "keeper", remaining.Items[0].Name)
+}
+
+func TestDeletePodsBySelectorRejectsMissingSelector(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	s := &Server{
+		logger:          zap.NewNop(),
+		informerManager: informers.NewManager(zap.NewNop(), client, nil),
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleDeletePodsBySelector(rec, httptest.NewRequest(http.MethodPost, "/api/v1/pods/delete-by-selector", strings.NewReader(`{"namespace":"batch"}`)))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "labelSelector is required")
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/aaronlmathis/kaptn/internal/k8s/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func newPodListTestServer(t *testing.T, pods ...*v1.Pod) *Server {
+	t.Helper()
+
+	client := fake.NewSimpleClientset()
+	manager := informers.NewManager(zap.NewNop(), client, nil)
+	for _, pod := range pods {
+		require.NoError(t, manager.PodsInformer.GetIndexer().Add(pod))
+	}
+
+	cfg := &config.Config{}
+	cfg.Security.AuthMode = "none"
+
+	return &Server{
+		logger:          zap.NewNop(),
+		config:          cfg,
+		informerManager: manager,
+		metricsService:  metrics.NewMetricsService(zap.NewNop(), client, metricsfake.NewSimpleClientset().MetricsV1beta1()),
+	}
+}
+
+func TestListPodsFieldSelectorByNodeName(t *testing.T) {
+	s := newPodListTestServer(t,
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "on-node-1", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-1"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "on-node-2", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-2"}},
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods?fieldSelector=spec.nodeName%3Dnode-1", nil)
+	rec := httptest.NewRecorder()
+	s.handleListPods(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response struct {
+		Data struct {
+			Items []map[string]interface{} `json:"items"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Data.Items, 1)
+	assert.Equal(t, "on-node-1", response.Data.Items[0]["name"])
+}
+
+func TestListPodsFieldSelectorByPhaseAndNamespace(t *testing.T) {
+	s := newPodListTestServer(t,
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "shop"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "shop"}, Status: v1.PodStatus{Phase: v1.PodPending}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "other"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods?fieldSelector=status.phase%3DRunning,metadata.namespace%3Dshop", nil)
+	rec := httptest.NewRecorder()
+	s.handleListPods(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response struct {
+		Data struct {
+			Items []map[string]interface{} `json:"items"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Data.Items, 1)
+	assert.Equal(t, "running", response.Data.Items[0]["name"])
+	assert.Equal(t, "shop", response.Data.Items[0]["namespace"])
+}
+
+func TestListPodsRejectsUnsupportedFieldLabel(t *testing.T) {
+	s := newPodListTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods?fieldSelector=spec.hostname%3Dweb", nil)
+	rec := httptest.NewRecorder()
+	s.handleListPods(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "spec.hostname")
+}
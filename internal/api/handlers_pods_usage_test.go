@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/aaronlmathis/kaptn/internal/k8s/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+// newSlowMetricsPodListServer returns a pod list server whose metrics API
+// blocks until release is closed
+func newSlowMetricsPodListServer(t *testing.T, release <-chan struct{}, pods ...*v1.Pod) *Server {
+	t.Helper()
+
+	client := fake.NewSimpleClientset()
+	manager := informers.NewManager(zap.NewNop(), client, nil)
+	for _, pod := range pods {
+		require.NoError(t, manager.PodsInformer.GetIndexer().Add(pod))
+	}
+
+	metricsClient := metricsfake.NewSimpleClientset()
+	metricsClient.PrependReactor("list", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		<-release
+		return false, nil, nil
+	})
+
+	cfg := &config.Config{}
+	cfg.Security.AuthMode = "none"
+
+	return &Server{
+		logger:          zap.NewNop(),
+		config:          cfg,
+		informerManager: manager,
+		metricsService:  metrics.NewMetricsService(zap.NewNop(), client, metricsClient.MetricsV1beta1()),
+		podUsageWait:    50 * time.Millisecond,
+	}
+}
+
+func decodePodList(t *testing.T, rec *httptest.ResponseRecorder) (items []map[string]interface{}, metricsPending bool) {
+	t.Helper()
+
+	var response struct {
+		Data struct {
+			Items          []map[string]interface{} `json:"items"`
+			MetricsPending bool                     `json:"metricsPending"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return response.Data.Items, response.Data.MetricsPending
+}
+
+func TestListPodsReturnsPromptlyWhenMetricsAreSlow(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	s := newSlowMetricsPodListServer(t, release,
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	s.handleListPods(rec, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+	assert.Less(t, time.Since(start), time.Second)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	items, pending := decodePodList(t, rec)
+	require.Len(t, items, 1)
+	assert.Equal(t, "web", items[0]["name"])
+	assert.True(t, pending)
+}
+
+func TestListPodsWaitsForFastMetrics(t *testing.T) {
+	s := newPodListTestServer(t,
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+
+	rec := httptest.NewRecorder()
+	s.handleListPods(rec, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	items, pending := decodePodList(t, rec)
+	require.Len(t, items, 1)
+	assert.False(t, pending)
+}
+
+func TestPodUsageFetchOutlivesTheRequest(t *testing.T) {
+	release := make(chan struct{})
+	s := newSlowMetricsPodListServer(t, release,
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rec := httptest.NewRecorder()
+	s.handleListPods(rec, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil).WithContext(ctx))
+	_, pending := decodePodList(t, rec)
+	require.True(t, pending)
+
+	// The server cancels the request context once the handler returns; the
+	// fetch must still complete and fill the cache for later requests
+	cancel()
+	close(release)
+	assert.Eventually(t, func() bool {
+		return s.podUsageCache.load(time.Now(), podUsageCacheMaxAge) != nil
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestAwaitPodUsageFallsBackToCachedUsage(t *testing.T) {
+	running := func() *podUsageFetch { return &podUsageFetch{done: make(chan struct{})} }
+
+	s := &Server{podUsageWait: 10 * time.Millisecond}
+	cached := podUsage{"default/web": {"cpu": map[string]interface{}{"milli": 100}}}
+	s.podUsageCache.store(cached, time.Now())
+
+	usage, pending := s.awaitPodUsage(running(), time.Now())
+	assert.True(t, pending)
+	assert.Equal(t, cached, usage)
+
+	// Usage older than the cache limit is not served
+	s = &Server{podUsageWait: 10 * time.Millisecond}
+	s.podUsageCache.store(cached, time.Now().Add(-2*podUsageCacheMaxAge))
+	usage, _ = s.awaitPodUsage(running(), time.Now())
+	assert.Empty(t, usage)
+}
+
+func TestPodUsageCacheKeepsNewerUsage(t *testing.T) {
+	var cache podUsageCache
+	now := time.Now()
+	newer := podUsage{"default/web": {"cpu": map[string]interface{}{"milli": 200}}}
+	older := podUsage{"default/web": {"cpu": map[string]interface{}{"milli": 100}}}
+
+	cache.store(newer, now)
+	cache.store(older, now.Add(-time.Second))
+	assert.Equal(t, newer, cache.load(now, podUsageCacheMaxAge))
+}
+
+func TestPodUsageFetchIsShared(t *testing.T) {
+	release := make(chan struct{})
+	s := newSlowMetricsPodListServer(t, release)
+
+	// Requests arriving while a fetch runs join it instead of scraping again
+	first := s.startPodUsageFetch(context.Background())
+	second := s.startPodUsageFetch(context.Background())
+	assert.Same(t, first, second)
+
+	close(release)
+	select {
+	case <-first.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the usage fetch")
+	}
+	require.NotNil(t, first.usage)
+
+	// Fresh cached usage is served without another fetch
+	third := s.startPodUsageFetch(context.Background())
+	assert.NotSame(t, first, third)
+	select {
+	case <-third.done:
+	default:
+		t.Fatal("fresh cached usage should not start a fetch")
+	}
+	assert.Equal(t, first.usage, third.usage)
+	assert.Nil(t, s.podUsageCache.inflight)
+}
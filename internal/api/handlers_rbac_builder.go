@@ -60,7 +60,7 @@ type ApplyResult struct {
 func (s *Server) handleGenerateRBACYAML(w http.ResponseWriter, r *http.Request) {
 	var formData RBACFormData
 	if err := json.NewDecoder(r.Body).Decode(&formData); err != nil {
-		s.logger.Error("Failed to decode request body", zap.Error(err))
+		s.requestLogger(r).Error("Failed to decode request body", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
@@ -69,7 +69,7 @@ func (s *Server) handleGenerateRBACYAML(w http.ResponseWriter, r *http.Request)
 
 	// Validate form data
 	if err := s.validateRBACFormData(&formData); err != nil {
-		s.logger.Error("Invalid form data", zap.Error(err))
+		s.requestLogger(r).Error("Invalid form data", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
@@ -79,7 +79,7 @@ func (s *Server) handleGenerateRBACYAML(w http.ResponseWriter, r *http.Request)
 	// Generate YAML
 	generatedYAML, err := s.generateRBACYAMLFromForm(&formData)
 	if err != nil {
-		s.logger.Error("Failed to generate YAML", zap.Error(err))
+		s.requestLogger(r).Error("Failed to generate YAML", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to generate YAML"})
@@ -93,7 +93,7 @@ func (s *Server) handleGenerateRBACYAML(w http.ResponseWriter, r *http.Request)
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		s.logger.Error("Failed to encode response", zap.Error(err))
+		s.requestLogger(r).Error("Failed to encode response", zap.Error(err))
 	}
 }
 
@@ -111,7 +111,7 @@ func (s *Server) handleGenerateRBACYAML(w http.ResponseWriter, r *http.Request)
 func (s *Server) handleDryRunRBAC(w http.ResponseWriter, r *http.Request) {
 	var formData RBACFormData
 	if err := json.NewDecoder(r.Body).Decode(&formData); err != nil {
-		s.logger.Error("Failed to decode request body", zap.Error(err))
+		s.requestLogger(r).Error("Failed to decode request body", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
@@ -120,7 +120,7 @@ func (s *Server) handleDryRunRBAC(w http.ResponseWriter, r *http.Request) {
 
 	// Validate form data
 	if err := s.validateRBACFormData(&formData); err != nil {
-		s.logger.Error("Invalid form data", zap.Error(err))
+		s.requestLogger(r).Error("Invalid form data", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
@@ -130,7 +130,7 @@ func (s *Server) handleDryRunRBAC(w http.ResponseWriter, r *http.Request) {
 	// Perform dry run
 	result, err := s.dryRunRBACConfiguration(r.Context(), &formData)
 	if err != nil {
-		s.logger.Error("Failed to perform dry run", zap.Error(err))
+		s.requestLogger(r).Error("Failed to perform dry run", zap.Error(err))
 		result = &ApplyResult{
 			Success: false,
 			Error:   fmt.Sprintf("Dry run failed: %v", err),
@@ -144,7 +144,7 @@ func (s *Server) handleDryRunRBAC(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		s.logger.Error("Failed to encode response", zap.Error(err))
+		s.requestLogger(r).Error("Failed to encode response", zap.Error(err))
 	}
 }
 
@@ -162,7 +162,7 @@ func (s *Server) handleDryRunRBAC(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleApplyRBAC(w http.ResponseWriter, r *http.Request) {
 	var formData RBACFormData
 	if err := json.NewDecoder(r.Body).Decode(&formData); err != nil {
-		s.logger.Error("Failed to decode request body", zap.Error(err))
+		s.requestLogger(r).Error("Failed to decode request body", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
@@ -171,7 +171,7 @@ func (s *Server) handleApplyRBAC(w http.ResponseWriter, r *http.Request) {
 
 	// Validate form data
 	if err := s.validateRBACFormData(&formData); err != nil {
-		s.logger.Error("Invalid form data", zap.Error(err))
+		s.requestLogger(r).Error("Invalid form data", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
@@ -181,7 +181,7 @@ func (s *Server) handleApplyRBAC(w http.ResponseWriter, r *http.Request) {
 	// Apply to cluster
 	result, err := s.applyRBACConfiguration(r.Context(), &formData)
 	if err != nil {
-		s.logger.Error("Failed to apply RBAC configuration", zap.Error(err))
+		s.requestLogger(r).Error("Failed to apply RBAC configuration", zap.Error(err))
 		result = &ApplyResult{
 			Success: false,
 			Error:   fmt.Sprintf("Apply failed: %v", err),
@@ -195,7 +195,7 @@ func (s *Server) handleApplyRBAC(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		s.logger.Error("Failed to encode response", zap.Error(err))
+		s.requestLogger(r).Error("Failed to encode response", zap.Error(err))
 	}
 }
 
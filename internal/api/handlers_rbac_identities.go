@@ -125,7 +125,7 @@ func (s *Server) handleListRBACIdentities(w http.ResponseWriter, r *http.Request
 	// Discover identities from bindings
 	identities, err := s.discoverRBACIdentities(r.Context(), kindFilter, namespace, includeBindings, includeRoles)
 	if err != nil {
-		s.logger.Error("Failed to discover RBAC identities", zap.Error(err))
+		s.requestLogger(r).Error("Failed to discover RBAC identities", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/analysis"
+)
+
+// handleGetRecentlyDeleted handles GET /api/v1/recently-deleted
+// @Summary Recently deleted objects
+// @Description Objects whose deletion was observed by the informers, newest first, with the deletion time and last known owner. The list is kept in memory, is bounded in size and age, and starts empty on restart.
+// @Tags Analysis
+// @Produce json
+// @Param kind query string false "Only deletions of this kind, e.g. pod, deployment, configmap"
+// @Param namespace query string false "Only deletions in this namespace"
+// @Success 200 {array} informers.DeletionRecord "Recently deleted objects"
+// @Failure 400 {object} map[string]interface{} "Unsupported kind"
+// @Failure 503 {object} map[string]interface{} "Informer cache not ready"
+// @Router /api/v1/recently-deleted [get]
+func (s *Server) handleGetRecentlyDeleted(w http.ResponseWriter, r *http.Request) {
+	var kind string
+	if raw := r.URL.Query().Get("kind"); strings.TrimSpace(raw) != "" {
+		parsed, err := analysis.ParseAgeKind(raw)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		kind = parsed
+	}
+
+	if s.informerManager == nil || s.informerManager.Deletions == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Informer cache not available")
+		return
+	}
+
+	records := s.informerManager.Deletions.List(kind, r.URL.Query().Get("namespace"))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   records,
+		"status": "success",
+	})
+}
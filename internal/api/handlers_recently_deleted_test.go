@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHandleGetRecentlyDeleted(t *testing.T) {
+	manager := informers.NewManager(zap.NewNop(), fake.NewSimpleClientset(), nil)
+	manager.Deletions.Record("pods", &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"}})
+	manager.Deletions.Record("configmaps", &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "shop"}})
+	manager.Deletions.Record("pods", &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-0", Namespace: "prod"}})
+
+	s := &Server{logger: zap.NewNop(), informerManager: manager}
+
+	get := func(query string) (int, []informers.DeletionRecord) {
+		rec := httptest.NewRecorder()
+		s.handleGetRecentlyDeleted(rec, httptest.NewRequest(http.MethodGet, "/api/v1/recently-deleted"+query, nil))
+		var response struct {
+			Data []informers.DeletionRecord `json:"data"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		return rec.Code, response.Data
+	}
+
+	code, records := get("")
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, records, 3)
+
+	code, records = get("?kind=pod&namespace=shop")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, records, 1)
+	assert.Equal(t, "web-0", records[0].Name)
+
+	code, _ = get("?kind=widgets")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	rec := httptest.NewRecorder()
+	(&Server{logger: zap.NewNop()}).handleGetRecentlyDeleted(rec, httptest.NewRequest(http.MethodGet, "/api/v1/recently-deleted", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// maxApplyManifestBytes bounds the manifests accepted by handlePostApply
+const maxApplyManifestBytes = 4 << 20
+
+// applyContentTypes are the media types handlePostApply accepts
+var applyContentTypes = map[string]bool{
+	"application/yaml":   true,
+	"application/x-yaml": true,
+	"text/yaml":          true,
+}
+
+// applyErrorStatus maps an ApplyResource or DiffResource error to an HTTP
+// status: the API server's status when it rejected a document, 400 for
+// manifests that cannot be decoded or name unknown kinds, and 500 otherwise
+func applyErrorStatus(err error) int {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		return int(status.Status().Code)
+	}
+	if errors.Is(err, resources.ErrInvalidManifest) || meta.IsNoMatchError(err) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// callerResourceManager returns a resource manager acting as the caller when
+// the request carries impersonated clients, so the API server enforces their
+// RBAC permissions, and the server's own manager otherwise
+func (s *Server) callerResourceManager(r *http.Request) *resources.ResourceManager {
+	clients, err := s.GetImpersonatedClients(r)
+	if err != nil {
+		return s.resourceManager
+	}
+	rm := resources.NewResourceManager(s.logger, clients.Client(), clients.DynamicClient())
+	rm.SetMutationAnnotations(s.config.Features.AnnotateMutations)
+	return rm
+}
+
+// readManifest reads a YAML request body, writing an error and returning
+// false when it is not YAML or cannot be read
+func readManifest(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || !applyContentTypes[mediaType] {
+			writeJSONError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/yaml")
+			return nil, false
+		}
+	}
+
+	manifest, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxApplyManifestBytes))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Failed to read manifest: "+err.Error())
+		return nil, false
+	}
+	return manifest, true
+}
+
+// handlePostApply handles POST /api/v1/resources/apply
+// @Summary Apply a YAML manifest
+// @Description Creates or updates every object of a YAML manifest with server-side apply, in document order. Documents are separated by "---". The whole manifest is decoded first, so a malformed document applies nothing; the error names the document by its 0-based index. Applying stops at the first document the API server rejects, and the objects applied before it are listed. A document setting fields another field manager owns is rejected with 409 unless force is set. Namespaced objects without a namespace are applied to "default".
+// @Tags Apply
+// @Accept application/yaml
+// @Produce json
+// @Param manifest body string true "One or more YAML documents"
+// @Param force query bool false "Take ownership of fields other field managers set instead of failing with a conflict"
+// @Success 200 {object} map[string]interface{} "Per-document results with created, updated or unchanged status"
+// @Failure 400 {object} map[string]interface{} "Manifest could not be decoded"
+// @Failure 409 {object} map[string]interface{} "Field manager conflict"
+// @Failure 415 {object} map[string]interface{} "Body is not YAML"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/resources/apply [post]
+func (s *Server) handlePostApply(w http.ResponseWriter, r *http.Request) {
+	manifest, ok := readManifest(w, r)
+	if !ok {
+		return
+	}
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+
+	results, err := s.callerResourceManager(r).ApplyResource(s.mutationContext(r), manifest, resources.ApplyOptions{Force: force})
+	if results == nil {
+		results = []resources.ApplyResult{}
+	}
+	if err != nil {
+		status := applyErrorStatus(err)
+		body := map[string]interface{}{
+			"data":   map[string]interface{}{"results": results},
+			"error":  err.Error(),
+			"status": "error",
+		}
+		var docErr *resources.ApplyDocumentError
+		if errors.As(err, &docErr) {
+			body["data"].(map[string]interface{})["failedIndex"] = docErr.Index
+		}
+		s.requestLogger(r).Warn("Failed to apply manifest", zap.Int("applied", len(results)), zap.Error(err))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   map[string]interface{}{"results": results},
+		"status": "success",
+	})
+}
+
+// handleDiffResource handles POST /api/v1/resources/diff
+// @Summary Diff a YAML manifest against the live object
+// @Description Compares the single object of a YAML manifest with its live version and lists the added, removed and changed fields as JSON paths, e.g. spec.template.spec.containers[0].image. Server-managed metadata and status are ignored on both sides. Removed fields include those the API server defaulted. When the object does not exist, create is true and every field of the manifest is added. Nothing is changed in the cluster.
+// @Tags Apply
+// @Accept application/yaml
+// @Produce json
+// @Param manifest body string true "One YAML document"
+// @Success 200 {object} map[string]interface{} "Structured diff"
+// @Failure 400 {object} map[string]interface{} "Manifest could not be decoded or names an unknown kind"
+// @Failure 415 {object} map[string]interface{} "Body is not YAML"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/resources/diff [post]
+func (s *Server) handleDiffResource(w http.ResponseWriter, r *http.Request) {
+	manifest, ok := readManifest(w, r)
+	if !ok {
+		return
+	}
+
+	diff, err := s.callerResourceManager(r).DiffResource(r.Context(), manifest)
+	if err != nil {
+		s.requestLogger(r).Warn("Failed to diff manifest", zap.Error(err))
+		writeJSONError(w, applyErrorStatus(err), err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   diff,
+		"status": "success",
+	})
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newApplyServer returns a server whose dynamic client creates the objects
+// of server-side apply requests, rejects applies to the "locked" namespace as
+// the API server would for a caller without permission, and reports a field
+// manager conflict for applies to the "contested" namespace
+func newApplyServer() *Server {
+	client := fake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap", Namespaced: true}}},
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dynamicClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		if patch.GetNamespace() == "locked" {
+			return true, nil, apierrors.NewForbidden(patch.GetResource().GroupResource(), patch.GetName(), nil)
+		}
+		if patch.GetNamespace() == "contested" {
+			return true, nil, apierrors.NewApplyConflict(nil, "Apply failed with 1 conflict: conflict with \"kubectl\": .data.mode")
+		}
+		object := &unstructured.Unstructured{}
+		if err := object.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, err
+		}
+		return true, object, dynamicClient.Tracker().Create(patch.GetResource(), object, patch.GetNamespace())
+	})
+
+	return &Server{
+		logger:          zap.NewNop(),
+		config:          &config.Config{},
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, dynamicClient),
+	}
+}
+
+func postApply(s *Server, contentType, manifest string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/resources/apply", strings.NewReader(manifest))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	s.handlePostApply(rec, req)
+	return rec
+}
+
+type applyResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		Results     []resources.ApplyResult `json:"results"`
+		FailedIndex *int                    `json:"failedIndex"`
+	} `json:"data"`
+}
+
+func decodeApplyResponse(t *testing.T, rec *httptest.ResponseRecorder) applyResponse {
+	t.Helper()
+	var response applyResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response), rec.Body.String())
+	return response
+}
+
+func TestHandlePostApply(t *testing.T) {
+	s := newApplyServer()
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n  namespace: shop\n"
+
+	rec := postApply(s, "application/yaml", manifest)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	response := decodeApplyResponse(t, rec)
+	assert.Equal(t, "success", response.Status)
+	require.Len(t, response.Data.Results, 2)
+	assert.Equal(t, resources.ApplyResult{Index: 0, APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "a", Status: resources.ApplyCreated}, response.Data.Results[0])
+	assert.Equal(t, "shop", response.Data.Results[1].Namespace)
+
+	// The first undecodable document is reported and nothing is applied
+	rec = postApply(s, "application/yaml; charset=utf-8", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: c\n---\nkind: [broken\n")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	response = decodeApplyResponse(t, rec)
+	require.NotNil(t, response.Data.FailedIndex)
+	assert.Equal(t, 1, *response.Data.FailedIndex)
+	assert.Contains(t, response.Error, "document 1")
+	assert.Empty(t, response.Data.Results)
+
+	// A rejected document keeps the API server's status and lists what was applied
+	rec = postApply(s, "text/yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: d\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: e\n  namespace: locked\n")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	response = decodeApplyResponse(t, rec)
+	require.Len(t, response.Data.Results, 1)
+	assert.Equal(t, "d", response.Data.Results[0].Name)
+	assert.Equal(t, 1, *response.Data.FailedIndex)
+
+	// A field manager conflict is returned rather than forced
+	rec = postApply(s, "application/yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: f\n  namespace: contested\n")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, decodeApplyResponse(t, rec).Error, "conflict")
+
+	rec = postApply(s, "application/json", `{"apiVersion":"v1"}`)
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
+
+func TestHandleDiffResource(t *testing.T) {
+	s := newApplyServer()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/resources/diff", strings.NewReader("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\ndata:\n  mode: live\n"))
+	req.Header.Set("Content-Type", "application/yaml")
+	rec := httptest.NewRecorder()
+	s.handleDiffResource(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response struct {
+		Data resources.ResourceDiff `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.True(t, response.Data.Create)
+	assert.Equal(t, "a", response.Data.Name)
+	assert.NotEmpty(t, response.Data.Added)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/resources/diff", strings.NewReader("apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n"))
+	rec = httptest.NewRecorder()
+	s.handleDiffResource(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "unknown resource")
+}
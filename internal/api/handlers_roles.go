@@ -19,7 +19,7 @@ import (
 // @Produce json
 // @Param namespace query string false "Namespace to filter by (empty for all namespaces)"
 // @Param search query string false "Search term for Role name"
-// @Param sortBy query string false "Sort by field (default: name)"
+// @Param sort query string false "Sort by field (default: name); sortBy is accepted as an alias"
 // @Param page query int false "Page number (default: 1)"
 // @Param pageSize query int false "Page size (default: 50, max: 100)"
 // @Success 200 {object} map[string]interface{} "Paginated list of Roles"
@@ -30,7 +30,7 @@ func (s *Server) handleListRoles(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
 	namespace := r.URL.Query().Get("namespace")
 	search := r.URL.Query().Get("search")
-	sortBy := r.URL.Query().Get("sortBy")
+	sortBy := getSortParam(r)
 	if sortBy == "" {
 		sortBy = "name"
 	}
@@ -52,7 +52,7 @@ func (s *Server) handleListRoles(w http.ResponseWriter, r *http.Request) {
 	// List roles from resource manager
 	roles, err := s.resourceManager.ListRoles(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list roles", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list roles", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -156,7 +156,7 @@ func (s *Server) handleGetRole(w http.ResponseWriter, r *http.Request) {
 	// Get role from resource manager
 	role, err := s.resourceManager.GetRole(r.Context(), namespace, name)
 	if err != nil {
-		s.logger.Error("Failed to get role",
+		s.requestLogger(r).Error("Failed to get role",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -196,7 +196,7 @@ func (s *Server) handleGetRole(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param namespace query string false "Namespace to filter by (empty for all namespaces)"
 // @Param search query string false "Search term for RoleBinding name"
-// @Param sortBy query string false "Sort by field (default: name)"
+// @Param sort query string false "Sort by field (default: name); sortBy is accepted as an alias"
 // @Param page query int false "Page number (default: 1)"
 // @Param pageSize query int false "Page size (default: 50, max: 100)"
 // @Success 200 {object} map[string]interface{} "Paginated list of RoleBindings"
@@ -207,7 +207,7 @@ func (s *Server) handleListRoleBindings(w http.ResponseWriter, r *http.Request)
 	// Parse query parameters
 	namespace := r.URL.Query().Get("namespace")
 	search := r.URL.Query().Get("search")
-	sortBy := r.URL.Query().Get("sortBy")
+	sortBy := getSortParam(r)
 	if sortBy == "" {
 		sortBy = "name"
 	}
@@ -229,7 +229,7 @@ func (s *Server) handleListRoleBindings(w http.ResponseWriter, r *http.Request)
 	// List role bindings from resource manager
 	roleBindings, err := s.resourceManager.ListRoleBindings(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list role bindings", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list role bindings", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -338,7 +338,7 @@ func (s *Server) handleGetRoleBinding(w http.ResponseWriter, r *http.Request) {
 	// Get role binding from resource manager
 	roleBinding, err := s.resourceManager.GetRoleBinding(r.Context(), namespace, name)
 	if err != nil {
-		s.logger.Error("Failed to get role binding",
+		s.requestLogger(r).Error("Failed to get role binding",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -387,12 +387,13 @@ func (s *Server) roleToResponse(role interface{}) map[string]interface{} {
 	}
 
 	// Extract metadata
-	metadata := roleObj["metadata"].(map[string]interface{})
-	name := metadata["name"].(string)
-	namespace := metadata["namespace"].(string)
+	metadata, _ := roleObj["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
 
 	// Calculate age
-	creationTime, _ := time.Parse(time.RFC3339, metadata["creationTimestamp"].(string))
+	creationTimestamp, _ := metadata["creationTimestamp"].(string)
+	creationTime, _ := time.Parse(time.RFC3339, creationTimestamp)
 	age := time.Since(creationTime)
 
 	var ageStr string
@@ -407,7 +408,7 @@ func (s *Server) roleToResponse(role interface{}) map[string]interface{} {
 	}
 
 	// Extract rules information
-	rules := roleObj["rules"].([]interface{})
+	rules, _ := roleObj["rules"].([]interface{})
 	ruleCount := len(rules)
 
 	// Count unique verbs and resources across all rules
@@ -415,17 +416,21 @@ func (s *Server) roleToResponse(role interface{}) map[string]interface{} {
 	resourceSet := make(map[string]bool)
 
 	for _, rule := range rules {
-		ruleMap := rule.(map[string]interface{})
+		ruleMap, _ := rule.(map[string]interface{})
 
 		if verbs, ok := ruleMap["verbs"].([]interface{}); ok {
 			for _, verb := range verbs {
-				verbSet[verb.(string)] = true
+				if v, ok := verb.(string); ok {
+					verbSet[v] = true
+				}
 			}
 		}
 
 		if resources, ok := ruleMap["resources"].([]interface{}); ok {
 			for _, resource := range resources {
-				resourceSet[resource.(string)] = true
+				if r, ok := resource.(string); ok {
+					resourceSet[r] = true
+				}
 			}
 		}
 	}
@@ -498,12 +503,13 @@ func (s *Server) roleBindingToResponse(roleBinding interface{}) map[string]inter
 	}
 
 	// Extract metadata
-	metadata := roleBindingObj["metadata"].(map[string]interface{})
-	name := metadata["name"].(string)
-	namespace := metadata["namespace"].(string)
+	metadata, _ := roleBindingObj["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
 
 	// Calculate age
-	creationTime, _ := time.Parse(time.RFC3339, metadata["creationTimestamp"].(string))
+	creationTimestamp, _ := metadata["creationTimestamp"].(string)
+	creationTime, _ := time.Parse(time.RFC3339, creationTimestamp)
 	age := time.Since(creationTime)
 
 	var ageStr string
@@ -518,12 +524,12 @@ func (s *Server) roleBindingToResponse(roleBinding interface{}) map[string]inter
 	}
 
 	// Extract role reference
-	roleRef := roleBindingObj["roleRef"].(map[string]interface{})
-	roleName := roleRef["name"].(string)
-	roleKind := roleRef["kind"].(string)
+	roleRef, _ := roleBindingObj["roleRef"].(map[string]interface{})
+	roleName, _ := roleRef["name"].(string)
+	roleKind, _ := roleRef["kind"].(string)
 
 	// Extract subjects
-	subjects := roleBindingObj["subjects"].([]interface{})
+	subjects, _ := roleBindingObj["subjects"].([]interface{})
 	subjectCount := len(subjects)
 
 	// Count subjects by kind and create display list
@@ -533,9 +539,9 @@ func (s *Server) roleBindingToResponse(roleBinding interface{}) map[string]inter
 	var subjectsDisplayList []string
 
 	for _, subject := range subjects {
-		subjectMap := subject.(map[string]interface{})
-		kind := subjectMap["kind"].(string)
-		name := subjectMap["name"].(string)
+		subjectMap, _ := subject.(map[string]interface{})
+		kind, _ := subjectMap["kind"].(string)
+		name, _ := subjectMap["name"].(string)
 
 		switch kind {
 		case "User":
@@ -548,7 +554,7 @@ func (s *Server) roleBindingToResponse(roleBinding interface{}) map[string]inter
 			serviceAccountCount++
 			namespace := ""
 			if ns, ok := subjectMap["namespace"]; ok {
-				namespace = ns.(string)
+				namespace, _ = ns.(string)
 			}
 			if namespace != "" {
 				subjectsDisplayList = append(subjectsDisplayList, fmt.Sprintf("SA:%s/%s", namespace, name))
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/analysis"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// handleGetDeploymentRolloutStatus handles GET /api/v1/deployments/{namespace}/{name}/rollout-status
+// @Summary Get deployment rollout status
+// @Description Reports rollout progress equivalent to kubectl rollout status: replica counts, observed versus desired generation and a complete, progressing or failed verdict. Intended for polling after a rollout or restart.
+// @Tags Deployments
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param name path string true "Deployment name"
+// @Success 200 {object} analysis.RolloutStatus "Rollout status"
+// @Failure 404 {object} map[string]interface{} "Deployment not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/deployments/{namespace}/{name}/rollout-status [get]
+func (s *Server) handleGetDeploymentRolloutStatus(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	deployment, err := s.kubeClient.AppsV1().Deployments(namespace).Get(r.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		s.writeRolloutStatusError(w, r, "deployment", namespace, name, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   analysis.DeploymentRolloutStatus(deployment),
+		"status": "success",
+	})
+}
+
+// handleGetStatefulSetRolloutStatus handles GET /api/v1/statefulsets/{namespace}/{name}/rollout-status
+// @Summary Get statefulset rollout status
+// @Description Reports rollout progress equivalent to kubectl rollout status, including partitioned rolling updates
+// @Tags StatefulSets
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param name path string true "StatefulSet name"
+// @Success 200 {object} analysis.RolloutStatus "Rollout status"
+// @Failure 404 {object} map[string]interface{} "StatefulSet not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/statefulsets/{namespace}/{name}/rollout-status [get]
+func (s *Server) handleGetStatefulSetRolloutStatus(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	statefulSet, err := s.kubeClient.AppsV1().StatefulSets(namespace).Get(r.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		s.writeRolloutStatusError(w, r, "statefulset", namespace, name, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   analysis.StatefulSetRolloutStatus(statefulSet),
+		"status": "success",
+	})
+}
+
+// writeRolloutStatusError writes a 404 for missing workloads and a 500 otherwise
+func (s *Server) writeRolloutStatusError(w http.ResponseWriter, r *http.Request, kind, namespace, name string, err error) {
+	statusCode := http.StatusInternalServerError
+	if errors.IsNotFound(err) {
+		statusCode = http.StatusNotFound
+	} else {
+		s.requestLogger(r).Error("Failed to get "+kind+" for rollout status",
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":  err.Error(),
+		"status": "error",
+	})
+}
+
+// handleRolloutHistory handles GET /api/v1/deployments/{namespace}/{name}/history
+// @Summary Get deployment rollout history
+// @Description Lists the revisions of a Deployment, oldest first, from the ReplicaSets it controls, with their change-cause annotations and images. Equivalent to kubectl rollout history.
+// @Tags Deployments
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param name path string true "Deployment name"
+// @Success 200 {array} resources.RolloutRevision "Revisions"
+// @Failure 404 {object} map[string]interface{} "Deployment not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/deployments/{namespace}/{name}/history [get]
+func (s *Server) handleRolloutHistory(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	history, err := s.resourceManager.RolloutHistory(r.Context(), namespace, name)
+	if err != nil {
+		s.writeRolloutStatusError(w, r, "deployment", namespace, name, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   history,
+		"status": "success",
+	})
+}
+
+// handleRolloutUndo handles POST /api/v1/deployments/{namespace}/{name}/undo
+// @Summary Roll back a deployment
+// @Description Restores the pod template of an earlier revision, equivalent to kubectl rollout undo. Without toRevision the Deployment rolls back to the revision before the current one. The response is skipped when the Deployment already runs that template.
+// @Tags Deployments
+// @Accept json
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param name path string true "Deployment name"
+// @Param request body object false "Optional target: {\"toRevision\": 3}"
+// @Success 200 {object} resources.RolloutUndoResult "Rollback result"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Deployment or revision not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/deployments/{namespace}/{name}/undo [post]
+func (s *Server) handleRolloutUndo(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	var req struct {
+		ToRevision int64 `json:"toRevision"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.ToRevision < 0 {
+		writeJSONError(w, http.StatusBadRequest, "toRevision must not be negative")
+		return
+	}
+
+	result, err := s.resourceManager.RolloutUndo(s.mutationContext(r), namespace, name, req.ToRevision)
+	if errors.IsNotFound(err) || stderrors.Is(err, resources.ErrRevisionNotFound) {
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		s.requestLogger(r).Error("Failed to roll back deployment",
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Int64("toRevision", req.ToRevision),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   result,
+		"status": "success",
+	})
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/analysis"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func rolloutStatusRequest(namespace, name string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/deployments/"+namespace+"/"+name+"/rollout-status", nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("namespace", namespace)
+	routeCtx.URLParams.Add("name", name)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+}
+
+func TestHandleGetDeploymentRolloutStatus(t *testing.T) {
+	replicas := int32(2)
+	s := &Server{
+		logger: zap.NewNop(),
+		kubeClient: fake.NewSimpleClientset(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Generation: 1},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 1,
+				Replicas:           2,
+				UpdatedReplicas:    2,
+				ReadyReplicas:      2,
+				AvailableReplicas:  2,
+			},
+		}),
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleGetDeploymentRolloutStatus(rec, rolloutStatusRequest("default", "web"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Data analysis.RolloutStatus `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, analysis.RolloutComplete, response.Data.Verdict)
+	assert.Equal(t, int32(2), response.Data.AvailableReplicas)
+
+	rec = httptest.NewRecorder()
+	s.handleGetDeploymentRolloutStatus(rec, rolloutStatusRequest("default", "missing"))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func rolloutRestartRequest(kind, namespace, name string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/"+kind+"/"+namespace+"/"+name+"/restart", nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("kind", kind)
+	routeCtx.URLParams.Add("namespace", namespace)
+	routeCtx.URLParams.Add("name", name)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+}
+
+func TestHandleRolloutRestart(t *testing.T) {
+	client := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Generation: 2},
+	})
+	s := &Server{
+		logger:          zap.NewNop(),
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleRolloutRestart(rec, rolloutRestartRequest("deployments", "default", "web"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response struct {
+		Data resources.RolloutRestartResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "Deployment", response.Data.Kind)
+	assert.Equal(t, int64(2), response.Data.Generation)
+	assert.NotEmpty(t, response.Data.RestartedAt)
+
+	rec = httptest.NewRecorder()
+	s.handleRolloutRestart(rec, rolloutRestartRequest("replicasets", "default", "web"))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	s.handleRolloutRestart(rec, rolloutRestartRequest("deployments", "default", "missing"))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandleRolloutUndo(t *testing.T) {
+	controller := true
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "uid-web", Annotations: map[string]string{resources.RevisionAnnotation: "2"}},
+			Spec:       appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web", Image: "web:2"}}}}},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "web-1",
+				Namespace:       "default",
+				Annotations:     map[string]string{resources.RevisionAnnotation: "1", resources.ChangeCauseAnnotation: "first release"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "uid-web", Controller: &controller}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web", Image: "web:1"}}}}},
+		},
+	)
+	s := &Server{
+		logger:          zap.NewNop(),
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleRolloutHistory(rec, rolloutStatusRequest("default", "web"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var history struct {
+		Data []resources.RolloutRevision `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &history))
+	require.Len(t, history.Data, 1)
+	assert.Equal(t, "first release", history.Data[0].ChangeCause)
+
+	undo := func(body string) *httptest.ResponseRecorder {
+		req := rolloutStatusRequest("default", "web")
+		req.Body = io.NopCloser(strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		s.handleRolloutUndo(rec, req)
+		return rec
+	}
+
+	rec = undo(`{"toRevision":5}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "has no revision 5")
+
+	rec = undo(`{"toRevision":-1}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = undo("")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var result struct {
+		Data resources.RolloutUndoResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, int64(1), result.Data.ToRevision)
+
+	deployment, err := client.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "web:1", deployment.Spec.Template.Spec.Containers[0].Image)
+}
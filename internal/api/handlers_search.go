@@ -1,81 +1,398 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/aaronlmathis/kaptn/internal/cache"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
 	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// handleSearch handles the search endpoint
+// globalSearchTimeout bounds the time a global search waits for the kinds
+// it searches; kinds not searched by then are reported as incomplete
+const globalSearchTimeout = 5 * time.Second
+
+// Match qualities of a global search result, best first
+const (
+	globalSearchExact = iota
+	globalSearchPrefix
+	globalSearchSubstring
+	globalSearchLabel
+)
+
+var globalSearchMatchNames = []string{"exact", "prefix", "substring", "label"}
+
+// globalSearchKind is a kind searched by the global search. Kinds served
+// from the informer cache list nothing; the others list through the
+// caller's resource manager.
+type globalSearchKind struct {
+	// resourceType is the type the frontend routes and the types filter use
+	resourceType string
+	// resource is the API resource, for the cache and permission checks
+	resource      string
+	group         string
+	kind          string
+	clusterScoped bool
+	list          func(ctx context.Context, rm *resources.ResourceManager, namespace string) ([]metav1.Object, error)
+}
+
+// globalSearchKinds lists the kinds the global search covers. The first are
+// served from the informer cache; the rest are less common and listed on
+// each search.
+var globalSearchKinds = func() []globalSearchKind {
+	var kinds []globalSearchKind
+	for _, cached := range []struct{ resourceType, resource string }{
+		{"pods", "pods"}, {"deployments", "deployments"}, {"statefulsets", "statefulsets"},
+		{"daemonsets", "daemonsets"}, {"replicasets", "replicasets"}, {"jobs", "jobs"},
+		{"cronjobs", "cronjobs"}, {"services", "services"}, {"ingresses", "ingresses"},
+		{"configmaps", "configmaps"}, {"secrets", "secrets"},
+		{"persistent-volume-claims", "persistentvolumeclaims"},
+		{"nodes", "nodes"}, {"namespaces", "namespaces"},
+	} {
+		gvk := cachedResourceKinds[cached.resource]
+		kinds = append(kinds, globalSearchKind{
+			resourceType:  cached.resourceType,
+			resource:      cached.resource,
+			group:         gvk.Group,
+			kind:          gvk.Kind,
+			clusterScoped: cached.resource == "nodes" || cached.resource == "namespaces",
+		})
+	}
+
+	return append(kinds,
+		globalSearchKind{resourceType: "service-accounts", resource: "serviceaccounts", kind: "ServiceAccount",
+			list: func(ctx context.Context, rm *resources.ResourceManager, namespace string) ([]metav1.Object, error) {
+				items, err := rm.ListServiceAccounts(ctx, namespace)
+				return objectMetas(items), err
+			}},
+		globalSearchKind{resourceType: "resource-quotas", resource: "resourcequotas", kind: "ResourceQuota",
+			list: func(ctx context.Context, rm *resources.ResourceManager, namespace string) ([]metav1.Object, error) {
+				items, err := rm.ListResourceQuotas(ctx, namespace)
+				return objectMetas(items), err
+			}},
+		globalSearchKind{resourceType: "limit-ranges", resource: "limitranges", kind: "LimitRange",
+			list: func(ctx context.Context, rm *resources.ResourceManager, namespace string) ([]metav1.Object, error) {
+				items, err := rm.ListLimitRanges(ctx, namespace)
+				return objectMetas(items), err
+			}},
+		globalSearchKind{resourceType: "network-policies", resource: "networkpolicies", group: "networking.k8s.io", kind: "NetworkPolicy",
+			list: func(ctx context.Context, rm *resources.ResourceManager, namespace string) ([]metav1.Object, error) {
+				items, err := rm.ListNetworkPolicies(ctx, namespace)
+				return objectMetas(items), err
+			}},
+		globalSearchKind{resourceType: "storage-classes", resource: "storageclasses", group: "storage.k8s.io", kind: "StorageClass", clusterScoped: true,
+			list: func(ctx context.Context, rm *resources.ResourceManager, _ string) ([]metav1.Object, error) {
+				items, err := rm.ListStorageClasses(ctx)
+				return objectMetas(items), err
+			}},
+		globalSearchKind{resourceType: "csi-drivers", resource: "csidrivers", group: "storage.k8s.io", kind: "CSIDriver", clusterScoped: true,
+			list: func(ctx context.Context, rm *resources.ResourceManager, _ string) ([]metav1.Object, error) {
+				items, err := rm.ListCSIDrivers(ctx)
+				return objectMetas(items), err
+			}},
+		globalSearchKind{resourceType: "clusterroles", resource: "clusterroles", group: "rbac.authorization.k8s.io", kind: "ClusterRole", clusterScoped: true,
+			list: func(ctx context.Context, rm *resources.ResourceManager, _ string) ([]metav1.Object, error) {
+				items, err := rm.ListClusterRoles(ctx)
+				objects := make([]metav1.Object, 0, len(items))
+				for _, item := range items {
+					objects = append(objects, item)
+				}
+				return objects, err
+			}},
+		globalSearchKind{resourceType: "clusterrolebindings", resource: "clusterrolebindings", group: "rbac.authorization.k8s.io", kind: "ClusterRoleBinding", clusterScoped: true,
+			list: func(ctx context.Context, rm *resources.ResourceManager, _ string) ([]metav1.Object, error) {
+				items, err := rm.ListClusterRoleBindings(ctx)
+				objects := make([]metav1.Object, 0, len(items))
+				for _, item := range items {
+					objects = append(objects, item)
+				}
+				return objects, err
+			}},
+	)
+}()
+
+// objectMetas returns the object metadata of a typed list's items
+func objectMetas[T any, PT interface {
+	*T
+	metav1.Object
+}](items []T) []metav1.Object {
+	objects := make([]metav1.Object, 0, len(items))
+	for i := range items {
+		objects = append(objects, PT(&items[i]))
+	}
+	return objects
+}
+
+// globalSearchResult is an object found by the global search. MatchField
+// is the field the query matched, name or labels.
+type globalSearchResult struct {
+	ID           string            `json:"id"`
+	Kind         string            `json:"kind"`
+	Namespace    string            `json:"namespace,omitempty"`
+	Name         string            `json:"name"`
+	ResourceType string            `json:"resourceType"`
+	URL          string            `json:"url"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	MatchField   string            `json:"matchField"`
+	Match        string            `json:"match"`
+	CreationTime string            `json:"creationTimestamp,omitempty"`
+	Age          string            `json:"age,omitempty"`
+
+	quality int
+}
+
+// globalSearchMatch reports how well an object matches a lower-case query:
+// by its name exactly, by a prefix or substring of it, or by one of its
+// labels written as key=value
+func globalSearchMatch(obj metav1.Object, query string) (int, bool) {
+	name := strings.ToLower(obj.GetName())
+	switch {
+	case name == query:
+		return globalSearchExact, true
+	case strings.HasPrefix(name, query):
+		return globalSearchPrefix, true
+	case strings.Contains(name, query):
+		return globalSearchSubstring, true
+	}
+	for key, value := range obj.GetLabels() {
+		if strings.Contains(strings.ToLower(key+"="+value), query) {
+			return globalSearchLabel, true
+		}
+	}
+	return 0, false
+}
+
+// globalSearchKindResult is the outcome of searching one kind
+type globalSearchKindResult struct {
+	kind    globalSearchKind
+	results []globalSearchResult
+	err     error
+}
+
+// handleGlobalSearch handles GET /api/v1/search
 // @Summary Search resources
-// @Description Search across all Kubernetes resources using cached data
+// @Description Finds objects of any common kind by name. Pods, workloads, services, config and the like are searched in the informer cache; less common kinds such as service accounts, network policies and cluster roles are listed as the caller. Kinds are searched concurrently and kinds not searched within a few seconds, or that failed, are listed as incomplete. Results are ranked by match quality: exact name, name prefix, name substring, then label (key=value) matches. Kinds the caller may not list are left out.
 // @Tags search
 // @Accept json
 // @Produce json
 // @Param q query string true "Search query"
 // @Param types query string false "Comma-separated list of resource types to filter by"
-// @Param namespace query string false "Namespace to search within"
+// @Param namespace query string false "Namespace to search within; cluster-scoped kinds are left out"
 // @Param limit query int false "Maximum number of results to return" default(100)
 // @Success 200 {object} map[string]interface{} "Search results"
 // @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/search [get]
-func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
-	// Get query parameters
+func (s *Server) handleGlobalSearch(w http.ResponseWriter, r *http.Request) {
 	query := strings.TrimSpace(r.URL.Query().Get("q"))
 	if query == "" {
-		s.respondWithError(w, http.StatusBadRequest, "Query parameter 'q' is required", nil)
+		writeJSONError(w, http.StatusBadRequest, "Query parameter 'q' is required")
 		return
 	}
+	namespace := strings.TrimSpace(r.URL.Query().Get("namespace"))
 
-	// Parse resource types filter
-	var resourceTypes []string
-	if typesParam := strings.TrimSpace(r.URL.Query().Get("types")); typesParam != "" {
-		resourceTypes = strings.Split(typesParam, ",")
-		for i, t := range resourceTypes {
-			resourceTypes[i] = strings.TrimSpace(t)
+	limit := 100
+	if raw := strings.TrimSpace(r.URL.Query().Get("limit")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			writeJSONError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
 		}
+		limit = min(parsed, maxListPageLimit)
 	}
 
-	// Parse namespace filter
-	namespace := strings.TrimSpace(r.URL.Query().Get("namespace"))
+	var types map[string]bool
+	if raw := strings.TrimSpace(r.URL.Query().Get("types")); raw != "" {
+		types = map[string]bool{}
+		for _, t := range strings.Split(raw, ",") {
+			types[strings.TrimSpace(t)] = true
+		}
+	}
 
-	// Parse limit
-	limit := 100 // Default limit
-	if limitParam := strings.TrimSpace(r.URL.Query().Get("limit")); limitParam != "" {
-		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
-			limit = parsedLimit
+	// Outside auth mode none, cache reads are limited to the kinds the
+	// caller may list
+	var secCtx *SecurityContext
+	if s.config != nil && s.config.Security.AuthMode != "none" {
+		var err error
+		secCtx, err = s.getSecurityContext(r)
+		if err != nil {
+			if secErr, ok := err.(*SecurityError); ok {
+				s.writeSecurityError(w, r, secErr, nil)
+			} else {
+				writeJSONError(w, http.StatusInternalServerError, "Security context error")
+			}
+			return
 		}
 	}
 
-	// Perform the search
-	searchResponse, err := s.searchService.Search(r.Context(), query, resourceTypes, namespace, limit)
-	if err != nil {
-		s.logger.Error("Search failed",
-			zap.String("query", query),
-			zap.Strings("resourceTypes", resourceTypes),
-			zap.String("namespace", namespace),
-			zap.Error(err))
-		s.respondWithError(w, http.StatusInternalServerError, "Search failed", err)
-		return
+	var kinds []globalSearchKind
+	for _, kind := range globalSearchKinds {
+		if types != nil && !types[kind.resourceType] && !types[kind.resource] {
+			continue
+		}
+		if namespace != "" && kind.clusterScoped {
+			continue
+		}
+		kinds = append(kinds, kind)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), globalSearchTimeout)
+	defer cancel()
+	rm := s.callerResourceManager(r)
+	lowerQuery := strings.ToLower(query)
+	found := make(chan globalSearchKindResult, len(kinds))
+	for _, kind := range kinds {
+		go func(kind globalSearchKind) {
+			results, err := s.searchKind(ctx, secCtx, rm, kind, namespace, lowerQuery)
+			found <- globalSearchKindResult{kind: kind, results: results, err: err}
+		}(kind)
 	}
 
-	s.logger.Debug("Search completed",
-		zap.String("query", query),
-		zap.Int("totalResults", searchResponse.Total),
-		zap.Strings("resourceTypes", resourceTypes),
-		zap.String("namespace", namespace))
+	results := []globalSearchResult{}
+	searched := map[string]bool{}
+	incomplete := []string{}
+collect:
+	for range kinds {
+		select {
+		case result := <-found:
+			searched[result.kind.resourceType] = true
+			if result.err != nil {
+				s.requestLogger(r).Debug("Global search skipped a kind",
+					zap.String("resource", result.kind.resource),
+					zap.Error(result.err))
+				incomplete = append(incomplete, result.kind.resourceType)
+				continue
+			}
+			results = append(results, result.results...)
+		case <-ctx.Done():
+			break collect
+		}
+	}
+	for _, kind := range kinds {
+		if !searched[kind.resourceType] {
+			incomplete = append(incomplete, kind.resourceType)
+		}
+	}
+	sort.Strings(incomplete)
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.quality != b.quality {
+			return a.quality < b.quality
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Namespace < b.Namespace
+	})
+	total := len(results)
+	if len(results) > limit {
+		results = results[:limit]
+	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{
+			"results":    results,
+			"total":      total,
+			"query":      query,
+			"incomplete": incomplete,
+		},
 		"status": "success",
-		"data":   searchResponse,
 	})
 }
 
+// searchKind finds the objects of one kind matching a lower-case query. A
+// kind the caller may not list has no results; a kind that cannot be
+// searched returns an error.
+func (s *Server) searchKind(ctx context.Context, secCtx *SecurityContext, rm *resources.ResourceManager, kind globalSearchKind, namespace, query string) ([]globalSearchResult, error) {
+	var objects []metav1.Object
+	if kind.list == nil {
+		if secCtx != nil {
+			if secCtx.SSARHelper == nil {
+				return nil, fmt.Errorf("permission checking unavailable")
+			}
+			allowed, err := secCtx.SSARHelper.CanPerformAction(ctx, secCtx.Client, "list", kind.group, kind.resource, namespace, "")
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				return nil, nil
+			}
+		}
+		if s.informerManager == nil {
+			return nil, fmt.Errorf("informer cache not available")
+		}
+		informer, ok := s.informerManager.ResourceInformer(kind.resource)
+		if !ok {
+			return nil, fmt.Errorf("informer cache for %s has not synced", kind.resource)
+		}
+		for _, item := range informer.GetStore().List() {
+			if obj, err := meta.Accessor(item); err == nil {
+				objects = append(objects, obj)
+			}
+		}
+	} else {
+		if rm == nil {
+			return nil, fmt.Errorf("resource manager not available")
+		}
+		var err error
+		objects, err = kind.list(ctx, rm, namespace)
+		if apierrors.IsForbidden(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var results []globalSearchResult
+	for _, obj := range objects {
+		if namespace != "" && obj.GetNamespace() != namespace {
+			continue
+		}
+		quality, ok := globalSearchMatch(obj, query)
+		if !ok {
+			continue
+		}
+		matchField := "name"
+		if quality == globalSearchLabel {
+			matchField = "labels"
+		}
+		result := globalSearchResult{
+			ID:           fmt.Sprintf("%s:%s:%s", kind.resourceType, obj.GetNamespace(), obj.GetName()),
+			Kind:         kind.kind,
+			Namespace:    obj.GetNamespace(),
+			Name:         obj.GetName(),
+			ResourceType: kind.resourceType,
+			URL:          cache.ResourceURL(kind.resourceType, obj.GetNamespace(), obj.GetName()),
+			Labels:       obj.GetLabels(),
+			MatchField:   matchField,
+			Match:        globalSearchMatchNames[quality],
+			quality:      quality,
+		}
+		if created := obj.GetCreationTimestamp(); !created.IsZero() {
+			result.CreationTime = created.UTC().Format(time.RFC3339)
+			result.Age = formatAge(created.Time)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
 // handleSearchStats handles the search cache statistics endpoint
 // @Summary Get search cache statistics
 // @Description Get statistics about the search cache including size and last refresh time
@@ -107,12 +424,12 @@ func (s *Server) handleSearchStats(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleRefreshSearchCache(w http.ResponseWriter, r *http.Request) {
 	err := s.searchService.RefreshCache(r.Context())
 	if err != nil {
-		s.logger.Error("Failed to refresh search cache", zap.Error(err))
+		s.requestLogger(r).Error("Failed to refresh search cache", zap.Error(err))
 		s.respondWithError(w, http.StatusInternalServerError, "Failed to refresh cache", err)
 		return
 	}
 
-	s.logger.Info("Search cache refresh completed")
+	s.requestLogger(r).Info("Search cache refresh completed")
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type globalSearchResponse struct {
+	Data struct {
+		Results    []globalSearchResult `json:"results"`
+		Total      int                  `json:"total"`
+		Incomplete []string             `json:"incomplete"`
+	} `json:"data"`
+}
+
+func newGlobalSearchServer(t *testing.T, objects ...runtime.Object) (*Server, *fake.Clientset) {
+	t.Helper()
+
+	client := fake.NewSimpleClientset(objects...)
+	manager := informers.NewManager(zap.NewNop(), client, nil)
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	cfg := &config.Config{}
+	cfg.Security.AuthMode = "none"
+	return &Server{
+		logger:          zap.NewNop(),
+		config:          cfg,
+		informerManager: manager,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}, client
+}
+
+func globalSearch(t *testing.T, s *Server, query string) globalSearchResponse {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	s.handleGlobalSearch(rec, httptest.NewRequest(http.MethodGet, "/api/v1/search?"+query, nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response globalSearchResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return response
+}
+
+func TestHandleGlobalSearchRanksMatches(t *testing.T) {
+	s, _ := newGlobalSearchServer(t,
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-7d9f", Namespace: "shop"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", Labels: map[string]string{"tier": "frontend"}}},
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "shop-web", Namespace: "shop"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "shop", Labels: map[string]string{"app": "web"}}},
+		&v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+	)
+
+	response := globalSearch(t, s, "q=Web")
+	assert.Empty(t, response.Data.Incomplete)
+	require.Equal(t, 5, response.Data.Total)
+
+	type match struct{ kind, namespace, name, matchField, match string }
+	var matches []match
+	for _, result := range response.Data.Results {
+		matches = append(matches, match{result.Kind, result.Namespace, result.Name, result.MatchField, result.Match})
+	}
+	assert.Equal(t, []match{
+		{"Deployment", "shop", "web", "name", "exact"},
+		{"ServiceAccount", "prod", "web", "name", "exact"},
+		{"Pod", "shop", "web-7d9f", "name", "prefix"},
+		{"Service", "shop", "shop-web", "name", "substring"},
+		{"ConfigMap", "shop", "settings", "labels", "label"},
+	}, matches)
+
+	deployment := response.Data.Results[0]
+	assert.Equal(t, "deployments", deployment.ResourceType)
+	assert.Equal(t, "/deployments/shop/web", deployment.URL)
+	assert.Equal(t, map[string]string{"tier": "frontend"}, deployment.Labels)
+
+	// Filtered by namespace, type and limit
+	response = globalSearch(t, s, "q=web&namespace=shop&types=pods,services")
+	require.Len(t, response.Data.Results, 2)
+	assert.Equal(t, "web-7d9f", response.Data.Results[0].Name)
+
+	response = globalSearch(t, s, "q=web&limit=1")
+	assert.Equal(t, 5, response.Data.Total)
+	require.Len(t, response.Data.Results, 1)
+	assert.Equal(t, "web", response.Data.Results[0].Name)
+}
+
+func TestHandleGlobalSearchSkipsForbiddenKinds(t *testing.T) {
+	s, client := newGlobalSearchServer(t,
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}},
+		&v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}},
+	)
+	client.PrependReactor("list", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "serviceaccounts"}, "", assert.AnError)
+	})
+	client.PrependReactor("list", "networkpolicies", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, assert.AnError
+	})
+
+	response := globalSearch(t, s, "q=web")
+	require.Len(t, response.Data.Results, 1)
+	assert.Equal(t, "Pod", response.Data.Results[0].Kind)
+	assert.Equal(t, []string{"network-policies"}, response.Data.Incomplete)
+}
+
+func TestHandleGlobalSearchRejectsBadParams(t *testing.T) {
+	s, _ := newGlobalSearchServer(t)
+
+	for _, query := range []string{"", "q=%20", "q=web&limit=0", "q=web&limit=many"} {
+		rec := httptest.NewRecorder()
+		s.handleGlobalSearch(rec, httptest.NewRequest(http.MethodGet, "/api/v1/search?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
@@ -75,7 +75,7 @@ type SecretUpdateRequest struct {
 // @Param labelSelector query string false "Label selector"
 // @Param fieldSelector query string false "Field selector"
 // @Param search query string false "Search in name, namespace, labels, type"
-// @Param sort query string false "Sort field (name, namespace, type, keys, age)"
+// @Param sort query string false "Sort field (name, namespace, type, keys, age); sortBy is accepted as an alias"
 // @Param order query string false "Sort order (asc, desc)"
 // @Param page query int false "Page number (1-based)"
 // @Param pageSize query int false "Number of items per page"
@@ -91,7 +91,7 @@ func (s *Server) handleListSecrets(w http.ResponseWriter, r *http.Request) {
 	labelSelector := r.URL.Query().Get("labelSelector")
 	fieldSelector := r.URL.Query().Get("fieldSelector")
 	search := r.URL.Query().Get("search")
-	sort := r.URL.Query().Get("sort")
+	sort := getSortParam(r)
 	order := r.URL.Query().Get("order")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pageSize")
@@ -112,7 +112,7 @@ func (s *Server) handleListSecrets(w http.ResponseWriter, r *http.Request) {
 	// Get secrets from resource manager
 	secrets, err := s.resourceManager.ListSecrets(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list secrets", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list secrets", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
@@ -134,7 +134,7 @@ func (s *Server) handleListSecrets(w http.ResponseWriter, r *http.Request) {
 
 	filteredSecrets, err := selectors.FilterSecrets(secrets, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter secrets", zap.Error(err))
+		s.requestLogger(r).Error("Failed to filter secrets", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to filter secrets: " + err.Error()})
@@ -198,7 +198,7 @@ func (s *Server) handleGetSecret(w http.ResponseWriter, r *http.Request) {
 	secret, err := s.resourceManager.GetSecret(r.Context(), namespace, name)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			s.logger.Warn("Secret not found",
+			s.requestLogger(r).Warn("Secret not found",
 				zap.String("namespace", namespace),
 				zap.String("name", name))
 			w.Header().Set("Content-Type", "application/json")
@@ -207,7 +207,7 @@ func (s *Server) handleGetSecret(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		s.logger.Error("Failed to get secret",
+		s.requestLogger(r).Error("Failed to get secret",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -243,7 +243,7 @@ func (s *Server) handleGetSecret(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleCreateSecret(w http.ResponseWriter, r *http.Request) {
 	var req SecretCreateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		s.logger.Error("Failed to decode request body", zap.Error(err))
+		s.requestLogger(r).Error("Failed to decode request body", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
@@ -298,7 +298,7 @@ func (s *Server) handleCreateSecret(w http.ResponseWriter, r *http.Request) {
 	createdSecret, err := s.resourceManager.CreateSecret(r.Context(), secret)
 	if err != nil {
 		if errors.IsAlreadyExists(err) {
-			s.logger.Warn("Secret already exists",
+			s.requestLogger(r).Warn("Secret already exists",
 				zap.String("namespace", req.Namespace),
 				zap.String("name", req.Name))
 			w.Header().Set("Content-Type", "application/json")
@@ -307,7 +307,7 @@ func (s *Server) handleCreateSecret(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		s.logger.Error("Failed to create secret",
+		s.requestLogger(r).Error("Failed to create secret",
 			zap.String("namespace", req.Namespace),
 			zap.String("name", req.Name),
 			zap.Error(err))
@@ -355,7 +355,7 @@ func (s *Server) handleUpdateSecret(w http.ResponseWriter, r *http.Request) {
 
 	var req SecretUpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		s.logger.Error("Failed to decode request body", zap.Error(err))
+		s.requestLogger(r).Error("Failed to decode request body", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
@@ -372,7 +372,7 @@ func (s *Server) handleUpdateSecret(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		s.logger.Error("Failed to get secret for update",
+		s.requestLogger(r).Error("Failed to get secret for update",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -409,7 +409,7 @@ func (s *Server) handleUpdateSecret(w http.ResponseWriter, r *http.Request) {
 	// Update the secret
 	updatedSecret, err := s.resourceManager.UpdateSecret(r.Context(), existingSecret)
 	if err != nil {
-		s.logger.Error("Failed to update secret",
+		s.requestLogger(r).Error("Failed to update secret",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -458,7 +458,7 @@ func (s *Server) handleDeleteSecret(w http.ResponseWriter, r *http.Request) {
 	// Get security context for authorization check
 	secCtx, err := s.getSecurityContext(r)
 	if err != nil {
-		s.logger.Error("Failed to get security context for secret deletion", zap.Error(err))
+		s.requestLogger(r).Error("Failed to get security context for secret deletion", zap.Error(err))
 		http.Error(w, "Authentication required", http.StatusUnauthorized)
 		return
 	}
@@ -466,7 +466,7 @@ func (s *Server) handleDeleteSecret(w http.ResponseWriter, r *http.Request) {
 	// Check delete permission for secrets using SSAR
 	if err := s.checkResourcePermission(r.Context(), secCtx, "delete", "secrets", namespace, name); err != nil {
 		if secErr, ok := err.(*SecurityError); ok {
-			s.writeSecurityError(w, secErr, secCtx.User)
+			s.writeSecurityError(w, r, secErr, secCtx.User)
 		} else {
 			http.Error(w, "Permission check failed", http.StatusInternalServerError)
 		}
@@ -490,7 +490,7 @@ func (s *Server) handleDeleteSecret(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		s.logger.Error("Failed to delete secret",
+		s.requestLogger(r).Error("Failed to delete secret",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.String("user", secCtx.User.Email),
@@ -502,7 +502,7 @@ func (s *Server) handleDeleteSecret(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log successful deletion for audit
-	s.logger.Info("Secret deleted successfully",
+	s.requestLogger(r).Info("Secret deleted successfully",
 		zap.String("user", secCtx.User.Email),
 		zap.String("user_sub", secCtx.User.Sub),
 		zap.Strings("user_groups", secCtx.User.Groups),
@@ -555,7 +555,7 @@ func (s *Server) handleGetSecretData(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		s.logger.Error("Failed to get secret for data access",
+		s.requestLogger(r).Error("Failed to get secret for data access",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.String("key", key),
@@ -627,7 +627,7 @@ func (s *Server) handleGetSecretUsageExamples(w http.ResponseWriter, r *http.Req
 			return
 		}
 
-		s.logger.Error("Failed to get secret for usage examples",
+		s.requestLogger(r).Error("Failed to get secret for usage examples",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/selectors"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// handleListServiceAccounts handles GET /api/v1/service-accounts
+// @Summary List service accounts
+// @Description Lists all service accounts in the cluster or a specific namespace, with filtering, sorting, and pagination.
+// @Tags ServiceAccounts
+// @Produce json
+// @Param namespace query string false "Namespace to filter by"
+// @Param labelSelector query string false "Label selector to filter service accounts"
+// @Param fieldSelector query string false "Field selector to filter service accounts"
+// @Param page query int false "Page number (default: 1)"
+// @Param pageSize query int false "Page size (default: 25)"
+// @Param sort query string false "Sort by field (name, namespace, age); sortBy is accepted as an alias"
+// @Param order query string false "Sort order (asc/desc)"
+// @Param search query string false "Search term"
+// @Success 200 {object} map[string]interface{} "Paginated list of service accounts"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/service-accounts [get]
+func (s *Server) handleListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if pageSize <= 0 {
+		pageSize = 25
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	serviceAccounts, err := s.resourceManager.ListServiceAccounts(r.Context(), namespace)
+	if err != nil {
+		s.requestLogger(r).Error("Failed to list service accounts", zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filtered, err := selectors.FilterServiceAccounts(serviceAccounts, selectors.ServiceAccountFilterOptions{
+		Namespace:     namespace,
+		LabelSelector: r.URL.Query().Get("labelSelector"),
+		FieldSelector: r.URL.Query().Get("fieldSelector"),
+		Search:        r.URL.Query().Get("search"),
+		Sort:          getSortParam(r),
+		Order:         r.URL.Query().Get("order"),
+		Page:          page,
+		PageSize:      pageSize,
+	})
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Failed to filter service accounts: "+err.Error())
+		return
+	}
+
+	responses := []map[string]interface{}{}
+	for _, serviceAccount := range filtered {
+		responses = append(responses, s.serviceAccountToResponse(serviceAccount))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{
+			"items":    responses,
+			"page":     page,
+			"pageSize": pageSize,
+			"total":    len(serviceAccounts),
+		},
+		"status": "success",
+	})
+}
+
+// handleGetServiceAccount handles GET /api/v1/service-accounts/{namespace}/{name}
+// @Summary Get service account details
+// @Description Get details for a specific service account: its token Secrets and imagePullSecrets, each marked found or missing, and the RoleBindings and ClusterRoleBindings that grant it roles, directly or through the system:serviceaccounts groups.
+// @Tags ServiceAccounts
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param name path string true "ServiceAccount name"
+// @Success 200 {object} map[string]interface{} "ServiceAccount details"
+// @Failure 404 {object} map[string]interface{} "Service account not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/service-accounts/{namespace}/{name} [get]
+func (s *Server) handleGetServiceAccount(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	serviceAccount, err := s.resourceManager.GetServiceAccount(r.Context(), namespace, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			writeJSONError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		s.requestLogger(r).Error("Failed to get service account",
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	details, err := s.resourceManager.ResolveServiceAccount(r.Context(), serviceAccount)
+	if err != nil {
+		s.requestLogger(r).Error("Failed to resolve service account",
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{
+			"summary":          s.serviceAccountToResponse(*serviceAccount),
+			"secrets":          details.Secrets,
+			"imagePullSecrets": details.ImagePullSecrets,
+			"bindings":         details.Bindings,
+			"metadata":         serviceAccount.ObjectMeta,
+			"kind":             "ServiceAccount",
+			"apiVersion":       "v1",
+		},
+		"status": "success",
+	})
+}
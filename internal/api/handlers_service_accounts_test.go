@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newServiceAccountServer() *Server {
+	client := fake.NewSimpleClientset(
+		&v1.ServiceAccount{
+			ObjectMeta:       metav1.ObjectMeta{Name: "builder", Namespace: "ci"},
+			ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry"}},
+		},
+		&v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "ci"}},
+		&v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "shop"}},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "shop"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "builder", Namespace: "ci"}},
+		},
+	)
+	return &Server{
+		logger:          zap.NewNop(),
+		config:          &config.Config{},
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}
+}
+
+func TestHandleListServiceAccounts(t *testing.T) {
+	s := newServiceAccountServer()
+
+	tests := []struct {
+		name   string
+		target string
+		ids    []string
+	}{
+		{"all namespaces", "/api/v1/service-accounts?sort=namespace", []string{"ci-builder", "ci-default", "shop-default"}},
+		{"namespace", "/api/v1/service-accounts?namespace=shop", []string{"shop-default"}},
+		{"search", "/api/v1/service-accounts?search=build", []string{"ci-builder"}},
+		{"paginated", "/api/v1/service-accounts?sort=namespace&order=desc&pageSize=1", []string{"shop-default"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.handleListServiceAccounts(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var response struct {
+				Data struct {
+					Items []map[string]interface{} `json:"items"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			ids := []string{}
+			for _, item := range response.Data.Items {
+				ids = append(ids, item["id"].(string))
+			}
+			assert.Equal(t, tt.ids, ids)
+		})
+	}
+}
+
+func TestHandleGetServiceAccount(t *testing.T) {
+	s := newServiceAccountServer()
+
+	rec := httptest.NewRecorder()
+	s.handleGetServiceAccount(rec, withNameParams(httptest.NewRequest(http.MethodGet, "/api/v1/service-accounts/ci/builder", nil), "ci", "builder"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Data struct {
+			Summary          map[string]interface{}            `json:"summary"`
+			Secrets          []resources.ServiceAccountSecret  `json:"secrets"`
+			ImagePullSecrets []resources.ServiceAccountSecret  `json:"imagePullSecrets"`
+			Bindings         []resources.ServiceAccountBinding `json:"bindings"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, true, response.Data.Summary["automountToken"])
+	assert.Empty(t, response.Data.Secrets)
+	assert.Equal(t, []resources.ServiceAccountSecret{{Name: "registry"}}, response.Data.ImagePullSecrets)
+	require.Len(t, response.Data.Bindings, 1)
+	assert.Equal(t, "deploy", response.Data.Bindings[0].Name)
+	assert.Equal(t, "shop", response.Data.Bindings[0].Namespace)
+
+	rec = httptest.NewRecorder()
+	s.handleGetServiceAccount(rec, withNameParams(httptest.NewRequest(http.MethodGet, "/api/v1/service-accounts/ci/missing", nil), "ci", "missing"))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -42,7 +42,7 @@ func (s *Server) handleGetService(w http.ResponseWriter, r *http.Request) {
 	// Get service from Kubernetes API
 	service, err := s.kubeClient.CoreV1().Services(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get service",
+		s.requestLogger(r).Error("Failed to get service",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -104,7 +104,7 @@ func (s *Server) handleGetEndpoints(w http.ResponseWriter, r *http.Request) {
 	// Get endpoints from Kubernetes API
 	endpoint, err := s.kubeClient.CoreV1().Endpoints(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get endpoints",
+		s.requestLogger(r).Error("Failed to get endpoints",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -170,7 +170,7 @@ func (s *Server) handleListEndpointSlices(w http.ResponseWriter, r *http.Request
 	// Get endpoint slices from resource manager
 	endpointSlices, err := s.resourceManager.ListEndpointSlices(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list endpoint slices", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list endpoint slices", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -268,7 +268,7 @@ func (s *Server) handleGetEndpointSlice(w http.ResponseWriter, r *http.Request)
 	// Get endpoint slice from resource manager
 	endpointSlice, err := s.resourceManager.GetEndpointSlice(r.Context(), namespace, name)
 	if err != nil {
-		s.logger.Error("Failed to get endpoint slice",
+		s.requestLogger(r).Error("Failed to get endpoint slice",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -281,11 +281,20 @@ func (s *Server) handleGetEndpointSlice(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	endpointSliceMap, err := unstructuredObject(endpointSlice, "EndpointSlice", "metadata")
+	if err != nil {
+		s.requestLogger(r).Error("Malformed endpoint slice",
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	// Convert to enhanced summary
-	summary := s.endpointSliceToResponse(endpointSlice)
+	summary := s.endpointSliceToResponse(endpointSliceMap)
 
 	// Add full endpoint slice details for detailed view
-	endpointSliceMap := endpointSlice.(map[string]interface{})
 	fullDetails := map[string]interface{}{
 		"summary":    summary,
 		"spec":       endpointSliceMap["spec"],
@@ -310,7 +319,7 @@ func (s *Server) handleGetEndpointSlice(w http.ResponseWriter, r *http.Request)
 // @Param namespace query string false "Namespace to filter by (empty for all namespaces)"
 // @Param labelSelector query string false "Label selector to filter NetworkPolicies"
 // @Param fieldSelector query string false "Field selector to filter NetworkPolicies"
-// @Param sort query string false "Sort by field"
+// @Param sort query string false "Sort by field; sortBy is accepted as an alias"
 // @Param order query string false "Sort order (asc/desc)"
 // @Param search query string false "Search term"
 // @Param page query int false "Page number (default: 1)"
@@ -326,7 +335,7 @@ func (s *Server) handleListNetworkPolicies(w http.ResponseWriter, r *http.Reques
 	fieldSelector := r.URL.Query().Get("fieldSelector")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pageSize")
-	sort := r.URL.Query().Get("sort")
+	sort := getSortParam(r)
 	order := r.URL.Query().Get("order")
 	search := r.URL.Query().Get("search")
 
@@ -344,7 +353,7 @@ func (s *Server) handleListNetworkPolicies(w http.ResponseWriter, r *http.Reques
 	// Get network policies from resource manager
 	networkPolicies, err := s.resourceManager.ListNetworkPolicies(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list network policies", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list network policies", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -377,8 +386,8 @@ func (s *Server) handleListNetworkPolicies(w http.ResponseWriter, r *http.Reques
 
 	filteredNetworkPolicies, err := selectors.FilterNetworkPolicies(networkPolicies, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter network policies", zap.Error(err))
-		http.Error(w, "Failed to filter network policies", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter network policies", zap.Error(err))
+		http.Error(w, "Failed to filter network policies: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -432,7 +441,7 @@ func (s *Server) handleGetNetworkPolicy(w http.ResponseWriter, r *http.Request)
 	// Get network policy from Kubernetes API
 	networkPolicy, err := s.kubeClient.NetworkingV1().NetworkPolicies(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get network policy",
+		s.requestLogger(r).Error("Failed to get network policy",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -472,7 +481,7 @@ func (s *Server) handleGetNetworkPolicy(w http.ResponseWriter, r *http.Request)
 // @Produce json
 // @Param namespace query string false "Namespace to filter by (empty for all namespaces)"
 // @Param search query string false "Search term for Service name"
-// @Param sortBy query string false "Sort by field (default: name)"
+// @Param sort query string false "Sort by field (default: name); sortBy is accepted as an alias"
 // @Param page query int false "Page number (default: 1)"
 // @Param pageSize query int false "Page size (default: 50, max: 100)"
 // @Success 200 {object} map[string]interface{} "Paginated list of Services"
@@ -483,7 +492,7 @@ func (s *Server) handleListServices(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
 	namespace := r.URL.Query().Get("namespace")
 	search := r.URL.Query().Get("search")
-	sortBy := r.URL.Query().Get("sortBy")
+	sortBy := getSortParam(r)
 	if sortBy == "" {
 		sortBy = "name"
 	}
@@ -505,7 +514,7 @@ func (s *Server) handleListServices(w http.ResponseWriter, r *http.Request) {
 	// List services from all namespaces (or specific namespace if provided)
 	services, err := s.resourceManager.ListServices(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list services", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list services", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -535,8 +544,8 @@ func (s *Server) handleListServices(w http.ResponseWriter, r *http.Request) {
 
 	filteredServices, err := selectors.FilterServices(services, filterOptions)
 	if err != nil {
-		s.logger.Error("Failed to filter services", zap.Error(err))
-		http.Error(w, "Failed to filter services", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter services", zap.Error(err))
+		http.Error(w, "Failed to filter services: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -588,7 +597,7 @@ func (s *Server) handleListServicesInNamespace(w http.ResponseWriter, r *http.Re
 
 	services, err := s.resourceManager.ListServices(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list services",
+		s.requestLogger(r).Error("Failed to list services",
 			zap.String("namespace", namespace),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -641,7 +650,7 @@ func (s *Server) handleListAllIngresses(w http.ResponseWriter, r *http.Request)
 		// Get ingresses from specific namespace
 		ingresses, err := s.resourceManager.ListIngresses(r.Context(), namespace)
 		if err != nil {
-			s.logger.Error("Failed to list ingresses",
+			s.requestLogger(r).Error("Failed to list ingresses",
 				zap.String("namespace", namespace),
 				zap.Error(err))
 			w.Header().Set("Content-Type", "application/json")
@@ -661,7 +670,7 @@ func (s *Server) handleListAllIngresses(w http.ResponseWriter, r *http.Request)
 		// First get all namespaces
 		namespaces, err := s.kubeClient.CoreV1().Namespaces().List(r.Context(), metav1.ListOptions{})
 		if err != nil {
-			s.logger.Error("Failed to list namespaces for ingresses", zap.Error(err))
+			s.requestLogger(r).Error("Failed to list namespaces for ingresses", zap.Error(err))
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(map[string]interface{}{
@@ -678,7 +687,7 @@ func (s *Server) handleListAllIngresses(w http.ResponseWriter, r *http.Request)
 		for _, ns := range namespaces.Items {
 			ingresses, err := s.resourceManager.ListIngresses(r.Context(), ns.Name)
 			if err != nil {
-				s.logger.Warn("Failed to list ingresses from namespace",
+				s.requestLogger(r).Warn("Failed to list ingresses from namespace",
 					zap.String("namespace", ns.Name),
 					zap.Error(err))
 				continue // Skip this namespace but continue with others
@@ -733,7 +742,7 @@ func (s *Server) handleListIngresses(w http.ResponseWriter, r *http.Request) {
 
 	ingresses, err := s.resourceManager.ListIngresses(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list ingresses",
+		s.requestLogger(r).Error("Failed to list ingresses",
 			zap.String("namespace", namespace),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -795,7 +804,7 @@ func (s *Server) handleGetIngress(w http.ResponseWriter, r *http.Request) {
 	// Get ingress from resource manager
 	ingressObj, err := s.resourceManager.GetIngress(r.Context(), namespace, name)
 	if err != nil {
-		s.logger.Error("Failed to get ingress",
+		s.requestLogger(r).Error("Failed to get ingress",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -840,7 +849,7 @@ func (s *Server) handleGetIngress(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleListIngressClasses(w http.ResponseWriter, r *http.Request) {
 	ingressClasses, err := s.resourceManager.ListIngressClasses(r.Context())
 	if err != nil {
-		s.logger.Error("Failed to list ingress classes", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list ingress classes", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -898,7 +907,7 @@ func (s *Server) handleGetIngressClass(w http.ResponseWriter, r *http.Request) {
 	// Get ingress class from resource manager
 	ingressClassObj, err := s.resourceManager.GetIngressClass(r.Context(), name)
 	if err != nil {
-		s.logger.Error("Failed to get ingress class",
+		s.requestLogger(r).Error("Failed to get ingress class",
 			zap.String("name", name),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -910,13 +919,22 @@ func (s *Server) handleGetIngressClass(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ingressClassMap, err := unstructuredObject(ingressClassObj, "IngressClass")
+	if err != nil {
+		s.requestLogger(r).Error("Malformed ingress class",
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	// Convert to enhanced summary
-	summary := s.ingressClassToResponse(ingressClassObj)
+	summary := s.ingressClassToResponse(ingressClassMap)
 
 	// Add full ingress class spec for detailed view
 	fullDetails := map[string]interface{}{
 		"summary": summary,
-		"spec":    ingressClassObj.(map[string]interface{})["spec"],
+		"spec":    ingressClassMap["spec"],
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -929,7 +947,8 @@ func (s *Server) handleGetIngressClass(w http.ResponseWriter, r *http.Request) {
 
 // ingressClassToResponse converts an IngressClass object to response format
 func (s *Server) ingressClassToResponse(ingressClassObj interface{}) map[string]interface{} {
-	ic := ingressClassObj.(map[string]interface{})
+	// A malformed object reads as empty rather than panicking
+	ic, _ := ingressClassObj.(map[string]interface{})
 
 	// Calculate age
 	creationTime, _ := ic["creationTimestamp"].(time.Time)
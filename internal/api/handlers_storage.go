@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aaronlmathis/kaptn/internal/k8s/analysis"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
@@ -61,7 +62,7 @@ func (s *Server) handleListPersistentVolumeClaims(w http.ResponseWriter, r *http
 	}
 
 	if err != nil {
-		s.logger.Error("Failed to list persistent volume claims", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list persistent volume claims", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -153,7 +154,7 @@ func (s *Server) handleGetPersistentVolumeClaim(w http.ResponseWriter, r *http.R
 	// Get PVC from Kubernetes API
 	pvc, err := s.kubeClient.CoreV1().PersistentVolumeClaims(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get persistent volume claim",
+		s.requestLogger(r).Error("Failed to get persistent volume claim",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -187,6 +188,47 @@ func (s *Server) handleGetPersistentVolumeClaim(w http.ResponseWriter, r *http.R
 	})
 }
 
+// handleGetStorageProblems handles GET /api/v1/storage/problems
+// @Summary List storage problems
+// @Description Lists PersistentVolumeClaims that are Pending or Lost, from the informer cache, with their storage class and requested size. Pending claims older than the threshold are flagged as stuck, which usually means no PersistentVolume matches or the provisioner is failing.
+// @Tags PersistentVolumeClaims
+// @Produce json
+// @Param namespace query string false "Limit the report to a namespace"
+// @Param threshold query string false "How long a claim may be Pending before it is stuck, e.g. 10m (default 5m)"
+// @Success 200 {object} analysis.StorageProblemReport "Storage problem report"
+// @Failure 400 {object} map[string]interface{} "Invalid threshold"
+// @Failure 503 {object} map[string]interface{} "Informer cache not ready"
+// @Router /api/v1/storage/problems [get]
+func (s *Server) handleGetStorageProblems(w http.ResponseWriter, r *http.Request) {
+	threshold := analysis.DefaultPVCPendingThreshold
+	if raw := r.URL.Query().Get("threshold"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			writeJSONError(w, http.StatusBadRequest, "Invalid threshold parameter. Must be a positive duration (e.g., '5m', '1h')")
+			return
+		}
+		threshold = parsed
+	}
+
+	if s.informerManager == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Informer cache not available")
+		return
+	}
+	pvcs, ok := s.informerManager.ListPersistentVolumeClaims()
+	if !ok {
+		writeJSONError(w, http.StatusServiceUnavailable, "Informer cache for persistentvolumeclaims has not synced yet")
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   analysis.FindStorageProblems(pvcs, namespace, threshold, time.Now()),
+		"status": "success",
+	})
+}
+
 // handleListStorageClasses handles GET /api/v1/storageclasses
 // @Summary List StorageClasses
 // @Description Lists all StorageClasses with optional search and pagination.
@@ -218,7 +260,7 @@ func (s *Server) handleListStorageClasses(w http.ResponseWriter, r *http.Request
 	// Get storage classes from resource manager
 	storageClasses, err := s.resourceManager.ListStorageClasses(r.Context())
 	if err != nil {
-		s.logger.Error("Failed to list storage classes", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list storage classes", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -311,7 +353,7 @@ func (s *Server) handleGetStorageClass(w http.ResponseWriter, r *http.Request) {
 	// Get storage class from resource manager
 	storageClass, err := s.resourceManager.GetStorageClass(r.Context(), name)
 	if err != nil {
-		s.logger.Error("Failed to get storage class",
+		s.requestLogger(r).Error("Failed to get storage class",
 			zap.String("name", name),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -376,7 +418,7 @@ func (s *Server) handleListVolumeSnapshots(w http.ResponseWriter, r *http.Reques
 	// Get volume snapshots from resource manager
 	volumeSnapshots, err := s.resourceManager.ListVolumeSnapshots(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list volume snapshots", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list volume snapshots", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -474,7 +516,7 @@ func (s *Server) handleGetVolumeSnapshot(w http.ResponseWriter, r *http.Request)
 	// Get volume snapshot from resource manager
 	volumeSnapshot, err := s.resourceManager.GetVolumeSnapshot(r.Context(), namespace, name)
 	if err != nil {
-		s.logger.Error("Failed to get volume snapshot",
+		s.requestLogger(r).Error("Failed to get volume snapshot",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -487,11 +529,20 @@ func (s *Server) handleGetVolumeSnapshot(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	volumeSnapshotMap, err := unstructuredObject(volumeSnapshot, "VolumeSnapshot", "metadata", "spec", "status")
+	if err != nil {
+		s.requestLogger(r).Error("Malformed volume snapshot",
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	// Convert to enhanced summary
-	summary := s.volumeSnapshotToResponse(volumeSnapshot)
+	summary := s.volumeSnapshotToResponse(volumeSnapshotMap)
 
 	// Add full volume snapshot details for detailed view
-	volumeSnapshotMap := volumeSnapshot.(map[string]interface{})
 	fullDetails := map[string]interface{}{
 		"summary":    summary,
 		"spec":       volumeSnapshotMap["spec"],
@@ -540,7 +591,7 @@ func (s *Server) handleListVolumeSnapshotClasses(w http.ResponseWriter, r *http.
 	// Get volume snapshot classes from resource manager
 	volumeSnapshotClasses, err := s.resourceManager.ListVolumeSnapshotClasses(r.Context())
 	if err != nil {
-		s.logger.Error("Failed to list volume snapshot classes", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list volume snapshot classes", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -637,7 +688,7 @@ func (s *Server) handleGetVolumeSnapshotClass(w http.ResponseWriter, r *http.Req
 	// Get volume snapshot class from resource manager
 	volumeSnapshotClass, err := s.resourceManager.GetVolumeSnapshotClass(r.Context(), name)
 	if err != nil {
-		s.logger.Error("Failed to get volume snapshot class",
+		s.requestLogger(r).Error("Failed to get volume snapshot class",
 			zap.String("name", name),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -649,11 +700,19 @@ func (s *Server) handleGetVolumeSnapshotClass(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	volumeSnapshotClassMap, err := unstructuredObject(volumeSnapshotClass, "VolumeSnapshotClass", "metadata")
+	if err != nil {
+		s.requestLogger(r).Error("Malformed volume snapshot class",
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	// Convert to enhanced summary
-	summary := s.volumeSnapshotClassToResponse(volumeSnapshotClass)
+	summary := s.volumeSnapshotClassToResponse(volumeSnapshotClassMap)
 
 	// Add full volume snapshot class details for detailed view
-	volumeSnapshotClassMap := volumeSnapshotClass.(map[string]interface{})
 	fullDetails := map[string]interface{}{
 		"summary":    summary,
 		"spec":       volumeSnapshotClassMap["spec"],
@@ -701,7 +760,7 @@ func (s *Server) handleListCSIDrivers(w http.ResponseWriter, r *http.Request) {
 	// Get CSI drivers from resource manager
 	csiDrivers, err := s.resourceManager.ListCSIDrivers(r.Context())
 	if err != nil {
-		s.logger.Error("Failed to list CSI drivers", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list CSI drivers", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -794,7 +853,7 @@ func (s *Server) handleGetCSIDriver(w http.ResponseWriter, r *http.Request) {
 	// Get CSI driver from resource manager
 	csiDriver, err := s.resourceManager.GetCSIDriver(r.Context(), name)
 	if err != nil {
-		s.logger.Error("Failed to get CSI driver",
+		s.requestLogger(r).Error("Failed to get CSI driver",
 			zap.String("name", name),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -835,7 +894,9 @@ func (s *Server) handleGetCSIDriver(w http.ResponseWriter, r *http.Request) {
 // @Param search query string false "Search term for ConfigMap name"
 // @Param page query int false "Page number (default: 1)"
 // @Param pageSize query int false "Page size (default: 25)"
+// @Param reveal query bool false "Include values of keys matching the configured redaction patterns; requires permission to read secrets"
 // @Success 200 {object} map[string]interface{} "Paginated list of ConfigMaps"
+// @Failure 403 {object} map[string]interface{} "Not allowed to reveal redacted keys"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/configmaps [get]
 func (s *Server) handleListConfigMaps(w http.ResponseWriter, r *http.Request) {
@@ -856,10 +917,15 @@ func (s *Server) handleListConfigMaps(w http.ResponseWriter, r *http.Request) {
 		page = 1
 	}
 
+	redactor, ok := s.configMapRedactorFor(w, r, namespace)
+	if !ok {
+		return
+	}
+
 	// Get config maps from resource manager
 	configMaps, err := s.resourceManager.ListConfigMaps(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list config maps", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list config maps", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -893,7 +959,7 @@ func (s *Server) handleListConfigMaps(w http.ResponseWriter, r *http.Request) {
 	// Convert to response format
 	var responses []map[string]interface{}
 	for _, configMap := range filteredConfigMaps {
-		responses = append(responses, s.configMapToResponse(configMap))
+		responses = append(responses, s.configMapToResponse(configMap, redactor))
 	}
 
 	// Apply pagination
@@ -925,13 +991,15 @@ func (s *Server) handleListConfigMaps(w http.ResponseWriter, r *http.Request) {
 
 // handleGetConfigMap handles GET /api/v1/namespaces/{namespace}/configmaps/{name}
 // @Summary Get ConfigMap details
-// @Description Get details and summary for a specific ConfigMap.
+// @Description Get details and summary for a specific ConfigMap. Values of keys matching the configured redaction patterns are replaced with "[REDACTED]" unless reveal=true is passed by a caller allowed to read secrets in the namespace.
 // @Tags ConfigMaps
 // @Produce json
 // @Param namespace path string true "Namespace"
 // @Param name path string true "ConfigMap name"
+// @Param reveal query bool false "Include values of redacted keys"
 // @Success 200 {object} map[string]interface{} "ConfigMap details"
 // @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 403 {object} map[string]interface{} "Not allowed to reveal redacted keys"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/namespaces/{namespace}/configmaps/{name} [get]
 func (s *Server) handleGetConfigMap(w http.ResponseWriter, r *http.Request) {
@@ -948,10 +1016,15 @@ func (s *Server) handleGetConfigMap(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	redactor, ok := s.configMapRedactorFor(w, r, namespace)
+	if !ok {
+		return
+	}
+
 	// Get config map from resource manager
 	configMap, err := s.resourceManager.GetConfigMap(r.Context(), namespace, name)
 	if err != nil {
-		s.logger.Error("Failed to get config map",
+		s.requestLogger(r).Error("Failed to get config map",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -964,42 +1037,55 @@ func (s *Server) handleGetConfigMap(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	unstructuredMap, err := unstructuredObject(configMap, "ConfigMap", "metadata", "data")
+	if err != nil {
+		s.requestLogger(r).Error("Malformed config map",
+			zap.String("namespace", namespace),
+			zap.String("name", name),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	// Convert unstructured to ConfigMap for enhanced summary
 	configMapObj := &v1.ConfigMap{}
-	if unstructuredMap, ok := configMap.(map[string]interface{}); ok {
-		// Extract metadata
-		if metadata, ok := unstructuredMap["metadata"].(map[string]interface{}); ok {
-			if name, ok := metadata["name"].(string); ok {
-				configMapObj.Name = name
-			}
-			if namespace, ok := metadata["namespace"].(string); ok {
-				configMapObj.Namespace = namespace
-			}
-			if creationTimestamp, ok := metadata["creationTimestamp"].(string); ok {
-				if ts, err := time.Parse(time.RFC3339, creationTimestamp); err == nil {
-					configMapObj.CreationTimestamp = metav1.NewTime(ts)
-				}
+	// Extract metadata
+	if metadata, ok := unstructuredMap["metadata"].(map[string]interface{}); ok {
+		if name, ok := metadata["name"].(string); ok {
+			configMapObj.Name = name
+		}
+		if namespace, ok := metadata["namespace"].(string); ok {
+			configMapObj.Namespace = namespace
+		}
+		if creationTimestamp, ok := metadata["creationTimestamp"].(string); ok {
+			if ts, err := time.Parse(time.RFC3339, creationTimestamp); err == nil {
+				configMapObj.CreationTimestamp = metav1.NewTime(ts)
 			}
 		}
-		// Extract data
-		if data, ok := unstructuredMap["data"].(map[string]interface{}); ok {
-			configMapObj.Data = make(map[string]string)
-			for k, v := range data {
-				if strVal, ok := v.(string); ok {
-					configMapObj.Data[k] = strVal
-				}
+	}
+	// Extract data
+	if data, ok := unstructuredMap["data"].(map[string]interface{}); ok {
+		configMapObj.Data = make(map[string]string)
+		for k, v := range data {
+			if strVal, ok := v.(string); ok {
+				configMapObj.Data[k] = strVal
 			}
 		}
 	}
 
 	// Convert to enhanced summary
-	summary := s.configMapToResponse(*configMapObj)
+	summary := s.configMapToResponse(*configMapObj, redactor)
+
+	data, _ := unstructuredMap["data"].(map[string]interface{})
+	metadata, _ := unstructuredMap["metadata"].(map[string]interface{})
+	redactedKeys, _ := summary["redactedKeys"].([]string)
+	metadata = redactor.redactMetadata(metadata, redactedKeys)
 
 	// Add full config map details for detailed view
 	fullDetails := map[string]interface{}{
 		"summary":    summary,
-		"spec":       configMap.(map[string]interface{})["data"],
-		"metadata":   configMap.(map[string]interface{})["metadata"],
+		"spec":       redactor.redactData(data),
+		"metadata":   metadata,
 		"kind":       "ConfigMap",
 		"apiVersion": "v1",
 	}
@@ -1048,7 +1134,7 @@ func (s *Server) handleListPersistentVolumes(w http.ResponseWriter, r *http.Requ
 		metav1.ListOptions{},
 	)
 	if err != nil {
-		s.logger.Error("Failed to list persistent volumes", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list persistent volumes", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -1137,7 +1223,7 @@ func (s *Server) handleGetPersistentVolume(w http.ResponseWriter, r *http.Reques
 	// Get PV from Kubernetes API
 	pv, err := s.kubeClient.CoreV1().PersistentVolumes().Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get persistent volume",
+		s.requestLogger(r).Error("Failed to get persistent volume",
 			zap.String("name", name),
 			zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -18,7 +18,7 @@ func (s *Server) handleGetSummaryCards(w http.ResponseWriter, r *http.Request) {
 	// Get summary cards from summary service
 	cards, err := s.summaryService.GetSummaryCards(ctx, namespace)
 	if err != nil {
-		s.logger.Error("Failed to get summary cards",
+		s.requestLogger(r).Error("Failed to get summary cards",
 			zap.String("namespace", namespace),
 			zap.Error(err))
 		http.Error(w, "Failed to get summary cards", http.StatusInternalServerError)
@@ -32,7 +32,7 @@ func (s *Server) handleGetSummaryCards(w http.ResponseWriter, r *http.Request) {
 		"cards":     cards,
 		"namespace": namespace,
 	}); err != nil {
-		s.logger.Error("Failed to encode summary cards response", zap.Error(err))
+		s.requestLogger(r).Error("Failed to encode summary cards response", zap.Error(err))
 	}
 }
 
@@ -53,7 +53,7 @@ func (s *Server) handleGetResourceSummary(w http.ResponseWriter, r *http.Request
 	// Get resource summary from summary service
 	summary, err := s.summaryService.GetResourceSummary(ctx, resourceType, namespace)
 	if err != nil {
-		s.logger.Error("Failed to get resource summary",
+		s.requestLogger(r).Error("Failed to get resource summary",
 			zap.String("resource", resourceType),
 			zap.String("namespace", namespace),
 			zap.Error(err))
@@ -65,7 +65,7 @@ func (s *Server) handleGetResourceSummary(w http.ResponseWriter, r *http.Request
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(summary); err != nil {
-		s.logger.Error("Failed to encode resource summary response", zap.Error(err))
+		s.requestLogger(r).Error("Failed to encode resource summary response", zap.Error(err))
 	}
 }
 
@@ -90,7 +90,7 @@ func (s *Server) handleGetNamespacedResourceSummary(w http.ResponseWriter, r *ht
 	// Get resource summary from summary service
 	summary, err := s.summaryService.GetResourceSummary(ctx, resourceType, namespace)
 	if err != nil {
-		s.logger.Error("Failed to get namespaced resource summary",
+		s.requestLogger(r).Error("Failed to get namespaced resource summary",
 			zap.String("resource", resourceType),
 			zap.String("namespace", namespace),
 			zap.Error(err))
@@ -102,6 +102,6 @@ func (s *Server) handleGetNamespacedResourceSummary(w http.ResponseWriter, r *ht
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(summary); err != nil {
-		s.logger.Error("Failed to encode namespaced resource summary response", zap.Error(err))
+		s.requestLogger(r).Error("Failed to encode namespaced resource summary response", zap.Error(err))
 	}
 }
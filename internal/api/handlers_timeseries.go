@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	apimiddleware "github.com/aaronlmathis/kaptn/internal/middleware"
 	"github.com/aaronlmathis/kaptn/internal/timeseries"
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/websocket"
@@ -23,6 +25,8 @@ type TimeSeriesResponse struct {
 	Series       map[string][]TimeSeriesPoint `json:"series"`
 	Capabilities map[string]bool              `json:"capabilities"`
 	Metadata     *TimeSeriesMetadata          `json:"metadata,omitempty"`
+	// Entities lists the nodes or namespaces with data in the response
+	Entities []string `json:"entities,omitempty"`
 }
 
 // TimeSeriesMetadata provides additional context about the response
@@ -38,6 +42,68 @@ type TimeSeriesPoint struct {
 	T      int64             `json:"t"`                // Unix timestamp in milliseconds
 	V      float64           `json:"v"`                // Value
 	Entity map[string]string `json:"entity,omitempty"` // Entity metadata
+
+	// Min, max and mean of a lo-res step, when lo-res aggregates are enabled
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	Avg *float64 `json:"avg,omitempty"`
+}
+
+// seriesAPIPoints returns the points of a series since the given time in API
+// format. Lo-res points carry their step's min, max and mean when the store
+// keeps lo-res aggregates.
+func seriesAPIPoints(series *timeseries.Series, since time.Time, res timeseries.Resolution) []TimeSeriesPoint {
+	var aggregates map[int64]timeseries.AggPoint
+	if res == timeseries.Lo {
+		for _, agg := range series.GetAggregatesSince(since) {
+			if aggregates == nil {
+				aggregates = make(map[int64]timeseries.AggPoint)
+			}
+			aggregates[agg.T.UnixMilli()] = agg
+		}
+	}
+
+	var apiPoints []TimeSeriesPoint
+	for _, point := range series.GetSince(since, res) {
+		apiPoint := TimeSeriesPoint{
+			T:      point.T.UnixMilli(),
+			V:      point.V,
+			Entity: point.Entity,
+		}
+		if agg, ok := aggregates[apiPoint.T]; ok {
+			apiPoint.Min, apiPoint.Max, apiPoint.Avg = &agg.Min, &agg.Max, &agg.Avg
+		}
+		apiPoints = append(apiPoints, apiPoint)
+	}
+	return apiPoints
+}
+
+// withEntityLabel sets an entity label, such as node, on points that lack it
+// so clients can tell which entity each point belongs to
+func withEntityLabel(points []TimeSeriesPoint, label, entity string) []TimeSeriesPoint {
+	for i, point := range points {
+		if point.Entity[label] == entity {
+			continue
+		}
+		labels := make(map[string]string, len(point.Entity)+1)
+		for key, value := range point.Entity {
+			labels[key] = value
+		}
+		labels[label] = entity
+		points[i].Entity = labels
+	}
+	return points
+}
+
+// seriesEntityExists reports whether the store has a series of any of the
+// metric bases for the entity
+func (s *Server) seriesEntityExists(metricBases []string, entity string) bool {
+	for _, seriesKey := range s.timeSeriesStore.Keys() {
+		if _, name, ok := timeseries.ParseEntitySeriesKey(seriesKey, metricBases); ok && name == entity {
+			return true
+		}
+	}
+	return false
 }
 
 // LiveTimeSeriesMessage represents a WebSocket message for live time series updates
@@ -192,27 +258,17 @@ func (s *Server) handleGetClusterTimeSeries(w http.ResponseWriter, r *http.Reque
 	resParam := r.URL.Query().Get("res")
 	sinceParam := r.URL.Query().Get("since")
 
-	// Default values
-	if resParam == "" {
-		resParam = "lo"
-	}
-	if sinceParam == "" {
-		sinceParam = "60m"
-	}
+	// Default values: per-prefix defaults for the requested series, then the global default
+	resParam, sinceParam = s.timeSeriesQueryDefaults(resParam, sinceParam, seriesParam, timeseries.AllSeriesKeys())
 
 	// Parse resolution
-	var resolution timeseries.Resolution
-	switch resParam {
-	case "hi":
-		resolution = timeseries.Hi
-	case "lo":
-		resolution = timeseries.Lo
-	default:
-		s.logger.Warn("Invalid resolution parameter", zap.String("res", resParam))
+	resolution, ok := timeseries.ParseResolution(resParam)
+	if !ok {
+		s.requestLogger(r).Warn("Invalid resolution parameter", zap.String("res", resParam))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
-			"error": "Invalid resolution parameter. Must be 'hi' or 'lo'",
+			"error": "Invalid resolution parameter. Must be 'hi', 'med' or 'lo'",
 		})
 		return
 	}
@@ -220,7 +276,7 @@ func (s *Server) handleGetClusterTimeSeries(w http.ResponseWriter, r *http.Reque
 	// Parse duration
 	since, err := time.ParseDuration(sinceParam)
 	if err != nil {
-		s.logger.Warn("Invalid since parameter", zap.String("since", sinceParam), zap.Error(err))
+		s.requestLogger(r).Warn("Invalid since parameter", zap.String("since", sinceParam), zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -250,7 +306,7 @@ func (s *Server) handleGetClusterTimeSeries(w http.ResponseWriter, r *http.Reque
 
 	for _, key := range requestedKeys {
 		if !validKeys[key] {
-			s.logger.Warn("Invalid series key", zap.String("key", key))
+			s.requestLogger(r).Warn("Invalid series key", zap.String("key", key))
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(map[string]string{
@@ -262,7 +318,7 @@ func (s *Server) handleGetClusterTimeSeries(w http.ResponseWriter, r *http.Reque
 
 	// Check if timeseries aggregator is available
 	if s.timeSeriesAggregator == nil {
-		s.logger.Error("TimeSeries aggregator not initialized")
+		s.requestLogger(r).Error("TimeSeries aggregator not initialized")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusServiceUnavailable)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -289,18 +345,8 @@ func (s *Server) handleGetClusterTimeSeries(w http.ResponseWriter, r *http.Reque
 			continue
 		}
 
-		// Get points since the specified time
-		points := series.GetSince(timeThreshold, resolution)
-
-		// Convert to API format
-		var apiPoints []TimeSeriesPoint
-		for _, point := range points {
-			apiPoints = append(apiPoints, TimeSeriesPoint{
-				T:      point.T.UnixMilli(),
-				V:      point.V,
-				Entity: point.Entity,
-			})
-		}
+		// Get points since the specified time in API format
+		apiPoints := seriesAPIPoints(series, timeThreshold, resolution)
 
 		seriesData[key] = apiPoints
 	}
@@ -312,7 +358,7 @@ func (s *Server) handleGetClusterTimeSeries(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Log successful request
-	s.logger.Debug("TimeSeries API request",
+	s.requestLogger(r).Debug("TimeSeries API request",
 		zap.Strings("series", requestedKeys),
 		zap.String("resolution", resParam),
 		zap.String("since", sinceParam),
@@ -334,7 +380,7 @@ func (s *Server) handleTimeSeriesLiveWebSocket(w http.ResponseWriter, r *http.Re
 
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		s.logger.Error("Failed to upgrade WebSocket connection", zap.Error(err))
+		s.requestLogger(r).Error("Failed to upgrade WebSocket connection", zap.Error(err))
 		return
 	}
 
@@ -342,7 +388,7 @@ func (s *Server) handleTimeSeriesLiveWebSocket(w http.ResponseWriter, r *http.Re
 	if s.timeSeriesStore != nil {
 		health := s.timeSeriesStore.GetHealth()
 		if !health.CheckWSClientLimit() {
-			s.logger.Warn("WebSocket connection rejected - client limit reached")
+			s.requestLogger(r).Warn("WebSocket connection rejected - client limit reached")
 			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "Client limit reached"))
 			conn.Close()
 			return
@@ -360,7 +406,7 @@ func (s *Server) handleTimeSeriesLiveWebSocket(w http.ResponseWriter, r *http.Re
 		TotalSeriesCount: 0,
 	}
 
-	s.logger.Info("New timeseries WebSocket client connected", zap.String("clientId", clientID))
+	s.requestLogger(r).Info("New timeseries WebSocket client connected", zap.String("clientId", clientID))
 
 	// Register client with manager
 	s.timeSeriesWSManager.addClient(client)
@@ -407,6 +453,7 @@ func (s *Server) sendTimeSeriesHello(client *TimeSeriesWSClient) {
 
 	// Send a default subscription after a short delay if client hasn't subscribed
 	go func() {
+		defer apimiddleware.RecoverGoroutine(s.logger, "timeseries-ws-default-subscription")
 		time.Sleep(2 * time.Second)
 
 		// Check if client is still connected and has no subscriptions
@@ -452,6 +499,7 @@ func (s *Server) sendTimeSeriesMessage(client *TimeSeriesWSClient, message inter
 
 // timeSeriesWSClientReader handles incoming messages from WebSocket client
 func (s *Server) timeSeriesWSClientReader(client *TimeSeriesWSClient) {
+	defer apimiddleware.RecoverGoroutine(s.logger, "timeseries-ws-reader")
 	defer func() {
 		s.timeSeriesWSManager.removeClient(client.ID)
 		client.Conn.Close()
@@ -499,6 +547,7 @@ func (s *Server) timeSeriesWSClientReader(client *TimeSeriesWSClient) {
 
 // timeSeriesWSClientWriter handles outgoing messages to WebSocket client
 func (s *Server) timeSeriesWSClientWriter(client *TimeSeriesWSClient) {
+	defer apimiddleware.RecoverGoroutine(s.logger, "timeseries-ws-writer")
 	ticker := time.NewTicker(54 * time.Second)
 	defer func() {
 		ticker.Stop()
@@ -536,14 +585,9 @@ func (s *Server) handleTimeSeriesSubscribe(client *TimeSeriesWSClient, messageBy
 	}
 
 	// Validate resolution
-	var resolution timeseries.Resolution
-	switch subscribeMsg.Res {
-	case "hi":
-		resolution = timeseries.Hi
-	case "lo":
-		resolution = timeseries.Lo
-	default:
-		s.sendTimeSeriesError(client, "Invalid resolution. Must be 'hi' or 'lo'")
+	resolution, ok := timeseries.ParseResolution(subscribeMsg.Res)
+	if !ok {
+		s.sendTimeSeriesError(client, "Invalid resolution. Must be 'hi', 'med' or 'lo'")
 		return
 	}
 
@@ -572,6 +616,8 @@ func (s *Server) handleTimeSeriesSubscribe(client *TimeSeriesWSClient, messageBy
 	nodeMetricBases := timeseries.GetNodeMetricBases()
 	podMetricBases := timeseries.GetPodMetricBases()
 	nsMetricBases := timeseries.GetNamespaceMetricBases()
+	svcMetricBases := timeseries.GetServiceMetricBases()
+	workloadMetricBases := timeseries.GetWorkloadMetricBases()
 
 	isValidEntityMetric := func(key string) bool {
 		// Check node patterns
@@ -592,6 +638,18 @@ func (s *Server) handleTimeSeriesSubscribe(client *TimeSeriesWSClient, messageBy
 				return true
 			}
 		}
+		// Check service patterns
+		for _, base := range svcMetricBases {
+			if strings.HasPrefix(key, base+".") && len(key) > len(base)+1 {
+				return true
+			}
+		}
+		// Check workload patterns
+		for _, base := range workloadMetricBases {
+			if strings.HasPrefix(key, base+".") && len(key) > len(base)+1 {
+				return true
+			}
+		}
 		return false
 	}
 
@@ -805,7 +863,7 @@ func (s *Server) handleClusterTimeSeriesLiveWebSocket(w http.ResponseWriter, r *
 
 	for _, key := range requestedKeys {
 		if !validKeys[key] {
-			s.logger.Warn("Invalid series key in WebSocket request", zap.String("key", key))
+			s.requestLogger(r).Warn("Invalid series key in WebSocket request", zap.String("key", key))
 			http.Error(w, "Invalid series key: "+key, http.StatusBadRequest)
 			return
 		}
@@ -813,7 +871,7 @@ func (s *Server) handleClusterTimeSeriesLiveWebSocket(w http.ResponseWriter, r *
 
 	// Check if timeseries store and aggregator are available
 	if s.timeSeriesStore == nil || s.timeSeriesAggregator == nil {
-		s.logger.Error("TimeSeries services not initialized for WebSocket")
+		s.requestLogger(r).Error("TimeSeries services not initialized for WebSocket")
 		http.Error(w, "TimeSeries service not available", http.StatusServiceUnavailable)
 		return
 	}
@@ -821,7 +879,7 @@ func (s *Server) handleClusterTimeSeriesLiveWebSocket(w http.ResponseWriter, r *
 	// Check WebSocket client limits
 	health := s.timeSeriesStore.GetHealth()
 	if !health.CheckWSClientLimit() {
-		s.logger.Warn("WebSocket connection rejected - client limit reached")
+		s.requestLogger(r).Warn("WebSocket connection rejected - client limit reached")
 		http.Error(w, "WebSocket client limit reached", http.StatusServiceUnavailable)
 		return
 	}
@@ -829,7 +887,7 @@ func (s *Server) handleClusterTimeSeriesLiveWebSocket(w http.ResponseWriter, r *
 	// Create room name for this WebSocket connection
 	room := "timeseries:cluster:" + strings.Join(requestedKeys, ",")
 
-	s.logger.Info("Starting timeseries WebSocket connection",
+	s.requestLogger(r).Info("Starting timeseries WebSocket connection",
 		zap.Strings("series", requestedKeys),
 		zap.String("room", room))
 
@@ -841,6 +899,7 @@ func (s *Server) handleClusterTimeSeriesLiveWebSocket(w http.ResponseWriter, r *
 // timeseries updates to WebSocket clients
 func (s *Server) startTimeSeriesWebSocketBroadcaster() {
 	go func() {
+		defer apimiddleware.RecoverGoroutine(s.logger, "timeseries-ws-broadcaster")
 		// Track last broadcast time for each series to implement coalescing
 		lastBroadcast := make(map[string]time.Time)
 
@@ -1023,7 +1082,7 @@ func (s *Server) handleGetTimeSeriesNodes(w http.ResponseWriter, r *http.Request
 	// Get all nodes
 	nodeList, err := s.kubeClient.CoreV1().Nodes().List(r.Context(), metav1.ListOptions{})
 	if err != nil {
-		s.logger.Error("Failed to list nodes for timeseries entities", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list nodes for timeseries entities", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -1076,7 +1135,7 @@ func (s *Server) handleGetTimeSeriesNamespaces(w http.ResponseWriter, r *http.Re
 	// Get all namespaces
 	namespaceList, err := s.kubeClient.CoreV1().Namespaces().List(r.Context(), metav1.ListOptions{})
 	if err != nil {
-		s.logger.Error("Failed to list namespaces for timeseries entities", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list namespaces for timeseries entities", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -1144,7 +1203,7 @@ func (s *Server) handleGetTimeSeriesPods(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err != nil {
-		s.logger.Error("Failed to list pods for timeseries entities", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list pods for timeseries entities", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -1305,7 +1364,7 @@ func (s *Server) handleGetTimeSeriesPods(w http.ResponseWriter, r *http.Request)
 func (s *Server) handleGetTimeSeriesHealth(w http.ResponseWriter, r *http.Request) {
 	// Check if timeseries store is available
 	if s.timeSeriesStore == nil {
-		s.logger.Error("TimeSeries store not initialized")
+		s.requestLogger(r).Error("TimeSeries store not initialized")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusServiceUnavailable)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -1334,7 +1393,7 @@ func (s *Server) handleGetTimeSeriesHealth(w http.ResponseWriter, r *http.Reques
 		Status:       health.GetStatus(),
 	}
 
-	s.logger.Debug("TimeSeries health request",
+	s.requestLogger(r).Debug("TimeSeries health request",
 		zap.String("status", health.GetStatus()),
 		zap.Int64("series_count", health.SeriesCount),
 		zap.Int64("ws_clients", health.WSClientCount))
@@ -1382,6 +1441,23 @@ func (s *Server) handleGetTimeSeriesCapabilities(w http.ResponseWriter, r *http.
 	})
 }
 
+// handleGetRestartStorm handles GET /api/v1/timeseries/restart-storm
+func (s *Server) handleGetRestartStorm(w http.ResponseWriter, r *http.Request) {
+	if s.timeSeriesAggregator == nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": "TimeSeries service not available",
+		})
+		return
+	}
+
+	status := s.timeSeriesAggregator.RestartStormStatus()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
 // handleGetNodesTimeSeries handles GET /api/v1/timeseries/nodes
 func (s *Server) handleGetNodesTimeSeries(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
@@ -1390,27 +1466,17 @@ func (s *Server) handleGetNodesTimeSeries(w http.ResponseWriter, r *http.Request
 	sinceParam := r.URL.Query().Get("since")
 	nodeFilter := r.URL.Query().Get("node")
 
-	// Default values
-	if resParam == "" {
-		resParam = "lo"
-	}
-	if sinceParam == "" {
-		sinceParam = "60m"
-	}
+	// Default values: per-prefix defaults for the requested series, then the global default
+	resParam, sinceParam = s.timeSeriesQueryDefaults(resParam, sinceParam, seriesParam, timeseries.GetNodeMetricBases())
 
 	// Parse resolution
-	var resolution timeseries.Resolution
-	switch resParam {
-	case "hi":
-		resolution = timeseries.Hi
-	case "lo":
-		resolution = timeseries.Lo
-	default:
-		s.logger.Warn("Invalid resolution parameter", zap.String("res", resParam))
+	resolution, ok := timeseries.ParseResolution(resParam)
+	if !ok {
+		s.requestLogger(r).Warn("Invalid resolution parameter", zap.String("res", resParam))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
-			"error": "Invalid resolution parameter. Must be 'hi' or 'lo'",
+			"error": "Invalid resolution parameter. Must be 'hi', 'med' or 'lo'",
 		})
 		return
 	}
@@ -1418,7 +1484,7 @@ func (s *Server) handleGetNodesTimeSeries(w http.ResponseWriter, r *http.Request
 	// Parse duration
 	since, err := time.ParseDuration(sinceParam)
 	if err != nil {
-		s.logger.Warn("Invalid since parameter", zap.String("since", sinceParam), zap.Error(err))
+		s.requestLogger(r).Warn("Invalid since parameter", zap.String("since", sinceParam), zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -1440,7 +1506,7 @@ func (s *Server) handleGetNodesTimeSeries(w http.ResponseWriter, r *http.Request
 
 	// Check if timeseries store is available
 	if s.timeSeriesStore == nil {
-		s.logger.Error("TimeSeries store not initialized")
+		s.requestLogger(r).Error("TimeSeries store not initialized")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusServiceUnavailable)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -1461,45 +1527,35 @@ func (s *Server) handleGetNodesTimeSeries(w http.ResponseWriter, r *http.Request
 	// Collect data for each requested metric base
 	seriesData := make(map[string][]TimeSeriesPoint)
 
-	// Get all series keys and filter for node metrics
-	allKeys := s.timeSeriesStore.Keys()
-	for _, seriesKey := range allKeys {
-		for _, metricBase := range requestedMetricBases {
-			if strings.HasPrefix(seriesKey, metricBase+".") {
-				// Extract node name from series key
-				_, nodeName, ok := timeseries.ParseNodeSeriesKey(seriesKey)
-				if !ok {
-					continue
-				}
-
-				// Apply node filter if specified
-				if nodeFilter != "" && nodeName != nodeFilter {
-					continue
-				}
-
-				// Get the series from the store
-				series, exists := s.timeSeriesStore.Get(seriesKey)
-				if !exists {
-					continue
-				}
+	// Get all series keys and filter for node metrics. Keys are split at
+	// the metric base since node names may contain dots.
+	nodes := make(map[string]bool)
+	for _, seriesKey := range s.timeSeriesStore.Keys() {
+		_, nodeName, ok := timeseries.ParseEntitySeriesKey(seriesKey, requestedMetricBases)
+		if !ok {
+			continue
+		}
 
-				// Get points since the specified time
-				points := series.GetSince(timeThreshold, resolution)
-
-				// Convert to API format
-				var apiPoints []TimeSeriesPoint
-				for _, point := range points {
-					apiPoints = append(apiPoints, TimeSeriesPoint{
-						T:      point.T.UnixMilli(),
-						V:      point.V,
-						Entity: point.Entity,
-					})
-				}
+		// Apply node filter if specified
+		if nodeFilter != "" && nodeName != nodeFilter {
+			continue
+		}
 
-				seriesData[seriesKey] = apiPoints
-			}
+		// Get the series from the store
+		series, exists := s.timeSeriesStore.Get(seriesKey)
+		if !exists {
+			continue
 		}
+
+		// Get points since the specified time in API format
+		seriesData[seriesKey] = withEntityLabel(seriesAPIPoints(series, timeThreshold, resolution), "node", nodeName)
+		nodes[nodeName] = true
 	}
+	entities := make([]string, 0, len(nodes))
+	for nodeName := range nodes {
+		entities = append(entities, nodeName)
+	}
+	sort.Strings(entities)
 
 	// Build response
 	response := TimeSeriesResponse{
@@ -1511,6 +1567,7 @@ func (s *Server) handleGetNodesTimeSeries(w http.ResponseWriter, r *http.Request
 			Scope:      "nodes",
 			Entity:     nodeFilter,
 		},
+		Entities: entities,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -1531,6 +1588,13 @@ func (s *Server) handleGetNodeTimeSeries(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Unlike the node filter of handleGetNodesTimeSeries, a node with no
+	// series in the store is not found
+	if s.timeSeriesStore != nil && !s.seriesEntityExists(timeseries.GetNodeMetricBases(), nodeName) {
+		writeJSONError(w, http.StatusNotFound, fmt.Sprintf("No timeseries data for node %q", nodeName))
+		return
+	}
+
 	// Add node filter to query and delegate to handleGetNodesTimeSeries
 	q := r.URL.Query()
 	q.Set("node", nodeName)
@@ -1548,27 +1612,17 @@ func (s *Server) handleGetPodsTimeSeries(w http.ResponseWriter, r *http.Request)
 	namespaceFilter := r.URL.Query().Get("namespace")
 	podFilter := r.URL.Query().Get("pod")
 
-	// Default values
-	if resParam == "" {
-		resParam = "lo"
-	}
-	if sinceParam == "" {
-		sinceParam = "60m"
-	}
+	// Default values: per-prefix defaults for the requested series, then the global default
+	resParam, sinceParam = s.timeSeriesQueryDefaults(resParam, sinceParam, seriesParam, timeseries.GetPodMetricBases())
 
 	// Parse resolution
-	var resolution timeseries.Resolution
-	switch resParam {
-	case "hi":
-		resolution = timeseries.Hi
-	case "lo":
-		resolution = timeseries.Lo
-	default:
-		s.logger.Warn("Invalid resolution parameter", zap.String("res", resParam))
+	resolution, ok := timeseries.ParseResolution(resParam)
+	if !ok {
+		s.requestLogger(r).Warn("Invalid resolution parameter", zap.String("res", resParam))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
-			"error": "Invalid resolution parameter. Must be 'hi' or 'lo'",
+			"error": "Invalid resolution parameter. Must be 'hi', 'med' or 'lo'",
 		})
 		return
 	}
@@ -1576,7 +1630,7 @@ func (s *Server) handleGetPodsTimeSeries(w http.ResponseWriter, r *http.Request)
 	// Parse duration
 	since, err := time.ParseDuration(sinceParam)
 	if err != nil {
-		s.logger.Warn("Invalid since parameter", zap.String("since", sinceParam), zap.Error(err))
+		s.requestLogger(r).Warn("Invalid since parameter", zap.String("since", sinceParam), zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -1598,7 +1652,7 @@ func (s *Server) handleGetPodsTimeSeries(w http.ResponseWriter, r *http.Request)
 
 	// Check if timeseries store is available
 	if s.timeSeriesStore == nil {
-		s.logger.Error("TimeSeries store not initialized")
+		s.requestLogger(r).Error("TimeSeries store not initialized")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusServiceUnavailable)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -1644,18 +1698,8 @@ func (s *Server) handleGetPodsTimeSeries(w http.ResponseWriter, r *http.Request)
 					continue
 				}
 
-				// Get points since the specified time
-				points := series.GetSince(timeThreshold, resolution)
-
-				// Convert to API format
-				var apiPoints []TimeSeriesPoint
-				for _, point := range points {
-					apiPoints = append(apiPoints, TimeSeriesPoint{
-						T:      point.T.UnixMilli(),
-						V:      point.V,
-						Entity: point.Entity,
-					})
-				}
+				// Get points since the specified time in API format
+				apiPoints := seriesAPIPoints(series, timeThreshold, resolution)
 
 				seriesData[seriesKey] = apiPoints
 			}
@@ -1710,27 +1754,17 @@ func (s *Server) handleGetNamespacesTimeSeries(w http.ResponseWriter, r *http.Re
 	sinceParam := r.URL.Query().Get("since")
 	namespaceFilter := r.URL.Query().Get("namespace")
 
-	// Default values
-	if resParam == "" {
-		resParam = "lo"
-	}
-	if sinceParam == "" {
-		sinceParam = "60m"
-	}
+	// Default values: per-prefix defaults for the requested series, then the global default
+	resParam, sinceParam = s.timeSeriesQueryDefaults(resParam, sinceParam, seriesParam, timeseries.GetNamespaceMetricBases())
 
 	// Parse resolution
-	var resolution timeseries.Resolution
-	switch resParam {
-	case "hi":
-		resolution = timeseries.Hi
-	case "lo":
-		resolution = timeseries.Lo
-	default:
-		s.logger.Warn("Invalid resolution parameter", zap.String("res", resParam))
+	resolution, ok := timeseries.ParseResolution(resParam)
+	if !ok {
+		s.requestLogger(r).Warn("Invalid resolution parameter", zap.String("res", resParam))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
-			"error": "Invalid resolution parameter. Must be 'hi' or 'lo'",
+			"error": "Invalid resolution parameter. Must be 'hi', 'med' or 'lo'",
 		})
 		return
 	}
@@ -1738,7 +1772,7 @@ func (s *Server) handleGetNamespacesTimeSeries(w http.ResponseWriter, r *http.Re
 	// Parse duration
 	since, err := time.ParseDuration(sinceParam)
 	if err != nil {
-		s.logger.Warn("Invalid since parameter", zap.String("since", sinceParam), zap.Error(err))
+		s.requestLogger(r).Warn("Invalid since parameter", zap.String("since", sinceParam), zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -1760,7 +1794,7 @@ func (s *Server) handleGetNamespacesTimeSeries(w http.ResponseWriter, r *http.Re
 
 	// Check if timeseries store is available
 	if s.timeSeriesStore == nil {
-		s.logger.Error("TimeSeries store not initialized")
+		s.requestLogger(r).Error("TimeSeries store not initialized")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusServiceUnavailable)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -1781,45 +1815,35 @@ func (s *Server) handleGetNamespacesTimeSeries(w http.ResponseWriter, r *http.Re
 	// Collect data for each requested metric base
 	seriesData := make(map[string][]TimeSeriesPoint)
 
-	// Get all series keys and filter for namespace metrics
-	allKeys := s.timeSeriesStore.Keys()
-	for _, seriesKey := range allKeys {
-		for _, metricBase := range requestedMetricBases {
-			if strings.HasPrefix(seriesKey, metricBase+".") {
-				// Extract namespace name from series key
-				_, namespace, ok := timeseries.ParseNamespaceSeriesKey(seriesKey)
-				if !ok {
-					continue
-				}
-
-				// Apply namespace filter if specified
-				if namespaceFilter != "" && namespace != namespaceFilter {
-					continue
-				}
-
-				// Get the series from the store
-				series, exists := s.timeSeriesStore.Get(seriesKey)
-				if !exists {
-					continue
-				}
-
-				// Get points since the specified time
-				points := series.GetSince(timeThreshold, resolution)
-
-				// Convert to API format
-				var apiPoints []TimeSeriesPoint
-				for _, point := range points {
-					apiPoints = append(apiPoints, TimeSeriesPoint{
-						T:      point.T.UnixMilli(),
-						V:      point.V,
-						Entity: point.Entity,
-					})
-				}
+	// A single namespace's series are looked up by key; otherwise all
+	// series keys are filtered for namespace metrics
+	namespaces := make(map[string]bool)
+	addSeries := func(seriesKey, namespace string) {
+		series, exists := s.timeSeriesStore.Get(seriesKey)
+		if !exists {
+			return
+		}
 
-				seriesData[seriesKey] = apiPoints
+		// Get points since the specified time in API format
+		seriesData[seriesKey] = withEntityLabel(seriesAPIPoints(series, timeThreshold, resolution), "namespace", namespace)
+		namespaces[namespace] = true
+	}
+	if namespaceFilter != "" {
+		for _, metricBase := range requestedMetricBases {
+			addSeries(timeseries.GenerateNamespaceSeriesKey(metricBase, namespaceFilter), namespaceFilter)
+		}
+	} else {
+		for _, seriesKey := range s.timeSeriesStore.Keys() {
+			if _, namespace, ok := timeseries.ParseEntitySeriesKey(seriesKey, requestedMetricBases); ok {
+				addSeries(seriesKey, namespace)
 			}
 		}
 	}
+	entities := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		entities = append(entities, namespace)
+	}
+	sort.Strings(entities)
 
 	// Build response
 	response := TimeSeriesResponse{
@@ -1831,13 +1855,16 @@ func (s *Server) handleGetNamespacesTimeSeries(w http.ResponseWriter, r *http.Re
 			Scope:      "namespaces",
 			Entity:     namespaceFilter,
 		},
+		Entities: entities,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-// handleGetNamespaceTimeSeries handles GET /api/v1/timeseries/namespaces/{namespace}
+// handleGetNamespaceTimeSeries handles GET /api/v1/timeseries/namespaces/{namespace}.
+// A namespace without collected series, such as one created since the last
+// collection, has an empty series map rather than an error.
 func (s *Server) handleGetNamespaceTimeSeries(w http.ResponseWriter, r *http.Request) {
 	// Extract namespace from URL
 	namespace := chi.URLParam(r, "namespace")
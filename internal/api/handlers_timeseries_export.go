@@ -0,0 +1,177 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"go.uber.org/zap"
+)
+
+// openMetricsContentType is the content type of the OpenMetrics text format
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// exportMetricPrefix namespaces exported series so they cannot collide with
+// other exporters scraped into the same Prometheus
+const exportMetricPrefix = "kaptn_"
+
+// exportFamily is one metric family of the export: every series sharing a base key
+type exportFamily struct {
+	name    string
+	meta    timeseries.SeriesMetadata
+	samples []string
+}
+
+// handleTimeseriesExport serves the latest sample of every collected series in
+// the OpenMetrics text format, so Prometheus or Thanos can scrape Kaptn
+// directly. Keys such as node.cpu.usage.cores.node-a become metric families
+// such as kaptn_node_cpu_usage_cores{node="node-a"}. Like /metrics, the
+// endpoint sits outside /api/v1 but behind the global Authenticate middleware,
+// so scrapers must send credentials unless the auth mode is none.
+// @Summary Export timeseries in OpenMetrics format
+// @Description Latest sample of every collected series in OpenMetrics text exposition format
+// @Tags TimeSeries
+// @Produce plain
+// @Success 200 {string} string "OpenMetrics exposition"
+// @Failure 503 {object} map[string]interface{} "Time series store not available"
+// @Router /metrics/timeseries [get]
+func (s *Server) handleTimeseriesExport(w http.ResponseWriter, r *http.Request) {
+	if s.timeSeriesStore == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Time series store not available")
+		return
+	}
+
+	families := make(map[string]*exportFamily)
+	for _, key := range s.timeSeriesStore.Keys() {
+		base, labels, meta, ok := timeseries.DescribeSeries(key)
+		if !ok {
+			continue
+		}
+		series, exists := s.timeSeriesStore.Get(key)
+		if !exists {
+			continue
+		}
+		point, ok := series.Latest()
+		if !ok {
+			continue
+		}
+
+		name := exportMetricName(base)
+		sampleName := name
+		if meta.Type == timeseries.MetricTypeCounter {
+			// OpenMetrics counter samples carry the _total suffix, their family does not
+			name = strings.TrimSuffix(name, "_total")
+			sampleName = name + "_total"
+		}
+
+		family, exists := families[name]
+		if !exists {
+			family = &exportFamily{name: name, meta: meta}
+			families[name] = family
+		}
+		family.samples = append(family.samples, fmt.Sprintf("%s%s %s %s",
+			sampleName,
+			formatExportLabels(exportLabels(labels, point.Entity)),
+			formatExportValue(point.V),
+			formatExportTimestamp(point.T.UnixMilli())))
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w.Header().Set("Content-Type", openMetricsContentType)
+	out := bufio.NewWriter(w)
+	for _, name := range names {
+		family := families[name]
+		sort.Strings(family.samples)
+		fmt.Fprintf(out, "# TYPE %s %s\n", family.name, family.meta.Type)
+		fmt.Fprintf(out, "# HELP %s %s\n", family.name, escapeExportString(family.meta.Help))
+		for _, sample := range family.samples {
+			out.WriteString(sample)
+			out.WriteByte('\n')
+		}
+	}
+	out.WriteString("# EOF\n")
+	if err := out.Flush(); err != nil {
+		s.requestLogger(r).Debug("Failed to write timeseries export", zap.Error(err))
+	}
+}
+
+// exportMetricName turns a series base key into a metric name
+func exportMetricName(base string) string {
+	return exportMetricPrefix + sanitizeExportName(base)
+}
+
+// sanitizeExportName replaces characters not allowed in metric and label names
+func sanitizeExportName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// exportLabels merges a point's entity metadata with the labels encoded in its
+// series key. The key's labels win, as they identify the series.
+func exportLabels(keyLabels, entity map[string]string) map[string]string {
+	labels := make(map[string]string, len(keyLabels)+len(entity))
+	for name, value := range entity {
+		if name = sanitizeExportName(name); name != "" && (name[0] < '0' || name[0] > '9') {
+			labels[name] = value
+		}
+	}
+	for name, value := range keyLabels {
+		labels[name] = value
+	}
+	return labels
+}
+
+// formatExportLabels renders labels sorted by name, or nothing when empty
+func formatExportLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, name, escapeExportString(labels[name]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// escapeExportString escapes a label value or help text
+func escapeExportString(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// formatExportValue renders a sample value, spelling out NaN and infinities
+func formatExportValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// formatExportTimestamp renders a Unix millisecond timestamp in seconds
+func formatExportTimestamp(millis int64) string {
+	return strconv.FormatFloat(float64(millis)/1000, 'f', 3, 64)
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTimeseriesExportOpenMetrics(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	ts := time.UnixMilli(1700000000123)
+
+	store.Upsert(timeseries.ClusterCPUUsedCores).Add(timeseries.NewPoint(ts.Add(-time.Second), 1))
+	store.Upsert(timeseries.ClusterCPUUsedCores).Add(timeseries.NewPoint(ts, 2.5))
+	store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeCPUUsageBase, "node-b")).Add(timeseries.NewPointWithEntity(ts, 0.5, map[string]string{"node": "node-b"}))
+	store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeCPUUsageBase, "node-a")).Add(timeseries.NewPointWithEntity(ts, 1.5, map[string]string{"node": "node-a"}))
+	store.Upsert(timeseries.GeneratePodSeriesKey(timeseries.PodRestartsTotalBase, "shop", "web-0")).Add(timeseries.NewPointWithEntity(ts, 3, map[string]string{"namespace": "shop", "pod": "web-0", "node": "node-a"}))
+	store.Upsert(timeseries.GenerateCustomMetricSeriesKey("qps", "Pod", "shop", "web-0")).Add(timeseries.NewPoint(ts, 9))
+
+	s := &Server{logger: zap.NewNop(), timeSeriesStore: store}
+	rec := httptest.NewRecorder()
+	s.handleTimeseriesExport(rec, httptest.NewRequest(http.MethodGet, "/metrics/timeseries", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, openMetricsContentType, rec.Header().Get("Content-Type"))
+
+	assert.Equal(t, `# TYPE kaptn_cluster_cpu_used_cores gauge
+# HELP kaptn_cluster_cpu_used_cores CPU cores in use across all nodes
+kaptn_cluster_cpu_used_cores 2.5 1700000000.123
+# TYPE kaptn_node_cpu_usage_cores gauge
+# HELP kaptn_node_cpu_usage_cores CPU cores in use on the node
+kaptn_node_cpu_usage_cores{node="node-a"} 1.5 1700000000.123
+kaptn_node_cpu_usage_cores{node="node-b"} 0.5 1700000000.123
+# TYPE kaptn_pod_restarts counter
+# HELP kaptn_pod_restarts Container restarts of the pod
+kaptn_pod_restarts_total{namespace="shop",node="node-a",pod="web-0"} 3 1700000000.123
+# EOF
+`, rec.Body.String())
+}
+
+func TestTimeseriesExportWithoutStore(t *testing.T) {
+	s := &Server{logger: zap.NewNop()}
+	rec := httptest.NewRecorder()
+	s.handleTimeseriesExport(rec, httptest.NewRequest(http.MethodGet, "/metrics/timeseries", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestExportLabelEscaping(t *testing.T) {
+	labels := exportLabels(map[string]string{"pod": "web"}, map[string]string{"pod": "other", "team-name": `a"b\c` + "\n", "0bad": "x"})
+	assert.Equal(t, `{pod="web",team_name="a\"b\\c\n"}`, formatExportLabels(labels))
+}
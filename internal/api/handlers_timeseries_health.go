@@ -8,10 +8,11 @@ import (
 // handleTimeSeriesHealth returns the health status of the timeseries system
 func (s *Server) handleTimeSeriesHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
+	settings := s.currentConfig().Timeseries
 
 	// Build health response
 	health := map[string]interface{}{
-		"enabled":              s.config.Timeseries.Enabled,
+		"enabled":              settings.Enabled,
 		"store_available":      s.timeSeriesStore != nil,
 		"aggregator_available": s.timeSeriesAggregator != nil,
 	}
@@ -30,20 +31,22 @@ func (s *Server) handleTimeSeriesHealth(w http.ResponseWriter, r *http.Request)
 
 	// Get configuration details
 	health["config"] = map[string]interface{}{
-		"window":                         s.config.Timeseries.Window,
-		"tick_interval":                  s.config.Timeseries.TickInterval,
-		"capacity_refresh_interval":      s.config.Timeseries.CapacityRefreshInterval,
-		"hi_res_step":                    s.config.Timeseries.HiRes.Step,
-		"lo_res_step":                    s.config.Timeseries.LoRes.Step,
-		"max_series":                     s.config.Timeseries.MaxSeries,
-		"max_points_per_series":          s.config.Timeseries.MaxPointsPerSeries,
-		"max_ws_clients":                 s.config.Timeseries.MaxWSClients,
-		"disable_network_if_unavailable": s.config.Timeseries.DisableNetworkIfUnavailable,
+		"window":                         settings.Window,
+		"tick_interval":                  settings.TickInterval,
+		"capacity_refresh_interval":      settings.CapacityRefreshInterval,
+		"hi_res_step":                    settings.HiRes.Step,
+		"lo_res_step":                    settings.LoRes.Step,
+		"med_res_step":                   settings.MedRes.Step,
+		"med_res_points":                 settings.MedRes.Points,
+		"max_series":                     settings.MaxSeries,
+		"max_points_per_series":          settings.MaxPointsPerSeries,
+		"max_ws_clients":                 settings.MaxWSClients,
+		"disable_network_if_unavailable": settings.DisableNetworkIfUnavailable,
 	}
 
 	// Set HTTP status based on health
 	status := http.StatusOK
-	if !s.config.Timeseries.Enabled {
+	if !settings.Enabled {
 		status = http.StatusServiceUnavailable
 		health["status"] = "disabled"
 	} else if s.timeSeriesStore == nil || s.timeSeriesAggregator == nil {
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"go.uber.org/zap"
+)
+
+// sseKeepAliveInterval is how often a comment frame is sent to keep idle
+// connections open through proxies
+const sseKeepAliveInterval = 30 * time.Second
+
+// handleClusterTimeSeriesSSE handles GET /api/v1/timeseries/cluster/sse
+// @Summary Stream cluster timeseries over Server-Sent Events
+// @Description Streams the same init/append messages as the timeseries WebSocket endpoint as text/event-stream, for clients and proxies that handle SSE more reliably than WebSockets
+// @Tags TimeSeries
+// @Produce text/event-stream
+// @Param series query string false "Comma-separated list of series keys (defaults to all cluster series)"
+// @Param res query string false "Resolution for the initial buffer: hi, med or lo (default hi)"
+// @Param since query string false "Time window for the initial buffer (default 15m)"
+// @Success 200 {string} string "Event stream of init and append events"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters"
+// @Failure 503 {object} map[string]interface{} "TimeSeries service not available"
+// @Router /api/v1/timeseries/cluster/sse [get]
+func (s *Server) handleClusterTimeSeriesSSE(w http.ResponseWriter, r *http.Request) {
+	resParam := r.URL.Query().Get("res")
+	if resParam == "" {
+		resParam = "hi"
+	}
+	sinceParam := r.URL.Query().Get("since")
+	if sinceParam == "" {
+		sinceParam = "15m"
+	}
+
+	resolution, ok := timeseries.ParseResolution(resParam)
+	if !ok {
+		s.writeSSEError(w, r, http.StatusBadRequest, "Invalid resolution parameter. Must be 'hi', 'med' or 'lo'")
+		return
+	}
+
+	since, err := time.ParseDuration(sinceParam)
+	if err != nil {
+		s.writeSSEError(w, r, http.StatusBadRequest, "Invalid since parameter. Must be a valid duration (e.g., '15m', '1h')")
+		return
+	}
+
+	// Parse and validate series keys
+	var requestedKeys []string
+	if seriesParam := r.URL.Query().Get("series"); seriesParam != "" {
+		for _, key := range strings.Split(seriesParam, ",") {
+			requestedKeys = append(requestedKeys, strings.TrimSpace(key))
+		}
+	} else {
+		requestedKeys = timeseries.AllSeriesKeys()
+	}
+
+	validKeys := make(map[string]bool)
+	for _, key := range timeseries.AllSeriesKeys() {
+		validKeys[key] = true
+	}
+	for _, key := range requestedKeys {
+		if !validKeys[key] {
+			s.writeSSEError(w, r, http.StatusBadRequest, "Invalid series key: "+key)
+			return
+		}
+	}
+
+	if s.timeSeriesStore == nil || s.timeSeriesWSManager == nil {
+		s.writeSSEError(w, r, http.StatusServiceUnavailable, "TimeSeries service not available")
+		return
+	}
+
+	if !s.timeSeriesStore.GetHealth().CheckWSClientLimit() {
+		s.requestLogger(r).Warn("SSE connection rejected - client limit reached")
+		s.writeSSEError(w, r, http.StatusServiceUnavailable, "TimeSeries client limit reached")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeSSEError(w, r, http.StatusInternalServerError, "Streaming not supported")
+		return
+	}
+
+	bufferSize := s.config.Timeseries.WSWriteBufferSize
+	if bufferSize <= 0 {
+		bufferSize = 1024
+	}
+
+	// Register as a subscriber so the broadcaster's coalesced appends reach this stream
+	subscription := TimeSeriesSubscription{
+		GroupID:    "sse",
+		Resolution: resolution,
+		Since:      since,
+		Series:     requestedKeys,
+	}
+	client := &TimeSeriesWSClient{
+		ID:               fmt.Sprintf("ts-sse-%d", time.Now().UnixNano()),
+		Send:             make(chan []byte, bufferSize),
+		Subscriptions:    map[string]TimeSeriesSubscription{subscription.GroupID: subscription},
+		LastActivity:     time.Now(),
+		TotalSeriesCount: len(requestedKeys),
+	}
+
+	s.timeSeriesWSManager.addClient(client)
+	defer func() {
+		s.timeSeriesWSManager.removeClient(client.ID)
+		s.requestLogger(r).Info("TimeSeries SSE client disconnected", zap.String("clientId", client.ID))
+	}()
+
+	s.requestLogger(r).Info("New timeseries SSE client connected",
+		zap.String("clientId", client.ID),
+		zap.Strings("series", requestedKeys))
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// Queue the initial buffer ahead of any appends
+	s.sendTimeSeriesInitialData(client, subscription.GroupID, subscription)
+
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case message := <-client.Send:
+			if err := writeSSEEvent(w, message); err != nil {
+				s.requestLogger(r).Debug("Failed to write SSE event", zap.String("clientId", client.ID), zap.Error(err))
+				return
+			}
+			flusher.Flush()
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeSSEEvent writes a JSON message as an SSE frame, using its "type" field as the event name
+func writeSSEEvent(w http.ResponseWriter, message []byte) error {
+	var envelope struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		return err
+	}
+
+	if envelope.Type != "" {
+		if _, err := fmt.Fprintf(w, "event: %s\n", envelope.Type); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "data: %s\n\n", message)
+	return err
+}
+
+// writeSSEError writes a JSON error before the event stream has started
+func (s *Server) writeSSEError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	s.requestLogger(r).Warn("TimeSeries SSE request rejected", zap.Int("status", status), zap.String("error", message))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":  message,
+		"status": "error",
+	})
+}
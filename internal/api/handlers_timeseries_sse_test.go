@@ -0,0 +1,129 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type sseFrame struct {
+	Event string
+	Data  string
+}
+
+// readSSEFrame reads lines until a blank line terminates the next event,
+// skipping comment-only frames
+func readSSEFrame(t *testing.T, reader *bufio.Reader) sseFrame {
+	t.Helper()
+
+	var frame sseFrame
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+
+		switch {
+		case line == "":
+			if frame.Event != "" || frame.Data != "" {
+				return frame
+			}
+		case strings.HasPrefix(line, "event: "):
+			frame.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			frame.Data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func newSSETestServer() *Server {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	return &Server{
+		logger:              zap.NewNop(),
+		config:              &config.Config{Timeseries: config.TimeseriesConfig{WSWriteBufferSize: 16}},
+		timeSeriesStore:     store,
+		timeSeriesWSManager: newTimeSeriesWSManager(),
+	}
+}
+
+func TestClusterTimeSeriesSSEStreamsInitAndAppend(t *testing.T) {
+	s := newSSETestServer()
+	now := time.Now()
+	s.timeSeriesStore.Upsert(timeseries.ClusterCPUUsedCores).Add(timeseries.NewPoint(now.Add(-5*time.Second), 1.5))
+
+	ts := httptest.NewServer(http.HandlerFunc(s.handleClusterTimeSeriesSSE))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "?series=" + timeseries.ClusterCPUUsedCores)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+
+	initFrame := readSSEFrame(t, reader)
+	assert.Equal(t, "init", initFrame.Event)
+
+	var initMsg TimeSeriesInitMessage
+	require.NoError(t, json.Unmarshal([]byte(initFrame.Data), &initMsg))
+	require.Len(t, initMsg.Data.Series[timeseries.ClusterCPUUsedCores], 1)
+	assert.Equal(t, 1.5, initMsg.Data.Series[timeseries.ClusterCPUUsedCores][0].V)
+
+	// Appends for other series are filtered by the subscription
+	s.timeSeriesWSManager.broadcastToSubscribers(timeseries.ClusterMemUsedBytes, TimeSeriesPoint{T: now.UnixMilli(), V: 1024})
+	s.timeSeriesWSManager.broadcastToSubscribers(timeseries.ClusterCPUUsedCores, TimeSeriesPoint{T: now.UnixMilli(), V: 2.5})
+
+	appendFrame := readSSEFrame(t, reader)
+	assert.Equal(t, "append", appendFrame.Event)
+
+	var appendMsg TimeSeriesAppendMessage
+	require.NoError(t, json.Unmarshal([]byte(appendFrame.Data), &appendMsg))
+	assert.Equal(t, timeseries.ClusterCPUUsedCores, appendMsg.Key)
+	assert.Equal(t, 2.5, appendMsg.Point.V)
+}
+
+func TestClusterTimeSeriesSSEUnregistersOnDisconnect(t *testing.T) {
+	s := newSSETestServer()
+
+	ts := httptest.NewServer(http.HandlerFunc(s.handleClusterTimeSeriesSSE))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "?series=" + timeseries.ClusterCPUUsedCores)
+	require.NoError(t, err)
+
+	readSSEFrame(t, bufio.NewReader(resp.Body))
+
+	s.timeSeriesWSManager.mu.RLock()
+	assert.Len(t, s.timeSeriesWSManager.clients, 1)
+	s.timeSeriesWSManager.mu.RUnlock()
+
+	resp.Body.Close()
+
+	assert.Eventually(t, func() bool {
+		s.timeSeriesWSManager.mu.RLock()
+		defer s.timeSeriesWSManager.mu.RUnlock()
+		return len(s.timeSeriesWSManager.clients) == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestClusterTimeSeriesSSERejectsUnknownSeries(t *testing.T) {
+	s := newSSETestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/timeseries/cluster/sse?series=bogus.key", nil)
+	rec := httptest.NewRecorder()
+	s.handleClusterTimeSeriesSSE(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "bogus.key")
+}
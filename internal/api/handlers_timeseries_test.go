@@ -0,0 +1,148 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSeriesAPIPointsLoAggregates(t *testing.T) {
+	config := timeseries.DefaultConfig()
+	config.LoResAggregates = true
+	series := timeseries.NewSeries(config)
+
+	start := time.Now().Add(-time.Minute).Truncate(config.LoResStep)
+	for i, v := range []float64{1, 9, 2, 2, 1, 3} {
+		series.Add(timeseries.NewPoint(start.Add(time.Duration(i)*time.Second), v))
+	}
+
+	points := seriesAPIPoints(series, time.Time{}, timeseries.Lo)
+	require.Len(t, points, 1)
+	require.NotNil(t, points[0].Max)
+	assert.Equal(t, 3.0, points[0].V)
+	assert.Equal(t, 9.0, *points[0].Max)
+	assert.Equal(t, 1.0, *points[0].Min)
+	assert.Equal(t, 3.0, *points[0].Avg)
+
+	for _, point := range seriesAPIPoints(series, time.Time{}, timeseries.Hi) {
+		assert.Nil(t, point.Max, "hi-res points carry no aggregates")
+	}
+}
+
+func newNodeTimeSeriesRouter(t *testing.T) http.Handler {
+	t.Helper()
+
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	now := time.Now()
+	store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeCPUUsageBase, "ip-10-0-0-1.ec2.internal")).
+		Add(timeseries.NewPointWithEntity(now, 1.5, map[string]string{"node": "ip-10-0-0-1.ec2.internal"}))
+	store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeMemUsageBase, "ip-10-0-0-1.ec2.internal")).
+		Add(timeseries.NewPoint(now, 1024))
+	store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeCPUUsageBase, "worker-2")).
+		Add(timeseries.NewPoint(now, 0.5))
+	store.Upsert(timeseries.ClusterCPUUsedCores).Add(timeseries.NewPoint(now, 2))
+
+	s := &Server{logger: zap.NewNop(), timeSeriesStore: store}
+	router := chi.NewRouter()
+	router.Get("/api/v1/timeseries/nodes", s.handleGetNodesTimeSeries)
+	router.Get("/api/v1/timeseries/nodes/{nodeName}", s.handleGetNodeTimeSeries)
+	return router
+}
+
+func getTimeSeries(t *testing.T, router http.Handler, target string) (int, TimeSeriesResponse) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	var response TimeSeriesResponse
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	}
+	return rec.Code, response
+}
+
+func TestHandleGetNodesTimeSeriesListsEntities(t *testing.T) {
+	router := newNodeTimeSeriesRouter(t)
+
+	code, response := getTimeSeries(t, router, "/api/v1/timeseries/nodes?series=node.cpu.usage.cores&res=hi&since=5m")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"ip-10-0-0-1.ec2.internal", "worker-2"}, response.Entities)
+	assert.Len(t, response.Series, 2)
+	assert.NotContains(t, response.Series, timeseries.ClusterCPUUsedCores)
+}
+
+func TestHandleGetNodeTimeSeries(t *testing.T) {
+	router := newNodeTimeSeriesRouter(t)
+
+	code, response := getTimeSeries(t, router, "/api/v1/timeseries/nodes/ip-10-0-0-1.ec2.internal?res=hi&since=5m")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"ip-10-0-0-1.ec2.internal"}, response.Entities)
+	require.Len(t, response.Series, 2)
+	for key, points := range response.Series {
+		require.Len(t, points, 1, key)
+		assert.Equal(t, "ip-10-0-0-1.ec2.internal", points[0].Entity["node"], key)
+	}
+
+	// Known node without data for the requested series
+	code, response = getTimeSeries(t, router, "/api/v1/timeseries/nodes/worker-2?series=node.mem.usage.bytes&res=hi&since=5m")
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.Series)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/timeseries/nodes/worker-9?res=hi&since=5m", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"error":"No timeseries data for node \"worker-9\"","status":"error"}`, rec.Body.String())
+
+	// The node query filter of the listing does not 404
+	code, _ = getTimeSeries(t, router, "/api/v1/timeseries/nodes?node=worker-9&res=hi&since=5m")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestHandleGetNamespaceTimeSeries(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	now := time.Now()
+	store.Upsert(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespaceCPUUsedBase, "shop")).
+		Add(timeseries.NewPointWithEntity(now, 0.75, map[string]string{"namespace": "shop"}))
+	store.Upsert(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePodsRestarts1hBase, "shop")).
+		Add(timeseries.NewPoint(now, 4))
+	store.Upsert(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePodsRunningBase, "prod")).
+		Add(timeseries.NewPoint(now, 12))
+
+	s := &Server{logger: zap.NewNop(), timeSeriesStore: store}
+	router := chi.NewRouter()
+	router.Get("/api/v1/timeseries/namespaces", s.handleGetNamespacesTimeSeries)
+	router.Get("/api/v1/timeseries/namespaces/{namespace}", s.handleGetNamespaceTimeSeries)
+
+	code, response := getTimeSeries(t, router, "/api/v1/timeseries/namespaces?res=hi&since=5m")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"prod", "shop"}, response.Entities)
+	assert.Len(t, response.Series, 3)
+
+	code, response = getTimeSeries(t, router, "/api/v1/timeseries/namespaces/shop?res=hi&since=5m")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "namespaces", response.Metadata.Scope)
+	assert.Equal(t, "shop", response.Metadata.Entity)
+	require.Len(t, response.Series, 2)
+	restarts := response.Series[timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePodsRestarts1hBase, "shop")]
+	require.Len(t, restarts, 1)
+	assert.Equal(t, 4.0, restarts[0].V)
+	assert.Equal(t, "shop", restarts[0].Entity["namespace"])
+
+	code, response = getTimeSeries(t, router, "/api/v1/timeseries/namespaces/shop?series=ns.cpu.used.cores&res=hi&since=5m")
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, response.Series, 1)
+
+	// No collected data is an empty payload, not an error
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/timeseries/namespaces/new-team?res=hi&since=5m", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"series":{}`)
+}
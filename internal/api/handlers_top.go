@@ -0,0 +1,261 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/units"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsapi "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+const (
+	// defaultTopLimit is the number of consumers returned without limit=
+	defaultTopLimit = 10
+	// maxTopLimit bounds limit=
+	maxTopLimit = 500
+)
+
+// topPod is one pod's current usage as reported by metrics-server
+type topPod struct {
+	Name        string  `json:"name"`
+	Namespace   string  `json:"namespace"`
+	Node        string  `json:"node"`
+	CPUCores    float64 `json:"cpuCores"`
+	MemoryBytes int64   `json:"memoryBytes"`
+	Containers  int     `json:"containers"`
+}
+
+// topNode is one node's current usage and the share of its allocatable
+// resources it represents
+type topNode struct {
+	Name                   string  `json:"name"`
+	CPUCores               float64 `json:"cpuCores"`
+	MemoryBytes            float64 `json:"memoryBytes"`
+	CPUAllocatableCores    float64 `json:"cpuAllocatableCores"`
+	MemoryAllocatableBytes float64 `json:"memoryAllocatableBytes"`
+	CPUPercent             float64 `json:"cpuPercent"`
+	MemoryPercent          float64 `json:"memoryPercent"`
+}
+
+// topParams reads the sort or sortBy (cpu or memory, default cpu) and limit
+// parameters of the top endpoints
+func topParams(r *http.Request) (sortBy string, limit int, err error) {
+	sortBy = getSortParam(r)
+	switch sortBy {
+	case "":
+		sortBy = "cpu"
+	case "cpu", "memory":
+	default:
+		return "", 0, fmt.Errorf("sortBy must be cpu or memory")
+	}
+
+	limit = defaultTopLimit
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 || limit > maxTopLimit {
+			return "", 0, fmt.Errorf("limit must be between 1 and %d", maxTopLimit)
+		}
+	}
+	return sortBy, limit, nil
+}
+
+// requireMetricsAPI writes a 501 and returns false when metrics-server is not
+// installed, so an empty top list is never mistaken for an idle cluster
+func (s *Server) requireMetricsAPI(w http.ResponseWriter, r *http.Request) bool {
+	if s.apiMetricsAdapter == nil || !s.apiMetricsAdapter.HasMetricsAPI(r.Context()) {
+		writeJSONError(w, http.StatusNotImplemented, "metrics API unavailable: metrics-server (metrics.k8s.io) is not installed")
+		return false
+	}
+	return true
+}
+
+// handleTopPods handles GET /api/v1/top/pods
+// @Summary Top pods by resource usage
+// @Description Lists the pods using the most CPU or memory right now, like kubectl top pods, with their namespace and node. Usage is read from metrics-server.
+// @Tags Metrics
+// @Produce json
+// @Param namespace query string false "Only pods in this namespace"
+// @Param sort query string false "cpu or memory (default: cpu); sortBy is accepted as an alias"
+// @Param limit query int false "Number of pods to return (default: 10, max: 500)"
+// @Success 200 {object} map[string]interface{} "Top pods"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 501 {object} map[string]interface{} "Metrics API unavailable"
+// @Router /api/v1/top/pods [get]
+func (s *Server) handleTopPods(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	sortBy, limit, err := topParams(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !s.requireMetricsAPI(w, r) {
+		return
+	}
+
+	podMetrics, err := s.apiMetricsAdapter.ListPodMetrics(r.Context())
+	if err != nil {
+		s.requestLogger(r).Error("Failed to list pod metrics", zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Metrics carry no placement, so nodes come from the pods themselves
+	pods, err := s.kubeClient.CoreV1().Pods(namespace).List(r.Context(), metav1.ListOptions{})
+	if err != nil {
+		s.requestLogger(r).Error("Failed to list pods", zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	nodes := make(map[string]string, len(pods.Items))
+	for _, pod := range pods.Items {
+		nodes[pod.Namespace+"/"+pod.Name] = pod.Spec.NodeName
+	}
+
+	items := []topPod{}
+	for _, item := range podMetrics {
+		metric, ok := item.(metricsapi.PodMetrics)
+		if !ok || (namespace != "" && metric.Namespace != namespace) {
+			continue
+		}
+		pod := topPod{
+			Name:       metric.Name,
+			Namespace:  metric.Namespace,
+			Node:       nodes[metric.Namespace+"/"+metric.Name],
+			Containers: len(metric.Containers),
+		}
+		for _, container := range metric.Containers {
+			pod.CPUCores += float64(container.Usage.Cpu().MilliValue()) / 1000
+			pod.MemoryBytes += units.ParseQuantityBytes(*container.Usage.Memory())
+		}
+		items = append(items, pod)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if sortBy == "memory" && a.MemoryBytes != b.MemoryBytes {
+			return a.MemoryBytes > b.MemoryBytes
+		}
+		if sortBy == "cpu" && a.CPUCores != b.CPUCores {
+			return a.CPUCores > b.CPUCores
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	total := len(items)
+	if len(items) > limit {
+		items = items[:limit]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{
+			"items":  items,
+			"total":  total,
+			"sortBy": sortBy,
+			"limit":  limit,
+		},
+		"status": "success",
+	})
+}
+
+// handleTopNodes handles GET /api/v1/top/nodes
+// @Summary Top nodes by resource usage
+// @Description Lists the nodes using the most CPU or memory right now, like kubectl top nodes, with the percentage of allocatable CPU and memory consumed. Usage is read from metrics-server.
+// @Tags Metrics
+// @Produce json
+// @Param sort query string false "cpu or memory (default: cpu); sortBy is accepted as an alias"
+// @Param limit query int false "Number of nodes to return (default: 10, max: 500)"
+// @Success 200 {object} map[string]interface{} "Top nodes"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 501 {object} map[string]interface{} "Metrics API unavailable"
+// @Router /api/v1/top/nodes [get]
+func (s *Server) handleTopNodes(w http.ResponseWriter, r *http.Request) {
+	sortBy, limit, err := topParams(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !s.requireMetricsAPI(w, r) {
+		return
+	}
+
+	cpuUsage, err := s.apiMetricsAdapter.ListNodeCPUUsage(r.Context())
+	if err != nil {
+		s.requestLogger(r).Error("Failed to list node CPU usage", zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	memoryUsage, err := s.apiMetricsAdapter.ListNodeMemoryUsage(r.Context())
+	if err != nil {
+		s.requestLogger(r).Error("Failed to list node memory usage", zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	nodeList, err := s.kubeClient.CoreV1().Nodes().List(r.Context(), metav1.ListOptions{})
+	if err != nil {
+		s.requestLogger(r).Error("Failed to list nodes", zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	items := []topNode{}
+	for _, node := range nodeList.Items {
+		cpu, hasCPU := cpuUsage[node.Name]
+		memory, hasMemory := memoryUsage[node.Name]
+		if !hasCPU && !hasMemory {
+			// Not reported by metrics-server yet, e.g. a node that just joined
+			continue
+		}
+		item := topNode{
+			Name:                   node.Name,
+			CPUCores:               cpu,
+			MemoryBytes:            memory,
+			CPUAllocatableCores:    float64(node.Status.Allocatable.Cpu().MilliValue()) / 1000,
+			MemoryAllocatableBytes: float64(units.ParseQuantityBytes(*node.Status.Allocatable.Memory())),
+		}
+		if item.CPUAllocatableCores > 0 {
+			item.CPUPercent = cpu / item.CPUAllocatableCores * 100
+		}
+		if item.MemoryAllocatableBytes > 0 {
+			item.MemoryPercent = memory / item.MemoryAllocatableBytes * 100
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if sortBy == "memory" && a.MemoryBytes != b.MemoryBytes {
+			return a.MemoryBytes > b.MemoryBytes
+		}
+		if sortBy == "cpu" && a.CPUCores != b.CPUCores {
+			return a.CPUCores > b.CPUCores
+		}
+		return a.Name < b.Name
+	})
+	total := len(items)
+	if len(items) > limit {
+		items = items[:limit]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{
+			"items":  items,
+			"total":  total,
+			"sortBy": sortBy,
+			"limit":  limit,
+		},
+		"status": "success",
+	})
+}
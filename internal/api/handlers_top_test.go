@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsapi "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func topUsage(cpu, memory string) v1.ResourceList {
+	return v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse(cpu),
+		v1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+// newTopServer returns a server with three pods on two nodes. The metrics API
+// is only advertised when metricsAPI is set.
+func newTopServer(metricsAPI bool) *Server {
+	client := fake.NewSimpleClientset(
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}, Status: v1.NodeStatus{Allocatable: topUsage("4", "8Gi")}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}, Status: v1.NodeStatus{Allocatable: topUsage("2", "4Gi")}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}, Spec: v1.PodSpec{NodeName: "node-a"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"}, Spec: v1.PodSpec{NodeName: "node-b"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "kube-system"}, Spec: v1.PodSpec{NodeName: "node-a"}},
+	)
+	if metricsAPI {
+		client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+			{GroupVersion: "metrics.k8s.io/v1beta1"},
+		}
+	}
+
+	// The fake tracker cannot list metrics kinds, so lists are answered here
+	metricsClient := metricsfake.NewSimpleClientset()
+	metricsClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsapi.PodMetricsList{Items: []metricsapi.PodMetrics{
+			{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}, Containers: []metricsapi.ContainerMetrics{
+				{Name: "app", Usage: topUsage("300m", "200Mi")},
+				{Name: "proxy", Usage: topUsage("100m", "50Mi")},
+			}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"}, Containers: []metricsapi.ContainerMetrics{
+				{Name: "postgres", Usage: topUsage("200m", "1Gi")},
+			}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "kube-system"}, Containers: []metricsapi.ContainerMetrics{
+				{Name: "coredns", Usage: topUsage("10m", "20Mi")},
+			}},
+		}}, nil
+	})
+	metricsClient.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsapi.NodeMetricsList{Items: []metricsapi.NodeMetrics{
+			{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}, Usage: topUsage("1", "2Gi")},
+			{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}, Usage: topUsage("1500m", "1Gi")},
+		}}, nil
+	})
+
+	s := &Server{
+		logger:     zap.NewNop(),
+		config:     &config.Config{},
+		kubeClient: client,
+	}
+	if metricsAPI {
+		s.apiMetricsAdapter = kubemetrics.NewAPIMetricsAdapter(zap.NewNop(), client, metricsClient.MetricsV1beta1())
+	} else {
+		s.apiMetricsAdapter = kubemetrics.NewAPIMetricsAdapter(zap.NewNop(), client, nil)
+	}
+	return s
+}
+
+func getTop[T any](t *testing.T, handler http.HandlerFunc, target string) (int, []T) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	var response struct {
+		Data struct {
+			Items []T `json:"items"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response), rec.Body.String())
+	return rec.Code, response.Data.Items
+}
+
+func TestHandleTopPods(t *testing.T) {
+	s := newTopServer(true)
+
+	code, pods := getTop[topPod](t, s.handleTopPods, "/api/v1/top/pods")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, pods, 3)
+	assert.Equal(t, topPod{Name: "web", Namespace: "shop", Node: "node-a", CPUCores: 0.4, MemoryBytes: 250 << 20, Containers: 2}, pods[0])
+	assert.Equal(t, "db", pods[1].Name)
+	assert.Equal(t, "node-b", pods[1].Node)
+
+	_, pods = getTop[topPod](t, s.handleTopPods, "/api/v1/top/pods?sortBy=memory&limit=1")
+	require.Len(t, pods, 1)
+	assert.Equal(t, "db", pods[0].Name)
+
+	_, pods = getTop[topPod](t, s.handleTopPods, "/api/v1/top/pods?namespace=kube-system")
+	require.Len(t, pods, 1)
+	assert.Equal(t, "dns", pods[0].Name)
+}
+
+func TestHandleTopNodes(t *testing.T) {
+	s := newTopServer(true)
+
+	code, nodes := getTop[topNode](t, s.handleTopNodes, "/api/v1/top/nodes")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, nodes, 2)
+	assert.Equal(t, "node-b", nodes[0].Name)
+	assert.InDelta(t, 75, nodes[0].CPUPercent, 0.001)
+	assert.InDelta(t, 25, nodes[0].MemoryPercent, 0.001)
+	assert.InDelta(t, 25, nodes[1].CPUPercent, 0.001)
+
+	_, nodes = getTop[topNode](t, s.handleTopNodes, "/api/v1/top/nodes?sortBy=memory")
+	assert.Equal(t, "node-a", nodes[0].Name)
+
+	// sort is read like on the list endpoints, ahead of the sortBy alias
+	_, nodes = getTop[topNode](t, s.handleTopNodes, "/api/v1/top/nodes?sort=memory&sortBy=cpu")
+	assert.Equal(t, "node-a", nodes[0].Name)
+}
+
+func TestHandleTopErrors(t *testing.T) {
+	s := newTopServer(false)
+	for _, handler := range []http.HandlerFunc{s.handleTopPods, s.handleTopNodes} {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/top", nil))
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+		assert.Contains(t, rec.Body.String(), "metrics API unavailable")
+	}
+
+	s = newTopServer(true)
+	for _, target := range []string{"/api/v1/top/pods?sortBy=disk", "/api/v1/top/pods?limit=0", "/api/v1/top/pods?limit=many"} {
+		rec := httptest.NewRecorder()
+		s.handleTopPods(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
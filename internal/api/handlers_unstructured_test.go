@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newUnstructuredTestServer(objects ...runtime.Object) *Server {
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+	kubeClient := fake.NewSimpleClientset()
+	return &Server{
+		logger:          zap.NewNop(),
+		kubeClient:      kubeClient,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), kubeClient, dynamicClient),
+	}
+}
+
+func serveWithParams(handler http.HandlerFunc, params map[string]string) *httptest.ResponseRecorder {
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	return rec
+}
+
+func assertJSONError(t *testing.T, rec *httptest.ResponseRecorder, contains string) {
+	t.Helper()
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "error", body["status"])
+	assert.Contains(t, body["error"], contains)
+}
+
+func TestHandleGetCustomResourceDefinitionMalformedSpec(t *testing.T) {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "widgets.example.com"},
+		"spec":       "not-an-object",
+	}}
+	s := newUnstructuredTestServer(crd)
+
+	rec := serveWithParams(s.handleGetCustomResourceDefinition, map[string]string{"name": "widgets.example.com"})
+
+	assertJSONError(t, rec, "malformed CustomResourceDefinition: spec")
+}
+
+func TestHandleGetVolumeSnapshotMalformedStatus(t *testing.T) {
+	snapshot := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       "VolumeSnapshot",
+		"metadata":   map[string]interface{}{"name": "nightly", "namespace": "data"},
+		"spec":       map[string]interface{}{},
+		"status":     []interface{}{"unexpected"},
+	}}
+	s := newUnstructuredTestServer(snapshot)
+
+	rec := serveWithParams(s.handleGetVolumeSnapshot, map[string]string{"namespace": "data", "name": "nightly"})
+
+	assertJSONError(t, rec, "malformed VolumeSnapshot: status")
+}
+
+func TestUnstructuredObject(t *testing.T) {
+	_, err := unstructuredObject("not-a-map", "EndpointSlice")
+	assert.EqualError(t, err, "malformed EndpointSlice: expected an object, got string")
+
+	obj, err := unstructuredObject(map[string]interface{}{"metadata": map[string]interface{}{}, "spec": nil}, "ConfigMap", "metadata", "spec", "data")
+	require.NoError(t, err)
+	assert.Contains(t, obj, "metadata")
+
+	_, err = unstructuredObject(map[string]interface{}{"data": "x"}, "ConfigMap", "data")
+	assert.Error(t, err)
+}
+
+func TestCRDToResponseToleratesMissingFields(t *testing.T) {
+	s := &Server{logger: zap.NewNop()}
+
+	response := s.crdToResponse(map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "widgets.example.com"},
+	})
+
+	assert.Equal(t, "widgets.example.com", response["name"])
+	assert.Equal(t, "", response["group"])
+}
+
+func TestRoleToResponseWithoutRules(t *testing.T) {
+	s := &Server{logger: zap.NewNop()}
+	role := rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "default", CreationTimestamp: metav1.Now()}}
+
+	response := s.roleToResponse(role)
+
+	assert.Equal(t, "empty", response["name"])
+	assert.Equal(t, 0, response["rules"])
+}
@@ -0,0 +1,368 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/ws"
+	apimiddleware "github.com/aaronlmathis/kaptn/internal/middleware"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// watchSendBuffer is how many frames may queue for a watch client before
+	// it is considered too slow and disconnected
+	watchSendBuffer = 64
+
+	// watchWriteWait is the time allowed to write a frame to a watch client
+	watchWriteWait = 10 * time.Second
+
+	// watchPingPeriod is how often watch clients are pinged
+	watchPingPeriod = 30 * time.Second
+)
+
+// watchSummarizers convert informer objects to the summaries returned by the
+// list endpoints, keyed by the lower-case plural resource name
+func (s *Server) watchSummarizers() map[string]func(obj interface{}) interface{} {
+	return map[string]func(obj interface{}) interface{}{
+		"pods": func(obj interface{}) interface{} {
+			if pod, ok := obj.(*v1.Pod); ok {
+				return s.enhancedPodToSummary(pod, nil)
+			}
+			return nil
+		},
+		"nodes": func(obj interface{}) interface{} {
+			if node, ok := obj.(*v1.Node); ok {
+				return s.nodeToEnrichedResponse(node)
+			}
+			return nil
+		},
+		"namespaces": func(obj interface{}) interface{} {
+			if namespace, ok := obj.(*v1.Namespace); ok {
+				return formatNamespaceSummary(namespace)
+			}
+			return nil
+		},
+		"deployments": func(obj interface{}) interface{} {
+			if deployment, ok := obj.(*appsv1.Deployment); ok {
+				return s.deploymentToResponse(*deployment)
+			}
+			return nil
+		},
+		"statefulsets": func(obj interface{}) interface{} {
+			if statefulSet, ok := obj.(*appsv1.StatefulSet); ok {
+				return s.statefulSetToResponse(*statefulSet)
+			}
+			return nil
+		},
+		"daemonsets": func(obj interface{}) interface{} {
+			if daemonSet, ok := obj.(*appsv1.DaemonSet); ok {
+				return s.daemonSetToResponse(*daemonSet)
+			}
+			return nil
+		},
+		"replicasets": func(obj interface{}) interface{} {
+			if replicaSet, ok := obj.(*appsv1.ReplicaSet); ok {
+				return s.replicaSetToResponse(*replicaSet)
+			}
+			return nil
+		},
+		"jobs": func(obj interface{}) interface{} {
+			if job, ok := obj.(*batchv1.Job); ok {
+				return s.jobToResponse(*job)
+			}
+			return nil
+		},
+		"cronjobs": func(obj interface{}) interface{} {
+			if cronJob, ok := obj.(*batchv1.CronJob); ok {
+				return s.cronJobToResponse(*cronJob)
+			}
+			return nil
+		},
+		"services": func(obj interface{}) interface{} {
+			if service, ok := obj.(*v1.Service); ok {
+				return s.serviceToResponse(*service)
+			}
+			return nil
+		},
+		"configmaps": func(obj interface{}) interface{} {
+			if configMap, ok := obj.(*v1.ConfigMap); ok {
+				return s.configMapToResponse(*configMap, s.configMapRedactor())
+			}
+			return nil
+		},
+		"secrets": func(obj interface{}) interface{} {
+			if secret, ok := obj.(*v1.Secret); ok {
+				return s.secretToSummary(secret, false)
+			}
+			return nil
+		},
+		"ingresses": func(obj interface{}) interface{} {
+			ingress, ok := obj.(*networkingv1.Ingress)
+			if !ok {
+				return nil
+			}
+			// The list endpoint formats ingresses from their unstructured form
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ingress)
+			if err != nil {
+				return nil
+			}
+			return s.ingressToResponse(content)
+		},
+		"persistentvolumeclaims": func(obj interface{}) interface{} {
+			if pvc, ok := obj.(*v1.PersistentVolumeClaim); ok {
+				return s.persistentVolumeClaimToResponse(pvc)
+			}
+			return nil
+		},
+	}
+}
+
+// watchResources returns the sorted resources that can be watched
+func (s *Server) watchResources() []string {
+	resources := make([]string, 0)
+	for resource := range s.watchSummarizers() {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+// watchCoalesceWindow returns the configured coalescing window of watch
+// streams, or zero for the default. The setting is validated on load.
+func (s *Server) watchCoalesceWindow() time.Duration {
+	if s.config == nil {
+		return 0
+	}
+	window, err := time.ParseDuration(s.config.Features.WatchCoalesceWindow)
+	if err != nil {
+		return 0
+	}
+	return window
+}
+
+// resourceWatch filters and summarizes the informer events of one watch
+// stream and feeds them to its coalescer
+type resourceWatch struct {
+	namespace string
+	selector  labels.Selector
+	summarize func(obj interface{}) interface{}
+	coalescer *ws.Coalescer
+}
+
+// matches reports whether an object is in the watched namespace and matches
+// the label selector
+func (rw *resourceWatch) matches(obj interface{}) bool {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	if rw.namespace != "" && accessor.GetNamespace() != rw.namespace {
+		return false
+	}
+	return rw.selector.Matches(labels.Set(accessor.GetLabels()))
+}
+
+// snapshot summarizes the matching objects of the informer store for the
+// initial sync frame
+func (rw *resourceWatch) snapshot(objects []interface{}) ws.SyncFrame {
+	items := make([]interface{}, 0, len(objects))
+	for _, obj := range objects {
+		if !rw.matches(obj) {
+			continue
+		}
+		if summary := rw.summarize(obj); summary != nil {
+			items = append(items, summary)
+		}
+	}
+	return ws.SyncFrame{Type: ws.SyncFrameType, Items: items}
+}
+
+// add queues an event for obj
+func (rw *resourceWatch) add(eventType string, obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	summary := rw.summarize(obj)
+	if summary == nil {
+		return
+	}
+	rw.coalescer.Add(ws.WatchEvent{Type: eventType, Key: key, Object: summary})
+}
+
+// handler returns the informer event handler of the watch. Objects listed
+// when the handler is registered are already part of the sync frame and are
+// skipped. An update that moves an object into or out of the filter is
+// reported as an addition or deletion.
+func (rw *resourceWatch) handler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if !isInInitialList && rw.matches(obj) {
+				rw.add(ws.WatchEventAdded, obj)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			wasMatching, isMatching := rw.matches(oldObj), rw.matches(newObj)
+			switch {
+			case wasMatching && isMatching:
+				rw.add(ws.WatchEventModified, newObj)
+			case isMatching:
+				rw.add(ws.WatchEventAdded, newObj)
+			case wasMatching:
+				rw.add(ws.WatchEventDeleted, newObj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if rw.matches(obj) {
+				rw.add(ws.WatchEventDeleted, obj)
+			}
+		},
+	}
+}
+
+// handleWatchResource returns the handler streaming changes to resource
+// @Summary Watch resource changes
+// @Description Streams changes to a resource over a WebSocket from the informer cache. A sync frame with the current objects is sent first, followed by batch frames of ADDED, MODIFIED and DELETED events carrying the same summaries as the list endpoint. Updates to the same object within the coalescing window (features.watch_coalesce_window, 250ms by default) are coalesced into one event.
+// @Tags WebSocket
+// @Param namespace query string false "Only objects in this namespace"
+// @Param labelSelector query string false "Only objects matching this label selector"
+// @Success 101 "Switching Protocols"
+// @Failure 400 {string} string "Invalid label selector"
+// @Failure 503 {string} string "Informer cache not ready"
+// @Router /api/v1/{resource}/watch [get]
+func (s *Server) handleWatchResource(resource string) http.HandlerFunc {
+	summarize := s.watchSummarizers()[resource]
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		selector, err := labels.Parse(r.URL.Query().Get("labelSelector"))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid label selector: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		if s.informerManager == nil || summarize == nil {
+			http.Error(w, "Informer cache not available", http.StatusServiceUnavailable)
+			return
+		}
+		informer, ok := s.informerManager.ResourceInformer(resource)
+		if !ok {
+			http.Error(w, "Informer cache not ready", http.StatusServiceUnavailable)
+			return
+		}
+
+		upgrader := websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				return true // Allow all origins for now
+			},
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			s.requestLogger(r).Error("Failed to upgrade watch connection", zap.String("resource", resource), zap.Error(err))
+			return
+		}
+
+		send := make(chan []byte, watchSendBuffer)
+		done := make(chan struct{})
+		var closeOnce sync.Once
+		disconnect := func() {
+			closeOnce.Do(func() { close(done) })
+		}
+
+		enqueue := func(frame interface{}) {
+			data, err := json.Marshal(frame)
+			if err != nil {
+				s.requestLogger(r).Error("Failed to marshal watch frame", zap.String("resource", resource), zap.Error(err))
+				return
+			}
+			select {
+			case send <- data:
+			case <-done:
+			default:
+				s.requestLogger(r).Warn("Disconnecting slow watch client", zap.String("resource", resource))
+				disconnect()
+			}
+		}
+
+		rw := &resourceWatch{
+			namespace: r.URL.Query().Get("namespace"),
+			selector:  selector,
+			summarize: summarize,
+		}
+		rw.coalescer = ws.NewCoalescer(s.watchCoalesceWindow(), func(frame ws.BatchFrame) {
+			enqueue(frame)
+		})
+
+		// Register before listing so no change between the snapshot and the
+		// first event is lost; a change seen twice is harmless to clients
+		registration, err := informer.AddEventHandler(rw.handler())
+		if err != nil {
+			s.requestLogger(r).Error("Failed to register watch handler", zap.String("resource", resource), zap.Error(err))
+			conn.Close()
+			return
+		}
+		enqueue(rw.snapshot(informer.GetStore().List()))
+
+		s.requestLogger(r).Debug("Watch client connected",
+			zap.String("resource", resource),
+			zap.String("namespace", rw.namespace),
+			zap.String("labelSelector", selector.String()))
+
+		go func() {
+			defer apimiddleware.RecoverGoroutine(s.logger, "resource-watch-reader")
+			defer disconnect()
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		go func() {
+			defer apimiddleware.RecoverGoroutine(s.logger, "resource-watch-writer")
+			ticker := time.NewTicker(watchPingPeriod)
+			defer func() {
+				ticker.Stop()
+				rw.coalescer.Stop()
+				if err := informer.RemoveEventHandler(registration); err != nil {
+					s.requestLogger(r).Warn("Failed to remove watch handler", zap.String("resource", resource), zap.Error(err))
+				}
+				conn.Close()
+				s.requestLogger(r).Debug("Watch client disconnected", zap.String("resource", resource))
+			}()
+
+			for {
+				select {
+				case <-done:
+					return
+				case data := <-send:
+					conn.SetWriteDeadline(time.Now().Add(watchWriteWait))
+					if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+						return
+					}
+				case <-ticker.C:
+					conn.SetWriteDeadline(time.Now().Add(watchWriteWait))
+					if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+						return
+					}
+				}
+			}
+		}()
+	}
+}
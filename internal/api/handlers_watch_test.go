@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/aaronlmathis/kaptn/internal/k8s/ws"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func watchPod(name, namespace, app string) *v1.Pod {
+	return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{"app": app},
+	}}
+}
+
+func TestResourceWatchFiltersAndCoalesces(t *testing.T) {
+	s := &Server{logger: zap.NewNop()}
+	selector, err := labels.Parse("app=web")
+	require.NoError(t, err)
+
+	var frames []ws.BatchFrame
+	rw := &resourceWatch{
+		namespace: "shop",
+		selector:  selector,
+		summarize: s.watchSummarizers()["pods"],
+	}
+	rw.coalescer = ws.NewCoalescer(time.Hour, func(frame ws.BatchFrame) {
+		frames = append(frames, frame)
+	})
+
+	sync := rw.snapshot([]interface{}{
+		watchPod("web-0", "shop", "web"),
+		watchPod("api-0", "shop", "api"),
+		watchPod("web-0", "prod", "web"),
+	})
+	assert.Equal(t, ws.SyncFrameType, sync.Type)
+	require.Len(t, sync.Items, 1)
+	assert.Equal(t, "web-0", sync.Items[0].(map[string]interface{})["name"])
+
+	handler := rw.handler()
+	handler.OnAdd(watchPod("web-1", "shop", "web"), true)
+	handler.OnAdd(watchPod("web-1", "shop", "web"), false)
+	handler.OnUpdate(watchPod("web-1", "shop", "web"), watchPod("web-1", "shop", "web"))
+	handler.OnAdd(watchPod("api-1", "shop", "api"), false)
+	handler.OnUpdate(watchPod("web-0", "shop", "web"), watchPod("web-0", "shop", "api"))
+	handler.OnUpdate(watchPod("api-2", "shop", "api"), watchPod("api-2", "shop", "web"))
+	handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "shop/web-3", Obj: watchPod("web-3", "shop", "web")})
+	rw.coalescer.Flush()
+
+	require.Len(t, frames, 1)
+	got := make(map[string]string)
+	for _, event := range frames[0].Events {
+		got[event.Key] = event.Type
+	}
+	assert.Equal(t, map[string]string{
+		"shop/web-1": ws.WatchEventAdded,
+		"shop/web-0": ws.WatchEventDeleted,
+		"shop/api-2": ws.WatchEventAdded,
+		"shop/web-3": ws.WatchEventDeleted,
+	}, got)
+}
+
+func TestHandleWatchResource(t *testing.T) {
+	client := fake.NewSimpleClientset(watchPod("web-0", "shop", "web"), watchPod("api-0", "shop", "api"))
+	manager := informers.NewManager(zap.NewNop(), client, nil)
+
+	s := &Server{logger: zap.NewNop(), informerManager: manager}
+
+	rec := httptest.NewRecorder()
+	s.handleWatchResource("pods")(rec, httptest.NewRequest(http.MethodGet, "/api/v1/pods/watch", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "an unsynced cache cannot be watched")
+
+	rec = httptest.NewRecorder()
+	s.handleWatchResource("pods")(rec, httptest.NewRequest(http.MethodGet, "/api/v1/pods/watch?labelSelector=app+in", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	require.NoError(t, manager.Start())
+	defer manager.Stop()
+
+	server := httptest.NewServer(s.handleWatchResource("pods"))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"?labelSelector=app%3Dweb", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var sync struct {
+		Type  string                   `json:"type"`
+		Items []map[string]interface{} `json:"items"`
+	}
+	require.NoError(t, conn.ReadJSON(&sync))
+	assert.Equal(t, ws.SyncFrameType, sync.Type)
+	require.Len(t, sync.Items, 1)
+	assert.Equal(t, "web-0", sync.Items[0]["name"])
+
+	_, err = client.CoreV1().Pods("shop").Create(context.Background(), watchPod("web-1", "shop", "web"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.CoreV1().Pods("shop").Create(context.Background(), watchPod("api-1", "shop", "api"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	var batch struct {
+		Type   string `json:"type"`
+		Events []struct {
+			Type   string                 `json:"type"`
+			Key    string                 `json:"key"`
+			Object map[string]interface{} `json:"object"`
+		} `json:"events"`
+	}
+	require.NoError(t, conn.ReadJSON(&batch))
+	assert.Equal(t, ws.BatchFrameType, batch.Type)
+	require.Len(t, batch.Events, 1)
+	assert.Equal(t, ws.WatchEventAdded, batch.Events[0].Type)
+	assert.Equal(t, "shop/web-1", batch.Events[0].Key)
+	assert.Equal(t, "web-1", batch.Events[0].Object["name"])
+}
+
+func TestWatchCoalesceWindow(t *testing.T) {
+	assert.Zero(t, (&Server{}).watchCoalesceWindow(), "no config uses the coalescer default")
+
+	cfg := &config.Config{}
+	cfg.Features.WatchCoalesceWindow = "1s"
+	assert.Equal(t, time.Second, (&Server{config: cfg}).watchCoalesceWindow())
+
+	cfg.Features.WatchCoalesceWindow = ""
+	assert.Zero(t, (&Server{config: cfg}).watchCoalesceWindow())
+}
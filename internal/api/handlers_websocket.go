@@ -98,12 +98,22 @@ func (s *Server) handleExecWebSocket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Reject a container name that selects more than one container
+	if containerName != "" {
+		if pod, err := s.kubeClient.CoreV1().Pods(namespace).Get(r.Context(), podName, metav1.GetOptions{}); err == nil {
+			if err := ambiguousContainerError(pod, containerName); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
 	// Default container name if not specified or auto-detect first container
 	if containerName == "" {
 		// Try to get the first container from the pod
 		pod, err := s.kubeClient.CoreV1().Pods(namespace).Get(r.Context(), podName, metav1.GetOptions{})
 		if err != nil {
-			s.logger.Error("Failed to get pod for container detection",
+			s.requestLogger(r).Error("Failed to get pod for container detection",
 				zap.String("namespace", namespace),
 				zap.String("pod", podName),
 				zap.Error(err))
@@ -111,11 +121,11 @@ func (s *Server) handleExecWebSocket(w http.ResponseWriter, r *http.Request) {
 			return
 		} else if len(pod.Spec.Containers) > 0 {
 			containerName = pod.Spec.Containers[0].Name // use first container
-			s.logger.Info("Auto-detected container",
+			s.requestLogger(r).Info("Auto-detected container",
 				zap.String("pod", podName),
 				zap.String("container", containerName))
 		} else {
-			s.logger.Error("Pod has no containers",
+			s.requestLogger(r).Error("Pod has no containers",
 				zap.String("namespace", namespace),
 				zap.String("pod", podName))
 			http.Error(w, "Pod has no containers", http.StatusBadRequest)
@@ -127,8 +137,22 @@ func (s *Server) handleExecWebSocket(w http.ResponseWriter, r *http.Request) {
 	var command []string
 	if commandStr != "" {
 		command = []string{commandStr}
+		if err := s.execCommandError(command); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 	} else {
-		// Let the exec service handle shell detection by passing empty command
+		// Let the exec service detect a shell by passing an empty command; it
+		// only tries shells on the allowlist
+		allowlist := s.config.Features.ExecAllowedCommands
+		if len(allowlist) == 0 {
+			http.Error(w, "pod exec is disabled: no commands are allowed", http.StatusForbidden)
+			return
+		}
+		if len(exec.AllowedShells(allowlist)) == 0 {
+			http.Error(w, "no shell is on the exec allowlist", http.StatusForbidden)
+			return
+		}
 		command = []string{}
 	}
 
@@ -137,17 +161,18 @@ func (s *Server) handleExecWebSocket(w http.ResponseWriter, r *http.Request) {
 
 	// Create exec request
 	execReq := exec.ExecRequest{
-		Namespace: namespace,
-		Pod:       podName,
-		Container: containerName,
-		Command:   command,
-		TTY:       tty,
+		Namespace:       namespace,
+		Pod:             podName,
+		Container:       containerName,
+		Command:         command,
+		TTY:             tty,
+		AllowedCommands: s.config.Features.ExecAllowedCommands,
 	}
 
 	// Start exec session
 	err := s.execService.StartExecSession(w, r, sessionID, execReq)
 	if err != nil {
-		s.logger.Error("Failed to start exec session",
+		s.requestLogger(r).Error("Failed to start exec session",
 			zap.String("sessionID", sessionID),
 			zap.String("namespace", namespace),
 			zap.String("pod", podName),
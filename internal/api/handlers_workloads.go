@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
 	"github.com/aaronlmathis/kaptn/internal/k8s/selectors"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
@@ -34,7 +36,7 @@ func (s *Server) handleGetPod(w http.ResponseWriter, r *http.Request) {
 		secCtx, err := s.getSecurityContext(r)
 		if err != nil {
 			if secErr, ok := err.(*SecurityError); ok {
-				s.writeSecurityError(w, secErr, nil)
+				s.writeSecurityError(w, r, secErr, nil)
 			} else {
 				http.Error(w, "Security context error", http.StatusInternalServerError)
 			}
@@ -44,7 +46,7 @@ func (s *Server) handleGetPod(w http.ResponseWriter, r *http.Request) {
 		// Phase 7: Check permission to get this specific pod
 		if err := s.checkResourcePermission(r.Context(), secCtx, "get", "pods", namespace, name); err != nil {
 			if secErr, ok := err.(*SecurityError); ok {
-				s.writeSecurityError(w, secErr, secCtx.User)
+				s.writeSecurityError(w, r, secErr, secCtx.User)
 			} else {
 				http.Error(w, "Permission check failed", http.StatusInternalServerError)
 			}
@@ -60,7 +62,7 @@ func (s *Server) handleGetPod(w http.ResponseWriter, r *http.Request) {
 	// Get pod from Kubernetes API using appropriate client
 	pod, err := kubeClient.CoreV1().Pods(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get pod",
+		s.requestLogger(r).Error("Failed to get pod",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -74,7 +76,7 @@ func (s *Server) handleGetPod(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log successful operation for audit
-	s.logger.Info("Pod retrieved successfully",
+	s.requestLogger(r).Info("Pod retrieved successfully",
 		zap.String("user", func() string {
 			if s.config.Security.AuthMode == "none" {
 				return "none-mode"
@@ -102,15 +104,30 @@ func (s *Server) handleGetPod(w http.ResponseWriter, r *http.Request) {
 
 	// Convert to enhanced summary with full details
 	summary := s.enhancedPodToSummary(pod, podMetricsMap)
+	freshness := s.metricsFreshness()
+
+	// Annotate container images with scan results when a scanner is configured
+	if containers, ok := summary["containers"].([]map[string]interface{}); ok {
+		s.attachImageScans(r.Context(), pod, containers)
+	}
+
+	// Flag container names that logs and exec cannot resolve unambiguously
+	duplicateNames := duplicateContainerNames(pod)
 
 	// Add full pod spec for detailed view
 	fullDetails := map[string]interface{}{
-		"summary":    summary,
-		"spec":       pod.Spec,
-		"status":     pod.Status,
-		"metadata":   pod.ObjectMeta,
-		"kind":       "Pod",
-		"apiVersion": "v1",
+		"summary":           summary,
+		"spec":              pod.Spec,
+		"status":            pod.Status,
+		"metadata":          pod.ObjectMeta,
+		"kind":              "Pod",
+		"apiVersion":        "v1",
+		"metricsAgeSeconds": freshness.AgeSeconds,
+		"stale":             freshness.Stale,
+		"imagePulls":        diagnoseImagePulls(r.Context(), kubeClient, pod),
+
+		"hasDuplicateContainerNames": len(duplicateNames) > 0,
+		"duplicateContainerNames":    duplicateNames,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -129,7 +146,7 @@ func (s *Server) handleListPods(w http.ResponseWriter, r *http.Request) {
 	labelSelector := r.URL.Query().Get("labelSelector")
 	fieldSelector := r.URL.Query().Get("fieldSelector")
 	search := r.URL.Query().Get("search")
-	sort := r.URL.Query().Get("sort")
+	sort := getSortParam(r)
 	order := r.URL.Query().Get("order")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pageSize")
@@ -145,13 +162,21 @@ func (s *Server) handleListPods(w http.ResponseWriter, r *http.Request) {
 		page = 1
 	}
 
+	// limit and continue opt into pages listed by the API server instead of
+	// the whole informer cache
+	limit, continueToken, paged, err := listPageParams(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Only apply Phase 7 security checks if auth mode is not 'none'
 	if s.config.Security.AuthMode != "none" {
 		// Phase 7: Get security context with impersonated client
 		secCtx, err := s.getSecurityContext(r)
 		if err != nil {
 			if secErr, ok := err.(*SecurityError); ok {
-				s.writeSecurityError(w, secErr, nil)
+				s.writeSecurityError(w, r, secErr, nil)
 			} else {
 				http.Error(w, "Security context error", http.StatusInternalServerError)
 			}
@@ -164,7 +189,7 @@ func (s *Server) handleListPods(w http.ResponseWriter, r *http.Request) {
 		if namespace != "" {
 			if err := s.checkResourcePermission(r.Context(), secCtx, "list", "pods", namespace, ""); err != nil {
 				if secErr, ok := err.(*SecurityError); ok {
-					s.writeSecurityError(w, secErr, secCtx.User)
+					s.writeSecurityError(w, r, secErr, secCtx.User)
 				} else {
 					http.Error(w, "Permission check failed", http.StatusInternalServerError)
 				}
@@ -174,7 +199,7 @@ func (s *Server) handleListPods(w http.ResponseWriter, r *http.Request) {
 			// For cluster-wide list, check with empty namespace (cluster scope)
 			if err := s.checkResourcePermission(r.Context(), secCtx, "list", "pods", "", ""); err != nil {
 				if secErr, ok := err.(*SecurityError); ok {
-					s.writeSecurityError(w, secErr, secCtx.User)
+					s.writeSecurityError(w, r, secErr, secCtx.User)
 				} else {
 					http.Error(w, "Permission check failed", http.StatusInternalServerError)
 				}
@@ -183,6 +208,25 @@ func (s *Server) handleListPods(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if paged {
+		s.writePodPage(w, r, limit, continueToken, selectors.PodFilterOptions{
+			Namespace:     namespace,
+			NodeName:      nodeName,
+			Phase:         phase,
+			LabelSelector: labelSelector,
+			FieldSelector: fieldSelector,
+			Search:        search,
+			Sort:          sort,
+			Order:         order,
+		})
+		return
+	}
+
+	// Fetch usage alongside the informer read so a slow metrics source
+	// cannot hold up the pod list
+	usageStart := time.Now()
+	usageFetch := s.startPodUsageFetch(r.Context())
+
 	// Get pods from informer cache
 	indexer := s.informerManager.GetPodLister()
 	podObjs := indexer.List()
@@ -212,22 +256,22 @@ func (s *Server) handleListPods(w http.ResponseWriter, r *http.Request) {
 
 	filteredPods, err := selectors.FilterPods(pods, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter pods", zap.Error(err))
-		http.Error(w, "Failed to filter pods", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter pods", zap.Error(err))
+		http.Error(w, "Failed to filter pods: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	// Log successful operation for audit
 	if s.config.Security.AuthMode != "none" {
 		// Log with user info when auth is enabled
-		s.logger.Info("Pods listed successfully",
+		s.requestLogger(r).Info("Pods listed successfully",
 			zap.String("namespace", namespace),
 			zap.Int("total_pods", len(filteredPods)),
 			zap.Int("page", page),
 			zap.Int("page_size", pageSize))
 	} else {
 		// Simple logging when auth is disabled
-		s.logger.Info("Pods listed successfully",
+		s.requestLogger(r).Info("Pods listed successfully",
 			zap.String("user", "none-mode"),
 			zap.String("namespace", namespace),
 			zap.Int("total_pods", len(filteredPods)),
@@ -235,17 +279,8 @@ func (s *Server) handleListPods(w http.ResponseWriter, r *http.Request) {
 			zap.Int("page_size", pageSize))
 	}
 
-	// Get pod metrics for enrichment
-	podMetricsMap := make(map[string]map[string]interface{})
-	if metrics, err := s.metricsService.GetClusterMetrics(r.Context()); err == nil {
-		for _, podMetric := range metrics.PodMetrics {
-			key := podMetric.Namespace + "/" + podMetric.Name
-			podMetricsMap[key] = map[string]interface{}{
-				"cpu":    calculatePodCPUUsage(podMetric),
-				"memory": calculatePodMemoryUsage(podMetric),
-			}
-		}
-	}
+	// Get pod metrics for enrichment, without waiting past the usage deadline
+	podMetricsMap, metricsPending := s.awaitPodUsage(usageFetch, usageStart)
 
 	// Convert to enhanced summaries
 	var items []map[string]interface{}
@@ -255,12 +290,16 @@ func (s *Server) handleListPods(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Prepare response with pagination metadata
+	freshness := s.metricsFreshness()
 	response := map[string]interface{}{
 		"data": map[string]interface{}{
-			"items":    items,
-			"page":     page,
-			"pageSize": pageSize,
-			"total":    totalBeforeFilter,
+			"items":             items,
+			"page":              page,
+			"pageSize":          pageSize,
+			"total":             totalBeforeFilter,
+			"metricsAgeSeconds": freshness.AgeSeconds,
+			"stale":             freshness.Stale,
+			"metricsPending":    metricsPending,
 		},
 		"status": "success",
 	}
@@ -276,7 +315,7 @@ func (s *Server) handleListDeployments(w http.ResponseWriter, r *http.Request) {
 	fieldSelector := r.URL.Query().Get("fieldSelector")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pageSize")
-	sort := r.URL.Query().Get("sort")
+	sort := getSortParam(r)
 	order := r.URL.Query().Get("order")
 	search := r.URL.Query().Get("search")
 
@@ -291,10 +330,27 @@ func (s *Server) handleListDeployments(w http.ResponseWriter, r *http.Request) {
 		page = 1
 	}
 
+	limit, continueToken, paged, err := listPageParams(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if paged {
+		s.writeDeploymentPage(w, r, limit, continueToken, selectors.DeploymentFilterOptions{
+			Namespace:     namespace,
+			LabelSelector: labelSelector,
+			FieldSelector: fieldSelector,
+			Search:        search,
+			Sort:          sort,
+			Order:         order,
+		})
+		return
+	}
+
 	// Get deployments from resource manager
 	deployments, err := s.resourceManager.ListDeployments(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list deployments", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list deployments", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -327,8 +383,8 @@ func (s *Server) handleListDeployments(w http.ResponseWriter, r *http.Request) {
 
 	filteredDeployments, err := selectors.FilterDeployments(deployments, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter deployments", zap.Error(err))
-		http.Error(w, "Failed to filter deployments", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter deployments", zap.Error(err))
+		http.Error(w, "Failed to filter deployments: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -354,6 +410,73 @@ func (s *Server) handleListDeployments(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// writePodPage lists one server-side page of pods and writes it with the
+// token for the next page. Selectors are evaluated by the API server; the
+// other filters and sorting apply within the page only.
+func (s *Server) writePodPage(w http.ResponseWriter, r *http.Request, limit int64, continueToken string, filterOpts selectors.PodFilterOptions) {
+	usageStart := time.Now()
+	usageFetch := s.startPodUsageFetch(r.Context())
+
+	page, err := s.resourceManager.ListPodsPaged(r.Context(), filterOpts.Namespace, filterOpts.LabelSelector, filterOpts.FieldSelector, limit, continueToken)
+	if err != nil {
+		s.writeListPageError(w, r, "pods", filterOpts.Namespace, err)
+		return
+	}
+
+	pods, err := selectors.FilterPods(page.Items, filterOpts)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Failed to filter pods: "+err.Error())
+		return
+	}
+
+	podMetricsMap, metricsPending := s.awaitPodUsage(usageFetch, usageStart)
+	items := []map[string]interface{}{}
+	for i := range pods {
+		items = append(items, s.enhancedPodToSummary(&pods[i], podMetricsMap))
+	}
+
+	freshness := s.metricsFreshness()
+	data := listPageData(items, limit, page.Continue, page.RemainingItemCount)
+	data["metricsAgeSeconds"] = freshness.AgeSeconds
+	data["stale"] = freshness.Stale
+	data["metricsPending"] = metricsPending
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   data,
+		"status": "success",
+	})
+}
+
+// writeDeploymentPage lists one server-side page of deployments like
+// writePodPage lists pods
+func (s *Server) writeDeploymentPage(w http.ResponseWriter, r *http.Request, limit int64, continueToken string, filterOpts selectors.DeploymentFilterOptions) {
+	page, err := s.resourceManager.ListDeploymentsPaged(r.Context(), filterOpts.Namespace, filterOpts.LabelSelector, filterOpts.FieldSelector, limit, continueToken)
+	if err != nil {
+		s.writeListPageError(w, r, "deployments", filterOpts.Namespace, err)
+		return
+	}
+
+	deployments, err := selectors.FilterDeployments(page.Items, filterOpts)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Failed to filter deployments: "+err.Error())
+		return
+	}
+
+	items := []map[string]interface{}{}
+	for _, deployment := range deployments {
+		items = append(items, s.deploymentToResponse(deployment))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   listPageData(items, limit, page.Continue, page.RemainingItemCount),
+		"status": "success",
+	})
+}
+
 func (s *Server) handleListStatefulSets(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
 	namespace := r.URL.Query().Get("namespace")
@@ -361,7 +484,7 @@ func (s *Server) handleListStatefulSets(w http.ResponseWriter, r *http.Request)
 	fieldSelector := r.URL.Query().Get("fieldSelector")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pageSize")
-	sort := r.URL.Query().Get("sort")
+	sort := getSortParam(r)
 	order := r.URL.Query().Get("order")
 	search := r.URL.Query().Get("search")
 
@@ -379,7 +502,7 @@ func (s *Server) handleListStatefulSets(w http.ResponseWriter, r *http.Request)
 	// Get statefulsets from resource manager
 	statefulSets, err := s.resourceManager.ListStatefulSets(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list statefulsets", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list statefulsets", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -412,8 +535,8 @@ func (s *Server) handleListStatefulSets(w http.ResponseWriter, r *http.Request)
 
 	filteredStatefulSets, err := selectors.FilterStatefulSets(statefulSets, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter statefulsets", zap.Error(err))
-		http.Error(w, "Failed to filter statefulsets", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter statefulsets", zap.Error(err))
+		http.Error(w, "Failed to filter statefulsets: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -446,7 +569,7 @@ func (s *Server) handleListReplicaSets(w http.ResponseWriter, r *http.Request) {
 	fieldSelector := r.URL.Query().Get("fieldSelector")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pageSize")
-	sort := r.URL.Query().Get("sort")
+	sort := getSortParam(r)
 	order := r.URL.Query().Get("order")
 	search := r.URL.Query().Get("search")
 
@@ -464,7 +587,7 @@ func (s *Server) handleListReplicaSets(w http.ResponseWriter, r *http.Request) {
 	// Get replicasets from resource manager
 	replicaSets, err := s.resourceManager.ListReplicaSets(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list replicasets", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list replicasets", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -497,8 +620,8 @@ func (s *Server) handleListReplicaSets(w http.ResponseWriter, r *http.Request) {
 
 	filteredReplicaSets, err := selectors.FilterReplicaSets(replicaSets, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter replicasets", zap.Error(err))
-		http.Error(w, "Failed to filter replicasets", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter replicasets", zap.Error(err))
+		http.Error(w, "Failed to filter replicasets: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -531,7 +654,7 @@ func (s *Server) handleListDaemonSets(w http.ResponseWriter, r *http.Request) {
 	fieldSelector := r.URL.Query().Get("fieldSelector")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pageSize")
-	sort := r.URL.Query().Get("sort")
+	sort := getSortParam(r)
 	order := r.URL.Query().Get("order")
 	search := r.URL.Query().Get("search")
 
@@ -549,7 +672,7 @@ func (s *Server) handleListDaemonSets(w http.ResponseWriter, r *http.Request) {
 	// Get daemonsets from resource manager
 	daemonSets, err := s.resourceManager.ListDaemonSets(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list daemonsets", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list daemonsets", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -582,8 +705,8 @@ func (s *Server) handleListDaemonSets(w http.ResponseWriter, r *http.Request) {
 
 	filteredDaemonSets, err := selectors.FilterDaemonSets(daemonSets, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter daemonsets", zap.Error(err))
-		http.Error(w, "Failed to filter daemonsets", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter daemonsets", zap.Error(err))
+		http.Error(w, "Failed to filter daemonsets: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -616,7 +739,7 @@ func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
 	fieldSelector := r.URL.Query().Get("fieldSelector")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pageSize")
-	sort := r.URL.Query().Get("sort")
+	sort := getSortParam(r)
 	order := r.URL.Query().Get("order")
 	search := r.URL.Query().Get("search")
 
@@ -634,7 +757,7 @@ func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
 	// Get jobs from resource manager
 	jobs, err := s.resourceManager.ListJobs(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list jobs", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list jobs", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -667,8 +790,8 @@ func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
 
 	filteredJobs, err := selectors.FilterJobs(jobs, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter jobs", zap.Error(err))
-		http.Error(w, "Failed to filter jobs", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter jobs", zap.Error(err))
+		http.Error(w, "Failed to filter jobs: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -701,7 +824,7 @@ func (s *Server) handleListCronJobs(w http.ResponseWriter, r *http.Request) {
 	fieldSelector := r.URL.Query().Get("fieldSelector")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pageSize")
-	sort := r.URL.Query().Get("sort")
+	sort := getSortParam(r)
 	order := r.URL.Query().Get("order")
 	search := r.URL.Query().Get("search")
 
@@ -719,7 +842,7 @@ func (s *Server) handleListCronJobs(w http.ResponseWriter, r *http.Request) {
 	// Get cronjobs from resource manager
 	cronJobs, err := s.resourceManager.ListCronJobs(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list cronjobs", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list cronjobs", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -752,8 +875,8 @@ func (s *Server) handleListCronJobs(w http.ResponseWriter, r *http.Request) {
 
 	filteredCronJobs, err := selectors.FilterCronJobs(cronJobs, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter cronjobs", zap.Error(err))
-		http.Error(w, "Failed to filter cronjobs", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter cronjobs", zap.Error(err))
+		http.Error(w, "Failed to filter cronjobs: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -796,7 +919,7 @@ func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
 	// Get job from Kubernetes API
 	job, err := s.kubeClient.BatchV1().Jobs(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get job",
+		s.requestLogger(r).Error("Failed to get job",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -847,7 +970,7 @@ func (s *Server) handleGetCronJob(w http.ResponseWriter, r *http.Request) {
 	// Get cronjob from Kubernetes API
 	cronJob, err := s.kubeClient.BatchV1().CronJobs(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get cronjob",
+		s.requestLogger(r).Error("Failed to get cronjob",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -898,7 +1021,7 @@ func (s *Server) handleGetDeployment(w http.ResponseWriter, r *http.Request) {
 	// Get deployment from Kubernetes API
 	deployment, err := s.kubeClient.AppsV1().Deployments(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get deployment",
+		s.requestLogger(r).Error("Failed to get deployment",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -916,12 +1039,13 @@ func (s *Server) handleGetDeployment(w http.ResponseWriter, r *http.Request) {
 
 	// Add full deployment spec for detailed view
 	fullDetails := map[string]interface{}{
-		"summary":    summary,
-		"spec":       deployment.Spec,
-		"status":     deployment.Status,
-		"metadata":   deployment.ObjectMeta,
-		"kind":       "Deployment",
-		"apiVersion": "apps/v1",
+		"summary":      summary,
+		"spec":         deployment.Spec,
+		"status":       deployment.Status,
+		"metadata":     deployment.ObjectMeta,
+		"kind":         "Deployment",
+		"apiVersion":   "apps/v1",
+		"scaleHistory": resources.ScaleHistory(deployment),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -949,7 +1073,7 @@ func (s *Server) handleGetStatefulSet(w http.ResponseWriter, r *http.Request) {
 	// Get statefulset from Kubernetes API
 	statefulSet, err := s.kubeClient.AppsV1().StatefulSets(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get statefulset",
+		s.requestLogger(r).Error("Failed to get statefulset",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -967,12 +1091,13 @@ func (s *Server) handleGetStatefulSet(w http.ResponseWriter, r *http.Request) {
 
 	// Add full statefulset spec for detailed view
 	fullDetails := map[string]interface{}{
-		"summary":    summary,
-		"spec":       statefulSet.Spec,
-		"status":     statefulSet.Status,
-		"metadata":   statefulSet.ObjectMeta,
-		"kind":       "StatefulSet",
-		"apiVersion": "apps/v1",
+		"summary":      summary,
+		"spec":         statefulSet.Spec,
+		"status":       statefulSet.Status,
+		"metadata":     statefulSet.ObjectMeta,
+		"kind":         "StatefulSet",
+		"apiVersion":   "apps/v1",
+		"scaleHistory": resources.ScaleHistory(statefulSet),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -1000,7 +1125,7 @@ func (s *Server) handleGetDaemonSet(w http.ResponseWriter, r *http.Request) {
 	// Get daemonset from Kubernetes API
 	daemonSet, err := s.kubeClient.AppsV1().DaemonSets(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get daemonset",
+		s.requestLogger(r).Error("Failed to get daemonset",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -1051,7 +1176,7 @@ func (s *Server) handleGetReplicaSet(w http.ResponseWriter, r *http.Request) {
 	// Get replicaset from Kubernetes API
 	replicaSet, err := s.kubeClient.AppsV1().ReplicaSets(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		s.logger.Error("Failed to get replicaset",
+		s.requestLogger(r).Error("Failed to get replicaset",
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
@@ -1069,12 +1194,13 @@ func (s *Server) handleGetReplicaSet(w http.ResponseWriter, r *http.Request) {
 
 	// Add full replicaset spec for detailed view
 	fullDetails := map[string]interface{}{
-		"summary":    summary,
-		"spec":       replicaSet.Spec,
-		"status":     replicaSet.Status,
-		"metadata":   replicaSet.ObjectMeta,
-		"kind":       "ReplicaSet",
-		"apiVersion": "apps/v1",
+		"summary":      summary,
+		"spec":         replicaSet.Spec,
+		"status":       replicaSet.Status,
+		"metadata":     replicaSet.ObjectMeta,
+		"kind":         "ReplicaSet",
+		"apiVersion":   "apps/v1",
+		"scaleHistory": resources.ScaleHistory(replicaSet),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -1092,7 +1218,7 @@ func (s *Server) handleListEndpoints(w http.ResponseWriter, r *http.Request) {
 	fieldSelector := r.URL.Query().Get("fieldSelector")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pageSize")
-	sort := r.URL.Query().Get("sort")
+	sort := getSortParam(r)
 	order := r.URL.Query().Get("order")
 	search := r.URL.Query().Get("search")
 
@@ -1110,7 +1236,7 @@ func (s *Server) handleListEndpoints(w http.ResponseWriter, r *http.Request) {
 	// Get endpoints from resource manager
 	endpoints, err := s.resourceManager.ListEndpoints(r.Context(), namespace)
 	if err != nil {
-		s.logger.Error("Failed to list endpoints", zap.Error(err))
+		s.requestLogger(r).Error("Failed to list endpoints", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -1143,8 +1269,8 @@ func (s *Server) handleListEndpoints(w http.ResponseWriter, r *http.Request) {
 
 	filteredEndpoints, err := selectors.FilterEndpoints(endpoints, filterOpts)
 	if err != nil {
-		s.logger.Error("Failed to filter endpoints", zap.Error(err))
-		http.Error(w, "Failed to filter endpoints", http.StatusBadRequest)
+		s.requestLogger(r).Error("Failed to filter endpoints", zap.Error(err))
+		http.Error(w, "Failed to filter endpoints: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
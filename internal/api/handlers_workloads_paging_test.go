@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newPagedListServer returns a server listing pods and deployments from an
+// API server that answers with one page of two objects and a continue
+// token, and the label selectors the pods were listed with
+func newPagedListServer(t *testing.T) (*Server, *[]string) {
+	t.Helper()
+
+	remaining := int64(5000)
+	listMeta := metav1.ListMeta{Continue: "next-page", RemainingItemCount: &remaining}
+	var selectors []string
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		selectors = append(selectors, action.(k8stesting.ListAction).GetListRestrictions().Labels.String())
+		return true, &v1.PodList{ListMeta: listMeta, Items: []v1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop", Labels: map[string]string{"app": "web"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "shop", Labels: map[string]string{"app": "web"}}},
+		}}, nil
+	})
+	client.PrependReactor("list", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &appsv1.DeploymentList{ListMeta: listMeta, Items: []appsv1.Deployment{
+			{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}},
+		}}, nil
+	})
+
+	cfg := &config.Config{}
+	cfg.Security.AuthMode = "none"
+	return &Server{
+		logger:          zap.NewNop(),
+		config:          cfg,
+		kubeClient:      client,
+		resourceManager: resources.NewResourceManager(zap.NewNop(), client, nil),
+	}, &selectors
+}
+
+type pagedListResponse struct {
+	Data struct {
+		Items []struct {
+			Name string `json:"name"`
+		} `json:"items"`
+		Continue           string `json:"continue"`
+		Limit              int64  `json:"limit"`
+		RemainingItemCount int64  `json:"remainingItemCount"`
+	} `json:"data"`
+}
+
+func TestHandleListPodsServerSidePage(t *testing.T) {
+	s, selectors := newPagedListServer(t)
+
+	rec := httptest.NewRecorder()
+	s.handleListPods(rec, httptest.NewRequest(http.MethodGet, "/api/v1/pods?limit=2&labelSelector=app%3Dweb&search=web-1", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response pagedListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Data.Items, 1, "search applies within the page")
+	assert.Equal(t, "web-1", response.Data.Items[0].Name)
+	assert.Equal(t, "next-page", response.Data.Continue)
+	assert.Equal(t, int64(2), response.Data.Limit)
+	assert.Equal(t, int64(5000), response.Data.RemainingItemCount)
+	assert.Equal(t, []string{"app=web"}, *selectors)
+}
+
+func TestHandleListDeploymentsServerSidePage(t *testing.T) {
+	s, _ := newPagedListServer(t)
+
+	rec := httptest.NewRecorder()
+	s.handleListDeployments(rec, httptest.NewRequest(http.MethodGet, "/api/v1/deployments?continue=abc", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response pagedListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Len(t, response.Data.Items, 2)
+	assert.Equal(t, "next-page", response.Data.Continue)
+}
+
+func TestHandleListServerSidePageErrors(t *testing.T) {
+	s, _ := newPagedListServer(t)
+	for _, target := range []string{"/api/v1/pods?limit=0", "/api/v1/pods?limit=abc"} {
+		rec := httptest.NewRecorder()
+		s.handleListPods(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewResourceExpired("continue token too old")
+	})
+	s.resourceManager = resources.NewResourceManager(zap.NewNop(), client, nil)
+	rec := httptest.NewRecorder()
+	s.handleListDeployments(rec, httptest.NewRequest(http.MethodGet, "/api/v1/deployments?continue=stale", nil))
+	assert.Equal(t, http.StatusGone, rec.Code)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/images"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+)
+
+// initImageScanner configures the image scanner from the integrations config.
+// Scanning is optional, so configuration problems fall back to the no-op scanner.
+func (s *Server) initImageScanner() {
+	s.imageScanner = images.NoopScanner{}
+	s.imageScanTimeout = images.DefaultScanTimeout
+
+	scannerConfig := s.config.Integrations.ImageScanner
+	if !scannerConfig.Enabled {
+		return
+	}
+
+	httpConfig := images.HTTPScannerConfig{
+		URL:   scannerConfig.URL,
+		Token: scannerConfig.Token,
+	}
+	if timeout, err := time.ParseDuration(scannerConfig.Timeout); err == nil && timeout > 0 {
+		httpConfig.Timeout = timeout
+		s.imageScanTimeout = timeout
+	}
+	if ttl, err := time.ParseDuration(scannerConfig.CacheTTL); err == nil {
+		httpConfig.CacheTTL = ttl
+	}
+
+	scanner, err := images.NewHTTPScanner(s.logger, httpConfig)
+	if err != nil {
+		s.logger.Warn("Image scanner disabled due to invalid configuration", zap.Error(err))
+		return
+	}
+
+	s.imageScanner = scanner
+	s.logger.Info("Image scanner enabled", zap.String("url", scannerConfig.URL))
+}
+
+// attachImageScans adds an "imageScan" entry to each container summary whose
+// image has scan results. Lookups are time-boxed; containers without results
+// are left untouched.
+func (s *Server) attachImageScans(ctx context.Context, pod *v1.Pod, containers []map[string]interface{}) {
+	if s.imageScanner == nil || len(containers) == 0 {
+		return
+	}
+	if _, isNoop := s.imageScanner.(images.NoopScanner); isNoop {
+		return
+	}
+
+	imageByContainer := make(map[string]string)
+	for _, container := range pod.Spec.InitContainers {
+		imageByContainer[container.Name] = container.Image
+	}
+	for _, container := range pod.Spec.Containers {
+		imageByContainer[container.Name] = container.Image
+	}
+
+	imageRefs := make([]string, 0, len(imageByContainer))
+	for _, image := range imageByContainer {
+		imageRefs = append(imageRefs, image)
+	}
+
+	scans := images.ScanImages(ctx, s.logger, s.imageScanner, imageRefs, s.imageScanTimeout)
+	for _, container := range containers {
+		name, _ := container["name"].(string)
+		if scan, ok := scans[imageByContainer[name]]; ok {
+			container["imageScan"] = scan
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/images"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// stubImageScanner reports fixed scan results per image
+type stubImageScanner map[string]*images.ImageScan
+
+func (s stubImageScanner) Scan(ctx context.Context, image string) (*images.ImageScan, error) {
+	return s[image], nil
+}
+
+func TestAttachImageScans(t *testing.T) {
+	s := &Server{
+		logger: zap.NewNop(),
+		imageScanner: stubImageScanner{
+			"nginx:1.25": {Severity: images.SeverityCounts{Critical: 2}},
+		},
+		imageScanTimeout: time.Second,
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.PodSpec{Containers: []v1.Container{
+			{Name: "app", Image: "nginx:1.25"},
+			{Name: "sidecar", Image: "envoy:1.30"},
+		}},
+	}
+	containers := []map[string]interface{}{{"name": "app"}, {"name": "sidecar"}}
+
+	s.attachImageScans(context.Background(), pod, containers)
+
+	scan, ok := containers[0]["imageScan"].(*images.ImageScan)
+	assert.True(t, ok)
+	assert.Equal(t, 2, scan.Severity.Critical)
+	assert.NotContains(t, containers[1], "imageScan")
+}
@@ -34,12 +34,12 @@ func (s *Server) ImpersonationMiddleware(next http.Handler) http.Handler {
 		if s.authMiddleware != nil {
 			if binding, err := s.authMiddleware.GetUserBinding(r.Context(), username); err == nil {
 				effectiveGroups = binding.Groups
-				s.logger.Debug("Using resolved groups from ConfigMap for impersonation",
+				s.requestLogger(r).Debug("Using resolved groups from ConfigMap for impersonation",
 					zap.String("username", username),
 					zap.Strings("original_groups", user.Groups),
 					zap.Strings("resolved_groups", effectiveGroups))
 			} else {
-				s.logger.Debug("Could not resolve groups from ConfigMap, using original groups",
+				s.requestLogger(r).Debug("Could not resolve groups from ConfigMap, using original groups",
 					zap.String("username", username),
 					zap.Error(err))
 			}
@@ -48,7 +48,7 @@ func (s *Server) ImpersonationMiddleware(next http.Handler) http.Handler {
 		// Build impersonated clients with the correct groups
 		clients, err := s.impersonationMgr.BuildClientsFromUserWithGroups(user, s.config.Security.UsernameFormat, effectiveGroups)
 		if err != nil {
-			s.logger.Error("Failed to build impersonated clients",
+			s.requestLogger(r).Error("Failed to build impersonated clients",
 				zap.Error(err),
 				zap.String("userEmail", user.Email),
 				zap.String("userSub", user.Sub),
@@ -69,13 +69,13 @@ func (s *Server) ImpersonationMiddleware(next http.Handler) http.Handler {
 			updatedUser.Groups = effectiveGroups
 			ctx = auth.WithUser(ctx, &updatedUser)
 
-			s.logger.Debug("Updated user context with resolved groups",
+			s.requestLogger(r).Debug("Updated user context with resolved groups",
 				zap.String("userEmail", user.Email),
 				zap.Strings("original_groups", user.Groups),
 				zap.Strings("effective_groups", effectiveGroups))
 		}
 
-		s.logger.Debug("Added impersonated clients to request context",
+		s.requestLogger(r).Debug("Added impersonated clients to request context",
 			zap.String("userEmail", user.Email),
 			zap.String("username", username),
 			zap.Strings("effective_groups", effectiveGroups))
@@ -101,7 +101,7 @@ func slicesEqual(a, b []string) bool {
 func (s *Server) RequireImpersonation(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !s.HasImpersonatedClients(r) {
-			s.logger.Warn("Impersonated clients not available in context")
+			s.requestLogger(r).Warn("Impersonated clients not available in context")
 			http.Error(w, "Authentication required", http.StatusUnauthorized)
 			return
 		}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// podContainerNameCounts counts how many regular, init and ephemeral
+// containers of a pod carry each name
+func podContainerNameCounts(pod *v1.Pod) map[string]int {
+	counts := make(map[string]int)
+	for _, container := range pod.Spec.Containers {
+		counts[container.Name]++
+	}
+	for _, container := range pod.Spec.InitContainers {
+		counts[container.Name]++
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		counts[container.Name]++
+	}
+	return counts
+}
+
+// duplicateContainerNames returns the sorted container names used by more than
+// one container of a pod. The API server rejects such specs, but generated or
+// hand-edited objects can still carry them.
+func duplicateContainerNames(pod *v1.Pod) []string {
+	var duplicates []string
+	for name, count := range podContainerNameCounts(pod) {
+		if count > 1 {
+			duplicates = append(duplicates, name)
+		}
+	}
+	sort.Strings(duplicates)
+	return duplicates
+}
+
+// ambiguousContainerError reports when a container name selects more than one
+// container of a pod, so logs and exec cannot tell which one was meant
+func ambiguousContainerError(pod *v1.Pod, name string) error {
+	if count := podContainerNameCounts(pod)[name]; count > 1 {
+		return fmt.Errorf("container name %q is ambiguous: it matches %d containers in pod %s/%s", name, count, pod.Namespace, pod.Name)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// collidingPod has an init container and a regular container both named app
+func collidingPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "app"}},
+			Containers:     []v1.Container{{Name: "app"}, {Name: "sidecar"}},
+		},
+	}
+}
+
+func TestDuplicateContainerNames(t *testing.T) {
+	pod := collidingPod()
+	assert.Equal(t, []string{"app"}, duplicateContainerNames(pod))
+
+	err := ambiguousContainerError(pod, "app")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"app" is ambiguous`)
+	assert.NoError(t, ambiguousContainerError(pod, "sidecar"))
+	assert.NoError(t, ambiguousContainerError(pod, "missing"))
+
+	pod.Spec.InitContainers[0].Name = "migrate"
+	assert.Empty(t, duplicateContainerNames(pod))
+}
+
+func TestAmbiguousContainerRejected(t *testing.T) {
+	s := &Server{logger: zap.NewNop(), kubeClient: fake.NewSimpleClientset(collidingPod())}
+
+	t.Run("logs", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/pods/shop/web-0/logs?container=app", nil)
+		routeCtx := chi.NewRouteContext()
+		routeCtx.URLParams.Add("namespace", "shop")
+		routeCtx.URLParams.Add("podName", "web-0")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+		rec := httptest.NewRecorder()
+		s.handleGetPodLogs(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "ambiguous")
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/exec/session-1?namespace=shop&pod=web-0&container=app", nil)
+		routeCtx := chi.NewRouteContext()
+		routeCtx.URLParams.Add("sessionId", "session-1")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+		rec := httptest.NewRecorder()
+		s.handleExecWebSocket(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "ambiguous")
+	})
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultImageRegistry is the registry of image references without a registry host
+const defaultImageRegistry = "docker.io"
+
+// imagePullSecretStatus describes one of a pod's imagePullSecrets. Secret
+// contents are never returned, only the registries they hold credentials for.
+type imagePullSecretStatus struct {
+	Name       string   `json:"name"`
+	Exists     bool     `json:"exists"`
+	Type       string   `json:"type,omitempty"`
+	Valid      bool     `json:"valid"`
+	Registries []string `json:"registries"`
+	Problem    string   `json:"problem,omitempty"`
+}
+
+// imagePullDiagnosis explains image pull failures from the pod's pull secrets
+// and the registries its images come from. Registries are never contacted.
+type imagePullDiagnosis struct {
+	Secrets []imagePullSecretStatus `json:"secrets"`
+	// Registries referenced by the pod's container images
+	ImageRegistries []string `json:"imageRegistries"`
+	// Containers currently failing to pull their image
+	PullFailures []imagePullFailure `json:"pullFailures"`
+	Issues       []string           `json:"issues"`
+}
+
+// imagePullFailure is a container waiting on ErrImagePull or ImagePullBackOff
+type imagePullFailure struct {
+	Container      string `json:"container"`
+	Image          string `json:"image"`
+	Registry       string `json:"registry"`
+	Reason         string `json:"reason"`
+	Message        string `json:"message,omitempty"`
+	HasCredentials bool   `json:"hasCredentials"` // A valid pull secret covers the registry
+}
+
+// diagnoseImagePulls resolves the pod's imagePullSecrets, checking that each
+// exists and is a docker config secret, and matches the registries they cover
+// against the images the pod is failing to pull
+func diagnoseImagePulls(ctx context.Context, client kubernetes.Interface, pod *v1.Pod) imagePullDiagnosis {
+	diagnosis := imagePullDiagnosis{
+		Secrets:         []imagePullSecretStatus{},
+		ImageRegistries: []string{},
+		PullFailures:    []imagePullFailure{},
+		Issues:          []string{},
+	}
+
+	covered := make(map[string]bool)
+	for _, ref := range pod.Spec.ImagePullSecrets {
+		status := resolveImagePullSecret(ctx, client, pod.Namespace, ref.Name)
+		if status.Valid {
+			for _, registry := range status.Registries {
+				covered[registry] = true
+			}
+		}
+		if status.Problem != "" {
+			diagnosis.Issues = append(diagnosis.Issues, fmt.Sprintf("imagePullSecret %q: %s", ref.Name, status.Problem))
+		}
+		diagnosis.Secrets = append(diagnosis.Secrets, status)
+	}
+
+	imageByContainer := make(map[string]string)
+	registries := make(map[string]bool)
+	for _, containers := range [][]v1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, container := range containers {
+			imageByContainer[container.Name] = container.Image
+			registries[imageRegistry(container.Image)] = true
+		}
+	}
+	for registry := range registries {
+		diagnosis.ImageRegistries = append(diagnosis.ImageRegistries, registry)
+	}
+	sort.Strings(diagnosis.ImageRegistries)
+
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		waiting := status.State.Waiting
+		if waiting == nil || (waiting.Reason != "ErrImagePull" && waiting.Reason != "ImagePullBackOff") {
+			continue
+		}
+		image := imageByContainer[status.Name]
+		if image == "" {
+			image = status.Image
+		}
+		failure := imagePullFailure{
+			Container:      status.Name,
+			Image:          image,
+			Registry:       imageRegistry(image),
+			Reason:         waiting.Reason,
+			Message:        waiting.Message,
+			HasCredentials: covered[imageRegistry(image)],
+		}
+		if !failure.HasCredentials {
+			diagnosis.Issues = append(diagnosis.Issues, fmt.Sprintf(
+				"container %q cannot pull %s and no valid imagePullSecret has credentials for %s",
+				failure.Container, failure.Image, failure.Registry))
+		}
+		diagnosis.PullFailures = append(diagnosis.PullFailures, failure)
+	}
+
+	return diagnosis
+}
+
+// resolveImagePullSecret reads a pull secret and lists the registries it covers
+func resolveImagePullSecret(ctx context.Context, client kubernetes.Interface, namespace, name string) imagePullSecretStatus {
+	status := imagePullSecretStatus{Name: name, Registries: []string{}}
+
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			status.Problem = "secret does not exist"
+		} else {
+			status.Problem = fmt.Sprintf("secret could not be read: %v", err)
+		}
+		return status
+	}
+	status.Exists = true
+	status.Type = string(secret.Type)
+
+	var registries []string
+	switch secret.Type {
+	case v1.SecretTypeDockerConfigJson:
+		registries, err = dockerConfigJSONRegistries(secret.Data[v1.DockerConfigJsonKey])
+	case v1.SecretTypeDockercfg:
+		registries, err = dockercfgRegistries(secret.Data[v1.DockerConfigKey])
+	default:
+		status.Problem = fmt.Sprintf("secret type is %s, expected %s", secret.Type, v1.SecretTypeDockerConfigJson)
+		return status
+	}
+	if err != nil {
+		status.Problem = err.Error()
+		return status
+	}
+	if len(registries) == 0 {
+		status.Problem = "secret has no registry credentials"
+		return status
+	}
+
+	status.Valid = true
+	status.Registries = registries
+	return status
+}
+
+// dockerConfigJSONRegistries returns the registries in a .dockerconfigjson payload
+func dockerConfigJSONRegistries(data []byte) ([]string, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("secret is missing %s", v1.DockerConfigJsonKey)
+	}
+	var config struct {
+		Auths map[string]json.RawMessage `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%s is not valid JSON: %v", v1.DockerConfigJsonKey, err)
+	}
+	return normalizedRegistries(config.Auths), nil
+}
+
+// dockercfgRegistries returns the registries in a legacy .dockercfg payload
+func dockercfgRegistries(data []byte) ([]string, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("secret is missing %s", v1.DockerConfigKey)
+	}
+	var auths map[string]json.RawMessage
+	if err := json.Unmarshal(data, &auths); err != nil {
+		return nil, fmt.Errorf("%s is not valid JSON: %v", v1.DockerConfigKey, err)
+	}
+	return normalizedRegistries(auths), nil
+}
+
+// normalizedRegistries returns the sorted, de-duplicated registry hosts of a
+// docker config auths map
+func normalizedRegistries(auths map[string]json.RawMessage) []string {
+	seen := make(map[string]bool, len(auths))
+	registries := make([]string, 0, len(auths))
+	for server := range auths {
+		registry := normalizeRegistry(server)
+		if registry == "" || seen[registry] {
+			continue
+		}
+		seen[registry] = true
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+	return registries
+}
+
+// normalizeRegistry reduces a docker config server entry such as
+// "https://index.docker.io/v1/" to its registry host
+func normalizeRegistry(server string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	host = strings.ToLower(host)
+	switch host {
+	case "index.docker.io", "registry-1.docker.io":
+		return defaultImageRegistry
+	}
+	return host
+}
+
+// imageRegistry returns the registry host of an image reference, following the
+// docker convention that a first path component is a host only when it
+// contains a "." or ":" or is "localhost"
+func imageRegistry(image string) string {
+	i := strings.Index(image, "/")
+	if i < 0 {
+		return defaultImageRegistry
+	}
+	first := image[:i]
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		return normalizeRegistry(first)
+	}
+	return defaultImageRegistry
+}
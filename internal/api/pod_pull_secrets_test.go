@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func pullSecretPod(secrets ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "app", Image: "ghcr.io/acme/app:1.2"},
+				{Name: "proxy", Image: "nginx:1.25"},
+			},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "app", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "pull access denied"}}},
+				{Name: "proxy", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			},
+		},
+	}
+	for _, name := range secrets {
+		pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, v1.LocalObjectReference{Name: name})
+	}
+	return pod
+}
+
+func TestDiagnoseImagePullsMissingSecret(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	diagnosis := diagnoseImagePulls(context.Background(), client, pullSecretPod("ghcr-creds"))
+
+	require.Len(t, diagnosis.Secrets, 1)
+	assert.Equal(t, "ghcr-creds", diagnosis.Secrets[0].Name)
+	assert.False(t, diagnosis.Secrets[0].Exists)
+	assert.False(t, diagnosis.Secrets[0].Valid)
+	assert.Equal(t, "secret does not exist", diagnosis.Secrets[0].Problem)
+
+	assert.Equal(t, []string{"docker.io", "ghcr.io"}, diagnosis.ImageRegistries)
+	require.Len(t, diagnosis.PullFailures, 1)
+	assert.Equal(t, "app", diagnosis.PullFailures[0].Container)
+	assert.Equal(t, "ghcr.io", diagnosis.PullFailures[0].Registry)
+	assert.False(t, diagnosis.PullFailures[0].HasCredentials)
+
+	require.Len(t, diagnosis.Issues, 2)
+	assert.Contains(t, diagnosis.Issues[0], `imagePullSecret "ghcr-creds": secret does not exist`)
+	assert.Contains(t, diagnosis.Issues[1], "no valid imagePullSecret has credentials for ghcr.io")
+}
+
+func TestDiagnoseImagePullsSecretTypes(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ghcr-creds", Namespace: "default"},
+			Type:       v1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				v1.DockerConfigJsonKey: []byte(`{"auths":{"ghcr.io":{"auth":"eDp5"},"https://index.docker.io/v1/":{"auth":"eDp5"}}}`),
+			},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "default"},
+			Type:       v1.SecretTypeOpaque,
+			Data:       map[string][]byte{"token": []byte("x")},
+		},
+	)
+
+	diagnosis := diagnoseImagePulls(context.Background(), client, pullSecretPod("ghcr-creds", "opaque"))
+
+	require.Len(t, diagnosis.Secrets, 2)
+	valid := diagnosis.Secrets[0]
+	assert.True(t, valid.Valid)
+	assert.Equal(t, string(v1.SecretTypeDockerConfigJson), valid.Type)
+	assert.Equal(t, []string{"docker.io", "ghcr.io"}, valid.Registries)
+
+	opaque := diagnosis.Secrets[1]
+	assert.True(t, opaque.Exists)
+	assert.False(t, opaque.Valid)
+	assert.Contains(t, opaque.Problem, "expected kubernetes.io/dockerconfigjson")
+
+	require.Len(t, diagnosis.PullFailures, 1)
+	assert.True(t, diagnosis.PullFailures[0].HasCredentials)
+	assert.Len(t, diagnosis.Issues, 1, "only the opaque secret is flagged")
+}
+
+func TestImageRegistry(t *testing.T) {
+	tests := map[string]string{
+		"nginx":                                "docker.io",
+		"library/nginx:1.25":                   "docker.io",
+		"ghcr.io/acme/app:1.2":                 "ghcr.io",
+		"localhost/app":                        "localhost",
+		"registry.local:5000/team/app@sha256:": "registry.local:5000",
+		"Quay.IO/org/app":                      "quay.io",
+	}
+	for image, want := range tests {
+		assert.Equal(t, want, imageRegistry(image), image)
+	}
+}
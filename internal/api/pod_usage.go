@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultPodUsageWait bounds how long pod list responses wait for usage
+	// data before returning pods without it
+	defaultPodUsageWait = 750 * time.Millisecond
+	// podUsageFetchTimeout bounds the background metrics fetch itself
+	podUsageFetchTimeout = 10 * time.Second
+	// podUsageCacheMaxAge bounds how stale cached usage may be when a fetch
+	// does not finish in time
+	podUsageCacheMaxAge = 2 * time.Minute
+	// podUsageFreshFor is how long cached usage is served without fetching
+	podUsageFreshFor = 5 * time.Second
+)
+
+// podUsage maps "namespace/name" to the cpu/memory usage of a pod
+type podUsage map[string]map[string]interface{}
+
+// podUsageFetch is a usage fetch shared by every request that starts while
+// it runs. usage is set before done is closed and is nil when it failed.
+type podUsageFetch struct {
+	done  chan struct{}
+	usage podUsage
+}
+
+// finishedPodUsageFetch returns a fetch that has already yielded usage
+func finishedPodUsageFetch(usage podUsage) *podUsageFetch {
+	fetch := &podUsageFetch{done: make(chan struct{}), usage: usage}
+	close(fetch.done)
+	return fetch
+}
+
+// podUsageCache keeps the result of the last successful usage fetch, so a
+// response that cannot wait for a fetch still shows recent usage, and the
+// fetch in flight, so concurrent requests share one metrics scrape
+type podUsageCache struct {
+	mu        sync.Mutex
+	usage     podUsage
+	fetchedAt time.Time
+	inflight  *podUsageFetch
+}
+
+// store records usage fetched at the given time unless newer usage is
+// already cached
+func (c *podUsageCache) store(usage podUsage, fetchedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.usage != nil && fetchedAt.Before(c.fetchedAt) {
+		return
+	}
+	c.usage = usage
+	c.fetchedAt = fetchedAt
+}
+
+// join returns cached usage younger than podUsageFreshFor as a finished
+// fetch, or else the fetch in flight. When neither exists it registers a new
+// fetch and start is true; the caller must run it and call finish.
+func (c *podUsageCache) join(now time.Time) (fetch *podUsageFetch, start bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.usage != nil && now.Sub(c.fetchedAt) < podUsageFreshFor {
+		return finishedPodUsageFetch(c.usage), false
+	}
+	if c.inflight != nil {
+		return c.inflight, false
+	}
+	c.inflight = &podUsageFetch{done: make(chan struct{})}
+	return c.inflight, true
+}
+
+// finish caches the usage of a fetch started at fetchedAt and hands it to
+// every request waiting on the fetch
+func (c *podUsageCache) finish(fetch *podUsageFetch, usage podUsage, fetchedAt time.Time) {
+	if usage != nil {
+		c.store(usage, fetchedAt)
+	}
+	c.mu.Lock()
+	if c.inflight == fetch {
+		c.inflight = nil
+	}
+	c.mu.Unlock()
+
+	fetch.usage = usage
+	close(fetch.done)
+}
+
+// load returns the cached usage, or nil when none is younger than maxAge
+func (c *podUsageCache) load(now time.Time, maxAge time.Duration) podUsage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.usage == nil || now.Sub(c.fetchedAt) > maxAge {
+		return nil
+	}
+	return c.usage
+}
+
+// startPodUsageFetch fetches pod usage from the metrics service in the
+// background so it can overlap with reading pods from the informer. Fresh
+// cached usage is returned without fetching, and a request arriving while a
+// fetch runs joins it. The fetch is detached from ctx's cancellation so it
+// can finish and fill the cache after the response has been written.
+func (s *Server) startPodUsageFetch(ctx context.Context) *podUsageFetch {
+	if s.metricsService == nil {
+		return finishedPodUsageFetch(nil)
+	}
+
+	now := time.Now()
+	fetch, start := s.podUsageCache.join(now)
+	if !start {
+		return fetch
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), podUsageFetchTimeout)
+		defer cancel()
+
+		metrics, err := s.metricsService.GetClusterMetrics(ctx)
+		if err != nil {
+			s.podUsageCache.finish(fetch, nil, now)
+			return
+		}
+
+		usage := make(podUsage, len(metrics.PodMetrics))
+		for _, podMetric := range metrics.PodMetrics {
+			key := podMetric.Namespace + "/" + podMetric.Name
+			usage[key] = map[string]interface{}{
+				"cpu":    calculatePodCPUUsage(podMetric),
+				"memory": calculatePodMemoryUsage(podMetric),
+			}
+		}
+		s.podUsageCache.finish(fetch, usage, now)
+	}()
+	return fetch
+}
+
+// awaitPodUsage waits for a fetch started at start until the usage wait
+// elapses, falling back to recently cached usage when the fetch fails or is
+// still running. pending reports that the fetch had not finished in time.
+func (s *Server) awaitPodUsage(fetch *podUsageFetch, start time.Time) (usage podUsage, pending bool) {
+	wait := s.podUsageWait
+	if wait <= 0 {
+		wait = defaultPodUsageWait
+	}
+
+	timer := time.NewTimer(time.Until(start.Add(wait)))
+	defer timer.Stop()
+
+	select {
+	case <-fetch.done:
+		usage = fetch.usage
+	case <-timer.C:
+		pending = true
+	}
+	if usage == nil {
+		usage = s.podUsageCache.load(time.Now(), podUsageCacheMaxAge)
+	}
+	if usage == nil {
+		usage = make(podUsage)
+	}
+	return usage, pending
+}
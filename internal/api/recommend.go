@@ -0,0 +1,100 @@
+package api
+
+import (
+	"math"
+	"sort"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+const (
+	// minRecommendationSamples is the usage history needed before sizing advice is given
+	minRecommendationSamples = 30
+	// recommendationPercentile is the usage percentile requests and limits are sized from
+	recommendationPercentile = 95
+	// requestHeadroom and limitHeadroom are the margins added on top of the percentile
+	requestHeadroom = 0.15
+	limitHeadroom   = 0.50
+	// overProvisionedFactor is how far a request may exceed the recommendation
+	// before it is flagged as over-provisioned
+	overProvisionedFactor = 2.0
+)
+
+// Verdicts of a resource recommendation
+const (
+	verdictOK               = "ok"
+	verdictOverProvisioned  = "over-provisioned"
+	verdictUnderProvisioned = "under-provisioned"
+	verdictNoRequest        = "no request"
+	verdictInsufficientData = "insufficient data"
+)
+
+// resourceRecommendation sizes one resource from its usage history and
+// compares the result with what is currently configured. Values are in cores
+// for CPU and bytes for memory.
+type resourceRecommendation struct {
+	Samples            int      `json:"samples"`
+	P95Usage           *float64 `json:"p95Usage,omitempty"`
+	MaxUsage           *float64 `json:"maxUsage,omitempty"`
+	CurrentRequest     *float64 `json:"currentRequest,omitempty"`
+	CurrentLimit       *float64 `json:"currentLimit,omitempty"`
+	RecommendedRequest *float64 `json:"recommendedRequest,omitempty"`
+	RecommendedLimit   *float64 `json:"recommendedLimit,omitempty"`
+	Verdict            string   `json:"verdict"`
+}
+
+// percentile returns the p-th percentile of values using linear interpolation
+// between closest ranks. values must not be empty and is sorted in place.
+func percentile(values []float64, p float64) float64 {
+	sort.Float64s(values)
+	if len(values) == 1 {
+		return values[0]
+	}
+	rank := p / 100 * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+}
+
+// recommend sizes a resource from its usage points: the request is the p95
+// usage plus requestHeadroom and the limit the p95 usage plus limitHeadroom.
+// current and limit are the configured request and limit, nil when unset. A
+// request below the p95 usage, or a limit the p95 usage is within the request
+// headroom of, is under-provisioned; a request more than overProvisionedFactor
+// times the recommendation is over-provisioned.
+func recommend(points []timeseries.Point, current, limit *float64) resourceRecommendation {
+	rec := resourceRecommendation{
+		Samples:        len(points),
+		CurrentRequest: current,
+		CurrentLimit:   limit,
+	}
+	if len(points) < minRecommendationSamples {
+		rec.Verdict = verdictInsufficientData
+		return rec
+	}
+
+	values := make([]float64, len(points))
+	for i, point := range points {
+		values[i] = point.V
+	}
+	p95 := percentile(values, recommendationPercentile)
+	maxUsage := values[len(values)-1]
+	request := p95 * (1 + requestHeadroom)
+	recommendedLimit := p95 * (1 + limitHeadroom)
+	rec.P95Usage = &p95
+	rec.MaxUsage = &maxUsage
+	rec.RecommendedRequest = &request
+	rec.RecommendedLimit = &recommendedLimit
+
+	switch {
+	case current == nil:
+		rec.Verdict = verdictNoRequest
+	case *current < p95, limit != nil && request > *limit:
+		rec.Verdict = verdictUnderProvisioned
+	case *current > request*overProvisionedFactor:
+		rec.Verdict = verdictOverProvisioned
+	default:
+		rec.Verdict = verdictOK
+	}
+	return rec
+}
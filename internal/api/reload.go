@@ -0,0 +1,209 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/auth"
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/logging"
+	"go.uber.org/zap"
+)
+
+// ReloadResult summarizes which settings a configuration reload applied or rejected
+type ReloadResult struct {
+	Applied   []string  `json:"applied"`
+	Rejected  []string  `json:"rejected"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// EnableConfigReload allows the configuration to be reloaded at runtime from
+// configPath (or the environment when empty), adjusting logLevel in place
+func (s *Server) EnableConfigReload(configPath string, logLevel zap.AtomicLevel) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	s.configPath = configPath
+	s.logLevel = &logLevel
+}
+
+// currentConfig returns the configuration in effect: the startup
+// configuration with the hot-reloadable settings of the latest reload applied.
+// A reload swaps in a new copy rather than modifying it, so the result may be
+// read without locking but must not be written.
+func (s *Server) currentConfig() *config.Config {
+	if cfg := s.liveConfig.Load(); cfg != nil {
+		return cfg
+	}
+	return s.config
+}
+
+// ReloadConfig reloads the configuration source and applies hot-reloadable settings
+func (s *Server) ReloadConfig() (*ReloadResult, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	var newCfg *config.Config
+	var err error
+	if s.configPath != "" {
+		newCfg, err = config.LoadFromFile(s.configPath)
+	} else {
+		newCfg, err = config.Load()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := newCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return s.applyConfig(newCfg), nil
+}
+
+// applyConfig applies the hot-reloadable fields of newCfg to a copy of the
+// current configuration and swaps it in. Fields that need a restart to take
+// effect are left unchanged and reported as rejected. The caller holds reloadMu.
+func (s *Server) applyConfig(newCfg *config.Config) *ReloadResult {
+	current := s.currentConfig()
+	next := *current
+
+	result := &ReloadResult{
+		Applied:   []string{},
+		Rejected:  []string{},
+		Timestamp: time.Now().UTC(),
+	}
+
+	// Settings bound at startup (listeners, auth, cluster connection, store sizing)
+	nonReloadable := []struct {
+		name    string
+		current interface{}
+		updated interface{}
+	}{
+		{"server.addr", current.Server.Addr, newCfg.Server.Addr},
+		{"server.base_path", current.Server.BasePath, newCfg.Server.BasePath},
+		{"security.auth_mode", current.Security.AuthMode, newCfg.Security.AuthMode},
+		{"read_only", current.ReadOnly, newCfg.ReadOnly},
+		{"security.tls", current.Security.TLS, newCfg.Security.TLS},
+		{"kubernetes.mode", current.Kubernetes.Mode, newCfg.Kubernetes.Mode},
+		{"kubernetes.kubeconfig_path", current.Kubernetes.KubeconfigPath, newCfg.Kubernetes.KubeconfigPath},
+		{"kubernetes.kubelet_summary", current.Kubernetes.KubeletSummary, newCfg.Kubernetes.KubeletSummary},
+		{"timeseries.enabled", current.Timeseries.Enabled, newCfg.Timeseries.Enabled},
+		{"timeseries.window", current.Timeseries.Window, newCfg.Timeseries.Window},
+		{"timeseries.self_metrics_window", current.Timeseries.SelfMetricsWindow, newCfg.Timeseries.SelfMetricsWindow},
+		{"timeseries.lo_res", current.Timeseries.LoRes, newCfg.Timeseries.LoRes},
+		{"timeseries.med_res", current.Timeseries.MedRes, newCfg.Timeseries.MedRes},
+		{"timeseries.snapshot_path", current.Timeseries.SnapshotPath, newCfg.Timeseries.SnapshotPath},
+		{"timeseries.snapshot_interval", current.Timeseries.SnapshotInterval, newCfg.Timeseries.SnapshotInterval},
+	}
+	for _, field := range nonReloadable {
+		if !reflect.DeepEqual(field.current, field.updated) {
+			s.logger.Warn("Ignoring change to non-reloadable setting; restart required",
+				zap.String("setting", field.name))
+			result.Rejected = append(result.Rejected, field.name)
+		}
+	}
+
+	// Log level
+	if newCfg.Logging.Level != current.Logging.Level {
+		if s.logLevel != nil {
+			s.logLevel.SetLevel(logging.ParseLevel(newCfg.Logging.Level))
+			next.Logging.Level = newCfg.Logging.Level
+			result.Applied = append(result.Applied, "logging.level")
+		} else {
+			result.Rejected = append(result.Rejected, "logging.level")
+		}
+	}
+
+	// Aggregator intervals, restart storm tuning, collector error log
+	// throttling, namespace scoping and the custom and external metric
+	// collectors
+	timeseriesChanged := newCfg.Timeseries.TickInterval != current.Timeseries.TickInterval ||
+		newCfg.Timeseries.IntervalProfile != current.Timeseries.IntervalProfile ||
+		newCfg.Timeseries.CapacityRefreshInterval != current.Timeseries.CapacityRefreshInterval ||
+		newCfg.Timeseries.RestartStormThreshold != current.Timeseries.RestartStormThreshold ||
+		newCfg.Timeseries.RestartStormWindow != current.Timeseries.RestartStormWindow ||
+		newCfg.Timeseries.CollectorErrorLogInterval != current.Timeseries.CollectorErrorLogInterval ||
+		!reflect.DeepEqual(newCfg.Timeseries.NamespaceAllowList, current.Timeseries.NamespaceAllowList) ||
+		!reflect.DeepEqual(newCfg.Timeseries.NamespaceDenyList, current.Timeseries.NamespaceDenyList) ||
+		!reflect.DeepEqual(newCfg.Timeseries.CustomMetrics, current.Timeseries.CustomMetrics) ||
+		!reflect.DeepEqual(newCfg.Timeseries.ExternalMetrics, current.Timeseries.ExternalMetrics)
+	if timeseriesChanged {
+		next.Timeseries.TickInterval = newCfg.Timeseries.TickInterval
+		next.Timeseries.IntervalProfile = newCfg.Timeseries.IntervalProfile
+		next.Timeseries.CapacityRefreshInterval = newCfg.Timeseries.CapacityRefreshInterval
+		next.Timeseries.RestartStormThreshold = newCfg.Timeseries.RestartStormThreshold
+		next.Timeseries.RestartStormWindow = newCfg.Timeseries.RestartStormWindow
+		next.Timeseries.CollectorErrorLogInterval = newCfg.Timeseries.CollectorErrorLogInterval
+		next.Timeseries.NamespaceAllowList = newCfg.Timeseries.NamespaceAllowList
+		next.Timeseries.NamespaceDenyList = newCfg.Timeseries.NamespaceDenyList
+		next.Timeseries.CustomMetrics = newCfg.Timeseries.CustomMetrics
+		next.Timeseries.ExternalMetrics = newCfg.Timeseries.ExternalMetrics
+
+		if s.timeSeriesAggregator != nil {
+			s.timeSeriesAggregator.UpdateConfig(aggregatorConfigFromSettings(&next))
+		}
+		result.Applied = append(result.Applied, "timeseries")
+	}
+
+	// Query defaults are read on every timeseries request through
+	// currentConfig, so they switch atomically with the rest of the config
+	if !reflect.DeepEqual(newCfg.Timeseries.QueryDefaults, current.Timeseries.QueryDefaults) {
+		next.Timeseries.QueryDefaults = newCfg.Timeseries.QueryDefaults
+		result.Applied = append(result.Applied, "timeseries.query_defaults")
+	}
+
+	s.liveConfig.Store(&next)
+
+	s.logger.Info("Configuration reloaded",
+		zap.Strings("applied", result.Applied),
+		zap.Strings("rejected", result.Rejected))
+
+	return result
+}
+
+// handleReloadConfig handles POST /api/v1/admin/reload
+// @Summary Reload configuration
+// @Description Reloads the configuration file and applies hot-reloadable settings (log level, timeseries intervals, custom and external metric collectors and query defaults). Changes to settings that require a restart are reported as rejected.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} ReloadResult "Reload result"
+// @Failure 403 {object} map[string]interface{} "Admin permission required"
+// @Failure 500 {object} map[string]interface{} "Reload failed"
+// @Router /api/v1/admin/reload [post]
+func (s *Server) handleReloadConfig(w http.ResponseWriter, r *http.Request) {
+	if s.config.Security.AuthMode != "none" {
+		user, ok := auth.UserFromContext(r.Context())
+		if !ok || user == nil || !user.IsAdmin() {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":  "admin permission required",
+				"status": "error",
+			})
+			return
+		}
+	}
+
+	result, err := s.ReloadConfig()
+	if err != nil {
+		s.requestLogger(r).Error("Configuration reload failed", zap.Error(err))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":  err.Error(),
+			"status": "error",
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   result,
+		"status": "success",
+	})
+}
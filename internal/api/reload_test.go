@@ -0,0 +1,148 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/aaronlmathis/kaptn/internal/timeseries/aggregator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+const reloadTestConfig = `
+server:
+  addr: "0.0.0.0:9090"
+security:
+  auth_mode: "none"
+kubernetes:
+  mode: "kubeconfig"
+authz:
+  mode: "idp_groups"
+logging:
+  level: "debug"
+timeseries:
+  enabled: true
+  window: "60m"
+  tick_interval: "5s"
+  capacity_refresh_interval: "45s"
+  custom_metrics:
+    - metric: "http_requests_per_second"
+      kind: "Pod"
+      namespace: "shop"
+`
+
+func TestReloadConfigAppliesHotReloadableSettings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(reloadTestConfig), 0o600))
+
+	agg := aggregator.NewAggregator(
+		zap.NewNop(),
+		timeseries.NewMemStore(timeseries.DefaultConfig()),
+		fake.NewSimpleClientset(),
+		metricsfake.NewSimpleClientset().MetricsV1beta1(),
+		&rest.Config{},
+		aggregator.DefaultConfig(),
+	)
+
+	s := &Server{
+		logger: zap.NewNop(),
+		config: &config.Config{
+			Server:     config.ServerConfig{Addr: "0.0.0.0:8080", BasePath: "/"},
+			Security:   config.SecurityConfig{AuthMode: "none"},
+			Kubernetes: config.KubernetesConfig{Mode: "kubeconfig"},
+			Logging:    config.LoggingConfig{Level: "info"},
+			Timeseries: config.TimeseriesConfig{
+				Enabled:                 true,
+				Window:                  "60m",
+				TickInterval:            "1s",
+				CapacityRefreshInterval: "30s",
+			},
+		},
+		timeSeriesAggregator: agg,
+	}
+
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	s.EnableConfigReload(configPath, level)
+
+	result, err := s.ReloadConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, zapcore.DebugLevel, level.Level())
+	assert.Equal(t, 5*time.Second, agg.TickInterval())
+	assert.Equal(t, 45*time.Second, agg.Config().CapacityRefreshInterval)
+	require.Len(t, agg.Config().CustomMetrics, 1, "enabled collectors are reloaded")
+	assert.Equal(t, "http_requests_per_second", agg.Config().CustomMetrics[0].Metric)
+	assert.ElementsMatch(t, []string{"logging.level", "timeseries"}, result.Applied)
+	assert.Contains(t, result.Rejected, "server.addr")
+	assert.NotContains(t, result.Rejected, "timeseries.custom_metrics")
+
+	// Non-reloadable settings keep their running values; the startup
+	// configuration itself is never modified
+	current := s.currentConfig()
+	assert.Equal(t, "0.0.0.0:8080", current.Server.Addr)
+	assert.Equal(t, "5s", current.Timeseries.TickInterval)
+	assert.Equal(t, "debug", current.Logging.Level)
+	assert.Equal(t, "1s", s.config.Timeseries.TickInterval)
+}
+
+func TestReloadConfigConcurrentWithReaders(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(reloadTestConfig), 0o600))
+
+	s := &Server{logger: zap.NewNop(), config: &config.Config{Logging: config.LoggingConfig{Level: "info"}}}
+	s.EnableConfigReload(configPath, zap.NewAtomicLevelAt(zapcore.InfoLevel))
+
+	// Requests read the configuration while reloads swap it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			s.timeSeriesQueryDefaults("", "", "", []string{timeseries.ClusterCPUUsedCores})
+			_ = s.currentConfig().Timeseries.TickInterval
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		_, err := s.ReloadConfig()
+		require.NoError(t, err)
+	}
+	<-done
+	assert.Equal(t, "5s", s.currentConfig().Timeseries.TickInterval)
+}
+
+func TestReloadConfigRejectsInvalidConfiguration(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("kubernetes:\n  mode: \"bogus\"\n"), 0o600))
+
+	s := &Server{
+		logger: zap.NewNop(),
+		config: &config.Config{Logging: config.LoggingConfig{Level: "info"}},
+	}
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	s.EnableConfigReload(configPath, level)
+
+	_, err := s.ReloadConfig()
+	assert.Error(t, err)
+	assert.Equal(t, zapcore.InfoLevel, level.Level())
+}
+
+func TestAggregatorConfigFromSettingsIntervalProfile(t *testing.T) {
+	cfg := &config.Config{}
+	assert.Equal(t, aggregator.ProductionConfig().ResourcePollInterval, aggregatorConfigFromSettings(cfg).ResourcePollInterval)
+
+	cfg.Timeseries.IntervalProfile = "fast"
+	assert.Equal(t, aggregator.DefaultConfig().ResourcePollInterval, aggregatorConfigFromSettings(cfg).ResourcePollInterval)
+
+	// Explicit intervals override the profile
+	cfg.Timeseries.IntervalProfile = "production"
+	cfg.Timeseries.CapacityRefreshInterval = "2m"
+	assert.Equal(t, 2*time.Minute, aggregatorConfigFromSettings(cfg).CapacityRefreshInterval)
+}
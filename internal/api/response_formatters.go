@@ -2,10 +2,12 @@ package api
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/aaronlmathis/kaptn/internal/k8s/metrics"
 	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"github.com/aaronlmathis/kaptn/internal/k8s/units"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
@@ -61,12 +63,8 @@ func (s *Server) nodeToSummary(node *v1.Node) map[string]interface{} {
 	}
 }
 
-// nodeToEnrichedResponse converts a Kubernetes node to enriched response format with maintenance alerts
-func (s *Server) nodeToEnrichedResponse(node *v1.Node) map[string]interface{} {
-	// Calculate age
-	age := calculateAge(node.CreationTimestamp.Time)
-
-	// Extract node roles from labels
+// nodeRoles returns the roles of a node from its node-role labels
+func nodeRoles(node *v1.Node) []string {
 	roles := []string{}
 	if _, isMaster := node.Labels["node-role.kubernetes.io/master"]; isMaster {
 		roles = append(roles, "master")
@@ -77,6 +75,37 @@ func (s *Server) nodeToEnrichedResponse(node *v1.Node) map[string]interface{} {
 	if len(roles) == 0 {
 		roles = append(roles, "worker")
 	}
+	return roles
+}
+
+// nodeToTableResponse converts a node to the columns of the node table,
+// skipping the condition, taint and alert analysis of nodeToEnrichedResponse
+func (s *Server) nodeToTableResponse(node *v1.Node) map[string]interface{} {
+	ready := false
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			ready = condition.Status == v1.ConditionTrue
+			break
+		}
+	}
+
+	return map[string]interface{}{
+		"name":        node.Name,
+		"roles":       nodeRoles(node),
+		"ready":       ready,
+		"version":     node.Status.NodeInfo.KubeletVersion,
+		"age":         calculateAge(node.CreationTimestamp.Time),
+		"schedulable": !node.Spec.Unschedulable,
+	}
+}
+
+// nodeToEnrichedResponse converts a Kubernetes node to enriched response format with maintenance alerts
+func (s *Server) nodeToEnrichedResponse(node *v1.Node) map[string]interface{} {
+	// Calculate age
+	age := calculateAge(node.CreationTimestamp.Time)
+
+	// Extract node roles from labels
+	roles := nodeRoles(node)
 
 	// Analyze node status and conditions
 	ready := false
@@ -186,8 +215,10 @@ func (s *Server) nodeToEnrichedResponse(node *v1.Node) map[string]interface{} {
 				"allocatable": cpuAllocatable.String(),
 			},
 			"memory": map[string]interface{}{
-				"capacity":    memCapacity.String(),
-				"allocatable": memAllocatable.String(),
+				"capacity":         units.HumanizeQuantityBytes(memCapacity),
+				"allocatable":      units.HumanizeQuantityBytes(memAllocatable),
+				"capacityBytes":    units.ParseQuantityBytes(memCapacity),
+				"allocatableBytes": units.ParseQuantityBytes(memAllocatable),
 			},
 		}
 	}
@@ -292,6 +323,13 @@ func (s *Server) enhancedPodToSummary(pod *v1.Pod, podMetricsMap map[string]map[
 	// Get status reason
 	statusReason := getStatusReason(pod)
 
+	// Calculate uptime since last (re)start
+	now := time.Now()
+	containerUptimes, podUptime := calculatePodUptime(pod, now)
+
+	// Distinguish time since scheduling from time stuck unscheduled
+	scheduledAge, pendingDuration := calculatePodScheduling(pod, now)
+
 	// Get metrics if available
 	key := pod.Namespace + "/" + pod.Name
 	var cpuMetrics, memoryMetrics map[string]interface{}
@@ -322,6 +360,16 @@ func (s *Server) enhancedPodToSummary(pod *v1.Pod, podMetricsMap map[string]map[
 		"cpu":          cpuMetrics,
 		"memory":       memoryMetrics,
 		"statusReason": statusReason,
+		// Running but failing readiness, which the phase alone does not show
+		"runningNotReady": isRunningNotReady(pod),
+		"containers":      containerUptimes,
+		// Uptime since the most recent container (re)start; nil when no container is running
+		"uptimeSeconds": podUptime,
+		"uptime":        formatUptime(podUptime),
+		// Seconds since the pod was scheduled; nil until it has been
+		"scheduledAgeSeconds": scheduledAge,
+		// Seconds an unscheduled pod has waited since creation; nil once scheduled
+		"pendingDurationSeconds": pendingDuration,
 		// Additional fields for compatibility
 		"podIP":             pod.Status.PodIP,
 		"labels":            pod.Labels,
@@ -539,6 +587,12 @@ func (s *Server) serviceToResponse(service v1.Service) map[string]interface{} {
 		}
 	}
 
+	// Resolve backend readiness; ExternalName services have no endpoints
+	var endpoints *serviceEndpointHealth
+	if service.Spec.Type != v1.ServiceTypeExternalName {
+		endpoints = s.serviceEndpoints(&service)
+	}
+
 	return map[string]interface{}{
 		"name":              service.Name,
 		"namespace":         service.Namespace,
@@ -551,6 +605,8 @@ func (s *Server) serviceToResponse(service v1.Service) map[string]interface{} {
 		"labels":            service.Labels,
 		"annotations":       service.Annotations,
 		"creationTimestamp": service.CreationTimestamp.Time,
+		"endpoints":         endpoints,
+		"noReadyEndpoints":  endpoints != nil && endpoints.Ready == 0,
 	}
 }
 
@@ -602,6 +658,101 @@ func calculateAge(creationTime time.Time) string {
 	return fmt.Sprintf("%ds", int(duration.Seconds()))
 }
 
+// calculatePodUptime computes per-container uptime since the last (re)start and the
+// pod-level uptime, which is the minimum across running containers. Containers that
+// are not currently running report a nil uptime.
+func calculatePodUptime(pod *v1.Pod, now time.Time) ([]map[string]interface{}, *int64) {
+	containers := make([]map[string]interface{}, 0, len(pod.Status.ContainerStatuses))
+	var podUptime *int64
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		var uptime *int64
+		if running := containerStatus.State.Running; running != nil && !running.StartedAt.IsZero() {
+			seconds := int64(now.Sub(running.StartedAt.Time).Seconds())
+			if seconds < 0 {
+				seconds = 0
+			}
+			uptime = &seconds
+
+			if podUptime == nil || seconds < *podUptime {
+				podUptime = &seconds
+			}
+		}
+
+		containers = append(containers, map[string]interface{}{
+			"name":          containerStatus.Name,
+			"ready":         containerStatus.Ready,
+			"restartCount":  containerStatus.RestartCount,
+			"uptimeSeconds": uptime,
+			"uptime":        formatUptime(uptime),
+		})
+	}
+
+	return containers, podUptime
+}
+
+// isRunningNotReady reports whether a Running pod's Ready condition is false
+func isRunningNotReady(pod *v1.Pod) bool {
+	if pod.Status.Phase != v1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionFalse
+		}
+	}
+	return false
+}
+
+// calculatePodScheduling returns the seconds since the PodScheduled condition
+// became true, and for pods that have not been scheduled yet, the seconds since
+// creation they have been waiting. Each value is nil when it does not apply.
+func calculatePodScheduling(pod *v1.Pod, now time.Time) (scheduledAge *int64, pendingDuration *int64) {
+	secondsSince := func(t time.Time) *int64 {
+		seconds := int64(now.Sub(t).Seconds())
+		if seconds < 0 {
+			seconds = 0
+		}
+		return &seconds
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionTrue {
+			if condition.LastTransitionTime.IsZero() {
+				return nil, nil
+			}
+			return secondsSince(condition.LastTransitionTime.Time), nil
+		}
+	}
+
+	if pod.Spec.NodeName != "" || pod.Status.Phase != v1.PodPending || pod.CreationTimestamp.IsZero() {
+		return nil, nil
+	}
+	return nil, secondsSince(pod.CreationTimestamp.Time)
+}
+
+// formatUptime renders an uptime in seconds as a human-readable string
+func formatUptime(seconds *int64) *string {
+	if seconds == nil {
+		return nil
+	}
+
+	duration := time.Duration(*seconds) * time.Second
+	var formatted string
+	switch {
+	case duration >= 24*time.Hour:
+		formatted = fmt.Sprintf("%dd%dh", int(duration.Hours())/24, int(duration.Hours())%24)
+	case duration >= time.Hour:
+		formatted = fmt.Sprintf("%dh%dm", int(duration.Hours()), int(duration.Minutes())%60)
+	case duration >= time.Minute:
+		formatted = fmt.Sprintf("%dm%ds", int(duration.Minutes()), int(duration.Seconds())%60)
+	default:
+		formatted = fmt.Sprintf("%ds", int(duration.Seconds()))
+	}
+
+	return &formatted
+}
+
 // getStatusReason gets the reason for a pod's current status
 func getStatusReason(pod *v1.Pod) *string {
 	// Check for container states that indicate issues
@@ -817,29 +968,48 @@ func (s *Server) ingressToResponse(ingress interface{}) map[string]interface{} {
 		}
 	}
 
-	// Extract hosts and paths
+	// Normalize rules, TLS and backends so networking.k8s.io/v1 and
+	// extensions/v1beta1 ingresses produce the same shape
+	apiVersion, _ := ingressObj["apiVersion"].(string)
+	rules := normalizeIngressRules(spec)
+	tls := normalizeIngressTLS(spec)
+
+	// v1 uses spec.defaultBackend, v1beta1 uses spec.backend
+	var defaultBackend map[string]interface{}
+	if backend, ok := spec["defaultBackend"].(map[string]interface{}); ok {
+		defaultBackend = normalizeIngressBackend(backend)
+	} else if backend, ok := spec["backend"].(map[string]interface{}); ok {
+		defaultBackend = normalizeIngressBackend(backend)
+	}
+
+	// Extract hosts, paths and referenced backends
 	hosts := []string{}
 	paths := []string{}
+	backends := []string{}
+	seenBackends := make(map[string]bool)
+	addBackend := func(backend map[string]interface{}) {
+		if backend == nil {
+			return
+		}
+		display, _ := backend["display"].(string)
+		if display != "" && !seenBackends[display] {
+			seenBackends[display] = true
+			backends = append(backends, display)
+		}
+	}
 
-	if rules, ok := spec["rules"].([]interface{}); ok {
-		for _, ruleInterface := range rules {
-			if rule, ok := ruleInterface.(map[string]interface{}); ok {
-				if host, ok := rule["host"].(string); ok && host != "" {
-					hosts = append(hosts, host)
-				}
-
-				if http, ok := rule["http"].(map[string]interface{}); ok {
-					if pathsArray, ok := http["paths"].([]interface{}); ok {
-						for _, pathInterface := range pathsArray {
-							if pathObj, ok := pathInterface.(map[string]interface{}); ok {
-								if pathStr, ok := pathObj["path"].(string); ok && pathStr != "" {
-									paths = append(paths, pathStr)
-								}
-							}
-						}
-					}
-				}
+	addBackend(defaultBackend)
+	for _, rule := range rules {
+		if host, ok := rule["host"].(string); ok && host != "" {
+			hosts = append(hosts, host)
+		}
+		rulePaths, _ := rule["paths"].([]map[string]interface{})
+		for _, path := range rulePaths {
+			if pathStr, ok := path["path"].(string); ok && pathStr != "" {
+				paths = append(paths, pathStr)
 			}
+			backend, _ := path["backend"].(map[string]interface{})
+			addBackend(backend)
 		}
 	}
 
@@ -890,6 +1060,11 @@ func (s *Server) ingressToResponse(ingress interface{}) map[string]interface{} {
 		"hosts":              hosts,
 		"hostsDisplay":       hostsDisplay,
 		"paths":              paths,
+		"rules":              rules,
+		"tls":                tls,
+		"defaultBackend":     defaultBackend,
+		"backends":           backends,
+		"apiVersion":         apiVersion,
 		"externalIPs":        externalIPs,
 		"externalIPsDisplay": externalIPsDisplay,
 		"creationTimestamp":  creationTimestamp,
@@ -898,6 +1073,132 @@ func (s *Server) ingressToResponse(ingress interface{}) map[string]interface{} {
 	}
 }
 
+// normalizeIngressRules flattens ingress rules into a version-independent shape
+func normalizeIngressRules(spec map[string]interface{}) []map[string]interface{} {
+	rules := []map[string]interface{}{}
+
+	rulesArray, _ := spec["rules"].([]interface{})
+	for _, ruleInterface := range rulesArray {
+		rule, ok := ruleInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		host, _ := rule["host"].(string)
+		paths := []map[string]interface{}{}
+
+		if http, ok := rule["http"].(map[string]interface{}); ok {
+			if pathsArray, ok := http["paths"].([]interface{}); ok {
+				for _, pathInterface := range pathsArray {
+					pathObj, ok := pathInterface.(map[string]interface{})
+					if !ok {
+						continue
+					}
+
+					path, _ := pathObj["path"].(string)
+					// pathType is required in v1 but absent in v1beta1
+					pathType, _ := pathObj["pathType"].(string)
+					if pathType == "" {
+						pathType = "ImplementationSpecific"
+					}
+
+					var backend map[string]interface{}
+					if backendObj, ok := pathObj["backend"].(map[string]interface{}); ok {
+						backend = normalizeIngressBackend(backendObj)
+					}
+
+					paths = append(paths, map[string]interface{}{
+						"path":     path,
+						"pathType": pathType,
+						"backend":  backend,
+					})
+				}
+			}
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"host":  host,
+			"paths": paths,
+		})
+	}
+
+	return rules
+}
+
+// normalizeIngressBackend converts a v1 (service.name/service.port) or
+// v1beta1 (serviceName/servicePort) backend into a common shape
+func normalizeIngressBackend(backend map[string]interface{}) map[string]interface{} {
+	var serviceName, servicePort string
+
+	if service, ok := backend["service"].(map[string]interface{}); ok {
+		serviceName, _ = service["name"].(string)
+		if port, ok := service["port"].(map[string]interface{}); ok {
+			if number, ok := port["number"]; ok && number != nil {
+				servicePort = fmt.Sprint(number)
+			} else if portName, ok := port["name"].(string); ok {
+				servicePort = portName
+			}
+		}
+	} else {
+		serviceName, _ = backend["serviceName"].(string)
+		if port, ok := backend["servicePort"]; ok && port != nil {
+			servicePort = fmt.Sprint(port)
+		}
+	}
+
+	normalized := map[string]interface{}{
+		"serviceName": serviceName,
+		"servicePort": servicePort,
+	}
+
+	// Resource backends have no service; surface the referenced kind/name instead
+	display := serviceName
+	if resource, ok := backend["resource"].(map[string]interface{}); ok && serviceName == "" {
+		kind, _ := resource["kind"].(string)
+		resourceName, _ := resource["name"].(string)
+		normalized["resource"] = map[string]interface{}{
+			"kind": kind,
+			"name": resourceName,
+		}
+		display = kind + "/" + resourceName
+	} else if servicePort != "" {
+		display = serviceName + ":" + servicePort
+	}
+	normalized["display"] = display
+
+	return normalized
+}
+
+// normalizeIngressTLS extracts TLS entries from an ingress spec
+func normalizeIngressTLS(spec map[string]interface{}) []map[string]interface{} {
+	tls := []map[string]interface{}{}
+
+	tlsArray, _ := spec["tls"].([]interface{})
+	for _, tlsInterface := range tlsArray {
+		tlsObj, ok := tlsInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		hosts := []string{}
+		if hostsArray, ok := tlsObj["hosts"].([]interface{}); ok {
+			for _, host := range hostsArray {
+				if hostStr, ok := host.(string); ok {
+					hosts = append(hosts, hostStr)
+				}
+			}
+		}
+		secretName, _ := tlsObj["secretName"].(string)
+
+		tls = append(tls, map[string]interface{}{
+			"hosts":      hosts,
+			"secretName": secretName,
+		})
+	}
+
+	return tls
+}
+
 // endpointsToResponse converts a Kubernetes endpoints to response format
 func (s *Server) endpointsToResponse(endpoint v1.Endpoints) map[string]interface{} {
 	age := calculateAge(endpoint.CreationTimestamp.Time)
@@ -1149,8 +1450,9 @@ func (s *Server) networkPolicyToResponse(networkPolicy networkingv1.NetworkPolic
 	}
 }
 
-// configMapToResponse converts a Kubernetes ConfigMap to response format
-func (s *Server) configMapToResponse(configMap v1.ConfigMap) map[string]interface{} {
+// configMapToResponse converts a Kubernetes ConfigMap to response format. The
+// redactor lists the keys whose values are hidden; pass nil to reveal them.
+func (s *Server) configMapToResponse(configMap v1.ConfigMap, redactor *configMapRedactor) map[string]interface{} {
 	age := calculateAge(configMap.CreationTimestamp.Time)
 
 	// Count data keys
@@ -1167,26 +1469,19 @@ func (s *Server) configMapToResponse(configMap v1.ConfigMap) map[string]interfac
 		dataSize += len(value)
 	}
 
-	// Format data size
-	dataSizeStr := "0 B"
-	if dataSize > 0 {
-		if dataSize < 1024 {
-			dataSizeStr = fmt.Sprintf("%d B", dataSize)
-		} else if dataSize < 1024*1024 {
-			dataSizeStr = fmt.Sprintf("%.1f KB", float64(dataSize)/1024)
-		} else {
-			dataSizeStr = fmt.Sprintf("%.1f MB", float64(dataSize)/(1024*1024))
-		}
-	}
+	dataSizeStr := units.HumanizeBytes(int64(dataSize))
 
 	// Get data keys for display
-	var dataKeys []string
+	var dataKeys, keyNames []string
 	for key := range configMap.Data {
 		dataKeys = append(dataKeys, key)
+		keyNames = append(keyNames, key)
 	}
 	for key := range configMap.BinaryData {
 		dataKeys = append(dataKeys, key+" (binary)")
+		keyNames = append(keyNames, key)
 	}
+	redactedKeys := redactor.redactedKeys(keyNames)
 
 	// Count labels and annotations
 	labelsCount := len(configMap.Labels)
@@ -1203,9 +1498,10 @@ func (s *Server) configMapToResponse(configMap v1.ConfigMap) map[string]interfac
 		"dataKeys":          dataKeys,
 		"labelsCount":       labelsCount,
 		"annotationsCount":  annotationsCount,
+		"redactedKeys":      redactedKeys,
 		"creationTimestamp": configMap.CreationTimestamp.Time,
 		"labels":            configMap.Labels,
-		"annotations":       configMap.Annotations,
+		"annotations":       redactor.redactAnnotations(configMap.Annotations, redactedKeys),
 	}
 }
 
@@ -1279,6 +1575,12 @@ func (s *Server) persistentVolumeToResponse(pv *v1.PersistentVolume) map[string]
 		volumeSource = "Local"
 	}
 
+	// Capacity in bytes for numeric sorting and utilization
+	capacityBytes := int64(0)
+	if storageQuantity, ok := pv.Spec.Capacity[v1.ResourceStorage]; ok {
+		capacityBytes = storageQuantity.Value()
+	}
+
 	// Count labels and annotations
 	labelsCount := len(pv.Labels)
 	annotationsCount := len(pv.Annotations)
@@ -1292,6 +1594,8 @@ func (s *Server) persistentVolumeToResponse(pv *v1.PersistentVolume) map[string]
 		"reclaimPolicy":      reclaimPolicy,
 		"status":             status,
 		"claim":              claimRef,
+		"boundClaim":         boundClaimForPV(pv, s.pvcIndexer()),
+		"capacityBytes":      capacityBytes,
 		"storageClass":       storageClass,
 		"volumeSource":       volumeSource,
 		"age":                age,
@@ -1443,6 +1747,7 @@ func (s *Server) storageClassToResponse(sc storagev1.StorageClass) map[string]in
 		"labelsCount":          labelsCount,
 		"annotationsCount":     annotationsCount,
 		"isDefault":            isDefault,
+		"usage":                s.storageClassUsageFromInformers(sc.Name),
 		"creationTimestamp":    sc.CreationTimestamp.Time,
 		"labels":               sc.Labels,
 		"annotations":          sc.Annotations,
@@ -1716,6 +2021,7 @@ func formatNamespaceSummary(namespace *v1.Namespace) map[string]interface{} {
 		"creationTimestamp": namespace.CreationTimestamp.Time,
 		"labels":            namespace.Labels,
 		"annotations":       namespace.Annotations,
+		"podSecurity":       resources.ParsePodSecurityLabels(namespace.Labels),
 	}
 }
 
@@ -1743,20 +2049,31 @@ func (s *Server) resourceQuotaToResponse(resourceQuota v1.ResourceQuota) map[str
 	if resourceQuota.Spec.Hard != nil {
 		for resourceName, quantity := range resourceQuota.Spec.Hard {
 			used := ""
+			// usedPercent drives utilization bars; it is nil until the quota
+			// controller has reported usage or when the hard limit is zero
+			var usedPercent interface{}
 			if resourceQuota.Status.Used != nil {
-				if usedQuantity, exists := resourceQuota.Status.Used[resourceName]; exists {
+				usedQuantity, exists := resourceQuota.Status.Used[resourceName]
+				if exists {
 					used = usedQuantity.String()
 				} else {
 					used = "0"
 				}
+				if !quantity.IsZero() {
+					usedPercent = float64(usedQuantity.MilliValue()) / float64(quantity.MilliValue()) * 100
+				}
 			}
 
 			hardLimits = append(hardLimits, map[string]interface{}{
-				"name":  string(resourceName),
-				"limit": quantity.String(),
-				"used":  used,
+				"name":        string(resourceName),
+				"limit":       quantity.String(),
+				"used":        used,
+				"usedPercent": usedPercent,
 			})
 		}
+		sort.Slice(hardLimits, func(i, j int) bool {
+			return hardLimits[i]["name"].(string) < hardLimits[j]["name"].(string)
+		})
 	}
 
 	if resourceQuota.Status.Used != nil {
@@ -1789,6 +2106,81 @@ func (s *Server) resourceQuotaToResponse(resourceQuota v1.ResourceQuota) map[str
 	}
 }
 
+// limitRangeToResponse converts a limit range to response format. Each limit
+// lists its constraints per resource, e.g. max["cpu"] = "2".
+func (s *Server) limitRangeToResponse(limitRange v1.LimitRange) map[string]interface{} {
+	age := "unknown"
+	if !limitRange.CreationTimestamp.IsZero() {
+		age = calculateAge(limitRange.CreationTimestamp.Time)
+	}
+
+	quantities := func(list v1.ResourceList) map[string]string {
+		result := make(map[string]string, len(list))
+		for resourceName, quantity := range list {
+			result[string(resourceName)] = quantity.String()
+		}
+		return result
+	}
+
+	limits := []map[string]interface{}{}
+	types := []string{}
+	for _, item := range limitRange.Spec.Limits {
+		limits = append(limits, map[string]interface{}{
+			"type":                 string(item.Type),
+			"max":                  quantities(item.Max),
+			"min":                  quantities(item.Min),
+			"default":              quantities(item.Default),
+			"defaultRequest":       quantities(item.DefaultRequest),
+			"maxLimitRequestRatio": quantities(item.MaxLimitRequestRatio),
+		})
+		types = append(types, string(item.Type))
+	}
+
+	return map[string]interface{}{
+		"id":                fmt.Sprintf("%s-%s", limitRange.Namespace, limitRange.Name), // For table sorting
+		"name":              limitRange.Name,
+		"namespace":         limitRange.Namespace,
+		"age":               age,
+		"limits":            limits,
+		"limitsCount":       len(limits),
+		"types":             types,
+		"labelsCount":       len(limitRange.Labels),
+		"annotationsCount":  len(limitRange.Annotations),
+		"creationTimestamp": limitRange.CreationTimestamp.Time,
+		"labels":            limitRange.Labels,
+		"annotations":       limitRange.Annotations,
+	}
+}
+
+// serviceAccountToResponse converts a service account to response format
+func (s *Server) serviceAccountToResponse(serviceAccount v1.ServiceAccount) map[string]interface{} {
+	age := "unknown"
+	if !serviceAccount.CreationTimestamp.IsZero() {
+		age = calculateAge(serviceAccount.CreationTimestamp.Time)
+	}
+
+	// Unset means the pod decides, which defaults to mounting the token
+	automountToken := true
+	if serviceAccount.AutomountServiceAccountToken != nil {
+		automountToken = *serviceAccount.AutomountServiceAccountToken
+	}
+
+	return map[string]interface{}{
+		"id":                    fmt.Sprintf("%s-%s", serviceAccount.Namespace, serviceAccount.Name), // For table sorting
+		"name":                  serviceAccount.Name,
+		"namespace":             serviceAccount.Namespace,
+		"age":                   age,
+		"secretsCount":          len(serviceAccount.Secrets),
+		"imagePullSecretsCount": len(serviceAccount.ImagePullSecrets),
+		"automountToken":        automountToken,
+		"labelsCount":           len(serviceAccount.Labels),
+		"annotationsCount":      len(serviceAccount.Annotations),
+		"creationTimestamp":     serviceAccount.CreationTimestamp.Time,
+		"labels":                serviceAccount.Labels,
+		"annotations":           serviceAccount.Annotations,
+	}
+}
+
 // apiResourceToResponse converts an API resource to response format
 func (s *Server) apiResourceToResponse(resource resources.APIResource) map[string]interface{} {
 	shortNamesStr := ""
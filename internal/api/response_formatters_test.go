@@ -0,0 +1,261 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func runningContainer(name string, startedAt time.Time) v1.ContainerStatus {
+	return v1.ContainerStatus{
+		Name:  name,
+		Ready: true,
+		State: v1.ContainerState{
+			Running: &v1.ContainerStateRunning{StartedAt: metav1.NewTime(startedAt)},
+		},
+	}
+}
+
+func TestCalculatePodUptimeFreshlyStarted(t *testing.T) {
+	now := time.Now()
+	pod := &v1.Pod{
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				runningContainer("app", now.Add(-5*time.Second)),
+			},
+		},
+	}
+
+	containers, podUptime := calculatePodUptime(pod, now)
+
+	require.NotNil(t, podUptime)
+	assert.Equal(t, int64(5), *podUptime)
+	require.Len(t, containers, 1)
+	assert.Equal(t, "5s", *containers[0]["uptime"].(*string))
+}
+
+func TestCalculatePodUptimeLongRunning(t *testing.T) {
+	now := time.Now()
+	pod := &v1.Pod{
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				runningContainer("app", now.Add(-50*time.Hour)),
+				runningContainer("sidecar", now.Add(-3*time.Hour)),
+				{
+					Name:         "crashing",
+					RestartCount: 4,
+					State: v1.ContainerState{
+						Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+				},
+			},
+		},
+	}
+
+	containers, podUptime := calculatePodUptime(pod, now)
+
+	require.NotNil(t, podUptime)
+	assert.Equal(t, int64(3*60*60), *podUptime, "pod uptime should be the minimum across running containers")
+	require.Len(t, containers, 3)
+	assert.Equal(t, "2d2h", *containers[0]["uptime"].(*string))
+	assert.Equal(t, "3h0m", *containers[1]["uptime"].(*string))
+	assert.Nil(t, containers[2]["uptimeSeconds"])
+	assert.Nil(t, containers[2]["uptime"])
+}
+
+func TestCalculatePodUptimeNoRunningContainers(t *testing.T) {
+	pod := &v1.Pod{
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "app", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			},
+		},
+	}
+
+	_, podUptime := calculatePodUptime(pod, time.Now())
+
+	assert.Nil(t, podUptime)
+	assert.Nil(t, formatUptime(podUptime))
+}
+
+func TestIngressToResponseNormalizesAPIVersions(t *testing.T) {
+	s := &Server{}
+
+	v1Ingress := map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata": map[string]interface{}{
+			"name":      "web",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"defaultBackend": map[string]interface{}{
+				"service": map[string]interface{}{
+					"name": "fallback",
+					"port": map[string]interface{}{"number": int64(8080)},
+				},
+			},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"host": "example.com",
+					"http": map[string]interface{}{
+						"paths": []interface{}{
+							map[string]interface{}{
+								"path":     "/",
+								"pathType": "ImplementationSpecific",
+								"backend": map[string]interface{}{
+									"service": map[string]interface{}{
+										"name": "web",
+										"port": map[string]interface{}{"number": int64(80)},
+									},
+								},
+							},
+							map[string]interface{}{
+								"path":     "/api",
+								"pathType": "ImplementationSpecific",
+								"backend": map[string]interface{}{
+									"service": map[string]interface{}{
+										"name": "api",
+										"port": map[string]interface{}{"name": "http"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"tls": []interface{}{
+				map[string]interface{}{
+					"hosts":      []interface{}{"example.com"},
+					"secretName": "web-tls",
+				},
+			},
+		},
+	}
+
+	v1beta1Ingress := map[string]interface{}{
+		"apiVersion": "extensions/v1beta1",
+		"kind":       "Ingress",
+		"metadata": map[string]interface{}{
+			"name":      "web",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"backend": map[string]interface{}{
+				"serviceName": "fallback",
+				"servicePort": int64(8080),
+			},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"host": "example.com",
+					"http": map[string]interface{}{
+						"paths": []interface{}{
+							map[string]interface{}{
+								"path": "/",
+								"backend": map[string]interface{}{
+									"serviceName": "web",
+									"servicePort": int64(80),
+								},
+							},
+							map[string]interface{}{
+								"path": "/api",
+								"backend": map[string]interface{}{
+									"serviceName": "api",
+									"servicePort": "http",
+								},
+							},
+						},
+					},
+				},
+			},
+			"tls": []interface{}{
+				map[string]interface{}{
+					"hosts":      []interface{}{"example.com"},
+					"secretName": "web-tls",
+				},
+			},
+		},
+	}
+
+	v1Response := s.ingressToResponse(v1Ingress)
+	v1beta1Response := s.ingressToResponse(v1beta1Ingress)
+
+	assert.Equal(t, "networking.k8s.io/v1", v1Response["apiVersion"])
+	assert.Equal(t, "extensions/v1beta1", v1beta1Response["apiVersion"])
+
+	for _, field := range []string{"hosts", "paths", "rules", "tls", "defaultBackend", "backends"} {
+		assert.Equal(t, v1Response[field], v1beta1Response[field], "field %s should match across API versions", field)
+	}
+
+	assert.Equal(t, []string{"fallback:8080", "web:80", "api:http"}, v1Response["backends"])
+	rules := v1beta1Response["rules"].([]map[string]interface{})
+	require.Len(t, rules, 1)
+	paths := rules[0]["paths"].([]map[string]interface{})
+	require.Len(t, paths, 2)
+	assert.Equal(t, "web", paths[0]["backend"].(map[string]interface{})["serviceName"])
+}
+
+func TestCalculatePodSchedulingScheduledPod(t *testing.T) {
+	now := time.Now()
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-10 * time.Minute))},
+		Spec:       v1.PodSpec{NodeName: "node-1"},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodScheduled, Status: v1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-4 * time.Minute))},
+			},
+		},
+	}
+
+	scheduledAge, pendingDuration := calculatePodScheduling(pod, now)
+
+	require.NotNil(t, scheduledAge)
+	assert.Equal(t, int64(4*60), *scheduledAge)
+	assert.Nil(t, pendingDuration)
+}
+
+func TestCalculatePodSchedulingLongPendingPod(t *testing.T) {
+	now := time.Now()
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-3 * time.Hour))},
+		Status: v1.PodStatus{
+			Phase: v1.PodPending,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: "Unschedulable", LastTransitionTime: metav1.NewTime(now.Add(-3 * time.Hour))},
+			},
+		},
+	}
+
+	scheduledAge, pendingDuration := calculatePodScheduling(pod, now)
+
+	assert.Nil(t, scheduledAge)
+	require.NotNil(t, pendingDuration)
+	assert.Equal(t, int64(3*60*60), *pendingDuration)
+
+	summary := (&Server{}).enhancedPodToSummary(pod, nil)
+	assert.Nil(t, summary["scheduledAgeSeconds"])
+	assert.GreaterOrEqual(t, *summary["pendingDurationSeconds"].(*int64), int64(3*60*60))
+}
+
+func TestEnhancedPodToSummaryRunningNotReady(t *testing.T) {
+	readyPod := func(status v1.ConditionStatus) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", CreationTimestamp: metav1.Now()},
+			Status: v1.PodStatus{
+				Phase:      v1.PodRunning,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}},
+			},
+		}
+	}
+
+	ready := (&Server{}).enhancedPodToSummary(readyPod(v1.ConditionTrue), nil)
+	assert.Equal(t, false, ready["runningNotReady"])
+
+	notReady := (&Server{}).enhancedPodToSummary(readyPod(v1.ConditionFalse), nil)
+	assert.Equal(t, true, notReady["runningNotReady"])
+}
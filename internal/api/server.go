@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aaronlmathis/kaptn/internal/analytics"
@@ -14,8 +16,10 @@ import (
 	"github.com/aaronlmathis/kaptn/internal/config"
 	"github.com/aaronlmathis/kaptn/internal/k8s"
 	"github.com/aaronlmathis/kaptn/internal/k8s/actions"
+	"github.com/aaronlmathis/kaptn/internal/k8s/analysis"
 	"github.com/aaronlmathis/kaptn/internal/k8s/client"
 	"github.com/aaronlmathis/kaptn/internal/k8s/exec"
+	"github.com/aaronlmathis/kaptn/internal/k8s/images"
 	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
 	"github.com/aaronlmathis/kaptn/internal/k8s/logs"
 	"github.com/aaronlmathis/kaptn/internal/k8s/metrics"
@@ -23,6 +27,8 @@ import (
 	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
 	"github.com/aaronlmathis/kaptn/internal/k8s/summaries"
 	"github.com/aaronlmathis/kaptn/internal/k8s/ws"
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/aaronlmathis/kaptn/internal/logging"
 	apimiddleware "github.com/aaronlmathis/kaptn/internal/middleware"
 	"github.com/aaronlmathis/kaptn/internal/timeseries"
 	"github.com/aaronlmathis/kaptn/internal/timeseries/aggregator"
@@ -36,6 +42,9 @@ import (
 	metricsv1beta1typed "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
 )
 
+// defaultRequestTimeout bounds every request that is not a long-lived stream
+const defaultRequestTimeout = 60 * time.Second
+
 // Server represents the API server
 type Server struct {
 	logger               *zap.Logger
@@ -50,8 +59,16 @@ type Server struct {
 	logsService          *logs.StreamManager
 	execService          *exec.ExecManager
 	metricsService       *metrics.MetricsService
+	apiMetricsAdapter    *kubemetrics.APIMetricsAdapter
 	overviewService      *overview.OverviewService
 	resourceManager      *resources.ResourceManager
+	orphanFinder         *analysis.OrphanFinder
+	imageScanner         images.ImageScanner
+	imageScanTimeout     time.Duration
+	requestTimeout       time.Duration // Deadline for non-streaming requests
+	imageInventory       *images.InventoryCache
+	podUsageWait         time.Duration
+	podUsageCache        podUsageCache
 	analyticsService     *analytics.AnalyticsService
 	summaryService       *summaries.SummaryService
 	resourceCache        *cache.ResourceCache
@@ -65,6 +82,15 @@ type Server struct {
 	timeSeriesAggregator *aggregator.Aggregator
 	timeSeriesWSManager  *TimeSeriesWSManager
 	capabilityService    *authz.CapabilityService
+	clientRateLimiter    *apimiddleware.ClientRateLimiter
+
+	// Runtime configuration reload. config stays as loaded at startup;
+	// liveConfig holds a copy with reloaded settings applied and is swapped
+	// whole on every reload.
+	reloadMu   sync.Mutex
+	configPath string
+	logLevel   *zap.AtomicLevel
+	liveConfig atomic.Pointer[config.Config]
 }
 
 // NewServer creates a new API server
@@ -93,6 +119,7 @@ func NewServer(logger *zap.Logger, cfg *config.Config) (*Server, error) {
 
 	// Initialize actions service
 	s.actionsService = actions.NewNodeActionsService(s.kubeClient, s.logger)
+	s.actionsService.SetNodeGetter(s.informerManager.GetNode)
 
 	// Set WebSocket broadcaster for job progress streaming
 	s.actionsService.SetWebSocketBroadcaster(s.wsHub)
@@ -168,6 +195,7 @@ func (s *Server) initKubernetesClient() error {
 		metricsInterface = metricsClient.MetricsV1beta1()
 	}
 	s.metricsService = metrics.NewMetricsService(s.logger, s.kubeClient, metricsInterface)
+	s.apiMetricsAdapter = kubemetrics.NewAPIMetricsAdapter(s.logger, s.kubeClient, metricsInterface)
 
 	// Initialize overview service
 	s.overviewService = overview.NewOverviewService(s.logger, s.kubeClient, s.metricsService)
@@ -175,6 +203,17 @@ func (s *Server) initKubernetesClient() error {
 
 	// Initialize resource manager
 	s.resourceManager = resources.NewResourceManager(s.logger, s.kubeClient, s.clientFactory.DynamicClient())
+	s.resourceManager.SetMutationAnnotations(s.config.Features.AnnotateMutations)
+	s.resourceManager.SetScaleHistoryLimit(s.config.Features.ScaleHistoryLimit)
+	// Serve read-only node lookups, such as exports, from the informer cache
+	s.resourceManager.SetNodeGetter(s.informerManager.GetNode)
+
+	// Initialize orphaned resource detection
+	s.orphanFinder = analysis.NewOrphanFinder(s.logger, s.kubeClient)
+
+	// Initialize optional image vulnerability lookups
+	s.initImageScanner()
+	s.imageInventory = images.NewInventoryCache(images.DefaultInventoryTTL)
 
 	// Initialize analytics service
 	if err := s.initAnalytics(); err != nil {
@@ -199,6 +238,16 @@ func (s *Server) initInformers() error {
 
 	s.informerManager = informers.NewManager(s.logger, s.kubeClient, s.dynamicClient)
 
+	// Record per-kind object counts, service endpoint readiness, PVC phases
+	// and workload replica availability from the informer caches
+	if s.timeSeriesAggregator != nil {
+		s.timeSeriesAggregator.SetObjectCounter(s.informerManager)
+		s.timeSeriesAggregator.SetPodLister(s.informerManager)
+		s.timeSeriesAggregator.SetEndpointSliceLister(s.informerManager)
+		s.timeSeriesAggregator.SetPersistentVolumeClaimLister(s.informerManager)
+		s.timeSeriesAggregator.SetWorkloadLister(s.informerManager)
+	}
+
 	// Add event handlers
 	nodeHandler := informers.NewNodeEventHandler(s.logger, s.wsHub)
 	s.informerManager.AddNodeEventHandler(nodeHandler)
@@ -394,6 +443,22 @@ func (s *Server) initAuth() error {
 	// Initialize authentication middleware
 	s.authMiddleware = auth.NewMiddleware(s.logger, authMode, s.oidcClient, s.sessionManager, authzResolver, s.config.Security.UsernameFormat)
 
+	// Static bearer tokens are configured explicitly rather than discovered
+	if authMode == auth.AuthModeToken {
+		tokens := make([]auth.StaticToken, 0, len(s.config.Security.Tokens))
+		for _, token := range s.config.Security.Tokens {
+			tokens = append(tokens, auth.StaticToken{
+				Token:  token.Token,
+				UserID: token.UserID,
+				Email:  token.Email,
+				Name:   token.Name,
+				Groups: token.Groups,
+			})
+		}
+		s.authMiddleware.SetAuthenticator(auth.NewStaticTokenAuthenticator(tokens))
+		s.logger.Info("Static token authentication initialized", zap.Int("tokens", len(tokens)))
+	}
+
 	// Set authentication middleware on WebSocket hub
 	s.wsHub.SetAuthMiddleware(s.authMiddleware)
 
@@ -510,6 +575,26 @@ func (s *Server) initTimeSeries() error {
 		}
 	}
 
+	if s.config.Timeseries.SelfMetricsWindow != "" {
+		if window, err := time.ParseDuration(s.config.Timeseries.SelfMetricsWindow); err == nil {
+			timeseriesConfig.SelfMetricsWindow = window
+		}
+	}
+
+	if aggregation, ok := timeseries.ParseReducer(s.config.Timeseries.LoRes.Aggregation); ok {
+		timeseriesConfig.Aggregation = aggregation
+	}
+	timeseriesConfig.LoResAggregates = s.config.Timeseries.LoRes.Aggregates
+	if s.config.Timeseries.MedRes.Step != "" {
+		if step, err := time.ParseDuration(s.config.Timeseries.MedRes.Step); err == nil {
+			timeseriesConfig.MedResStep = step
+		}
+	}
+	timeseriesConfig.MedResPoints = s.config.Timeseries.MedRes.Points
+	if reducer, ok := timeseries.ParseReducer(s.config.Timeseries.MedRes.Reducer); ok {
+		timeseriesConfig.MedResReducer = reducer
+	}
+
 	// Apply additional timeseries configuration from YAML
 	if s.config.Timeseries.MaxSeries > 0 {
 		timeseriesConfig.MaxSeries = s.config.Timeseries.MaxSeries
@@ -521,7 +606,8 @@ func (s *Server) initTimeSeries() error {
 		timeseriesConfig.MaxWSClients = s.config.Timeseries.MaxWSClients
 	}
 
-	s.timeSeriesStore = timeseries.NewMemStore(timeseriesConfig)
+	s.timeSeriesStore = s.newTimeSeriesStore(timeseriesConfig)
+	s.actionsService.SetTimeSeriesStore(s.timeSeriesStore)
 
 	// Initialize TimeSeries WebSocket manager
 	s.timeSeriesWSManager = newTimeSeriesWSManager()
@@ -533,19 +619,7 @@ func (s *Server) initTimeSeries() error {
 	}
 
 	// Create aggregator configuration
-	aggregatorConfig := aggregator.DefaultConfig()
-	if s.config.Timeseries.TickInterval != "" {
-		if interval, err := time.ParseDuration(s.config.Timeseries.TickInterval); err == nil {
-			aggregatorConfig.TickInterval = interval
-		}
-	}
-	if s.config.Timeseries.CapacityRefreshInterval != "" {
-		if interval, err := time.ParseDuration(s.config.Timeseries.CapacityRefreshInterval); err == nil {
-			aggregatorConfig.CapacityRefreshInterval = interval
-		}
-	}
-	// Pass through TLS configuration from Kubernetes config
-	aggregatorConfig.InsecureTLS = s.config.Kubernetes.InsecureTLS
+	aggregatorConfig := aggregatorConfigFromSettings(s.config)
 
 	// Create timeseries aggregator
 	s.timeSeriesAggregator = aggregator.NewAggregator(
@@ -559,11 +633,75 @@ func (s *Server) initTimeSeries() error {
 
 	s.logger.Info("TimeSeries service initialized",
 		zap.Duration("window", timeseriesConfig.MaxWindow),
+		zap.Duration("selfMetricsWindow", timeseriesConfig.SelfMetricsWindow),
+		zap.Duration("medResStep", timeseriesConfig.MedResStep),
+		zap.Int("medResPoints", timeseriesConfig.MedResPoints),
 		zap.Duration("tickInterval", aggregatorConfig.TickInterval))
 
 	return nil
 }
 
+// aggregatorConfigFromSettings builds the aggregator configuration from the
+// timeseries section of the server configuration, starting from the
+// production intervals unless the fast profile is selected
+func aggregatorConfigFromSettings(cfg *config.Config) aggregator.Config {
+	aggregatorConfig := aggregator.ProductionConfig()
+	if cfg.Timeseries.IntervalProfile == "fast" {
+		aggregatorConfig = aggregator.DefaultConfig()
+	}
+	if cfg.Timeseries.TickInterval != "" {
+		if interval, err := time.ParseDuration(cfg.Timeseries.TickInterval); err == nil {
+			aggregatorConfig.TickInterval = interval
+		}
+	}
+	if cfg.Timeseries.CapacityRefreshInterval != "" {
+		if interval, err := time.ParseDuration(cfg.Timeseries.CapacityRefreshInterval); err == nil {
+			aggregatorConfig.CapacityRefreshInterval = interval
+		}
+	}
+	if cfg.Timeseries.RestartStormThreshold > 0 {
+		aggregatorConfig.RestartStormThreshold = cfg.Timeseries.RestartStormThreshold
+	}
+	if cfg.Timeseries.RestartStormWindow != "" {
+		if window, err := time.ParseDuration(cfg.Timeseries.RestartStormWindow); err == nil {
+			aggregatorConfig.RestartStormWindow = window
+		}
+	}
+	if cfg.Timeseries.CollectorErrorLogInterval != "" {
+		if interval, err := time.ParseDuration(cfg.Timeseries.CollectorErrorLogInterval); err == nil {
+			aggregatorConfig.ErrorLogInterval = interval
+		}
+	}
+	aggregatorConfig.NamespaceAllowList = cfg.Timeseries.NamespaceAllowList
+	aggregatorConfig.NamespaceDenyList = cfg.Timeseries.NamespaceDenyList
+	for _, metric := range cfg.Timeseries.CustomMetrics {
+		aggregatorConfig.CustomMetrics = append(aggregatorConfig.CustomMetrics, kubemetrics.CustomMetricQuery{
+			Metric:    metric.Metric,
+			Kind:      metric.Kind,
+			Group:     metric.Group,
+			Namespace: metric.Namespace,
+			Selector:  metric.Selector,
+		})
+	}
+	for _, metric := range cfg.Timeseries.ExternalMetrics {
+		aggregatorConfig.ExternalMetrics = append(aggregatorConfig.ExternalMetrics, kubemetrics.ExternalMetricQuery{
+			Metric:    metric.Metric,
+			Namespace: metric.Namespace,
+			Selector:  metric.Selector,
+		})
+	}
+	// Pass through TLS configuration from Kubernetes config
+	aggregatorConfig.InsecureTLS = cfg.Kubernetes.InsecureTLS
+	aggregatorConfig.SummaryAPI = kubemetrics.SummaryAPIConfig{
+		Mode:   cfg.Kubernetes.KubeletSummary.Mode,
+		Scheme: cfg.Kubernetes.KubeletSummary.Scheme,
+		Port:   cfg.Kubernetes.KubeletSummary.Port,
+		Path:   cfg.Kubernetes.KubeletSummary.Path,
+	}
+
+	return aggregatorConfig
+}
+
 // Start starts the server components
 func (s *Server) Start(ctx context.Context) error {
 	// Start WebSocket hub
@@ -628,6 +766,10 @@ func (s *Server) Stop() {
 	if s.wsHub != nil {
 		s.wsHub.Stop()
 	}
+
+	if s.clientRateLimiter != nil {
+		s.clientRateLimiter.Stop()
+	}
 }
 
 // Handler returns the HTTP handler
@@ -635,6 +777,11 @@ func (s *Server) Handler() http.Handler {
 	return s.router
 }
 
+// requestLogger returns the logger scoped to the request, tagged with its request ID
+func (s *Server) requestLogger(r *http.Request) *zap.Logger {
+	return logging.FromContext(r.Context(), s.logger)
+}
+
 // requestContextMiddleware adds the HTTP request to the context for audit logging
 func (s *Server) requestContextMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -653,12 +800,13 @@ func (s *Server) requestContextMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// webSocketAwareTimeout applies timeout middleware but skips WebSocket upgrade requests
+// webSocketAwareTimeout applies timeout middleware but skips WebSocket upgrades
+// and other streaming requests, such as watches and namespace backups
 func (s *Server) webSocketAwareTimeout(timeout time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Skip timeout for WebSocket upgrade requests
-			if r.Header.Get("Upgrade") == "websocket" {
+			// Skip timeout for long-lived streaming requests
+			if apimiddleware.IsStreamingRequest(r) {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -670,13 +818,17 @@ func (s *Server) webSocketAwareTimeout(timeout time.Duration) func(http.Handler)
 }
 
 func (s *Server) setupMiddleware() {
-	s.router.Use(middleware.RequestID)
-	s.router.Use(apimiddleware.RequestIDResponseMiddleware) // Add request ID to response headers
+	s.router.Use(apimiddleware.TracingMiddleware(s.logger)) // Assign request ID and request-scoped logger
 	s.router.Use(s.requestContextMiddleware)                // Add request to context for audit logging
+	s.router.Use(apimiddleware.PeerAddress)                 // Record the peer address before RealIP rewrites it
 	s.router.Use(middleware.RealIP)
 	s.router.Use(middleware.Logger)
-	s.router.Use(middleware.Recoverer)
-	s.router.Use(s.webSocketAwareTimeout(60 * time.Second))
+	s.router.Use(apimiddleware.Recoverer(s.logger)) // Turn handler panics into logged 500s
+	requestTimeout := s.requestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = defaultRequestTimeout
+	}
+	s.router.Use(s.webSocketAwareTimeout(requestTimeout))
 
 	// Prometheus metrics middleware
 	s.router.Use(apimiddleware.PrometheusMiddleware)
@@ -687,6 +839,18 @@ func (s *Server) setupMiddleware() {
 	// Authentication middleware (always applied, handles different auth modes)
 	s.router.Use(s.authMiddleware.Authenticate)
 
+	// Per-client throttling, keyed by the authenticated identity or client IP
+	s.clientRateLimiter = apimiddleware.NewClientRateLimiter(s.logger, apimiddleware.ClientRateLimitConfig{
+		ReadsPerMinute:  s.config.RateLimits.ReadsPerMinute,
+		ReadBurst:       s.config.RateLimits.ReadBurst,
+		WritesPerMinute: s.config.RateLimits.WritesPerMinute,
+		WriteBurst:      s.config.RateLimits.WriteBurst,
+		ExemptPaths:     apimiddleware.DefaultClientRateLimitExemptPaths,
+		TrustedProxies:  s.config.RateLimits.TrustedProxies,
+	})
+	s.clientRateLimiter.StartCleanup(10 * time.Minute)
+	s.router.Use(s.clientRateLimiter.Middleware)
+
 	// Impersonation middleware (adds impersonated K8s clients to context)
 	s.router.Use(s.ImpersonationMiddleware)
 
@@ -737,6 +901,7 @@ func (s *Server) setupRoutes() {
 
 	// Prometheus metrics endpoint
 	s.router.Handle("/metrics", promhttp.Handler())
+	s.router.Get("/metrics/timeseries", s.handleTimeseriesExport)
 
 	// // OAuth callback route - redirect to test page with parameters
 	// s.router.Get("/callback", func(w http.ResponseWriter, r *http.Request) {
@@ -794,6 +959,7 @@ func (s *Server) setupRoutes() {
 			// Phase 8: Admin Utilities & Observability
 			r.Post("/admin/authz/reload", s.handleBindingsReload) // Force reload bindings store
 			r.Get("/admin/authz/sar", s.handleGenericSAR)         // Generic SAR runner for debugging
+			r.Post("/admin/reload", s.handleReloadConfig)         // Reload hot-reloadable configuration
 		})
 
 		// Permission checking endpoints for UI gating (Phase 6)
@@ -836,7 +1002,7 @@ func (s *Server) setupRoutes() {
 			r.Get("/capabilities", s.handleGetCapabilities)
 
 			// Search endpoints
-			r.Get("/search", s.handleSearch)
+			r.Get("/search", s.handleGlobalSearch)
 			r.Get("/search/stats", s.handleSearchStats)
 			r.Post("/search/refresh", s.handleRefreshSearchCache)
 
@@ -844,6 +1010,7 @@ func (s *Server) setupRoutes() {
 			r.Get("/timeseries/cluster", s.handleGetClusterTimeSeries)
 			r.Get("/timeseries/health", s.handleTimeSeriesHealth)
 			r.Get("/timeseries/capabilities", s.handleGetTimeSeriesCapabilities)
+			r.Get("/timeseries/restart-storm", s.handleGetRestartStorm)
 
 			// Entity discovery endpoints for timeseries
 			r.Get("/timeseries/entities/nodes", s.handleGetTimeSeriesNodes)
@@ -860,12 +1027,27 @@ func (s *Server) setupRoutes() {
 
 			r.Get("/nodes", s.handleListNodes)
 			r.Get("/nodes/{name}", s.handleGetNode)
+			r.Get("/nodes/{name}/drain-simulation", s.handleGetDrainSimulation)
+			r.Get("/analysis/orphans", s.handleGetOrphans)
+			r.Get("/analysis/age-distribution", s.handleGetAgeDistribution)
+			r.Get("/storage/problems", s.handleGetStorageProblems)
+			r.Get("/recently-deleted", s.handleGetRecentlyDeleted)
+			r.Get("/compare", s.handleCompareAcrossNamespaces)
+			r.Get("/{kind}/{namespace}/{name}/diff-last-applied", s.handleDiffLastApplied)
+			r.Get("/{kind}/{namespace}/{name}/relationships", s.handleGetRelationships)
+			r.Get("/{kind}/{namespace}/{name}/events", s.handleGetObjectEvents)
 			r.Get("/pods", s.handleListPods)
 			r.Get("/pods/{namespace}/{name}", s.handleGetPod)
+			r.Get("/pods/{namespace}/{name}/containers/{container}/storage", s.handleGetContainerStorage)
+			r.Get("/pods/{namespace}/{name}/recommendation", s.handleGetPodRecommendation)
+			r.Get("/images", s.handleListImages)
 			r.Get("/deployments", s.handleListDeployments)
 			r.Get("/deployments/{namespace}/{name}", s.handleGetDeployment)
+			r.Get("/deployments/{namespace}/{name}/rollout-status", s.handleGetDeploymentRolloutStatus)
+			r.Get("/deployments/{namespace}/{name}/history", s.handleRolloutHistory)
 			r.Get("/statefulsets", s.handleListStatefulSets)
 			r.Get("/statefulsets/{namespace}/{name}", s.handleGetStatefulSet)
+			r.Get("/statefulsets/{namespace}/{name}/rollout-status", s.handleGetStatefulSetRolloutStatus)
 			r.Get("/replicasets", s.handleListReplicaSets)
 			r.Get("/replicasets/{namespace}/{name}", s.handleGetReplicaSet)
 			r.Get("/daemonsets", s.handleListDaemonSets)
@@ -880,8 +1062,12 @@ func (s *Server) setupRoutes() {
 
 			r.Get("/metrics", s.handleGetMetrics)
 			r.Get("/metrics/namespace/{namespace}", s.handleGetNamespaceMetrics)
+			r.Get("/top/pods", s.handleTopPods)
+			r.Get("/top/nodes", s.handleTopNodes)
 			r.Get("/namespaces", s.handleListNamespaces)
 			r.Get("/namespaces/{name}", s.handleGetNamespace)
+			r.Get("/namespaces/{name}/usage-history", s.handleGetNamespaceUsageHistory)
+			r.Get("/namespaces/{name}/backup", s.handleNamespaceBackup)
 			r.Get("/services", s.handleListServices)
 			r.Get("/services/{namespace}", s.handleListServicesInNamespace)
 			r.Get("/services/{namespace}/{name}", s.handleGetService)
@@ -915,6 +1101,10 @@ func (s *Server) setupRoutes() {
 			r.Get("/cluster-role-bindings", s.handleListClusterRoleBindings)
 			r.Get("/cluster-role-bindings/{name}", s.handleGetClusterRoleBinding)
 			r.Get("/identities", s.handleListRBACIdentities)
+			r.Get("/rbac/who-can", s.handleAccessReview)
+			r.Get("/rbac/effective", s.handleEffectiveRBAC)
+			r.Get("/service-accounts", s.handleListServiceAccounts)
+			r.Get("/service-accounts/{namespace}/{name}", s.handleGetServiceAccount)
 			r.Get("/persistent-volumes", s.handleListPersistentVolumes)
 			r.Get("/persistent-volumes/{name}", s.handleGetPersistentVolume)
 			r.Get("/persistent-volume-claims", s.handleListPersistentVolumeClaims)
@@ -929,13 +1119,22 @@ func (s *Server) setupRoutes() {
 			r.Get("/volume-snapshot-classes/{name}", s.handleGetVolumeSnapshotClass)
 			r.Get("/resource-quotas", s.handleListResourceQuotas)
 			r.Get("/resource-quotas/{namespace}/{name}", s.handleGetResourceQuota)
+			r.Get("/resourcequotas", s.handleListResourceQuotas)
+			r.Get("/resourcequotas/{namespace}/{name}", s.handleGetResourceQuota)
+			r.Get("/limit-ranges", s.handleListLimitRanges)
+			r.Get("/limit-ranges/{namespace}/{name}", s.handleGetLimitRange)
+			r.Get("/limitranges", s.handleListLimitRanges)
+			r.Get("/limitranges/{namespace}/{name}", s.handleGetLimitRange)
 			r.Get("/api-resources", s.handleListAPIResources)
 			r.Get("/api-resources/{name}", s.handleGetAPIResource)
 			r.Get("/crds", s.handleListCustomResourceDefinitions)
 			r.Get("/crds/{name}", s.handleGetCustomResourceDefinition)
 			r.Get("/export/{namespace}/{kind}/{name}", s.handleExportResource)
 			r.Get("/export/{kind}/{name}", s.handleExportClusterScopedResource)
+			r.Post("/export", s.handleExportResources)
+			r.Post("/resources/diff", s.handleDiffResource)
 			r.Get("/pods/{namespace}/{podName}/logs", s.handleGetPodLogs)
+			r.Get("/pods/{namespace}/{podName}/logs/stream", s.handlePodLogsStream)
 
 			// Analytics endpoints
 			r.Get("/analytics/visitors", s.handleGetVisitors)
@@ -963,9 +1162,16 @@ func (s *Server) setupRoutes() {
 			r.Get("/stream/jobs/{jobId}", s.handleJobWebSocket)
 			r.Get("/stream/logs/{streamId}", s.handleLogsWebSocket)
 
+			// Resource watch streams backed by the informer cache
+			for _, resource := range s.watchResources() {
+				r.Get("/"+resource+"/watch", s.handleWatchResource(resource))
+			}
+			r.Get("/resources/{group}/{version}/{resource}", s.handleKubeStyleResource)
+
 			// TimeSeries WebSocket endpoints
 			r.Get("/timeseries/live", s.handleTimeSeriesLiveWebSocket)
 			r.Get("/timeseries/cluster/live", s.handleClusterTimeSeriesLiveWebSocket)
+			r.Get("/timeseries/cluster/sse", s.handleClusterTimeSeriesSSE)
 		})
 
 		// Write endpoints (require write permissions)
@@ -974,6 +1180,9 @@ func (s *Server) setupRoutes() {
 				r.Use(s.authMiddleware.RequireAuth)
 				r.Use(s.authMiddleware.RequireWrite)
 			}
+			if s.config.ReadOnly {
+				r.Use(apimiddleware.ReadOnly(s.logger, apimiddleware.DefaultReadOnlyExemptRoutes))
+			}
 			r.Use(s.authMiddleware.RateLimit(s.config.RateLimits.ActionsPerMinute))
 
 			// Add idempotency middleware for state-changing operations
@@ -990,11 +1199,17 @@ func (s *Server) setupRoutes() {
 
 			// M5: Advanced write endpoints
 			r.Post("/scale", s.handleScaleResource)
+			r.Post("/{kind}/{namespace}/{name}/restart", s.handleRolloutRestart)
+			r.Patch("/{kind}/{namespace}/{name}/metadata", s.handlePatchMetadata)
+			r.Post("/deployments/{namespace}/{name}/undo", s.handleRolloutUndo)
 			r.Delete("/resources", s.handleDeleteResource)
+			r.Post("/pods/delete-by-selector", s.handleDeletePodsBySelector)
 			r.Delete("/resource-quotas/{namespace}/{name}", s.handleDeleteResourceQuota)
 			r.Post("/namespaces", s.handleCreateNamespace)
 			r.Delete("/namespaces/{namespace}", s.handleDeleteNamespace)
+			r.Post("/namespaces/{name}/pod-security", s.handleSetNamespacePodSecurity)
 			r.Get("/exec/{sessionId}", s.handleExecWebSocket)
+			r.Get("/pods/{namespace}/{podName}/exec", s.handlePodExecWebSocket)
 			r.Post("/logs/stream", s.handleStartLogStream)
 			r.Delete("/logs/stream/{streamId}", s.handleStopLogStream)
 
@@ -1015,6 +1230,9 @@ func (s *Server) setupRoutes() {
 				r.Use(s.authMiddleware.RequireAuth)
 				r.Use(s.authMiddleware.RequireWrite)
 			}
+			if s.config.ReadOnly {
+				r.Use(apimiddleware.ReadOnly(s.logger, nil))
+			}
 			r.Use(s.authMiddleware.RateLimit(s.config.RateLimits.ApplyPerMinute))
 
 			// Add idempotency middleware for apply operations
@@ -1027,6 +1245,8 @@ func (s *Server) setupRoutes() {
 
 			// Enhanced apply endpoint for Apply Config drawer
 			r.Post("/apply", s.handleApplyConfig)
+			// Multi-document YAML apply through the resource manager
+			r.Post("/resources/apply", s.handlePostApply)
 			// Existing namespace-specific apply endpoint
 			r.Post("/namespaces/{namespace}/apply", s.handleApplyYAML)
 		})
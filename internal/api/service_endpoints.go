@@ -0,0 +1,97 @@
+package api
+
+import (
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// serviceEndpointHealth counts the backend addresses of a service by readiness
+type serviceEndpointHealth struct {
+	Ready    int `json:"ready"`
+	NotReady int `json:"notReady"`
+	Total    int `json:"total"`
+}
+
+// serviceEndpoints resolves a service's backend addresses from the informer
+// cache, preferring EndpointSlices and falling back to the Endpoints object.
+// It returns nil when the informers are not available.
+func (s *Server) serviceEndpoints(service *v1.Service) *serviceEndpointHealth {
+	if s.informerManager == nil {
+		return nil
+	}
+
+	var slices []*discoveryv1.EndpointSlice
+	objs, err := s.informerManager.GetEndpointSliceLister().ByIndex(cache.NamespaceIndex, service.Namespace)
+	if err == nil {
+		for _, obj := range objs {
+			slice, ok := obj.(*discoveryv1.EndpointSlice)
+			if ok && slice.Labels[discoveryv1.LabelServiceName] == service.Name {
+				slices = append(slices, slice)
+			}
+		}
+	}
+	if len(slices) > 0 {
+		return endpointHealthFromSlices(slices)
+	}
+
+	obj, exists, err := s.informerManager.GetEndpointLister().GetByKey(service.Namespace + "/" + service.Name)
+	if err != nil || !exists {
+		return &serviceEndpointHealth{}
+	}
+	endpoints, ok := obj.(*v1.Endpoints)
+	if !ok {
+		return &serviceEndpointHealth{}
+	}
+	return endpointHealthFromEndpoints(endpoints)
+}
+
+// endpointHealthFromSlices counts the endpoints of a service's slices. The
+// same backend can appear in several slices (e.g. one per IP family), so
+// endpoints are deduplicated by target, counting a backend ready if any of
+// its entries is ready. A nil Ready condition means ready, per the API.
+func endpointHealthFromSlices(slices []*discoveryv1.EndpointSlice) *serviceEndpointHealth {
+	ready := make(map[string]bool)
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			key := endpointKey(endpoint)
+			if key == "" {
+				continue
+			}
+			isReady := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+			ready[key] = ready[key] || isReady
+		}
+	}
+
+	health := &serviceEndpointHealth{Total: len(ready)}
+	for _, isReady := range ready {
+		if isReady {
+			health.Ready++
+		} else {
+			health.NotReady++
+		}
+	}
+	return health
+}
+
+// endpointKey identifies the backend behind a slice endpoint
+func endpointKey(endpoint discoveryv1.Endpoint) string {
+	if ref := endpoint.TargetRef; ref != nil && ref.Name != "" {
+		return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+	}
+	if len(endpoint.Addresses) > 0 {
+		return endpoint.Addresses[0]
+	}
+	return ""
+}
+
+// endpointHealthFromEndpoints counts ready and not-ready addresses of an Endpoints object
+func endpointHealthFromEndpoints(endpoints *v1.Endpoints) *serviceEndpointHealth {
+	health := &serviceEndpointHealth{}
+	for _, subset := range endpoints.Subsets {
+		health.Ready += len(subset.Addresses)
+		health.NotReady += len(subset.NotReadyAddresses)
+	}
+	health.Total = health.Ready + health.NotReady
+	return health
+}
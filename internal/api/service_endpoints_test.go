@@ -0,0 +1,100 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newServiceEndpointsTestServer(t *testing.T, objects ...interface{}) *Server {
+	t.Helper()
+
+	manager := informers.NewManager(zap.NewNop(), fake.NewSimpleClientset(), nil)
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *discoveryv1.EndpointSlice:
+			require.NoError(t, manager.EndpointSlicesInformer.GetIndexer().Add(o))
+		case *v1.Endpoints:
+			require.NoError(t, manager.EndpointsInformer.GetIndexer().Add(o))
+		}
+	}
+	return &Server{logger: zap.NewNop(), informerManager: manager}
+}
+
+func sliceEndpoint(pod string, ready bool, address string) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses:  []string{address},
+		Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		TargetRef:  &v1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: pod},
+	}
+}
+
+func TestServiceToResponseMixedEndpointReadiness(t *testing.T) {
+	s := newServiceEndpointsTestServer(t,
+		&discoveryv1.EndpointSlice{
+			ObjectMeta:  metav1.ObjectMeta{Name: "web-ipv4", Namespace: "shop", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				sliceEndpoint("web-1", true, "10.0.0.1"),
+				sliceEndpoint("web-2", true, "10.0.0.2"),
+				sliceEndpoint("web-3", false, "10.0.0.3"),
+			},
+		},
+		// The IPv6 slice lists the same pods and must not double count them
+		&discoveryv1.EndpointSlice{
+			ObjectMeta:  metav1.ObjectMeta{Name: "web-ipv6", Namespace: "shop", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}},
+			AddressType: discoveryv1.AddressTypeIPv6,
+			Endpoints: []discoveryv1.Endpoint{
+				sliceEndpoint("web-1", true, "fd00::1"),
+				sliceEndpoint("web-3", false, "fd00::3"),
+			},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-abc", Namespace: "shop", Labels: map[string]string{discoveryv1.LabelServiceName: "api"}},
+			Endpoints:  []discoveryv1.Endpoint{sliceEndpoint("api-1", true, "10.0.1.1")},
+		},
+	)
+
+	response := s.serviceToResponse(v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}})
+
+	assert.Equal(t, &serviceEndpointHealth{Ready: 2, NotReady: 1, Total: 3}, response["endpoints"])
+	assert.Equal(t, false, response["noReadyEndpoints"])
+}
+
+func TestServiceToResponseNoReadyEndpoints(t *testing.T) {
+	s := newServiceEndpointsTestServer(t,
+		&v1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "shop"},
+			Subsets: []v1.EndpointSubset{{
+				NotReadyAddresses: []v1.EndpointAddress{{IP: "10.0.2.1"}, {IP: "10.0.2.2"}},
+			}},
+		},
+	)
+
+	withNotReady := s.serviceToResponse(v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "shop"}})
+	assert.Equal(t, &serviceEndpointHealth{NotReady: 2, Total: 2}, withNotReady["endpoints"])
+	assert.Equal(t, true, withNotReady["noReadyEndpoints"])
+
+	withoutBackends := s.serviceToResponse(v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "shop"}})
+	assert.Equal(t, &serviceEndpointHealth{}, withoutBackends["endpoints"])
+	assert.Equal(t, true, withoutBackends["noReadyEndpoints"])
+}
+
+func TestServiceToResponseExternalNameHasNoEndpoints(t *testing.T) {
+	s := newServiceEndpointsTestServer(t)
+
+	response := s.serviceToResponse(v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"},
+		Spec:       v1.ServiceSpec{Type: v1.ServiceTypeExternalName, ExternalName: "db.example.com"},
+	})
+
+	assert.Nil(t, response["endpoints"])
+	assert.Equal(t, false, response["noReadyEndpoints"])
+}
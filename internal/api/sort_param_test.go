@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func listedNames(t *testing.T, rec *httptest.ResponseRecorder) []string {
+	t.Helper()
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response struct {
+		Data struct {
+			Items []map[string]interface{} `json:"items"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	names := make([]string, 0, len(response.Data.Items))
+	for _, item := range response.Data.Items {
+		name, _ := item["name"].(string)
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestGetSortParam(t *testing.T) {
+	tests := map[string]string{
+		"/":                       "",
+		"/?sort=age":              "age",
+		"/?sortBy=age":            "age",
+		"/?sort=name&sortBy=age":  "name",
+		"/?sort=&sortBy=restarts": "restarts",
+	}
+	for target, want := range tests {
+		assert.Equal(t, want, getSortParam(httptest.NewRequest(http.MethodGet, target, nil)), target)
+	}
+}
+
+func TestListNodesAcceptsSortAndSortBy(t *testing.T) {
+	older := viewTestNode("a-older", v1.ConditionTrue)
+	newer := viewTestNode("b-newer", v1.ConditionTrue)
+	newer.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	s := newNodeListTestServer(t, older, newer)
+
+	for _, param := range []string{"sort", "sortBy"} {
+		rec := httptest.NewRecorder()
+		s.handleListNodes(rec, httptest.NewRequest(http.MethodGet, "/api/v1/nodes?view=table&"+param+"=age", nil))
+		assert.Equal(t, []string{"b-newer", "a-older"}, listedNames(t, rec), param)
+	}
+}
+
+func TestListPodsAcceptsSortAndSortBy(t *testing.T) {
+	s := newPodListTestServer(t,
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a-on-node-2", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-2"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "b-on-node-1", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-1"}},
+	)
+
+	for _, param := range []string{"sort", "sortBy"} {
+		rec := httptest.NewRecorder()
+		s.handleListPods(rec, httptest.NewRequest(http.MethodGet, "/api/v1/pods?"+param+"=node", nil))
+		assert.Equal(t, []string{"b-on-node-1", "a-on-node-2"}, listedNames(t, rec), param)
+	}
+}
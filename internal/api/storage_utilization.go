@@ -0,0 +1,116 @@
+package api
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// storageClassUsage summarizes the volumes and claims provisioned from a storage class
+type storageClassUsage struct {
+	PersistentVolumes      int     `json:"persistentVolumes"`
+	BoundPersistentVolumes int     `json:"boundPersistentVolumes"`
+	Claims                 int     `json:"claims"`
+	ProvisionedBytes       int64   `json:"provisionedBytes"`
+	RequestedBytes         int64   `json:"requestedBytes"`
+	BoundPercent           float64 `json:"boundPercent"`
+}
+
+// boundClaimForPV resolves the PVC referenced by the PV's claimRef from the
+// informer cache and reports how much of the volume the claim requests.
+// Returns nil when the PV is unclaimed or the claim is not cached.
+func boundClaimForPV(pv *v1.PersistentVolume, pvcIndexer cache.Indexer) map[string]interface{} {
+	if pv.Spec.ClaimRef == nil || pvcIndexer == nil {
+		return nil
+	}
+
+	obj, exists, err := pvcIndexer.GetByKey(pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name)
+	if err != nil || !exists {
+		return nil
+	}
+	pvc, ok := obj.(*v1.PersistentVolumeClaim)
+	if !ok {
+		return nil
+	}
+
+	// A recreated claim with the same name is not the one this volume was bound to
+	if pv.Spec.ClaimRef.UID != "" && pv.Spec.ClaimRef.UID != pvc.UID {
+		return nil
+	}
+
+	requestedBytes := int64(0)
+	if quantity, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+		requestedBytes = quantity.Value()
+	}
+
+	claim := map[string]interface{}{
+		"namespace":      pvc.Namespace,
+		"name":           pvc.Name,
+		"phase":          string(pvc.Status.Phase),
+		"bound":          pvc.Spec.VolumeName == pv.Name,
+		"requestedBytes": requestedBytes,
+	}
+
+	if capacity, ok := pv.Spec.Capacity[v1.ResourceStorage]; ok && capacity.Value() > 0 {
+		claim["requestedPercent"] = float64(requestedBytes) / float64(capacity.Value()) * 100
+	}
+
+	return claim
+}
+
+// computeStorageClassUsage counts the PVs and PVCs using a storage class and
+// the bytes provisioned and requested through it
+func computeStorageClassUsage(className string, pvObjs, pvcObjs []interface{}) storageClassUsage {
+	usage := storageClassUsage{}
+
+	for _, obj := range pvObjs {
+		pv, ok := obj.(*v1.PersistentVolume)
+		if !ok || pv.Spec.StorageClassName != className {
+			continue
+		}
+		usage.PersistentVolumes++
+		if pv.Status.Phase == v1.VolumeBound {
+			usage.BoundPersistentVolumes++
+		}
+		if capacity, ok := pv.Spec.Capacity[v1.ResourceStorage]; ok {
+			usage.ProvisionedBytes += capacity.Value()
+		}
+	}
+
+	for _, obj := range pvcObjs {
+		pvc, ok := obj.(*v1.PersistentVolumeClaim)
+		if !ok || pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != className {
+			continue
+		}
+		usage.Claims++
+		if request, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+			usage.RequestedBytes += request.Value()
+		}
+	}
+
+	if usage.PersistentVolumes > 0 {
+		usage.BoundPercent = float64(usage.BoundPersistentVolumes) / float64(usage.PersistentVolumes) * 100
+	}
+
+	return usage
+}
+
+// storageClassUsageFromInformers computes storage class usage from the informer
+// caches, returning nil when informers are not running
+func (s *Server) storageClassUsageFromInformers(className string) *storageClassUsage {
+	if s.informerManager == nil {
+		return nil
+	}
+
+	usage := computeStorageClassUsage(className,
+		s.informerManager.GetPersistentVolumeLister().List(),
+		s.informerManager.GetPersistentVolumeClaimLister().List())
+	return &usage
+}
+
+// pvcIndexer returns the PVC informer cache, or nil when informers are not running
+func (s *Server) pvcIndexer() cache.Indexer {
+	if s.informerManager == nil {
+		return nil
+	}
+	return s.informerManager.GetPersistentVolumeClaimLister()
+}
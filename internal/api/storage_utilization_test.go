@@ -0,0 +1,114 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func storageTestPV(name, class, capacity string, claim *v1.PersistentVolumeClaim) *v1.PersistentVolume {
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.PersistentVolumeSpec{
+			StorageClassName: class,
+			Capacity:         v1.ResourceList{v1.ResourceStorage: resource.MustParse(capacity)},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeAvailable},
+	}
+	if claim != nil {
+		pv.Spec.ClaimRef = &v1.ObjectReference{Namespace: claim.Namespace, Name: claim.Name, UID: claim.UID}
+		pv.Status.Phase = v1.VolumeBound
+	}
+	return pv
+}
+
+func storageTestPVC(name, class, request, volume string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name + "-uid")},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: &class,
+			VolumeName:       volume,
+			Resources: v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(request)},
+			},
+		},
+		Status: v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+	}
+}
+
+func newStorageTestServer(t *testing.T, objects ...interface{}) *Server {
+	t.Helper()
+
+	manager := informers.NewManager(zap.NewNop(), fake.NewSimpleClientset(), nil)
+	for _, obj := range objects {
+		switch typed := obj.(type) {
+		case *v1.PersistentVolume:
+			require.NoError(t, manager.PersistentVolumesInformer.GetIndexer().Add(typed))
+		case *v1.PersistentVolumeClaim:
+			require.NoError(t, manager.PersistentVolumeClaimsInformer.GetIndexer().Add(typed))
+		}
+	}
+
+	return &Server{logger: zap.NewNop(), informerManager: manager}
+}
+
+func TestPersistentVolumeResponseIncludesBoundClaim(t *testing.T) {
+	pvc := storageTestPVC("data", "fast", "5Gi", "pv-data")
+	pv := storageTestPV("pv-data", "fast", "10Gi", pvc)
+	s := newStorageTestServer(t, pv, pvc)
+
+	response := s.persistentVolumeToResponse(pv)
+
+	assert.Equal(t, int64(10*1024*1024*1024), response["capacityBytes"])
+	claim, ok := response["boundClaim"].(map[string]interface{})
+	require.True(t, ok, "expected boundClaim to be resolved from the informer cache")
+	assert.Equal(t, "default", claim["namespace"])
+	assert.Equal(t, "data", claim["name"])
+	assert.Equal(t, true, claim["bound"])
+	assert.Equal(t, int64(5*1024*1024*1024), claim["requestedBytes"])
+	assert.InDelta(t, 50.0, claim["requestedPercent"], 0.001)
+}
+
+func TestPersistentVolumeResponseIgnoresRecreatedClaim(t *testing.T) {
+	pvc := storageTestPVC("data", "fast", "5Gi", "pv-data")
+	pv := storageTestPV("pv-data", "fast", "10Gi", pvc)
+	pv.Spec.ClaimRef.UID = "previous-claim-uid"
+	s := newStorageTestServer(t, pv, pvc)
+
+	response := s.persistentVolumeToResponse(pv)
+
+	assert.Nil(t, response["boundClaim"])
+}
+
+func TestStorageClassResponseCountsUsage(t *testing.T) {
+	fastClaim := storageTestPVC("fast-claim", "fast", "8Gi", "pv-1")
+	pendingClaim := storageTestPVC("pending-claim", "fast", "2Gi", "")
+	slowClaim := storageTestPVC("slow-claim", "slow", "1Gi", "pv-3")
+
+	s := newStorageTestServer(t,
+		storageTestPV("pv-1", "fast", "10Gi", fastClaim),
+		storageTestPV("pv-2", "fast", "20Gi", nil),
+		storageTestPV("pv-3", "slow", "1Gi", slowClaim),
+		fastClaim, pendingClaim, slowClaim,
+	)
+
+	response := s.storageClassToResponse(storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fast"}})
+
+	usage, ok := response["usage"].(*storageClassUsage)
+	require.True(t, ok)
+	assert.Equal(t, 2, usage.PersistentVolumes)
+	assert.Equal(t, 1, usage.BoundPersistentVolumes)
+	assert.Equal(t, 2, usage.Claims)
+	assert.Equal(t, int64(30*1024*1024*1024), usage.ProvisionedBytes)
+	assert.Equal(t, int64(10*1024*1024*1024), usage.RequestedBytes)
+	assert.InDelta(t, 50.0, usage.BoundPercent, 0.001)
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+)
+
+// Built-in query defaults used when no configuration is loaded
+const (
+	defaultTimeSeriesResolution = "lo"
+	defaultTimeSeriesWindow     = "60m"
+)
+
+// timeSeriesQueryDefaults fills in res and since for a timeseries query that
+// omitted them. Precedence is the explicit parameter, then the longest
+// configured series prefix matching every requested key, then the global
+// default. keys are the requested series keys or metric bases; seriesParam,
+// when set, replaces them.
+func (s *Server) timeSeriesQueryDefaults(resParam, sinceParam, seriesParam string, keys []string) (string, string) {
+	if resParam != "" && sinceParam != "" {
+		return resParam, sinceParam
+	}
+
+	defaults := config.TimeseriesQueryDefaults{}
+	if cfg := s.currentConfig(); cfg != nil {
+		defaults = cfg.Timeseries.QueryDefaults
+	}
+
+	if seriesParam != "" {
+		keys = strings.Split(seriesParam, ",")
+		for i, key := range keys {
+			keys[i] = strings.TrimSpace(key)
+		}
+	}
+
+	prefixDefault, _ := commonPrefixDefault(defaults.ByPrefix, keys)
+
+	if resParam == "" {
+		resParam = firstNonEmpty(prefixDefault.Resolution, defaults.Resolution, defaultTimeSeriesResolution)
+	}
+	if sinceParam == "" {
+		sinceParam = firstNonEmpty(prefixDefault.Window, defaults.Window, defaultTimeSeriesWindow)
+	}
+	return resParam, sinceParam
+}
+
+// commonPrefixDefault returns the default of the longest prefix matching each
+// key, provided every key resolves to the same prefix. Mixed kinds, such as a
+// query spanning cluster and pod series, use the global default.
+func commonPrefixDefault(byPrefix map[string]config.TimeseriesQueryDefault, keys []string) (config.TimeseriesQueryDefault, bool) {
+	if len(byPrefix) == 0 || len(keys) == 0 {
+		return config.TimeseriesQueryDefault{}, false
+	}
+
+	matched := ""
+	for i, key := range keys {
+		longest := ""
+		for prefix := range byPrefix {
+			if strings.HasPrefix(key, prefix) && len(prefix) > len(longest) {
+				longest = prefix
+			}
+		}
+		if longest == "" || (i > 0 && longest != matched) {
+			return config.TimeseriesQueryDefault{}, false
+		}
+		matched = longest
+	}
+	return byPrefix[matched], true
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func queryDefaultsTestServer() *Server {
+	cfg := &config.Config{}
+	cfg.Timeseries.QueryDefaults = config.TimeseriesQueryDefaults{
+		Resolution: "lo",
+		Window:     "60m",
+		ByPrefix: map[string]config.TimeseriesQueryDefault{
+			"pod.":            {Resolution: "hi", Window: "15m"},
+			"pod.net.":        {Window: "5m"},
+			"cluster.":        {Window: "2h"},
+			"node.cpu.":       {Resolution: "hi"},
+			"ns.mem.used.":    {Window: "30m"},
+			"ns.mem.request.": {Window: "45m"},
+		},
+	}
+	return &Server{logger: zap.NewNop(), config: cfg}
+}
+
+func TestTimeSeriesQueryDefaultsPrecedence(t *testing.T) {
+	s := queryDefaultsTestServer()
+
+	tests := []struct {
+		name        string
+		res, since  string
+		seriesParam string
+		keys        []string
+		wantRes     string
+		wantSince   string
+	}{
+		{"explicit params win", "lo", "3h", "", []string{"pod.cpu.usage.cores"}, "lo", "3h"},
+		{"prefix default", "", "", "", []string{"pod.cpu.usage.cores", "pod.mem.working_set.bytes"}, "hi", "15m"},
+		{"explicit res keeps prefix window", "lo", "", "", []string{"pod.cpu.usage.cores"}, "lo", "15m"},
+		{"longest prefix wins, missing fields fall back to global", "", "", "", []string{"pod.net.rx.bytes"}, "lo", "5m"},
+		{"series param replaces the default keys", "", "", "cluster.cpu.used.cores", timeseries.GetPodMetricBases(), "lo", "2h"},
+		{"mixed kinds use the global default", "", "", "", []string{"pod.cpu.usage.cores", "cluster.cpu.used.cores"}, "lo", "60m"},
+		{"unmatched keys use the global default", "", "", "", []string{"ctr.cpu.usage.cores"}, "lo", "60m"},
+		{"different prefixes of one kind use the global default", "", "", "", []string{"ns.mem.used.bytes", "ns.mem.request.bytes"}, "lo", "60m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, since := s.timeSeriesQueryDefaults(tt.res, tt.since, tt.seriesParam, tt.keys)
+			assert.Equal(t, tt.wantRes, res)
+			assert.Equal(t, tt.wantSince, since)
+		})
+	}
+}
+
+func TestTimeSeriesQueryDefaultsWithoutConfig(t *testing.T) {
+	s := &Server{logger: zap.NewNop()}
+	res, since := s.timeSeriesQueryDefaults("", "", "", []string{"pod.cpu.usage.cores"})
+	assert.Equal(t, "lo", res)
+	assert.Equal(t, "60m", since)
+}
+
+func TestPodsTimeSeriesOmittedParamsUsePrefixDefaults(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Timeseries.QueryDefaults = config.TimeseriesQueryDefaults{
+		Resolution: "lo",
+		Window:     "60m",
+		ByPrefix:   map[string]config.TimeseriesQueryDefault{"pod.": {Resolution: "hi", Window: "15m"}},
+	}
+	s := &Server{logger: zap.NewNop(), config: cfg, timeSeriesStore: timeseries.NewMemStore(timeseries.DefaultConfig())}
+
+	rec := httptest.NewRecorder()
+	s.handleGetPodsTimeSeries(rec, httptest.NewRequest(http.MethodGet, "/api/v1/timeseries/pods", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response TimeSeriesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.NotNil(t, response.Metadata)
+	assert.Equal(t, "hi", response.Metadata.Resolution)
+	assert.Equal(t, "15m", response.Metadata.TimeSpan)
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/aaronlmathis/kaptn/internal/timeseries/aggregator"
+	"go.uber.org/zap"
+)
+
+// newTimeSeriesStore creates the timeseries store, restoring it from the
+// configured snapshot file when one exists. An unreadable or incompatible
+// snapshot is logged and discarded, so the store starts empty.
+func (s *Server) newTimeSeriesStore(config timeseries.Config) *timeseries.MemStore {
+	// Restored series keep the reducer they are created with, so the
+	// aggregator's reducers are registered before the snapshot is read
+	store := timeseries.NewMemStore(config)
+	aggregator.RegisterAggregations(store)
+
+	path := s.config.Timeseries.SnapshotPath
+	if path == "" {
+		return store
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			s.logger.Warn("Failed to open timeseries snapshot", zap.String("path", path), zap.Error(err))
+		}
+		return store
+	}
+	defer file.Close()
+
+	if err := store.Restore(file); err != nil {
+		s.logger.Warn("Discarding timeseries snapshot", zap.String("path", path), zap.Error(err))
+		return store
+	}
+	s.logger.Info("Restored timeseries snapshot",
+		zap.String("path", path),
+		zap.Int("series", len(store.Keys())))
+	return store
+}
+
+// TimeSeriesSnapshotInterval returns how often the timeseries store should be
+// written to disk, or zero when persistence is disabled
+func (s *Server) TimeSeriesSnapshotInterval() time.Duration {
+	if s.timeSeriesStore == nil || s.config.Timeseries.SnapshotPath == "" {
+		return 0
+	}
+	interval, err := time.ParseDuration(s.config.Timeseries.SnapshotInterval)
+	if err != nil || interval <= 0 {
+		return 5 * time.Minute
+	}
+	return interval
+}
+
+// SaveTimeSeriesSnapshot writes the timeseries store to the configured
+// snapshot file. The snapshot is written to a temporary file first and renamed
+// into place, so a crash mid-write never leaves a truncated snapshot behind.
+func (s *Server) SaveTimeSeriesSnapshot() error {
+	path := s.config.Timeseries.SnapshotPath
+	if s.timeSeriesStore == nil || path == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := s.timeSeriesStore.Snapshot(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace snapshot file: %w", err)
+	}
+	return nil
+}
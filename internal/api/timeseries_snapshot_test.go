@@ -0,0 +1,58 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTimeSeriesSnapshotPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "timeseries.snapshot")
+	cfg := &config.Config{}
+	cfg.Timeseries.SnapshotPath = path
+	cfg.Timeseries.SnapshotInterval = "1m"
+
+	s := &Server{logger: zap.NewNop(), config: cfg}
+	s.timeSeriesStore = s.newTimeSeriesStore(timeseries.DefaultConfig())
+	assert.Empty(t, s.timeSeriesStore.Keys(), "a missing snapshot starts an empty store")
+	assert.Equal(t, time.Minute, s.TimeSeriesSnapshotInterval())
+
+	s.timeSeriesStore.Upsert(timeseries.ClusterNodesCount).Add(timeseries.NewPoint(time.Now(), 3))
+	require.NoError(t, s.SaveTimeSeriesSnapshot())
+
+	restarted := &Server{logger: zap.NewNop(), config: cfg}
+	restarted.timeSeriesStore = restarted.newTimeSeriesStore(timeseries.DefaultConfig())
+	point, ok := restarted.latestSeriesPoint(timeseries.ClusterNodesCount)
+	require.True(t, ok)
+	assert.Equal(t, 3.0, point.V)
+
+	// Restored series downsample with the aggregator's reducers, so a rate
+	// keeps the peak of each step rather than the default mean
+	start := time.Now().Truncate(time.Minute).Add(-30 * time.Minute)
+	s.timeSeriesStore.Upsert(timeseries.ClusterNetRxBps).Add(timeseries.NewPoint(start, 1))
+	require.NoError(t, s.SaveTimeSeriesSnapshot())
+	restarted.timeSeriesStore = restarted.newTimeSeriesStore(timeseries.DefaultConfig())
+	series, ok := restarted.timeSeriesStore.Get(timeseries.ClusterNetRxBps)
+	require.True(t, ok)
+	series.Add(timeseries.NewPoint(start.Add(time.Minute), 10))
+	series.Add(timeseries.NewPoint(start.Add(time.Minute+2*time.Second), 2))
+	series.Add(timeseries.NewPoint(start.Add(2*time.Minute), 0)) // Closes the step
+	lo := series.GetSince(start.Add(time.Second), timeseries.Lo)
+	require.NotEmpty(t, lo)
+	assert.Equal(t, 10.0, lo[len(lo)-1].V)
+
+	// A snapshot taken with another layout is discarded rather than failing startup
+	other := timeseries.DefaultConfig()
+	other.HiResStep = 2 * time.Second
+	assert.Empty(t, restarted.newTimeSeriesStore(other).Keys())
+
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o600))
+	assert.Empty(t, restarted.newTimeSeriesStore(timeseries.DefaultConfig()).Keys())
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"go.uber.org/zap"
+)
+
+// NewTLSConfig builds the TLS configuration of the HTTPS listener. The
+// certificate is served through a certReloader, so a rotated certificate and
+// key are picked up within seconds without a restart.
+func NewTLSConfig(cfg config.TLSConfig, logger *zap.Logger) (*tls.Config, error) {
+	minVersion, err := parseTLSVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+	reloader, err := newCertReloader(cfg.CertFile, cfg.KeyFile, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:     minVersion,
+		GetCertificate: reloader.GetCertificate,
+	}, nil
+}
+
+// parseTLSVersion maps a configured version such as "1.2" to its constant. An
+// empty version selects TLS 1.2.
+func parseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q", version)
+	}
+}
+
+// certCheckInterval is how often handshakes check the certificate files for
+// changes
+const certCheckInterval = 10 * time.Second
+
+// certReloader serves a certificate loaded from disk, reloading it when the
+// certificate or key file's modification time changes
+type certReloader struct {
+	certFile      string
+	keyFile       string
+	logger        *zap.Logger
+	checkInterval time.Duration
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	certTime time.Time
+	keyTime  time.Time
+
+	// checkMu guards the change checks. The file times of a failed reload
+	// are kept so a broken rotation is retried only once the files change
+	// again.
+	checkMu        sync.Mutex
+	lastCheck      time.Time
+	failedCertTime time.Time
+	failedKeyTime  time.Time
+}
+
+// newCertReloader loads the initial certificate, failing if it is unreadable
+func newCertReloader(certFile, keyFile string, logger *zap.Logger) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, logger: logger, checkInterval: certCheckInterval}
+	certTime, keyTime, err := r.modTimes()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(certTime, keyTime); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// modTimes returns the modification times of the certificate and key files
+func (r *certReloader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat TLS cert file: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat TLS key file: %w", err)
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
+
+// load reads the key pair and records the file times it was read at
+func (r *certReloader) load(certTime, keyTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.certTime = certTime
+	r.keyTime = keyTime
+	r.mu.Unlock()
+	return nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate. The files are checked
+// at most once per check interval. A certificate that fails to reload, for
+// example while only one of the files has been replaced, is logged once and
+// the previous certificate keeps being served until the files change again.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.reloadIfChanged()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// reloadIfChanged reloads the key pair when the files changed since the last
+// load and since the last failed attempt
+func (r *certReloader) reloadIfChanged() {
+	r.checkMu.Lock()
+	defer r.checkMu.Unlock()
+
+	now := time.Now()
+	if !r.lastCheck.IsZero() && now.Sub(r.lastCheck) < r.checkInterval {
+		return
+	}
+	r.lastCheck = now
+
+	certTime, keyTime, err := r.modTimes()
+	if err != nil {
+		return
+	}
+	r.mu.RLock()
+	changed := !certTime.Equal(r.certTime) || !keyTime.Equal(r.keyTime)
+	r.mu.RUnlock()
+	if !changed || (certTime.Equal(r.failedCertTime) && keyTime.Equal(r.failedKeyTime)) {
+		return
+	}
+
+	if err := r.load(certTime, keyTime); err != nil {
+		r.failedCertTime, r.failedKeyTime = certTime, keyTime
+		r.logger.Warn("Failed to reload TLS certificate", zap.Error(err))
+		return
+	}
+	r.failedCertTime, r.failedKeyTime = time.Time{}, time.Time{}
+	r.logger.Info("Reloaded TLS certificate", zap.String("certFile", r.certFile))
+}
+
+// HTTPSRedirectHandler redirects every request to the same path over HTTPS on
+// the port of httpsAddr
+func HTTPSRedirectHandler(httpsAddr string) http.Handler {
+	_, port, _ := net.SplitHostPort(httpsAddr)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.Trim(host, "[]")
+		if port != "" && port != "443" {
+			host = net.JoinHostPort(host, port)
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// writeTestCert writes a self-signed certificate for 127.0.0.1 with the given
+// serial number and returns it
+func writeTestCert(t *testing.T, certFile, keyFile string, serial int64) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "kaptn-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// startTLSServer serves ok over TLS with the given configuration and returns its URL
+func startTLSServer(t *testing.T, cfg config.TLSConfig) string {
+	t.Helper()
+	tlsConfig, err := NewTLSConfig(cfg, zap.NewNop())
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("ok"))
+		}),
+		TLSConfig: tlsConfig,
+		ErrorLog:  log.New(io.Discard, "", 0),
+	}
+	go server.ServeTLS(listener, "", "")
+	t.Cleanup(func() { server.Close() })
+	return "https://" + listener.Addr().String()
+}
+
+func tlsClient(roots *x509.CertPool, maxVersion uint16) *http.Client {
+	return &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: roots, MaxVersion: maxVersion},
+		},
+	}
+}
+
+func TestTLSServing(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	cert := writeTestCert(t, certFile, keyFile, 1)
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	url := startTLSServer(t, config.TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile, MinVersion: "1.3"})
+
+	resp, err := tlsClient(roots, 0).Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotNil(t, resp.TLS)
+	assert.Equal(t, uint16(tls.VersionTLS13), resp.TLS.Version)
+
+	_, err = tlsClient(roots, tls.VersionTLS12).Get(url)
+	assert.Error(t, err, "TLS 1.2 must be rejected when the minimum is 1.3")
+}
+
+func TestTLSCertificateReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	first := writeTestCert(t, certFile, keyFile, 1)
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	reloader, err := newCertReloader(certFile, keyFile, zap.New(core))
+	require.NoError(t, err)
+	reloader.checkInterval = 0
+	served, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.Raw, served.Certificate[0])
+
+	second := writeTestCert(t, certFile, keyFile, 2)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+
+	served, err = reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.Raw, served.Certificate[0])
+
+	// A broken key pair keeps the previous certificate
+	require.NoError(t, os.WriteFile(keyFile, []byte("garbage"), 0o600))
+	evenLater := later.Add(time.Minute)
+	require.NoError(t, os.Chtimes(keyFile, evenLater, evenLater))
+	served, err = reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.Raw, served.Certificate[0])
+
+	// The broken pair is not retried, or logged, until the files change again
+	_, err = reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, logs.FilterMessage("Failed to reload TLS certificate").Len())
+
+	third := writeTestCert(t, certFile, keyFile, 3)
+	latest := evenLater.Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, latest, latest))
+	require.NoError(t, os.Chtimes(keyFile, latest, latest))
+	served, err = reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, third.Raw, served.Certificate[0])
+}
+
+func TestTLSCertificateReloadCheckInterval(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	first := writeTestCert(t, certFile, keyFile, 1)
+
+	reloader, err := newCertReloader(certFile, keyFile, zap.NewNop())
+	require.NoError(t, err)
+	_, err = reloader.GetCertificate(nil)
+	require.NoError(t, err)
+
+	writeTestCert(t, certFile, keyFile, 2)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+
+	served, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.Raw, served.Certificate[0], "the files are not checked again within the interval")
+}
+
+func TestNewTLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeTestCert(t, certFile, keyFile, 1)
+
+	_, err := NewTLSConfig(config.TLSConfig{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.4"}, zap.NewNop())
+	assert.Error(t, err)
+
+	_, err = NewTLSConfig(config.TLSConfig{CertFile: filepath.Join(dir, "missing.crt"), KeyFile: keyFile}, zap.NewNop())
+	assert.Error(t, err)
+
+	tlsConfig, err := NewTLSConfig(config.TLSConfig{CertFile: certFile, KeyFile: keyFile}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+}
+
+func TestHTTPSRedirectHandler(t *testing.T) {
+	tests := []struct {
+		httpsAddr string
+		host      string
+		expected  string
+	}{
+		{":443", "kaptn.example.com", "https://kaptn.example.com/api/v1/pods?namespace=a"},
+		{"0.0.0.0:8443", "kaptn.example.com:8080", "https://kaptn.example.com:8443/api/v1/pods?namespace=a"},
+		{":443", "[::1]:80", "https://[::1]/api/v1/pods?namespace=a"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "http://"+tt.host+"/api/v1/pods?namespace=a", nil)
+		rec := httptest.NewRecorder()
+		HTTPSRedirectHandler(tt.httpsAddr).ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+		assert.Equal(t, tt.expected, rec.Header().Get("Location"))
+	}
+}
@@ -2,9 +2,16 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/aaronlmathis/kaptn/internal/auth"
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // Utility functions
@@ -14,6 +21,66 @@ func getUserFromContext(ctx context.Context) (*auth.User, bool) {
 	return auth.UserFromContext(ctx)
 }
 
+// mutationContext returns the request context annotated with the acting user so
+// mutations can record who made them
+func (s *Server) mutationContext(r *http.Request) context.Context {
+	user, ok := getUserFromContext(r.Context())
+	if !ok || user == nil {
+		return r.Context()
+	}
+
+	actor := user.Email
+	if actor == "" {
+		actor = user.ID
+	}
+	return resources.WithActor(r.Context(), actor)
+}
+
+// writeJSONError writes a {"error", "status": "error"} JSON response
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":  message,
+		"status": "error",
+	})
+}
+
+// unstructuredObject checks that an object returned by the resource manager is
+// an unstructured map and that each named field, when present, is an object.
+// Handlers report a failure as a 500 rather than panicking on the assertion.
+func unstructuredObject(obj interface{}, kind string, fields ...string) (map[string]interface{}, error) {
+	objMap, ok := obj.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("malformed %s: expected an object, got %T", kind, obj)
+	}
+	for _, field := range fields {
+		value, present := objMap[field]
+		if !present || value == nil {
+			continue
+		}
+		if _, ok := value.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("malformed %s: %s is %T, expected an object", kind, field, value)
+		}
+	}
+	return objMap, nil
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag,
+// using the weak comparison HTTP requires for If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
 // parseIntParam safely parses an integer parameter from a string
 func parseIntParam(param string, defaultValue int) int {
 	if param == "" {
@@ -64,6 +131,15 @@ func validateResourceName(name string) error {
 	return nil
 }
 
+// getSortParam returns the sort field of a list request. "sort" is the
+// canonical parameter; "sortBy" is accepted as an alias for older clients.
+func getSortParam(r *http.Request) string {
+	if sort := r.URL.Query().Get("sort"); sort != "" {
+		return sort
+	}
+	return r.URL.Query().Get("sortBy")
+}
+
 // getPaginationParams extracts pagination parameters from query string
 func getPaginationParams(page, limit string) (int, int, int) {
 	pageNum := parseIntParam(page, 1)
@@ -137,3 +213,54 @@ func sanitizeString(input string) string {
 	}
 	return result
 }
+
+// maxListPageLimit bounds the limit of a server-side page of a list
+const maxListPageLimit = 1000
+
+// listPageParams reads the limit and continue parameters of a server-side
+// page of a list. paged is false when neither is given.
+func listPageParams(r *http.Request) (limit int64, continueToken string, paged bool, err error) {
+	limitParam := r.URL.Query().Get("limit")
+	continueToken = r.URL.Query().Get("continue")
+	if limitParam == "" && continueToken == "" {
+		return 0, "", false, nil
+	}
+	if limitParam != "" {
+		limit, err = strconv.ParseInt(limitParam, 10, 64)
+		if err != nil || limit <= 0 || limit > maxListPageLimit {
+			return 0, "", false, fmt.Errorf("limit must be between 1 and %d", maxListPageLimit)
+		}
+	}
+	return limit, continueToken, true, nil
+}
+
+// listPageData is the response data of a server-side page: its items, the
+// limit it was listed with and the token for the next page
+func listPageData(items interface{}, limit int64, continueToken string, remainingItemCount *int64) map[string]interface{} {
+	data := map[string]interface{}{
+		"items":    items,
+		"limit":    limit,
+		"continue": continueToken,
+	}
+	if remainingItemCount != nil {
+		data["remainingItemCount"] = *remainingItemCount
+	}
+	return data
+}
+
+// writeListPageError writes the error of listing a server-side page: 410
+// when the continue token expired, 400 for selectors the API server rejected
+// and 500 otherwise
+func (s *Server) writeListPageError(w http.ResponseWriter, r *http.Request, resource, namespace string, err error) {
+	switch {
+	case apierrors.IsResourceExpired(err):
+		writeJSONError(w, http.StatusGone, "continue token expired, restart the listing without it")
+	case apierrors.IsBadRequest(err):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	default:
+		s.requestLogger(r).Error("Failed to list "+resource,
+			zap.String("namespace", namespace),
+			zap.Error(err))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+	}
+}
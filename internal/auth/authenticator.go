@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidToken is returned when a bearer token is present but not accepted
+var ErrInvalidToken = errors.New("invalid bearer token")
+
+// Authenticator resolves the identity of the caller making a request.
+// Implementations return a nil user and nil error when the request carries
+// no credentials they understand, and an error when credentials are present
+// but invalid.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*User, error)
+	Mode() AuthMode
+}
+
+// TokenVerifier verifies a bearer token and returns the identity it carries
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) (*User, error)
+}
+
+// NoneAuthenticator performs no authentication (development mode)
+type NoneAuthenticator struct{}
+
+// NewNoneAuthenticator creates an authenticator that never resolves an identity
+func NewNoneAuthenticator() *NoneAuthenticator {
+	return &NoneAuthenticator{}
+}
+
+// Authenticate always succeeds without an identity
+func (a *NoneAuthenticator) Authenticate(r *http.Request) (*User, error) {
+	return nil, nil
+}
+
+// Mode returns the auth mode implemented by this authenticator
+func (a *NoneAuthenticator) Mode() AuthMode {
+	return AuthModeNone
+}
+
+// HeaderAuthenticator trusts identity headers set by an upstream proxy
+type HeaderAuthenticator struct{}
+
+// NewHeaderAuthenticator creates an authenticator that reads X-User-* headers
+func NewHeaderAuthenticator() *HeaderAuthenticator {
+	return &HeaderAuthenticator{}
+}
+
+// Authenticate extracts the user from the request headers
+func (a *HeaderAuthenticator) Authenticate(r *http.Request) (*User, error) {
+	return userFromHeaders(r), nil
+}
+
+// Mode returns the auth mode implemented by this authenticator
+func (a *HeaderAuthenticator) Mode() AuthMode {
+	return AuthModeHeader
+}
+
+// StaticToken maps a pre-shared bearer token to an identity
+type StaticToken struct {
+	Token  string
+	UserID string
+	Email  string
+	Name   string
+	Groups []string
+}
+
+// StaticTokenAuthenticator validates bearer tokens against a fixed set
+type StaticTokenAuthenticator struct {
+	tokens []StaticToken
+}
+
+// NewStaticTokenAuthenticator creates an authenticator for the given tokens.
+// Entries with an empty token are ignored.
+func NewStaticTokenAuthenticator(tokens []StaticToken) *StaticTokenAuthenticator {
+	valid := make([]StaticToken, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Token != "" {
+			valid = append(valid, token)
+		}
+	}
+	return &StaticTokenAuthenticator{tokens: valid}
+}
+
+// Authenticate validates the bearer token on the request
+func (a *StaticTokenAuthenticator) Authenticate(r *http.Request) (*User, error) {
+	token := bearerToken(r)
+	if token == "" {
+		return nil, nil
+	}
+
+	// Compare against every entry so timing does not reveal which token matched
+	var match *StaticToken
+	for i := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(a.tokens[i].Token), []byte(token)) == 1 {
+			match = &a.tokens[i]
+		}
+	}
+	if match == nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &User{
+		ID:     match.UserID,
+		Email:  match.Email,
+		Name:   match.Name,
+		Groups: match.Groups,
+		Claims: map[string]interface{}{
+			"sub":    match.UserID,
+			"email":  match.Email,
+			"name":   match.Name,
+			"groups": match.Groups,
+		},
+	}, nil
+}
+
+// Mode returns the auth mode implemented by this authenticator
+func (a *StaticTokenAuthenticator) Mode() AuthMode {
+	return AuthModeToken
+}
+
+// OIDCAuthenticator validates bearer JWTs against the configured OIDC issuer
+type OIDCAuthenticator struct {
+	verifier TokenVerifier
+}
+
+// NewOIDCAuthenticator creates an authenticator backed by the given verifier,
+// typically an *OIDCClient
+func NewOIDCAuthenticator(verifier TokenVerifier) *OIDCAuthenticator {
+	return &OIDCAuthenticator{verifier: verifier}
+}
+
+// Authenticate verifies the bearer token on the request
+func (a *OIDCAuthenticator) Authenticate(r *http.Request) (*User, error) {
+	if a.verifier == nil {
+		return nil, nil // OIDC not configured
+	}
+
+	token := bearerToken(r)
+	if token == "" {
+		return nil, nil
+	}
+
+	return a.verifier.VerifyToken(r.Context(), token)
+}
+
+// Mode returns the auth mode implemented by this authenticator
+func (a *OIDCAuthenticator) Mode() AuthMode {
+	return AuthModeOIDC
+}
+
+// bearerToken extracts the bearer token from the Authorization header
+func bearerToken(r *http.Request) string {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return ""
+	}
+
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return ""
+	}
+
+	return strings.TrimSpace(parts[1])
+}
+
+// userFromHeaders extracts user information from request headers
+func userFromHeaders(r *http.Request) *User {
+	userID := r.Header.Get("X-User-ID")
+	email := r.Header.Get("X-User-Email")
+	name := r.Header.Get("X-User-Name")
+	groupsHeader := r.Header.Get("X-User-Groups")
+
+	if userID == "" && email == "" {
+		return nil
+	}
+
+	var groups []string
+	if groupsHeader != "" {
+		groups = strings.Split(groupsHeader, ",")
+		for i, group := range groups {
+			groups[i] = strings.TrimSpace(group)
+		}
+	}
+
+	return &User{
+		ID:     userID,
+		Email:  email,
+		Name:   name,
+		Groups: groups,
+		Claims: map[string]interface{}{
+			"sub":    userID,
+			"email":  email,
+			"name":   name,
+			"groups": groups,
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeVerifier struct {
+	valid string
+	user  *User
+}
+
+func (f *fakeVerifier) VerifyToken(ctx context.Context, token string) (*User, error) {
+	if token != f.valid {
+		return nil, errors.New("signature verification failed")
+	}
+	return f.user, nil
+}
+
+func requestWithBearer(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestStaticTokenAuthenticator(t *testing.T) {
+	authenticator := NewStaticTokenAuthenticator([]StaticToken{
+		{Token: "secret-1", UserID: "ci-bot", Groups: []string{"kaptn-viewers"}},
+		{Token: "", UserID: "ignored"},
+	})
+
+	user, err := authenticator.Authenticate(requestWithBearer("secret-1"))
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "ci-bot", user.ID)
+	assert.Equal(t, []string{"kaptn-viewers"}, user.Groups)
+
+	user, err = authenticator.Authenticate(requestWithBearer("wrong"))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.Nil(t, user)
+
+	user, err = authenticator.Authenticate(requestWithBearer(""))
+	assert.NoError(t, err)
+	assert.Nil(t, user, "requests without a token carry no identity")
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	authenticator := NewOIDCAuthenticator(&fakeVerifier{
+		valid: "good.jwt.token",
+		user:  &User{ID: "user-123", Email: "user@example.com"},
+	})
+
+	user, err := authenticator.Authenticate(requestWithBearer("good.jwt.token"))
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", user.ID)
+
+	_, err = authenticator.Authenticate(requestWithBearer("forged.jwt.token"))
+	assert.Error(t, err)
+
+	user, err = NewOIDCAuthenticator(nil).Authenticate(requestWithBearer("good.jwt.token"))
+	assert.NoError(t, err)
+	assert.Nil(t, user)
+}
+
+func TestMiddlewareTokenModePropagatesIdentity(t *testing.T) {
+	m := NewMiddleware(zap.NewNop(), AuthModeToken, nil, nil, nil, "")
+	m.SetAuthenticator(NewStaticTokenAuthenticator([]StaticToken{
+		{Token: "secret-1", UserID: "ci-bot", Email: "ci@example.com"},
+	}))
+
+	var seen *User
+	handler := m.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = UserFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, requestWithBearer("secret-1"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, seen)
+	assert.Equal(t, "ci-bot", seen.ID)
+	assert.Equal(t, "ci@example.com", seen.Email)
+
+	seen = nil
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, requestWithBearer("wrong"))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Nil(t, seen)
+}
+
+func TestMiddlewareHeaderModeUsesAuthenticator(t *testing.T) {
+	m := NewMiddleware(zap.NewNop(), AuthModeHeader, nil, nil, nil, "")
+
+	var seen *User
+	handler := m.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = UserFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-User-ID", "dev")
+	req.Header.Set("X-User-Groups", "a, b")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NotNil(t, seen)
+	assert.Equal(t, "dev", seen.ID)
+	assert.Equal(t, []string{"a", "b"}, seen.Groups)
+}
+
+func TestMiddlewareTokenModeWithoutAuthenticator(t *testing.T) {
+	m := NewMiddleware(zap.NewNop(), AuthModeToken, nil, nil, nil, "")
+	handler := m.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be reached")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, requestWithBearer("secret-1"))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -24,6 +24,7 @@ const (
 	AuthModeNone   AuthMode = "none"
 	AuthModeHeader AuthMode = "header"
 	AuthModeOIDC   AuthMode = "oidc"
+	AuthModeToken  AuthMode = "token"
 )
 
 // Middleware provides authentication and authorization middleware
@@ -31,6 +32,7 @@ type Middleware struct {
 	logger         *zap.Logger
 	authMode       AuthMode
 	oidcClient     *OIDCClient
+	authenticator  Authenticator
 	sessionManager *SessionManager
 	authzResolver  *AuthzResolver
 	usernameFormat string
@@ -60,6 +62,7 @@ func NewMiddleware(logger *zap.Logger, authMode AuthMode, oidcClient *OIDCClient
 		logger:         logger,
 		authMode:       authMode,
 		oidcClient:     oidcClient,
+		authenticator:  defaultAuthenticator(authMode, oidcClient),
 		sessionManager: sessionManager,
 		authzResolver:  authzResolver,
 		usernameFormat: usernameFormat,
@@ -69,6 +72,29 @@ func NewMiddleware(logger *zap.Logger, authMode AuthMode, oidcClient *OIDCClient
 	}
 }
 
+// defaultAuthenticator selects the authenticator for the given auth mode.
+// Token mode has no default and must be configured with SetAuthenticator.
+func defaultAuthenticator(authMode AuthMode, oidcClient *OIDCClient) Authenticator {
+	switch authMode {
+	case AuthModeNone:
+		return NewNoneAuthenticator()
+	case AuthModeHeader:
+		return NewHeaderAuthenticator()
+	case AuthModeOIDC:
+		if oidcClient != nil {
+			return NewOIDCAuthenticator(oidcClient)
+		}
+		return NewOIDCAuthenticator(nil)
+	default:
+		return nil
+	}
+}
+
+// SetAuthenticator overrides the authenticator used to resolve request identities
+func (m *Middleware) SetAuthenticator(authenticator Authenticator) {
+	m.authenticator = authenticator
+}
+
 // Authenticate returns a middleware that authenticates requests
 func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -80,9 +106,21 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 			next.ServeHTTP(w, r)
 			return
 
-		case AuthModeHeader:
-			// Extract user from headers (for development/testing)
-			user := m.authenticateFromHeaders(r)
+		case AuthModeHeader, AuthModeToken:
+			// Header mode trusts identity headers (for development/testing);
+			// token mode validates pre-shared bearer tokens
+			if m.authenticator == nil {
+				m.logger.Error("No authenticator configured", zap.String("mode", string(m.authMode)))
+				m.writeUnauthorized(w, "Authentication mode not configured")
+				return
+			}
+
+			user, err := m.authenticator.Authenticate(r)
+			if err != nil {
+				m.logger.Debug("Authentication failed", zap.String("mode", string(m.authMode)), zap.Error(err))
+				m.writeUnauthorized(w, "Invalid or missing authentication")
+				return
+			}
 			if user != nil {
 				ctx = WithUser(ctx, user)
 			}
@@ -141,7 +179,7 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 
 			// If no session cookie or session invalid, try Bearer token
 			if user == nil {
-				user, err = m.authenticateFromToken(ctx, r)
+				user, err = m.authenticator.Authenticate(r)
 				if err != nil {
 					m.logger.Debug("Token authentication failed", zap.Error(err))
 					// Only return error if all auth methods failed
@@ -370,71 +408,6 @@ func (m *Middleware) SecureHeaders(next http.Handler) http.Handler {
 	})
 }
 
-// authenticateFromHeaders extracts user information from request headers
-func (m *Middleware) authenticateFromHeaders(r *http.Request) *User {
-	userID := r.Header.Get("X-User-ID")
-	email := r.Header.Get("X-User-Email")
-	name := r.Header.Get("X-User-Name")
-	groupsHeader := r.Header.Get("X-User-Groups")
-
-	if userID == "" && email == "" {
-		return nil
-	}
-
-	var groups []string
-	if groupsHeader != "" {
-		groups = strings.Split(groupsHeader, ",")
-		for i, group := range groups {
-			groups[i] = strings.TrimSpace(group)
-		}
-	}
-
-	return &User{
-		ID:     userID,
-		Email:  email,
-		Name:   name,
-		Groups: groups,
-		Claims: map[string]interface{}{
-			"sub":    userID,
-			"email":  email,
-			"name":   name,
-			"groups": groups,
-		},
-	}
-}
-
-// authenticateFromToken extracts and validates JWT token from request
-func (m *Middleware) authenticateFromToken(ctx context.Context, r *http.Request) (*User, error) {
-	if m.oidcClient == nil {
-		return nil, nil // OIDC not configured
-	}
-
-	// Extract token from Authorization header
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		return nil, nil // No token provided
-	}
-
-	// Check for Bearer token
-	parts := strings.SplitN(authHeader, " ", 2)
-	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-		return nil, nil // Invalid format
-	}
-
-	token := parts[1]
-	if token == "" {
-		return nil, nil // Empty token
-	}
-
-	// Verify the token
-	user, err := m.oidcClient.VerifyToken(ctx, token)
-	if err != nil {
-		return nil, err
-	}
-
-	return user, nil
-}
-
 // getRateLimiter gets or creates a rate limiter for a user
 func (m *Middleware) getRateLimiter(userID string, requestsPerMinute int) *rate.Limiter {
 	m.rateMutex.Lock()
@@ -95,72 +95,77 @@ func (ss *SearchService) Search(ctx context.Context, query string, resourceTypes
 
 // generateResourceURL generates the frontend URL for a resource
 func (ss *SearchService) generateResourceURL(item *ResourceCacheItem) string {
-	switch item.ResourceType {
+	return ResourceURL(item.ResourceType, item.Namespace, item.Name)
+}
+
+// ResourceURL returns the frontend URL of a resource of the given type
+func ResourceURL(resourceType, namespace, name string) string {
+	switch resourceType {
 	case "pods":
-		if item.Namespace != "" {
-			return fmt.Sprintf("/pods/%s/%s", item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/pods/%s/%s", namespace, name)
 		}
-		return fmt.Sprintf("/pods/%s", item.Name)
+		return fmt.Sprintf("/pods/%s", name)
 	case "deployments":
-		if item.Namespace != "" {
-			return fmt.Sprintf("/deployments/%s/%s", item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/deployments/%s/%s", namespace, name)
 		}
-		return fmt.Sprintf("/deployments/%s", item.Name)
+		return fmt.Sprintf("/deployments/%s", name)
 	case "services":
-		if item.Namespace != "" {
-			return fmt.Sprintf("/services/%s/%s", item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/services/%s/%s", namespace, name)
 		}
-		return fmt.Sprintf("/services/%s", item.Name)
+		return fmt.Sprintf("/services/%s", name)
 	case "configmaps":
-		if item.Namespace != "" {
-			return fmt.Sprintf("/config-maps/%s/%s", item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/config-maps/%s/%s", namespace, name)
 		}
-		return fmt.Sprintf("/config-maps/%s", item.Name)
+		return fmt.Sprintf("/config-maps/%s", name)
 	case "secrets":
-		if item.Namespace != "" {
-			return fmt.Sprintf("/secrets/%s/%s", item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/secrets/%s/%s", namespace, name)
 		}
-		return fmt.Sprintf("/secrets/%s", item.Name)
+		return fmt.Sprintf("/secrets/%s", name)
 	case "nodes":
-		return fmt.Sprintf("/nodes/%s", item.Name)
+		return fmt.Sprintf("/nodes/%s", name)
 	case "namespaces":
-		return fmt.Sprintf("/namespaces/%s", item.Name)
+		return fmt.Sprintf("/namespaces/%s", name)
 	case "statefulsets":
-		if item.Namespace != "" {
-			return fmt.Sprintf("/statefulsets/%s/%s", item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/statefulsets/%s/%s", namespace, name)
 		}
-		return fmt.Sprintf("/statefulsets/%s", item.Name)
+		return fmt.Sprintf("/statefulsets/%s", name)
 	case "daemonsets":
-		if item.Namespace != "" {
-			return fmt.Sprintf("/daemonsets/%s/%s", item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/daemonsets/%s/%s", namespace, name)
 		}
-		return fmt.Sprintf("/daemonsets/%s", item.Name)
+		return fmt.Sprintf("/daemonsets/%s", name)
 	case "replicasets":
-		if item.Namespace != "" {
-			return fmt.Sprintf("/replicasets/%s/%s", item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/replicasets/%s/%s", namespace, name)
 		}
-		return fmt.Sprintf("/replicasets/%s", item.Name)
+		return fmt.Sprintf("/replicasets/%s", name)
 	case "jobs":
-		if item.Namespace != "" {
-			return fmt.Sprintf("/k8s-jobs/%s/%s", item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/k8s-jobs/%s/%s", namespace, name)
 		}
-		return fmt.Sprintf("/k8s-jobs/%s", item.Name)
+		return fmt.Sprintf("/k8s-jobs/%s", name)
 	case "cronjobs":
-		if item.Namespace != "" {
-			return fmt.Sprintf("/cronjobs/%s/%s", item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/cronjobs/%s/%s", namespace, name)
 		}
-		return fmt.Sprintf("/cronjobs/%s", item.Name)
+		return fmt.Sprintf("/cronjobs/%s", name)
 	case "serviceaccounts":
-		if item.Namespace != "" {
-			return fmt.Sprintf("/service-accounts/%s/%s", item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/service-accounts/%s/%s", namespace, name)
 		}
-		return fmt.Sprintf("/service-accounts/%s", item.Name)
+		return fmt.Sprintf("/service-accounts/%s", name)
 	default:
 		// Generic fallback
-		if item.Namespace != "" {
-			return fmt.Sprintf("/%s/%s/%s", item.ResourceType, item.Namespace, item.Name)
+		if namespace != "" {
+			return fmt.Sprintf("/%s/%s/%s", resourceType, namespace, name)
 		}
-		return fmt.Sprintf("/%s/%s", item.ResourceType, item.Name)
+		return fmt.Sprintf("/%s/%s", resourceType, name)
 	}
 }
 
@@ -2,10 +2,14 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/aaronlmathis/kaptn/internal/k8s/resources"
 	"gopkg.in/yaml.v3"
 )
 
@@ -23,6 +27,8 @@ type Config struct {
 	Caching      CachingConfig      `yaml:"caching"`
 	Jobs         JobsConfig         `yaml:"jobs"`
 	Timeseries   TimeseriesConfig   `yaml:"timeseries"`
+	// ReadOnly rejects every mutating endpoint with 403, whatever the caller's RBAC
+	ReadOnly bool `yaml:"read_only"`
 }
 
 // ServerConfig represents the server configuration
@@ -42,10 +48,20 @@ type CORSConfig struct {
 
 // SecurityConfig represents the security configuration
 type SecurityConfig struct {
-	AuthMode       string     `yaml:"auth_mode"`
-	OIDC           OIDCConfig `yaml:"oidc"`
-	TLS            TLSConfig  `yaml:"tls"`
-	UsernameFormat string     `yaml:"username_format"`
+	AuthMode       string              `yaml:"auth_mode"`
+	OIDC           OIDCConfig          `yaml:"oidc"`
+	Tokens         []StaticTokenConfig `yaml:"tokens"`
+	TLS            TLSConfig           `yaml:"tls"`
+	UsernameFormat string              `yaml:"username_format"`
+}
+
+// StaticTokenConfig maps a pre-shared bearer token to an identity (auth mode "token")
+type StaticTokenConfig struct {
+	Token  string   `yaml:"token"`
+	UserID string   `yaml:"user_id"`
+	Email  string   `yaml:"email"`
+	Name   string   `yaml:"name"`
+	Groups []string `yaml:"groups"`
 }
 
 // OIDCConfig represents the OIDC configuration
@@ -61,9 +77,12 @@ type OIDCConfig struct {
 
 // TLSConfig represents TLS configuration
 type TLSConfig struct {
-	Enabled  bool   `yaml:"enabled"`
-	CertFile string `yaml:"cert_file"`
-	KeyFile  string `yaml:"key_file"`
+	Enabled    bool   `yaml:"enabled"`
+	CertFile   string `yaml:"cert_file"`
+	KeyFile    string `yaml:"key_file"`
+	MinVersion string `yaml:"min_version"` // Lowest accepted TLS version: 1.0, 1.1, 1.2 or 1.3
+	// Plain HTTP listener that redirects to HTTPS, e.g. ":80". Empty disables it.
+	RedirectHTTPAddr string `yaml:"redirect_http_addr"`
 }
 
 // AuthzConfig represents authorization configuration
@@ -98,6 +117,16 @@ type KubernetesConfig struct {
 	KubeconfigPath   string `yaml:"kubeconfig_path"`
 	NamespaceDefault string `yaml:"namespace_default"`
 	InsecureTLS      bool   `yaml:"insecure_tls"` // Skip TLS verification for development environments
+
+	KubeletSummary KubeletSummaryConfig `yaml:"kubelet_summary"`
+}
+
+// KubeletSummaryConfig controls how the kubelet Summary API is reached
+type KubeletSummaryConfig struct {
+	Mode   string `yaml:"mode"`   // "proxy" via the API server (default) or "direct" to each kubelet
+	Scheme string `yaml:"scheme"` // http or https for direct mode
+	Port   int    `yaml:"port"`   // kubelet port for direct mode; 0 uses the node's advertised port
+	Path   string `yaml:"path"`   // Summary API path, defaults to /stats/summary
 }
 
 // FeaturesConfig represents the features configuration
@@ -106,12 +135,34 @@ type FeaturesConfig struct {
 	EnableNodeActions         bool `yaml:"enable_nodes_actions"`
 	EnableOverview            bool `yaml:"enable_overview"`
 	EnablePrometheusAnalytics bool `yaml:"enable_prometheus_analytics"`
+	// AnnotateMutations stamps objects changed through Kaptn with kaptn.io/last-modified-by/at
+	AnnotateMutations bool `yaml:"annotate_mutations"`
+	// ScaleHistoryLimit keeps the last N scale actions in a kaptn.io/scale-history annotation; 0 disables it
+	ScaleHistoryLimit int `yaml:"scale_history_limit"`
+	// ExecAllowedCommands lists the executables pod exec sessions may run;
+	// "*" allows any command and an empty list disables exec
+	ExecAllowedCommands []string `yaml:"exec_allowed_commands"`
+	// ConfigMapRedactKeys lists regular expressions of ConfigMap key names whose
+	// values are redacted in API responses unless the caller asks to reveal them
+	ConfigMapRedactKeys []string `yaml:"configmap_redact_keys"`
+	// WatchCoalesceWindow is how long resource watch streams hold events to
+	// coalesce them into one batch frame, as a duration such as "250ms"
+	WatchCoalesceWindow string `yaml:"watch_coalesce_window"`
 }
 
 // RateLimitsConfig represents the rate limits configuration
 type RateLimitsConfig struct {
 	ApplyPerMinute   int `yaml:"apply_per_minute"`
 	ActionsPerMinute int `yaml:"actions_per_minute"`
+
+	// Per-client throttling across the whole API (0 disables a class)
+	ReadsPerMinute  int `yaml:"reads_per_minute"`
+	ReadBurst       int `yaml:"read_burst"`
+	WritesPerMinute int `yaml:"writes_per_minute"`
+	WriteBurst      int `yaml:"write_burst"`
+	// TrustedProxies lists CIDRs of reverse proxies whose forwarded client
+	// addresses are used to key per-client limits; others are keyed by peer
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
 // LoggingConfig represents the logging configuration
@@ -123,7 +174,17 @@ type LoggingConfig struct {
 
 // IntegrationsConfig represents external integrations configuration
 type IntegrationsConfig struct {
-	Prometheus PrometheusConfig `yaml:"prometheus"`
+	Prometheus   PrometheusConfig   `yaml:"prometheus"`
+	ImageScanner ImageScannerConfig `yaml:"image_scanner"`
+}
+
+// ImageScannerConfig configures the optional external image vulnerability lookup
+type ImageScannerConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	URL      string `yaml:"url"`       // Queried as GET <url>?image=<ref>
+	Token    string `yaml:"token"`     // Optional bearer token
+	Timeout  string `yaml:"timeout"`   // Time box for scan lookups on a pod detail request
+	CacheTTL string `yaml:"cache_ttl"` // How long results are cached per image
 }
 
 // PrometheusConfig represents Prometheus integration configuration
@@ -154,14 +215,32 @@ type JobsConfig struct {
 type TimeseriesConfig struct {
 	Enabled                 bool   `yaml:"enabled"`
 	Window                  string `yaml:"window"`
+	SelfMetricsWindow       string `yaml:"self_metrics_window"` // Retention for the aggregator's own kaptn.* series
 	TickInterval            string `yaml:"tick_interval"`
 	CapacityRefreshInterval string `yaml:"capacity_refresh_interval"`
-	HiRes                   struct {
+	// Aggregator poll intervals: "production" polls metrics every 15s, the
+	// Summary API every 30s and object state every 60s; "fast" uses
+	// 5s/10s/10s for demos and development
+	IntervalProfile string `yaml:"interval_profile"`
+	HiRes           struct {
 		Step string `yaml:"step"`
 	} `yaml:"hi_res"`
 	LoRes struct {
-		Step string `yaml:"step"`
+		Step        string `yaml:"step"`
+		Aggregation string `yaml:"aggregation"` // Default reducer: mean, min, max, sum or last
+		Aggregates  bool   `yaml:"aggregates"`  // Also keep min, max and mean per step
 	} `yaml:"lo_res"`
+	// Rollup tier kept for step * points, for long dashboard windows
+	MedRes struct {
+		Step    string `yaml:"step"`
+		Points  int    `yaml:"points"`  // 0 disables the tier
+		Reducer string `yaml:"reducer"` // mean, min, max, sum or last
+	} `yaml:"med_res"`
+
+	// Series are written to SnapshotPath every SnapshotInterval and restored
+	// from it on startup. An empty path disables persistence.
+	SnapshotPath     string `yaml:"snapshot_path"`
+	SnapshotInterval string `yaml:"snapshot_interval"`
 
 	// Health and guardrails
 	MaxSeries          int `yaml:"max_series"`
@@ -170,8 +249,62 @@ type TimeseriesConfig struct {
 	WSReadLimit        int `yaml:"ws_read_limit"`        // WebSocket read buffer limit in bytes
 	WSWriteBufferSize  int `yaml:"ws_write_buffer_size"` // WebSocket write channel buffer size
 
+	// Restart storm detection
+	RestartStormThreshold float64 `yaml:"restart_storm_threshold"` // Cluster restarts per minute that trigger a storm
+	RestartStormWindow    string  `yaml:"restart_storm_window"`    // Window the restart rate is averaged over
+
+	// Identical collector failures (e.g. Metrics API down) are re-logged at most this often
+	CollectorErrorLogInterval string `yaml:"collector_error_log_interval"`
+
+	// Namespaces covered by per-namespace and per-pod series; the deny list wins
+	NamespaceAllowList []string `yaml:"namespace_allow_list"`
+	NamespaceDenyList  []string `yaml:"namespace_deny_list"`
+
 	// Feature flags
 	DisableNetworkIfUnavailable bool `yaml:"disable_network_if_unavailable"`
+
+	// Defaults for queries that omit res or since
+	QueryDefaults TimeseriesQueryDefaults `yaml:"query_defaults"`
+
+	// Metrics recorded from custom.metrics.k8s.io and external.metrics.k8s.io
+	CustomMetrics   []CustomMetricConfig   `yaml:"custom_metrics"`
+	ExternalMetrics []ExternalMetricConfig `yaml:"external_metrics"`
+}
+
+// CustomMetricConfig selects a custom metric to record for the objects it
+// describes. An empty namespace queries root-scoped objects such as nodes.
+type CustomMetricConfig struct {
+	Metric    string `yaml:"metric"`
+	Kind      string `yaml:"kind"`
+	Group     string `yaml:"group"`
+	Namespace string `yaml:"namespace"`
+	Selector  string `yaml:"selector"`
+}
+
+// ExternalMetricConfig selects an external metric to record for a namespace
+type ExternalMetricConfig struct {
+	Metric    string `yaml:"metric"`
+	Namespace string `yaml:"namespace"`
+	Selector  string `yaml:"selector"`
+}
+
+// TimeseriesQueryDefaults sets the resolution and window of timeseries queries
+// that omit the res or since parameter. An explicit parameter always wins, then
+// the longest by_prefix entry matching every requested series key, then the
+// global resolution and window. A config file's by_prefix replaces the
+// built-in entries rather than merging with them, so listing only the wanted
+// prefixes, or an empty map, removes the others.
+type TimeseriesQueryDefaults struct {
+	Resolution string                            `yaml:"resolution"` // "hi" or "lo"
+	Window     string                            `yaml:"window"`     // e.g. "60m"
+	ByPrefix   map[string]TimeseriesQueryDefault `yaml:"by_prefix"`  // Keyed by series key prefix, e.g. "pod."
+}
+
+// TimeseriesQueryDefault is the query default for one series prefix. Empty
+// fields fall back to the global default.
+type TimeseriesQueryDefault struct {
+	Resolution string `yaml:"resolution"`
+	Window     string `yaml:"window"`
 }
 
 // Load loads the configuration from environment variables and defaults
@@ -209,9 +342,11 @@ func loadWithDefaults(configPath string) (*Config, error) {
 				Scopes:       getEnvStringSlice("KAPTN_OIDC_SCOPES", []string{"openid", "profile", "email", "groups"}),
 			},
 			TLS: TLSConfig{
-				Enabled:  getEnvBool("KAPTN_TLS_ENABLED", false),
-				CertFile: getEnv("KAPTN_TLS_CERT_FILE", ""),
-				KeyFile:  getEnv("KAPTN_TLS_KEY_FILE", ""),
+				Enabled:          getEnvBool("KAPTN_TLS_ENABLED", false),
+				CertFile:         getEnv("KAPTN_TLS_CERT_FILE", ""),
+				KeyFile:          getEnv("KAPTN_TLS_KEY_FILE", ""),
+				MinVersion:       getEnv("KAPTN_TLS_MIN_VERSION", "1.2"),
+				RedirectHTTPAddr: getEnv("KAPTN_TLS_REDIRECT_HTTP_ADDR", ""),
 			},
 		},
 		Authz: AuthzConfig{
@@ -234,16 +369,32 @@ func loadWithDefaults(configPath string) (*Config, error) {
 			KubeconfigPath:   getEnv("KUBECONFIG", ""),
 			NamespaceDefault: getEnv("KAPTN_NAMESPACE_DEFAULT", "default"),
 			InsecureTLS:      getEnvBool("KAPTN_KUBE_INSECURE_TLS", false),
+			KubeletSummary: KubeletSummaryConfig{
+				Mode:   getEnv("KAPTN_KUBELET_SUMMARY_MODE", "proxy"),
+				Scheme: getEnv("KAPTN_KUBELET_SUMMARY_SCHEME", "https"),
+				Port:   getEnvInt("KAPTN_KUBELET_SUMMARY_PORT", 0),
+				Path:   getEnv("KAPTN_KUBELET_SUMMARY_PATH", "/stats/summary"),
+			},
 		},
 		Features: FeaturesConfig{
 			EnableApply:               getEnvBool("KAPTN_ENABLE_APPLY", true),
 			EnableNodeActions:         getEnvBool("KAPTN_ENABLE_NODE_ACTIONS", true),
 			EnableOverview:            getEnvBool("KAPTN_ENABLE_OVERVIEW", true),
 			EnablePrometheusAnalytics: getEnvBool("KAPTN_ENABLE_PROMETHEUS_ANALYTICS", true),
+			AnnotateMutations:         getEnvBool("KAPTN_ANNOTATE_MUTATIONS", false),
+			ScaleHistoryLimit:         getEnvInt("KAPTN_SCALE_HISTORY_LIMIT", 0),
+			ExecAllowedCommands:       getEnvStringSlice("KAPTN_EXEC_ALLOWED_COMMANDS", []string{"/bin/sh", "/bin/bash", "/bin/ash", "sh", "bash", "ash"}),
+			ConfigMapRedactKeys:       getEnvStringSlice("KAPTN_CONFIGMAP_REDACT_KEYS", []string{`(?i)(password|passwd|secret|token|api[_-]?key|credential|connection[_-]?string|dsn)`}),
+			WatchCoalesceWindow:       getEnv("KAPTN_WATCH_COALESCE_WINDOW", "250ms"),
 		},
 		RateLimits: RateLimitsConfig{
 			ApplyPerMinute:   getEnvInt("KAPTN_APPLY_PER_MINUTE", 10),
 			ActionsPerMinute: getEnvInt("KAPTN_ACTIONS_PER_MINUTE", 20),
+			ReadsPerMinute:   getEnvInt("KAPTN_READS_PER_MINUTE", 1200),
+			ReadBurst:        getEnvInt("KAPTN_READ_BURST", 200),
+			WritesPerMinute:  getEnvInt("KAPTN_WRITES_PER_MINUTE", 120),
+			WriteBurst:       getEnvInt("KAPTN_WRITE_BURST", 30),
+			TrustedProxies:   getEnvStringSlice("KAPTN_TRUSTED_PROXIES", nil),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
@@ -256,6 +407,13 @@ func loadWithDefaults(configPath string) (*Config, error) {
 				Timeout: getEnv("KAPTN_PROMETHEUS_TIMEOUT", "5s"),
 				Enabled: getEnvBool("KAPTN_PROMETHEUS_ENABLED", true),
 			},
+			ImageScanner: ImageScannerConfig{
+				Enabled:  getEnvBool("KAPTN_IMAGE_SCANNER_ENABLED", false),
+				URL:      getEnv("KAPTN_IMAGE_SCANNER_URL", ""),
+				Token:    getEnv("KAPTN_IMAGE_SCANNER_TOKEN", ""),
+				Timeout:  getEnv("KAPTN_IMAGE_SCANNER_TIMEOUT", "2s"),
+				CacheTTL: getEnv("KAPTN_IMAGE_SCANNER_CACHE_TTL", "5m"),
+			},
 		},
 		Caching: CachingConfig{
 			OverviewTTL:    getEnv("KAPTN_OVERVIEW_TTL", "2s"),
@@ -273,25 +431,54 @@ func loadWithDefaults(configPath string) (*Config, error) {
 		Timeseries: TimeseriesConfig{
 			Enabled:                 getEnvBool("KAPTN_TIMESERIES_ENABLED", true),
 			Window:                  getEnv("KAPTN_TIMESERIES_WINDOW", "60m"),
+			SelfMetricsWindow:       getEnv("KAPTN_TIMESERIES_SELF_METRICS_WINDOW", "24h"),
 			TickInterval:            getEnv("KAPTN_TIMESERIES_TICK_INTERVAL", "1s"),
 			CapacityRefreshInterval: getEnv("KAPTN_TIMESERIES_CAPACITY_REFRESH_INTERVAL", "30s"),
+			IntervalProfile:         getEnv("KAPTN_TIMESERIES_INTERVAL_PROFILE", "production"),
+			SnapshotPath:            getEnv("KAPTN_TIMESERIES_SNAPSHOT_PATH", ""),
+			SnapshotInterval:        getEnv("KAPTN_TIMESERIES_SNAPSHOT_INTERVAL", "5m"),
 			HiRes: struct {
 				Step string `yaml:"step"`
 			}{
 				Step: getEnv("KAPTN_TIMESERIES_HI_RES_STEP", "1s"),
 			},
 			LoRes: struct {
-				Step string `yaml:"step"`
+				Step        string `yaml:"step"`
+				Aggregation string `yaml:"aggregation"` // Default reducer: mean, min, max, sum or last
+				Aggregates  bool   `yaml:"aggregates"`  // Also keep min, max and mean per step
+			}{
+				Step:        getEnv("KAPTN_TIMESERIES_LO_RES_STEP", "5s"),
+				Aggregation: getEnv("KAPTN_TIMESERIES_LO_RES_AGGREGATION", "mean"),
+				Aggregates:  getEnvBool("KAPTN_TIMESERIES_LO_RES_AGGREGATES", false),
+			},
+			MedRes: struct {
+				Step    string `yaml:"step"`
+				Points  int    `yaml:"points"`  // 0 disables the tier
+				Reducer string `yaml:"reducer"` // mean, min, max, sum or last
 			}{
-				Step: getEnv("KAPTN_TIMESERIES_LO_RES_STEP", "5s"),
+				Step:    getEnv("KAPTN_TIMESERIES_MED_RES_STEP", "1m"),
+				Points:  getEnvInt("KAPTN_TIMESERIES_MED_RES_POINTS", 1440),
+				Reducer: getEnv("KAPTN_TIMESERIES_MED_RES_REDUCER", "mean"),
 			},
 			MaxSeries:                   getEnvInt("KAPTN_TIMESERIES_MAX_SERIES", 1000),
 			MaxPointsPerSeries:          getEnvInt("KAPTN_TIMESERIES_MAX_POINTS_PER_SERIES", 10000),
 			MaxWSClients:                getEnvInt("KAPTN_TIMESERIES_MAX_WS_CLIENTS", 500),
 			WSReadLimit:                 getEnvInt("KAPTN_TIMESERIES_WS_READ_LIMIT", 4096),
 			WSWriteBufferSize:           getEnvInt("KAPTN_TIMESERIES_WS_WRITE_BUFFER_SIZE", 1024),
+			RestartStormThreshold:       getEnvFloat("KAPTN_TIMESERIES_RESTART_STORM_THRESHOLD", 10),
+			RestartStormWindow:          getEnv("KAPTN_TIMESERIES_RESTART_STORM_WINDOW", "2m"),
+			CollectorErrorLogInterval:   getEnv("KAPTN_TIMESERIES_COLLECTOR_ERROR_LOG_INTERVAL", "5m"),
 			DisableNetworkIfUnavailable: getEnvBool("KAPTN_TIMESERIES_DISABLE_NETWORK_IF_UNAVAILABLE", true),
+			QueryDefaults: TimeseriesQueryDefaults{
+				Resolution: getEnv("KAPTN_TIMESERIES_QUERY_RESOLUTION", "lo"),
+				Window:     getEnv("KAPTN_TIMESERIES_QUERY_WINDOW", "60m"),
+				ByPrefix: map[string]TimeseriesQueryDefault{
+					"pod.": {Resolution: "hi", Window: "15m"},
+					"ctr.": {Resolution: "hi", Window: "15m"},
+				},
+			},
 		},
+		ReadOnly: getEnvBool("KAPTN_READ_ONLY", false),
 	}
 
 	// If a config file path is provided, load and merge it
@@ -337,6 +524,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 func getEnvStringSlice(key string, defaultValue []string) []string {
 	if value := os.Getenv(key); value != "" {
 		parts := strings.Split(value, ",")
@@ -435,6 +631,33 @@ func mergeConfigs(envConfig, fileConfig *Config) *Config {
 			result.Features.EnablePrometheusAnalytics = parsed
 		}
 	}
+	if envValue := os.Getenv("KAPTN_ANNOTATE_MUTATIONS"); envValue != "" {
+		if parsed, err := strconv.ParseBool(envValue); err == nil {
+			result.Features.AnnotateMutations = parsed
+		}
+	}
+	if envValue := os.Getenv("KAPTN_SCALE_HISTORY_LIMIT"); envValue != "" {
+		if parsed, err := strconv.Atoi(envValue); err == nil {
+			result.Features.ScaleHistoryLimit = parsed
+		}
+	}
+	if envValue := os.Getenv("KAPTN_EXEC_ALLOWED_COMMANDS"); envValue != "" {
+		result.Features.ExecAllowedCommands = getEnvStringSlice("KAPTN_EXEC_ALLOWED_COMMANDS", nil)
+	}
+	if envValue := os.Getenv("KAPTN_CONFIGMAP_REDACT_KEYS"); envValue != "" {
+		result.Features.ConfigMapRedactKeys = getEnvStringSlice("KAPTN_CONFIGMAP_REDACT_KEYS", nil)
+	}
+	if envValue := os.Getenv("KAPTN_WATCH_COALESCE_WINDOW"); envValue != "" {
+		result.Features.WatchCoalesceWindow = envValue
+	}
+	if envValue := os.Getenv("KAPTN_TRUSTED_PROXIES"); envValue != "" {
+		result.RateLimits.TrustedProxies = getEnvStringSlice("KAPTN_TRUSTED_PROXIES", nil)
+	}
+	if envValue := os.Getenv("KAPTN_READ_ONLY"); envValue != "" {
+		if parsed, err := strconv.ParseBool(envValue); err == nil {
+			result.ReadOnly = parsed
+		}
+	}
 
 	// Handle Prometheus configuration
 	if envValue := os.Getenv("KAPTN_PROMETHEUS_URL"); envValue != "" {
@@ -540,8 +763,48 @@ func (c *Config) Validate() error {
 	if c.Kubernetes.Mode != "incluster" && c.Kubernetes.Mode != "kubeconfig" {
 		return fmt.Errorf("kubernetes mode must be 'incluster' or 'kubeconfig'")
 	}
-	if c.Security.AuthMode != "none" && c.Security.AuthMode != "header" && c.Security.AuthMode != "oidc" {
-		return fmt.Errorf("auth mode must be 'none', 'header', or 'oidc'")
+	switch c.Kubernetes.KubeletSummary.Mode {
+	case "", "proxy", "direct":
+	default:
+		return fmt.Errorf("kubelet summary mode must be 'proxy' or 'direct'")
+	}
+	switch c.Kubernetes.KubeletSummary.Scheme {
+	case "", "http", "https":
+	default:
+		return fmt.Errorf("kubelet summary scheme must be 'http' or 'https'")
+	}
+	if c.Kubernetes.KubeletSummary.Port < 0 || c.Kubernetes.KubeletSummary.Port > 65535 {
+		return fmt.Errorf("kubelet summary port must be between 0 and 65535")
+	}
+	for _, pattern := range c.Features.ConfigMapRedactKeys {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid configmap redact key pattern %q: %w", pattern, err)
+		}
+	}
+	for _, proxy := range c.RateLimits.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return fmt.Errorf("invalid trusted proxy %q: must be an IP address or CIDR", proxy)
+			}
+		}
+	}
+	if c.Security.AuthMode != "none" && c.Security.AuthMode != "header" && c.Security.AuthMode != "oidc" && c.Security.AuthMode != "token" {
+		return fmt.Errorf("auth mode must be 'none', 'header', 'oidc', or 'token'")
+	}
+
+	// Validate static tokens if token auth mode is enabled
+	if c.Security.AuthMode == "token" {
+		if len(c.Security.Tokens) == 0 {
+			return fmt.Errorf("at least one token is required when auth mode is 'token'")
+		}
+		for i, token := range c.Security.Tokens {
+			if token.Token == "" {
+				return fmt.Errorf("token %d must not be empty", i)
+			}
+			if token.UserID == "" && token.Email == "" {
+				return fmt.Errorf("token %d must have a user_id or email", i)
+			}
+		}
 	}
 
 	// Validate username format
@@ -561,6 +824,15 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Features.ScaleHistoryLimit < 0 || c.Features.ScaleHistoryLimit > resources.MaxScaleHistoryLimit {
+		return fmt.Errorf("scale history limit must be between 0 and %d", resources.MaxScaleHistoryLimit)
+	}
+	if c.Features.WatchCoalesceWindow != "" {
+		if window, err := time.ParseDuration(c.Features.WatchCoalesceWindow); err != nil || window <= 0 {
+			return fmt.Errorf("watch coalesce window must be a positive duration")
+		}
+	}
+
 	// Validate authorization configuration
 	if c.Authz.Mode != "idp_groups" && c.Authz.Mode != "user_bindings" {
 		return fmt.Errorf("authz mode must be 'idp_groups' or 'user_bindings'")
@@ -588,6 +860,64 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Timeseries.SelfMetricsWindow != "" {
+		if window, err := time.ParseDuration(c.Timeseries.SelfMetricsWindow); err != nil || window <= 0 {
+			return fmt.Errorf("timeseries self metrics window must be a positive duration")
+		}
+	}
+	if c.Timeseries.SnapshotPath != "" && c.Timeseries.SnapshotInterval != "" {
+		if interval, err := time.ParseDuration(c.Timeseries.SnapshotInterval); err != nil || interval <= 0 {
+			return fmt.Errorf("timeseries snapshot interval must be a positive duration")
+		}
+	}
+	if c.Timeseries.MedRes.Step != "" {
+		if step, err := time.ParseDuration(c.Timeseries.MedRes.Step); err != nil || step <= 0 {
+			return fmt.Errorf("timeseries med_res step must be a positive duration")
+		}
+	}
+	if c.Timeseries.MedRes.Points < 0 {
+		return fmt.Errorf("timeseries med_res points cannot be negative")
+	}
+	switch c.Timeseries.IntervalProfile {
+	case "", "production", "fast":
+	default:
+		return fmt.Errorf("timeseries interval_profile must be 'production' or 'fast'")
+	}
+	if !validReducer(c.Timeseries.MedRes.Reducer) {
+		return fmt.Errorf("timeseries med_res reducer must be one of mean, min, max, sum or last")
+	}
+	if !validReducer(c.Timeseries.LoRes.Aggregation) {
+		return fmt.Errorf("timeseries lo_res aggregation must be one of mean, min, max, sum or last")
+	}
+	if c.Timeseries.CollectorErrorLogInterval != "" {
+		if interval, err := time.ParseDuration(c.Timeseries.CollectorErrorLogInterval); err != nil || interval <= 0 {
+			return fmt.Errorf("timeseries collector error log interval must be a positive duration")
+		}
+	}
+	for i, namespace := range c.Timeseries.NamespaceAllowList {
+		if strings.TrimSpace(namespace) == "" {
+			return fmt.Errorf("timeseries namespace_allow_list[%d] must not be empty", i)
+		}
+	}
+	for i, namespace := range c.Timeseries.NamespaceDenyList {
+		if strings.TrimSpace(namespace) == "" {
+			return fmt.Errorf("timeseries namespace_deny_list[%d] must not be empty", i)
+		}
+	}
+	for i, metric := range c.Timeseries.CustomMetrics {
+		if metric.Metric == "" || metric.Kind == "" {
+			return fmt.Errorf("timeseries custom_metrics[%d] requires metric and kind", i)
+		}
+	}
+	for i, metric := range c.Timeseries.ExternalMetrics {
+		if metric.Metric == "" {
+			return fmt.Errorf("timeseries external_metrics[%d] requires metric", i)
+		}
+	}
+	if err := c.Timeseries.QueryDefaults.validate(); err != nil {
+		return err
+	}
+
 	// Validate TLS configuration
 	if c.Security.TLS.Enabled {
 		if c.Security.TLS.CertFile == "" {
@@ -596,11 +926,59 @@ func (c *Config) Validate() error {
 		if c.Security.TLS.KeyFile == "" {
 			return fmt.Errorf("TLS key file is required when TLS is enabled")
 		}
+		switch c.Security.TLS.MinVersion {
+		case "", "1.0", "1.1", "1.2", "1.3":
+		default:
+			return fmt.Errorf("TLS min_version must be one of 1.0, 1.1, 1.2 or 1.3")
+		}
+		if c.Security.TLS.RedirectHTTPAddr != "" && c.Security.TLS.RedirectHTTPAddr == c.Server.Addr {
+			return fmt.Errorf("TLS redirect_http_addr must differ from server addr")
+		}
 	}
 
 	return nil
 }
 
+// validReducer reports whether name is a timeseries downsampling reducer; empty
+// selects the mean
+func validReducer(name string) bool {
+	switch name {
+	case "", "mean", "min", "max", "sum", "last":
+		return true
+	}
+	return false
+}
+
+// validate checks the global and per-prefix timeseries query defaults
+func (d TimeseriesQueryDefaults) validate() error {
+	check := func(scope string, def TimeseriesQueryDefault) error {
+		switch def.Resolution {
+		case "", "hi", "med", "lo":
+		default:
+			return fmt.Errorf("timeseries query default resolution for %s must be 'hi', 'med' or 'lo'", scope)
+		}
+		if def.Window != "" {
+			if window, err := time.ParseDuration(def.Window); err != nil || window <= 0 {
+				return fmt.Errorf("timeseries query default window for %s must be a positive duration", scope)
+			}
+		}
+		return nil
+	}
+
+	if err := check("all series", TimeseriesQueryDefault{Resolution: d.Resolution, Window: d.Window}); err != nil {
+		return err
+	}
+	for prefix, def := range d.ByPrefix {
+		if prefix == "" {
+			return fmt.Errorf("timeseries query default prefix cannot be empty")
+		}
+		if err := check("prefix "+prefix, def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetSummaryConfig creates a summary service configuration from the main config
 func (c *Config) GetSummaryConfig() map[string]interface{} {
 	return map[string]interface{}{
@@ -107,3 +107,21 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadFromFileReplacesQueryDefaultPrefixes(t *testing.T) {
+	path := t.TempDir() + "/config.yaml"
+	data := "timeseries:\n  query_defaults:\n    by_prefix:\n      \"node.\": { resolution: \"hi\", window: \"30m\" }\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("Failed to load config file: %v", err)
+	}
+
+	byPrefix := cfg.Timeseries.QueryDefaults.ByPrefix
+	if len(byPrefix) != 1 || byPrefix["node."].Window != "30m" {
+		t.Errorf("Expected by_prefix to hold only the file's node. entry, got %v", byPrefix)
+	}
+}
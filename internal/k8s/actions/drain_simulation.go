@@ -0,0 +1,243 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// DrainSimulationOptions control which pods a simulated drain evicts
+type DrainSimulationOptions struct {
+	// Force includes DaemonSet pods, which are skipped otherwise
+	Force bool `json:"force,omitempty"`
+}
+
+// DrainSimulation describes where pods evicted by a drain would reschedule
+type DrainSimulation struct {
+	Node        string              `json:"node"`
+	Feasible    bool                `json:"feasible"`
+	Placements  []DrainPodPlacement `json:"placements"`
+	Unplaceable []DrainPodPlacement `json:"unplaceable"`
+	Skipped     []string            `json:"skipped"`
+	Capacity    []DrainNodeCapacity `json:"capacity"`
+}
+
+// DrainPodPlacement records the simulated destination of a single pod
+type DrainPodPlacement struct {
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	CPURequest    int64  `json:"cpuRequestMilli"`
+	MemoryRequest int64  `json:"memoryRequestBytes"`
+	TargetNode    string `json:"targetNode,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// DrainNodeCapacity is the free capacity of a candidate node after the simulated drain
+type DrainNodeCapacity struct {
+	Node            string `json:"node"`
+	FreeCPUMilli    int64  `json:"freeCpuMilli"`
+	FreeMemoryBytes int64  `json:"freeMemoryBytes"`
+	FreePods        int64  `json:"freePods"`
+	PlacedPods      int    `json:"placedPods"`
+}
+
+// ErrNodeCapacityUnavailable is returned by SimulateDrain when no time series
+// store has been set to read node capacity from
+var ErrNodeCapacityUnavailable = errors.New("node capacity series are not available")
+
+// SetTimeSeriesStore sets the store whose node series drain simulations place
+// pods against
+func (s *NodeActionsService) SetTimeSeriesStore(store timeseries.Store) {
+	s.store = store
+}
+
+// SimulateDrain reports, without evicting anything, where each evictable pod on
+// the node would fit based on the latest allocatable, requested and
+// schedulable series of the other nodes in the time series store
+func (s *NodeActionsService) SimulateDrain(ctx context.Context, nodeName string, opts DrainSimulationOptions) (*DrainSimulation, error) {
+	if s.store == nil {
+		return nil, ErrNodeCapacityUnavailable
+	}
+	getNode := s.getNode
+	if getNode == nil {
+		getNode = func(ctx context.Context, name string) (*v1.Node, error) {
+			return s.client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		}
+	}
+	if _, err := getNode(ctx, nodeName); err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+
+	pods, err := s.client.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods on node %s: %w", nodeName, err)
+	}
+
+	return planDrain(nodeName, nodeCapacities(s.store, nodeName), pods.Items, opts), nil
+}
+
+// nodeCapacities returns the free capacity of every node other than nodeName
+// whose latest schedulable sample is 1: allocatable minus requested CPU and
+// memory, and allocatable pods minus the active pod count. Nodes without
+// allocatable samples are left out, and missing request samples count as 0.
+func nodeCapacities(store timeseries.Store, nodeName string) map[string]*DrainNodeCapacity {
+	latest := func(base, node string) (float64, bool) {
+		series, ok := store.Get(timeseries.GenerateNodeSeriesKey(base, node))
+		if !ok {
+			return 0, false
+		}
+		point, ok := series.Latest()
+		return point.V, ok
+	}
+
+	candidates := make(map[string]*DrainNodeCapacity)
+	prefix := timeseries.NodeSchedulableBase + "."
+	for _, key := range store.Keys() {
+		node, ok := strings.CutPrefix(key, prefix)
+		if !ok || node == nodeName {
+			continue
+		}
+		if schedulable, ok := latest(timeseries.NodeSchedulableBase, node); !ok || schedulable != 1 {
+			continue
+		}
+
+		allocatableCPU, okCPU := latest(timeseries.NodeAllocatableCPUBase, node)
+		allocatableMemory, okMemory := latest(timeseries.NodeAllocatableMemBase, node)
+		allocatablePods, okPods := latest(timeseries.NodeAllocatablePodsBase, node)
+		if !okCPU || !okMemory || !okPods {
+			continue
+		}
+		requestedCPU, _ := latest(timeseries.NodeRequestedCPUBase, node)
+		requestedMemory, _ := latest(timeseries.NodeRequestedMemBase, node)
+		podCount, _ := latest(timeseries.NodePodsCountBase, node)
+
+		candidates[node] = &DrainNodeCapacity{
+			Node:            node,
+			FreeCPUMilli:    int64(math.Round((allocatableCPU - requestedCPU) * 1000)),
+			FreeMemoryBytes: int64(allocatableMemory - requestedMemory),
+			FreePods:        int64(allocatablePods - podCount),
+		}
+	}
+	return candidates
+}
+
+// planDrain performs a first-fit placement of the target node's evictable pods
+// onto the candidate nodes
+func planDrain(nodeName string, candidates map[string]*DrainNodeCapacity, pods []v1.Pod, opts DrainSimulationOptions) *DrainSimulation {
+	simulation := &DrainSimulation{
+		Node:        nodeName,
+		Placements:  []DrainPodPlacement{},
+		Unplaceable: []DrainPodPlacement{},
+		Skipped:     []string{},
+		Capacity:    []DrainNodeCapacity{},
+	}
+
+	var evictable []v1.Pod
+	for _, pod := range pods {
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed || pod.Spec.NodeName != nodeName {
+			continue
+		}
+
+		// Mirror the filtering done by a real drain
+		if isDaemonSetPod(&pod) && !opts.Force {
+			simulation.Skipped = append(simulation.Skipped, fmt.Sprintf("%s/%s (DaemonSet)", pod.Namespace, pod.Name))
+			continue
+		}
+		if isMirrorPod(&pod) {
+			simulation.Skipped = append(simulation.Skipped, fmt.Sprintf("%s/%s (static pod)", pod.Namespace, pod.Name))
+			continue
+		}
+
+		evictable = append(evictable, pod)
+	}
+
+	// Deterministic ordering for both pods and candidate nodes
+	sort.Slice(evictable, func(i, j int) bool {
+		if evictable[i].Namespace == evictable[j].Namespace {
+			return evictable[i].Name < evictable[j].Name
+		}
+		return evictable[i].Namespace < evictable[j].Namespace
+	})
+
+	candidateNames := make([]string, 0, len(candidates))
+	for name := range candidates {
+		candidateNames = append(candidateNames, name)
+	}
+	sort.Strings(candidateNames)
+
+	for _, pod := range evictable {
+		cpu, memory := podRequests(&pod)
+		placement := DrainPodPlacement{
+			Namespace:     pod.Namespace,
+			Name:          pod.Name,
+			CPURequest:    cpu,
+			MemoryRequest: memory,
+		}
+
+		for _, name := range candidateNames {
+			candidate := candidates[name]
+			if candidate.FreePods >= 1 && candidate.FreeCPUMilli >= cpu && candidate.FreeMemoryBytes >= memory {
+				candidate.FreeCPUMilli -= cpu
+				candidate.FreeMemoryBytes -= memory
+				candidate.FreePods--
+				candidate.PlacedPods++
+				placement.TargetNode = name
+				break
+			}
+		}
+
+		if placement.TargetNode == "" {
+			if len(candidates) == 0 {
+				placement.Reason = "no other schedulable nodes"
+			} else {
+				placement.Reason = "insufficient cpu, memory or pod capacity on all schedulable nodes"
+			}
+			simulation.Unplaceable = append(simulation.Unplaceable, placement)
+			continue
+		}
+
+		simulation.Placements = append(simulation.Placements, placement)
+	}
+
+	for _, name := range candidateNames {
+		simulation.Capacity = append(simulation.Capacity, *candidates[name])
+	}
+	simulation.Feasible = len(simulation.Unplaceable) == 0
+
+	return simulation
+}
+
+// podRequests returns the effective CPU (millicores) and memory (bytes) requests of a pod,
+// taking the larger of the summed app containers and any single init container
+func podRequests(pod *v1.Pod) (int64, int64) {
+	cpu := resource.Quantity{}
+	memory := resource.Quantity{}
+	for _, container := range pod.Spec.Containers {
+		cpu.Add(*container.Resources.Requests.Cpu())
+		memory.Add(*container.Resources.Requests.Memory())
+	}
+
+	cpuMilli := cpu.MilliValue()
+	memoryBytes := memory.Value()
+	for _, container := range pod.Spec.InitContainers {
+		if initCPU := container.Resources.Requests.Cpu().MilliValue(); initCPU > cpuMilli {
+			cpuMilli = initCPU
+		}
+		if initMemory := container.Resources.Requests.Memory().Value(); initMemory > memoryBytes {
+			memoryBytes = initMemory
+		}
+	}
+
+	return cpuMilli, memoryBytes
+}
@@ -0,0 +1,136 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// storeSimulationNode records the series a drain simulation reads for a node
+func storeSimulationNode(store timeseries.Store, name string, schedulable, cpuCores, memoryBytes, requestedCPU, requestedMemory, pods float64) {
+	now := time.Now()
+	for base, value := range map[string]float64{
+		timeseries.NodeSchedulableBase:     schedulable,
+		timeseries.NodeAllocatableCPUBase:  cpuCores,
+		timeseries.NodeAllocatableMemBase:  memoryBytes,
+		timeseries.NodeAllocatablePodsBase: 110,
+		timeseries.NodeRequestedCPUBase:    requestedCPU,
+		timeseries.NodeRequestedMemBase:    requestedMemory,
+		timeseries.NodePodsCountBase:       pods,
+	} {
+		store.Upsert(timeseries.GenerateNodeSeriesKey(base, name)).Add(timeseries.NewPoint(now, value))
+	}
+}
+
+func simulationPod(name, nodeName, cpu, memory string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{{
+				Name: "app",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+func TestNodeActionsService_SimulateDrain(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	daemonPod := simulationPod("agent", "node-a", "100m", "64Mi")
+	daemonPod.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent"}}
+
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		simulationPod("existing", "node-b", "1", "1Gi"),
+		simulationPod("small", "node-a", "500m", "512Mi"),
+		simulationPod("huge", "node-a", "3", "2Gi"),
+		daemonPod,
+	)
+
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	storeSimulationNode(store, "node-a", 1, 4, 8<<30, 3.6, 2.5*(1<<30), 3)
+	// node-b already has 1 CPU requested, leaving 1 CPU free
+	storeSimulationNode(store, "node-b", 1, 2, 4<<30, 1, 1<<30, 1)
+	storeSimulationNode(store, "node-cordoned", 0, 64, 256<<30, 0, 0, 0)
+
+	service := NewNodeActionsService(fakeClient, logger)
+	service.SetTimeSeriesStore(store)
+
+	simulation, err := service.SimulateDrain(context.Background(), "node-a", DrainSimulationOptions{})
+	require.NoError(t, err)
+
+	assert.False(t, simulation.Feasible)
+	assert.Equal(t, []string{"default/agent (DaemonSet)"}, simulation.Skipped)
+
+	// The small pod fits on node-b
+	require.Len(t, simulation.Placements, 1)
+	assert.Equal(t, "small", simulation.Placements[0].Name)
+	assert.Equal(t, "node-b", simulation.Placements[0].TargetNode)
+	assert.Equal(t, int64(500), simulation.Placements[0].CPURequest)
+
+	// The huge pod needs 3 CPU but only node-b (1 CPU free) is schedulable
+	require.Len(t, simulation.Unplaceable, 1)
+	assert.Equal(t, "huge", simulation.Unplaceable[0].Name)
+	assert.Empty(t, simulation.Unplaceable[0].TargetNode)
+	assert.NotEmpty(t, simulation.Unplaceable[0].Reason)
+
+	require.Len(t, simulation.Capacity, 1)
+	assert.Equal(t, "node-b", simulation.Capacity[0].Node)
+	assert.Equal(t, int64(500), simulation.Capacity[0].FreeCPUMilli)
+	assert.Equal(t, int64(108), simulation.Capacity[0].FreePods)
+	assert.Equal(t, 1, simulation.Capacity[0].PlacedPods)
+}
+
+func TestNodeActionsService_SimulateDrain_NodeNotFound(t *testing.T) {
+	service := NewNodeActionsService(fake.NewSimpleClientset(), zaptest.NewLogger(t))
+	service.SetTimeSeriesStore(timeseries.NewMemStore(timeseries.DefaultConfig()))
+
+	_, err := service.SimulateDrain(context.Background(), "missing", DrainSimulationOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "got %v", err)
+}
+
+func TestNodeActionsService_SimulateDrain_UsesNodeGetter(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	storeSimulationNode(store, "node-a", 1, 4, 8<<30, 0, 0, 0)
+
+	service := NewNodeActionsService(fakeClient, zaptest.NewLogger(t))
+	service.SetTimeSeriesStore(store)
+	var looked []string
+	service.SetNodeGetter(func(ctx context.Context, name string) (*v1.Node, error) {
+		looked = append(looked, name)
+		return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+	})
+
+	_, err := service.SimulateDrain(context.Background(), "node-a", DrainSimulationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node-a"}, looked)
+	for _, action := range fakeClient.Actions() {
+		assert.NotEqual(t, "nodes", action.GetResource().Resource, "the node is not read from the API server")
+	}
+}
+
+func TestNodeActionsService_SimulateDrain_WithoutStore(t *testing.T) {
+	service := NewNodeActionsService(fake.NewSimpleClientset(), zaptest.NewLogger(t))
+
+	_, err := service.SimulateDrain(context.Background(), "node-a", DrainSimulationOptions{})
+	assert.ErrorIs(t, err, ErrNodeCapacityUnavailable)
+}
@@ -129,6 +129,16 @@ func (j *Job) UpdateStatus(message string) {
 	j.triggerPersistenceSave()
 }
 
+// SetDetail records a structured detail of the job, such as a partial result,
+// and broadcasts it with step
+func (j *Job) SetDetail(key string, value interface{}, step string) {
+	j.mu.Lock()
+	j.Details[key] = value
+	j.mu.Unlock()
+
+	j.UpdateStatus(step)
+}
+
 // SetError marks the job as failed with an error
 func (j *Job) SetError(err error) {
 	j.mu.Lock()
@@ -0,0 +1,138 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// SupportedAgeKinds lists the kinds an age distribution can be computed for
+var SupportedAgeKinds = []string{
+	"pods", "nodes", "namespaces", "deployments", "statefulsets", "daemonsets",
+	"replicasets", "jobs", "cronjobs", "services", "configmaps", "secrets",
+	"ingresses", "persistentvolumeclaims",
+}
+
+// ageBuckets are the upper bounds of the age histogram buckets; objects older
+// than the last bound fall in the final open-ended bucket
+var ageBuckets = []struct {
+	label string
+	upper time.Duration
+}{
+	{"<1h", time.Hour},
+	{"1-24h", 24 * time.Hour},
+	{"1-7d", 7 * 24 * time.Hour},
+	{">7d", 0},
+}
+
+// AgeBucket counts the objects whose age falls in one histogram bucket
+type AgeBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// AgedObject identifies an object and how long ago it was created
+type AgedObject struct {
+	Namespace         string    `json:"namespace,omitempty"`
+	Name              string    `json:"name"`
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+	AgeSeconds        int64     `json:"ageSeconds"`
+}
+
+// AgeDistribution is a histogram of object ages computed from creation timestamps
+type AgeDistribution struct {
+	Kind        string      `json:"kind"`
+	Namespace   string      `json:"namespace,omitempty"`
+	Total       int         `json:"total"`
+	Buckets     []AgeBucket `json:"buckets"`
+	Oldest      *AgedObject `json:"oldest,omitempty"`
+	GeneratedAt time.Time   `json:"generatedAt"`
+}
+
+// ParseAgeKind validates the kind of an age distribution, accepting singular,
+// plural and short names. An empty kind selects pods.
+func ParseAgeKind(raw string) (string, error) {
+	kind := strings.ToLower(strings.TrimSpace(raw))
+	if kind == "" {
+		return "pods", nil
+	}
+
+	aliases := map[string]string{
+		"po": "pods", "no": "nodes", "ns": "namespaces", "deploy": "deployments",
+		"sts": "statefulsets", "ds": "daemonsets", "rs": "replicasets", "cj": "cronjobs",
+		"svc": "services", "cm": "configmaps", "ing": "ingresses", "pvc": "persistentvolumeclaims",
+	}
+	if alias, ok := aliases[kind]; ok {
+		return alias, nil
+	}
+	for _, supported := range SupportedAgeKinds {
+		if kind == supported || kind+"s" == supported || kind+"es" == supported {
+			return supported, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported kind %q (supported: %s)", strings.TrimSpace(raw), strings.Join(SupportedAgeKinds, ", "))
+}
+
+// ComputeAgeDistribution buckets objects by the time since their creation.
+// Only objects in namespace are counted when it is set; objects without
+// object metadata or a creation timestamp are skipped.
+func ComputeAgeDistribution(kind, namespace string, objects []interface{}, now time.Time) *AgeDistribution {
+	distribution := &AgeDistribution{
+		Kind:        kind,
+		Namespace:   namespace,
+		Buckets:     make([]AgeBucket, len(ageBuckets)),
+		GeneratedAt: now.UTC(),
+	}
+	for i, bucket := range ageBuckets {
+		distribution.Buckets[i].Label = bucket.label
+	}
+
+	aged := make([]AgedObject, 0, len(objects))
+	for _, obj := range objects {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			continue
+		}
+		if namespace != "" && accessor.GetNamespace() != namespace {
+			continue
+		}
+		created := accessor.GetCreationTimestamp().Time
+		if created.IsZero() {
+			continue
+		}
+
+		age := now.Sub(created)
+		distribution.Buckets[ageBucketIndex(age)].Count++
+		distribution.Total++
+		aged = append(aged, AgedObject{
+			Namespace:         accessor.GetNamespace(),
+			Name:              accessor.GetName(),
+			CreationTimestamp: created.UTC(),
+			AgeSeconds:        int64(age / time.Second),
+		})
+	}
+
+	if len(aged) > 0 {
+		sort.Slice(aged, func(i, j int) bool {
+			if !aged[i].CreationTimestamp.Equal(aged[j].CreationTimestamp) {
+				return aged[i].CreationTimestamp.Before(aged[j].CreationTimestamp)
+			}
+			return aged[i].Namespace+"/"+aged[i].Name < aged[j].Namespace+"/"+aged[j].Name
+		})
+		distribution.Oldest = &aged[0]
+	}
+	return distribution
+}
+
+// ageBucketIndex returns the histogram bucket of an age
+func ageBucketIndex(age time.Duration) int {
+	for i, bucket := range ageBuckets {
+		if bucket.upper > 0 && age < bucket.upper {
+			return i
+		}
+	}
+	return len(ageBuckets) - 1
+}
@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func agedPod(namespace, name string, created time.Time) *v1.Pod {
+	return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace:         namespace,
+		Name:              name,
+		CreationTimestamp: metav1.NewTime(created),
+	}}
+}
+
+func bucketCounts(distribution *AgeDistribution) map[string]int {
+	counts := make(map[string]int, len(distribution.Buckets))
+	for _, bucket := range distribution.Buckets {
+		counts[bucket.Label] = bucket.Count
+	}
+	return counts
+}
+
+func TestComputeAgeDistribution(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	objects := []interface{}{
+		agedPod("default", "fresh", now.Add(-10*time.Minute)),
+		agedPod("default", "hour-edge", now.Add(-time.Hour)),
+		agedPod("prod", "half-day", now.Add(-12*time.Hour)),
+		agedPod("prod", "three-days", now.Add(-72*time.Hour)),
+		agedPod("prod", "week-edge", now.Add(-7*24*time.Hour)),
+		agedPod("default", "ancient", now.Add(-90*24*time.Hour)),
+		agedPod("default", "no-timestamp", time.Time{}),
+		"not an object",
+	}
+
+	distribution := ComputeAgeDistribution("pods", "", objects, now)
+	assert.Equal(t, 6, distribution.Total)
+	assert.Equal(t, []string{"<1h", "1-24h", "1-7d", ">7d"}, []string{
+		distribution.Buckets[0].Label, distribution.Buckets[1].Label,
+		distribution.Buckets[2].Label, distribution.Buckets[3].Label,
+	})
+	assert.Equal(t, map[string]int{"<1h": 1, "1-24h": 2, "1-7d": 1, ">7d": 2}, bucketCounts(distribution))
+	require.NotNil(t, distribution.Oldest)
+	assert.Equal(t, "ancient", distribution.Oldest.Name)
+	assert.Equal(t, int64(90*24*3600), distribution.Oldest.AgeSeconds)
+
+	prod := ComputeAgeDistribution("pods", "prod", objects, now)
+	assert.Equal(t, 3, prod.Total)
+	assert.Equal(t, map[string]int{"<1h": 0, "1-24h": 1, "1-7d": 1, ">7d": 1}, bucketCounts(prod))
+	assert.Equal(t, "week-edge", prod.Oldest.Name)
+
+	empty := ComputeAgeDistribution("deployments", "", []interface{}{}, now)
+	assert.Zero(t, empty.Total)
+	assert.Len(t, empty.Buckets, 4)
+	assert.Nil(t, empty.Oldest)
+
+	deployments := ComputeAgeDistribution("deployments", "", []interface{}{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour))}},
+	}, now)
+	assert.Equal(t, map[string]int{"<1h": 0, "1-24h": 1, "1-7d": 0, ">7d": 0}, bucketCounts(deployments))
+}
+
+func TestParseAgeKind(t *testing.T) {
+	for raw, expected := range map[string]string{
+		"":                      "pods",
+		"pod":                   "pods",
+		"Pods":                  "pods",
+		"deploy":                "deployments",
+		"ingress":               "ingresses",
+		"persistentvolumeclaim": "persistentvolumeclaims",
+		"ns":                    "namespaces",
+	} {
+		kind, err := ParseAgeKind(raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, expected, kind, raw)
+	}
+
+	_, err := ParseAgeKind("widgets")
+	assert.Error(t, err)
+}
@@ -0,0 +1,256 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SupportedCompareKinds lists the kinds that can be compared across namespaces
+var SupportedCompareKinds = []string{"configmaps", "deployments"}
+
+// MaxCompareNamespaces bounds how many namespaces one comparison fetches from
+const MaxCompareNamespaces = 20
+
+// compareFields are the top-level fields compared for each kind, besides
+// labels and annotations
+var compareFields = map[string][]string{
+	"configmaps":  {"data", "binaryData", "immutable"},
+	"deployments": {"spec"},
+}
+
+// driftIgnoredAnnotations differ between namespaces by nature and are not
+// configuration drift
+var driftIgnoredAnnotations = map[string]bool{
+	"kubectl.kubernetes.io/last-applied-configuration": true,
+	"deployment.kubernetes.io/revision":                true,
+}
+
+// plainPathSegment matches map keys that need no quoting in a field path
+var plainPathSegment = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ComparedField is one row of a comparison matrix: the value of a field in
+// every namespace the object exists in. A nil value means the field is unset.
+type ComparedField struct {
+	Path    string                 `json:"path"`
+	Values  map[string]interface{} `json:"values"`
+	Differs bool                   `json:"differs"`
+}
+
+// Comparison is a field-level matrix of one object compared across namespaces
+type Comparison struct {
+	Kind            string          `json:"kind"`
+	Name            string          `json:"name"`
+	Namespaces      []string        `json:"namespaces"`
+	Missing         []string        `json:"missing"`
+	Fields          []ComparedField `json:"fields"`
+	DifferingFields int             `json:"differingFields"`
+	Identical       bool            `json:"identical"`
+	GeneratedAt     time.Time       `json:"generatedAt"`
+}
+
+// ParseCompareKind validates the kind of a comparison, accepting the same
+// singular, plural and short names as ParseAgeKind
+func ParseCompareKind(raw string) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return "", fmt.Errorf("kind is required (supported: %s)", strings.Join(SupportedCompareKinds, ", "))
+	}
+	kind, err := ParseAgeKind(raw)
+	if err == nil {
+		for _, supported := range SupportedCompareKinds {
+			if kind == supported {
+				return kind, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unsupported kind %q (supported: %s)", strings.TrimSpace(raw), strings.Join(SupportedCompareKinds, ", "))
+}
+
+// ParseCompareNamespaces splits a comma-separated namespace list, dropping
+// blanks and duplicates. At least two and at most MaxCompareNamespaces are
+// required.
+func ParseCompareNamespaces(raw string) ([]string, error) {
+	seen := make(map[string]bool)
+	var namespaces []string
+	for _, namespace := range strings.Split(raw, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" || seen[namespace] {
+			continue
+		}
+		seen[namespace] = true
+		namespaces = append(namespaces, namespace)
+	}
+	if len(namespaces) < 2 {
+		return nil, fmt.Errorf("at least two namespaces are required")
+	}
+	if len(namespaces) > MaxCompareNamespaces {
+		return nil, fmt.Errorf("at most %d namespaces can be compared", MaxCompareNamespaces)
+	}
+	return namespaces, nil
+}
+
+// CompareAcrossNamespaces fetches the named object from each namespace and
+// compares them. An object missing from a namespace is reported as missing;
+// any other fetch error fails the comparison.
+func CompareAcrossNamespaces(ctx context.Context, client kubernetes.Interface, kind, name string, namespaces []string, now time.Time) (*Comparison, error) {
+	objects := make(map[string]runtime.Object, len(namespaces))
+	for _, namespace := range namespaces {
+		var (
+			obj runtime.Object
+			err error
+		)
+		switch kind {
+		case "configmaps":
+			obj, err = client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		case "deployments":
+			obj, err = client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		default:
+			return nil, fmt.Errorf("unsupported kind %q", kind)
+		}
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s/%s: %w", kind, namespace, name, err)
+		}
+		objects[namespace] = obj
+	}
+	return CompareObjects(kind, name, namespaces, objects, now)
+}
+
+// CompareObjects builds the comparison matrix of objects, keyed by namespace.
+// Namespaces without an object are listed as missing and left out of the
+// matrix. Fields are sorted by path.
+func CompareObjects(kind, name string, namespaces []string, objects map[string]runtime.Object, now time.Time) (*Comparison, error) {
+	comparison := &Comparison{
+		Kind:        kind,
+		Name:        name,
+		Namespaces:  namespaces,
+		Missing:     []string{},
+		Fields:      []ComparedField{},
+		GeneratedAt: now.UTC(),
+	}
+
+	flattened := make(map[string]map[string]interface{})
+	paths := make(map[string]bool)
+	for _, namespace := range namespaces {
+		obj, ok := objects[namespace]
+		if !ok || obj == nil {
+			comparison.Missing = append(comparison.Missing, namespace)
+			continue
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s %s/%s: %w", kind, namespace, name, err)
+		}
+
+		fields := make(map[string]interface{})
+		if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+			flattenField("metadata.labels", metadata["labels"], fields)
+			if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+				for key, value := range annotations {
+					if !driftIgnoredAnnotations[key] {
+						flattenField(joinFieldPath("metadata.annotations", key), value, fields)
+					}
+				}
+			}
+		}
+		for _, field := range compareFields[kind] {
+			flattenField(field, content[field], fields)
+		}
+
+		flattened[namespace] = fields
+		for path := range fields {
+			paths[path] = true
+		}
+	}
+
+	sortedPaths := make([]string, 0, len(paths))
+	for path := range paths {
+		sortedPaths = append(sortedPaths, path)
+	}
+	sort.Strings(sortedPaths)
+
+	for _, path := range sortedPaths {
+		field := ComparedField{Path: path, Values: make(map[string]interface{}, len(flattened))}
+		var first interface{}
+		firstSet := false
+		for _, namespace := range namespaces {
+			fields, ok := flattened[namespace]
+			if !ok {
+				continue
+			}
+			value := fields[path]
+			field.Values[namespace] = value
+			if !firstSet {
+				first, firstSet = value, true
+			} else if !reflect.DeepEqual(first, value) {
+				field.Differs = true
+			}
+		}
+		if field.Differs {
+			comparison.DifferingFields++
+		}
+		comparison.Fields = append(comparison.Fields, field)
+	}
+
+	comparison.Identical = comparison.DifferingFields == 0 && len(comparison.Missing) == 0
+	return comparison, nil
+}
+
+// flattenField records the leaves of value under path. Nested maps and lists
+// are expanded; unset values are skipped.
+func flattenField(path string, value interface{}, fields map[string]interface{}) {
+	switch typed := value.(type) {
+	case nil:
+	case map[string]interface{}:
+		for key, nested := range typed {
+			flattenField(joinFieldPath(path, key), nested, fields)
+		}
+	case []interface{}:
+		for i, nested := range typed {
+			flattenField(fmt.Sprintf("%s[%d]", path, i), nested, fields)
+		}
+	default:
+		fields[path] = value
+	}
+}
+
+// ConfigMapDataKey returns the ConfigMap data or binaryData key a field path
+// of a comparison or last-applied diff points at
+func ConfigMapDataKey(path string) (string, bool) {
+	for _, field := range []string{"data", "binaryData"} {
+		rest, ok := strings.CutPrefix(path, field)
+		if !ok {
+			continue
+		}
+		if key, plain := strings.CutPrefix(rest, "."); plain && plainPathSegment.MatchString(key) {
+			return key, true
+		}
+		if strings.HasPrefix(rest, "[") && strings.HasSuffix(rest, "]") {
+			if key, err := strconv.Unquote(rest[1 : len(rest)-1]); err == nil {
+				return key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// joinFieldPath appends a map key to a field path, quoting keys such as
+// file names that contain dots
+func joinFieldPath(path, key string) string {
+	if plainPathSegment.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s[%q]", path, key)
+}
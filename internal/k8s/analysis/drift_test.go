@@ -0,0 +1,124 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func appConfig(namespace string, data map[string]string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-config",
+			Namespace: namespace,
+			Labels:    map[string]string{"app": "shop"},
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": namespace,
+			},
+		},
+		Data: data,
+	}
+}
+
+func comparedField(t *testing.T, comparison *Comparison, path string) ComparedField {
+	for _, field := range comparison.Fields {
+		if field.Path == path {
+			return field
+		}
+	}
+	t.Fatalf("field %s not compared", path)
+	return ComparedField{}
+}
+
+func TestCompareAcrossNamespacesConfigMap(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		appConfig("staging", map[string]string{"LOG_LEVEL": "debug", "app.properties": "port=8080"}),
+		appConfig("prod", map[string]string{"LOG_LEVEL": "info", "app.properties": "port=8080"}),
+	)
+
+	comparison, err := CompareAcrossNamespaces(context.Background(), client, "configmaps", "app-config", []string{"staging", "prod", "dev"}, time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"dev"}, comparison.Missing)
+	assert.False(t, comparison.Identical)
+	assert.Equal(t, 1, comparison.DifferingFields)
+
+	logLevel := comparedField(t, comparison, "data.LOG_LEVEL")
+	assert.True(t, logLevel.Differs)
+	assert.Equal(t, map[string]interface{}{"staging": "debug", "prod": "info"}, logLevel.Values)
+
+	properties := comparedField(t, comparison, `data["app.properties"]`)
+	assert.False(t, properties.Differs)
+	assert.False(t, comparedField(t, comparison, "metadata.labels.app").Differs)
+
+	for _, field := range comparison.Fields {
+		assert.NotContains(t, field.Path, "last-applied-configuration")
+	}
+}
+
+func TestCompareObjectsDeployment(t *testing.T) {
+	deployment := func(namespace, image string) *appsv1.Deployment {
+		replicas := int32(2)
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "web", Image: image}},
+				}},
+			},
+		}
+	}
+
+	comparison, err := CompareObjects("deployments", "web", []string{"a", "b"}, map[string]runtime.Object{
+		"a": deployment("a", "web:1.0"),
+		"b": deployment("b", "web:1.1"),
+	}, time.Now())
+	require.NoError(t, err)
+
+	assert.Empty(t, comparison.Missing)
+	assert.Equal(t, 1, comparison.DifferingFields)
+	assert.True(t, comparedField(t, comparison, "spec.template.spec.containers[0].image").Differs)
+	assert.False(t, comparedField(t, comparison, "spec.replicas").Differs)
+}
+
+func TestParseCompareArguments(t *testing.T) {
+	kind, err := ParseCompareKind("cm")
+	require.NoError(t, err)
+	assert.Equal(t, "configmaps", kind)
+	_, err = ParseCompareKind("secret")
+	assert.Error(t, err)
+	_, err = ParseCompareKind("")
+	assert.Error(t, err)
+
+	namespaces, err := ParseCompareNamespaces(" a, b,,a ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, namespaces)
+	_, err = ParseCompareNamespaces("a")
+	assert.Error(t, err)
+}
+
+func TestConfigMapDataKey(t *testing.T) {
+	for path, expected := range map[string]string{
+		"data.LOG_LEVEL":             "LOG_LEVEL",
+		`data["app.properties"]`:     "app.properties",
+		"binaryData.keystore":        "keystore",
+		`binaryData["tls.jks"]`:      "tls.jks",
+		"data":                       "",
+		"metadata.labels.app":        "",
+		"dataset.LOG_LEVEL":          "",
+		"spec.template.spec.volumes": "",
+	} {
+		key, ok := ConfigMapDataKey(path)
+		assert.Equal(t, expected != "", ok, path)
+		assert.Equal(t, expected, key, path)
+	}
+}
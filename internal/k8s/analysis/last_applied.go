@@ -0,0 +1,246 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SupportedLastAppliedKinds lists the kinds that can be diffed against their
+// last-applied configuration. Secrets are left out so the diff never echoes
+// secret data.
+var SupportedLastAppliedKinds = []string{
+	"configmaps", "cronjobs", "daemonsets", "deployments",
+	"ingresses", "jobs", "services", "statefulsets",
+}
+
+// Last-applied change types
+const (
+	LastAppliedChanged = "changed"
+	LastAppliedRemoved = "removed"
+)
+
+// lastAppliedIgnoredMetadata are metadata fields the API server owns. Applied
+// manifests sometimes carry them, e.g. creationTimestamp: null from a dry run.
+var lastAppliedIgnoredMetadata = map[string]bool{
+	"creationTimestamp": true,
+	"generation":        true,
+	"managedFields":     true,
+	"resourceVersion":   true,
+	"selfLink":          true,
+	"uid":               true,
+}
+
+// LastAppliedChange is a declared field whose live value no longer matches
+// the last-applied configuration. Live is nil for removed fields.
+type LastAppliedChange struct {
+	Path    string      `json:"path"`
+	Type    string      `json:"type"`
+	Applied interface{} `json:"applied"`
+	Live    interface{} `json:"live"`
+}
+
+// LastAppliedDiff compares a live object with its
+// kubectl.kubernetes.io/last-applied-configuration annotation. Only fields
+// the applied manifest declares are compared, so defaults filled in by the
+// API server are not reported as drift.
+type LastAppliedDiff struct {
+	Kind           string              `json:"kind"`
+	Namespace      string              `json:"namespace"`
+	Name           string              `json:"name"`
+	HasLastApplied bool                `json:"hasLastApplied"`
+	Message        string              `json:"message,omitempty"`
+	Changes        []LastAppliedChange `json:"changes"`
+	Drifted        bool                `json:"drifted"`
+	GeneratedAt    time.Time           `json:"generatedAt"`
+}
+
+// ParseLastAppliedKind validates the kind of a last-applied diff, accepting
+// the same singular, plural and short names as ParseAgeKind
+func ParseLastAppliedKind(raw string) (string, error) {
+	kind, err := ParseAgeKind(raw)
+	if err == nil && strings.TrimSpace(raw) != "" {
+		for _, supported := range SupportedLastAppliedKinds {
+			if kind == supported {
+				return kind, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unsupported kind %q (supported: %s)", strings.TrimSpace(raw), strings.Join(SupportedLastAppliedKinds, ", "))
+}
+
+// DiffLastApplied fetches an object and diffs it against its last-applied
+// configuration. Fetch errors are returned unwrapped so callers can test
+// them with apierrors.IsNotFound.
+func DiffLastApplied(ctx context.Context, client kubernetes.Interface, kind, namespace, name string, now time.Time) (*LastAppliedDiff, error) {
+	var (
+		obj runtime.Object
+		err error
+	)
+	switch kind {
+	case "configmaps":
+		obj, err = client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "cronjobs":
+		obj, err = client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "daemonsets":
+		obj, err = client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "deployments":
+		obj, err = client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "ingresses":
+		obj, err = client.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "jobs":
+		obj, err = client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "services":
+		obj, err = client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "statefulsets":
+		obj, err = client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return DiffAgainstLastApplied(kind, obj, now)
+}
+
+// DiffAgainstLastApplied diffs obj against its last-applied configuration.
+// An object without the annotation, e.g. one created with kubectl create or
+// by a controller, yields a diff with HasLastApplied false and a message.
+// Both sides are normalized through JSON so numbers compare equal regardless
+// of their Go type, and resource quantities compare by value, so 0.5 and 500m
+// are the same CPU request.
+func DiffAgainstLastApplied(kind string, obj runtime.Object, now time.Time) (*LastAppliedDiff, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s metadata: %w", kind, err)
+	}
+	diff := &LastAppliedDiff{
+		Kind:        kind,
+		Namespace:   accessor.GetNamespace(),
+		Name:        accessor.GetName(),
+		Changes:     []LastAppliedChange{},
+		GeneratedAt: now.UTC(),
+	}
+
+	raw := accessor.GetAnnotations()[v1.LastAppliedConfigAnnotation]
+	if strings.TrimSpace(raw) == "" {
+		diff.Message = fmt.Sprintf("%s has no %s annotation; it was not created or updated with kubectl apply, so there is no declared state to diff against", accessor.GetName(), v1.LastAppliedConfigAnnotation)
+		return diff, nil
+	}
+	diff.HasLastApplied = true
+
+	var applied map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &applied); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", v1.LastAppliedConfigAnnotation, err)
+	}
+
+	// Round-trip the live object through JSON so both sides use the same types
+	encoded, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", kind, err)
+	}
+	var live map[string]interface{}
+	if err := json.Unmarshal(encoded, &live); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", kind, err)
+	}
+
+	for key, value := range applied {
+		switch key {
+		case "apiVersion", "kind", "status":
+			// Typed objects are fetched without type metadata and status is
+			// never declared
+			continue
+		case "metadata":
+			declared, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			liveMetadata, _ := live["metadata"].(map[string]interface{})
+			for field, nested := range declared {
+				if lastAppliedIgnoredMetadata[field] {
+					continue
+				}
+				liveValue, exists := liveMetadata[field]
+				diffDeclared(joinFieldPath("metadata", field), nested, liveValue, exists, diff)
+			}
+		default:
+			liveValue, exists := live[key]
+			diffDeclared(key, value, liveValue, exists, diff)
+		}
+	}
+
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		return diff.Changes[i].Path < diff.Changes[j].Path
+	})
+	diff.Drifted = len(diff.Changes) > 0
+	return diff, nil
+}
+
+// diffDeclared records the declared leaves under path whose live value
+// differs. Lists of the same length are compared item by item; a list whose
+// length changed is reported as a whole. Declared nulls are skipped.
+func diffDeclared(path string, applied, live interface{}, exists bool, diff *LastAppliedDiff) {
+	if applied == nil {
+		return
+	}
+	if !exists || live == nil {
+		diff.Changes = append(diff.Changes, LastAppliedChange{Path: path, Type: LastAppliedRemoved, Applied: applied})
+		return
+	}
+
+	switch declared := applied.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for key, nested := range declared {
+			liveValue, exists := liveMap[key]
+			diffDeclared(joinFieldPath(path, key), nested, liveValue, exists, diff)
+		}
+		return
+	case []interface{}:
+		liveList, ok := live.([]interface{})
+		if !ok || len(liveList) != len(declared) {
+			break
+		}
+		for i, nested := range declared {
+			diffDeclared(fmt.Sprintf("%s[%d]", path, i), nested, liveList[i], true, diff)
+		}
+		return
+	default:
+		if reflect.DeepEqual(applied, live) || sameQuantity(path, applied, live) {
+			return
+		}
+	}
+
+	diff.Changes = append(diff.Changes, LastAppliedChange{Path: path, Type: LastAppliedChanged, Applied: applied, Live: live})
+}
+
+// sameQuantity reports whether two resource values under a resources field
+// are the same quantity written differently
+func sameQuantity(path string, applied, live interface{}) bool {
+	if !strings.Contains(path, "resources.") {
+		return false
+	}
+	a, err := resource.ParseQuantity(fmt.Sprint(applied))
+	if err != nil {
+		return false
+	}
+	b, err := resource.ParseQuantity(fmt.Sprint(live))
+	if err != nil {
+		return false
+	}
+	return a.Cmp(b) == 0
+}
@@ -0,0 +1,166 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const appliedWebDeployment = `{"apiVersion":"apps/v1","kind":"Deployment",
+"metadata":{"name":"web","namespace":"shop","creationTimestamp":null,"labels":{"app":"web","tier":"frontend"}},
+"spec":{"replicas":2,"selector":{"matchLabels":{"app":"web"}},
+"template":{"metadata":{"labels":{"app":"web"}},"spec":{"containers":[
+{"name":"web","image":"web:1.0","args":["--port","8080"],"resources":{"requests":{"cpu":"0.5","memory":"128Mi"}}}]}}}}`
+
+func liveWebDeployment(annotations map[string]string) *appsv1.Deployment {
+	replicas := int32(5)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "web",
+			Namespace:         "shop",
+			UID:               "uid-web",
+			Generation:        4,
+			CreationTimestamp: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Labels:            map[string]string{"app": "web", "edited": "true"},
+			Annotations:       annotations,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyAlways,
+					Containers: []v1.Container{{
+						Name:                     "web",
+						Image:                    "web:1.1",
+						Args:                     []string{"--port", "8080", "--debug"},
+						TerminationMessagePath:   "/dev/termination-log",
+						TerminationMessagePolicy: v1.TerminationMessageReadFile,
+						Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("500m"),
+							v1.ResourceMemory: resource.MustParse("128Mi"),
+						}},
+					}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 5},
+	}
+}
+
+func lastAppliedChange(t *testing.T, diff *LastAppliedDiff, path string) LastAppliedChange {
+	for _, change := range diff.Changes {
+		if change.Path == path {
+			return change
+		}
+	}
+	t.Fatalf("no change at %s", path)
+	return LastAppliedChange{}
+}
+
+func TestDiffAgainstLastAppliedDeployment(t *testing.T) {
+	deployment := liveWebDeployment(map[string]string{v1.LastAppliedConfigAnnotation: appliedWebDeployment})
+
+	diff, err := DiffAgainstLastApplied("deployments", deployment, time.Now())
+	require.NoError(t, err)
+
+	assert.True(t, diff.HasLastApplied)
+	assert.True(t, diff.Drifted)
+	assert.Empty(t, diff.Message)
+
+	paths := make([]string, 0, len(diff.Changes))
+	for _, change := range diff.Changes {
+		paths = append(paths, change.Path)
+	}
+	// Defaults, server-owned metadata, status and the live-only label are not
+	// drift, and 0.5 CPU equals 500m
+	assert.Equal(t, []string{
+		"metadata.labels.tier",
+		"spec.replicas",
+		"spec.template.spec.containers[0].args",
+		"spec.template.spec.containers[0].image",
+	}, paths)
+
+	replicas := lastAppliedChange(t, diff, "spec.replicas")
+	assert.Equal(t, LastAppliedChanged, replicas.Type)
+	assert.Equal(t, float64(2), replicas.Applied)
+	assert.Equal(t, float64(5), replicas.Live)
+
+	tier := lastAppliedChange(t, diff, "metadata.labels.tier")
+	assert.Equal(t, LastAppliedRemoved, tier.Type)
+	assert.Nil(t, tier.Live)
+
+	args := lastAppliedChange(t, diff, "spec.template.spec.containers[0].args")
+	assert.Equal(t, []interface{}{"--port", "8080"}, args.Applied)
+	assert.Equal(t, []interface{}{"--port", "8080", "--debug"}, args.Live)
+}
+
+func TestDiffAgainstLastAppliedInSync(t *testing.T) {
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-config",
+			Namespace: "shop",
+			Annotations: map[string]string{
+				v1.LastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"app-config","namespace":"shop"},"data":{"app.properties":"port=8080"}}`,
+			},
+		},
+		Data: map[string]string{"app.properties": "port=8080"},
+	}
+
+	diff, err := DiffAgainstLastApplied("configmaps", configMap, time.Now())
+	require.NoError(t, err)
+	assert.True(t, diff.HasLastApplied)
+	assert.False(t, diff.Drifted)
+	assert.Empty(t, diff.Changes)
+
+	configMap.Data["app.properties"] = "port=9090"
+	diff, err = DiffAgainstLastApplied("configmaps", configMap, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "port=9090", lastAppliedChange(t, diff, `data["app.properties"]`).Live)
+}
+
+func TestDiffLastApplied(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		liveWebDeployment(map[string]string{v1.LastAppliedConfigAnnotation: appliedWebDeployment}),
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"}},
+	)
+
+	diff, err := DiffLastApplied(context.Background(), client, "deployments", "shop", "web", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "shop", diff.Namespace)
+	assert.True(t, diff.Drifted)
+
+	// An object that was never applied has nothing to diff against
+	diff, err = DiffLastApplied(context.Background(), client, "statefulsets", "shop", "db", time.Now())
+	require.NoError(t, err)
+	assert.False(t, diff.HasLastApplied)
+	assert.False(t, diff.Drifted)
+	assert.Contains(t, diff.Message, "kubectl apply")
+
+	_, err = DiffLastApplied(context.Background(), client, "deployments", "shop", "missing", time.Now())
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestParseLastAppliedKind(t *testing.T) {
+	kind, err := ParseLastAppliedKind("deploy")
+	require.NoError(t, err)
+	assert.Equal(t, "deployments", kind)
+	kind, err = ParseLastAppliedKind("ConfigMap")
+	require.NoError(t, err)
+	assert.Equal(t, "configmaps", kind)
+
+	for _, raw := range []string{"", "secrets", "pods"} {
+		_, err := ParseLastAppliedKind(raw)
+		assert.Error(t, err, raw)
+	}
+}
@@ -0,0 +1,339 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Orphan candidate kinds accepted by FindOrphans
+const (
+	KindConfigMap = "configmaps"
+	KindSecret    = "secrets"
+	KindService   = "services"
+	KindPVC       = "persistentvolumeclaims"
+)
+
+// SupportedOrphanKinds lists every kind scanned when no kinds are requested
+var SupportedOrphanKinds = []string{KindConfigMap, KindSecret, KindService, KindPVC}
+
+// Objects that Kubernetes or common tooling manage implicitly and that are never
+// referenced the way user workloads reference their configuration
+var (
+	ignoredConfigMaps = map[string]bool{
+		"kube-root-ca.crt": true,
+	}
+	ignoredSecretTypes = map[v1.SecretType]bool{
+		v1.SecretTypeServiceAccountToken: true,
+		"helm.sh/release.v1":             true,
+		v1.SecretTypeBootstrapToken:      true,
+	}
+)
+
+// OrphanedResource is an object that appears to be unused
+type OrphanedResource struct {
+	Kind              string    `json:"kind"`
+	Namespace         string    `json:"namespace"`
+	Name              string    `json:"name"`
+	Reason            string    `json:"reason"`
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+}
+
+// OrphanReport is the result of an orphan scan. Objects are only flagged; nothing is deleted.
+type OrphanReport struct {
+	Orphans     []OrphanedResource `json:"orphans"`
+	Scanned     map[string]int     `json:"scanned"`
+	Namespace   string             `json:"namespace,omitempty"`
+	GeneratedAt time.Time          `json:"generatedAt"`
+}
+
+// OrphanFinder detects ConfigMaps, Secrets, Services and PVCs that have no owner
+// and are not referenced by any workload
+type OrphanFinder struct {
+	logger *zap.Logger
+	client kubernetes.Interface
+}
+
+// NewOrphanFinder creates a new orphan finder
+func NewOrphanFinder(logger *zap.Logger, client kubernetes.Interface) *OrphanFinder {
+	return &OrphanFinder{
+		logger: logger,
+		client: client,
+	}
+}
+
+// ParseOrphanKinds validates a comma-separated kinds list, accepting singular,
+// plural and short names. An empty list selects every supported kind.
+func ParseOrphanKinds(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return SupportedOrphanKinds, nil
+	}
+
+	aliases := map[string]string{
+		"configmap": KindConfigMap, "configmaps": KindConfigMap, "cm": KindConfigMap,
+		"secret": KindSecret, "secrets": KindSecret,
+		"service": KindService, "services": KindService, "svc": KindService,
+		"persistentvolumeclaim": KindPVC, "persistentvolumeclaims": KindPVC, "pvc": KindPVC, "pvcs": KindPVC,
+	}
+
+	seen := make(map[string]bool)
+	var kinds []string
+	for _, part := range strings.Split(raw, ",") {
+		kind, ok := aliases[strings.ToLower(strings.TrimSpace(part))]
+		if !ok {
+			return nil, fmt.Errorf("unsupported kind %q (supported: %s)", strings.TrimSpace(part), strings.Join(SupportedOrphanKinds, ", "))
+		}
+		if !seen[kind] {
+			seen[kind] = true
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds, nil
+}
+
+// FindOrphans scans the requested kinds in a namespace (all namespaces when
+// empty) and returns the objects that look unused
+func (f *OrphanFinder) FindOrphans(ctx context.Context, namespace string, kinds []string) (*OrphanReport, error) {
+	pods, err := f.client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	refs, err := f.collectReferences(ctx, namespace, pods.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OrphanReport{
+		Orphans:     []OrphanedResource{},
+		Scanned:     make(map[string]int),
+		Namespace:   namespace,
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for _, kind := range kinds {
+		wg.Add(1)
+		go func(kind string) {
+			defer wg.Done()
+
+			var orphans []OrphanedResource
+			var scanned int
+			var scanErr error
+			switch kind {
+			case KindConfigMap:
+				orphans, scanned, scanErr = f.scanConfigMaps(ctx, namespace, refs)
+			case KindSecret:
+				orphans, scanned, scanErr = f.scanSecrets(ctx, namespace, refs)
+			case KindService:
+				orphans, scanned, scanErr = f.scanServices(ctx, namespace, pods.Items)
+			case KindPVC:
+				orphans, scanned, scanErr = f.scanPVCs(ctx, namespace, refs)
+			default:
+				scanErr = fmt.Errorf("unsupported kind %q", kind)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if scanErr != nil {
+				if firstErr == nil {
+					firstErr = scanErr
+				}
+				return
+			}
+			report.Scanned[kind] = scanned
+			report.Orphans = append(report.Orphans, orphans...)
+		}(kind)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(report.Orphans, func(i, j int) bool {
+		a, b := report.Orphans[i], report.Orphans[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	f.logger.Debug("Orphan scan completed",
+		zap.String("namespace", namespace),
+		zap.Strings("kinds", kinds),
+		zap.Int("orphans", len(report.Orphans)))
+
+	return report, nil
+}
+
+// collectReferences gathers references from pods, workload templates, service
+// accounts and ingresses, so scaled-down or suspended workloads still count as users
+func (f *OrphanFinder) collectReferences(ctx context.Context, namespace string, pods []v1.Pod) (*References, error) {
+	refs := NewReferences()
+	for i := range pods {
+		refs.AddPod(&pods[i])
+	}
+
+	deployments, err := f.client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		refs.AddPodSpec(d.Namespace, &d.Spec.Template.Spec)
+	}
+
+	statefulSets, err := f.client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, sts := range statefulSets.Items {
+		refs.AddPodSpec(sts.Namespace, &sts.Spec.Template.Spec)
+		// PVCs created from claim templates are named <template>-<statefulset>-<ordinal>
+		// and are kept across scale-downs, so every ordinal counts as referenced
+		for _, claim := range sts.Spec.VolumeClaimTemplates {
+			refs.PVCPrefixes = append(refs.PVCPrefixes, refKey(sts.Namespace, claim.Name+"-"+sts.Name+"-"))
+		}
+	}
+
+	daemonSets, err := f.client.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, ds := range daemonSets.Items {
+		refs.AddPodSpec(ds.Namespace, &ds.Spec.Template.Spec)
+	}
+
+	jobs, err := f.client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, job := range jobs.Items {
+		refs.AddPodSpec(job.Namespace, &job.Spec.Template.Spec)
+	}
+
+	cronJobs, err := f.client.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	for _, cj := range cronJobs.Items {
+		refs.AddPodSpec(cj.Namespace, &cj.Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	serviceAccounts, err := f.client.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	for i := range serviceAccounts.Items {
+		refs.AddServiceAccount(&serviceAccounts.Items[i])
+	}
+
+	ingresses, err := f.client.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %w", err)
+	}
+	for i := range ingresses.Items {
+		refs.AddIngress(&ingresses.Items[i])
+	}
+
+	return refs, nil
+}
+
+func (f *OrphanFinder) scanConfigMaps(ctx context.Context, namespace string, refs *References) ([]OrphanedResource, int, error) {
+	configMaps, err := f.client.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list configmaps: %w", err)
+	}
+
+	var orphans []OrphanedResource
+	for _, cm := range configMaps.Items {
+		if len(cm.OwnerReferences) > 0 || ignoredConfigMaps[cm.Name] {
+			continue
+		}
+		if !refs.ConfigMaps[refKey(cm.Namespace, cm.Name)] {
+			orphans = append(orphans, newOrphan(KindConfigMap, cm.ObjectMeta, "not referenced by any pod or workload"))
+		}
+	}
+	return orphans, len(configMaps.Items), nil
+}
+
+func (f *OrphanFinder) scanSecrets(ctx context.Context, namespace string, refs *References) ([]OrphanedResource, int, error) {
+	secrets, err := f.client.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	var orphans []OrphanedResource
+	for _, secret := range secrets.Items {
+		if len(secret.OwnerReferences) > 0 || ignoredSecretTypes[secret.Type] {
+			continue
+		}
+		if !refs.Secrets[refKey(secret.Namespace, secret.Name)] {
+			orphans = append(orphans, newOrphan(KindSecret, secret.ObjectMeta, "not referenced by any pod, workload, service account or ingress"))
+		}
+	}
+	return orphans, len(secrets.Items), nil
+}
+
+func (f *OrphanFinder) scanServices(ctx context.Context, namespace string, pods []v1.Pod) ([]OrphanedResource, int, error) {
+	services, err := f.client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	var orphans []OrphanedResource
+	for _, svc := range services.Items {
+		// Services without a selector have manually managed endpoints and ExternalName
+		// services never have endpoints, so neither can be judged by pod matching
+		if len(svc.OwnerReferences) > 0 || svc.Spec.Type == v1.ServiceTypeExternalName || len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if len(PodsMatchingSelector(pods, svc.Namespace, svc.Spec.Selector)) == 0 {
+			orphans = append(orphans, newOrphan(KindService, svc.ObjectMeta, "selector matches no active pods"))
+		}
+	}
+	return orphans, len(services.Items), nil
+}
+
+func (f *OrphanFinder) scanPVCs(ctx context.Context, namespace string, refs *References) ([]OrphanedResource, int, error) {
+	claims, err := f.client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list persistentvolumeclaims: %w", err)
+	}
+
+	var orphans []OrphanedResource
+	for _, pvc := range claims.Items {
+		if len(pvc.OwnerReferences) > 0 {
+			continue
+		}
+		if !refs.HasPVC(pvc.Namespace, pvc.Name) {
+			orphans = append(orphans, newOrphan(KindPVC, pvc.ObjectMeta, "not mounted by any pod or workload"))
+		}
+	}
+	return orphans, len(claims.Items), nil
+}
+
+func newOrphan(kind string, meta metav1.ObjectMeta, reason string) OrphanedResource {
+	return OrphanedResource{
+		Kind:              kind,
+		Namespace:         meta.Namespace,
+		Name:              meta.Name,
+		Reason:            reason,
+		CreationTimestamp: meta.CreationTimestamp.Time,
+	}
+}
@@ -0,0 +1,134 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func orphanTestPod(name string, labels map[string]string, spec v1.PodSpec) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec:       spec,
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+func TestFindOrphansUnreferencedConfigMap(t *testing.T) {
+	pod := orphanTestPod("web", nil, v1.PodSpec{
+		Volumes: []v1.Volume{{
+			Name: "config",
+			VolumeSource: v1.VolumeSource{
+				ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "mounted"}},
+			},
+		}},
+		Containers: []v1.Container{{
+			Name: "app",
+			EnvFrom: []v1.EnvFromSource{{
+				ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "env"}},
+			}},
+		}},
+	})
+
+	client := fake.NewSimpleClientset(
+		pod,
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "mounted", Namespace: "default"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "env", Namespace: "default"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "stale", Namespace: "default"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kube-root-ca.crt", Namespace: "default"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:            "owned",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web"}},
+		}},
+	)
+
+	finder := NewOrphanFinder(zaptest.NewLogger(t), client)
+	report, err := finder.FindOrphans(context.Background(), "", []string{KindConfigMap})
+	require.NoError(t, err)
+
+	require.Len(t, report.Orphans, 1)
+	assert.Equal(t, "stale", report.Orphans[0].Name)
+	assert.Equal(t, KindConfigMap, report.Orphans[0].Kind)
+	assert.NotEmpty(t, report.Orphans[0].Reason)
+	assert.Equal(t, 5, report.Scanned[KindConfigMap])
+}
+
+func TestFindOrphansServiceWithNoMatchingPods(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		orphanTestPod("web-1", map[string]string{"app": "web"}, v1.PodSpec{}),
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "default"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "legacy"}},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeExternalName, ExternalName: "example.com"},
+		},
+	)
+
+	finder := NewOrphanFinder(zaptest.NewLogger(t), client)
+	report, err := finder.FindOrphans(context.Background(), "default", []string{KindService})
+	require.NoError(t, err)
+
+	require.Len(t, report.Orphans, 1)
+	assert.Equal(t, "legacy", report.Orphans[0].Name)
+	assert.Equal(t, KindService, report.Orphans[0].Kind)
+}
+
+func TestFindOrphansScaledDownWorkloadsKeepReferences(t *testing.T) {
+	replicas := int32(0)
+	template := v1.PodTemplateSpec{Spec: v1.PodSpec{
+		Containers: []v1.Container{{
+			Name: "app",
+			Env: []v1.EnvVar{{
+				Name: "PASSWORD",
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "db"}, Key: "password"},
+				},
+			}},
+		}},
+	}}
+
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas, Template: template},
+		},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"}, Type: v1.SecretTypeServiceAccountToken},
+		&v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "scratch", Namespace: "default"}},
+	)
+
+	finder := NewOrphanFinder(zaptest.NewLogger(t), client)
+	report, err := finder.FindOrphans(context.Background(), "", SupportedOrphanKinds)
+	require.NoError(t, err)
+
+	require.Len(t, report.Orphans, 1)
+	assert.Equal(t, KindPVC, report.Orphans[0].Kind)
+	assert.Equal(t, "scratch", report.Orphans[0].Name)
+}
+
+func TestParseOrphanKinds(t *testing.T) {
+	kinds, err := ParseOrphanKinds("cm, svc,configmaps")
+	require.NoError(t, err)
+	assert.Equal(t, []string{KindConfigMap, KindService}, kinds)
+
+	kinds, err = ParseOrphanKinds("")
+	require.NoError(t, err)
+	assert.Equal(t, SupportedOrphanKinds, kinds)
+
+	_, err = ParseOrphanKinds("nodes")
+	assert.Error(t, err)
+}
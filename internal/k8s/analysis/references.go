@@ -0,0 +1,151 @@
+package analysis
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// References is the set of namespaced objects referenced by workloads, keyed by
+// "namespace/name"
+type References struct {
+	ConfigMaps map[string]bool
+	Secrets    map[string]bool
+	PVCs       map[string]bool
+
+	// PVCPrefixes holds "namespace/prefix" entries for claims generated from
+	// StatefulSet volume claim templates
+	PVCPrefixes []string
+}
+
+// NewReferences creates an empty reference set
+func NewReferences() *References {
+	return &References{
+		ConfigMaps: make(map[string]bool),
+		Secrets:    make(map[string]bool),
+		PVCs:       make(map[string]bool),
+	}
+}
+
+// AddPod records the ConfigMaps, Secrets and PVCs a pod uses
+func (r *References) AddPod(pod *v1.Pod) {
+	r.AddPodSpec(pod.Namespace, &pod.Spec)
+}
+
+// AddPodSpec records the ConfigMaps, Secrets and PVCs a pod spec (or workload
+// pod template) uses through volumes, projected sources, env, envFrom and image
+// pull secrets
+func (r *References) AddPodSpec(ns string, spec *v1.PodSpec) {
+	for _, volume := range spec.Volumes {
+		switch {
+		case volume.ConfigMap != nil:
+			r.ConfigMaps[refKey(ns, volume.ConfigMap.Name)] = true
+		case volume.Secret != nil:
+			r.Secrets[refKey(ns, volume.Secret.SecretName)] = true
+		case volume.PersistentVolumeClaim != nil:
+			r.PVCs[refKey(ns, volume.PersistentVolumeClaim.ClaimName)] = true
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					r.ConfigMaps[refKey(ns, source.ConfigMap.Name)] = true
+				}
+				if source.Secret != nil {
+					r.Secrets[refKey(ns, source.Secret.Name)] = true
+				}
+			}
+		}
+	}
+
+	for _, pullSecret := range spec.ImagePullSecrets {
+		r.Secrets[refKey(ns, pullSecret.Name)] = true
+	}
+
+	containers := make([]v1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	containers = append(containers, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				r.ConfigMaps[refKey(ns, envFrom.ConfigMapRef.Name)] = true
+			}
+			if envFrom.SecretRef != nil {
+				r.Secrets[refKey(ns, envFrom.SecretRef.Name)] = true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				r.ConfigMaps[refKey(ns, env.ValueFrom.ConfigMapKeyRef.Name)] = true
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				r.Secrets[refKey(ns, env.ValueFrom.SecretKeyRef.Name)] = true
+			}
+		}
+	}
+}
+
+// AddServiceAccount records the Secrets attached to a service account
+func (r *References) AddServiceAccount(sa *v1.ServiceAccount) {
+	for _, secret := range sa.Secrets {
+		r.Secrets[refKey(sa.Namespace, secret.Name)] = true
+	}
+	for _, pullSecret := range sa.ImagePullSecrets {
+		r.Secrets[refKey(sa.Namespace, pullSecret.Name)] = true
+	}
+}
+
+// AddIngress records the TLS Secrets used by an ingress
+func (r *References) AddIngress(ingress *networkingv1.Ingress) {
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName != "" {
+			r.Secrets[refKey(ingress.Namespace, tls.SecretName)] = true
+		}
+	}
+}
+
+// HasPVC reports whether a PVC is mounted or generated by a known workload
+func (r *References) HasPVC(namespace, name string) bool {
+	key := refKey(namespace, name)
+	if r.PVCs[key] {
+		return true
+	}
+	for _, prefix := range r.PVCPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// PodsMatchingSelector returns the active pods in a namespace selected by the given labels
+func PodsMatchingSelector(pods []v1.Pod, namespace string, selector map[string]string) []v1.Pod {
+	if len(selector) == 0 {
+		return nil
+	}
+
+	sel := labels.SelectorFromSet(selector)
+	var matched []v1.Pod
+	for _, pod := range pods {
+		if pod.Namespace != namespace || !isActivePod(&pod) {
+			continue
+		}
+		if sel.Matches(labels.Set(pod.Labels)) {
+			matched = append(matched, pod)
+		}
+	}
+	return matched
+}
+
+// isActivePod reports whether a pod still holds on to the objects it references
+func isActivePod(pod *v1.Pod) bool {
+	return pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed
+}
+
+// refKey builds the namespace/name key used in reference sets
+func refKey(namespace, name string) string {
+	return namespace + "/" + name
+}
@@ -0,0 +1,385 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Bounds of the owner and child chains followed by BuildRelationshipGraph
+const (
+	DefaultRelationshipDepth = 3
+	MaxRelationshipDepth     = 5
+)
+
+// Relationship edge types. Edges point from the owner, the referencing object
+// or the selecting Service to the other end.
+const (
+	RelationshipOwns       = "owns"
+	RelationshipReferences = "references"
+	RelationshipSelects    = "selects"
+)
+
+// ErrCacheNotSynced is returned when the informer cache of the requested
+// kind has not synced yet
+var ErrCacheNotSynced = errors.New("informer cache has not synced yet")
+
+// ObjectLister lists cached objects by lower-case plural resource name, such
+// as the informer manager's ListResource. ok is false for an unknown resource
+// or a cache that has not synced.
+type ObjectLister interface {
+	ListResource(resource string) ([]interface{}, bool)
+}
+
+// relationshipKinds maps the namespaced resources held by the informer
+// caches to their kinds
+var relationshipKinds = map[string]string{
+	"pods":                   "Pod",
+	"deployments":            "Deployment",
+	"statefulsets":           "StatefulSet",
+	"daemonsets":             "DaemonSet",
+	"replicasets":            "ReplicaSet",
+	"jobs":                   "Job",
+	"cronjobs":               "CronJob",
+	"services":               "Service",
+	"configmaps":             "ConfigMap",
+	"secrets":                "Secret",
+	"ingresses":              "Ingress",
+	"persistentvolumeclaims": "PersistentVolumeClaim",
+}
+
+// RelationshipNode is an object in a relationship graph. Missing is set for
+// objects that are referenced but not in the cache, such as a deleted owner
+// or a ConfigMap a pod expects.
+type RelationshipNode struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Missing   bool   `json:"missing,omitempty"`
+}
+
+// RelationshipEdge links two nodes of a relationship graph by their IDs
+type RelationshipEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// RelationshipGraph holds the owners, children and references of a root
+// object. Truncated is set when the owner or child chains go deeper than
+// Depth.
+type RelationshipGraph struct {
+	Root      string             `json:"root"`
+	Depth     int                `json:"depth"`
+	Truncated bool               `json:"truncated"`
+	Nodes     []RelationshipNode `json:"nodes"`
+	Edges     []RelationshipEdge `json:"edges"`
+}
+
+// ParseRelationshipKind validates the kind of a relationship graph root,
+// accepting singular, plural and short names of the namespaced kinds held by
+// the informer caches
+func ParseRelationshipKind(raw string) (string, error) {
+	kind, err := ParseAgeKind(raw)
+	if err == nil && strings.TrimSpace(raw) != "" {
+		if _, ok := relationshipKinds[kind]; ok {
+			return kind, nil
+		}
+	}
+	supported := make([]string, 0, len(relationshipKinds))
+	for resource := range relationshipKinds {
+		supported = append(supported, resource)
+	}
+	sort.Strings(supported)
+	return "", fmt.Errorf("unsupported kind %q (supported: %s)", strings.TrimSpace(raw), strings.Join(supported, ", "))
+}
+
+// RelationshipResourceKind returns the kind of a resource returned by
+// ParseRelationshipKind, such as Deployment for deployments
+func RelationshipResourceKind(resource string) string {
+	return relationshipKinds[resource]
+}
+
+// relationshipObject is a cached object with its kind
+type relationshipObject struct {
+	kind   string
+	meta   metav1.Object
+	object interface{}
+}
+
+// relationshipID identifies an object in a graph
+func relationshipID(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// relationshipBuilder accumulates the nodes and edges of a graph
+type relationshipBuilder struct {
+	namespace string
+	byID      map[string]relationshipObject
+	byOwner   map[types.UID][]relationshipObject
+	nodes     map[string]RelationshipNode
+	edges     map[RelationshipEdge]bool
+}
+
+// node adds a node for kind/name, marking it missing when it is not cached,
+// and returns its ID
+func (b *relationshipBuilder) node(kind, name string) string {
+	id := relationshipID(kind, b.namespace, name)
+	if _, exists := b.nodes[id]; !exists {
+		_, cached := b.byID[id]
+		b.nodes[id] = RelationshipNode{ID: id, Kind: kind, Namespace: b.namespace, Name: name, Missing: !cached}
+	}
+	return id
+}
+
+func (b *relationshipBuilder) edge(from, to, edgeType string) {
+	b.edges[RelationshipEdge{From: from, To: to, Type: edgeType}] = true
+}
+
+// BuildRelationshipGraph builds the relationship graph of a namespaced object
+// from the informer caches, so it costs no API calls. It follows the
+// ownerReference chain up and the owned objects down for at most depth
+// levels, and adds the ConfigMaps, Secrets, PVCs and Services the root's spec
+// links to: those its pod template uses, the Services selecting its pods,
+// the Services and TLS Secrets of an Ingress, the pods a Service selects, and
+// for a ConfigMap, Secret or PVC the top-level objects using it. Kinds whose
+// cache has not synced are left out of the graph.
+func BuildRelationshipGraph(lister ObjectLister, resource, namespace, name string, depth int) (*RelationshipGraph, error) {
+	rootKind, ok := relationshipKinds[resource]
+	if !ok {
+		return nil, fmt.Errorf("unsupported kind %q", resource)
+	}
+	if depth <= 0 || depth > MaxRelationshipDepth {
+		depth = DefaultRelationshipDepth
+	}
+
+	b := &relationshipBuilder{
+		namespace: namespace,
+		byID:      make(map[string]relationshipObject),
+		byOwner:   make(map[types.UID][]relationshipObject),
+		nodes:     make(map[string]RelationshipNode),
+		edges:     make(map[RelationshipEdge]bool),
+	}
+	for cachedResource, kind := range relationshipKinds {
+		objects, synced := lister.ListResource(cachedResource)
+		if !synced {
+			if cachedResource == resource {
+				return nil, fmt.Errorf("%w: %s", ErrCacheNotSynced, resource)
+			}
+			continue
+		}
+		for _, object := range objects {
+			accessor, err := meta.Accessor(object)
+			if err != nil || accessor.GetNamespace() != namespace {
+				continue
+			}
+			cached := relationshipObject{kind: kind, meta: accessor, object: object}
+			b.byID[relationshipID(kind, namespace, accessor.GetName())] = cached
+			for _, ref := range accessor.GetOwnerReferences() {
+				b.byOwner[ref.UID] = append(b.byOwner[ref.UID], cached)
+			}
+		}
+	}
+
+	root, ok := b.byID[relationshipID(rootKind, namespace, name)]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: resource}, name)
+	}
+	graph := &RelationshipGraph{Root: b.node(rootKind, name), Depth: depth}
+
+	// Owners, up the ownerReference chain
+	frontier := []relationshipObject{root}
+	for level := 0; len(frontier) > 0; level++ {
+		var next []relationshipObject
+		for _, object := range frontier {
+			childID := relationshipID(object.kind, namespace, object.meta.GetName())
+			for _, ref := range object.meta.GetOwnerReferences() {
+				if level == depth {
+					graph.Truncated = true
+					break
+				}
+				b.edge(b.node(ref.Kind, ref.Name), childID, RelationshipOwns)
+				if owner, cached := b.byID[relationshipID(ref.Kind, namespace, ref.Name)]; cached && owner.meta.GetUID() == ref.UID {
+					next = append(next, owner)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	// Children, down to the objects owned by the root's descendants
+	frontier = []relationshipObject{root}
+	for level := 0; len(frontier) > 0; level++ {
+		var next []relationshipObject
+		for _, object := range frontier {
+			ownerID := relationshipID(object.kind, namespace, object.meta.GetName())
+			for _, child := range b.byOwner[object.meta.GetUID()] {
+				if level == depth {
+					graph.Truncated = true
+					break
+				}
+				b.edge(ownerID, b.node(child.kind, child.meta.GetName()), RelationshipOwns)
+				next = append(next, child)
+			}
+		}
+		frontier = next
+	}
+
+	b.addReferences(root)
+
+	graph.Nodes = make([]RelationshipNode, 0, len(b.nodes))
+	for _, node := range b.nodes {
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+	graph.Edges = make([]RelationshipEdge, 0, len(b.edges))
+	for edge := range b.edges {
+		graph.Edges = append(graph.Edges, edge)
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		a, c := graph.Edges[i], graph.Edges[j]
+		if a.From != c.From {
+			return a.From < c.From
+		}
+		if a.To != c.To {
+			return a.To < c.To
+		}
+		return a.Type < c.Type
+	})
+	return graph, nil
+}
+
+// addReferences adds the objects the root's spec links to, or for a
+// ConfigMap, Secret or PVC the objects linking to it
+func (b *relationshipBuilder) addReferences(root relationshipObject) {
+	rootID := relationshipID(root.kind, b.namespace, root.meta.GetName())
+
+	if spec, podLabels := podTemplateOf(root.object); spec != nil {
+		refs := NewReferences()
+		refs.AddPodSpec(b.namespace, spec)
+		for _, ref := range []struct {
+			kind  string
+			names map[string]bool
+		}{{"ConfigMap", refs.ConfigMaps}, {"Secret", refs.Secrets}, {"PersistentVolumeClaim", refs.PVCs}} {
+			for key := range ref.names {
+				b.edge(rootID, b.node(ref.kind, strings.TrimPrefix(key, b.namespace+"/")), RelationshipReferences)
+			}
+		}
+		for _, service := range b.cached("Service") {
+			selector := service.object.(*v1.Service).Spec.Selector
+			if len(selector) > 0 && labels.SelectorFromSet(selector).Matches(labels.Set(podLabels)) {
+				b.edge(b.node("Service", service.meta.GetName()), rootID, RelationshipSelects)
+			}
+		}
+	}
+
+	switch object := root.object.(type) {
+	case *v1.Service:
+		for _, pod := range b.cached("Pod") {
+			if len(object.Spec.Selector) > 0 && labels.SelectorFromSet(object.Spec.Selector).Matches(labels.Set(pod.meta.GetLabels())) {
+				b.edge(rootID, b.node("Pod", pod.meta.GetName()), RelationshipSelects)
+			}
+		}
+	case *networkingv1.Ingress:
+		for _, service := range ingressServices(object) {
+			b.edge(rootID, b.node("Service", service), RelationshipReferences)
+		}
+		for _, tls := range object.Spec.TLS {
+			if tls.SecretName != "" {
+				b.edge(rootID, b.node("Secret", tls.SecretName), RelationshipReferences)
+			}
+		}
+	case *v1.ConfigMap, *v1.Secret, *v1.PersistentVolumeClaim:
+		key := refKey(b.namespace, root.meta.GetName())
+		for _, user := range b.cached("") {
+			// Owned objects inherit their references from the top-level owner
+			if metav1.GetControllerOf(user.meta) != nil {
+				continue
+			}
+			spec, _ := podTemplateOf(user.object)
+			if spec != nil {
+				refs := NewReferences()
+				refs.AddPodSpec(b.namespace, spec)
+				if (root.kind == "ConfigMap" && refs.ConfigMaps[key]) ||
+					(root.kind == "Secret" && refs.Secrets[key]) ||
+					(root.kind == "PersistentVolumeClaim" && refs.PVCs[key]) {
+					b.edge(b.node(user.kind, user.meta.GetName()), rootID, RelationshipReferences)
+				}
+			}
+			if ingress, isIngress := user.object.(*networkingv1.Ingress); isIngress && root.kind == "Secret" {
+				for _, tls := range ingress.Spec.TLS {
+					if tls.SecretName == root.meta.GetName() {
+						b.edge(b.node("Ingress", ingress.Name), rootID, RelationshipReferences)
+					}
+				}
+			}
+		}
+	}
+}
+
+// cached returns the cached objects of a kind, or of every kind when kind is
+// empty
+func (b *relationshipBuilder) cached(kind string) []relationshipObject {
+	var objects []relationshipObject
+	for _, object := range b.byID {
+		if kind == "" || object.kind == kind {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+// podTemplateOf returns the pod spec of a pod or the pod template of a
+// workload, with the labels its pods carry. The spec is nil for other kinds.
+func podTemplateOf(object interface{}) (*v1.PodSpec, map[string]string) {
+	switch o := object.(type) {
+	case *v1.Pod:
+		return &o.Spec, o.Labels
+	case *appsv1.Deployment:
+		return &o.Spec.Template.Spec, o.Spec.Template.Labels
+	case *appsv1.StatefulSet:
+		return &o.Spec.Template.Spec, o.Spec.Template.Labels
+	case *appsv1.DaemonSet:
+		return &o.Spec.Template.Spec, o.Spec.Template.Labels
+	case *appsv1.ReplicaSet:
+		return &o.Spec.Template.Spec, o.Spec.Template.Labels
+	case *batchv1.Job:
+		return &o.Spec.Template.Spec, o.Spec.Template.Labels
+	case *batchv1.CronJob:
+		return &o.Spec.JobTemplate.Spec.Template.Spec, o.Spec.JobTemplate.Spec.Template.Labels
+	}
+	return nil, nil
+}
+
+// ingressServices returns the Services an Ingress routes to, including its
+// default backend
+func ingressServices(ingress *networkingv1.Ingress) []string {
+	var services []string
+	if backend := ingress.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+		services = append(services, backend.Service.Name)
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				services = append(services, path.Backend.Service.Name)
+			}
+		}
+	}
+	return services
+}
@@ -0,0 +1,141 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// staticObjects is an ObjectLister over fixed objects; resources without an
+// entry have not synced
+type staticObjects map[string][]interface{}
+
+func (s staticObjects) ListResource(resource string) ([]interface{}, bool) {
+	objects, ok := s[resource]
+	return objects, ok
+}
+
+func ownedBy(kind, name string, uid types.UID) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, UID: uid, Controller: &controller}}
+}
+
+func relationshipObjects() staticObjects {
+	template := v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:    "app",
+				EnvFrom: []v1.EnvFromSource{{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "web-config"}}}},
+			}},
+			Volumes: []v1.Volume{{Name: "tls", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "web-tls"}}}},
+		},
+	}
+	pod := func(name string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", UID: types.UID(name), Labels: template.Labels, OwnerReferences: ownedBy("ReplicaSet", "web-7d9f", "rs-uid")},
+			Spec:       template.Spec,
+		}
+	}
+
+	return staticObjects{
+		"deployments": {&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", UID: "deploy-uid"},
+			Spec:       appsv1.DeploymentSpec{Template: template},
+		}},
+		"replicasets": {&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-7d9f", Namespace: "shop", UID: "rs-uid", OwnerReferences: ownedBy("Deployment", "web", "deploy-uid")},
+			Spec:       appsv1.ReplicaSetSpec{Template: template},
+		}},
+		"pods": {pod("web-7d9f-a"), pod("web-7d9f-b"),
+			// Same name in another namespace
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-7d9f-c", Namespace: "prod", OwnerReferences: ownedBy("ReplicaSet", "web-7d9f", "rs-uid")}},
+		},
+		"services": {
+			&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}, Spec: v1.ServiceSpec{Selector: map[string]string{"app": "web"}}},
+			&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"}, Spec: v1.ServiceSpec{Selector: map[string]string{"app": "api"}}},
+		},
+		"configmaps": {&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-config", Namespace: "shop"}}},
+		"secrets":    {},
+	}
+}
+
+func TestBuildRelationshipGraphDeployment(t *testing.T) {
+	graph, err := BuildRelationshipGraph(relationshipObjects(), "deployments", "shop", "web", 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Deployment/shop/web", graph.Root)
+	assert.Equal(t, DefaultRelationshipDepth, graph.Depth)
+	assert.False(t, graph.Truncated)
+	assert.Equal(t, []RelationshipNode{
+		{ID: "ConfigMap/shop/web-config", Kind: "ConfigMap", Namespace: "shop", Name: "web-config"},
+		{ID: "Deployment/shop/web", Kind: "Deployment", Namespace: "shop", Name: "web"},
+		{ID: "Pod/shop/web-7d9f-a", Kind: "Pod", Namespace: "shop", Name: "web-7d9f-a"},
+		{ID: "Pod/shop/web-7d9f-b", Kind: "Pod", Namespace: "shop", Name: "web-7d9f-b"},
+		{ID: "ReplicaSet/shop/web-7d9f", Kind: "ReplicaSet", Namespace: "shop", Name: "web-7d9f"},
+		// The Secret cache has synced without it
+		{ID: "Secret/shop/web-tls", Kind: "Secret", Namespace: "shop", Name: "web-tls", Missing: true},
+		{ID: "Service/shop/web", Kind: "Service", Namespace: "shop", Name: "web"},
+	}, graph.Nodes)
+	assert.Equal(t, []RelationshipEdge{
+		{From: "Deployment/shop/web", To: "ConfigMap/shop/web-config", Type: RelationshipReferences},
+		{From: "Deployment/shop/web", To: "ReplicaSet/shop/web-7d9f", Type: RelationshipOwns},
+		{From: "Deployment/shop/web", To: "Secret/shop/web-tls", Type: RelationshipReferences},
+		{From: "ReplicaSet/shop/web-7d9f", To: "Pod/shop/web-7d9f-a", Type: RelationshipOwns},
+		{From: "ReplicaSet/shop/web-7d9f", To: "Pod/shop/web-7d9f-b", Type: RelationshipOwns},
+		{From: "Service/shop/web", To: "Deployment/shop/web", Type: RelationshipSelects},
+	}, graph.Edges)
+}
+
+func TestBuildRelationshipGraphOwnersAndDepth(t *testing.T) {
+	objects := relationshipObjects()
+
+	graph, err := BuildRelationshipGraph(objects, "pods", "shop", "web-7d9f-a", 0)
+	require.NoError(t, err)
+	assert.Contains(t, graph.Edges, RelationshipEdge{From: "ReplicaSet/shop/web-7d9f", To: "Pod/shop/web-7d9f-a", Type: RelationshipOwns})
+	assert.Contains(t, graph.Edges, RelationshipEdge{From: "Deployment/shop/web", To: "ReplicaSet/shop/web-7d9f", Type: RelationshipOwns})
+	assert.NotContains(t, graph.Edges, RelationshipEdge{From: "ReplicaSet/shop/web-7d9f", To: "Pod/shop/web-7d9f-b", Type: RelationshipOwns}, "siblings are not children")
+
+	// One level up and down
+	graph, err = BuildRelationshipGraph(objects, "replicasets", "shop", "web-7d9f", 1)
+	require.NoError(t, err)
+	assert.False(t, graph.Truncated)
+	graph, err = BuildRelationshipGraph(objects, "deployments", "shop", "web", 1)
+	require.NoError(t, err)
+	assert.True(t, graph.Truncated)
+	assert.NotContains(t, graph.Edges, RelationshipEdge{From: "ReplicaSet/shop/web-7d9f", To: "Pod/shop/web-7d9f-a", Type: RelationshipOwns})
+}
+
+func TestBuildRelationshipGraphConfigReferences(t *testing.T) {
+	graph, err := BuildRelationshipGraph(relationshipObjects(), "configmaps", "shop", "web-config", 0)
+	require.NoError(t, err)
+
+	// Only the top-level Deployment is linked, not its ReplicaSet and pods
+	assert.Equal(t, []RelationshipEdge{
+		{From: "Deployment/shop/web", To: "ConfigMap/shop/web-config", Type: RelationshipReferences},
+	}, graph.Edges)
+}
+
+func TestBuildRelationshipGraphErrors(t *testing.T) {
+	objects := relationshipObjects()
+
+	_, err := BuildRelationshipGraph(objects, "deployments", "shop", "missing", 0)
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = BuildRelationshipGraph(objects, "ingresses", "shop", "web", 0)
+	assert.ErrorIs(t, err, ErrCacheNotSynced)
+
+	kind, err := ParseRelationshipKind("deploy")
+	require.NoError(t, err)
+	assert.Equal(t, "deployments", kind)
+	assert.Equal(t, "Deployment", RelationshipResourceKind(kind))
+	for _, raw := range []string{"", "nodes", "widgets"} {
+		_, err := ParseRelationshipKind(raw)
+		assert.Error(t, err, raw)
+	}
+}
@@ -0,0 +1,152 @@
+package analysis
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// RolloutVerdict is the terminal state of a rollout as reported by kubectl rollout status
+type RolloutVerdict string
+
+// Rollout verdicts returned by DeploymentRolloutStatus and StatefulSetRolloutStatus
+const (
+	RolloutComplete    RolloutVerdict = "complete"
+	RolloutProgressing RolloutVerdict = "progressing"
+	RolloutFailed      RolloutVerdict = "failed"
+)
+
+// deploymentProgressDeadlineExceeded is the Progressing condition reason set by
+// the deployment controller when a rollout stalls past progressDeadlineSeconds
+const deploymentProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+// RolloutStatus reports the progress of a workload rollout
+type RolloutStatus struct {
+	Kind                string         `json:"kind"`
+	Namespace           string         `json:"namespace"`
+	Name                string         `json:"name"`
+	Generation          int64          `json:"generation"`
+	ObservedGeneration  int64          `json:"observedGeneration"`
+	Replicas            int32          `json:"replicas"`
+	UpdatedReplicas     int32          `json:"updatedReplicas"`
+	ReadyReplicas       int32          `json:"readyReplicas"`
+	AvailableReplicas   int32          `json:"availableReplicas"`
+	UnavailableReplicas int32          `json:"unavailableReplicas"`
+	Verdict             RolloutVerdict `json:"verdict"`
+	Message             string         `json:"message"`
+}
+
+// DeploymentRolloutStatus evaluates a deployment rollout the way
+// kubectl rollout status does
+func DeploymentRolloutStatus(deployment *appsv1.Deployment) RolloutStatus {
+	status := RolloutStatus{
+		Kind:                "Deployment",
+		Namespace:           deployment.Namespace,
+		Name:                deployment.Name,
+		Generation:          deployment.Generation,
+		ObservedGeneration:  deployment.Status.ObservedGeneration,
+		Replicas:            1,
+		UpdatedReplicas:     deployment.Status.UpdatedReplicas,
+		ReadyReplicas:       deployment.Status.ReadyReplicas,
+		AvailableReplicas:   deployment.Status.AvailableReplicas,
+		UnavailableReplicas: deployment.Status.UnavailableReplicas,
+		Verdict:             RolloutProgressing,
+	}
+	if deployment.Spec.Replicas != nil {
+		status.Replicas = *deployment.Spec.Replicas
+	}
+
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		status.Message = "Waiting for deployment spec update to be observed..."
+		return status
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == deploymentProgressDeadlineExceeded {
+			status.Verdict = RolloutFailed
+			status.Message = fmt.Sprintf("deployment %q exceeded its progress deadline", deployment.Name)
+			return status
+		}
+	}
+
+	switch {
+	case status.UpdatedReplicas < status.Replicas:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...",
+			deployment.Name, status.UpdatedReplicas, status.Replicas)
+	case deployment.Status.Replicas > status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...",
+			deployment.Name, deployment.Status.Replicas-status.UpdatedReplicas)
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...",
+			deployment.Name, status.AvailableReplicas, status.UpdatedReplicas)
+	default:
+		status.Verdict = RolloutComplete
+		status.Message = fmt.Sprintf("deployment %q successfully rolled out", deployment.Name)
+	}
+
+	return status
+}
+
+// StatefulSetRolloutStatus evaluates a statefulset rollout the way
+// kubectl rollout status does, including partitioned rolling updates
+func StatefulSetRolloutStatus(statefulSet *appsv1.StatefulSet) RolloutStatus {
+	status := RolloutStatus{
+		Kind:               "StatefulSet",
+		Namespace:          statefulSet.Namespace,
+		Name:               statefulSet.Name,
+		Generation:         statefulSet.Generation,
+		ObservedGeneration: statefulSet.Status.ObservedGeneration,
+		Replicas:           1,
+		UpdatedReplicas:    statefulSet.Status.UpdatedReplicas,
+		ReadyReplicas:      statefulSet.Status.ReadyReplicas,
+		AvailableReplicas:  statefulSet.Status.AvailableReplicas,
+		Verdict:            RolloutProgressing,
+	}
+	if statefulSet.Spec.Replicas != nil {
+		status.Replicas = *statefulSet.Spec.Replicas
+	}
+	if status.Replicas > status.AvailableReplicas {
+		status.UnavailableReplicas = status.Replicas - status.AvailableReplicas
+	}
+
+	if statefulSet.Status.ObservedGeneration == 0 || statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		status.Message = "Waiting for statefulset spec update to be observed..."
+		return status
+	}
+
+	if status.ReadyReplicas < status.Replicas {
+		status.Message = fmt.Sprintf("Waiting for %d pods to be ready...", status.Replicas-status.ReadyReplicas)
+		return status
+	}
+
+	// OnDelete statefulsets only roll when pods are deleted by hand, so ready
+	// replicas at the observed generation is as complete as they get
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		status.Verdict = RolloutComplete
+		status.Message = fmt.Sprintf("statefulset %q has %d ready pods (OnDelete update strategy)", statefulSet.Name, status.ReadyReplicas)
+		return status
+	}
+
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		expected := status.Replicas - *rollingUpdate.Partition
+		if status.UpdatedReplicas < expected {
+			status.Message = fmt.Sprintf("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated...",
+				status.UpdatedReplicas, expected)
+			return status
+		}
+		status.Verdict = RolloutComplete
+		status.Message = fmt.Sprintf("partitioned roll out complete: %d new pods have been updated...", status.UpdatedReplicas)
+		return status
+	}
+
+	if statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision {
+		status.Message = fmt.Sprintf("waiting for statefulset rolling update to complete %d pods at revision %s...",
+			status.UpdatedReplicas, statefulSet.Status.UpdateRevision)
+		return status
+	}
+
+	status.Verdict = RolloutComplete
+	status.Message = fmt.Sprintf("statefulset rolling update complete %d pods at revision %s...",
+		statefulSet.Status.CurrentReplicas, statefulSet.Status.CurrentRevision)
+	return status
+}
@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func rolloutTestDeployment(replicas int32, status appsv1.DeploymentStatus) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     status,
+	}
+}
+
+func TestDeploymentRolloutStatusInProgress(t *testing.T) {
+	deployment := rolloutTestDeployment(3, appsv1.DeploymentStatus{
+		ObservedGeneration:  2,
+		Replicas:            4,
+		UpdatedReplicas:     2,
+		ReadyReplicas:       3,
+		AvailableReplicas:   3,
+		UnavailableReplicas: 1,
+	})
+
+	status := DeploymentRolloutStatus(deployment)
+
+	assert.Equal(t, RolloutProgressing, status.Verdict)
+	assert.Contains(t, status.Message, "2 out of 3 new replicas have been updated")
+	assert.Equal(t, int32(3), status.Replicas)
+	assert.Equal(t, int32(1), status.UnavailableReplicas)
+}
+
+func TestDeploymentRolloutStatusWaitsForObservedGeneration(t *testing.T) {
+	deployment := rolloutTestDeployment(3, appsv1.DeploymentStatus{
+		ObservedGeneration: 1,
+		Replicas:           3,
+		UpdatedReplicas:    3,
+		AvailableReplicas:  3,
+	})
+
+	status := DeploymentRolloutStatus(deployment)
+
+	assert.Equal(t, RolloutProgressing, status.Verdict)
+	assert.Equal(t, int64(2), status.Generation)
+	assert.Equal(t, int64(1), status.ObservedGeneration)
+	assert.Contains(t, status.Message, "spec update to be observed")
+}
+
+func TestDeploymentRolloutStatusComplete(t *testing.T) {
+	deployment := rolloutTestDeployment(3, appsv1.DeploymentStatus{
+		ObservedGeneration: 2,
+		Replicas:           3,
+		UpdatedReplicas:    3,
+		ReadyReplicas:      3,
+		AvailableReplicas:  3,
+	})
+
+	status := DeploymentRolloutStatus(deployment)
+
+	assert.Equal(t, RolloutComplete, status.Verdict)
+	assert.Equal(t, `deployment "web" successfully rolled out`, status.Message)
+}
+
+func TestDeploymentRolloutStatusProgressDeadlineExceeded(t *testing.T) {
+	deployment := rolloutTestDeployment(3, appsv1.DeploymentStatus{
+		ObservedGeneration: 2,
+		Replicas:           4,
+		UpdatedReplicas:    1,
+		AvailableReplicas:  3,
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentProgressing,
+			Status: v1.ConditionFalse,
+			Reason: "ProgressDeadlineExceeded",
+		}},
+	})
+
+	status := DeploymentRolloutStatus(deployment)
+
+	assert.Equal(t, RolloutFailed, status.Verdict)
+	assert.Contains(t, status.Message, "exceeded its progress deadline")
+}
+
+func TestStatefulSetRolloutStatusPartitioned(t *testing.T) {
+	replicas := int32(3)
+	partition := int32(1)
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Generation: 4},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 4,
+			ReadyReplicas:      3,
+			AvailableReplicas:  3,
+			UpdatedReplicas:    1,
+		},
+	}
+
+	status := StatefulSetRolloutStatus(statefulSet)
+	assert.Equal(t, RolloutProgressing, status.Verdict)
+	assert.Contains(t, status.Message, "1 out of 2 new pods have been updated")
+
+	statefulSet.Status.UpdatedReplicas = 2
+	status = StatefulSetRolloutStatus(statefulSet)
+	assert.Equal(t, RolloutComplete, status.Verdict)
+}
@@ -0,0 +1,132 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/units"
+)
+
+// DefaultPVCPendingThreshold is how long a claim may stay Pending before it
+// is reported as stuck. Dynamic provisioning normally binds a claim within
+// seconds; anything past a few minutes points to a missing PersistentVolume,
+// a storage class without a provisioner or a failing provisioner.
+const DefaultPVCPendingThreshold = 5 * time.Minute
+
+// betaStorageClassAnnotation is the storage class of claims created before
+// spec.storageClassName existed
+const betaStorageClassAnnotation = "volume.beta.kubernetes.io/storage-class"
+
+// StorageProblem is a PersistentVolumeClaim that is not usable: Pending, or
+// Lost because its bound PersistentVolume is gone
+type StorageProblem struct {
+	Namespace      string                              `json:"namespace"`
+	Name           string                              `json:"name"`
+	Phase          v1.PersistentVolumeClaimPhase       `json:"phase"`
+	StorageClass   string                              `json:"storageClass,omitempty"`
+	VolumeName     string                              `json:"volumeName,omitempty"`
+	AccessModes    []v1.PersistentVolumeAccessMode     `json:"accessModes,omitempty"`
+	RequestedSize  string                              `json:"requestedSize,omitempty"`
+	RequestedBytes int64                               `json:"requestedBytes"`
+	CreatedAt      time.Time                           `json:"createdAt"`
+	AgeSeconds     int64                               `json:"ageSeconds"`
+	Stuck          bool                                `json:"stuck"`
+	Conditions     []v1.PersistentVolumeClaimCondition `json:"conditions,omitempty"`
+}
+
+// StorageProblemReport lists the unusable claims of the cluster or of one
+// namespace. Stuck counts the Pending claims older than the threshold.
+type StorageProblemReport struct {
+	Namespace   string           `json:"namespace,omitempty"`
+	Threshold   string           `json:"threshold"`
+	Pending     int              `json:"pending"`
+	Stuck       int              `json:"stuck"`
+	Lost        int              `json:"lost"`
+	Problems    []StorageProblem `json:"problems"`
+	GeneratedAt time.Time        `json:"generatedAt"`
+}
+
+// PVCStorageClass returns the storage class a claim requests, honouring the
+// legacy beta annotation
+func PVCStorageClass(pvc *v1.PersistentVolumeClaim) string {
+	if pvc.Spec.StorageClassName != nil {
+		return *pvc.Spec.StorageClassName
+	}
+	return pvc.Annotations[betaStorageClassAnnotation]
+}
+
+// PVCPendingTooLong reports whether a claim has been Pending for longer than
+// threshold. The age is measured from the claim's creation, since Pending is
+// the phase every claim starts in.
+func PVCPendingTooLong(pvc *v1.PersistentVolumeClaim, threshold time.Duration, now time.Time) bool {
+	if pvc.Status.Phase != v1.ClaimPending || pvc.CreationTimestamp.IsZero() {
+		return false
+	}
+	return now.Sub(pvc.CreationTimestamp.Time) > threshold
+}
+
+// FindStorageProblems lists the Pending and Lost claims, Lost first and then
+// the longest-pending ones. Only claims in namespace are considered when it
+// is set.
+func FindStorageProblems(pvcs []*v1.PersistentVolumeClaim, namespace string, threshold time.Duration, now time.Time) *StorageProblemReport {
+	report := &StorageProblemReport{
+		Namespace:   namespace,
+		Threshold:   threshold.String(),
+		Problems:    []StorageProblem{},
+		GeneratedAt: now.UTC(),
+	}
+
+	for _, pvc := range pvcs {
+		if namespace != "" && pvc.Namespace != namespace {
+			continue
+		}
+		switch pvc.Status.Phase {
+		case v1.ClaimPending:
+			report.Pending++
+		case v1.ClaimLost:
+			report.Lost++
+		default:
+			continue
+		}
+
+		problem := StorageProblem{
+			Namespace:    pvc.Namespace,
+			Name:         pvc.Name,
+			Phase:        pvc.Status.Phase,
+			StorageClass: PVCStorageClass(pvc),
+			VolumeName:   pvc.Spec.VolumeName,
+			AccessModes:  pvc.Spec.AccessModes,
+			CreatedAt:    pvc.CreationTimestamp.UTC(),
+			Stuck:        PVCPendingTooLong(pvc, threshold, now),
+			Conditions:   pvc.Status.Conditions,
+		}
+		if !pvc.CreationTimestamp.IsZero() {
+			problem.AgeSeconds = int64(now.Sub(pvc.CreationTimestamp.Time) / time.Second)
+		}
+		if requested, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+			problem.RequestedBytes = units.ParseQuantityBytes(requested)
+			problem.RequestedSize = units.HumanizeBytes(problem.RequestedBytes)
+		}
+		if problem.Stuck {
+			report.Stuck++
+		}
+		report.Problems = append(report.Problems, problem)
+	}
+
+	sort.Slice(report.Problems, func(i, j int) bool {
+		a, b := report.Problems[i], report.Problems[j]
+		if (a.Phase == v1.ClaimLost) != (b.Phase == v1.ClaimLost) {
+			return a.Phase == v1.ClaimLost
+		}
+		if a.AgeSeconds != b.AgeSeconds {
+			return a.AgeSeconds > b.AgeSeconds
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
@@ -0,0 +1,97 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func claim(namespace, name string, phase v1.PersistentVolumeClaimPhase, size string, created time.Time) *v1.PersistentVolumeClaim {
+	className := "fast-ssd"
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: &className,
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+		Status: v1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func TestFindStorageProblems(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	legacy := claim("shop", "legacy", v1.ClaimPending, "1G", now.Add(-time.Minute))
+	legacy.Spec.StorageClassName = nil
+	legacy.Annotations = map[string]string{betaStorageClassAnnotation: "standard"}
+	lost := claim("shop", "orders", v1.ClaimLost, "20Gi", now.Add(-time.Hour))
+	lost.Spec.VolumeName = "pv-orders"
+
+	pvcs := []*v1.PersistentVolumeClaim{
+		claim("shop", "data-db-0", v1.ClaimPending, "10Gi", now.Add(-2*time.Hour)),
+		claim("shop", "cache", v1.ClaimBound, "1Gi", now.Add(-24*time.Hour)),
+		legacy,
+		lost,
+		claim("staging", "scratch", v1.ClaimPending, "512Mi", now.Add(-30*time.Minute)),
+	}
+
+	report := FindStorageProblems(pvcs, "", DefaultPVCPendingThreshold, now)
+	assert.Equal(t, 3, report.Pending)
+	assert.Equal(t, 2, report.Stuck)
+	assert.Equal(t, 1, report.Lost)
+	assert.Equal(t, "5m0s", report.Threshold)
+	require.Len(t, report.Problems, 4, "bound claims are not problems")
+
+	// Lost claims come first, then the longest-pending ones
+	assert.Equal(t, "orders", report.Problems[0].Name)
+	assert.Equal(t, "pv-orders", report.Problems[0].VolumeName)
+	assert.False(t, report.Problems[0].Stuck)
+
+	stuck := report.Problems[1]
+	assert.Equal(t, "data-db-0", stuck.Name)
+	assert.True(t, stuck.Stuck)
+	assert.Equal(t, "fast-ssd", stuck.StorageClass)
+	assert.Equal(t, "10 GiB", stuck.RequestedSize)
+	assert.Equal(t, int64(10<<30), stuck.RequestedBytes)
+	assert.Equal(t, int64(7200), stuck.AgeSeconds)
+	assert.Equal(t, []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, stuck.AccessModes)
+
+	assert.Equal(t, "scratch", report.Problems[2].Name)
+	assert.True(t, report.Problems[2].Stuck)
+
+	// A claim created a minute ago is still within the threshold
+	fresh := report.Problems[3]
+	assert.Equal(t, "legacy", fresh.Name)
+	assert.False(t, fresh.Stuck)
+	assert.Equal(t, "standard", fresh.StorageClass)
+	assert.Equal(t, "953.7 MiB", fresh.RequestedSize)
+
+	// A longer threshold and a namespace filter
+	report = FindStorageProblems(pvcs, "staging", time.Hour, now)
+	assert.Equal(t, "staging", report.Namespace)
+	assert.Equal(t, 1, report.Pending)
+	assert.Equal(t, 0, report.Stuck)
+	require.Len(t, report.Problems, 1)
+	assert.False(t, report.Problems[0].Stuck)
+}
+
+func TestPVCPendingTooLong(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, PVCPendingTooLong(claim("shop", "a", v1.ClaimPending, "1Gi", now.Add(-6*time.Minute)), DefaultPVCPendingThreshold, now))
+	assert.False(t, PVCPendingTooLong(claim("shop", "b", v1.ClaimPending, "1Gi", now.Add(-5*time.Minute)), DefaultPVCPendingThreshold, now))
+	assert.False(t, PVCPendingTooLong(claim("shop", "c", v1.ClaimBound, "1Gi", now.Add(-time.Hour)), DefaultPVCPendingThreshold, now))
+	assert.False(t, PVCPendingTooLong(claim("shop", "d", v1.ClaimPending, "1Gi", time.Time{}), DefaultPVCPendingThreshold, now))
+}
@@ -55,6 +55,9 @@ func NewFactory(logger *zap.Logger, mode ClientMode, kubeconfigPath string) (*Fa
 		return nil, fmt.Errorf("unsupported client mode: %s", mode)
 	}
 
+	// Tag outbound calls with the originating request ID
+	config.Wrap(WrapRequestID)
+
 	// Create the clientset
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/aaronlmathis/kaptn/internal/logging"
+	"k8s.io/client-go/rest"
+)
+
+// requestIDRoundTripper appends the originating request ID to the User-Agent of
+// outbound Kubernetes API calls so API server audit logs can be correlated
+type requestIDRoundTripper struct {
+	next http.RoundTripper
+}
+
+// WrapRequestID returns a transport wrapper suitable for rest.Config.Wrap
+func WrapRequestID(rt http.RoundTripper) http.RoundTripper {
+	return &requestIDRoundTripper{next: rt}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *requestIDRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID := logging.RequestIDFromContext(req.Context())
+	if requestID == "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the original request
+	req = req.Clone(req.Context())
+	userAgent := req.Header.Get("User-Agent")
+	if userAgent == "" {
+		userAgent = rest.DefaultKubernetesUserAgent()
+	}
+	req.Header.Set("User-Agent", userAgent+" request-id/"+requestID)
+
+	return t.next.RoundTrip(req)
+}
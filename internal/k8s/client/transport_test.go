@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/logging"
+)
+
+type recordingRoundTripper struct {
+	req *http.Request
+}
+
+func (r *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.req = req
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestRequestIDRoundTripper(t *testing.T) {
+	recorder := &recordingRoundTripper{}
+	rt := WrapRequestID(recorder)
+
+	ctx := logging.WithRequestID(context.Background(), "abc-123")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://kubernetes.default/api/v1/pods", nil)
+	req.Header.Set("User-Agent", "kaptn/v1.0")
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip returned error: %v", err)
+	}
+
+	if got := recorder.req.Header.Get("User-Agent"); got != "kaptn/v1.0 request-id/abc-123" {
+		t.Errorf("Expected request ID suffix in User-Agent, got %q", got)
+	}
+	if got := req.Header.Get("User-Agent"); got != "kaptn/v1.0" {
+		t.Errorf("Original request must not be modified, got %q", got)
+	}
+}
+
+func TestRequestIDRoundTripperWithoutID(t *testing.T) {
+	recorder := &recordingRoundTripper{}
+	rt := WrapRequestID(recorder)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://kubernetes.default/api/v1/pods", nil)
+	req.Header.Set("User-Agent", "kaptn/v1.0")
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip returned error: %v", err)
+	}
+
+	if got := recorder.req.Header.Get("User-Agent"); got != "kaptn/v1.0" {
+		t.Errorf("Expected User-Agent to be unchanged, got %q", got)
+	}
+}
@@ -35,12 +35,15 @@ type ExecSession struct {
 	podName   string
 	container string
 	command   []string
+	shells    []string
 	conn      *websocket.Conn
 	ctx       context.Context
 	cancel    context.CancelFunc
 	stdin     *websocketReader
 	stdout    *websocketWriter
 	stderr    *websocketWriter
+	sizes     *terminalSizeQueue
+	writeMu   *sync.Mutex
 }
 
 // ExecRequest represents a request to start an exec session
@@ -50,6 +53,9 @@ type ExecRequest struct {
 	Container string   `json:"container"`
 	Command   []string `json:"command"`
 	TTY       bool     `json:"tty"`
+	// AllowedCommands is the exec allowlist; a session without a command
+	// only detects shells on it
+	AllowedCommands []string `json:"-"`
 }
 
 // Message represents a WebSocket message for terminal communication
@@ -93,18 +99,27 @@ func (em *ExecManager) StartExecSession(w http.ResponseWriter, r *http.Request,
 	// The request context gets canceled after the HTTP upgrade
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// stdout and stderr are written concurrently and a websocket connection
+	// supports one writer at a time
+	writeMu := &sync.Mutex{}
+	sizes := newTerminalSizeQueue(ctx)
 	session := &ExecSession{
 		ID:        sessionID,
 		namespace: req.Namespace,
 		podName:   req.Pod,
 		container: req.Container,
 		command:   req.Command,
+		shells:    AllowedShells(req.AllowedCommands),
 		conn:      conn,
 		ctx:       ctx,
 		cancel:    cancel,
-		stdin:     newWebsocketReader(conn),
-		stdout:    newWebsocketWriter(conn, "stdout"),
-		stderr:    newWebsocketWriter(conn, "stderr"),
+		// Closing the socket ends stdin and cancels the session, which tears
+		// down the executor's stream to the API server
+		stdin:   newWebsocketReader(conn, sizes, cancel),
+		stdout:  newWebsocketWriter(conn, "stdout", writeMu),
+		stderr:  newWebsocketWriter(conn, "stderr", writeMu),
+		sizes:   sizes,
+		writeMu: writeMu,
 	}
 
 	em.mutex.Lock()
@@ -156,10 +171,12 @@ func (em *ExecManager) handleExecSession(session *ExecSession, tty bool) {
 	// If no command specified, try to detect available shell
 	command := session.command
 	if len(command) == 0 {
-		detectedShell, err := em.detectAvailableShell(session.namespace, session.podName, session.container)
+		detectedShell, err := em.detectAvailableShell(session.namespace, session.podName, session.container, session.shells)
 		if err != nil {
 			em.logger.Error("Failed to detect available shell", zap.String("sessionID", session.ID), zap.Error(err))
+			session.writeMu.Lock()
 			em.sendError(session.conn, fmt.Sprintf("Failed to detect available shell: %v", err))
+			session.writeMu.Unlock()
 			return
 		}
 		command = []string{detectedShell}
@@ -191,7 +208,9 @@ func (em *ExecManager) handleExecSession(session *ExecSession, tty bool) {
 	executor, err := remotecommand.NewSPDYExecutor(em.restConfig, "POST", execReq.URL())
 	if err != nil {
 		em.logger.Error("Failed to create executor", zap.String("sessionID", session.ID), zap.Error(err))
+		session.writeMu.Lock()
 		em.sendError(session.conn, fmt.Sprintf("Failed to create executor: %v", err))
+		session.writeMu.Unlock()
 		return
 	}
 
@@ -203,26 +222,49 @@ func (em *ExecManager) handleExecSession(session *ExecSession, tty bool) {
 	em.logger.Info("Starting executor stream", zap.String("sessionID", session.ID))
 
 	// Execute the command
-	err = executor.StreamWithContext(session.ctx, remotecommand.StreamOptions{
+	streamOptions := remotecommand.StreamOptions{
 		Stdin:  session.stdin,
 		Stdout: session.stdout,
 		Stderr: session.stderr,
 		Tty:    tty,
-	})
+	}
+	if tty {
+		streamOptions.TerminalSizeQueue = session.sizes
+	}
+	err = executor.StreamWithContext(session.ctx, streamOptions)
 
-	if err != nil {
+	if err != nil && session.ctx.Err() == nil {
 		em.logger.Error("Exec failed", zap.String("sessionID", session.ID), zap.Error(err))
+		session.writeMu.Lock()
 		em.sendError(session.conn, fmt.Sprintf("Exec failed: %v", err))
+		session.writeMu.Unlock()
 		return
 	}
 
 	em.logger.Info("Exec session completed", zap.String("sessionID", session.ID))
 }
 
-// detectAvailableShell tries to find an available shell in the container
-func (em *ExecManager) detectAvailableShell(namespace, podName, container string) (string, error) {
-	// List of shells to try in order of preference
-	shells := []string{"/bin/bash", "/bin/sh", "/usr/bin/bash", "/usr/bin/sh", "/bin/ash", "/usr/bin/ash"}
+// detectableShells lists the shells tried, in order of preference, when a
+// session names no command
+var detectableShells = []string{"/bin/bash", "/bin/sh", "/usr/bin/bash", "/usr/bin/sh", "/bin/ash", "/usr/bin/ash"}
+
+// AllowedShells returns the detectable shells the allowlist admits, in order
+// of preference
+func AllowedShells(allowlist []string) []string {
+	var shells []string
+	for _, shell := range detectableShells {
+		if CommandAllowed([]string{shell}, allowlist) {
+			shells = append(shells, shell)
+		}
+	}
+	return shells
+}
+
+// detectAvailableShell tries to find one of the given shells in the container
+func (em *ExecManager) detectAvailableShell(namespace, podName, container string, shells []string) (string, error) {
+	if len(shells) == 0 {
+		return "", fmt.Errorf("no shell is on the exec allowlist")
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -276,17 +318,22 @@ func (em *ExecManager) sendError(conn *websocket.Conn, message string) {
 	conn.WriteJSON(msg)
 }
 
-// websocketReader implements io.Reader for WebSocket stdin
+// websocketReader implements io.Reader for WebSocket stdin. Resize messages
+// are passed to the terminal size queue; onClose runs when the client goes away.
 type websocketReader struct {
-	conn   *websocket.Conn
-	buffer []byte
-	mutex  sync.Mutex
+	conn    *websocket.Conn
+	buffer  []byte
+	mutex   sync.Mutex
+	sizes   *terminalSizeQueue
+	onClose func()
 }
 
-func newWebsocketReader(conn *websocket.Conn) *websocketReader {
+func newWebsocketReader(conn *websocket.Conn, sizes *terminalSizeQueue, onClose func()) *websocketReader {
 	return &websocketReader{
-		conn:   conn,
-		buffer: make([]byte, 0),
+		conn:    conn,
+		buffer:  make([]byte, 0),
+		sizes:   sizes,
+		onClose: onClose,
 	}
 }
 
@@ -299,14 +346,22 @@ func (r *websocketReader) Read(p []byte) (n int, err error) {
 		var msg Message
 		err := r.conn.ReadJSON(&msg)
 		if err != nil {
+			if r.onClose != nil {
+				r.onClose()
+			}
 			if websocket.IsCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
 				return 0, io.EOF
 			}
 			return 0, err
 		}
 
-		if msg.Type == "stdin" {
+		switch msg.Type {
+		case "stdin":
 			r.buffer = append(r.buffer, []byte(msg.Data)...)
+		case "resize":
+			if r.sizes != nil && msg.Cols > 0 && msg.Rows > 0 {
+				r.sizes.push(remotecommand.TerminalSize{Width: uint16(msg.Cols), Height: uint16(msg.Rows)})
+			}
 		}
 		// Ignore other message types in the reader
 	}
@@ -323,17 +378,19 @@ func (r *websocketReader) start(ctx context.Context) {
 	<-ctx.Done()
 }
 
-// websocketWriter implements io.Writer for WebSocket stdout/stderr
+// websocketWriter implements io.Writer for WebSocket stdout/stderr. Writers
+// of one connection share its mutex.
 type websocketWriter struct {
 	conn    *websocket.Conn
 	msgType string
-	mutex   sync.Mutex
+	mutex   *sync.Mutex
 }
 
-func newWebsocketWriter(conn *websocket.Conn, msgType string) *websocketWriter {
+func newWebsocketWriter(conn *websocket.Conn, msgType string, mutex *sync.Mutex) *websocketWriter {
 	return &websocketWriter{
 		conn:    conn,
 		msgType: msgType,
+		mutex:   mutex,
 	}
 }
 
@@ -371,5 +428,58 @@ func (em *ExecManager) ResizeSession(sessionID string, cols, rows int) error {
 		Rows: rows,
 	}
 
+	session.writeMu.Lock()
+	defer session.writeMu.Unlock()
 	return session.conn.WriteJSON(msg)
 }
+
+// terminalSizeQueue passes terminal resizes from the client to the executor.
+// Only the latest size is kept, so a burst of resizes never blocks stdin.
+type terminalSizeQueue struct {
+	ctx   context.Context
+	sizes chan remotecommand.TerminalSize
+}
+
+func newTerminalSizeQueue(ctx context.Context) *terminalSizeQueue {
+	return &terminalSizeQueue{ctx: ctx, sizes: make(chan remotecommand.TerminalSize, 1)}
+}
+
+// push queues a size, replacing one the executor has not picked up yet
+func (q *terminalSizeQueue) push(size remotecommand.TerminalSize) {
+	for {
+		select {
+		case q.sizes <- size:
+			return
+		default:
+		}
+		select {
+		case <-q.sizes:
+		default:
+		}
+	}
+}
+
+// Next returns the next terminal size, or nil once the session has ended,
+// which stops the executor's resize loop
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-q.sizes:
+		return &size
+	case <-q.ctx.Done():
+		return nil
+	}
+}
+
+// CommandAllowed reports whether a command's executable is on the allowlist.
+// "*" allows any command; an empty command is never allowed.
+func CommandAllowed(command []string, allowlist []string) bool {
+	if len(command) == 0 {
+		return false
+	}
+	for _, allowed := range allowlist {
+		if allowed == "*" || allowed == command[0] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package exec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+func TestCommandAllowed(t *testing.T) {
+	allowlist := []string{"/bin/sh", "bash"}
+
+	assert.True(t, CommandAllowed([]string{"/bin/sh"}, allowlist))
+	assert.True(t, CommandAllowed([]string{"bash", "-c", "ls"}, allowlist))
+	assert.False(t, CommandAllowed([]string{"/bin/bash"}, allowlist), "only exact executables match")
+	assert.False(t, CommandAllowed([]string{"rm", "/bin/sh"}, allowlist), "arguments are not checked")
+	assert.False(t, CommandAllowed(nil, allowlist))
+	assert.False(t, CommandAllowed([]string{"/bin/sh"}, nil))
+	assert.True(t, CommandAllowed([]string{"python3"}, []string{"*"}))
+	assert.False(t, CommandAllowed(nil, []string{"*"}))
+}
+
+func TestAllowedShells(t *testing.T) {
+	assert.Equal(t, []string{"/bin/sh", "/bin/ash"}, AllowedShells([]string{"/bin/ash", "/bin/sh", "python3"}))
+	assert.Equal(t, detectableShells, AllowedShells([]string{"*"}))
+	assert.Empty(t, AllowedShells([]string{"bash", "sh"}), "only exact executables match")
+	assert.Empty(t, AllowedShells(nil))
+}
+
+func TestTerminalSizeQueueKeepsLatestSize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	queue := newTerminalSizeQueue(ctx)
+
+	queue.push(remotecommand.TerminalSize{Width: 80, Height: 24})
+	queue.push(remotecommand.TerminalSize{Width: 120, Height: 40})
+
+	size := queue.Next()
+	require.NotNil(t, size)
+	assert.Equal(t, remotecommand.TerminalSize{Width: 120, Height: 40}, *size)
+
+	// Once the session ends the executor's resize loop is released
+	cancel()
+	assert.Nil(t, queue.Next())
+}
@@ -0,0 +1,212 @@
+package images
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// DefaultInventoryTTL is how long a built image inventory is served from cache
+const DefaultInventoryTTL = 15 * time.Second
+
+// ImageUsage describes one image reference and where it runs in the cluster
+type ImageUsage struct {
+	Image      string   `json:"image"`
+	Digests    []string `json:"digests"`
+	Pods       int      `json:"pods"`
+	Containers int      `json:"containers"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// imageAccumulator collects usage for one image while scanning pods
+type imageAccumulator struct {
+	pods       map[string]bool
+	containers int
+	namespaces map[string]bool
+	digests    map[string]bool
+}
+
+// BuildInventory returns the deduplicated images used by pods, sorted by
+// image reference. Pods are scanned concurrently in shards and merged.
+func BuildInventory(pods []*v1.Pod) []ImageUsage {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(pods) {
+		workers = len(pods)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	partials := make([]map[string]*imageAccumulator, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			acc := make(map[string]*imageAccumulator)
+			for i := w; i < len(pods); i += workers {
+				addPodImages(acc, pods[i])
+			}
+			partials[w] = acc
+		}(w)
+	}
+	wg.Wait()
+
+	merged := make(map[string]*imageAccumulator)
+	for _, partial := range partials {
+		for image, acc := range partial {
+			target, ok := merged[image]
+			if !ok {
+				merged[image] = acc
+				continue
+			}
+			target.containers += acc.containers
+			for pod := range acc.pods {
+				target.pods[pod] = true
+			}
+			for ns := range acc.namespaces {
+				target.namespaces[ns] = true
+			}
+			for digest := range acc.digests {
+				target.digests[digest] = true
+			}
+		}
+	}
+
+	inventory := make([]ImageUsage, 0, len(merged))
+	for image, acc := range merged {
+		inventory = append(inventory, ImageUsage{
+			Image:      image,
+			Digests:    sortedKeys(acc.digests),
+			Pods:       len(acc.pods),
+			Containers: acc.containers,
+			Namespaces: sortedKeys(acc.namespaces),
+		})
+	}
+	sort.Slice(inventory, func(i, j int) bool { return inventory[i].Image < inventory[j].Image })
+	return inventory
+}
+
+// addPodImages records the images of a pod's init, regular and ephemeral containers
+func addPodImages(acc map[string]*imageAccumulator, pod *v1.Pod) {
+	if pod == nil {
+		return
+	}
+
+	digests := make(map[string]string)
+	for _, statuses := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses} {
+		for _, status := range statuses {
+			if digest := ImageDigest(status.ImageID); digest != "" {
+				digests[status.Name] = digest
+			}
+		}
+	}
+
+	podKey := pod.Namespace + "/" + pod.Name
+	record := func(name, image string) {
+		if image == "" {
+			return
+		}
+		entry, ok := acc[image]
+		if !ok {
+			entry = &imageAccumulator{
+				pods:       make(map[string]bool),
+				namespaces: make(map[string]bool),
+				digests:    make(map[string]bool),
+			}
+			acc[image] = entry
+		}
+		entry.pods[podKey] = true
+		entry.containers++
+		entry.namespaces[pod.Namespace] = true
+		if digest := digests[name]; digest != "" {
+			entry.digests[digest] = true
+		}
+	}
+
+	for _, c := range pod.Spec.InitContainers {
+		record(c.Name, c.Image)
+	}
+	for _, c := range pod.Spec.Containers {
+		record(c.Name, c.Image)
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		record(c.Name, c.Image)
+	}
+}
+
+// ImageDigest extracts the content digest from a container status imageID,
+// e.g. "docker-pullable://nginx@sha256:abc" becomes "sha256:abc"
+func ImageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	if i := strings.Index(imageID, "sha256:"); i >= 0 {
+		return imageID[i:]
+	}
+	return ""
+}
+
+// FilterInventory returns the images whose reference contains search,
+// ignoring case. An empty search returns the inventory unchanged.
+func FilterInventory(inventory []ImageUsage, search string) []ImageUsage {
+	search = strings.ToLower(strings.TrimSpace(search))
+	if search == "" {
+		return inventory
+	}
+
+	filtered := make([]ImageUsage, 0)
+	for _, usage := range inventory {
+		if strings.Contains(strings.ToLower(usage.Image), search) {
+			filtered = append(filtered, usage)
+		}
+	}
+	return filtered
+}
+
+// InventoryCache serves a built inventory for a short TTL so repeated
+// requests do not rescan every pod
+type InventoryCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	inventory []ImageUsage
+	expiresAt time.Time
+}
+
+// NewInventoryCache creates an inventory cache; a non-positive ttl uses DefaultInventoryTTL
+func NewInventoryCache(ttl time.Duration) *InventoryCache {
+	if ttl <= 0 {
+		ttl = DefaultInventoryTTL
+	}
+	return &InventoryCache{ttl: ttl, now: time.Now}
+}
+
+// Get returns the cached inventory, rebuilding it from listPods once expired
+func (c *InventoryCache) Get(listPods func() []*v1.Pod) []ImageUsage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if c.inventory != nil && now.Before(c.expiresAt) {
+		return c.inventory
+	}
+
+	c.inventory = BuildInventory(listPods())
+	c.expiresAt = now.Add(c.ttl)
+	return c.inventory
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,89 @@
+package images
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func inventoryPod(namespace, name string, images map[string]string, imageIDs map[string]string) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	for container, image := range images {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: container, Image: image})
+		if imageID, ok := imageIDs[container]; ok {
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{Name: container, Image: image, ImageID: imageID})
+		}
+	}
+	return pod
+}
+
+func TestBuildInventoryDeduplicatesImages(t *testing.T) {
+	pods := []*v1.Pod{
+		inventoryPod("default", "web-1",
+			map[string]string{"nginx": "nginx:1.25", "sidecar": "envoy:1.29"},
+			map[string]string{"nginx": "docker-pullable://nginx@sha256:aaa"}),
+		inventoryPod("default", "web-2",
+			map[string]string{"nginx": "nginx:1.25"},
+			map[string]string{"nginx": "containerd://nginx@sha256:aaa"}),
+		inventoryPod("payments", "api",
+			map[string]string{"nginx": "nginx:1.25", "app": "payments:v2"},
+			map[string]string{"nginx": "sha256:bbb"}),
+	}
+	pods[2].Spec.InitContainers = []v1.Container{{Name: "migrate", Image: "payments:v2"}}
+
+	inventory := BuildInventory(pods)
+
+	require.Len(t, inventory, 3)
+	assert.Equal(t, ImageUsage{Image: "envoy:1.29", Digests: []string{}, Pods: 1, Containers: 1, Namespaces: []string{"default"}}, inventory[0])
+	assert.Equal(t, ImageUsage{
+		Image:      "nginx:1.25",
+		Digests:    []string{"sha256:aaa", "sha256:bbb"},
+		Pods:       3,
+		Containers: 3,
+		Namespaces: []string{"default", "payments"},
+	}, inventory[1])
+	assert.Equal(t, ImageUsage{Image: "payments:v2", Digests: []string{}, Pods: 1, Containers: 2, Namespaces: []string{"payments"}}, inventory[2])
+}
+
+func TestBuildInventoryEmpty(t *testing.T) {
+	assert.Empty(t, BuildInventory(nil))
+}
+
+func TestImageDigest(t *testing.T) {
+	assert.Equal(t, "sha256:abc", ImageDigest("docker-pullable://nginx@sha256:abc"))
+	assert.Equal(t, "sha256:abc", ImageDigest("sha256:abc"))
+	assert.Equal(t, "", ImageDigest(""))
+}
+
+func TestFilterInventory(t *testing.T) {
+	inventory := []ImageUsage{{Image: "docker.io/library/nginx:1.25"}, {Image: "quay.io/prometheus/node-exporter"}}
+
+	assert.Equal(t, []ImageUsage{{Image: "docker.io/library/nginx:1.25"}}, FilterInventory(inventory, "NGINX"))
+	assert.Len(t, FilterInventory(inventory, ""), 2)
+	assert.Empty(t, FilterInventory(inventory, "redis"))
+}
+
+func TestInventoryCacheServesUntilExpiry(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewInventoryCache(10 * time.Second)
+	cache.now = func() time.Time { return now }
+
+	calls := 0
+	listPods := func() []*v1.Pod {
+		calls++
+		return []*v1.Pod{inventoryPod("default", "web", map[string]string{"nginx": "nginx:1.25"}, nil)}
+	}
+
+	require.Len(t, cache.Get(listPods), 1)
+	now = now.Add(5 * time.Second)
+	require.Len(t, cache.Get(listPods), 1)
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(10 * time.Second)
+	cache.Get(listPods)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,225 @@
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultScanTimeout bounds how long a pod detail request waits for scan results
+const DefaultScanTimeout = 2 * time.Second
+
+// SeverityCounts counts vulnerabilities found in an image by severity
+type SeverityCounts struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Unknown  int `json:"unknown"`
+}
+
+// ImageScan is the scan result attached to a container image
+type ImageScan struct {
+	Severity    SeverityCounts `json:"severity"`
+	LastScanned *time.Time     `json:"lastScanned,omitempty"`
+}
+
+// ImageScanner looks up vulnerability scan results for an image reference.
+// Implementations return (nil, nil) when the image has not been scanned.
+type ImageScanner interface {
+	Scan(ctx context.Context, image string) (*ImageScan, error)
+}
+
+// NoopScanner is the default scanner; it never reports results
+type NoopScanner struct{}
+
+// Scan always returns no result
+func (NoopScanner) Scan(ctx context.Context, image string) (*ImageScan, error) {
+	return nil, nil
+}
+
+// HTTPScannerConfig configures the HTTP-backed scanner
+type HTTPScannerConfig struct {
+	URL      string
+	Token    string
+	Timeout  time.Duration
+	CacheTTL time.Duration
+}
+
+// HTTPScanner queries an external scan service with GET <url>?image=<ref>.
+// The service responds with an ImageScan JSON document, or 404 when the
+// image is unknown. Results are cached per image for CacheTTL.
+type HTTPScanner struct {
+	logger   *zap.Logger
+	baseURL  string
+	token    string
+	client   *http.Client
+	cacheTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedScan
+}
+
+// cachedScan is a scan result with its expiry
+type cachedScan struct {
+	scan      *ImageScan
+	expiresAt time.Time
+}
+
+// NewHTTPScanner creates an HTTP-backed image scanner
+func NewHTTPScanner(logger *zap.Logger, config HTTPScannerConfig) (*HTTPScanner, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("image scanner URL is required")
+	}
+	if _, err := url.Parse(config.URL); err != nil {
+		return nil, fmt.Errorf("invalid image scanner URL: %w", err)
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultScanTimeout
+	}
+
+	return &HTTPScanner{
+		logger:   logger,
+		baseURL:  config.URL,
+		token:    config.Token,
+		client:   &http.Client{Timeout: config.Timeout},
+		cacheTTL: config.CacheTTL,
+		cache:    make(map[string]cachedScan),
+	}, nil
+}
+
+// Scan fetches the scan result for an image
+func (s *HTTPScanner) Scan(ctx context.Context, image string) (*ImageScan, error) {
+	if scan, ok := s.cached(image); ok {
+		return scan, nil
+	}
+
+	u, err := url.Parse(s.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image scanner URL: %w", err)
+	}
+	query := u.Query()
+	query.Set("image", image)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scan request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query image scanner: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		s.store(image, nil)
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("image scanner returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var scan ImageScan
+	if err := json.NewDecoder(resp.Body).Decode(&scan); err != nil {
+		return nil, fmt.Errorf("failed to decode scan result: %w", err)
+	}
+
+	s.store(image, &scan)
+	return &scan, nil
+}
+
+// cached returns an unexpired cached result for an image
+func (s *HTTPScanner) cached(image string) (*ImageScan, bool) {
+	if s.cacheTTL <= 0 {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.cache[image]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.scan, true
+}
+
+// store caches a result, including "not scanned" results
+func (s *HTTPScanner) store(image string, scan *ImageScan) {
+	if s.cacheTTL <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache[image] = cachedScan{scan: scan, expiresAt: time.Now().Add(s.cacheTTL)}
+}
+
+// ScanImages scans each distinct image concurrently and returns the results
+// that arrived within timeout. Failed, unscanned and late images are left out
+// so a slow scanner never blocks the caller for longer than timeout.
+func ScanImages(ctx context.Context, logger *zap.Logger, scanner ImageScanner, images []string, timeout time.Duration) map[string]*ImageScan {
+	results := make(map[string]*ImageScan)
+	if scanner == nil || len(images) == 0 {
+		return results
+	}
+	if timeout <= 0 {
+		timeout = DefaultScanTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type scanResult struct {
+		image string
+		scan  *ImageScan
+	}
+
+	seen := make(map[string]bool, len(images))
+	resultCh := make(chan scanResult, len(images))
+	pending := 0
+	for _, image := range images {
+		if image == "" || seen[image] {
+			continue
+		}
+		seen[image] = true
+		pending++
+
+		go func(image string) {
+			scan, err := scanner.Scan(ctx, image)
+			if err != nil {
+				logger.Debug("Image scan failed", zap.String("image", image), zap.Error(err))
+			}
+			resultCh <- scanResult{image: image, scan: scan}
+		}(image)
+	}
+
+	for pending > 0 {
+		select {
+		case result := <-resultCh:
+			pending--
+			if result.scan != nil {
+				results[result.image] = result.scan
+			}
+		case <-ctx.Done():
+			logger.Debug("Image scan timed out", zap.Int("pending", pending))
+			return results
+		}
+	}
+
+	return results
+}
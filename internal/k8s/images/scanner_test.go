@@ -0,0 +1,104 @@
+package images
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeScanner returns fixed results and blocks on images listed in slow
+type fakeScanner struct {
+	results map[string]*ImageScan
+	slow    map[string]bool
+}
+
+func (f *fakeScanner) Scan(ctx context.Context, image string) (*ImageScan, error) {
+	if f.slow[image] {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return f.results[image], nil
+}
+
+func TestScanImagesReturnsKnownResults(t *testing.T) {
+	scanned := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	scanner := &fakeScanner{results: map[string]*ImageScan{
+		"nginx:1.25": {Severity: SeverityCounts{Critical: 1, High: 3}, LastScanned: &scanned},
+	}}
+
+	results := ScanImages(context.Background(), zap.NewNop(), scanner, []string{"nginx:1.25", "nginx:1.25", "busybox:latest"}, time.Second)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, 1, results["nginx:1.25"].Severity.Critical)
+	assert.Equal(t, 3, results["nginx:1.25"].Severity.High)
+	assert.Equal(t, &scanned, results["nginx:1.25"].LastScanned)
+}
+
+func TestScanImagesIsTimeBoxed(t *testing.T) {
+	scanner := &fakeScanner{
+		results: map[string]*ImageScan{"fast:1": {Severity: SeverityCounts{Low: 2}}},
+		slow:    map[string]bool{"slow:1": true},
+	}
+
+	start := time.Now()
+	results := ScanImages(context.Background(), zap.NewNop(), scanner, []string{"fast:1", "slow:1"}, 50*time.Millisecond)
+
+	assert.Less(t, time.Since(start), time.Second)
+	require.Len(t, results, 1)
+	assert.Equal(t, 2, results["fast:1"].Severity.Low)
+}
+
+func TestNoopScanner(t *testing.T) {
+	results := ScanImages(context.Background(), zap.NewNop(), NoopScanner{}, []string{"nginx:1.25"}, time.Second)
+	assert.Empty(t, results)
+}
+
+func TestHTTPScanner(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		switch r.URL.Query().Get("image") {
+		case "registry.example.com/app:v1":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"severity":{"critical":0,"high":1,"medium":4},"lastScanned":"2024-05-01T12:00:00Z"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	scanner, err := NewHTTPScanner(zap.NewNop(), HTTPScannerConfig{URL: server.URL, Token: "secret", CacheTTL: time.Minute})
+	require.NoError(t, err)
+
+	scan, err := scanner.Scan(context.Background(), "registry.example.com/app:v1")
+	require.NoError(t, err)
+	require.NotNil(t, scan)
+	assert.Equal(t, 1, scan.Severity.High)
+	assert.Equal(t, 4, scan.Severity.Medium)
+	require.NotNil(t, scan.LastScanned)
+	assert.True(t, scan.LastScanned.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)))
+
+	// Unknown images are not an error
+	scan, err = scanner.Scan(context.Background(), "unknown:latest")
+	require.NoError(t, err)
+	assert.Nil(t, scan)
+
+	// Cached results do not hit the service again
+	_, err = scanner.Scan(context.Background(), "registry.example.com/app:v1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestNewHTTPScannerRequiresURL(t *testing.T) {
+	_, err := NewHTTPScanner(zap.NewNop(), HTTPScannerConfig{})
+	assert.Error(t, err)
+}
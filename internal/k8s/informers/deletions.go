@@ -0,0 +1,143 @@
+package informers
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// DefaultDeletionTrackerSize bounds how many deletions are remembered
+	DefaultDeletionTrackerSize = 1000
+	// DefaultDeletionTrackerMaxAge is how long a deletion is remembered
+	DefaultDeletionTrackerMaxAge = time.Hour
+)
+
+// DeletionOwner is the last known owner of a deleted object
+type DeletionOwner struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// DeletionRecord describes an object whose deletion was observed by an informer
+type DeletionRecord struct {
+	Kind              string         `json:"kind"`
+	Namespace         string         `json:"namespace,omitempty"`
+	Name              string         `json:"name"`
+	UID               string         `json:"uid,omitempty"`
+	DeletionTimestamp time.Time      `json:"deletionTimestamp"`
+	ObservedAt        time.Time      `json:"observedAt"`
+	Owner             *DeletionOwner `json:"owner,omitempty"`
+}
+
+// DeletionTracker remembers recent deletions in a bounded ring. Entries are
+// dropped once the ring is full or they were observed longer ago than the
+// maximum age.
+type DeletionTracker struct {
+	mu      sync.Mutex
+	records []DeletionRecord
+	next    int
+	full    bool
+	maxAge  time.Duration
+	now     func() time.Time
+}
+
+// NewDeletionTracker creates a tracker remembering at most size deletions for
+// at most maxAge
+func NewDeletionTracker(size int, maxAge time.Duration) *DeletionTracker {
+	if size <= 0 {
+		size = DefaultDeletionTrackerSize
+	}
+	if maxAge <= 0 {
+		maxAge = DefaultDeletionTrackerMaxAge
+	}
+	return &DeletionTracker{
+		records: make([]DeletionRecord, size),
+		maxAge:  maxAge,
+		now:     time.Now,
+	}
+}
+
+// Handler returns an informer event handler recording deletions of kind
+func (t *DeletionTracker) Handler(kind string) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			t.Record(kind, obj)
+		},
+	}
+}
+
+// Record stores the deletion of obj. Tombstones left by a missed watch event
+// are unwrapped; objects without metadata are ignored.
+func (t *DeletionTracker) Record(kind string, obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	observed := t.now().UTC()
+	record := DeletionRecord{
+		Kind:              kind,
+		Namespace:         accessor.GetNamespace(),
+		Name:              accessor.GetName(),
+		UID:               string(accessor.GetUID()),
+		DeletionTimestamp: observed,
+		ObservedAt:        observed,
+	}
+	if deleted := accessor.GetDeletionTimestamp(); deleted != nil {
+		record.DeletionTimestamp = deleted.UTC()
+	}
+	if owners := accessor.GetOwnerReferences(); len(owners) > 0 {
+		owner := owners[0]
+		for _, ref := range owners {
+			if ref.Controller != nil && *ref.Controller {
+				owner = ref
+				break
+			}
+		}
+		record.Owner = &DeletionOwner{Kind: owner.Kind, Name: owner.Name}
+	}
+
+	t.records[t.next] = record
+	t.next = (t.next + 1) % len(t.records)
+	if t.next == 0 {
+		t.full = true
+	}
+}
+
+// List returns the remembered deletions, newest first. An empty kind or
+// namespace matches every deletion.
+func (t *DeletionTracker) List(kind, namespace string) []DeletionRecord {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	count := t.next
+	if t.full {
+		count = len(t.records)
+	}
+	cutoff := t.now().Add(-t.maxAge)
+
+	records := make([]DeletionRecord, 0, count)
+	for i := 1; i <= count; i++ {
+		record := t.records[(t.next-i+len(t.records))%len(t.records)]
+		if record.ObservedAt.Before(cutoff) {
+			continue
+		}
+		if kind != "" && record.Kind != kind {
+			continue
+		}
+		if namespace != "" && record.Namespace != namespace {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records
+}
@@ -0,0 +1,80 @@
+package informers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestDeletionRecordedFromInformer(t *testing.T) {
+	controller := true
+	deletedAt := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:              "web-7d9f-abcde",
+		Namespace:         "shop",
+		UID:               "pod-uid",
+		DeletionTimestamp: &deletedAt,
+		OwnerReferences: []metav1.OwnerReference{
+			{Kind: "Node", Name: "node-a"},
+			{Kind: "ReplicaSet", Name: "web-7d9f", Controller: &controller},
+		},
+	}}
+	client := fake.NewSimpleClientset(pod)
+	manager := NewManager(zap.NewNop(), client, nil)
+	require.NoError(t, manager.Start())
+	defer manager.Stop()
+
+	require.NoError(t, client.CoreV1().Pods("shop").Delete(context.Background(), pod.Name, metav1.DeleteOptions{}))
+
+	require.Eventually(t, func() bool {
+		return len(manager.Deletions.List("pods", "shop")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	record := manager.Deletions.List("pods", "shop")[0]
+	assert.Equal(t, "pods", record.Kind)
+	assert.Equal(t, "shop", record.Namespace)
+	assert.Equal(t, "web-7d9f-abcde", record.Name)
+	assert.Equal(t, "pod-uid", record.UID)
+	assert.Equal(t, deletedAt.UTC(), record.DeletionTimestamp)
+	assert.False(t, record.ObservedAt.IsZero())
+	assert.Equal(t, &DeletionOwner{Kind: "ReplicaSet", Name: "web-7d9f"}, record.Owner, "the controller owner is preferred")
+
+	assert.Empty(t, manager.Deletions.List("deployments", ""))
+	assert.Empty(t, manager.Deletions.List("", "other"))
+}
+
+func TestDeletionTrackerBounds(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewDeletionTracker(3, time.Hour)
+	tracker.now = func() time.Time { return now }
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		tracker.Record("configmaps", &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+		now = now.Add(time.Minute)
+	}
+
+	var names []string
+	for _, record := range tracker.List("", "") {
+		names = append(names, record.Name)
+	}
+	assert.Equal(t, []string{"d", "c", "b"}, names, "newest first, oldest evicted")
+	assert.Equal(t, now.Add(-time.Minute), tracker.List("", "")[0].DeletionTimestamp, "deletion time falls back to when it was observed")
+
+	// Tombstones from a missed watch event are unwrapped
+	tracker.Record("configmaps", cache.DeletedFinalStateUnknown{
+		Key: "default/e",
+		Obj: &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "e", Namespace: "default"}},
+	})
+	assert.Equal(t, "e", tracker.List("", "")[0].Name)
+
+	now = now.Add(time.Hour + time.Second)
+	assert.Empty(t, tracker.List("", ""), "entries older than the maximum age are dropped")
+}
@@ -6,6 +6,10 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
@@ -69,6 +73,9 @@ type Manager struct {
 	ClusterRolesInformer        cache.SharedIndexInformer
 	ClusterRoleBindingsInformer cache.SharedIndexInformer
 
+	// Deletions remembers objects recently deleted from the informer caches
+	Deletions *DeletionTracker
+
 	// Context for cancellation
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -155,10 +162,16 @@ func NewManager(logger *zap.Logger, client kubernetes.Interface, dynamicClient d
 		ClusterRolesInformer:        factory.Rbac().V1().ClusterRoles().Informer(),
 		ClusterRoleBindingsInformer: factory.Rbac().V1().ClusterRoleBindings().Informer(),
 
+		Deletions: NewDeletionTracker(DefaultDeletionTrackerSize, DefaultDeletionTrackerMaxAge),
+
 		ctx:    ctx,
 		cancel: cancel,
 	}
 
+	for resource, informer := range manager.resourceInformers() {
+		informer.AddEventHandler(manager.Deletions.Handler(resource))
+	}
+
 	// Add volume snapshot informers if dynamic client is available
 	if dynamicFactory != nil {
 		logger.Info("Creating volume snapshot informers")
@@ -436,6 +449,20 @@ func (m *Manager) GetNodeLister() cache.Indexer {
 	return m.NodesInformer.GetIndexer()
 }
 
+// GetNode returns a node from the node informer cache, falling back to a live
+// Get when the node is not cached yet. The returned node is a copy and may be
+// modified by the caller.
+func (m *Manager) GetNode(ctx context.Context, name string) (*v1.Node, error) {
+	if obj, exists, err := m.NodesInformer.GetIndexer().GetByKey(name); err == nil && exists {
+		if node, ok := obj.(*v1.Node); ok {
+			return node.DeepCopy(), nil
+		}
+	}
+
+	m.logger.Debug("Node not in informer cache, fetching live", zap.String("name", name))
+	return m.client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+}
+
 // GetPodLister returns a lister for pods
 func (m *Manager) GetPodLister() cache.Indexer {
 	return m.PodsInformer.GetIndexer()
@@ -578,3 +605,141 @@ func (m *Manager) GetClusterRoleLister() cache.Indexer {
 func (m *Manager) GetClusterRoleBindingLister() cache.Indexer {
 	return m.ClusterRoleBindingsInformer.GetIndexer()
 }
+
+// ObjectCounts returns the number of cached objects per resource, keyed by the
+// lower-case plural resource name. Informers that have not synced yet are
+// omitted so callers never mistake an empty cache for an empty cluster.
+func (m *Manager) ObjectCounts() map[string]int {
+	informers := m.resourceInformers()
+	counts := make(map[string]int, len(informers))
+	for resource, informer := range informers {
+		if informer == nil || !informer.HasSynced() {
+			continue
+		}
+		counts[resource] = len(informer.GetStore().ListKeys())
+	}
+	return counts
+}
+
+// ListResource returns the cached objects of a resource, keyed by the
+// lower-case plural resource name like ObjectCounts. ok is false for an
+// unknown resource or an informer that has not synced yet.
+func (m *Manager) ListResource(resource string) ([]interface{}, bool) {
+	informer := m.resourceInformers()[resource]
+	if informer == nil || !informer.HasSynced() {
+		return nil, false
+	}
+	return informer.GetStore().List(), true
+}
+
+// ListPods returns the cached Pods. ok is false while the informer has not
+// synced.
+func (m *Manager) ListPods() ([]*v1.Pod, bool) {
+	if m.PodsInformer == nil || !m.PodsInformer.HasSynced() {
+		return nil, false
+	}
+	objects := m.PodsInformer.GetStore().List()
+	pods := make([]*v1.Pod, 0, len(objects))
+	for _, obj := range objects {
+		if pod, ok := obj.(*v1.Pod); ok {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, true
+}
+
+// ListEndpointSlices returns the cached EndpointSlices. ok is false while the
+// informer has not synced.
+func (m *Manager) ListEndpointSlices() ([]*discoveryv1.EndpointSlice, bool) {
+	if m.EndpointSlicesInformer == nil || !m.EndpointSlicesInformer.HasSynced() {
+		return nil, false
+	}
+	objects := m.EndpointSlicesInformer.GetStore().List()
+	slices := make([]*discoveryv1.EndpointSlice, 0, len(objects))
+	for _, obj := range objects {
+		if slice, ok := obj.(*discoveryv1.EndpointSlice); ok {
+			slices = append(slices, slice)
+		}
+	}
+	return slices, true
+}
+
+// ListPersistentVolumeClaims returns the cached PersistentVolumeClaims. ok is
+// false while the informer has not synced.
+func (m *Manager) ListPersistentVolumeClaims() ([]*v1.PersistentVolumeClaim, bool) {
+	if m.PersistentVolumeClaimsInformer == nil || !m.PersistentVolumeClaimsInformer.HasSynced() {
+		return nil, false
+	}
+	objects := m.PersistentVolumeClaimsInformer.GetStore().List()
+	pvcs := make([]*v1.PersistentVolumeClaim, 0, len(objects))
+	for _, obj := range objects {
+		if pvc, ok := obj.(*v1.PersistentVolumeClaim); ok {
+			pvcs = append(pvcs, pvc)
+		}
+	}
+	return pvcs, true
+}
+
+// ListDeployments returns the cached Deployments. ok is false while the
+// informer has not synced.
+func (m *Manager) ListDeployments() ([]*appsv1.Deployment, bool) {
+	if m.DeploymentsInformer == nil || !m.DeploymentsInformer.HasSynced() {
+		return nil, false
+	}
+	objects := m.DeploymentsInformer.GetStore().List()
+	deployments := make([]*appsv1.Deployment, 0, len(objects))
+	for _, obj := range objects {
+		if deployment, ok := obj.(*appsv1.Deployment); ok {
+			deployments = append(deployments, deployment)
+		}
+	}
+	return deployments, true
+}
+
+// ListDaemonSets returns the cached DaemonSets. ok is false while the
+// informer has not synced.
+func (m *Manager) ListDaemonSets() ([]*appsv1.DaemonSet, bool) {
+	if m.DaemonSetsInformer == nil || !m.DaemonSetsInformer.HasSynced() {
+		return nil, false
+	}
+	objects := m.DaemonSetsInformer.GetStore().List()
+	daemonSets := make([]*appsv1.DaemonSet, 0, len(objects))
+	for _, obj := range objects {
+		if daemonSet, ok := obj.(*appsv1.DaemonSet); ok {
+			daemonSets = append(daemonSets, daemonSet)
+		}
+	}
+	return daemonSets, true
+}
+
+// ResourceInformer returns the informer of a resource, keyed by the lower-case
+// plural resource name like ListResource. ok is false for an unknown resource
+// or an informer that has not synced yet.
+func (m *Manager) ResourceInformer(resource string) (cache.SharedIndexInformer, bool) {
+	informer := m.resourceInformers()[resource]
+	if informer == nil || !informer.HasSynced() {
+		return nil, false
+	}
+	return informer, true
+}
+
+// resourceInformers returns the informers of the common built-in resources,
+// keyed by the lower-case plural resource name
+func (m *Manager) resourceInformers() map[string]cache.SharedIndexInformer {
+	return map[string]cache.SharedIndexInformer{
+		"pods":                   m.PodsInformer,
+		"nodes":                  m.NodesInformer,
+		"namespaces":             m.NamespacesInformer,
+		"deployments":            m.DeploymentsInformer,
+		"statefulsets":           m.StatefulSetsInformer,
+		"daemonsets":             m.DaemonSetsInformer,
+		"replicasets":            m.ReplicaSetsInformer,
+		"jobs":                   m.JobsInformer,
+		"cronjobs":               m.CronJobsInformer,
+		"services":               m.ServicesInformer,
+		"configmaps":             m.ConfigMapsInformer,
+		"secrets":                m.SecretsInformer,
+		"ingresses":              m.IngressesInformer,
+		"persistentvolumeclaims": m.PersistentVolumeClaimsInformer,
+	}
+}
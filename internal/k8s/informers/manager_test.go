@@ -0,0 +1,123 @@
+package informers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func countNodeGets(client *fake.Clientset) int {
+	gets := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "get" && action.GetResource().Resource == "nodes" {
+			gets++
+		}
+	}
+	return gets
+}
+
+func TestGetNodeCacheHit(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	manager := NewManager(zap.NewNop(), client, nil)
+
+	cached := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"zone": "a"}}}
+	require.NoError(t, manager.NodesInformer.GetIndexer().Add(cached))
+
+	node, err := manager.GetNode(context.Background(), "node-a")
+	require.NoError(t, err)
+	assert.Equal(t, "a", node.Labels["zone"])
+	assert.Equal(t, 0, countNodeGets(client), "a cached node must not reach the API server")
+
+	// Callers get a copy, never the cached object
+	node.Labels["zone"] = "b"
+	assert.Equal(t, "a", cached.Labels["zone"])
+}
+
+func TestGetNodeCacheMissFallsBackToLiveGet(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}})
+	manager := NewManager(zap.NewNop(), client, nil)
+
+	node, err := manager.GetNode(context.Background(), "node-b")
+	require.NoError(t, err)
+	assert.Equal(t, "node-b", node.Name)
+	assert.Equal(t, 1, countNodeGets(client))
+
+	_, err = manager.GetNode(context.Background(), "missing")
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestObjectCountsMatchInformerContents(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod"}},
+	)
+	manager := NewManager(zap.NewNop(), client, nil)
+
+	// Unsynced caches are not reported
+	assert.Empty(t, manager.ObjectCounts())
+
+	require.NoError(t, manager.Start())
+	defer manager.Stop()
+
+	counts := manager.ObjectCounts()
+	assert.Equal(t, len(manager.DeploymentsInformer.GetStore().List()), counts["deployments"])
+	assert.Equal(t, 2, counts["deployments"])
+	assert.Equal(t, 1, counts["configmaps"])
+	assert.Equal(t, 2, counts["namespaces"])
+	assert.Equal(t, 0, counts["secrets"])
+	assert.Equal(t, 0, counts["pods"])
+	assert.Len(t, counts, len(manager.resourceInformers()))
+}
+
+func TestListResource(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "default"}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+	)
+	manager := NewManager(zap.NewNop(), client, nil)
+
+	_, ok := manager.ListResource("pods")
+	assert.False(t, ok, "an unsynced cache is not listed")
+
+	require.NoError(t, manager.Start())
+	defer manager.Stop()
+
+	pods, ok := manager.ListResource("pods")
+	require.True(t, ok)
+	assert.Len(t, pods, 1)
+	nodes, ok := manager.ListResource("nodes")
+	require.True(t, ok)
+	assert.Len(t, nodes, 1)
+
+	_, ok = manager.ListResource("widgets")
+	assert.False(t, ok)
+}
+
+func TestListPods(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "default"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-0", Namespace: "prod"}},
+	)
+	manager := NewManager(zap.NewNop(), client, nil)
+
+	_, ok := manager.ListPods()
+	assert.False(t, ok, "an unsynced cache is not listed")
+
+	require.NoError(t, manager.Start())
+	defer manager.Stop()
+
+	pods, ok := manager.ListPods()
+	require.True(t, ok)
+	assert.Len(t, pods, 2)
+}
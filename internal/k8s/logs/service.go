@@ -28,12 +28,20 @@ type LogEntry struct {
 type LogFilter struct {
 	Container    string
 	SinceSeconds *int64
+	SinceTime    *metav1.Time // e.g. a container's last start
 	TailLines    *int64
 	Follow       bool
 	Timestamps   bool
 	Previous     bool
+	// Reconnect reopens a followed stream that ends while the pod is still
+	// running, such as when its container restarts
+	Reconnect bool
 }
 
+// reconnectDelay is how long a followed stream waits before reopening after
+// the container's log stream ends
+var reconnectDelay = 2 * time.Second
+
 // StreamManager manages active log streams
 type StreamManager struct {
 	logger       *zap.Logger
@@ -136,7 +144,7 @@ func (sm *StreamManager) streamLogs(stream *LogStream) {
 	// Get pod information to determine containers
 	pod, err := sm.kubeClient.CoreV1().Pods(stream.namespace).Get(stream.ctx, stream.podName, metav1.GetOptions{})
 	if err != nil {
-		stream.errors <- fmt.Errorf("failed to get pod: %w", err)
+		stream.sendError(fmt.Errorf("failed to get pod: %w", err))
 		return
 	}
 
@@ -168,7 +176,10 @@ func (sm *StreamManager) streamLogs(stream *LogStream) {
 	wg.Wait()
 }
 
-// streamContainerLogs streams logs from a specific container
+// streamContainerLogs streams logs from a specific container. With
+// Reconnect, a followed stream that ends while the pod is still running is
+// reopened from the moment it ended, so the output of a restarted container
+// continues on the same stream.
 func (sm *StreamManager) streamContainerLogs(stream *LogStream, containerName string) {
 	logOptions := &v1.PodLogOptions{
 		Container:  containerName,
@@ -180,40 +191,97 @@ func (sm *StreamManager) streamContainerLogs(stream *LogStream, containerName st
 	if stream.filter.SinceSeconds != nil {
 		logOptions.SinceSeconds = stream.filter.SinceSeconds
 	}
+	if stream.filter.SinceTime != nil {
+		logOptions.SinceTime = stream.filter.SinceTime
+	}
 
 	if stream.filter.TailLines != nil {
 		logOptions.TailLines = stream.filter.TailLines
 	}
 
-	// Get log stream
+	reconnecting := false
+	for {
+		err := sm.readContainerLogs(stream, containerName, logOptions)
+		// A restarting container refuses log requests until it runs again, so
+		// only the first open reports its error
+		if err != nil && !reconnecting {
+			stream.sendError(err)
+			return
+		}
+		if !stream.filter.Follow || !stream.filter.Reconnect || stream.filter.Previous {
+			return
+		}
+
+		ended := metav1.Now()
+		select {
+		case <-stream.ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+		if !sm.podRunning(stream) {
+			return
+		}
+
+		sm.logger.Debug("Reconnecting log stream",
+			zap.String("streamID", stream.ID),
+			zap.String("namespace", stream.namespace),
+			zap.String("pod", stream.podName),
+			zap.String("container", containerName))
+		reconnecting = true
+		logOptions.SinceSeconds = nil
+		logOptions.TailLines = nil
+		logOptions.SinceTime = &ended
+	}
+}
+
+// readContainerLogs opens one log stream of a container and forwards its lines
+// until the stream ends or the log stream is cancelled
+func (sm *StreamManager) readContainerLogs(stream *LogStream, containerName string, logOptions *v1.PodLogOptions) error {
 	req := sm.kubeClient.CoreV1().Pods(stream.namespace).GetLogs(stream.podName, logOptions)
 	logStream, err := req.Stream(stream.ctx)
 	if err != nil {
-		stream.errors <- fmt.Errorf("failed to get log stream for container %s: %w", containerName, err)
-		return
+		return fmt.Errorf("failed to get log stream for container %s: %w", containerName, err)
 	}
 	defer logStream.Close()
 
-	// Read logs line by line
+	// Read logs line by line. Sending blocks while the consumer is behind,
+	// which stops reading from the API server instead of buffering without
+	// bound.
 	scanner := bufio.NewScanner(logStream)
 	for scanner.Scan() {
+		logEntry := sm.parseLogLine(scanner.Text(), containerName, stream.namespace, stream.podName, stream.filter.Timestamps)
 		select {
+		case stream.events <- logEntry:
 		case <-stream.ctx.Done():
-			return
-		default:
-			line := scanner.Text()
-			logEntry := sm.parseLogLine(line, containerName, stream.namespace, stream.podName, stream.filter.Timestamps)
-
-			select {
-			case stream.events <- logEntry:
-			case <-stream.ctx.Done():
-				return
-			}
+			return nil
 		}
 	}
 
-	if err := scanner.Err(); err != nil && err != io.EOF {
-		stream.errors <- fmt.Errorf("error reading logs from container %s: %w", containerName, err)
+	if err := scanner.Err(); err != nil && err != io.EOF && stream.ctx.Err() == nil {
+		return fmt.Errorf("error reading logs from container %s: %w", containerName, err)
+	}
+	return nil
+}
+
+// podRunning reports whether the pod of a stream still exists and has not
+// finished, so its containers may produce more logs
+func (sm *StreamManager) podRunning(stream *LogStream) bool {
+	pod, err := sm.kubeClient.CoreV1().Pods(stream.namespace).Get(stream.ctx, stream.podName, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	return pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed
+}
+
+// sendError reports a stream error unless the stream has been cancelled, so a
+// consumer that stopped reading never blocks the stream
+func (ls *LogStream) sendError(err error) {
+	select {
+	case ls.errors <- err:
+	case <-ls.ctx.Done():
 	}
 }
 
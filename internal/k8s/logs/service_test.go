@@ -0,0 +1,129 @@
+package logs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func runningPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "web"}, {Name: "sidecar"}}},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+// collectEntries reads entries until the stream ends or n entries arrived
+func collectEntries(t *testing.T, stream *LogStream, n int) []LogEntry {
+	t.Helper()
+	var entries []LogEntry
+	timeout := time.After(5 * time.Second)
+	for len(entries) < n {
+		select {
+		case entry, ok := <-stream.Events():
+			if !ok {
+				return entries
+			}
+			entries = append(entries, entry)
+		case <-timeout:
+			t.Fatalf("timed out after %d of %d log entries", len(entries), n)
+		}
+	}
+	return entries
+}
+
+func TestStreamFollowsAllContainers(t *testing.T) {
+	sm := NewStreamManager(zap.NewNop(), fake.NewSimpleClientset(runningPod()))
+
+	stream, err := sm.StartStream(context.Background(), "s1", "shop", "web-0", LogFilter{Follow: true})
+	require.NoError(t, err)
+	defer sm.StopStream("s1")
+
+	// The fake client serves a single line per container and ends the stream
+	entries := collectEntries(t, stream, 3)
+	require.Len(t, entries, 2)
+	containers := []string{entries[0].Container, entries[1].Container}
+	assert.ElementsMatch(t, []string{"web", "sidecar"}, containers)
+	assert.Equal(t, "fake logs", entries[0].Line)
+
+	select {
+	case <-stream.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream did not finish")
+	}
+}
+
+func TestStreamReconnectsUntilPodFinishes(t *testing.T) {
+	previous := reconnectDelay
+	reconnectDelay = 10 * time.Millisecond
+	defer func() { reconnectDelay = previous }()
+
+	kubeClient := fake.NewSimpleClientset(runningPod())
+	sm := NewStreamManager(zap.NewNop(), kubeClient)
+
+	stream, err := sm.StartStream(context.Background(), "s1", "shop", "web-0", LogFilter{Container: "web", Follow: true, Reconnect: true})
+	require.NoError(t, err)
+	defer sm.StopStream("s1")
+
+	// Each time the container's stream ends it is reopened
+	entries := collectEntries(t, stream, 3)
+	require.Len(t, entries, 3)
+
+	pod := runningPod()
+	pod.Status.Phase = v1.PodSucceeded
+	_, err = kubeClient.CoreV1().Pods("shop").UpdateStatus(context.Background(), pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// Once the pod has finished the stream ends
+	for range stream.Events() {
+	}
+	select {
+	case <-stream.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream did not finish")
+	}
+}
+
+func TestStopStreamReleasesBlockedReaders(t *testing.T) {
+	previous := reconnectDelay
+	reconnectDelay = time.Millisecond
+	defer func() { reconnectDelay = previous }()
+
+	sm := NewStreamManager(zap.NewNop(), fake.NewSimpleClientset(runningPod()))
+	stream, err := sm.StartStream(context.Background(), "s1", "shop", "web-0", LogFilter{Follow: true, Reconnect: true})
+	require.NoError(t, err)
+
+	// Nobody reads the stream, so its readers fill the buffer and block
+	time.Sleep(50 * time.Millisecond)
+	sm.StopStream("s1")
+
+	select {
+	case <-stream.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("stopped stream did not release its readers")
+	}
+	_, exists := sm.GetStream("s1")
+	assert.False(t, exists)
+}
+
+func TestStreamReportsMissingPod(t *testing.T) {
+	sm := NewStreamManager(zap.NewNop(), fake.NewSimpleClientset())
+	stream, err := sm.StartStream(context.Background(), "s1", "shop", "missing", LogFilter{Follow: true})
+	require.NoError(t, err)
+	defer sm.StopStream("s1")
+
+	select {
+	case err := <-stream.Errors():
+		assert.Contains(t, err.Error(), "failed to get pod")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error reported")
+	}
+}
@@ -3,8 +3,10 @@ package metrics
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/aaronlmathis/kaptn/internal/k8s/units"
 	"go.uber.org/zap"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -13,11 +15,13 @@ import (
 
 // ClusterMetrics represents overall cluster health metrics
 type ClusterMetrics struct {
-	Timestamp      time.Time        `json:"timestamp"`
-	NodeMetrics    []NodeMetrics    `json:"nodeMetrics"`
-	PodMetrics     []PodMetrics     `json:"podMetrics"`
-	ClusterSummary ClusterSummary   `json:"clusterSummary"`
-	ResourceQuotas []NamespaceQuota `json:"resourceQuotas"`
+	Timestamp         time.Time        `json:"timestamp"`
+	MetricsAgeSeconds float64          `json:"metricsAgeSeconds"`
+	Stale             bool             `json:"stale"`
+	NodeMetrics       []NodeMetrics    `json:"nodeMetrics"`
+	PodMetrics        []PodMetrics     `json:"podMetrics"`
+	ClusterSummary    ClusterSummary   `json:"clusterSummary"`
+	ResourceQuotas    []NamespaceQuota `json:"resourceQuotas"`
 }
 
 // NodeMetrics represents resource usage for a node
@@ -76,22 +80,93 @@ type ResourceQuotaSpec struct {
 	Used string `json:"used"`
 }
 
+// DefaultStaleThreshold is how old the last metrics sample may be before usage is reported as stale
+const DefaultStaleThreshold = 2 * time.Minute
+
+// MetricsFreshness describes how current the usage data from metrics-server is
+type MetricsFreshness struct {
+	LastCollected *time.Time `json:"lastCollected,omitempty"`
+	AgeSeconds    float64    `json:"metricsAgeSeconds"`
+	Stale         bool       `json:"stale"`
+}
+
 // MetricsService provides cluster metrics collection
 type MetricsService struct {
 	logger        *zap.Logger
 	kubeClient    kubernetes.Interface
 	metricsClient metricsv1beta1.MetricsV1beta1Interface
+
+	// Freshness tracking for metrics-server samples
+	freshnessMu    sync.RWMutex
+	lastCollected  time.Time
+	staleThreshold time.Duration
+	now            func() time.Time
 }
 
 // NewMetricsService creates a new metrics service
 func NewMetricsService(logger *zap.Logger, kubeClient kubernetes.Interface, metricsClient metricsv1beta1.MetricsV1beta1Interface) *MetricsService {
 	return &MetricsService{
-		logger:        logger,
-		kubeClient:    kubeClient,
-		metricsClient: metricsClient,
+		logger:         logger,
+		kubeClient:     kubeClient,
+		metricsClient:  metricsClient,
+		staleThreshold: DefaultStaleThreshold,
+		now:            time.Now,
+	}
+}
+
+// SetStaleThreshold sets the sample age beyond which usage is reported as stale
+func (ms *MetricsService) SetStaleThreshold(threshold time.Duration) {
+	if threshold <= 0 {
+		return
+	}
+	ms.freshnessMu.Lock()
+	defer ms.freshnessMu.Unlock()
+	ms.staleThreshold = threshold
+}
+
+// Freshness reports the age of the newest metrics sample. When no sample has
+// been collected yet the age is -1 and the metrics are considered stale.
+func (ms *MetricsService) Freshness() MetricsFreshness {
+	ms.freshnessMu.RLock()
+	defer ms.freshnessMu.RUnlock()
+
+	if ms.lastCollected.IsZero() {
+		return MetricsFreshness{AgeSeconds: -1, Stale: true}
+	}
+
+	lastCollected := ms.lastCollected
+	age := ms.now().Sub(lastCollected)
+	if age < 0 {
+		age = 0
+	}
+
+	return MetricsFreshness{
+		LastCollected: &lastCollected,
+		AgeSeconds:    age.Seconds(),
+		Stale:         age > ms.staleThreshold,
 	}
 }
 
+// recordCollection records a successful metrics-server list. The sample time is
+// the newest timestamp reported by metrics-server, so a server that keeps
+// answering with old scrapes is still detected as stale. Empty lists carry no
+// sample time and leave the freshness unchanged.
+func (ms *MetricsService) recordCollection(sampleTime time.Time) {
+	ms.freshnessMu.Lock()
+	defer ms.freshnessMu.Unlock()
+
+	if sampleTime.After(ms.lastCollected) {
+		ms.lastCollected = sampleTime
+	}
+}
+
+// applyFreshness copies the current freshness onto a metrics response
+func (ms *MetricsService) applyFreshness(metrics *ClusterMetrics) {
+	freshness := ms.Freshness()
+	metrics.MetricsAgeSeconds = freshness.AgeSeconds
+	metrics.Stale = freshness.Stale
+}
+
 // GetClusterMetrics retrieves comprehensive cluster metrics
 func (ms *MetricsService) GetClusterMetrics(ctx context.Context) (*ClusterMetrics, error) {
 	metrics := &ClusterMetrics{
@@ -169,6 +244,7 @@ func (ms *MetricsService) GetClusterMetrics(ctx context.Context) (*ClusterMetric
 		}
 	}
 
+	ms.applyFreshness(metrics)
 	return metrics, nil
 }
 
@@ -193,14 +269,19 @@ func (ms *MetricsService) getNodeMetrics(ctx context.Context) ([]NodeMetrics, er
 	for _, node := range nodes.Items {
 		nodeCapacities[node.Name] = map[string]int64{
 			"cpu":    node.Status.Capacity.Cpu().MilliValue(),
-			"memory": node.Status.Capacity.Memory().Value(),
+			"memory": units.ParseQuantityBytes(*node.Status.Capacity.Memory()),
 		}
 	}
 
+	var newestSample time.Time
 	var nodeMetrics []NodeMetrics
 	for _, nodeMetric := range nodeMetricsList.Items {
+		if nodeMetric.Timestamp.Time.After(newestSample) {
+			newestSample = nodeMetric.Timestamp.Time
+		}
+
 		cpuUsed := nodeMetric.Usage.Cpu().MilliValue()
-		memoryUsed := nodeMetric.Usage.Memory().Value()
+		memoryUsed := units.ParseQuantityBytes(*nodeMetric.Usage.Memory())
 
 		cpuCapacity := nodeCapacities[nodeMetric.Name]["cpu"]
 		memoryCapacity := nodeCapacities[nodeMetric.Name]["memory"]
@@ -214,13 +295,14 @@ func (ms *MetricsService) getNodeMetrics(ctx context.Context) ([]NodeMetrics, er
 				Percent:   calculatePercentage(cpuUsed, cpuCapacity),
 			},
 			Memory: ResourceUsage{
-				Used:      nodeMetric.Usage.Memory().String(),
+				Used:      units.HumanizeBytes(memoryUsed),
 				UsedBytes: memoryUsed,
 				Percent:   calculatePercentage(memoryUsed, memoryCapacity),
 			},
 		})
 	}
 
+	ms.recordCollection(newestSample)
 	return nodeMetrics, nil
 }
 
@@ -235,8 +317,13 @@ func (ms *MetricsService) getPodMetrics(ctx context.Context) ([]PodMetrics, erro
 		return nil, err
 	}
 
+	var newestSample time.Time
 	var podMetrics []PodMetrics
 	for _, podMetric := range podMetricsList.Items {
+		if podMetric.Timestamp.Time.After(newestSample) {
+			newestSample = podMetric.Timestamp.Time
+		}
+
 		var containerMetrics []ContainerMetrics
 		for _, container := range podMetric.Containers {
 			containerMetrics = append(containerMetrics, ContainerMetrics{
@@ -246,8 +333,8 @@ func (ms *MetricsService) getPodMetrics(ctx context.Context) ([]PodMetrics, erro
 					UsedBytes: container.Usage.Cpu().MilliValue(),
 				},
 				Memory: ResourceUsage{
-					Used:      container.Usage.Memory().String(),
-					UsedBytes: container.Usage.Memory().Value(),
+					Used:      units.HumanizeQuantityBytes(*container.Usage.Memory()),
+					UsedBytes: units.ParseQuantityBytes(*container.Usage.Memory()),
 				},
 			})
 		}
@@ -260,6 +347,7 @@ func (ms *MetricsService) getPodMetrics(ctx context.Context) ([]PodMetrics, erro
 		})
 	}
 
+	ms.recordCollection(newestSample)
 	return podMetrics, nil
 }
 
@@ -315,7 +403,7 @@ func (ms *MetricsService) getClusterSummary(ctx context.Context) (ClusterSummary
 
 			for _, node := range nodes.Items {
 				totalCPUCapacity += node.Status.Capacity.Cpu().MilliValue()
-				totalMemoryCapacity += node.Status.Capacity.Memory().Value()
+				totalMemoryCapacity += units.ParseQuantityBytes(*node.Status.Capacity.Memory())
 			}
 
 			summary.CPUUtilization = calculatePercentage(totalCPUUsed, totalCPUCapacity)
@@ -380,11 +468,13 @@ func (ms *MetricsService) GetNamespaceMetrics(ctx context.Context, namespace str
 		return nil, err
 	}
 
-	return &ClusterMetrics{
+	metrics := &ClusterMetrics{
 		Timestamp:      time.Now(),
 		PodMetrics:     podMetrics,
 		ResourceQuotas: quotas,
-	}, nil
+	}
+	ms.applyFreshness(metrics)
+	return metrics, nil
 }
 
 // getNamespacePodMetrics retrieves pod metrics for a specific namespace
@@ -398,8 +488,13 @@ func (ms *MetricsService) getNamespacePodMetrics(ctx context.Context, namespace
 		return nil, err
 	}
 
+	var newestSample time.Time
 	var podMetrics []PodMetrics
 	for _, podMetric := range podMetricsList.Items {
+		if podMetric.Timestamp.Time.After(newestSample) {
+			newestSample = podMetric.Timestamp.Time
+		}
+
 		var containerMetrics []ContainerMetrics
 		for _, container := range podMetric.Containers {
 			containerMetrics = append(containerMetrics, ContainerMetrics{
@@ -409,8 +504,8 @@ func (ms *MetricsService) getNamespacePodMetrics(ctx context.Context, namespace
 					UsedBytes: container.Usage.Cpu().MilliValue(),
 				},
 				Memory: ResourceUsage{
-					Used:      container.Usage.Memory().String(),
-					UsedBytes: container.Usage.Memory().Value(),
+					Used:      units.HumanizeQuantityBytes(*container.Usage.Memory()),
+					UsedBytes: units.ParseQuantityBytes(*container.Usage.Memory()),
 				},
 			})
 		}
@@ -423,6 +518,7 @@ func (ms *MetricsService) getNamespacePodMetrics(ctx context.Context, namespace
 		})
 	}
 
+	ms.recordCollection(newestSample)
 	return podMetrics, nil
 }
 
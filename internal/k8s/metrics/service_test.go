@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func newFreshnessTestService(sampleTime, now time.Time) *MetricsService {
+	kubeClient := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}})
+	metricsClient := metricsfake.NewSimpleClientset()
+	// The fake tracker does not map NodeMetrics to the "nodes" resource, so serve the list directly
+	metricsClient.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsv1beta1api.NodeMetricsList{Items: []metricsv1beta1api.NodeMetrics{{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+			Timestamp:  metav1.NewTime(sampleTime),
+			Usage: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("250m"),
+				v1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		}}}, nil
+	})
+
+	ms := NewMetricsService(zap.NewNop(), kubeClient, metricsClient.MetricsV1beta1())
+	ms.now = func() time.Time { return now }
+	return ms
+}
+
+func TestGetClusterMetricsFresh(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ms := newFreshnessTestService(now.Add(-30*time.Second), now)
+
+	metrics, err := ms.GetClusterMetrics(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 30.0, metrics.MetricsAgeSeconds)
+	assert.False(t, metrics.Stale)
+
+	freshness := ms.Freshness()
+	require.NotNil(t, freshness.LastCollected)
+	assert.True(t, freshness.LastCollected.Equal(now.Add(-30*time.Second)))
+}
+
+func TestGetClusterMetricsStale(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ms := newFreshnessTestService(now.Add(-10*time.Minute), now)
+
+	metrics, err := ms.GetClusterMetrics(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 600.0, metrics.MetricsAgeSeconds)
+	assert.True(t, metrics.Stale)
+
+	// Raising the threshold above the sample age makes the same data fresh
+	ms.SetStaleThreshold(15 * time.Minute)
+	assert.False(t, ms.Freshness().Stale)
+}
+
+func TestFreshnessBeforeFirstCollection(t *testing.T) {
+	ms := NewMetricsService(zap.NewNop(), fake.NewSimpleClientset(), nil)
+
+	freshness := ms.Freshness()
+	assert.Nil(t, freshness.LastCollected)
+	assert.Equal(t, -1.0, freshness.AgeSeconds)
+	assert.True(t, freshness.Stale)
+}
@@ -32,8 +32,9 @@ type OverviewData struct {
 	Memory struct {
 		UsagePercent float64 `json:"usagePercent"`
 	} `json:"memory"`
-	Advisories []string  `json:"advisories"`
-	AsOf       time.Time `json:"asOf"`
+	Metrics    metrics.MetricsFreshness `json:"metrics"`
+	Advisories []string                 `json:"advisories"`
+	AsOf       time.Time                `json:"asOf"`
 }
 
 // CachedOverview represents cached overview data with TTL
@@ -252,6 +253,13 @@ func (os *OverviewService) fetchOverviewData(ctx context.Context) (*OverviewData
 		data.Memory.UsagePercent = metricsResult.memoryPercent
 	}
 
+	// Report how old the usage figures are so flat usage can be told apart from stale data
+	if os.metricsService != nil {
+		data.Metrics = os.metricsService.Freshness()
+	} else {
+		data.Metrics = metrics.MetricsFreshness{AgeSeconds: -1, Stale: true}
+	}
+
 	// Check for critical errors
 	if podErr != nil || nodeErr != nil {
 		return nil, fmt.Errorf("failed to fetch overview data: pods=%v, nodes=%v, metrics=%v", podErr, nodeErr, metricsErr)
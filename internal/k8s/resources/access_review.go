@@ -0,0 +1,294 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessReviewSubject is the User, Group or ServiceAccount an access review
+// evaluates. Namespace is only used by ServiceAccounts.
+type AccessReviewSubject struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Validate checks that the subject names a supported kind
+func (s AccessReviewSubject) Validate() error {
+	switch s.Kind {
+	case rbacv1.UserKind, rbacv1.GroupKind:
+	case rbacv1.ServiceAccountKind:
+		if s.Namespace == "" {
+			return fmt.Errorf("a ServiceAccount subject requires a namespace")
+		}
+	default:
+		return fmt.Errorf("subject kind must be User, Group or ServiceAccount, got %q", s.Kind)
+	}
+	if s.Name == "" {
+		return fmt.Errorf("subject name is required")
+	}
+	return nil
+}
+
+// AccessReviewDecision is the API server's answer to a SubjectAccessReview or
+// SelfSubjectAccessReview
+type AccessReviewDecision struct {
+	Allowed         bool   `json:"allowed"`
+	Denied          bool   `json:"denied,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+	EvaluationError string `json:"evaluationError,omitempty"`
+}
+
+// AccessReviewBinding is a RoleBinding or ClusterRoleBinding whose role grants
+// the reviewed verb. ResourceNames lists the objects the matching rules are
+// restricted to, empty when they apply to every object.
+type AccessReviewBinding struct {
+	Kind          string           `json:"kind"`
+	Name          string           `json:"name"`
+	Namespace     string           `json:"namespace,omitempty"`
+	RoleKind      string           `json:"roleKind"`
+	RoleName      string           `json:"roleName"`
+	Subjects      []rbacv1.Subject `json:"subjects"`
+	ResourceNames []string         `json:"resourceNames,omitempty"`
+}
+
+// AccessReviewResult holds the API server's decision and the bindings that
+// grant the access. Subject is nil when the review was evaluated for the
+// caller.
+type AccessReviewResult struct {
+	Verb      string                `json:"verb"`
+	Group     string                `json:"group"`
+	Resource  string                `json:"resource"`
+	Namespace string                `json:"namespace,omitempty"`
+	Subject   *AccessReviewSubject  `json:"subject,omitempty"`
+	Review    AccessReviewDecision  `json:"review"`
+	Bindings  []AccessReviewBinding `json:"bindings"`
+}
+
+// AccessReview answers "who can verb resource in namespace". It asks the API
+// server with a SubjectAccessReview for subject, or a SelfSubjectAccessReview
+// for the caller when subject is nil, and scans RoleBindings and
+// ClusterRoleBindings for those whose role grants the verb. With a subject
+// only the bindings naming it, directly or through the groups of a
+// ServiceAccount, are listed. An empty namespace asks about every namespace,
+// so RoleBindings of all namespaces are scanned. A subresource is written
+// after the resource, as in pods/exec.
+func (rm *ResourceManager) AccessReview(ctx context.Context, verb, group, resource, namespace string, subject *AccessReviewSubject) (*AccessReviewResult, error) {
+	result := &AccessReviewResult{
+		Verb:      verb,
+		Group:     group,
+		Resource:  resource,
+		Namespace: namespace,
+		Subject:   subject,
+		Bindings:  []AccessReviewBinding{},
+	}
+
+	review, err := rm.reviewAccess(ctx, verb, group, resource, namespace, subject)
+	if err != nil {
+		return nil, err
+	}
+	result.Review = review
+
+	clusterRoles, err := rm.kubeClient.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster roles: %w", err)
+	}
+	clusterRoleRules := make(map[string][]rbacv1.PolicyRule, len(clusterRoles.Items))
+	for _, role := range clusterRoles.Items {
+		clusterRoleRules[role.Name] = role.Rules
+	}
+	roles, err := rm.kubeClient.RbacV1().Roles(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	roleRules := make(map[string][]rbacv1.PolicyRule, len(roles.Items))
+	for _, role := range roles.Items {
+		roleRules[role.Namespace+"/"+role.Name] = role.Rules
+	}
+	rulesFor := func(ref rbacv1.RoleRef, bindingNamespace string) []rbacv1.PolicyRule {
+		if ref.Kind == "ClusterRole" {
+			return clusterRoleRules[ref.Name]
+		}
+		return roleRules[bindingNamespace+"/"+ref.Name]
+	}
+
+	clusterRoleBindings, err := rm.kubeClient.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster role bindings: %w", err)
+	}
+	for _, binding := range clusterRoleBindings.Items {
+		if subject != nil && !accessReviewSubjectBound(binding.Subjects, "", *subject) {
+			continue
+		}
+		if granted, names := rulesGrant(rulesFor(binding.RoleRef, ""), verb, group, resource); granted {
+			result.Bindings = append(result.Bindings, AccessReviewBinding{
+				Kind:          "ClusterRoleBinding",
+				Name:          binding.Name,
+				RoleKind:      binding.RoleRef.Kind,
+				RoleName:      binding.RoleRef.Name,
+				Subjects:      binding.Subjects,
+				ResourceNames: names,
+			})
+		}
+	}
+
+	roleBindings, err := rm.kubeClient.RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role bindings: %w", err)
+	}
+	for _, binding := range roleBindings.Items {
+		if subject != nil && !accessReviewSubjectBound(binding.Subjects, binding.Namespace, *subject) {
+			continue
+		}
+		if granted, names := rulesGrant(rulesFor(binding.RoleRef, binding.Namespace), verb, group, resource); granted {
+			result.Bindings = append(result.Bindings, AccessReviewBinding{
+				Kind:          "RoleBinding",
+				Name:          binding.Name,
+				Namespace:     binding.Namespace,
+				RoleKind:      binding.RoleRef.Kind,
+				RoleName:      binding.RoleRef.Name,
+				Subjects:      binding.Subjects,
+				ResourceNames: names,
+			})
+		}
+	}
+
+	sort.SliceStable(result.Bindings, func(i, j int) bool {
+		a, b := result.Bindings[i], result.Bindings[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return result, nil
+}
+
+// reviewAccess asks the API server whether subject, or the caller when it is
+// nil, may perform the verb
+func (rm *ResourceManager) reviewAccess(ctx context.Context, verb, group, resource, namespace string, subject *AccessReviewSubject) (AccessReviewDecision, error) {
+	resource, subresource, _ := strings.Cut(resource, "/")
+	attributes := &authorizationv1.ResourceAttributes{
+		Verb:        verb,
+		Group:       group,
+		Resource:    resource,
+		Subresource: subresource,
+		Namespace:   namespace,
+	}
+
+	var status authorizationv1.SubjectAccessReviewStatus
+	if subject == nil {
+		review, err := rm.kubeClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attributes},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return AccessReviewDecision{}, fmt.Errorf("failed to perform self subject access review: %w", err)
+		}
+		status = review.Status
+	} else {
+		spec := authorizationv1.SubjectAccessReviewSpec{ResourceAttributes: attributes}
+		switch subject.Kind {
+		case rbacv1.UserKind:
+			spec.User = subject.Name
+		case rbacv1.GroupKind:
+			spec.Groups = []string{subject.Name}
+		case rbacv1.ServiceAccountKind:
+			spec.User = "system:serviceaccount:" + subject.Namespace + ":" + subject.Name
+			spec.Groups = []string{serviceAccountsGroup, serviceAccountsGroupPrefix + subject.Namespace, "system:authenticated"}
+		}
+		review, err := rm.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{Spec: spec}, metav1.CreateOptions{})
+		if err != nil {
+			return AccessReviewDecision{}, fmt.Errorf("failed to perform subject access review: %w", err)
+		}
+		status = review.Status
+	}
+
+	return AccessReviewDecision{
+		Allowed:         status.Allowed,
+		Denied:          status.Denied,
+		Reason:          status.Reason,
+		EvaluationError: status.EvaluationError,
+	}, nil
+}
+
+// accessReviewSubjectBound reports whether a binding's subjects include the
+// reviewed subject, or for a ServiceAccount one of its groups. ServiceAccount
+// subjects without a namespace default to the binding's namespace.
+func accessReviewSubjectBound(subjects []rbacv1.Subject, bindingNamespace string, reviewed AccessReviewSubject) bool {
+	for _, subject := range subjects {
+		switch {
+		case subject.Kind == rbacv1.ServiceAccountKind && reviewed.Kind == rbacv1.ServiceAccountKind:
+			namespace := subject.Namespace
+			if namespace == "" {
+				namespace = bindingNamespace
+			}
+			if subject.Name == reviewed.Name && namespace == reviewed.Namespace {
+				return true
+			}
+		case subject.Kind == reviewed.Kind && subject.Name == reviewed.Name:
+			return true
+		case subject.Kind == rbacv1.GroupKind && reviewed.Kind == rbacv1.ServiceAccountKind:
+			if subject.Name == serviceAccountsGroup || subject.Name == serviceAccountsGroupPrefix+reviewed.Namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rulesGrant reports whether any rule allows the verb on the resource and
+// returns the resource names the matching rules are restricted to. A rule
+// without resource names applies to every object, so none are returned then.
+func rulesGrant(rules []rbacv1.PolicyRule, verb, group, resource string) (bool, []string) {
+	granted := false
+	unrestricted := false
+	var names []string
+	for _, rule := range rules {
+		if !ruleContains(rule.Verbs, verb) || !ruleContains(rule.APIGroups, group) || !ruleMatchesResource(rule.Resources, resource) {
+			continue
+		}
+		granted = true
+		if len(rule.ResourceNames) == 0 {
+			unrestricted = true
+		}
+		names = append(names, rule.ResourceNames...)
+	}
+	if unrestricted {
+		return granted, nil
+	}
+	sort.Strings(names)
+	return granted, names
+}
+
+// ruleContains reports whether a rule field lists value or the * wildcard
+func ruleContains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == rbacv1.ResourceAll || candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleMatchesResource matches a resource, or resource/subresource, against
+// the resources of a rule, which may use * and */subresource wildcards
+func ruleMatchesResource(resources []string, resource string) bool {
+	_, subresource, hasSubresource := strings.Cut(resource, "/")
+	for _, candidate := range resources {
+		if candidate == rbacv1.ResourceAll || candidate == resource {
+			return true
+		}
+		if hasSubresource && candidate == "*/"+subresource {
+			return true
+		}
+	}
+	return false
+}
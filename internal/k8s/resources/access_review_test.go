@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newAccessReviewClient returns a client where the "deleter" Role of shop may
+// delete pods, "edit" and "admin" are ClusterRoles that may, and "view" may
+// not. Access reviews are allowed for the user alice only.
+func newAccessReviewClient() *kubefake.Clientset {
+	client := kubefake.NewSimpleClientset(
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "admin"}, Rules: []rbacv1.PolicyRule{
+			{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}},
+		}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "edit"}, Rules: []rbacv1.PolicyRule{
+			{Verbs: []string{"create", "delete"}, APIGroups: []string{""}, Resources: []string{"pods", "services"}},
+		}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}, Rules: []rbacv1.PolicyRule{
+			{Verbs: []string{"get", "list"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+		}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "deleter", Namespace: "shop"}, Rules: []rbacv1.PolicyRule{
+			{Verbs: []string{"delete"}, APIGroups: []string{""}, Resources: []string{"pods"}, ResourceNames: []string{"web-0"}},
+		}},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "platform"}},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewers"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "editors", Namespace: "shop"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "janitor", Namespace: "shop"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "deleter"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "cleanup"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "editors", Namespace: "prod"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "bob"}},
+		},
+	)
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.User == "alice"
+		return true, review, nil
+	})
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = true
+		review.Status.Reason = "caller is an admin"
+		return true, review, nil
+	})
+	return client
+}
+
+func bindingNames(bindings []AccessReviewBinding) []string {
+	names := []string{}
+	for _, binding := range bindings {
+		names = append(names, binding.Kind+"/"+binding.Namespace+"/"+binding.Name)
+	}
+	return names
+}
+
+func TestAccessReviewWhoCan(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), newAccessReviewClient(), nil)
+
+	result, err := rm.AccessReview(context.Background(), "delete", "", "pods", "shop", nil)
+	require.NoError(t, err)
+	assert.Equal(t, AccessReviewDecision{Allowed: true, Reason: "caller is an admin"}, result.Review)
+	assert.Equal(t, []string{"ClusterRoleBinding//admins", "RoleBinding/shop/editors", "RoleBinding/shop/janitor"}, bindingNames(result.Bindings))
+	assert.Equal(t, []string{"web-0"}, result.Bindings[2].ResourceNames)
+	assert.Nil(t, result.Bindings[1].ResourceNames)
+	assert.Equal(t, "edit", result.Bindings[1].RoleName)
+
+	// Every namespace
+	result, err = rm.AccessReview(context.Background(), "delete", "", "pods", "", nil)
+	require.NoError(t, err)
+	assert.Contains(t, bindingNames(result.Bindings), "RoleBinding/prod/editors")
+
+	// Subresources only match rules naming them or a wildcard
+	result, err = rm.AccessReview(context.Background(), "delete", "", "pods/exec", "shop", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ClusterRoleBinding//admins"}, bindingNames(result.Bindings))
+}
+
+func TestAccessReviewForSubject(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), newAccessReviewClient(), nil)
+
+	result, err := rm.AccessReview(context.Background(), "delete", "", "pods", "shop", &AccessReviewSubject{Kind: rbacv1.UserKind, Name: "alice"})
+	require.NoError(t, err)
+	assert.True(t, result.Review.Allowed)
+	assert.Equal(t, []string{"RoleBinding/shop/editors"}, bindingNames(result.Bindings))
+
+	// A ServiceAccount subject without a namespace belongs to the binding's namespace
+	result, err = rm.AccessReview(context.Background(), "delete", "", "pods", "shop", &AccessReviewSubject{Kind: rbacv1.ServiceAccountKind, Name: "cleanup", Namespace: "shop"})
+	require.NoError(t, err)
+	assert.False(t, result.Review.Allowed)
+	assert.Equal(t, []string{"RoleBinding/shop/janitor"}, bindingNames(result.Bindings))
+
+	assert.Error(t, AccessReviewSubject{Kind: rbacv1.ServiceAccountKind, Name: "cleanup"}.Validate())
+	assert.Error(t, AccessReviewSubject{Kind: "Robot", Name: "r2"}.Validate())
+	assert.Error(t, AccessReviewSubject{Kind: rbacv1.UserKind}.Validate())
+}
@@ -0,0 +1,62 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Annotations stamped onto objects mutated through Kaptn when enabled
+const (
+	AnnotationLastModifiedBy = "kaptn.io/last-modified-by"
+	AnnotationLastModifiedAt = "kaptn.io/last-modified-at"
+)
+
+// defaultActor is recorded when a mutation carries no authenticated user
+const defaultActor = "kaptn"
+
+// actorContextKey carries the acting user for mutation annotations
+type actorContextKey struct{}
+
+// WithActor returns a context that records the user performing a mutation
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the acting user recorded by WithActor, if any
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	return actor
+}
+
+// StampModification records who changed an object and when. Existing
+// annotations are preserved; only the Kaptn markers are added or replaced.
+func StampModification(obj metav1.Object, actor string, at time.Time) {
+	if actor == "" {
+		actor = defaultActor
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 2)
+	}
+	annotations[AnnotationLastModifiedBy] = actor
+	annotations[AnnotationLastModifiedAt] = at.UTC().Format(time.RFC3339)
+	obj.SetAnnotations(annotations)
+}
+
+// SetMutationAnnotations enables or disables stamping mutated objects with
+// the last-modified-by/at annotations
+func (rm *ResourceManager) SetMutationAnnotations(enabled bool) {
+	rm.annotateMutations = enabled
+}
+
+// stampModification applies the modification annotations when enabled,
+// taking the actor from the request context
+func (rm *ResourceManager) stampModification(ctx context.Context, obj metav1.Object) {
+	if !rm.annotateMutations {
+		return
+	}
+	StampModification(obj, ActorFromContext(ctx), rm.now())
+}
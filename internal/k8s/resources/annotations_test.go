@@ -0,0 +1,65 @@
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func annotatedDeployment(annotations map[string]string) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Annotations: annotations},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+}
+
+func TestScaleResourceStampsModificationAnnotations(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(annotatedDeployment(map[string]string{
+		"team":                   "payments",
+		AnnotationLastModifiedBy: "previous@example.com",
+	}))
+	rm := NewResourceManager(zap.NewNop(), kubeClient, nil)
+	rm.SetMutationAnnotations(true)
+	rm.now = func() time.Time { return time.Date(2024, 3, 1, 9, 30, 0, 0, time.FixedZone("EST", -5*3600)) }
+
+	ctx := WithActor(context.Background(), "alice@example.com")
+	require.NoError(t, rm.ScaleResource(ctx, ScaleRequest{Namespace: "default", Name: "web", Kind: "Deployment", Replicas: 3}))
+
+	deployment, err := kubeClient.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+	assert.Equal(t, map[string]string{
+		"team":                   "payments",
+		AnnotationLastModifiedBy: "alice@example.com",
+		AnnotationLastModifiedAt: "2024-03-01T14:30:00Z",
+	}, deployment.Annotations)
+}
+
+func TestScaleResourceWithoutAnnotationsEnabled(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(annotatedDeployment(nil))
+	rm := NewResourceManager(zap.NewNop(), kubeClient, nil)
+
+	ctx := WithActor(context.Background(), "alice@example.com")
+	require.NoError(t, rm.ScaleResource(ctx, ScaleRequest{Namespace: "default", Name: "web", Kind: "Deployment", Replicas: 2}))
+
+	deployment, err := kubeClient.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, deployment.Annotations)
+}
+
+func TestStampModificationDefaultsActor(t *testing.T) {
+	deployment := annotatedDeployment(nil)
+
+	StampModification(deployment, "", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, "kaptn", deployment.Annotations[AnnotationLastModifiedBy])
+	assert.Equal(t, "2024-03-01T00:00:00Z", deployment.Annotations[AnnotationLastModifiedAt])
+}
@@ -0,0 +1,85 @@
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// apiResourceCatalogTTL is how long discovery results are reused before the
+// API server is asked again
+const apiResourceCatalogTTL = 5 * time.Minute
+
+// APIResourceCatalog is a discovered API resource list with a content hash
+// suitable for use as an HTTP ETag
+type APIResourceCatalog struct {
+	Resources []APIResource
+	ETag      string
+}
+
+// apiResourceCatalogCache holds the last discovered catalog
+type apiResourceCatalogCache struct {
+	mu        sync.Mutex
+	catalog   *APIResourceCatalog
+	expiresAt time.Time
+}
+
+// APIResourceCatalog returns the cluster's API resources, reusing the last
+// discovery result for a few minutes since it rarely changes
+func (rm *ResourceManager) APIResourceCatalog(ctx context.Context) (*APIResourceCatalog, error) {
+	cache := &rm.apiResources
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := rm.now()
+	if cache.catalog != nil && now.Before(cache.expiresAt) {
+		return cache.catalog, nil
+	}
+
+	resources, err := rm.ListAPIResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	etag, err := apiResourcesETag(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.catalog = &APIResourceCatalog{Resources: resources, ETag: etag}
+	cache.expiresAt = now.Add(apiResourceCatalogTTL)
+	return cache.catalog, nil
+}
+
+// apiResourcesETag returns a strong ETag derived from the discovered resources
+func apiResourcesETag(resources []APIResource) (string, error) {
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash API resources: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// FilterAPIResources returns the resources in group (when group is non-nil;
+// "" is the core group) and with the given scope (when namespaced is non-nil)
+func FilterAPIResources(resources []APIResource, group *string, namespaced *bool) []APIResource {
+	if group == nil && namespaced == nil {
+		return resources
+	}
+
+	filtered := make([]APIResource, 0, len(resources))
+	for _, resource := range resources {
+		if group != nil && resource.Group != *group {
+			continue
+		}
+		if namespaced != nil && resource.Namespaced != *namespaced {
+			continue
+		}
+		filtered = append(filtered, resource)
+	}
+	return filtered
+}
@@ -0,0 +1,93 @@
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newDiscoveryResourceManager(lists ...*metav1.APIResourceList) (*ResourceManager, *fakediscovery.FakeDiscovery) {
+	kubeClient := kubefake.NewSimpleClientset()
+	discovery := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = lists
+	return NewResourceManager(zap.NewNop(), kubeClient, nil), discovery
+}
+
+func coreAndAppsResources() []*metav1.APIResourceList {
+	return []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Namespaced: true},
+			{Name: "pods/log", Kind: "Pod", Namespaced: true},
+			{Name: "nodes", Kind: "Node"},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true},
+		}},
+	}
+}
+
+func TestAPIResourceCatalogCachesDiscovery(t *testing.T) {
+	rm, discovery := newDiscoveryResourceManager(coreAndAppsResources()...)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	rm.now = func() time.Time { return now }
+
+	first, err := rm.APIResourceCatalog(context.Background())
+	require.NoError(t, err)
+	require.Len(t, first.Resources, 3)
+	assert.NotEmpty(t, first.ETag)
+
+	// Within the TTL discovery changes are not seen
+	discovery.Resources[1].APIResources = append(discovery.Resources[1].APIResources, metav1.APIResource{Name: "statefulsets", Kind: "StatefulSet", Namespaced: true})
+	cached, err := rm.APIResourceCatalog(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, first, cached)
+
+	now = now.Add(apiResourceCatalogTTL)
+	refreshed, err := rm.APIResourceCatalog(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, refreshed.Resources, 4)
+	assert.NotEqual(t, first.ETag, refreshed.ETag)
+}
+
+func TestAPIResourcesETagIsStable(t *testing.T) {
+	resources := []APIResource{{Name: "pods", Kind: "Pod", Version: "v1", APIVersion: "v1", Namespaced: true}}
+
+	a, err := apiResourcesETag(resources)
+	require.NoError(t, err)
+	b, err := apiResourcesETag(append([]APIResource{}, resources...))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+}
+
+func TestFilterAPIResources(t *testing.T) {
+	resources := []APIResource{
+		{Name: "pods", Group: "", Namespaced: true},
+		{Name: "nodes", Group: ""},
+		{Name: "deployments", Group: "apps", Namespaced: true},
+	}
+	core := ""
+	apps := "apps"
+	namespaced := true
+	clusterScoped := false
+
+	names := func(resources []APIResource) []string {
+		var out []string
+		for _, resource := range resources {
+			out = append(out, resource.Name)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"pods", "nodes", "deployments"}, names(FilterAPIResources(resources, nil, nil)))
+	assert.Equal(t, []string{"pods", "nodes"}, names(FilterAPIResources(resources, &core, nil)))
+	assert.Equal(t, []string{"deployments"}, names(FilterAPIResources(resources, &apps, nil)))
+	assert.Equal(t, []string{"pods", "deployments"}, names(FilterAPIResources(resources, nil, &namespaced)))
+	assert.Equal(t, []string{"nodes"}, names(FilterAPIResources(resources, &core, &clusterScoped)))
+}
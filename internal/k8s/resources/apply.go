@@ -0,0 +1,227 @@
+package resources
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// applyFieldManager owns the fields Kaptn sets through server-side apply
+const applyFieldManager = "kaptn"
+
+// ApplyStatus is what applying a document did to the cluster
+type ApplyStatus string
+
+const (
+	ApplyCreated   ApplyStatus = "created"
+	ApplyUpdated   ApplyStatus = "updated"
+	ApplyUnchanged ApplyStatus = "unchanged"
+)
+
+// ApplyOptions control how ApplyResource applies a manifest
+type ApplyOptions struct {
+	// Force takes ownership of fields another field manager set, instead of
+	// failing the document with a conflict
+	Force bool
+}
+
+// ApplyResult is the outcome of applying one document of a manifest. Index
+// is the document's position in the manifest, counting from 0; empty
+// documents are not counted but comment-only ones are.
+type ApplyResult struct {
+	Index      int         `json:"index"`
+	APIVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Namespace  string      `json:"namespace,omitempty"`
+	Name       string      `json:"name"`
+	Status     ApplyStatus `json:"status"`
+}
+
+// ErrInvalidManifest is wrapped by the errors of manifests that cannot be
+// decoded into Kubernetes objects
+var ErrInvalidManifest = errors.New("invalid manifest")
+
+// ApplyDocumentError reports the document of a manifest that could not be
+// decoded or applied
+type ApplyDocumentError struct {
+	Index int
+	Err   error
+}
+
+func (e *ApplyDocumentError) Error() string {
+	return fmt.Sprintf("document %d: %v", e.Index, e.Err)
+}
+
+func (e *ApplyDocumentError) Unwrap() error {
+	return e.Err
+}
+
+// applyDocument is a decoded manifest document and its position
+type applyDocument struct {
+	index  int
+	object *unstructured.Unstructured
+}
+
+// ApplyResource creates or updates the objects of a YAML manifest with
+// server-side apply, in order. Documents are separated by "---"; empty and
+// comment-only documents are skipped. Every document is decoded before anything is
+// applied, so a malformed manifest changes nothing. Applying stops at the
+// first document the API server rejects, returning the results so far
+// together with an *ApplyDocumentError. A document setting fields another
+// field manager owns fails with a 409 Conflict unless opts.Force is set.
+// Namespaced objects without a namespace are applied to "default".
+func (rm *ResourceManager) ApplyResource(ctx context.Context, manifest []byte, opts ApplyOptions) ([]ApplyResult, error) {
+	documents, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("%w: no objects", ErrInvalidManifest)
+	}
+
+	mapper := rm.newDiscoveryRESTMapper()
+	results := make([]ApplyResult, 0, len(documents))
+	for _, doc := range documents {
+		result, err := rm.applyObject(ctx, mapper, doc.object, opts)
+		if err != nil {
+			return results, &ApplyDocumentError{Index: doc.index, Err: err}
+		}
+		result.Index = doc.index
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// decodeManifest splits a manifest into its non-empty documents, reporting
+// the first document that is not a Kubernetes object
+func decodeManifest(manifest []byte) ([]applyDocument, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+	var documents []applyDocument
+	for index := 0; ; index++ {
+		raw, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, &ApplyDocumentError{Index: index, Err: fmt.Errorf("%w: %v", ErrInvalidManifest, err)}
+		}
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+
+		// Decoding through JSON keeps integers as int64, as the API returns them
+		var content map[string]interface{}
+		data, err := yaml.YAMLToJSON(raw)
+		if err == nil {
+			err = utiljson.Unmarshal(data, &content)
+		}
+		if err != nil {
+			return nil, &ApplyDocumentError{Index: index, Err: fmt.Errorf("%w: %v", ErrInvalidManifest, err)}
+		}
+		if len(content) == 0 {
+			// Only comments
+			continue
+		}
+		object := &unstructured.Unstructured{Object: content}
+		switch {
+		case object.GetAPIVersion() == "":
+			return nil, &ApplyDocumentError{Index: index, Err: fmt.Errorf("%w: apiVersion is required", ErrInvalidManifest)}
+		case object.GetKind() == "":
+			return nil, &ApplyDocumentError{Index: index, Err: fmt.Errorf("%w: kind is required", ErrInvalidManifest)}
+		case object.GetName() == "":
+			return nil, &ApplyDocumentError{Index: index, Err: fmt.Errorf("%w: metadata.name is required", ErrInvalidManifest)}
+		}
+		documents = append(documents, applyDocument{index: index, object: object})
+	}
+}
+
+// newDiscoveryRESTMapper maps kinds to resources through discovery, reloading
+// it when a kind is unknown in case it was just installed
+func (rm *ResourceManager) newDiscoveryRESTMapper() meta.RESTMapper {
+	return restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(rm.kubeClient.Discovery()))
+}
+
+// resourceClientFor resolves the resource of a manifest object and returns
+// the dynamic client for it. Namespaced objects without a namespace are put
+// in "default"; cluster-scoped objects have their namespace cleared.
+func (rm *ResourceManager) resourceClientFor(mapper meta.RESTMapper, object *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := schema.FromAPIVersionAndKind(object.GetAPIVersion(), object.GetKind())
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("unknown resource %s: %w", gvk, err)
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		object.SetNamespace("")
+		return rm.dynamicClient.Resource(mapping.Resource), nil
+	}
+	if object.GetNamespace() == "" {
+		object.SetNamespace(metav1.NamespaceDefault)
+	}
+	return rm.dynamicClient.Resource(mapping.Resource).Namespace(object.GetNamespace()), nil
+}
+
+// applyObject server-side applies one object. An object is unchanged when
+// the apply left its resourceVersion as it was.
+func (rm *ResourceManager) applyObject(ctx context.Context, mapper meta.RESTMapper, object *unstructured.Unstructured, opts ApplyOptions) (ApplyResult, error) {
+	result := ApplyResult{
+		APIVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+		Name:       object.GetName(),
+	}
+
+	client, err := rm.resourceClientFor(mapper, object)
+	if err != nil {
+		return result, err
+	}
+	result.Namespace = object.GetNamespace()
+
+	// Server-set metadata carried over from exported objects is not applied
+	for _, field := range []string{"resourceVersion", "uid", "managedFields", "creationTimestamp"} {
+		unstructured.RemoveNestedField(object.Object, "metadata", field)
+	}
+
+	existing, err := client.Get(ctx, object.GetName(), metav1.GetOptions{})
+	exists := err == nil
+	if err != nil && !apierrors.IsNotFound(err) {
+		return result, err
+	}
+
+	rm.stampModification(ctx, object)
+	applied, err := client.Apply(ctx, object.GetName(), object, metav1.ApplyOptions{FieldManager: applyFieldManager, Force: opts.Force})
+	if err != nil {
+		return result, err
+	}
+
+	switch {
+	case !exists:
+		result.Status = ApplyCreated
+	case applied.GetResourceVersion() == existing.GetResourceVersion():
+		result.Status = ApplyUnchanged
+	default:
+		result.Status = ApplyUpdated
+	}
+
+	rm.logger.Info("Applied resource",
+		zap.String("kind", result.Kind),
+		zap.String("namespace", result.Namespace),
+		zap.String("name", result.Name),
+		zap.String("status", string(result.Status)))
+	return result, nil
+}
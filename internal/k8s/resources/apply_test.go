@@ -0,0 +1,193 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var (
+	namespacesGVR  = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	configMapsGVR  = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	deploymentsGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+)
+
+// newApplyResourceManager returns a manager whose dynamic client answers
+// server-side apply like an API server: it creates missing objects and only
+// bumps the resourceVersion when the applied content differs
+func newApplyResourceManager(t *testing.T) (*ResourceManager, *dynamicfake.FakeDynamicClient) {
+	t.Helper()
+
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+			{Name: "namespaces", Kind: "Namespace"},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true},
+		}},
+	}
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	tracker := dynamicClient.Tracker()
+	dynamicClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		desired := &unstructured.Unstructured{}
+		if err := desired.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, err
+		}
+
+		existing, err := tracker.Get(patch.GetResource(), patch.GetNamespace(), patch.GetName())
+		if apierrors.IsNotFound(err) {
+			desired.SetResourceVersion("1")
+			return true, desired, tracker.Create(patch.GetResource(), desired, patch.GetNamespace())
+		}
+		if err != nil {
+			return true, nil, err
+		}
+
+		current := existing.(*unstructured.Unstructured)
+		version, _ := strconv.Atoi(current.GetResourceVersion())
+		desired.SetResourceVersion(current.GetResourceVersion())
+		if apiequality.Semantic.DeepEqual(current.Object, desired.Object) {
+			return true, current, nil
+		}
+		desired.SetResourceVersion(strconv.Itoa(version + 1))
+		return true, desired, tracker.Update(patch.GetResource(), desired, patch.GetNamespace())
+	})
+
+	return NewResourceManager(zap.NewNop(), kubeClient, dynamicClient), dynamicClient
+}
+
+const applyManifest = `# app manifest
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: shop
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: shop
+data:
+  mode: live
+---
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+`
+
+func TestApplyResourceCreatesUpdatesAndSkipsUnchanged(t *testing.T) {
+	rm, dynamicClient := newApplyResourceManager(t)
+	ctx := context.Background()
+
+	results, err := rm.ApplyResource(ctx, []byte(applyManifest), ApplyOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []ApplyResult{
+		{Index: 0, APIVersion: "v1", Kind: "Namespace", Name: "shop", Status: ApplyCreated},
+		{Index: 1, APIVersion: "v1", Kind: "ConfigMap", Namespace: "shop", Name: "settings", Status: ApplyCreated},
+		{Index: 2, APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web", Status: ApplyCreated},
+	}, results)
+
+	// Namespaced objects without a namespace land in default
+	deployment, err := dynamicClient.Resource(deploymentsGVR).Namespace("default").Get(ctx, "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	replicas, _, _ := unstructured.NestedInt64(deployment.Object, "spec", "replicas")
+	assert.Equal(t, int64(2), replicas)
+
+	changed := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: shop
+data:
+  mode: maintenance
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: shop
+`
+	results, err = rm.ApplyResource(ctx, []byte(changed), ApplyOptions{})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, ApplyUpdated, results[0].Status)
+	assert.Equal(t, ApplyUnchanged, results[1].Status)
+
+	configMap, err := dynamicClient.Resource(configMapsGVR).Namespace("shop").Get(ctx, "settings", metav1.GetOptions{})
+	require.NoError(t, err)
+	mode, _, _ := unstructured.NestedString(configMap.Object, "data", "mode")
+	assert.Equal(t, "maintenance", mode)
+}
+
+func TestApplyResourceReportsDocumentErrors(t *testing.T) {
+	rm, dynamicClient := newApplyResourceManager(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		manifest string
+		index    int
+		message  string
+	}{
+		{"malformed yaml", "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: ok\n---\nkind: [unclosed\n", 1, "document 1"},
+		{"missing kind", "apiVersion: v1\nmetadata:\n  name: x\n", 0, "kind is required"},
+		{"missing name", "# settings\n---\napiVersion: v1\nkind: ConfigMap\n", 1, "metadata.name is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := rm.ApplyResource(ctx, []byte(tt.manifest), ApplyOptions{})
+			require.Error(t, err)
+			assert.Empty(t, results)
+			var docErr *ApplyDocumentError
+			require.True(t, errors.As(err, &docErr))
+			assert.Equal(t, tt.index, docErr.Index)
+			assert.Contains(t, err.Error(), tt.message)
+			assert.ErrorIs(t, err, ErrInvalidManifest)
+		})
+	}
+
+	// Nothing from a manifest with a bad document is applied
+	_, err := dynamicClient.Resource(namespacesGVR).Get(ctx, "ok", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// An unknown kind stops applying at its document
+	manifest := "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: first\n---\napiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n"
+	results, err := rm.ApplyResource(ctx, []byte(manifest), ApplyOptions{})
+	require.Error(t, err)
+	var docErr *ApplyDocumentError
+	require.True(t, errors.As(err, &docErr))
+	assert.Equal(t, 1, docErr.Index)
+	assert.Contains(t, err.Error(), "unknown resource")
+	assert.NotErrorIs(t, err, ErrInvalidManifest)
+	require.Len(t, results, 1)
+	assert.Equal(t, ApplyCreated, results[0].Status)
+
+	_, err = rm.ApplyResource(ctx, []byte("# nothing here\n---\n"), ApplyOptions{})
+	assert.EqualError(t, err, "invalid manifest: no objects")
+}
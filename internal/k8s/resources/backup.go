@@ -0,0 +1,322 @@
+package resources
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// NamespaceBackupFile describes one per-kind YAML file written to a backup archive
+type NamespaceBackupFile struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind"`
+	Count int    `json:"count"`
+}
+
+// NamespaceBackupOptions controls what a namespace backup exports
+type NamespaceBackupOptions struct {
+	// IncludeSecretData keeps Secret values. Without it Secrets are exported
+	// with their keys only.
+	IncludeSecretData bool
+	// Redact, when set, is called with every listed object before it is
+	// written and may hide values in place
+	Redact func(obj runtime.Object)
+}
+
+// backupKind lists the objects of one kind in a namespace as typed pointers
+type backupKind struct {
+	kind       string
+	apiVersion string
+	file       string
+	list       func(ctx context.Context, rm *ResourceManager, namespace string) ([]interface{}, error)
+}
+
+// namespaceBackupKinds are the kinds exported by a namespace backup. Pods and
+// ReplicaSets are left out since their controllers recreate them.
+var namespaceBackupKinds = []backupKind{
+	{kind: "Deployment", apiVersion: "apps/v1", file: "deployments.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.AppsV1().Deployments(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "StatefulSet", apiVersion: "apps/v1", file: "statefulsets.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.AppsV1().StatefulSets(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "DaemonSet", apiVersion: "apps/v1", file: "daemonsets.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.AppsV1().DaemonSets(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "Job", apiVersion: "batch/v1", file: "jobs.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.BatchV1().Jobs(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "CronJob", apiVersion: "batch/v1", file: "cronjobs.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.BatchV1().CronJobs(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "Service", apiVersion: "v1", file: "services.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.CoreV1().Services(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "ConfigMap", apiVersion: "v1", file: "configmaps.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.CoreV1().ConfigMaps(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "Secret", apiVersion: "v1", file: "secrets.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.CoreV1().Secrets(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "PersistentVolumeClaim", apiVersion: "v1", file: "persistentvolumeclaims.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.CoreV1().PersistentVolumeClaims(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "ServiceAccount", apiVersion: "v1", file: "serviceaccounts.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.CoreV1().ServiceAccounts(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "Ingress", apiVersion: "networking.k8s.io/v1", file: "ingresses.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.NetworkingV1().Ingresses(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "NetworkPolicy", apiVersion: "networking.k8s.io/v1", file: "networkpolicies.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.NetworkingV1().NetworkPolicies(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "Role", apiVersion: "rbac.authorization.k8s.io/v1", file: "roles.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.RbacV1().Roles(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "RoleBinding", apiVersion: "rbac.authorization.k8s.io/v1", file: "rolebindings.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.RbacV1().RoleBindings(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "ResourceQuota", apiVersion: "v1", file: "resourcequotas.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.CoreV1().ResourceQuotas(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+	{kind: "LimitRange", apiVersion: "v1", file: "limitranges.yaml", list: func(ctx context.Context, rm *ResourceManager, ns string) ([]interface{}, error) {
+		list, err := rm.kubeClient.CoreV1().LimitRanges(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return itemPointers(list.Items), nil
+	}},
+}
+
+// itemPointers returns pointers to the items of a typed list
+func itemPointers[T any](items []T) []interface{} {
+	result := make([]interface{}, len(items))
+	for i := range items {
+		result[i] = &items[i]
+	}
+	return result
+}
+
+// WriteNamespaceBackup streams a gzip-compressed tar archive of the namespace's
+// resources to w, one multi-document YAML file per kind under <namespace>/.
+// Kinds are exported concurrently and each file is added to the archive as soon
+// as its kind is ready. Kinds that cannot be listed or have no objects are
+// skipped. Secret values are blanked unless opts.IncludeSecretData is set. The
+// namespace is checked before anything is written so a missing namespace can
+// still be reported to the caller.
+func (rm *ResourceManager) WriteNamespaceBackup(ctx context.Context, namespace string, w io.Writer, opts NamespaceBackupOptions) ([]NamespaceBackupFile, error) {
+	if _, err := rm.kubeClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+
+	rm.logger.Info("Writing namespace backup", zap.String("namespace", namespace))
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	modTime := rm.now()
+
+	var (
+		mu       sync.Mutex
+		files    []NamespaceBackupFile
+		writeErr error
+		wg       sync.WaitGroup
+	)
+
+	for _, bk := range namespaceBackupKinds {
+		wg.Add(1)
+		go func(bk backupKind) {
+			defer wg.Done()
+
+			data, count, err := rm.exportBackupKind(ctx, namespace, bk, opts)
+			if err != nil {
+				rm.logger.Warn("Skipping kind in namespace backup",
+					zap.String("namespace", namespace),
+					zap.String("kind", bk.kind),
+					zap.Error(err))
+				return
+			}
+			if count == 0 {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if writeErr != nil {
+				return
+			}
+
+			name := namespace + "/" + bk.file
+			header := &tar.Header{
+				Name:    name,
+				Mode:    0o644,
+				Size:    int64(len(data)),
+				ModTime: modTime,
+			}
+			if err := tw.WriteHeader(header); err != nil {
+				writeErr = fmt.Errorf("failed to write %s header: %w", name, err)
+				return
+			}
+			if _, err := tw.Write(data); err != nil {
+				writeErr = fmt.Errorf("failed to write %s: %w", name, err)
+				return
+			}
+			// Push the finished file to the client rather than buffering the archive
+			if err := gz.Flush(); err != nil {
+				writeErr = fmt.Errorf("failed to flush archive: %w", err)
+				return
+			}
+			files = append(files, NamespaceBackupFile{Name: name, Kind: bk.kind, Count: count})
+		}(bk)
+	}
+	wg.Wait()
+
+	if writeErr != nil {
+		return files, writeErr
+	}
+	if err := tw.Close(); err != nil {
+		return files, fmt.Errorf("failed to close archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return files, fmt.Errorf("failed to close archive: %w", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files, nil
+}
+
+// exportBackupKind renders every object of a kind as a multi-document YAML
+// stream ordered by name. Whole objects are written, data included, so the
+// files can be applied as they are; only server-populated fields are dropped.
+func (rm *ResourceManager) exportBackupKind(ctx context.Context, namespace string, bk backupKind, opts NamespaceBackupOptions) ([]byte, int, error) {
+	items, err := bk.list(ctx, rm, namespace)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	objects := make([]*unstructured.Unstructured, 0, len(items))
+	for _, item := range items {
+		if secret, ok := item.(*v1.Secret); ok && !opts.IncludeSecretData {
+			blankSecretData(secret)
+		}
+		if object, ok := item.(runtime.Object); ok && opts.Redact != nil {
+			opts.Redact(object)
+		}
+		obj := rm.convertToUnstructured(item)
+		if obj == nil {
+			return nil, 0, fmt.Errorf("failed to convert %s to unstructured", bk.kind)
+		}
+		obj.SetAPIVersion(bk.apiVersion)
+		obj.SetKind(bk.kind)
+		objects = append(objects, rm.stripManagedFields(obj))
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].GetName() < objects[j].GetName()
+	})
+
+	var buf bytes.Buffer
+	for i, obj := range objects {
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal %s: %w", bk.kind, err)
+		}
+		buf.Write(data)
+	}
+
+	return buf.Bytes(), len(objects), nil
+}
+
+// blankSecretData empties the values of a Secret in place, keeping its keys so
+// a restored Secret shows what has to be filled in. The last-applied
+// configuration is dropped since it repeats the values.
+func blankSecretData(secret *v1.Secret) {
+	for key := range secret.Data {
+		secret.Data[key] = []byte{}
+	}
+	for key := range secret.StringData {
+		secret.StringData[key] = ""
+	}
+	if _, ok := secret.Annotations[v1.LastAppliedConfigAnnotation]; ok {
+		annotations := make(map[string]string, len(secret.Annotations))
+		for key, value := range secret.Annotations {
+			if key != v1.LastAppliedConfigAnnotation {
+				annotations[key] = value
+			}
+		}
+		secret.Annotations = annotations
+	}
+}
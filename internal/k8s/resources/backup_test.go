@@ -0,0 +1,154 @@
+package resources
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
+)
+
+// readBackupArchive returns the file contents of a tar.gz archive keyed by name
+func readBackupArchive(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+func newBackupFixture() *kubefake.Clientset {
+	replicas := int32(2)
+	return kubefake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", ResourceVersion: "42"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-config", Namespace: "team-a"}, Data: map[string]string{"mode": "prod"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "team-a"}},
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-b"}},
+	)
+}
+
+func TestWriteNamespaceBackupArchiveContents(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), newBackupFixture(), dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	var buf bytes.Buffer
+	written, err := rm.WriteNamespaceBackup(context.Background(), "team-a", &buf, NamespaceBackupOptions{})
+	require.NoError(t, err)
+
+	files := readBackupArchive(t, buf.Bytes())
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{"team-a/deployments.yaml", "team-a/configmaps.yaml", "team-a/services.yaml"}, names)
+
+	assert.Equal(t, []NamespaceBackupFile{
+		{Name: "team-a/configmaps.yaml", Kind: "ConfigMap", Count: 2},
+		{Name: "team-a/deployments.yaml", Kind: "Deployment", Count: 1},
+		{Name: "team-a/services.yaml", Kind: "Service", Count: 1},
+	}, written)
+
+	docs := strings.Split(files["team-a/configmaps.yaml"], "---\n")
+	require.Len(t, docs, 2)
+	var first map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(docs[0]), &first))
+	assert.Equal(t, "ConfigMap", first["kind"])
+	assert.Equal(t, "v1", first["apiVersion"])
+	assert.Equal(t, "app-config", first["metadata"].(map[string]interface{})["name"])
+	assert.NotContains(t, files["team-a/configmaps.yaml"], "other")
+	assert.Contains(t, files["team-a/configmaps.yaml"], "mode: prod", "objects are exported with their data")
+
+	var deployment map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(files["team-a/deployments.yaml"]), &deployment))
+	assert.Equal(t, "apps/v1", deployment["apiVersion"])
+	assert.NotContains(t, deployment["metadata"], "resourceVersion")
+}
+
+func TestWriteNamespaceBackupSecretData(t *testing.T) {
+	client := kubefake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "db",
+				Namespace:   "team-a",
+				Annotations: map[string]string{v1.LastAppliedConfigAnnotation: `{"data":{"password":"aHVudGVyMg=="}}`},
+			},
+			Data: map[string][]byte{"password": []byte("hunter2")},
+		},
+	)
+	rm := NewResourceManager(zap.NewNop(), client, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	var buf bytes.Buffer
+	_, err := rm.WriteNamespaceBackup(context.Background(), "team-a", &buf, NamespaceBackupOptions{})
+	require.NoError(t, err)
+
+	secrets := readBackupArchive(t, buf.Bytes())["team-a/secrets.yaml"]
+	var secret map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(secrets), &secret))
+	assert.Equal(t, map[string]interface{}{"password": ""}, secret["data"], "keys are kept without their values")
+	assert.NotContains(t, secrets, "aHVudGVyMg==")
+	assert.NotContains(t, secrets, v1.LastAppliedConfigAnnotation)
+
+	buf.Reset()
+	_, err = rm.WriteNamespaceBackup(context.Background(), "team-a", &buf, NamespaceBackupOptions{IncludeSecretData: true})
+	require.NoError(t, err)
+	assert.Contains(t, readBackupArchive(t, buf.Bytes())["team-a/secrets.yaml"], "aHVudGVyMg==")
+}
+
+func TestWriteNamespaceBackupSkipsFailingKinds(t *testing.T) {
+	client := newBackupFixture()
+	client.PrependReactor("list", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(v1.Resource("secrets"), "", errors.New("denied"))
+	})
+	rm := NewResourceManager(zap.NewNop(), client, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	var buf bytes.Buffer
+	_, err := rm.WriteNamespaceBackup(context.Background(), "team-a", &buf, NamespaceBackupOptions{})
+	require.NoError(t, err)
+
+	files := readBackupArchive(t, buf.Bytes())
+	assert.Contains(t, files, "team-a/configmaps.yaml")
+	assert.NotContains(t, files, "team-a/secrets.yaml")
+}
+
+func TestWriteNamespaceBackupMissingNamespace(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), kubefake.NewSimpleClientset(), dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	var buf bytes.Buffer
+	_, err := rm.WriteNamespaceBackup(context.Background(), "missing", &buf, NamespaceBackupOptions{})
+	require.Error(t, err)
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Zero(t, buf.Len())
+}
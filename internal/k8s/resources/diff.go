@@ -0,0 +1,169 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DiffChange is one differing field. Path is a JSON path such as
+// spec.template.spec.containers[0].image; Old is unset for added fields and
+// New for removed ones.
+type DiffChange struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// ResourceDiff compares a submitted manifest with the live object. Added
+// fields are only in the manifest, removed fields only in the live object
+// (including those the API server defaulted) and changed fields in both
+// with different values. Create is set when the object does not exist yet,
+// in which case every field of the manifest is added.
+type ResourceDiff struct {
+	APIVersion string       `json:"apiVersion"`
+	Kind       string       `json:"kind"`
+	Namespace  string       `json:"namespace,omitempty"`
+	Name       string       `json:"name"`
+	Create     bool         `json:"create"`
+	Added      []DiffChange `json:"added"`
+	Removed    []DiffChange `json:"removed"`
+	Changed    []DiffChange `json:"changed"`
+}
+
+// Empty reports whether the manifest matches the live object
+func (d *ResourceDiff) Empty() bool {
+	return !d.Create && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffResource compares the single object of a YAML manifest with its live
+// version. Both sides are stripped of server-managed metadata and status, as
+// ExportResource does, so only fields a manifest would set are compared.
+func (rm *ResourceManager) DiffResource(ctx context.Context, manifest []byte) (*ResourceDiff, error) {
+	documents, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if len(documents) != 1 {
+		return nil, fmt.Errorf("%w: expected one object, found %d", ErrInvalidManifest, len(documents))
+	}
+	submitted := documents[0].object
+
+	client, err := rm.resourceClientFor(rm.newDiscoveryRESTMapper(), submitted)
+	if err != nil {
+		return nil, err
+	}
+	diff := &ResourceDiff{
+		APIVersion: submitted.GetAPIVersion(),
+		Kind:       submitted.GetKind(),
+		Namespace:  submitted.GetNamespace(),
+		Name:       submitted.GetName(),
+		Added:      []DiffChange{},
+		Removed:    []DiffChange{},
+		Changed:    []DiffChange{},
+	}
+
+	live := map[string]interface{}{}
+	existing, err := client.Get(ctx, submitted.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		diff.Create = true
+	case err != nil:
+		return nil, err
+	default:
+		live = rm.stripManagedFields(existing).Object
+	}
+
+	diffValues("", live, rm.stripManagedFields(ensureMetadata(submitted)).Object, diff)
+	for _, changes := range [][]DiffChange{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	}
+	return diff, nil
+}
+
+// ensureMetadata gives an object the metadata map stripManagedFields expects
+func ensureMetadata(object *unstructured.Unstructured) *unstructured.Unstructured {
+	if _, ok := object.Object["metadata"].(map[string]interface{}); !ok {
+		object.Object["metadata"] = map[string]interface{}{}
+	}
+	return object
+}
+
+// diffValues records the differences between a live and a submitted value
+func diffValues(path string, live, submitted interface{}, diff *ResourceDiff) {
+	liveMap, liveIsMap := live.(map[string]interface{})
+	submittedMap, submittedIsMap := submitted.(map[string]interface{})
+	if liveIsMap && submittedIsMap {
+		for key, value := range submittedMap {
+			childPath := joinDiffPath(path, key)
+			if liveValue, ok := liveMap[key]; ok {
+				diffValues(childPath, liveValue, value, diff)
+			} else {
+				diff.Added = append(diff.Added, DiffChange{Path: childPath, New: value})
+			}
+		}
+		for key, value := range liveMap {
+			if _, ok := submittedMap[key]; !ok {
+				diff.Removed = append(diff.Removed, DiffChange{Path: joinDiffPath(path, key), Old: value})
+			}
+		}
+		return
+	}
+
+	liveList, liveIsList := live.([]interface{})
+	submittedList, submittedIsList := submitted.([]interface{})
+	if liveIsList && submittedIsList {
+		for i, value := range submittedList {
+			childPath := path + "[" + strconv.Itoa(i) + "]"
+			if i < len(liveList) {
+				diffValues(childPath, liveList[i], value, diff)
+			} else {
+				diff.Added = append(diff.Added, DiffChange{Path: childPath, New: value})
+			}
+		}
+		for i := len(submittedList); i < len(liveList); i++ {
+			diff.Removed = append(diff.Removed, DiffChange{Path: path + "[" + strconv.Itoa(i) + "]", Old: liveList[i]})
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(normalizeDiffNumber(live), normalizeDiffNumber(submitted)) {
+		diff.Changed = append(diff.Changed, DiffChange{Path: path, Old: live, New: submitted})
+	}
+}
+
+// plainDiffKey matches map keys that can be written after a dot in a path
+var plainDiffKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// joinDiffPath appends a map key to a path, quoting keys such as
+// app.kubernetes.io/name that contain separators
+func joinDiffPath(path, key string) string {
+	if !plainDiffKey.MatchString(key) {
+		return path + "[" + strconv.Quote(key) + "]"
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// normalizeDiffNumber compares integers and floats by value, since manifests
+// and live objects may decode the same number differently
+func normalizeDiffNumber(value interface{}) interface{} {
+	switch number := value.(type) {
+	case int64:
+		return float64(number)
+	case int:
+		return float64(number)
+	case int32:
+		return float64(number)
+	}
+	return value
+}
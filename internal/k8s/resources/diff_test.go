@@ -0,0 +1,163 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func liveDeployment() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":            "web",
+			"namespace":       "shop",
+			"resourceVersion": "42",
+			"uid":             "0b6c-1234",
+			"generation":      int64(3),
+			"managedFields":   []interface{}{map[string]interface{}{"manager": "kubectl"}},
+			"labels":          map[string]interface{}{"app.kubernetes.io/name": "web", "tier": "frontend"},
+		},
+		"spec": map[string]interface{}{
+			"replicas":                int64(2),
+			"progressDeadlineSeconds": int64(600),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web", "image": "web:1.0"},
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{"readyReplicas": int64(2)},
+	}}
+}
+
+const submittedDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+  labels:
+    app.kubernetes.io/name: web
+    tier: frontend
+    team: payments
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:1.1
+      - name: proxy
+        image: envoy:1.29
+`
+
+func TestDiffResourceAgainstLiveObject(t *testing.T) {
+	rm, dynamicClient := newApplyResourceManager(t)
+	ctx := context.Background()
+	_, err := dynamicClient.Resource(deploymentsGVR).Namespace("shop").Create(ctx, liveDeployment(), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	diff, err := rm.DiffResource(ctx, []byte(submittedDeployment))
+	require.NoError(t, err)
+	assert.False(t, diff.Create)
+	assert.False(t, diff.Empty())
+	assert.Equal(t, "shop", diff.Namespace)
+
+	// Status, resourceVersion, uid, generation and managedFields are ignored
+	assert.Equal(t, []DiffChange{
+		{Path: `metadata.labels.team`, New: "payments"},
+		{Path: `spec.template.spec.containers[1]`, New: map[string]interface{}{"name": "proxy", "image": "envoy:1.29"}},
+	}, diff.Added)
+	assert.Equal(t, []DiffChange{{Path: "spec.progressDeadlineSeconds", Old: int64(600)}}, diff.Removed)
+	assert.Equal(t, []DiffChange{{Path: "spec.template.spec.containers[0].image", Old: "web:1.0", New: "web:1.1"}}, diff.Changed)
+
+	// Nothing was applied
+	live, err := dynamicClient.Resource(deploymentsGVR).Namespace("shop").Get(ctx, "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "42", live.GetResourceVersion())
+}
+
+func TestDiffResourceIdenticalAndQuotedPaths(t *testing.T) {
+	rm, dynamicClient := newApplyResourceManager(t)
+	ctx := context.Background()
+	live := liveDeployment()
+	_, err := dynamicClient.Resource(deploymentsGVR).Namespace("shop").Create(ctx, live, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+  labels:
+    app.kubernetes.io/name: api
+    tier: frontend
+spec:
+  replicas: 2
+  progressDeadlineSeconds: 600
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:1.0
+`
+	diff, err := rm.DiffResource(ctx, []byte(manifest))
+	require.NoError(t, err)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Equal(t, []DiffChange{{Path: `metadata.labels["app.kubernetes.io/name"]`, Old: "web", New: "api"}}, diff.Changed)
+
+	manifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+  labels:
+    app.kubernetes.io/name: web
+    tier: frontend
+spec:
+  replicas: 2
+  progressDeadlineSeconds: 600
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:1.0
+`
+	diff, err = rm.DiffResource(ctx, []byte(manifest))
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+}
+
+func TestDiffResourceNotFoundIsCreate(t *testing.T) {
+	rm, _ := newApplyResourceManager(t)
+
+	diff, err := rm.DiffResource(context.Background(), []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\ndata:\n  mode: live\n"))
+	require.NoError(t, err)
+	assert.True(t, diff.Create)
+	assert.Equal(t, "default", diff.Namespace)
+	assert.Empty(t, diff.Removed)
+	assert.Empty(t, diff.Changed)
+	paths := make([]string, 0, len(diff.Added))
+	for _, change := range diff.Added {
+		paths = append(paths, change.Path)
+	}
+	assert.Equal(t, []string{"apiVersion", "data", "kind", "metadata"}, paths)
+}
+
+func TestDiffResourceRejectsMultipleObjects(t *testing.T) {
+	rm, _ := newApplyResourceManager(t)
+
+	_, err := rm.DiffResource(context.Background(), []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: b\n"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidManifest))
+	assert.Contains(t, err.Error(), "expected one object, found 2")
+}
@@ -0,0 +1,132 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+var widgetsGVR = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+// pagedDynamicClient pages the lists of the fake dynamic client, which drops
+// Limit and Continue, like an API server would and records the list options
+// it received. Continue tokens are the offset of the next object.
+type pagedDynamicClient struct {
+	dynamic.Interface
+	options []metav1.ListOptions
+}
+
+func (c *pagedDynamicClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &pagedResource{NamespaceableResourceInterface: c.Interface.Resource(gvr), client: c}
+}
+
+type pagedResource struct {
+	dynamic.NamespaceableResourceInterface
+	client *pagedDynamicClient
+}
+
+func (r *pagedResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return &pagedNamespacedResource{ResourceInterface: r.NamespaceableResourceInterface.Namespace(namespace), client: r.client}
+}
+
+type pagedNamespacedResource struct {
+	dynamic.ResourceInterface
+	client *pagedDynamicClient
+}
+
+func (r *pagedNamespacedResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	r.client.options = append(r.client.options, opts)
+
+	list, err := r.ResourceInterface.List(ctx, metav1.ListOptions{LabelSelector: opts.LabelSelector})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].GetNamespace()+"/"+list.Items[i].GetName() < list.Items[j].GetNamespace()+"/"+list.Items[j].GetName()
+	})
+
+	start := 0
+	if opts.Continue != "" {
+		start, _ = strconv.Atoi(opts.Continue)
+	}
+	end := len(list.Items)
+	if opts.Limit > 0 && start+int(opts.Limit) < end {
+		end = start + int(opts.Limit)
+	}
+	items := list.Items[start:end]
+	list.SetContinue("")
+	if end < len(list.Items) {
+		list.SetContinue(strconv.Itoa(end))
+	}
+	list.Items = items
+	return list, nil
+}
+
+func newWidget(namespace, name string, labels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace, "labels": labels},
+	}}
+}
+
+func TestListResourcePagePagesThroughLargeCollection(t *testing.T) {
+	objects := []runtime.Object{}
+	for i := 0; i < 2500; i++ {
+		objects = append(objects, newWidget("shop", fmt.Sprintf("widget-%04d", i), map[string]interface{}{"tier": "web"}))
+	}
+	objects = append(objects, newWidget("prod", "widget-prod", map[string]interface{}{"tier": "db"}))
+	client := &pagedDynamicClient{Interface: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{widgetsGVR: "WidgetList"}, objects...)}
+	rm := NewResourceManager(zap.NewNop(), kubefake.NewSimpleClientset(), client)
+	ctx := context.Background()
+
+	seen := map[string]bool{}
+	continueToken := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3, "listing did not end")
+		list, err := rm.ListResourcePage(ctx, widgetsGVR, "shop", "tier=web", 1000, continueToken)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(list.Items), 1000)
+		for _, item := range list.Items {
+			assert.False(t, seen[item.GetName()], "duplicate %s", item.GetName())
+			seen[item.GetName()] = true
+		}
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			break
+		}
+	}
+	assert.Len(t, seen, 2500)
+	assert.Equal(t, []metav1.ListOptions{
+		{LabelSelector: "tier=web", Limit: 1000},
+		{LabelSelector: "tier=web", Limit: 1000, Continue: "1000"},
+		{LabelSelector: "tier=web", Limit: 1000, Continue: "2000"},
+	}, client.options)
+
+	// All namespaces without a limit
+	list, err := rm.ListResourcePage(ctx, widgetsGVR, "", "", 0, "")
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 2501)
+	assert.Empty(t, list.GetContinue())
+}
+
+func TestListResourcePageWithoutDynamicClient(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), kubefake.NewSimpleClientset(), nil)
+
+	_, err := rm.ListResourcePage(context.Background(), widgetsGVR, "", "", 10, "")
+	assert.Error(t, err)
+}
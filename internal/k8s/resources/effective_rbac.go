@@ -0,0 +1,199 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// serviceAccountUserPrefix starts the username of a ServiceAccount
+const serviceAccountUserPrefix = "system:serviceaccount:"
+
+// ParseRBACSubject reads a subject written as its username. Names of the
+// form system:serviceaccount:<namespace>:<name> are ServiceAccounts; other
+// names are Users, or Groups when kind is Group.
+func ParseRBACSubject(raw, kind string) (AccessReviewSubject, error) {
+	if rest, ok := strings.CutPrefix(raw, serviceAccountUserPrefix); ok {
+		namespace, name, found := strings.Cut(rest, ":")
+		if !found || namespace == "" || name == "" || strings.Contains(name, ":") {
+			return AccessReviewSubject{}, fmt.Errorf("service account subject must be written as %s<namespace>:<name>", serviceAccountUserPrefix)
+		}
+		return AccessReviewSubject{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: namespace}, nil
+	}
+
+	subject := AccessReviewSubject{Kind: rbacv1.UserKind, Name: raw}
+	switch kind {
+	case "", rbacv1.UserKind:
+	case rbacv1.GroupKind:
+		subject.Kind = rbacv1.GroupKind
+	default:
+		return AccessReviewSubject{}, fmt.Errorf("subject kind must be User or Group, got %q", kind)
+	}
+	return subject, subject.Validate()
+}
+
+// EffectiveRBACBinding is a RoleBinding or ClusterRoleBinding naming the
+// subject. RoleMissing is set when the role it references does not exist.
+type EffectiveRBACBinding struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	RoleKind    string `json:"roleKind"`
+	RoleName    string `json:"roleName"`
+	RoleMissing bool   `json:"roleMissing,omitempty"`
+}
+
+// EffectiveRule is a policy rule granted to the subject with the bindings
+// that grant it, written as kind/namespace/name
+type EffectiveRule struct {
+	rbacv1.PolicyRule
+	Bindings []string `json:"bindings"`
+}
+
+// EffectiveRBAC holds what a subject may do. ClusterRules come from
+// ClusterRoleBindings and apply in every namespace; NamespaceRules come from
+// RoleBindings and apply in the namespace they are keyed by.
+type EffectiveRBAC struct {
+	Subject        AccessReviewSubject        `json:"subject"`
+	Bindings       []EffectiveRBACBinding     `json:"bindings"`
+	ClusterRules   []EffectiveRule            `json:"clusterRules"`
+	NamespaceRules map[string][]EffectiveRule `json:"namespaceRules"`
+}
+
+// effectiveRules aggregates rules, merging identical rules granted by more
+// than one binding
+type effectiveRules struct {
+	rules []EffectiveRule
+	index map[string]int
+}
+
+func (e *effectiveRules) add(rules []rbacv1.PolicyRule, binding string) {
+	if e.index == nil {
+		e.index = map[string]int{}
+	}
+	for _, rule := range rules {
+		key := fmt.Sprintf("%q", rule)
+		if i, ok := e.index[key]; ok {
+			if last := e.rules[i].Bindings; last[len(last)-1] != binding {
+				e.rules[i].Bindings = append(last, binding)
+			}
+			continue
+		}
+		e.index[key] = len(e.rules)
+		e.rules = append(e.rules, EffectiveRule{PolicyRule: rule, Bindings: []string{binding}})
+	}
+}
+
+// EffectiveRBAC resolves what a subject may do. It scans RoleBindings, of
+// one namespace or of all when namespace is empty, and ClusterRoleBindings
+// for those naming the subject, or for a ServiceAccount one of its groups,
+// and aggregates the rules of the roles they reference by namespace. Groups
+// a User belongs to are not known to the API server, so bindings of those
+// groups are not included.
+func (rm *ResourceManager) EffectiveRBAC(ctx context.Context, subject AccessReviewSubject, namespace string) (*EffectiveRBAC, error) {
+	clusterRoles, err := rm.ListClusterRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clusterRoleRules := make(map[string][]rbacv1.PolicyRule, len(clusterRoles))
+	for _, role := range clusterRoles {
+		clusterRoleRules[role.Name] = role.Rules
+	}
+	roles, err := rm.ListRoles(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	roleRules := make(map[string][]rbacv1.PolicyRule, len(roles))
+	for _, item := range roles {
+		if role, ok := item.(rbacv1.Role); ok {
+			roleRules[role.Namespace+"/"+role.Name] = role.Rules
+		}
+	}
+	rulesFor := func(ref rbacv1.RoleRef, bindingNamespace string) ([]rbacv1.PolicyRule, bool) {
+		if ref.Kind == "ClusterRole" {
+			rules, ok := clusterRoleRules[ref.Name]
+			return rules, ok
+		}
+		rules, ok := roleRules[bindingNamespace+"/"+ref.Name]
+		return rules, ok
+	}
+
+	result := &EffectiveRBAC{
+		Subject:        subject,
+		Bindings:       []EffectiveRBACBinding{},
+		ClusterRules:   []EffectiveRule{},
+		NamespaceRules: map[string][]EffectiveRule{},
+	}
+
+	clusterRoleBindings, err := rm.ListClusterRoleBindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(clusterRoleBindings, func(i, j int) bool {
+		return clusterRoleBindings[i].Name < clusterRoleBindings[j].Name
+	})
+	var clusterRules effectiveRules
+	for _, binding := range clusterRoleBindings {
+		if !accessReviewSubjectBound(binding.Subjects, "", subject) {
+			continue
+		}
+		rules, found := rulesFor(binding.RoleRef, "")
+		result.Bindings = append(result.Bindings, EffectiveRBACBinding{
+			Kind:        "ClusterRoleBinding",
+			Name:        binding.Name,
+			RoleKind:    binding.RoleRef.Kind,
+			RoleName:    binding.RoleRef.Name,
+			RoleMissing: !found,
+		})
+		clusterRules.add(rules, "ClusterRoleBinding//"+binding.Name)
+	}
+	if clusterRules.rules != nil {
+		result.ClusterRules = clusterRules.rules
+	}
+
+	items, err := rm.ListRoleBindings(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	roleBindings := make([]rbacv1.RoleBinding, 0, len(items))
+	for _, item := range items {
+		if binding, ok := item.(rbacv1.RoleBinding); ok {
+			roleBindings = append(roleBindings, binding)
+		}
+	}
+	sort.Slice(roleBindings, func(i, j int) bool {
+		if roleBindings[i].Namespace != roleBindings[j].Namespace {
+			return roleBindings[i].Namespace < roleBindings[j].Namespace
+		}
+		return roleBindings[i].Name < roleBindings[j].Name
+	})
+	namespaceRules := map[string]*effectiveRules{}
+	for _, binding := range roleBindings {
+		if !accessReviewSubjectBound(binding.Subjects, binding.Namespace, subject) {
+			continue
+		}
+		rules, found := rulesFor(binding.RoleRef, binding.Namespace)
+		result.Bindings = append(result.Bindings, EffectiveRBACBinding{
+			Kind:        "RoleBinding",
+			Name:        binding.Name,
+			Namespace:   binding.Namespace,
+			RoleKind:    binding.RoleRef.Kind,
+			RoleName:    binding.RoleRef.Name,
+			RoleMissing: !found,
+		})
+		if namespaceRules[binding.Namespace] == nil {
+			namespaceRules[binding.Namespace] = &effectiveRules{}
+		}
+		namespaceRules[binding.Namespace].add(rules, "RoleBinding/"+binding.Namespace+"/"+binding.Name)
+	}
+	for ns, rules := range namespaceRules {
+		if rules.rules != nil {
+			result.NamespaceRules[ns] = rules.rules
+		}
+	}
+
+	return result, nil
+}
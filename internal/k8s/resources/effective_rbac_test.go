@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEffectiveRBACAggregatesBindings(t *testing.T) {
+	readPods := rbacv1.PolicyRule{Verbs: []string{"get", "list"}, APIGroups: []string{""}, Resources: []string{"pods"}}
+	client := kubefake.NewSimpleClientset(
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}, Rules: []rbacv1.PolicyRule{readPods}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "shop"}, Rules: []rbacv1.PolicyRule{
+			{Verbs: []string{"patch"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
+			readPods,
+		}},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "all-service-accounts-view"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "ci-deployer", Namespace: "shop"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "deployer"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "ci"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "ci-view", Namespace: "shop"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "ci", Namespace: "shop"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "ci-gone", Namespace: "prod"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "deleted"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "ci", Namespace: "shop"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "shop"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "deployer"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "web"}},
+		},
+	)
+	rm := NewResourceManager(zap.NewNop(), client, nil)
+
+	subject, err := ParseRBACSubject("system:serviceaccount:shop:ci", "")
+	require.NoError(t, err)
+	result, err := rm.EffectiveRBAC(context.Background(), subject, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []EffectiveRBACBinding{
+		{Kind: "ClusterRoleBinding", Name: "all-service-accounts-view", RoleKind: "ClusterRole", RoleName: "view"},
+		{Kind: "RoleBinding", Name: "ci-gone", Namespace: "prod", RoleKind: "Role", RoleName: "deleted", RoleMissing: true},
+		{Kind: "RoleBinding", Name: "ci-deployer", Namespace: "shop", RoleKind: "Role", RoleName: "deployer"},
+		{Kind: "RoleBinding", Name: "ci-view", Namespace: "shop", RoleKind: "ClusterRole", RoleName: "view"},
+	}, result.Bindings)
+	assert.Equal(t, []EffectiveRule{
+		{PolicyRule: readPods, Bindings: []string{"ClusterRoleBinding//all-service-accounts-view"}},
+	}, result.ClusterRules)
+
+	// The rule granted by both shop bindings is listed once
+	assert.Equal(t, map[string][]EffectiveRule{
+		"shop": {
+			{PolicyRule: rbacv1.PolicyRule{Verbs: []string{"patch"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}}, Bindings: []string{"RoleBinding/shop/ci-deployer"}},
+			{PolicyRule: readPods, Bindings: []string{"RoleBinding/shop/ci-deployer", "RoleBinding/shop/ci-view"}},
+		},
+	}, result.NamespaceRules)
+
+	// Limited to one namespace
+	result, err = rm.EffectiveRBAC(context.Background(), subject, "prod")
+	require.NoError(t, err)
+	assert.Len(t, result.Bindings, 2)
+	assert.Empty(t, result.NamespaceRules)
+}
+
+func TestParseRBACSubject(t *testing.T) {
+	subject, err := ParseRBACSubject("alice", "")
+	require.NoError(t, err)
+	assert.Equal(t, AccessReviewSubject{Kind: rbacv1.UserKind, Name: "alice"}, subject)
+
+	subject, err = ParseRBACSubject("platform", rbacv1.GroupKind)
+	require.NoError(t, err)
+	assert.Equal(t, AccessReviewSubject{Kind: rbacv1.GroupKind, Name: "platform"}, subject)
+
+	for _, raw := range []string{"", "system:serviceaccount:shop", "system:serviceaccount::ci", "system:serviceaccount:shop:ci:x"} {
+		_, err := ParseRBACSubject(raw, "")
+		assert.Error(t, err, raw)
+	}
+	_, err = ParseRBACSubject("alice", "Robot")
+	assert.Error(t, err)
+}
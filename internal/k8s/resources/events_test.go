@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// pagedEventsClient serves events in pages of the requested limit, like an
+// API server, and records the list options it received. The fake clientset
+// drops Limit and Continue, so event listing is intercepted here.
+type pagedEventsClient struct {
+	kubernetes.Interface
+	events  []v1.Event
+	options []metav1.ListOptions
+}
+
+func (c *pagedEventsClient) CoreV1() typedcorev1.CoreV1Interface {
+	return &pagedCoreV1{CoreV1Interface: c.Interface.CoreV1(), client: c}
+}
+
+type pagedCoreV1 struct {
+	typedcorev1.CoreV1Interface
+	client *pagedEventsClient
+}
+
+func (c *pagedCoreV1) Events(namespace string) typedcorev1.EventInterface {
+	return &pagedEvents{EventInterface: c.CoreV1Interface.Events(namespace), client: c.client}
+}
+
+type pagedEvents struct {
+	typedcorev1.EventInterface
+	client *pagedEventsClient
+}
+
+func (e *pagedEvents) List(ctx context.Context, opts metav1.ListOptions) (*v1.EventList, error) {
+	e.client.options = append(e.client.options, opts)
+
+	start := 0
+	for i, event := range e.client.events {
+		if event.Name == opts.Continue {
+			start = i
+		}
+	}
+	end := len(e.client.events)
+	if opts.Limit > 0 && start+int(opts.Limit) < end {
+		end = start + int(opts.Limit)
+	}
+
+	list := &v1.EventList{Items: e.client.events[start:end]}
+	if end < len(e.client.events) {
+		list.Continue = e.client.events[end].Name
+		remaining := int64(len(e.client.events) - end)
+		list.RemainingItemCount = &remaining
+	}
+	return list, nil
+}
+
+func TestListEventsPaged(t *testing.T) {
+	client := &pagedEventsClient{Interface: kubefake.NewSimpleClientset()}
+	for _, name := range []string{"a", "b", "c"} {
+		client.events = append(client.events, v1.Event{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+	}
+	rm := NewResourceManager(zap.NewNop(), client, nil)
+	ctx := context.Background()
+
+	first, err := rm.ListEventsPaged(ctx, "default", 2, "")
+	require.NoError(t, err)
+	assert.Len(t, first.Items, 2)
+	assert.Equal(t, "c", first.Continue)
+	require.NotNil(t, first.RemainingItemCount)
+	assert.Equal(t, int64(1), *first.RemainingItemCount)
+
+	second, err := rm.ListEventsPaged(ctx, "default", 2, first.Continue)
+	require.NoError(t, err)
+	require.Len(t, second.Items, 1)
+	assert.Equal(t, "c", second.Items[0].Name)
+	assert.Empty(t, second.Continue)
+	assert.Nil(t, second.RemainingItemCount)
+
+	assert.Equal(t, []metav1.ListOptions{{Limit: 2}, {Limit: 2, Continue: "c"}}, client.options)
+}
+
+func TestListEventsPagedEmpty(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), kubefake.NewSimpleClientset(), nil)
+
+	page, err := rm.ListEventsPaged(context.Background(), "", 10, "")
+	require.NoError(t, err)
+	assert.NotNil(t, page.Items)
+	assert.Empty(t, page.Continue)
+}
+
+func TestListEventsMatchingPassesFieldSelector(t *testing.T) {
+	client := &pagedEventsClient{Interface: kubefake.NewSimpleClientset()}
+	rm := NewResourceManager(zap.NewNop(), client, nil)
+
+	_, err := rm.ListEventsMatching(context.Background(), "shop", "involvedObject.name=web-0", 5, "")
+	require.NoError(t, err)
+	assert.Equal(t, []metav1.ListOptions{{FieldSelector: "involvedObject.name=web-0", Limit: 5}}, client.options)
+}
@@ -0,0 +1,78 @@
+package resources
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodPage is one page of pods listed with a continue token
+type PodPage struct {
+	Items []v1.Pod
+	// Continue requests the next page; it is empty on the last page
+	Continue string
+	// RemainingItemCount estimates the pods after this page, when the API
+	// server reports it
+	RemainingItemCount *int64
+}
+
+// ListPodsPaged lists at most limit pods matching the label and field
+// selectors from the API server, starting where the page that returned
+// continueToken ended. Unlike the informer cache it never holds more than a
+// page, so it suits clusters with tens of thousands of pods. Tokens expire
+// after a few minutes, in which case the API server answers 410 Gone.
+func (rm *ResourceManager) ListPodsPaged(ctx context.Context, namespace, labelSelector, fieldSelector string, limit int64, continueToken string) (*PodPage, error) {
+	pods, err := rm.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
+		Limit:         limit,
+		Continue:      continueToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	page := &PodPage{
+		Items:              pods.Items,
+		Continue:           pods.Continue,
+		RemainingItemCount: pods.RemainingItemCount,
+	}
+	if page.Items == nil {
+		page.Items = []v1.Pod{}
+	}
+	return page, nil
+}
+
+// DeploymentPage is one page of deployments listed with a continue token
+type DeploymentPage struct {
+	Items []appsv1.Deployment
+	// Continue requests the next page; it is empty on the last page
+	Continue string
+	// RemainingItemCount estimates the deployments after this page, when the
+	// API server reports it
+	RemainingItemCount *int64
+}
+
+// ListDeploymentsPaged lists at most limit deployments like ListPodsPaged
+// lists pods
+func (rm *ResourceManager) ListDeploymentsPaged(ctx context.Context, namespace, labelSelector, fieldSelector string, limit int64, continueToken string) (*DeploymentPage, error) {
+	deployments, err := rm.kubeClient.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
+		Limit:         limit,
+		Continue:      continueToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	page := &DeploymentPage{
+		Items:              deployments.Items,
+		Continue:           deployments.Continue,
+		RemainingItemCount: deployments.RemainingItemCount,
+	}
+	if page.Items == nil {
+		page.Items = []appsv1.Deployment{}
+	}
+	return page, nil
+}
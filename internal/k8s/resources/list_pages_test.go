@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestListPodsPaged(t *testing.T) {
+	remaining := int64(9000)
+	client := kubefake.NewSimpleClientset()
+	var restrictions k8stesting.ListRestrictions
+	client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		restrictions = action.(k8stesting.ListAction).GetListRestrictions()
+		return true, &v1.PodList{
+			ListMeta: metav1.ListMeta{Continue: "next", RemainingItemCount: &remaining},
+			Items:    []v1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop", Labels: map[string]string{"app": "web"}}}},
+		}, nil
+	})
+	rm := NewResourceManager(zap.NewNop(), client, nil)
+
+	page, err := rm.ListPodsPaged(context.Background(), "shop", "app=web", "spec.nodeName=node-1", 500, "")
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "next", page.Continue)
+	assert.Equal(t, &remaining, page.RemainingItemCount)
+	assert.Equal(t, "app=web", restrictions.Labels.String())
+	assert.Equal(t, "spec.nodeName=node-1", restrictions.Fields.String())
+}
+
+func TestListDeploymentsPagedEmpty(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), kubefake.NewSimpleClientset(), nil)
+
+	page, err := rm.ListDeploymentsPaged(context.Background(), "", "", "", 10, "")
+	require.NoError(t, err)
+	assert.Equal(t, []appsv1.Deployment{}, page.Items)
+	assert.Empty(t, page.Continue)
+	assert.Nil(t, page.RemainingItemCount)
+}
@@ -0,0 +1,152 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/restmapper"
+)
+
+// MetadataRemoveSuffix marks a key to remove when merging labels or
+// annotations: "app-" with an empty value removes "app", as kubectl label
+// does. Keys cannot end in "-", so the marker is never a real key.
+const MetadataRemoveSuffix = "-"
+
+// ErrInvalidMetadata is returned for label or annotation keys and values the
+// API server would reject
+var ErrInvalidMetadata = errors.New("invalid metadata")
+
+// ResolveKind maps a resource name as written on the command line, such as
+// deployments, deploy or widgets.example.com, to its preferred kind
+func (rm *ResourceManager) ResolveKind(resource string) (schema.GroupVersionKind, error) {
+	mapper := restmapper.NewShortcutExpander(rm.newDiscoveryRESTMapper(), rm.kubeClient.Discovery(), nil)
+	gvk, err := mapper.KindFor(schema.ParseGroupResource(strings.ToLower(resource)).WithVersion(""))
+	if err != nil {
+		return schema.GroupVersionKind{}, fmt.Errorf("unknown resource %q: %w", resource, err)
+	}
+	return gvk, nil
+}
+
+// UpdateLabels sets the labels of an object. With merge the given labels are
+// added to or replace the existing ones and keys ending in
+// MetadataRemoveSuffix remove a label; without it the given labels replace
+// all existing ones. The object's labels after the update are returned.
+func (rm *ResourceManager) UpdateLabels(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string, labels map[string]string, merge bool) (map[string]string, error) {
+	for key, value := range labels {
+		key = strings.TrimSuffix(key, MetadataRemoveSuffix)
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("%w: label key %q: %s", ErrInvalidMetadata, key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, fmt.Errorf("%w: label value %q: %s", ErrInvalidMetadata, value, strings.Join(errs, "; "))
+		}
+	}
+
+	updated, err := rm.updateMetadataMap(ctx, gvk, namespace, name, "labels", labels, merge)
+	if err != nil {
+		return nil, err
+	}
+	return updated.GetLabels(), nil
+}
+
+// UpdateAnnotations sets the annotations of an object like UpdateLabels sets
+// its labels
+func (rm *ResourceManager) UpdateAnnotations(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string, annotations map[string]string, merge bool) (map[string]string, error) {
+	for key := range annotations {
+		key = strings.TrimSuffix(key, MetadataRemoveSuffix)
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("%w: annotation key %q: %s", ErrInvalidMetadata, key, strings.Join(errs, "; "))
+		}
+	}
+
+	updated, err := rm.updateMetadataMap(ctx, gvk, namespace, name, "annotations", annotations, merge)
+	if err != nil {
+		return nil, err
+	}
+	return updated.GetAnnotations(), nil
+}
+
+// updateMetadataMap patches metadata.labels or metadata.annotations. Kinds
+// known to client-go are sent a strategic merge patch; custom resources do
+// not support one and are sent a JSON merge patch instead. Replacing the map
+// removes the keys the object has beyond the given ones, so the patch is
+// made against the current object and carries its resourceVersion to fail
+// with a conflict if it changed meanwhile.
+func (rm *ResourceManager) updateMetadataMap(ctx context.Context, gvk schema.GroupVersionKind, namespace, name, field string, values map[string]string, merge bool) (*unstructured.Unstructured, error) {
+	if rm.dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client not available")
+	}
+	mapping, err := rm.newDiscoveryRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("unknown resource %s: %w", gvk, err)
+	}
+	var client dynamic.ResourceInterface = rm.dynamicClient.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		client = rm.dynamicClient.Resource(mapping.Resource).Namespace(namespace)
+	}
+
+	entries := map[string]interface{}{}
+	metadata := map[string]interface{}{field: entries}
+	if merge {
+		for key, value := range values {
+			if removed, ok := strings.CutSuffix(key, MetadataRemoveSuffix); ok {
+				if value != "" {
+					return nil, fmt.Errorf("%w: %q removes %q and must have an empty value", ErrInvalidMetadata, key, removed)
+				}
+				entries[removed] = nil
+				continue
+			}
+			entries[key] = value
+		}
+	} else {
+		current, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		existing, _, _ := unstructured.NestedStringMap(current.Object, "metadata", field)
+		for key := range existing {
+			entries[key] = nil
+		}
+		for key, value := range values {
+			if strings.HasSuffix(key, MetadataRemoveSuffix) {
+				return nil, fmt.Errorf("%w: %q: keys are only removed by merging", ErrInvalidMetadata, key)
+			}
+			entries[key] = value
+		}
+		metadata["resourceVersion"] = current.GetResourceVersion()
+	}
+
+	if rm.annotateMutations {
+		stamp := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		rm.stampModification(ctx, stamp)
+		annotations, ok := metadata["annotations"].(map[string]interface{})
+		if !ok {
+			annotations = map[string]interface{}{}
+			metadata["annotations"] = annotations
+		}
+		for key, value := range stamp.GetAnnotations() {
+			annotations[key] = value
+		}
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode patch: %w", err)
+	}
+	patchType := types.MergePatchType
+	if scheme.Scheme.Recognizes(mapping.GroupVersionKind) {
+		patchType = types.StrategicMergePatchType
+	}
+	return client.Patch(ctx, name, patchType, patch, metav1.PatchOptions{FieldManager: applyFieldManager})
+}
@@ -0,0 +1,141 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var (
+	configMapGVK = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	widgetGVK    = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+)
+
+// newMetadataResourceManager returns a manager over a labelled ConfigMap and
+// Widget custom resource, and the patch types its dynamic client received
+func newMetadataResourceManager(t *testing.T) (*ResourceManager, *[]types.PatchType) {
+	t.Helper()
+
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, ShortNames: []string{"cm"}},
+		}},
+		{GroupVersion: "example.com/v1", APIResources: []metav1.APIResource{
+			{Name: "widgets", Kind: "Widget", Namespaced: true},
+		}},
+	}
+
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name": "web", "namespace": "shop",
+			"labels": map[string]interface{}{"app": "web", "tier": "frontend"},
+		},
+	}}
+	widget := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata": map[string]interface{}{
+			"name": "gear", "namespace": "shop",
+			"annotations": map[string]interface{}{"note": "spare", "owner": "alice"},
+		},
+	}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), configMap, widget)
+
+	patchTypes := &[]types.PatchType{}
+	dynamicClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		*patchTypes = append(*patchTypes, patch.GetPatchType())
+		if patch.GetPatchType() != types.StrategicMergePatchType {
+			return false, nil, nil
+		}
+		// The tracker cannot apply strategic merge patches to unstructured
+		// objects; for metadata maps they are the same as merge patches
+		merged := k8stesting.NewPatchAction(patch.GetResource(), patch.GetNamespace(), patch.GetName(), types.MergePatchType, patch.GetPatch())
+		return k8stesting.ObjectReaction(dynamicClient.Tracker())(merged)
+	})
+
+	return NewResourceManager(zap.NewNop(), kubeClient, dynamicClient), patchTypes
+}
+
+func TestUpdateLabelsMerge(t *testing.T) {
+	rm, patchTypes := newMetadataResourceManager(t)
+
+	labels, err := rm.UpdateLabels(context.Background(), configMapGVK, "shop", "web", map[string]string{
+		"tier": "backend",
+		"team": "payments",
+		"app-": "",
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tier": "backend", "team": "payments"}, labels)
+	assert.Equal(t, []types.PatchType{types.StrategicMergePatchType}, *patchTypes)
+}
+
+func TestUpdateAnnotationsReplaceCustomResource(t *testing.T) {
+	rm, patchTypes := newMetadataResourceManager(t)
+
+	annotations, err := rm.UpdateAnnotations(context.Background(), widgetGVK, "shop", "gear", map[string]string{"owner": "bob"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "bob"}, annotations)
+	assert.Equal(t, []types.PatchType{types.MergePatchType}, *patchTypes)
+}
+
+func TestUpdateAnnotationsStampsModification(t *testing.T) {
+	rm, _ := newMetadataResourceManager(t)
+	rm.SetMutationAnnotations(true)
+
+	annotations, err := rm.UpdateAnnotations(WithActor(context.Background(), "bob"), widgetGVK, "shop", "gear", map[string]string{"note": "in use"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "in use", annotations["note"])
+	assert.Equal(t, "alice", annotations["owner"])
+	assert.Equal(t, "bob", annotations[AnnotationLastModifiedBy])
+	assert.Contains(t, annotations, AnnotationLastModifiedAt)
+}
+
+func TestUpdateMetadataRejectsInvalidInput(t *testing.T) {
+	rm, patchTypes := newMetadataResourceManager(t)
+	ctx := context.Background()
+
+	for _, labels := range []map[string]string{
+		{"bad key!": "x"},
+		{"app": "not a valid value"},
+		{"app-": "web"},
+	} {
+		_, err := rm.UpdateLabels(ctx, configMapGVK, "shop", "web", labels, true)
+		assert.ErrorIs(t, err, ErrInvalidMetadata, labels)
+	}
+	_, err := rm.UpdateAnnotations(ctx, widgetGVK, "shop", "gear", map[string]string{"note-": ""}, false)
+	assert.ErrorIs(t, err, ErrInvalidMetadata)
+	assert.Empty(t, *patchTypes)
+}
+
+func TestResolveKind(t *testing.T) {
+	rm, _ := newMetadataResourceManager(t)
+
+	for resource, expected := range map[string]schema.GroupVersionKind{
+		"configmaps":          configMapGVK,
+		"cm":                  configMapGVK,
+		"widgets.example.com": widgetGVK,
+		"Widget":              widgetGVK,
+	} {
+		gvk, err := rm.ResolveKind(resource)
+		require.NoError(t, err, resource)
+		assert.Equal(t, expected, gvk, resource)
+	}
+	_, err := rm.ResolveKind("gadgets")
+	assert.Error(t, err)
+}
@@ -0,0 +1,285 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// DefaultDrainTimeoutSeconds bounds how long a drain waits for evicted pods
+// to terminate
+const DefaultDrainTimeoutSeconds = 300
+
+// drainPollInterval is how often a drain checks whether evicted pods are gone
+var drainPollInterval = 2 * time.Second
+
+// Per-pod drain states
+const (
+	DrainPodSkipped  = "skipped"
+	DrainPodEvicting = "evicting"
+	DrainPodEvicted  = "evicted"
+	DrainPodFailed   = "failed"
+	DrainPodTimedOut = "timedOut"
+)
+
+// DrainNodeOptions control which pods a drain may remove and how long it
+// waits for them
+type DrainNodeOptions struct {
+	// GracePeriodSeconds overrides the pods' termination grace period
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+	// IgnoreDaemonSets skips DaemonSet pods instead of refusing to drain
+	IgnoreDaemonSets bool `json:"ignoreDaemonSets,omitempty"`
+	// DeleteEmptyDir allows evicting pods whose emptyDir data is lost
+	DeleteEmptyDir bool `json:"deleteEmptyDir,omitempty"`
+	// Force allows evicting pods without a controller, which are not recreated
+	Force bool `json:"force,omitempty"`
+	// TimeoutSeconds bounds the wait for evicted pods to terminate
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+// DrainPodResult is the drain state of one pod on the node
+type DrainPodResult struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// DrainNodeResult lists every pod on a drained node and what happened to it.
+// Pods still evicting have not terminated yet.
+type DrainNodeResult struct {
+	Node     string           `json:"node"`
+	Cordoned bool             `json:"cordoned"`
+	Pods     []DrainPodResult `json:"pods"`
+	Evicting int              `json:"evicting"`
+	Complete bool             `json:"complete"`
+}
+
+// CordonNode marks a node unschedulable. Cordoning a cordoned node is a no-op.
+func (rm *ResourceManager) CordonNode(ctx context.Context, name string) error {
+	return rm.setNodeUnschedulable(ctx, name, true)
+}
+
+// UncordonNode marks a node schedulable again
+func (rm *ResourceManager) UncordonNode(ctx context.Context, name string) error {
+	return rm.setNodeUnschedulable(ctx, name, false)
+}
+
+// setNodeUnschedulable updates the unschedulable flag of a node
+func (rm *ResourceManager) setNodeUnschedulable(ctx context.Context, name string, unschedulable bool) error {
+	node, err := rm.kubeClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get node: %w", err)
+	}
+	if node.Spec.Unschedulable == unschedulable {
+		return nil
+	}
+
+	rm.logger.Info("Setting node schedulability",
+		zap.String("node", name),
+		zap.Bool("unschedulable", unschedulable))
+
+	node.Spec.Unschedulable = unschedulable
+	rm.stampModification(ctx, node)
+	if _, err := rm.kubeClient.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update node: %w", err)
+	}
+	return nil
+}
+
+// DrainNode cordons a node and evicts its pods through the Eviction API, so
+// PodDisruptionBudgets are honored, then waits until the evicted pods have
+// terminated or the timeout passes. Like kubectl drain it refuses, after
+// cordoning, when a pod would block the drain: a DaemonSet pod without
+// IgnoreDaemonSets, a pod with emptyDir data without DeleteEmptyDir, or a pod
+// without a controller without Force. Completed and static pods are skipped.
+// Evictions a PodDisruptionBudget refuses are retried until the timeout, as
+// kubectl does; other eviction failures are reported per pod. progress, when set, is called with
+// a snapshot of the result whenever a pod changes state.
+func (rm *ResourceManager) DrainNode(ctx context.Context, name string, opts DrainNodeOptions, progress func(DrainNodeResult)) (*DrainNodeResult, error) {
+	if opts.TimeoutSeconds <= 0 {
+		opts.TimeoutSeconds = DefaultDrainTimeoutSeconds
+	}
+
+	if err := rm.CordonNode(ctx, name); err != nil {
+		return nil, err
+	}
+	result := &DrainNodeResult{Node: name, Cordoned: true, Pods: []DrainPodResult{}}
+
+	pods, err := rm.kubeClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods on node %s: %w", name, err)
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		if pods.Items[i].Namespace != pods.Items[j].Namespace {
+			return pods.Items[i].Namespace < pods.Items[j].Namespace
+		}
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+
+	var evictable []v1.Pod
+	var blockers []string
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != name {
+			continue
+		}
+		skip, block := drainDisposition(&pod, opts)
+		switch {
+		case block != "":
+			blockers = append(blockers, fmt.Sprintf("%s/%s (%s)", pod.Namespace, pod.Name, block))
+		case skip != "":
+			result.Pods = append(result.Pods, DrainPodResult{Namespace: pod.Namespace, Name: pod.Name, Status: DrainPodSkipped, Reason: skip})
+		default:
+			evictable = append(evictable, pod)
+		}
+	}
+	if len(blockers) > 0 {
+		return nil, fmt.Errorf("cannot drain node %s: %s", name, strings.Join(blockers, ", "))
+	}
+
+	rm.logger.Info("Draining node",
+		zap.String("node", name),
+		zap.Int("evictable", len(evictable)),
+		zap.Int("skipped", len(result.Pods)))
+
+	report := func() {
+		result.Evicting = 0
+		for _, pod := range result.Pods {
+			if pod.Status == DrainPodEvicting {
+				result.Evicting++
+			}
+		}
+		if progress != nil {
+			snapshot := *result
+			snapshot.Pods = append([]DrainPodResult{}, result.Pods...)
+			progress(snapshot)
+		}
+	}
+
+	// Evicted pods are waited for by UID, so a StatefulSet pod recreated under
+	// the same name counts as gone. Pods whose eviction a PodDisruptionBudget
+	// refused stay evicting while the eviction is retried.
+	waiting := make(map[int]v1.Pod)
+	blocked := make(map[int]v1.Pod)
+	evict := func(i int, pod v1.Pod) {
+		eviction := &policyv1.Eviction{
+			ObjectMeta:    metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+			DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: opts.GracePeriodSeconds},
+		}
+		err := rm.kubeClient.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction)
+		delete(blocked, i)
+		switch {
+		case errors.IsNotFound(err):
+			result.Pods[i].Status = DrainPodEvicted
+			result.Pods[i].Reason = ""
+		case errors.IsTooManyRequests(err):
+			result.Pods[i].Reason = err.Error()
+			blocked[i] = pod
+		case err != nil:
+			result.Pods[i].Status = DrainPodFailed
+			result.Pods[i].Reason = err.Error()
+		default:
+			result.Pods[i].Reason = ""
+			waiting[i] = pod
+		}
+	}
+	for _, pod := range evictable {
+		result.Pods = append(result.Pods, DrainPodResult{Namespace: pod.Namespace, Name: pod.Name, Status: DrainPodEvicting})
+		evict(len(result.Pods)-1, pod)
+		report()
+	}
+
+	deadline := time.Now().Add(time.Duration(opts.TimeoutSeconds) * time.Second)
+	for len(waiting)+len(blocked) > 0 {
+		for i, pod := range waiting {
+			current, err := rm.kubeClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+			if errors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+				result.Pods[i].Status = DrainPodEvicted
+				delete(waiting, i)
+				report()
+			}
+		}
+		if len(waiting)+len(blocked) == 0 {
+			break
+		}
+		if !time.Now().Before(deadline) {
+			for i := range waiting {
+				result.Pods[i].Status = DrainPodTimedOut
+				result.Pods[i].Reason = fmt.Sprintf("still terminating after %d seconds", opts.TimeoutSeconds)
+			}
+			for i := range blocked {
+				result.Pods[i].Status = DrainPodFailed
+				result.Pods[i].Reason = fmt.Sprintf("eviction still refused after %d seconds: %s", opts.TimeoutSeconds, result.Pods[i].Reason)
+			}
+			report()
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(drainPollInterval):
+		}
+
+		retry := make(map[int]v1.Pod, len(blocked))
+		for i, pod := range blocked {
+			retry[i] = pod
+		}
+		for i, pod := range retry {
+			evict(i, pod)
+			if _, stillBlocked := blocked[i]; !stillBlocked {
+				report()
+			}
+		}
+	}
+
+	result.Complete = true
+	for _, pod := range result.Pods {
+		if pod.Status == DrainPodFailed || pod.Status == DrainPodTimedOut {
+			result.Complete = false
+		}
+	}
+	report()
+	return result, nil
+}
+
+// drainDisposition decides whether a drain skips a pod, is blocked by it, or
+// evicts it when both reasons are empty
+func drainDisposition(pod *v1.Pod, opts DrainNodeOptions) (skip, block string) {
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return "completed", ""
+	}
+	if _, mirror := pod.Annotations[v1.MirrorPodAnnotationKey]; mirror {
+		return "static pod", ""
+	}
+
+	controller := metav1.GetControllerOf(pod)
+	if controller != nil && controller.Kind == "DaemonSet" {
+		if opts.IgnoreDaemonSets {
+			return "DaemonSet", ""
+		}
+		return "", "DaemonSet pod, set ignoreDaemonSets"
+	}
+	if !opts.DeleteEmptyDir {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.EmptyDir != nil {
+				return "", "uses emptyDir, set deleteEmptyDir"
+			}
+		}
+	}
+	if controller == nil && !opts.Force {
+		return "", "not managed by a controller, set force"
+	}
+	return "", ""
+}
@@ -0,0 +1,186 @@
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func nodePod(name, node, controllerKind string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name, UID: types.UID("uid-" + name)},
+		Spec:       v1.PodSpec{NodeName: node},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	if controllerKind != "" {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: controllerKind, Name: name + "-owner", Controller: &controller}}
+	}
+	return pod
+}
+
+// newDrainFixture returns a manager whose evictions delete the pod, except
+// that a PodDisruptionBudget refuses the pods in blocked that many times (or
+// always, for a negative count), and the pods in stuck never terminate
+func newDrainFixture(t *testing.T, blocked map[string]int, stuck map[string]bool, objects ...runtime.Object) (*ResourceManager, *kubefake.Clientset) {
+	t.Helper()
+	kubeClient := kubefake.NewSimpleClientset(objects...)
+	kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+		if refusals := blocked[eviction.Name]; refusals != 0 {
+			blocked[eviction.Name] = refusals - 1
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		if !stuck[eviction.Name] {
+			err := kubeClient.Tracker().Delete(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, eviction.Namespace, eviction.Name)
+			require.NoError(t, err)
+		}
+		return true, nil, nil
+	})
+
+	previous := drainPollInterval
+	drainPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { drainPollInterval = previous })
+
+	return NewResourceManager(zap.NewNop(), kubeClient, nil), kubeClient
+}
+
+func drainStates(result *DrainNodeResult) map[string]string {
+	states := make(map[string]string, len(result.Pods))
+	for _, pod := range result.Pods {
+		states[pod.Name] = pod.Status
+	}
+	return states
+}
+
+func TestCordonAndUncordonNode(t *testing.T) {
+	rm, kubeClient := newDrainFixture(t, nil, nil, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}})
+	rm.SetMutationAnnotations(true)
+
+	require.NoError(t, rm.CordonNode(context.Background(), "node-a"))
+	node, err := kubeClient.CoreV1().Nodes().Get(context.Background(), "node-a", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, node.Spec.Unschedulable)
+	assert.Contains(t, node.Annotations, AnnotationLastModifiedAt)
+
+	require.NoError(t, rm.UncordonNode(context.Background(), "node-a"))
+	node, err = kubeClient.CoreV1().Nodes().Get(context.Background(), "node-a", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, node.Spec.Unschedulable)
+
+	assert.Error(t, rm.CordonNode(context.Background(), "missing"))
+}
+
+func TestDrainNode(t *testing.T) {
+	completed := nodePod("batch-1", "node-a", "Job")
+	completed.Status.Phase = v1.PodSucceeded
+
+	rm, kubeClient := newDrainFixture(t, map[string]int{"db-0": 2}, nil,
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		nodePod("web-1", "node-a", "ReplicaSet"),
+		nodePod("db-0", "node-a", "StatefulSet"),
+		nodePod("agent", "node-a", "DaemonSet"),
+		completed,
+		nodePod("web-2", "node-b", "ReplicaSet"),
+	)
+
+	var updates []DrainNodeResult
+	result, err := rm.DrainNode(context.Background(), "node-a", DrainNodeOptions{IgnoreDaemonSets: true, TimeoutSeconds: 5}, func(progress DrainNodeResult) {
+		updates = append(updates, progress)
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.Cordoned)
+	assert.True(t, result.Complete, "the PDB-protected pod is evicted once the budget allows it")
+	assert.Equal(t, map[string]string{
+		"agent":   DrainPodSkipped,
+		"batch-1": DrainPodSkipped,
+		"web-1":   DrainPodEvicted,
+		"db-0":    DrainPodEvicted,
+	}, drainStates(result))
+	assert.Zero(t, result.Evicting)
+
+	require.Greater(t, len(updates), 1)
+	// db-0 stays evicting while the budget refuses it, next to web-1
+	assert.Equal(t, DrainPodEvicting, drainStates(&updates[0])["db-0"])
+	assert.Contains(t, updates[0].Pods[2].Reason, "disruption budget")
+	assert.Equal(t, 2, updates[1].Evicting)
+	assert.Equal(t, DrainPodEvicting, drainStates(&updates[1])["web-1"])
+
+	node, err := kubeClient.CoreV1().Nodes().Get(context.Background(), "node-a", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, node.Spec.Unschedulable)
+
+	_, err = kubeClient.CoreV1().Pods("shop").Get(context.Background(), "web-2", metav1.GetOptions{})
+	assert.NoError(t, err, "pods on other nodes are untouched")
+}
+
+func TestDrainNodeRefusesBlockingPods(t *testing.T) {
+	withEmptyDir := nodePod("cache-0", "node-a", "ReplicaSet")
+	withEmptyDir.Spec.Volumes = []v1.Volume{{Name: "scratch", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+
+	rm, kubeClient := newDrainFixture(t, nil, nil,
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		nodePod("agent", "node-a", "DaemonSet"),
+		nodePod("standalone", "node-a", ""),
+		withEmptyDir,
+	)
+
+	_, err := rm.DrainNode(context.Background(), "node-a", DrainNodeOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "shop/agent (DaemonSet pod, set ignoreDaemonSets)")
+	assert.Contains(t, err.Error(), "shop/standalone (not managed by a controller, set force)")
+	assert.Contains(t, err.Error(), "shop/cache-0 (uses emptyDir, set deleteEmptyDir)")
+
+	list, err := kubeClient.CoreV1().Pods("shop").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 3, "nothing is evicted when the drain is refused")
+
+	result, err := rm.DrainNode(context.Background(), "node-a", DrainNodeOptions{IgnoreDaemonSets: true, DeleteEmptyDir: true, Force: true}, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Complete)
+	assert.Equal(t, DrainPodEvicted, drainStates(result)["standalone"])
+	assert.Equal(t, DrainPodEvicted, drainStates(result)["cache-0"])
+}
+
+func TestDrainNodeTimesOutWaitingForTermination(t *testing.T) {
+	rm, _ := newDrainFixture(t, nil, map[string]bool{"slow-0": true},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		nodePod("slow-0", "node-a", "ReplicaSet"),
+	)
+
+	result, err := rm.DrainNode(context.Background(), "node-a", DrainNodeOptions{TimeoutSeconds: 1}, nil)
+	require.NoError(t, err)
+	assert.False(t, result.Complete)
+	assert.Equal(t, DrainPodTimedOut, drainStates(result)["slow-0"])
+}
+
+func TestDrainNodeFailsWhenEvictionStaysBlocked(t *testing.T) {
+	rm, _ := newDrainFixture(t, map[string]int{"db-0": -1}, nil,
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		nodePod("db-0", "node-a", "StatefulSet"),
+	)
+
+	result, err := rm.DrainNode(context.Background(), "node-a", DrainNodeOptions{TimeoutSeconds: 1}, nil)
+	require.NoError(t, err)
+	assert.False(t, result.Complete)
+	require.Len(t, result.Pods, 1)
+	assert.Equal(t, DrainPodFailed, result.Pods[0].Status)
+	assert.Contains(t, result.Pods[0].Reason, "eviction still refused after 1 seconds")
+}
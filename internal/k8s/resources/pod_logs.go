@@ -0,0 +1,159 @@
+package resources
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrNoPreviousLogs is returned when the logs of a container's previous
+// instance are requested but the container has never restarted
+var ErrNoPreviousLogs = stderrors.New("no previous container logs")
+
+// ErrContainerNotRunning is returned when the logs since a container's last
+// start are requested but the container is not running, e.g. while it waits
+// out a crash-loop back-off
+var ErrContainerNotRunning = stderrors.New("container is not running")
+
+// LogWindow is the time range covered by one instance of a container. End
+// is nil for the running instance.
+type LogWindow struct {
+	Container string
+	Start     metav1.Time
+	End       *metav1.Time
+}
+
+// RestartLogWindow returns the window of a container's logs since its last
+// start: from state.running.startedAt for the running instance or, with
+// previous, from lastState.terminated.startedAt to finishedAt for the
+// instance before it. An empty container name selects the pod's only
+// container.
+func RestartLogWindow(pod *v1.Pod, containerName string, previous bool) (LogWindow, error) {
+	if containerName == "" {
+		if len(pod.Spec.Containers) != 1 {
+			return LogWindow{}, fmt.Errorf("pod %s/%s has %d containers, a container name is required",
+				pod.Namespace, pod.Name, len(pod.Spec.Containers))
+		}
+		containerName = pod.Spec.Containers[0].Name
+	}
+	window := LogWindow{Container: containerName}
+
+	status, ok := podContainerStatuses(pod)[containerName]
+	if !ok {
+		return window, fmt.Errorf("container %s of pod %s/%s has no status yet", containerName, pod.Namespace, pod.Name)
+	}
+
+	if previous {
+		terminated := status.LastTerminationState.Terminated
+		if terminated == nil {
+			return window, fmt.Errorf("%w: container %s of pod %s/%s has not restarted, so there is no previous instance to read logs from",
+				ErrNoPreviousLogs, containerName, pod.Namespace, pod.Name)
+		}
+		window.Start = terminated.StartedAt
+		window.End = terminated.FinishedAt.DeepCopy()
+		return window, nil
+	}
+
+	if status.State.Running == nil {
+		return window, fmt.Errorf("%w: container %s of pod %s/%s has no running instance; use previous to read the last one",
+			ErrContainerNotRunning, containerName, pod.Namespace, pod.Name)
+	}
+	window.Start = status.State.Running.StartedAt
+	return window, nil
+}
+
+// GetPodLogsSince retrieves the logs a container wrote from sinceTime on,
+// from its last terminated instance with previous. Together with
+// RestartLogWindow it reads the logs since a container's last restart.
+func (rm *ResourceManager) GetPodLogsSince(ctx context.Context, namespace, podName, containerName string, tailLines *int64, previous bool, sinceTime metav1.Time) (string, error) {
+	logs, err := rm.kubeClient.CoreV1().Pods(namespace).GetLogs(podName, &v1.PodLogOptions{
+		Container: containerName,
+		Previous:  previous,
+		SinceTime: &sinceTime,
+		TailLines: tailLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod logs: %w", err)
+	}
+	return string(logs), nil
+}
+
+// GetAllContainerLogs retrieves the logs of every container of a pod, init
+// containers included, keyed by container name. Containers that have not
+// started yet have no logs and are left out.
+func (rm *ResourceManager) GetAllContainerLogs(ctx context.Context, namespace, podName string, tailLines *int64) (map[string]string, error) {
+	pod, err := rm.kubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	statuses := podContainerStatuses(pod)
+	containers := make([]string, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	for _, container := range pod.Spec.InitContainers {
+		containers = append(containers, container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, container.Name)
+	}
+
+	logs := make(map[string]string, len(containers))
+	for _, name := range containers {
+		if !containerStarted(statuses[name]) {
+			continue
+		}
+		containerLogs, err := rm.GetPodLogs(ctx, namespace, podName, name, tailLines, false)
+		if err != nil {
+			return nil, fmt.Errorf("container %s: %w", name, err)
+		}
+		logs[name] = containerLogs
+	}
+	return logs, nil
+}
+
+// checkPreviousLogs returns ErrNoPreviousLogs, wrapped with a description,
+// when the container has no terminated instance to read logs from. An empty
+// container name selects the pod's only container.
+func (rm *ResourceManager) checkPreviousLogs(ctx context.Context, namespace, podName, containerName string) error {
+	pod, err := rm.kubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get pod: %w", err)
+	}
+	if containerName == "" {
+		if len(pod.Spec.Containers) != 1 {
+			// Leave the choice of container to the API server's error
+			return nil
+		}
+		containerName = pod.Spec.Containers[0].Name
+	}
+
+	status, ok := podContainerStatuses(pod)[containerName]
+	if !ok {
+		return nil
+	}
+	if status.RestartCount == 0 && status.LastTerminationState.Terminated == nil {
+		return fmt.Errorf("%w: container %s of pod %s/%s has not restarted, so there is no previous instance to read logs from",
+			ErrNoPreviousLogs, containerName, namespace, podName)
+	}
+	return nil
+}
+
+// podContainerStatuses indexes the statuses of a pod's init, regular and
+// ephemeral containers by container name
+func podContainerStatuses(pod *v1.Pod) map[string]v1.ContainerStatus {
+	statuses := make(map[string]v1.ContainerStatus)
+	for _, group := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses} {
+		for _, status := range group {
+			statuses[status.Name] = status
+		}
+	}
+	return statuses
+}
+
+// containerStarted reports whether a container has run at least once, so
+// the kubelet has logs for it
+func containerStarted(status v1.ContainerStatus) bool {
+	return status.State.Running != nil || status.State.Terminated != nil || status.RestartCount > 0
+}
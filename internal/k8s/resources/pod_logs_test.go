@@ -0,0 +1,137 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func logsTestPod() *v1.Pod {
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "migrate"}},
+			Containers:     []v1.Container{{Name: "web"}, {Name: "sidecar"}, {Name: "late"}},
+		},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "migrate", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0}}},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:                 "web",
+					State:                running,
+					RestartCount:         3,
+					LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 137}},
+				},
+				{Name: "sidecar", State: running},
+				{Name: "late", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			},
+		},
+	}
+}
+
+func TestGetPodLogsPrevious(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), kubefake.NewSimpleClientset(logsTestPod()), nil)
+	ctx := context.Background()
+
+	logs, err := rm.GetPodLogs(ctx, "shop", "web-0", "web", nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, "fake logs", logs)
+
+	_, err = rm.GetPodLogs(ctx, "shop", "web-0", "sidecar", nil, true)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNoPreviousLogs))
+	assert.Contains(t, err.Error(), "container sidecar of pod shop/web-0 has not restarted")
+
+	_, err = rm.GetPodLogs(ctx, "shop", "missing", "web", nil, true)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrNoPreviousLogs))
+}
+
+func TestGetAllContainerLogs(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), kubefake.NewSimpleClientset(logsTestPod()), nil)
+
+	tail := int64(50)
+	logs, err := rm.GetAllContainerLogs(context.Background(), "shop", "web-0", &tail)
+	require.NoError(t, err)
+	// The container that has not started yet has no logs
+	assert.Equal(t, map[string]string{
+		"migrate": "fake logs",
+		"web":     "fake logs",
+		"sidecar": "fake logs",
+	}, logs)
+
+	_, err = rm.GetAllContainerLogs(context.Background(), "shop", "missing", nil)
+	assert.Error(t, err)
+}
+
+func TestRestartLogWindow(t *testing.T) {
+	started := metav1.NewTime(time.Date(2026, 3, 1, 10, 5, 0, 0, time.UTC))
+	lastStarted := metav1.NewTime(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	lastFinished := metav1.NewTime(time.Date(2026, 3, 1, 10, 4, 30, 0, time.UTC))
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "web"}, {Name: "worker"}}},
+		Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+			{
+				Name:                 "web",
+				State:                v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: started}},
+				RestartCount:         4,
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{StartedAt: lastStarted, FinishedAt: lastFinished}},
+			},
+			{
+				Name:                 "worker",
+				State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				RestartCount:         7,
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{StartedAt: lastStarted, FinishedAt: lastFinished}},
+			},
+		}},
+	}
+
+	window, err := RestartLogWindow(pod, "web", false)
+	require.NoError(t, err)
+	assert.Equal(t, LogWindow{Container: "web", Start: started}, window)
+
+	window, err = RestartLogWindow(pod, "web", true)
+	require.NoError(t, err)
+	assert.Equal(t, lastStarted, window.Start)
+	require.NotNil(t, window.End)
+	assert.Equal(t, lastFinished, *window.End)
+
+	// A crash-looping container has only its previous instance to read
+	_, err = RestartLogWindow(pod, "worker", false)
+	assert.ErrorIs(t, err, ErrContainerNotRunning)
+	window, err = RestartLogWindow(pod, "worker", true)
+	require.NoError(t, err)
+	assert.Equal(t, lastStarted, window.Start)
+
+	_, err = RestartLogWindow(pod, "", false)
+	assert.ErrorContains(t, err, "has 2 containers")
+
+	single := logsTestPod()
+	single.Spec.Containers = single.Spec.Containers[1:2]
+	_, err = RestartLogWindow(single, "", true)
+	assert.ErrorIs(t, err, ErrNoPreviousLogs)
+	window, err = RestartLogWindow(single, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "sidecar", window.Container)
+}
+
+func TestGetPodLogsSince(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), kubefake.NewSimpleClientset(logsTestPod()), nil)
+
+	logs, err := rm.GetPodLogsSince(context.Background(), "shop", "web-0", "web", nil, false, metav1.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "fake logs", logs)
+}
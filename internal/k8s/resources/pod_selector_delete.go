@@ -0,0 +1,128 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DefaultMaxSelectorPodDeletes caps how many pods one selector delete may target
+const DefaultMaxSelectorPodDeletes = 50
+
+// selectorDeleteWorkers bounds concurrent pod deletions
+const selectorDeleteWorkers = 8
+
+// PodSelectorDeleteRequest represents a request to delete the pods of a
+// namespace that match a label selector
+type PodSelectorDeleteRequest struct {
+	Namespace          string `json:"namespace"`
+	LabelSelector      string `json:"labelSelector"`
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+	DryRun             bool   `json:"dryRun"`
+}
+
+// PodDeleteResult is the outcome of deleting a single pod
+type PodDeleteResult struct {
+	Name    string `json:"name"`
+	Deleted bool   `json:"deleted"`
+	Error   string `json:"error,omitempty"`
+}
+
+// PodSelectorDeleteResult lists the pods a selector matched and what
+// happened to each
+type PodSelectorDeleteResult struct {
+	Namespace string            `json:"namespace"`
+	DryRun    bool              `json:"dryRun"`
+	Targeted  []string          `json:"targeted"`
+	Deleted   []string          `json:"deleted"`
+	Results   []PodDeleteResult `json:"results"`
+}
+
+// DeletePodsBySelector deletes the pods among candidates that are in the
+// request namespace and match its label selector. Candidates normally come
+// from the pod informer. The request is rejected without deleting anything
+// when the selector is empty or invalid, or matches more than maxPods pods.
+// Individual deletion failures are reported per pod rather than as an error.
+func (rm *ResourceManager) DeletePodsBySelector(ctx context.Context, candidates []*v1.Pod, req PodSelectorDeleteRequest, maxPods int) (*PodSelectorDeleteResult, error) {
+	if req.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	if req.LabelSelector == "" {
+		return nil, fmt.Errorf("labelSelector is required")
+	}
+	selector, err := labels.Parse(req.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+	if selector.Empty() {
+		return nil, fmt.Errorf("labelSelector must not select every pod")
+	}
+	if maxPods <= 0 {
+		maxPods = DefaultMaxSelectorPodDeletes
+	}
+
+	var targets []string
+	for _, pod := range candidates {
+		if pod.Namespace == req.Namespace && selector.Matches(labels.Set(pod.Labels)) {
+			targets = append(targets, pod.Name)
+		}
+	}
+	sort.Strings(targets)
+	if len(targets) > maxPods {
+		return nil, fmt.Errorf("selector matches %d pods, exceeding the limit of %d", len(targets), maxPods)
+	}
+
+	result := &PodSelectorDeleteResult{
+		Namespace: req.Namespace,
+		DryRun:    req.DryRun,
+		Targeted:  append([]string{}, targets...),
+		Deleted:   []string{},
+		Results:   make([]PodDeleteResult, len(targets)),
+	}
+
+	rm.logger.Info("Deleting pods by selector",
+		zap.String("namespace", req.Namespace),
+		zap.String("labelSelector", req.LabelSelector),
+		zap.Int("targeted", len(targets)),
+		zap.Bool("dryRun", req.DryRun))
+
+	if req.DryRun {
+		for i, name := range targets {
+			result.Results[i] = PodDeleteResult{Name: name}
+		}
+		return result, nil
+	}
+
+	deleteOptions := metav1.DeleteOptions{GracePeriodSeconds: req.GracePeriodSeconds}
+	sem := make(chan struct{}, selectorDeleteWorkers)
+	var wg sync.WaitGroup
+	for i, name := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			podResult := PodDeleteResult{Name: name, Deleted: true}
+			if err := rm.kubeClient.CoreV1().Pods(req.Namespace).Delete(ctx, name, deleteOptions); err != nil {
+				podResult.Deleted = false
+				podResult.Error = err.Error()
+			}
+			result.Results[i] = podResult
+		}(i, name)
+	}
+	wg.Wait()
+
+	for _, podResult := range result.Results {
+		if podResult.Deleted {
+			result.Deleted = append(result.Deleted, podResult.Name)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func labeledPod(namespace, name string, labels map[string]string) *v1.Pod {
+	return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}}
+}
+
+func newSelectorDeleteFixture(pods ...*v1.Pod) (*ResourceManager, *kubefake.Clientset) {
+	objects := make([]runtime.Object, 0, len(pods))
+	for _, pod := range pods {
+		objects = append(objects, pod)
+	}
+	kubeClient := kubefake.NewSimpleClientset(objects...)
+	return NewResourceManager(zap.NewNop(), kubeClient, nil), kubeClient
+}
+
+func remainingPods(t *testing.T, kubeClient *kubefake.Clientset, namespace string) []string {
+	t.Helper()
+	list, err := kubeClient.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	names := make([]string, 0, len(list.Items))
+	for _, pod := range list.Items {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestDeletePodsBySelector(t *testing.T) {
+	pods := []*v1.Pod{
+		labeledPod("jobs", "import-1", map[string]string{"app": "import"}),
+		labeledPod("jobs", "import-2", map[string]string{"app": "import"}),
+		labeledPod("jobs", "export-1", map[string]string{"app": "export"}),
+		labeledPod("other", "import-3", map[string]string{"app": "import"}),
+	}
+	rm, kubeClient := newSelectorDeleteFixture(pods...)
+
+	result, err := rm.DeletePodsBySelector(context.Background(), pods, PodSelectorDeleteRequest{Namespace: "jobs", LabelSelector: "app=import"}, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"import-1", "import-2"}, result.Targeted)
+	assert.Equal(t, []string{"import-1", "import-2"}, result.Deleted)
+	assert.Equal(t, []string{"export-1"}, remainingPods(t, kubeClient, "jobs"))
+	assert.Equal(t, []string{"import-3"}, remainingPods(t, kubeClient, "other"))
+}
+
+func TestDeletePodsBySelectorDryRun(t *testing.T) {
+	pods := []*v1.Pod{labeledPod("jobs", "import-1", map[string]string{"app": "import"})}
+	rm, kubeClient := newSelectorDeleteFixture(pods...)
+
+	result, err := rm.DeletePodsBySelector(context.Background(), pods, PodSelectorDeleteRequest{Namespace: "jobs", LabelSelector: "app=import", DryRun: true}, 10)
+	require.NoError(t, err)
+
+	assert.True(t, result.DryRun)
+	assert.Equal(t, []string{"import-1"}, result.Targeted)
+	assert.Empty(t, result.Deleted)
+	assert.Equal(t, []string{"import-1"}, remainingPods(t, kubeClient, "jobs"))
+}
+
+func TestDeletePodsBySelectorEnforcesCap(t *testing.T) {
+	var pods []*v1.Pod
+	for i := 0; i < 3; i++ {
+		pods = append(pods, labeledPod("jobs", fmt.Sprintf("worker-%d", i), map[string]string{"app": "worker"}))
+	}
+	rm, kubeClient := newSelectorDeleteFixture(pods...)
+
+	_, err := rm.DeletePodsBySelector(context.Background(), pods, PodSelectorDeleteRequest{Namespace: "jobs", LabelSelector: "app=worker"}, 2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "matches 3 pods, exceeding the limit of 2")
+	assert.Len(t, remainingPods(t, kubeClient, "jobs"), 3)
+}
+
+func TestDeletePodsBySelectorReportsPerPodFailures(t *testing.T) {
+	pods := []*v1.Pod{
+		labeledPod("jobs", "import-1", map[string]string{"app": "import"}),
+		labeledPod("jobs", "import-gone", map[string]string{"app": "import"}),
+	}
+	// import-gone is in the informer snapshot but already removed from the cluster
+	rm, _ := newSelectorDeleteFixture(pods[0])
+
+	result, err := rm.DeletePodsBySelector(context.Background(), pods, PodSelectorDeleteRequest{Namespace: "jobs", LabelSelector: "app=import"}, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"import-1"}, result.Deleted)
+	require.Len(t, result.Results, 2)
+	assert.False(t, result.Results[1].Deleted)
+	assert.NotEmpty(t, result.Results[1].Error)
+}
+
+func TestDeletePodsBySelectorRejectsUnsafeSelectors(t *testing.T) {
+	rm, _ := newSelectorDeleteFixture()
+
+	_, err := rm.DeletePodsBySelector(context.Background(), nil, PodSelectorDeleteRequest{Namespace: "jobs"}, 10)
+	assert.ErrorContains(t, err, "labelSelector is required")
+
+	_, err = rm.DeletePodsBySelector(context.Background(), nil, PodSelectorDeleteRequest{LabelSelector: "app=import"}, 10)
+	assert.ErrorContains(t, err, "namespace is required")
+
+	_, err = rm.DeletePodsBySelector(context.Background(), nil, PodSelectorDeleteRequest{Namespace: "jobs", LabelSelector: "app in (("}, 10)
+	assert.ErrorContains(t, err, "invalid label selector")
+}
@@ -0,0 +1,158 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Pod Security Admission namespace label prefix
+const podSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+// Pod Security Admission modes
+const (
+	PodSecurityModeEnforce = "enforce"
+	PodSecurityModeAudit   = "audit"
+	PodSecurityModeWarn    = "warn"
+)
+
+// podSecurityModes lists the admission modes in display order
+var podSecurityModes = []string{PodSecurityModeEnforce, PodSecurityModeAudit, PodSecurityModeWarn}
+
+// podSecurityLevels are the Pod Security Standards accepted by the admission controller
+var podSecurityLevels = map[string]bool{
+	"privileged": true,
+	"baseline":   true,
+	"restricted": true,
+}
+
+// podSecurityVersionPattern matches "latest" or a Kubernetes minor version such as v1.29
+var podSecurityVersionPattern = regexp.MustCompile(`^(latest|v1\.(0|[1-9][0-9]*))$`)
+
+// PodSecuritySetting is the level and version configured for one admission mode
+type PodSecuritySetting struct {
+	Level   string `json:"level,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// PodSecurityLabels holds the Pod Security Admission settings of a namespace.
+// A nil mode is unset on the namespace (or left untouched when updating).
+type PodSecurityLabels struct {
+	Enforce *PodSecuritySetting `json:"enforce"`
+	Audit   *PodSecuritySetting `json:"audit"`
+	Warn    *PodSecuritySetting `json:"warn"`
+}
+
+// PodSecurityLevelLabel returns the namespace label holding a mode's level
+func PodSecurityLevelLabel(mode string) string {
+	return podSecurityLabelPrefix + mode
+}
+
+// PodSecurityVersionLabel returns the namespace label holding a mode's version
+func PodSecurityVersionLabel(mode string) string {
+	return podSecurityLabelPrefix + mode + "-version"
+}
+
+// ParsePodSecurityLabels reads the Pod Security Admission settings from
+// namespace labels
+func ParsePodSecurityLabels(labels map[string]string) PodSecurityLabels {
+	var result PodSecurityLabels
+	for _, mode := range podSecurityModes {
+		level, hasLevel := labels[PodSecurityLevelLabel(mode)]
+		version, hasVersion := labels[PodSecurityVersionLabel(mode)]
+		if !hasLevel && !hasVersion {
+			continue
+		}
+		*result.mode(mode) = &PodSecuritySetting{Level: level, Version: version}
+	}
+	return result
+}
+
+// Validate checks that every set mode uses a known level and a valid version
+func (p PodSecurityLabels) Validate() error {
+	for _, mode := range podSecurityModes {
+		setting := *p.mode(mode)
+		if setting == nil {
+			continue
+		}
+		if setting.Level == "" {
+			if setting.Version != "" {
+				return fmt.Errorf("%s: version requires a level", mode)
+			}
+			continue
+		}
+		if !podSecurityLevels[setting.Level] {
+			return fmt.Errorf("%s: invalid level %q (allowed: privileged, baseline, restricted)", mode, setting.Level)
+		}
+		if setting.Version != "" && !podSecurityVersionPattern.MatchString(setting.Version) {
+			return fmt.Errorf("%s: invalid version %q (expected \"latest\" or v1.<minor>)", mode, setting.Version)
+		}
+	}
+	return nil
+}
+
+func (p *PodSecurityLabels) mode(mode string) **PodSecuritySetting {
+	switch mode {
+	case PodSecurityModeEnforce:
+		return &p.Enforce
+	case PodSecurityModeAudit:
+		return &p.Audit
+	default:
+		return &p.Warn
+	}
+}
+
+// SetNamespacePodSecurity updates the Pod Security Admission labels of a
+// namespace with a merge patch. Modes left nil are untouched; a mode with an
+// empty level has its labels removed, and an empty version removes only the
+// version label.
+func (rm *ResourceManager) SetNamespacePodSecurity(ctx context.Context, name string, settings PodSecurityLabels) (PodSecurityLabels, error) {
+	if err := settings.Validate(); err != nil {
+		return PodSecurityLabels{}, err
+	}
+
+	rm.logger.Info("Updating namespace pod security labels", zap.String("name", name))
+
+	labels := make(map[string]interface{})
+	for _, mode := range podSecurityModes {
+		setting := *settings.mode(mode)
+		if setting == nil {
+			continue
+		}
+		if setting.Level == "" {
+			labels[PodSecurityLevelLabel(mode)] = nil
+			labels[PodSecurityVersionLabel(mode)] = nil
+			continue
+		}
+		labels[PodSecurityLevelLabel(mode)] = setting.Level
+		if setting.Version == "" {
+			labels[PodSecurityVersionLabel(mode)] = nil
+		} else {
+			labels[PodSecurityVersionLabel(mode)] = setting.Version
+		}
+	}
+
+	metadata := map[string]interface{}{"labels": labels}
+	stamp := &metav1.ObjectMeta{}
+	rm.stampModification(ctx, stamp)
+	if len(stamp.Annotations) > 0 {
+		metadata["annotations"] = stamp.Annotations
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		return PodSecurityLabels{}, fmt.Errorf("failed to build namespace patch: %w", err)
+	}
+
+	updated, err := rm.kubeClient.CoreV1().Namespaces().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return PodSecurityLabels{}, fmt.Errorf("failed to patch namespace: %w", err)
+	}
+
+	return ParsePodSecurityLabels(updated.Labels), nil
+}
@@ -0,0 +1,100 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParsePodSecurityLabels(t *testing.T) {
+	settings := ParsePodSecurityLabels(map[string]string{
+		"pod-security.kubernetes.io/enforce":         "baseline",
+		"pod-security.kubernetes.io/enforce-version": "v1.29",
+		"pod-security.kubernetes.io/warn":            "restricted",
+		"team":                                       "payments",
+	})
+
+	assert.Equal(t, &PodSecuritySetting{Level: "baseline", Version: "v1.29"}, settings.Enforce)
+	assert.Nil(t, settings.Audit)
+	assert.Equal(t, &PodSecuritySetting{Level: "restricted"}, settings.Warn)
+}
+
+func TestPodSecurityLabelsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings PodSecurityLabels
+		wantErr  string
+	}{
+		{name: "valid", settings: PodSecurityLabels{
+			Enforce: &PodSecuritySetting{Level: "restricted", Version: "latest"},
+			Audit:   &PodSecuritySetting{Level: "baseline", Version: "v1.30"},
+		}},
+		{name: "clear mode", settings: PodSecurityLabels{Warn: &PodSecuritySetting{}}},
+		{name: "unknown level", settings: PodSecurityLabels{Enforce: &PodSecuritySetting{Level: "strict"}}, wantErr: `enforce: invalid level "strict"`},
+		{name: "bad version", settings: PodSecurityLabels{Audit: &PodSecuritySetting{Level: "baseline", Version: "1.29"}}, wantErr: `audit: invalid version "1.29"`},
+		{name: "version without level", settings: PodSecurityLabels{Warn: &PodSecuritySetting{Version: "latest"}}, wantErr: "warn: version requires a level"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSetNamespacePodSecurity(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name: "payments",
+		Labels: map[string]string{
+			"team":                             "payments",
+			"pod-security.kubernetes.io/audit": "baseline",
+			"pod-security.kubernetes.io/audit-version": "v1.28",
+			"pod-security.kubernetes.io/warn":          "baseline",
+		},
+	}})
+	rm := NewResourceManager(zap.NewNop(), kubeClient, nil)
+
+	settings, err := rm.SetNamespacePodSecurity(context.Background(), "payments", PodSecurityLabels{
+		Enforce: &PodSecuritySetting{Level: "restricted", Version: "latest"},
+		Audit:   &PodSecuritySetting{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &PodSecuritySetting{Level: "restricted", Version: "latest"}, settings.Enforce)
+	assert.Nil(t, settings.Audit)
+	assert.Equal(t, &PodSecuritySetting{Level: "baseline"}, settings.Warn)
+
+	namespace, err := kubeClient.CoreV1().Namespaces().Get(context.Background(), "payments", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"team":                               "payments",
+		"pod-security.kubernetes.io/enforce": "restricted",
+		"pod-security.kubernetes.io/enforce-version": "latest",
+		"pod-security.kubernetes.io/warn":            "baseline",
+	}, namespace.Labels)
+}
+
+func TestSetNamespacePodSecurityRejectsInvalidLevel(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}})
+	rm := NewResourceManager(zap.NewNop(), kubeClient, nil)
+
+	_, err := rm.SetNamespacePodSecurity(context.Background(), "payments", PodSecurityLabels{
+		Enforce: &PodSecuritySetting{Level: "locked-down"},
+	})
+	require.Error(t, err)
+
+	namespace, err := kubeClient.CoreV1().Namespaces().Get(context.Background(), "payments", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, namespace.Labels)
+}
@@ -0,0 +1,216 @@
+package resources
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Annotations the deployment controller and kubectl use to track revisions
+const (
+	RevisionAnnotation    = "deployment.kubernetes.io/revision"
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// ErrRevisionNotFound is returned when a rollback targets a revision the
+// Deployment has no ReplicaSet for
+var ErrRevisionNotFound = stderrors.New("revision not found")
+
+// RolloutRevision is one entry of a Deployment's rollout history, backed by
+// the ReplicaSet that holds that revision's pod template
+type RolloutRevision struct {
+	Revision    int64     `json:"revision"`
+	ReplicaSet  string    `json:"replicaSet"`
+	ChangeCause string    `json:"changeCause,omitempty"`
+	Images      []string  `json:"images"`
+	Replicas    int32     `json:"replicas"`
+	Current     bool      `json:"current"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// RolloutUndoResult describes a rollback. Skipped is set when the Deployment
+// already runs the target template. Generation is the Deployment generation
+// after the rollback, for tracking it with rollout status.
+type RolloutUndoResult struct {
+	Namespace    string `json:"namespace"`
+	Name         string `json:"name"`
+	FromRevision int64  `json:"fromRevision"`
+	ToRevision   int64  `json:"toRevision"`
+	Skipped      bool   `json:"skipped"`
+	Generation   int64  `json:"generation"`
+}
+
+// RolloutHistory lists the revisions of a Deployment, oldest first, from the
+// ReplicaSets it controls
+func (rm *ResourceManager) RolloutHistory(ctx context.Context, namespace, name string) ([]RolloutRevision, error) {
+	deployment, err := rm.kubeClient.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+	replicaSets, err := rm.deploymentReplicaSets(ctx, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	current := revisionOf(deployment.Annotations)
+	history := make([]RolloutRevision, 0, len(replicaSets))
+	for _, replicaSet := range replicaSets {
+		revision := revisionOf(replicaSet.Annotations)
+		var replicas int32
+		if replicaSet.Spec.Replicas != nil {
+			replicas = *replicaSet.Spec.Replicas
+		}
+		images := make([]string, 0, len(replicaSet.Spec.Template.Spec.Containers))
+		for _, container := range replicaSet.Spec.Template.Spec.Containers {
+			images = append(images, container.Image)
+		}
+		history = append(history, RolloutRevision{
+			Revision:    revision,
+			ReplicaSet:  replicaSet.Name,
+			ChangeCause: replicaSet.Annotations[ChangeCauseAnnotation],
+			Images:      images,
+			Replicas:    replicas,
+			Current:     revision == current,
+			CreatedAt:   replicaSet.CreationTimestamp.Time,
+		})
+	}
+	return history, nil
+}
+
+// RolloutUndo rolls a Deployment back to the pod template of toRevision, or
+// of the revision before the current one when toRevision is 0, like kubectl
+// rollout undo. The deployment controller then records the rollback as a new
+// revision. ErrRevisionNotFound is returned, wrapped, when the revision does
+// not exist.
+func (rm *ResourceManager) RolloutUndo(ctx context.Context, namespace, name string, toRevision int64) (*RolloutUndoResult, error) {
+	if toRevision < 0 {
+		return nil, fmt.Errorf("revision must not be negative")
+	}
+
+	deployment, err := rm.kubeClient.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+	if deployment.Spec.Paused {
+		return nil, fmt.Errorf("cannot roll back paused deployment %s/%s; resume it first", namespace, name)
+	}
+	replicaSets, err := rm.deploymentReplicaSets(ctx, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	current := revisionOf(deployment.Annotations)
+	var target *appsv1.ReplicaSet
+	available := make([]string, 0, len(replicaSets))
+	for i := range replicaSets {
+		revision := revisionOf(replicaSets[i].Annotations)
+		available = append(available, strconv.FormatInt(revision, 10))
+		if toRevision == 0 {
+			// The newest revision older than the current one
+			if revision < current && (target == nil || revision > revisionOf(target.Annotations)) {
+				target = &replicaSets[i]
+			}
+		} else if revision == toRevision {
+			target = &replicaSets[i]
+		}
+	}
+	if target == nil {
+		if toRevision == 0 {
+			return nil, fmt.Errorf("%w: deployment %s/%s has no revision before %d", ErrRevisionNotFound, namespace, name, current)
+		}
+		return nil, fmt.Errorf("%w: deployment %s/%s has no revision %d (available: %s)", ErrRevisionNotFound, namespace, name, toRevision, strings.Join(available, ", "))
+	}
+
+	result := &RolloutUndoResult{
+		Namespace:    namespace,
+		Name:         name,
+		FromRevision: current,
+		ToRevision:   revisionOf(target.Annotations),
+		Generation:   deployment.Generation,
+	}
+
+	template := withoutPodTemplateHash(target.Spec.Template)
+	if equality.Semantic.DeepEqual(withoutPodTemplateHash(deployment.Spec.Template), template) {
+		result.Skipped = true
+		return result, nil
+	}
+
+	rm.logger.Info("Rolling back deployment",
+		zap.String("namespace", namespace),
+		zap.String("name", name),
+		zap.Int64("fromRevision", result.FromRevision),
+		zap.Int64("toRevision", result.ToRevision))
+
+	deployment.Spec.Template = template
+	if cause, ok := target.Annotations[ChangeCauseAnnotation]; ok {
+		if deployment.Annotations == nil {
+			deployment.Annotations = make(map[string]string)
+		}
+		deployment.Annotations[ChangeCauseAnnotation] = cause
+	}
+	rm.stampModification(ctx, deployment)
+	updated, err := rm.kubeClient.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll back deployment: %w", err)
+	}
+	result.Generation = updated.Generation
+	return result, nil
+}
+
+// deploymentReplicaSets lists the ReplicaSets a Deployment controls that
+// carry a revision, sorted by revision
+func (rm *ResourceManager) deploymentReplicaSets(ctx context.Context, deployment *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	listOptions := metav1.ListOptions{}
+	if deployment.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deployment selector: %w", err)
+		}
+		listOptions.LabelSelector = selector.String()
+	}
+	list, err := rm.kubeClient.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+
+	var owned []appsv1.ReplicaSet
+	for _, replicaSet := range list.Items {
+		controller := metav1.GetControllerOf(&replicaSet)
+		if controller == nil || controller.UID != deployment.UID || revisionOf(replicaSet.Annotations) == 0 {
+			continue
+		}
+		owned = append(owned, replicaSet)
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return revisionOf(owned[i].Annotations) < revisionOf(owned[j].Annotations)
+	})
+	return owned, nil
+}
+
+// revisionOf parses the revision annotation, returning 0 when it is missing
+// or malformed
+func revisionOf(annotations map[string]string) int64 {
+	revision, err := strconv.ParseInt(annotations[RevisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+// withoutPodTemplateHash returns a copy of a pod template without the
+// pod-template-hash label the deployment controller adds to ReplicaSets
+func withoutPodTemplateHash(template v1.PodTemplateSpec) v1.PodTemplateSpec {
+	copied := *template.DeepCopy()
+	delete(copied.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	return copied
+}
@@ -0,0 +1,147 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func webTemplate(image string) v1.PodTemplateSpec {
+	return v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "web", Image: image}}},
+	}
+}
+
+// webRevision returns a ReplicaSet holding one revision of the web Deployment
+func webRevision(revision int64, image, changeCause string) *appsv1.ReplicaSet {
+	controller := true
+	template := webTemplate(image)
+	template.Labels[appsv1.DefaultDeploymentUniqueLabelKey] = "hash-" + strconv.FormatInt(revision, 10)
+	annotations := map[string]string{RevisionAnnotation: strconv.FormatInt(revision, 10)}
+	if changeCause != "" {
+		annotations[ChangeCauseAnnotation] = changeCause
+	}
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-" + strconv.FormatInt(revision, 10),
+			Namespace:       "shop",
+			Labels:          map[string]string{"app": "web"},
+			Annotations:     annotations,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "uid-web", Controller: &controller}},
+		},
+		Spec: appsv1.ReplicaSetSpec{Template: template},
+	}
+}
+
+func newRolloutFixture(objects ...runtime.Object) (*ResourceManager, *kubefake.Clientset) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "shop",
+			UID:         "uid-web",
+			Generation:  3,
+			Annotations: map[string]string{RevisionAnnotation: "3"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: webTemplate("web:1.2"),
+		},
+	}
+	objects = append(objects,
+		deployment,
+		webRevision(1, "web:1.0", "kubectl create --filename=web.yaml"),
+		webRevision(2, "web:1.1", "image bumped to 1.1"),
+		webRevision(3, "web:1.2", ""),
+	)
+	kubeClient := kubefake.NewSimpleClientset(objects...)
+	return NewResourceManager(zap.NewNop(), kubeClient, nil), kubeClient
+}
+
+func TestRolloutHistory(t *testing.T) {
+	otherController := true
+	foreign := webRevision(9, "web:9", "")
+	foreign.Name = "web-copy"
+	foreign.OwnerReferences = []metav1.OwnerReference{{Kind: "Deployment", Name: "web-copy", UID: "uid-copy", Controller: &otherController}}
+
+	rm, _ := newRolloutFixture(foreign)
+
+	history, err := rm.RolloutHistory(context.Background(), "shop", "web")
+	require.NoError(t, err)
+	require.Len(t, history, 3, "ReplicaSets of other deployments are left out")
+
+	assert.Equal(t, int64(1), history[0].Revision)
+	assert.Equal(t, "kubectl create --filename=web.yaml", history[0].ChangeCause)
+	assert.Equal(t, []string{"web:1.0"}, history[0].Images)
+	assert.False(t, history[0].Current)
+	assert.Equal(t, "web-3", history[2].ReplicaSet)
+	assert.True(t, history[2].Current)
+
+	_, err = rm.RolloutHistory(context.Background(), "shop", "missing")
+	assert.Error(t, err)
+}
+
+func TestRolloutUndo(t *testing.T) {
+	rm, kubeClient := newRolloutFixture()
+	rm.SetMutationAnnotations(true)
+	ctx := WithActor(context.Background(), "alice@example.com")
+
+	// Without a revision the previous one is restored
+	result, err := rm.RolloutUndo(ctx, "shop", "web", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.FromRevision)
+	assert.Equal(t, int64(2), result.ToRevision)
+	assert.False(t, result.Skipped)
+
+	deployment, err := kubeClient.AppsV1().Deployments("shop").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "web:1.1", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, map[string]string{"app": "web"}, deployment.Spec.Template.Labels, "the pod-template-hash label is dropped")
+	assert.Equal(t, "image bumped to 1.1", deployment.Annotations[ChangeCauseAnnotation])
+	assert.Equal(t, "alice@example.com", deployment.Annotations[AnnotationLastModifiedBy])
+
+	// Rolling back to the template already running is a no-op
+	result, err = rm.RolloutUndo(ctx, "shop", "web", 2)
+	require.NoError(t, err)
+	assert.True(t, result.Skipped)
+
+	result, err = rm.RolloutUndo(ctx, "shop", "web", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.ToRevision)
+	deployment, err = kubeClient.AppsV1().Deployments("shop").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "web:1.0", deployment.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestRolloutUndoMissingRevision(t *testing.T) {
+	rm, _ := newRolloutFixture()
+
+	_, err := rm.RolloutUndo(context.Background(), "shop", "web", 7)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrRevisionNotFound))
+	assert.Contains(t, err.Error(), "has no revision 7 (available: 1, 2, 3)")
+
+	_, err = rm.RolloutUndo(context.Background(), "shop", "web", -1)
+	assert.Error(t, err)
+
+	// The first revision has nothing before it
+	rm, kubeClient := newRolloutFixture()
+	deployment, err := kubeClient.AppsV1().Deployments("shop").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	deployment.Annotations[RevisionAnnotation] = "1"
+	_, err = kubeClient.AppsV1().Deployments("shop").Update(context.Background(), deployment, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	_, err = rm.RolloutUndo(context.Background(), "shop", "web", 0)
+	assert.True(t, errors.Is(err, ErrRevisionNotFound))
+}
@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RestartedAtAnnotation is the pod template annotation kubectl rollout
+// restart sets; changing it rolls every pod of the workload
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// RolloutRestartResult identifies a restarted workload. Generation is the
+// workload generation after the restart; the restart has been picked up once
+// the controller's observed generation reaches it.
+type RolloutRestartResult struct {
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	RestartedAt string `json:"restartedAt"`
+	Generation  int64  `json:"generation"`
+}
+
+// NormalizeRolloutRestartKind maps a kind, its plural or its short name to
+// Deployment, StatefulSet or DaemonSet
+func NormalizeRolloutRestartKind(kind string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "deployment", "deployments", "deploy":
+		return "Deployment", nil
+	case "statefulset", "statefulsets", "sts":
+		return "StatefulSet", nil
+	case "daemonset", "daemonsets", "ds":
+		return "DaemonSet", nil
+	default:
+		return "", fmt.Errorf("unsupported resource kind for rollout restart: %s", kind)
+	}
+}
+
+// RolloutRestart triggers a rolling restart of a Deployment, StatefulSet or
+// DaemonSet, like kubectl rollout restart, by patching the pod template with
+// the current time in the restartedAt annotation
+func (rm *ResourceManager) RolloutRestart(ctx context.Context, namespace, name, kind string) (*RolloutRestartResult, error) {
+	kind, err := NormalizeRolloutRestartKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	restartedAt := rm.now().UTC().Format(time.RFC3339)
+	spec := map[string]interface{}{
+		"template": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]string{RestartedAtAnnotation: restartedAt},
+			},
+		},
+	}
+	body := map[string]interface{}{"spec": spec}
+	stamp := &metav1.ObjectMeta{}
+	rm.stampModification(ctx, stamp)
+	if len(stamp.Annotations) > 0 {
+		body["metadata"] = map[string]interface{}{"annotations": stamp.Annotations}
+	}
+
+	patch, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build restart patch: %w", err)
+	}
+
+	rm.logger.Info("Restarting rollout",
+		zap.String("namespace", namespace),
+		zap.String("name", name),
+		zap.String("kind", kind))
+
+	var generation int64
+	switch kind {
+	case "Deployment":
+		deployment, err := rm.kubeClient.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to restart deployment: %w", err)
+		}
+		generation = deployment.Generation
+	case "StatefulSet":
+		statefulSet, err := rm.kubeClient.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to restart statefulset: %w", err)
+		}
+		generation = statefulSet.Generation
+	case "DaemonSet":
+		daemonSet, err := rm.kubeClient.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to restart daemonset: %w", err)
+		}
+		generation = daemonSet.Generation
+	}
+
+	return &RolloutRestartResult{
+		Kind:        kind,
+		Namespace:   namespace,
+		Name:        name,
+		RestartedAt: restartedAt,
+		Generation:  generation,
+	}, nil
+}
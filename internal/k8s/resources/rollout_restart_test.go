@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRolloutRestart(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", Generation: 3},
+			Spec: appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"prometheus.io/scrape": "true"}},
+			}},
+		},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop", Generation: 7}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "shop"}},
+	)
+	rm := NewResourceManager(zap.NewNop(), kubeClient, nil)
+	rm.SetMutationAnnotations(true)
+	rm.now = func() time.Time { return time.Date(2024, 3, 1, 9, 30, 0, 0, time.FixedZone("EST", -5*3600)) }
+	ctx := WithActor(context.Background(), "alice@example.com")
+
+	result, err := rm.RolloutRestart(ctx, "shop", "web", "deployments")
+	require.NoError(t, err)
+	assert.Equal(t, &RolloutRestartResult{
+		Kind:        "Deployment",
+		Namespace:   "shop",
+		Name:        "web",
+		RestartedAt: "2024-03-01T14:30:00Z",
+		Generation:  3,
+	}, result)
+
+	deployment, err := kubeClient.AppsV1().Deployments("shop").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"prometheus.io/scrape": "true",
+		RestartedAtAnnotation:  "2024-03-01T14:30:00Z",
+	}, deployment.Spec.Template.Annotations)
+	assert.Equal(t, "alice@example.com", deployment.Annotations[AnnotationLastModifiedBy])
+
+	result, err = rm.RolloutRestart(ctx, "shop", "db", "StatefulSet")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), result.Generation)
+
+	_, err = rm.RolloutRestart(ctx, "shop", "agent", "ds")
+	require.NoError(t, err)
+	daemonSet, err := kubeClient.AppsV1().DaemonSets("shop").Get(context.Background(), "agent", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-01T14:30:00Z", daemonSet.Spec.Template.Annotations[RestartedAtAnnotation])
+}
+
+func TestRolloutRestartErrors(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), kubefake.NewSimpleClientset(), nil)
+
+	_, err := rm.RolloutRestart(context.Background(), "shop", "web", "ReplicaSet")
+	assert.EqualError(t, err, "unsupported resource kind for rollout restart: ReplicaSet")
+
+	_, err = rm.RolloutRestart(context.Background(), "shop", "missing", "Deployment")
+	require.Error(t, err)
+	assert.True(t, apierrors.IsNotFound(err))
+}
@@ -0,0 +1,101 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnotationScaleHistory holds the JSON log of scale actions made through Kaptn
+const AnnotationScaleHistory = "kaptn.io/scale-history"
+
+// MaxScaleHistoryLimit bounds the configured history length so the annotation
+// stays well below the API server's annotation size limit
+const MaxScaleHistoryLimit = 100
+
+// ScaleHistoryEntry records one scale action
+type ScaleHistoryEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	From      int32     `json:"from"`
+	To        int32     `json:"to"`
+	User      string    `json:"user"`
+}
+
+// SetScaleHistoryLimit enables the scale history annotation, keeping at most
+// limit entries. Zero or a negative limit disables it.
+func (rm *ResourceManager) SetScaleHistoryLimit(limit int) {
+	if limit > MaxScaleHistoryLimit {
+		limit = MaxScaleHistoryLimit
+	}
+	if limit < 0 {
+		limit = 0
+	}
+	rm.scaleHistoryLimit = limit
+}
+
+// ScaleHistory returns the scale actions recorded on obj, oldest first. A
+// missing or unreadable annotation yields an empty history.
+func ScaleHistory(obj metav1.Object) []ScaleHistoryEntry {
+	raw, ok := obj.GetAnnotations()[AnnotationScaleHistory]
+	if !ok || raw == "" {
+		return []ScaleHistoryEntry{}
+	}
+	var entries []ScaleHistoryEntry
+	if err := json.Unmarshal([]byte(raw), &entries); err != nil || entries == nil {
+		return []ScaleHistoryEntry{}
+	}
+	return entries
+}
+
+// AppendScaleHistory adds entry to the scale history annotation on obj,
+// dropping the oldest entries beyond limit. Other annotations are preserved.
+func AppendScaleHistory(obj metav1.Object, entry ScaleHistoryEntry, limit int) error {
+	if entry.User == "" {
+		entry.User = defaultActor
+	}
+	entry.Timestamp = entry.Timestamp.UTC()
+
+	entries := append(ScaleHistory(obj), entry)
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[AnnotationScaleHistory] = string(data)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// recordScale appends a scale action to obj's history when enabled. current
+// is the replica count before scaling; nil means the API default of 1.
+func (rm *ResourceManager) recordScale(ctx context.Context, obj metav1.Object, current *int32, replicas int32) {
+	if rm.scaleHistoryLimit <= 0 {
+		return
+	}
+	from := int32(1)
+	if current != nil {
+		from = *current
+	}
+	entry := ScaleHistoryEntry{
+		Timestamp: rm.now(),
+		From:      from,
+		To:        replicas,
+		User:      ActorFromContext(ctx),
+	}
+	if err := AppendScaleHistory(obj, entry, rm.scaleHistoryLimit); err != nil {
+		rm.logger.Warn("Failed to record scale history",
+			zap.String("namespace", obj.GetNamespace()),
+			zap.String("name", obj.GetName()),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestScaleResourceAppendsScaleHistory(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(annotatedDeployment(map[string]string{"team": "payments"}))
+	rm := NewResourceManager(zap.NewNop(), kubeClient, nil)
+	rm.SetScaleHistoryLimit(3)
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	step := 0
+	rm.now = func() time.Time {
+		step++
+		return start.Add(time.Duration(step) * time.Minute)
+	}
+
+	ctx := WithActor(context.Background(), "alice@example.com")
+	for _, replicas := range []int32{3, 5, 2, 4} {
+		require.NoError(t, rm.ScaleResource(ctx, ScaleRequest{Namespace: "default", Name: "web", Kind: "Deployment", Replicas: replicas}))
+	}
+
+	deployment, err := kubeClient.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "payments", deployment.Annotations["team"])
+
+	// The first action (1 -> 3) was dropped by the cap
+	history := ScaleHistory(deployment)
+	require.Len(t, history, 3)
+	assert.Equal(t, ScaleHistoryEntry{Timestamp: start.Add(2 * time.Minute), From: 3, To: 5, User: "alice@example.com"}, history[0])
+	assert.Equal(t, int32(5), history[1].From)
+	assert.Equal(t, int32(2), history[1].To)
+	assert.Equal(t, int32(2), history[2].From)
+	assert.Equal(t, int32(4), history[2].To)
+}
+
+func TestScaleHistoryDisabledByDefault(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(annotatedDeployment(nil))
+	rm := NewResourceManager(zap.NewNop(), kubeClient, nil)
+
+	require.NoError(t, rm.ScaleResource(context.Background(), ScaleRequest{Namespace: "default", Name: "web", Kind: "Deployment", Replicas: 2}))
+
+	deployment, err := kubeClient.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, deployment.Annotations, AnnotationScaleHistory)
+	assert.Empty(t, ScaleHistory(deployment))
+}
+
+func TestScaleHistoryToleratesMalformedAnnotation(t *testing.T) {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{AnnotationScaleHistory: "not json"},
+	}}
+	assert.Empty(t, ScaleHistory(deployment))
+
+	require.NoError(t, AppendScaleHistory(deployment, ScaleHistoryEntry{From: 1, To: 2}, 5))
+	history := ScaleHistory(deployment)
+	require.Len(t, history, 1)
+	assert.Equal(t, defaultActor, history[0].User)
+}
+
+func TestSetScaleHistoryLimitBounds(t *testing.T) {
+	rm := NewResourceManager(zap.NewNop(), kubefake.NewSimpleClientset(), nil)
+	rm.SetScaleHistoryLimit(1000)
+	assert.Equal(t, MaxScaleHistoryLimit, rm.scaleHistoryLimit)
+	rm.SetScaleHistoryLimit(-1)
+	assert.Equal(t, 0, rm.scaleHistoryLimit)
+}
@@ -1,9 +1,11 @@
 package resources
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
@@ -19,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
 )
 
 // ResourceManager provides advanced resource management operations
@@ -26,8 +29,25 @@ type ResourceManager struct {
 	logger        *zap.Logger
 	kubeClient    kubernetes.Interface
 	dynamicClient dynamic.Interface
+
+	// annotateMutations stamps mutated objects with kaptn.io/last-modified-* annotations
+	annotateMutations bool
+	now               func() time.Time
+
+	// scaleHistoryLimit caps the kaptn.io/scale-history log; 0 disables it
+	scaleHistoryLimit int
+
+	// apiResources caches discovery results for APIResourceCatalog
+	apiResources apiResourceCatalogCache
+
+	// getNode reads nodes for exports, such as from an informer cache; nil
+	// reads them from the API server
+	getNode NodeGetter
 }
 
+// NodeGetter looks up a node by name
+type NodeGetter func(ctx context.Context, name string) (*v1.Node, error)
+
 // ScaleRequest represents a request to scale a resource
 type ScaleRequest struct {
 	Namespace string `json:"namespace"`
@@ -51,6 +71,13 @@ type NamespaceRequest struct {
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+// ResourceRef identifies a single resource for bulk operations
+type ResourceRef struct {
+	Namespace string `json:"namespace,omitempty"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+}
+
 // ResourceExport represents an exported resource
 type ResourceExport struct {
 	APIVersion string      `json:"apiVersion"`
@@ -65,7 +92,23 @@ func NewResourceManager(logger *zap.Logger, kubeClient kubernetes.Interface, dyn
 		logger:        logger,
 		kubeClient:    kubeClient,
 		dynamicClient: dynamicClient,
+		now:           time.Now,
+	}
+}
+
+// SetNodeGetter sets where read-only node lookups, such as exports, get
+// nodes from. Lookups that modify a node always read it from the API server.
+func (rm *ResourceManager) SetNodeGetter(getNode NodeGetter) {
+	rm.getNode = getNode
+}
+
+// lookupNode reads a node through the node getter, or from the API server
+// when none is set
+func (rm *ResourceManager) lookupNode(ctx context.Context, name string) (*v1.Node, error) {
+	if rm.getNode != nil {
+		return rm.getNode(ctx, name)
 	}
+	return rm.kubeClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
 }
 
 // ScaleResource scales a deployment, replicaset, or statefulset
@@ -95,7 +138,9 @@ func (rm *ResourceManager) scaleDeployment(ctx context.Context, namespace, name
 		return fmt.Errorf("failed to get deployment: %w", err)
 	}
 
+	rm.recordScale(ctx, deployment, deployment.Spec.Replicas, replicas)
 	deployment.Spec.Replicas = &replicas
+	rm.stampModification(ctx, deployment)
 	_, err = rm.kubeClient.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to scale deployment: %w", err)
@@ -111,7 +156,9 @@ func (rm *ResourceManager) scaleReplicaSet(ctx context.Context, namespace, name
 		return fmt.Errorf("failed to get replicaset: %w", err)
 	}
 
+	rm.recordScale(ctx, replicaSet, replicaSet.Spec.Replicas, replicas)
 	replicaSet.Spec.Replicas = &replicas
+	rm.stampModification(ctx, replicaSet)
 	_, err = rm.kubeClient.AppsV1().ReplicaSets(namespace).Update(ctx, replicaSet, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to scale replicaset: %w", err)
@@ -127,7 +174,9 @@ func (rm *ResourceManager) scaleStatefulSet(ctx context.Context, namespace, name
 		return fmt.Errorf("failed to get statefulset: %w", err)
 	}
 
+	rm.recordScale(ctx, statefulSet, statefulSet.Spec.Replicas, replicas)
 	statefulSet.Spec.Replicas = &replicas
+	rm.stampModification(ctx, statefulSet)
 	_, err = rm.kubeClient.AppsV1().StatefulSets(namespace).Update(ctx, statefulSet, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to scale statefulset: %w", err)
@@ -450,7 +499,7 @@ func (rm *ResourceManager) ExportResource(ctx context.Context, namespace, name,
 		}
 		obj = rm.stripManagedFields(unstructuredCSI)
 	case "Node":
-		node, err := rm.kubeClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		node, err := rm.lookupNode(ctx, name)
 		if err != nil {
 			return nil, err
 		}
@@ -533,6 +582,11 @@ func (rm *ResourceManager) ExportResource(ctx context.Context, namespace, name,
 		return nil, fmt.Errorf("unsupported resource kind for export: %s", kind)
 	}
 
+	return newResourceExport(obj, kind), nil
+}
+
+// newResourceExport builds the export document for a stripped object
+func newResourceExport(obj *unstructured.Unstructured, kind string) *ResourceExport {
 	export := &ResourceExport{
 		APIVersion: obj.GetAPIVersion(),
 		Kind:       obj.GetKind(),
@@ -572,7 +626,57 @@ func (rm *ResourceManager) ExportResource(ctx context.Context, namespace, name,
 		}
 	}
 
-	return export, nil
+	return export
+}
+
+// ExportResources exports each referenced resource and joins them into a single
+// multi-document YAML stream. Documents are emitted in the order of refs; a ref
+// that cannot be exported is recorded as a comment in its place.
+func (rm *ResourceManager) ExportResources(ctx context.Context, refs []ResourceRef) ([]byte, error) {
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("at least one resource reference is required")
+	}
+
+	var buf bytes.Buffer
+	exported := 0
+
+	for i, ref := range refs {
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+
+		export, err := rm.ExportResource(ctx, ref.Namespace, ref.Name, ref.Kind)
+		if err == nil {
+			var data []byte
+			data, err = yaml.Marshal(export)
+			if err == nil {
+				buf.Write(data)
+				exported++
+				continue
+			}
+		}
+
+		rm.logger.Warn("Failed to export resource",
+			zap.String("namespace", ref.Namespace),
+			zap.String("kind", ref.Kind),
+			zap.String("name", ref.Name),
+			zap.Error(err))
+		fmt.Fprintf(&buf, "# Failed to export %s %s: %s\n", ref.Kind, refDisplayName(ref), strings.ReplaceAll(err.Error(), "\n", " "))
+	}
+
+	if exported == 0 {
+		return nil, fmt.Errorf("none of the %d requested resources could be exported", len(refs))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// refDisplayName returns namespace/name for namespaced refs and name otherwise
+func refDisplayName(ref ResourceRef) string {
+	if ref.Namespace == "" {
+		return ref.Name
+	}
+	return ref.Namespace + "/" + ref.Name
 }
 
 // ListServices lists all services in a namespace
@@ -683,6 +787,47 @@ func (rm *ResourceManager) ListEvents(ctx context.Context, namespace string) ([]
 	return events.Items, nil
 }
 
+// EventPage is one page of events listed with a continue token
+type EventPage struct {
+	Items []v1.Event
+	// Continue requests the next page; it is empty on the last page
+	Continue string
+	// RemainingItemCount estimates the events after this page, when the API
+	// server reports it
+	RemainingItemCount *int64
+}
+
+// ListEventsPaged lists at most limit events in a namespace or across all
+// namespaces, starting where the page that returned continueToken ended. A
+// limit of 0 lists all remaining events. Tokens expire after a few minutes,
+// in which case the API server answers 410 Gone.
+func (rm *ResourceManager) ListEventsPaged(ctx context.Context, namespace string, limit int64, continueToken string) (*EventPage, error) {
+	return rm.ListEventsMatching(ctx, namespace, "", limit, continueToken)
+}
+
+// ListEventsMatching lists events like ListEventsPaged, restricted by the API
+// server to those matching fieldSelector. Events support selecting on type,
+// reason, source and the involvedObject fields.
+func (rm *ResourceManager) ListEventsMatching(ctx context.Context, namespace, fieldSelector string, limit int64, continueToken string) (*EventPage, error) {
+	events, err := rm.kubeClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fieldSelector,
+		Limit:         limit,
+		Continue:      continueToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	page := &EventPage{
+		Items:              events.Items,
+		Continue:           events.Continue,
+		RemainingItemCount: events.RemainingItemCount,
+	}
+	if page.Items == nil {
+		page.Items = []v1.Event{}
+	}
+	return page, nil
+}
+
 // ListEndpointSlices lists all endpoint slices in a namespace or across all namespaces
 func (rm *ResourceManager) ListEndpointSlices(ctx context.Context, namespace string) ([]interface{}, error) {
 	// Use dynamic client to get EndpointSlices from discovery.k8s.io/v1
@@ -980,10 +1125,28 @@ func (rm *ResourceManager) getIstioVirtualService(ctx context.Context, namespace
 	return virtualServiceObj, nil
 }
 
-// GetPodLogs retrieves logs for a pod
-func (rm *ResourceManager) GetPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines *int64) (string, error) {
+// GetPod retrieves a specific pod
+func (rm *ResourceManager) GetPod(ctx context.Context, namespace, name string) (*v1.Pod, error) {
+	pod, err := rm.kubeClient.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s in namespace %s: %w", name, namespace, err)
+	}
+	return pod, nil
+}
+
+// GetPodLogs retrieves logs for a pod. With previous, the logs of the
+// container's last terminated instance are returned; ErrNoPreviousLogs is
+// returned, wrapped, when the container has never restarted.
+func (rm *ResourceManager) GetPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines *int64, previous bool) (string, error) {
+	if previous {
+		if err := rm.checkPreviousLogs(ctx, namespace, podName, containerName); err != nil {
+			return "", err
+		}
+	}
+
 	logOptions := &v1.PodLogOptions{
 		Container: containerName,
+		Previous:  previous,
 	}
 
 	if tailLines != nil {
@@ -1464,6 +1627,27 @@ func (rm *ResourceManager) DeleteResourceQuota(ctx context.Context, namespace, n
 	return nil
 }
 
+// ListLimitRanges lists limit ranges in a namespace or all namespaces
+func (rm *ResourceManager) ListLimitRanges(ctx context.Context, namespace string) ([]v1.LimitRange, error) {
+	limitRanges, err := rm.kubeClient.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list limit ranges: %w", err)
+	}
+	if limitRanges.Items == nil {
+		return []v1.LimitRange{}, nil
+	}
+	return limitRanges.Items, nil
+}
+
+// GetLimitRange gets a specific limit range
+func (rm *ResourceManager) GetLimitRange(ctx context.Context, namespace, name string) (*v1.LimitRange, error) {
+	limitRange, err := rm.kubeClient.CoreV1().LimitRanges(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get limit range %s in namespace %s: %w", name, namespace, err)
+	}
+	return limitRange, nil
+}
+
 // APIResource represents a Kubernetes API resource
 type APIResource struct {
 	ID           int      `json:"id"`
@@ -1564,12 +1748,12 @@ func (rm *ResourceManager) ListAPIResources(ctx context.Context) ([]APIResource,
 
 // GetAPIResource gets a specific API resource by name and group
 func (rm *ResourceManager) GetAPIResource(ctx context.Context, name, group string) (*APIResource, error) {
-	allResources, err := rm.ListAPIResources(ctx)
+	catalog, err := rm.APIResourceCatalog(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, resource := range allResources {
+	for _, resource := range catalog.Resources {
 		if resource.Name == name && resource.Group == group {
 			return &resource, nil
 		}
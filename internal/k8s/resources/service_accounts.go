@@ -0,0 +1,177 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// serviceAccountsGroup holds every service account, and the group named
+	// by serviceAccountsGroupPrefix plus a namespace those of that namespace
+	serviceAccountsGroup       = "system:serviceaccounts"
+	serviceAccountsGroupPrefix = "system:serviceaccounts:"
+	// serviceAccountNameAnnotation ties a token Secret to its account
+	serviceAccountNameAnnotation = "kubernetes.io/service-account.name"
+)
+
+// ServiceAccountSecret is a Secret referenced by a service account. Found is
+// false when the account names a Secret that does not exist.
+type ServiceAccountSecret struct {
+	Name  string        `json:"name"`
+	Type  v1.SecretType `json:"type,omitempty"`
+	Found bool          `json:"found"`
+}
+
+// ServiceAccountBinding is a RoleBinding or ClusterRoleBinding granting a
+// role to a service account. Subject names the binding subject that matched:
+// the account itself or one of the system:serviceaccounts groups.
+type ServiceAccountBinding struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	RoleKind  string `json:"roleKind"`
+	RoleName  string `json:"roleName"`
+	Subject   string `json:"subject"`
+}
+
+// ServiceAccountDetails lists the Secrets and bindings of a service account
+type ServiceAccountDetails struct {
+	Secrets          []ServiceAccountSecret  `json:"secrets"`
+	ImagePullSecrets []ServiceAccountSecret  `json:"imagePullSecrets"`
+	Bindings         []ServiceAccountBinding `json:"bindings"`
+}
+
+// ListServiceAccounts lists service accounts in a namespace or all namespaces
+func (rm *ResourceManager) ListServiceAccounts(ctx context.Context, namespace string) ([]v1.ServiceAccount, error) {
+	serviceAccounts, err := rm.kubeClient.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	if serviceAccounts.Items == nil {
+		return []v1.ServiceAccount{}, nil
+	}
+	return serviceAccounts.Items, nil
+}
+
+// GetServiceAccount gets a specific service account
+func (rm *ResourceManager) GetServiceAccount(ctx context.Context, namespace, name string) (*v1.ServiceAccount, error) {
+	serviceAccount, err := rm.kubeClient.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service account %s in namespace %s: %w", name, namespace, err)
+	}
+	return serviceAccount, nil
+}
+
+// ResolveServiceAccount resolves the Secrets a service account references and
+// finds the bindings granting it roles. Token Secrets are those listed on the
+// account plus any kubernetes.io/service-account-token Secret annotated with
+// its name, since clusters since 1.24 no longer populate the secrets field.
+func (rm *ResourceManager) ResolveServiceAccount(ctx context.Context, sa *v1.ServiceAccount) (*ServiceAccountDetails, error) {
+	secretList, err := rm.kubeClient.CoreV1().Secrets(sa.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets in namespace %s: %w", sa.Namespace, err)
+	}
+	secrets := make(map[string]*v1.Secret, len(secretList.Items))
+	for i := range secretList.Items {
+		secrets[secretList.Items[i].Name] = &secretList.Items[i]
+	}
+	resolve := func(name string) ServiceAccountSecret {
+		if secret, ok := secrets[name]; ok {
+			return ServiceAccountSecret{Name: name, Type: secret.Type, Found: true}
+		}
+		return ServiceAccountSecret{Name: name}
+	}
+
+	details := &ServiceAccountDetails{
+		Secrets:          []ServiceAccountSecret{},
+		ImagePullSecrets: []ServiceAccountSecret{},
+		Bindings:         []ServiceAccountBinding{},
+	}
+	listed := make(map[string]bool, len(sa.Secrets))
+	for _, ref := range sa.Secrets {
+		listed[ref.Name] = true
+		details.Secrets = append(details.Secrets, resolve(ref.Name))
+	}
+	for _, secret := range secretList.Items {
+		if secret.Type == v1.SecretTypeServiceAccountToken && !listed[secret.Name] &&
+			secret.Annotations[serviceAccountNameAnnotation] == sa.Name {
+			details.Secrets = append(details.Secrets, resolve(secret.Name))
+		}
+	}
+	for _, ref := range sa.ImagePullSecrets {
+		details.ImagePullSecrets = append(details.ImagePullSecrets, resolve(ref.Name))
+	}
+
+	// RoleBindings in any namespace may name an account of another namespace
+	roleBindings, err := rm.kubeClient.RbacV1().RoleBindings("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role bindings: %w", err)
+	}
+	for _, binding := range roleBindings.Items {
+		if subject, ok := matchServiceAccountSubject(binding.Subjects, binding.Namespace, sa); ok {
+			details.Bindings = append(details.Bindings, ServiceAccountBinding{
+				Kind:      "RoleBinding",
+				Name:      binding.Name,
+				Namespace: binding.Namespace,
+				RoleKind:  binding.RoleRef.Kind,
+				RoleName:  binding.RoleRef.Name,
+				Subject:   subject,
+			})
+		}
+	}
+	clusterRoleBindings, err := rm.kubeClient.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster role bindings: %w", err)
+	}
+	for _, binding := range clusterRoleBindings.Items {
+		if subject, ok := matchServiceAccountSubject(binding.Subjects, "", sa); ok {
+			details.Bindings = append(details.Bindings, ServiceAccountBinding{
+				Kind:     "ClusterRoleBinding",
+				Name:     binding.Name,
+				RoleKind: binding.RoleRef.Kind,
+				RoleName: binding.RoleRef.Name,
+				Subject:  subject,
+			})
+		}
+	}
+
+	sort.SliceStable(details.Bindings, func(i, j int) bool {
+		a, b := details.Bindings[i], details.Bindings[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return details, nil
+}
+
+// matchServiceAccountSubject returns the first subject granting a binding to
+// the service account, either by name or through its groups. A ServiceAccount
+// subject without a namespace defaults to the binding's namespace.
+func matchServiceAccountSubject(subjects []rbacv1.Subject, bindingNamespace string, sa *v1.ServiceAccount) (string, bool) {
+	for _, subject := range subjects {
+		switch subject.Kind {
+		case rbacv1.ServiceAccountKind:
+			namespace := subject.Namespace
+			if namespace == "" {
+				namespace = bindingNamespace
+			}
+			if subject.Name == sa.Name && namespace == sa.Namespace {
+				return "ServiceAccount/" + sa.Name, true
+			}
+		case rbacv1.GroupKind:
+			if subject.Name == serviceAccountsGroup || subject.Name == serviceAccountsGroupPrefix+sa.Namespace {
+				return "Group/" + subject.Name, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,78 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolveServiceAccount(t *testing.T) {
+	sa := &v1.ServiceAccount{
+		ObjectMeta:       metav1.ObjectMeta{Name: "builder", Namespace: "ci"},
+		Secrets:          []v1.ObjectReference{{Name: "builder-token-old"}},
+		ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry"}, {Name: "gone"}},
+	}
+	client := kubefake.NewSimpleClientset(
+		sa,
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "builder-token-old", Namespace: "ci"}, Type: v1.SecretTypeServiceAccountToken},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "builder-token", Namespace: "ci", Annotations: map[string]string{"kubernetes.io/service-account.name": "builder"}},
+			Type:       v1.SecretTypeServiceAccountToken,
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-token", Namespace: "ci", Annotations: map[string]string{"kubernetes.io/service-account.name": "other"}},
+			Type:       v1.SecretTypeServiceAccountToken,
+		},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "ci"}, Type: v1.SecretTypeDockerConfigJson},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "prod"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "builder", Namespace: "ci"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "ci"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "builder"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "prod"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "builder"}},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "discovery"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:discovery"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:ci"}},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "builder"}},
+		},
+	)
+	rm := NewResourceManager(zap.NewNop(), client, nil)
+
+	details, err := rm.ResolveServiceAccount(context.Background(), sa)
+	require.NoError(t, err)
+
+	assert.Equal(t, []ServiceAccountSecret{
+		{Name: "builder-token-old", Type: v1.SecretTypeServiceAccountToken, Found: true},
+		{Name: "builder-token", Type: v1.SecretTypeServiceAccountToken, Found: true},
+	}, details.Secrets)
+	assert.Equal(t, []ServiceAccountSecret{
+		{Name: "registry", Type: v1.SecretTypeDockerConfigJson, Found: true},
+		{Name: "gone"},
+	}, details.ImagePullSecrets)
+	assert.Equal(t, []ServiceAccountBinding{
+		{Kind: "ClusterRoleBinding", Name: "discovery", RoleKind: "ClusterRole", RoleName: "system:discovery", Subject: "Group/system:serviceaccounts:ci"},
+		{Kind: "RoleBinding", Name: "local", Namespace: "ci", RoleKind: "Role", RoleName: "reader", Subject: "ServiceAccount/builder"},
+		{Kind: "RoleBinding", Name: "deploy", Namespace: "prod", RoleKind: "ClusterRole", RoleName: "edit", Subject: "ServiceAccount/builder"},
+	}, details.Bindings)
+}
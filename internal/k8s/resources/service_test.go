@@ -2,15 +2,19 @@ package resources
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
 )
 
 func TestListIngresses(t *testing.T) {
@@ -183,3 +187,108 @@ func TestFetchIstioGateways(t *testing.T) {
 		t.Errorf("Expected resource type annotation 'istio-gateway', got '%v'", resourceType)
 	}
 }
+
+func TestExportResourcesMultiDoc(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "team-a"},
+			Data:       map[string]string{"key": "value"},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeClusterIP},
+		},
+	)
+	rm := NewResourceManager(zap.NewNop(), kubeClient, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	refs := []ResourceRef{
+		{Namespace: "team-a", Kind: "Service", Name: "app"},
+		{Namespace: "team-a", Kind: "ConfigMap", Name: "app-config"},
+	}
+
+	data, err := rm.ExportResources(context.Background(), refs)
+	if err != nil {
+		t.Fatalf("ExportResources returned error: %v", err)
+	}
+
+	docs := strings.Split(string(data), "---\n")
+	if len(docs) != 2 {
+		t.Fatalf("Expected 2 YAML documents, got %d:\n%s", len(docs), data)
+	}
+
+	expectedKinds := []string{"Service", "ConfigMap"}
+	for i, doc := range docs {
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			t.Fatalf("Document %d is not valid YAML: %v", i, err)
+		}
+		if obj["kind"] != expectedKinds[i] {
+			t.Errorf("Document %d: expected kind %s, got %v", i, expectedKinds[i], obj["kind"])
+		}
+		metadata, ok := obj["metadata"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("Document %d has no metadata", i)
+		}
+		if _, exists := metadata["resourceVersion"]; exists {
+			t.Errorf("Document %d: expected resourceVersion to be stripped", i)
+		}
+	}
+}
+
+func TestExportResourcesPartialFailure(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "team-a"},
+	})
+	rm := NewResourceManager(zap.NewNop(), kubeClient, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	data, err := rm.ExportResources(context.Background(), []ResourceRef{
+		{Namespace: "team-a", Kind: "ConfigMap", Name: "missing"},
+		{Namespace: "team-a", Kind: "ConfigMap", Name: "app-config"},
+	})
+	if err != nil {
+		t.Fatalf("ExportResources returned error: %v", err)
+	}
+
+	docs := strings.Split(string(data), "---\n")
+	if len(docs) != 2 {
+		t.Fatalf("Expected 2 YAML documents, got %d", len(docs))
+	}
+	if !strings.HasPrefix(docs[0], "# Failed to export ConfigMap team-a/missing") {
+		t.Errorf("Expected failure marker for missing resource, got %q", docs[0])
+	}
+
+	if _, err := rm.ExportResources(context.Background(), []ResourceRef{{Namespace: "team-a", Kind: "ConfigMap", Name: "missing"}}); err == nil {
+		t.Error("Expected error when no resources could be exported")
+	}
+}
+
+func TestExportNodeUsesNodeGetter(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-live"}})
+	rm := NewResourceManager(zap.NewNop(), kubeClient, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	// Without a getter the node is read from the API server
+	if _, err := rm.ExportResource(context.Background(), "", "node-live", "Node"); err != nil {
+		t.Fatalf("ExportResource returned error: %v", err)
+	}
+
+	var looked []string
+	rm.SetNodeGetter(func(ctx context.Context, name string) (*v1.Node, error) {
+		looked = append(looked, name)
+		return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+	})
+	kubeClient.ClearActions()
+
+	export, err := rm.ExportResource(context.Background(), "", "node-cached", "Node")
+	if err != nil {
+		t.Fatalf("ExportResource returned error: %v", err)
+	}
+	if metadata, ok := export.Metadata.(map[string]interface{}); !ok || metadata["name"] != "node-cached" {
+		t.Errorf("expected the cached node to be exported, got %v", export.Metadata)
+	}
+	if len(looked) != 1 || looked[0] != "node-cached" {
+		t.Errorf("expected the node getter to be used, got %v", looked)
+	}
+	if actions := kubeClient.Actions(); len(actions) != 0 {
+		t.Errorf("expected no API server calls, got %v", actions)
+	}
+}
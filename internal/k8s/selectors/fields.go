@@ -0,0 +1,157 @@
+package selectors
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// Field selectors on list endpoints are evaluated in memory against the
+// informer caches rather than passed to the API server, so every list
+// endpoint behaves the same whether it is served from a cache or not. Each
+// kind supports the field labels the API server supports for it (see the
+// *ToFieldSet functions); selecting on any other label is rejected instead of
+// silently matching nothing.
+
+// objectMetaFieldSet returns the metadata fields every kind supports
+func objectMetaFieldSet(meta metav1.Object) fields.Set {
+	return fields.Set{
+		"metadata.name":      meta.GetName(),
+		"metadata.namespace": meta.GetNamespace(),
+	}
+}
+
+// mergeFieldSets adds extra fields to a base field set
+func mergeFieldSets(base fields.Set, extra fields.Set) fields.Set {
+	for key, value := range extra {
+		base[key] = value
+	}
+	return base
+}
+
+// parseFieldSelector parses a field selector and checks that it only uses
+// field labels present in supported
+func parseFieldSelector(selector string, supported fields.Set) (fields.Selector, error) {
+	fieldSelector, err := fields.ParseSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field selector: %w", err)
+	}
+
+	for _, requirement := range fieldSelector.Requirements() {
+		if _, ok := supported[requirement.Field]; !ok {
+			return nil, fmt.Errorf("invalid field selector: field label %q is not supported (supported: %s)",
+				requirement.Field, strings.Join(supportedFieldLabels(supported), ", "))
+		}
+	}
+
+	return fieldSelector, nil
+}
+
+// supportedFieldLabels lists the field labels of a field set in sorted order
+func supportedFieldLabels(set fields.Set) []string {
+	labels := make([]string, 0, len(set))
+	for key := range set {
+		labels = append(labels, key)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// DeploymentToFieldSet converts a deployment to a field set for field selector matching
+func DeploymentToFieldSet(deployment *appsv1.Deployment) fields.Set {
+	return objectMetaFieldSet(deployment)
+}
+
+// StatefulSetToFieldSet converts a statefulset to a field set for field selector matching
+func StatefulSetToFieldSet(statefulSet *appsv1.StatefulSet) fields.Set {
+	return objectMetaFieldSet(statefulSet)
+}
+
+// DaemonSetToFieldSet converts a daemonset to a field set for field selector matching
+func DaemonSetToFieldSet(daemonSet *appsv1.DaemonSet) fields.Set {
+	return objectMetaFieldSet(daemonSet)
+}
+
+// ReplicaSetToFieldSet converts a replicaset to a field set for field selector matching
+func ReplicaSetToFieldSet(replicaSet *appsv1.ReplicaSet) fields.Set {
+	return mergeFieldSets(objectMetaFieldSet(replicaSet), fields.Set{
+		"status.replicas": strconv.Itoa(int(replicaSet.Status.Replicas)),
+	})
+}
+
+// ServiceToFieldSet converts a service to a field set for field selector matching
+func ServiceToFieldSet(service *v1.Service) fields.Set {
+	return mergeFieldSets(objectMetaFieldSet(service), fields.Set{
+		"spec.clusterIP": service.Spec.ClusterIP,
+		"spec.type":      string(service.Spec.Type),
+	})
+}
+
+// JobToFieldSet converts a job to a field set for field selector matching
+func JobToFieldSet(job *batchv1.Job) fields.Set {
+	return mergeFieldSets(objectMetaFieldSet(job), fields.Set{
+		"status.successful": strconv.Itoa(int(job.Status.Succeeded)),
+	})
+}
+
+// CronJobToFieldSet converts a cronjob to a field set for field selector matching
+func CronJobToFieldSet(cronJob *batchv1.CronJob) fields.Set {
+	return objectMetaFieldSet(cronJob)
+}
+
+// EndpointsToFieldSet converts endpoints to a field set for field selector matching
+func EndpointsToFieldSet(endpoints *v1.Endpoints) fields.Set {
+	return objectMetaFieldSet(endpoints)
+}
+
+// NetworkPolicyToFieldSet converts a network policy to a field set for field selector matching
+func NetworkPolicyToFieldSet(networkPolicy *networkingv1.NetworkPolicy) fields.Set {
+	return objectMetaFieldSet(networkPolicy)
+}
+
+// ResourceQuotaToFieldSet converts a resource quota to a field set for field selector matching
+func ResourceQuotaToFieldSet(resourceQuota *v1.ResourceQuota) fields.Set {
+	return objectMetaFieldSet(resourceQuota)
+}
+
+// LimitRangeToFieldSet converts a limit range to a field set for field selector matching
+func LimitRangeToFieldSet(limitRange *v1.LimitRange) fields.Set {
+	return objectMetaFieldSet(limitRange)
+}
+
+// ServiceAccountToFieldSet converts a service account to a field set for field selector matching
+func ServiceAccountToFieldSet(serviceAccount *v1.ServiceAccount) fields.Set {
+	return objectMetaFieldSet(serviceAccount)
+}
+
+// SecretToFieldSet converts a secret to a field set for field selector matching
+func SecretToFieldSet(secret *v1.Secret) fields.Set {
+	return mergeFieldSets(objectMetaFieldSet(secret), fields.Set{
+		"type": string(secret.Type),
+	})
+}
+
+// EventToFieldSet converts an event to a field set for field selector matching
+func EventToFieldSet(event *v1.Event) fields.Set {
+	return mergeFieldSets(objectMetaFieldSet(event), fields.Set{
+		"involvedObject.kind":            event.InvolvedObject.Kind,
+		"involvedObject.namespace":       event.InvolvedObject.Namespace,
+		"involvedObject.name":            event.InvolvedObject.Name,
+		"involvedObject.uid":             string(event.InvolvedObject.UID),
+		"involvedObject.apiVersion":      event.InvolvedObject.APIVersion,
+		"involvedObject.resourceVersion": event.InvolvedObject.ResourceVersion,
+		"involvedObject.fieldPath":       event.InvolvedObject.FieldPath,
+		"reason":                         event.Reason,
+		"reportingComponent":             event.ReportingController,
+		"source":                         event.Source.Component,
+		"type":                           event.Type,
+	})
+}
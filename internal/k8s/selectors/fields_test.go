@@ -0,0 +1,118 @@
+package selectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFilterPodsByNodeNameFieldSelector(t *testing.T) {
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "other"}, Spec: v1.PodSpec{NodeName: "node-1"}},
+	}
+
+	filtered, err := FilterPods(pods, PodFilterOptions{FieldSelector: "spec.nodeName=node-1", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+	for _, pod := range filtered {
+		assert.Equal(t, "node-1", pod.Spec.NodeName)
+	}
+
+	filtered, err = FilterPods(pods, PodFilterOptions{FieldSelector: "spec.nodeName!=node-1,metadata.namespace=default", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "b", filtered[0].Name)
+}
+
+func TestFilterPodsFieldSelectorFields(t *testing.T) {
+	pods := []v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec:       v1.PodSpec{NodeName: "node-1"},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"},
+			Spec:       v1.PodSpec{NodeName: "node-2"},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "staging"},
+			Status:     v1.PodStatus{Phase: v1.PodPending},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		selector string
+		expected []string
+	}{
+		{name: "name", selector: "metadata.name=web", expected: []string{"shop/web", "staging/web"}},
+		{name: "namespace", selector: "metadata.namespace=staging", expected: []string{"staging/web"}},
+		{name: "node name", selector: "spec.nodeName=node-2", expected: []string{"shop/db"}},
+		{name: "unscheduled", selector: "spec.nodeName=", expected: []string{"staging/web"}},
+		{name: "phase", selector: "status.phase=Running", expected: []string{"shop/db", "shop/web"}},
+		{name: "not equal", selector: "status.phase!=Running", expected: []string{"staging/web"}},
+		{name: "double equals", selector: "metadata.name==db", expected: []string{"shop/db"}},
+		{name: "combined", selector: "metadata.name=web,status.phase=Running", expected: []string{"shop/web"}},
+		{name: "no match", selector: "spec.nodeName=node-3", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, err := FilterPods(pods, PodFilterOptions{FieldSelector: tt.selector, Page: 1, PageSize: 10})
+			require.NoError(t, err)
+			names := []string{}
+			for _, pod := range filtered {
+				names = append(names, pod.Namespace+"/"+pod.Name)
+			}
+			assert.ElementsMatch(t, tt.expected, names)
+		})
+	}
+}
+
+func TestFilterRejectsUnsupportedFieldLabel(t *testing.T) {
+	_, err := FilterPods([]v1.Pod{}, PodFilterOptions{FieldSelector: "spec.hostname=web"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.hostname")
+
+	_, err = FilterServices([]v1.Service{}, ServiceFilterOptions{FieldSelector: "spec.nodeName=node-1"})
+	assert.Error(t, err)
+}
+
+func TestFilterServicesByTypeFieldSelector(t *testing.T) {
+	services := []v1.Service{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}, Spec: v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}, Spec: v1.ServiceSpec{Type: v1.ServiceTypeClusterIP}},
+	}
+
+	filtered, err := FilterServices(services, ServiceFilterOptions{FieldSelector: "spec.type=LoadBalancer", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "web", filtered[0].Name)
+}
+
+func TestFilterEventsByInvolvedObject(t *testing.T) {
+	events := []v1.Event{
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e1", Namespace: "default"},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "web"},
+			Type:           v1.EventTypeWarning,
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e2", Namespace: "default"},
+			InvolvedObject: v1.ObjectReference{Kind: "Deployment", Name: "web"},
+			Type:           v1.EventTypeNormal,
+		},
+	}
+
+	filtered, err := FilterEvents(events, EventFilterOptions{FieldSelector: "involvedObject.kind=Pod,involvedObject.name=web", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "e1", filtered[0].Name)
+}
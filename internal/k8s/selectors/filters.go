@@ -179,9 +179,9 @@ func FilterPods(pods []v1.Pod, options PodFilterOptions) ([]v1.Pod, error) {
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, PodToFieldSet(&v1.Pod{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -275,9 +275,9 @@ func FilterNodes(nodes []v1.Node, options NodeFilterOptions) ([]v1.Node, error)
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, NodeToFieldSet(&v1.Node{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -391,13 +391,16 @@ func isNodeReady(node *v1.Node) bool {
 // PodToFieldSet converts a pod to a field set for field selector matching
 func PodToFieldSet(pod *v1.Pod) fields.Set {
 	return fields.Set{
-		"metadata.name":      pod.Name,
-		"metadata.namespace": pod.Namespace,
-		"spec.nodeName":      pod.Spec.NodeName,
-		"spec.restartPolicy": string(pod.Spec.RestartPolicy),
-		"status.phase":       string(pod.Status.Phase),
-		"status.podIP":       pod.Status.PodIP,
-		"status.hostIP":      pod.Status.HostIP,
+		"metadata.name":            pod.Name,
+		"metadata.namespace":       pod.Namespace,
+		"spec.nodeName":            pod.Spec.NodeName,
+		"spec.restartPolicy":       string(pod.Spec.RestartPolicy),
+		"status.phase":             string(pod.Status.Phase),
+		"status.podIP":             pod.Status.PodIP,
+		"status.hostIP":            pod.Status.HostIP,
+		"spec.schedulerName":       pod.Spec.SchedulerName,
+		"spec.serviceAccountName":  pod.Spec.ServiceAccountName,
+		"status.nominatedNodeName": pod.Status.NominatedNodeName,
 	}
 }
 
@@ -511,9 +514,9 @@ func FilterDeployments(deployments []appsv1.Deployment, options DeploymentFilter
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, DeploymentToFieldSet(&appsv1.Deployment{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -530,11 +533,8 @@ func FilterDeployments(deployments []appsv1.Deployment, options DeploymentFilter
 
 		// Apply field selector
 		if fieldSelector != nil {
-			fields := fields.Set{
-				"metadata.name":      deployment.Name,
-				"metadata.namespace": deployment.Namespace,
-			}
-			if !fieldSelector.Matches(fields) {
+			fieldSet := DeploymentToFieldSet(&deployment)
+			if !fieldSelector.Matches(fieldSet) {
 				continue
 			}
 		}
@@ -610,9 +610,9 @@ func FilterStatefulSets(statefulSets []appsv1.StatefulSet, options StatefulSetFi
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, StatefulSetToFieldSet(&appsv1.StatefulSet{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -629,11 +629,8 @@ func FilterStatefulSets(statefulSets []appsv1.StatefulSet, options StatefulSetFi
 
 		// Apply field selector
 		if fieldSelector != nil {
-			fields := fields.Set{
-				"metadata.name":      statefulSet.Name,
-				"metadata.namespace": statefulSet.Namespace,
-			}
-			if !fieldSelector.Matches(fields) {
+			fieldSet := StatefulSetToFieldSet(&statefulSet)
+			if !fieldSelector.Matches(fieldSet) {
 				continue
 			}
 		}
@@ -709,9 +706,9 @@ func FilterServices(services []v1.Service, options ServiceFilterOptions) ([]v1.S
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, ServiceToFieldSet(&v1.Service{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -728,11 +725,8 @@ func FilterServices(services []v1.Service, options ServiceFilterOptions) ([]v1.S
 
 		// Apply field selector
 		if fieldSelector != nil {
-			fields := fields.Set{
-				"metadata.name":      service.Name,
-				"metadata.namespace": service.Namespace,
-			}
-			if !fieldSelector.Matches(fields) {
+			fieldSet := ServiceToFieldSet(&service)
+			if !fieldSelector.Matches(fieldSet) {
 				continue
 			}
 		}
@@ -808,9 +802,9 @@ func FilterDaemonSets(daemonSets []appsv1.DaemonSet, options DaemonSetFilterOpti
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, DaemonSetToFieldSet(&appsv1.DaemonSet{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -827,11 +821,8 @@ func FilterDaemonSets(daemonSets []appsv1.DaemonSet, options DaemonSetFilterOpti
 
 		// Apply field selector
 		if fieldSelector != nil {
-			fields := fields.Set{
-				"metadata.name":      daemonSet.Name,
-				"metadata.namespace": daemonSet.Namespace,
-			}
-			if !fieldSelector.Matches(fields) {
+			fieldSet := DaemonSetToFieldSet(&daemonSet)
+			if !fieldSelector.Matches(fieldSet) {
 				continue
 			}
 		}
@@ -907,9 +898,9 @@ func FilterReplicaSets(replicaSets []appsv1.ReplicaSet, options ReplicaSetFilter
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, ReplicaSetToFieldSet(&appsv1.ReplicaSet{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -926,11 +917,8 @@ func FilterReplicaSets(replicaSets []appsv1.ReplicaSet, options ReplicaSetFilter
 
 		// Apply field selector
 		if fieldSelector != nil {
-			fields := fields.Set{
-				"metadata.name":      replicaSet.Name,
-				"metadata.namespace": replicaSet.Namespace,
-			}
-			if !fieldSelector.Matches(fields) {
+			fieldSet := ReplicaSetToFieldSet(&replicaSet)
+			if !fieldSelector.Matches(fieldSet) {
 				continue
 			}
 		}
@@ -1194,15 +1182,12 @@ func FilterJobs(jobs []batchv1.Job, options JobFilterOptions) ([]batchv1.Job, er
 
 		// Apply field selector
 		if options.FieldSelector != "" {
-			selector, err := fields.ParseSelector(options.FieldSelector)
+			selector, err := parseFieldSelector(options.FieldSelector, JobToFieldSet(&batchv1.Job{}))
 			if err != nil {
-				return nil, fmt.Errorf("invalid field selector: %w", err)
+				return nil, err
 			}
 			// Field selector support for basic fields
-			fieldSet := fields.Set{
-				"metadata.name":      job.Name,
-				"metadata.namespace": job.Namespace,
-			}
+			fieldSet := JobToFieldSet(&job)
 			if !selector.Matches(fieldSet) {
 				continue
 			}
@@ -1327,9 +1312,9 @@ func FilterCronJobs(cronJobs []batchv1.CronJob, options CronJobFilterOptions) ([
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, CronJobToFieldSet(&batchv1.CronJob{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -1350,10 +1335,7 @@ func FilterCronJobs(cronJobs []batchv1.CronJob, options CronJobFilterOptions) ([
 
 		// Field selector filter
 		if fieldSelector != nil {
-			cronJobFields := fields.Set{
-				"metadata.name":      cronJob.Name,
-				"metadata.namespace": cronJob.Namespace,
-			}
+			cronJobFields := CronJobToFieldSet(&cronJob)
 			if !fieldSelector.Matches(cronJobFields) {
 				continue
 			}
@@ -1469,9 +1451,9 @@ func FilterEndpoints(endpoints []v1.Endpoints, options EndpointsFilterOptions) (
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, EndpointsToFieldSet(&v1.Endpoints{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -1519,10 +1501,7 @@ func FilterEndpoints(endpoints []v1.Endpoints, options EndpointsFilterOptions) (
 
 		// Apply field selector (basic implementation)
 		if fieldSelector != nil {
-			fieldSet := fields.Set{
-				"metadata.name":      endpoint.Name,
-				"metadata.namespace": endpoint.Namespace,
-			}
+			fieldSet := EndpointsToFieldSet(&endpoint)
 			if !fieldSelector.Matches(fieldSet) {
 				continue
 			}
@@ -1610,9 +1589,9 @@ func FilterNetworkPolicies(networkPolicies []networkingv1.NetworkPolicy, options
 	// Parse field selector if provided
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, NetworkPolicyToFieldSet(&networkingv1.NetworkPolicy{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -1629,10 +1608,7 @@ func FilterNetworkPolicies(networkPolicies []networkingv1.NetworkPolicy, options
 
 		// Apply field selector filter
 		if fieldSelector != nil {
-			fieldSet := fields.Set{
-				"metadata.name":      networkPolicy.Name,
-				"metadata.namespace": networkPolicy.Namespace,
-			}
+			fieldSet := NetworkPolicyToFieldSet(&networkPolicy)
 			if !fieldSelector.Matches(fieldSet) {
 				continue
 			}
@@ -1754,9 +1730,9 @@ func FilterResourceQuotas(resourceQuotas []v1.ResourceQuota, options ResourceQuo
 	fieldSelector := fields.Everything()
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, ResourceQuotaToFieldSet(&v1.ResourceQuota{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -1772,10 +1748,7 @@ func FilterResourceQuotas(resourceQuotas []v1.ResourceQuota, options ResourceQuo
 		}
 
 		// Field selector filter (basic support for metadata.name and metadata.namespace)
-		fieldsSet := fields.Set{
-			"metadata.name":      rq.Name,
-			"metadata.namespace": rq.Namespace,
-		}
+		fieldsSet := ResourceQuotaToFieldSet(&rq)
 		if !fieldSelector.Matches(fieldsSet) {
 			continue
 		}
@@ -1862,6 +1835,182 @@ func sortResourceQuotas(resourceQuotas []v1.ResourceQuota, sortField, order stri
 	})
 }
 
+// LimitRangeFilterOptions represents filtering options for limit ranges
+type LimitRangeFilterOptions struct {
+	Namespace     string
+	LabelSelector string
+	FieldSelector string
+	Page          int
+	PageSize      int
+	Sort          string // Field to sort by (name, namespace, age)
+	Order         string // Sort order (asc, desc)
+	Search        string // Text search across name, namespace, labels
+}
+
+// FilterLimitRanges filters and paginates limit ranges based on the provided options
+func FilterLimitRanges(limitRanges []v1.LimitRange, options LimitRangeFilterOptions) ([]v1.LimitRange, error) {
+	filtered := []v1.LimitRange{}
+
+	labelSelector := labels.Everything()
+	if options.LabelSelector != "" {
+		var err error
+		labelSelector, err = labels.Parse(options.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+	}
+
+	fieldSelector := fields.Everything()
+	if options.FieldSelector != "" {
+		var err error
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, LimitRangeToFieldSet(&v1.LimitRange{}))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	searchLower := strings.ToLower(options.Search)
+	for _, lr := range limitRanges {
+		if options.Namespace != "" && lr.Namespace != options.Namespace {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(lr.Labels)) {
+			continue
+		}
+		if !fieldSelector.Matches(LimitRangeToFieldSet(&lr)) {
+			continue
+		}
+
+		if searchLower != "" {
+			found := strings.Contains(strings.ToLower(lr.Name), searchLower) ||
+				strings.Contains(strings.ToLower(lr.Namespace), searchLower)
+			for key, value := range lr.Labels {
+				if found {
+					break
+				}
+				found = strings.Contains(strings.ToLower(key), searchLower) ||
+					strings.Contains(strings.ToLower(value), searchLower)
+			}
+			if !found {
+				continue
+			}
+		}
+
+		filtered = append(filtered, lr)
+	}
+
+	sortLimitRanges(filtered, options.Sort, options.Order)
+	return paginateSlice(filtered, options.Page, options.PageSize), nil
+}
+
+// sortLimitRanges sorts limit ranges by the specified field and order
+func sortLimitRanges(limitRanges []v1.LimitRange, sortField, order string) {
+	sort.Slice(limitRanges, func(i, j int) bool {
+		var less bool
+		switch sortField {
+		case "namespace":
+			less = limitRanges[i].Namespace < limitRanges[j].Namespace
+		case "age":
+			less = limitRanges[i].CreationTimestamp.Time.After(limitRanges[j].CreationTimestamp.Time)
+		default:
+			less = limitRanges[i].Name < limitRanges[j].Name
+		}
+
+		if order == "desc" {
+			return !less
+		}
+		return less
+	})
+}
+
+// ServiceAccountFilterOptions represents filtering options for service accounts
+type ServiceAccountFilterOptions struct {
+	Namespace     string
+	LabelSelector string
+	FieldSelector string
+	Page          int
+	PageSize      int
+	Sort          string // Field to sort by (name, namespace, age)
+	Order         string // Sort order (asc, desc)
+	Search        string // Text search across name, namespace, labels
+}
+
+// FilterServiceAccounts filters and paginates service accounts based on the provided options
+func FilterServiceAccounts(serviceAccounts []v1.ServiceAccount, options ServiceAccountFilterOptions) ([]v1.ServiceAccount, error) {
+	filtered := []v1.ServiceAccount{}
+
+	labelSelector := labels.Everything()
+	if options.LabelSelector != "" {
+		var err error
+		labelSelector, err = labels.Parse(options.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+	}
+
+	fieldSelector := fields.Everything()
+	if options.FieldSelector != "" {
+		var err error
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, ServiceAccountToFieldSet(&v1.ServiceAccount{}))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	searchLower := strings.ToLower(options.Search)
+	for _, sa := range serviceAccounts {
+		if options.Namespace != "" && sa.Namespace != options.Namespace {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(sa.Labels)) {
+			continue
+		}
+		if !fieldSelector.Matches(ServiceAccountToFieldSet(&sa)) {
+			continue
+		}
+
+		if searchLower != "" {
+			found := strings.Contains(strings.ToLower(sa.Name), searchLower) ||
+				strings.Contains(strings.ToLower(sa.Namespace), searchLower)
+			for key, value := range sa.Labels {
+				if found {
+					break
+				}
+				found = strings.Contains(strings.ToLower(key), searchLower) ||
+					strings.Contains(strings.ToLower(value), searchLower)
+			}
+			if !found {
+				continue
+			}
+		}
+
+		filtered = append(filtered, sa)
+	}
+
+	sortServiceAccounts(filtered, options.Sort, options.Order)
+	return paginateSlice(filtered, options.Page, options.PageSize), nil
+}
+
+// sortServiceAccounts sorts service accounts by the specified field and order
+func sortServiceAccounts(serviceAccounts []v1.ServiceAccount, sortField, order string) {
+	sort.Slice(serviceAccounts, func(i, j int) bool {
+		var less bool
+		switch sortField {
+		case "namespace":
+			less = serviceAccounts[i].Namespace < serviceAccounts[j].Namespace
+		case "age":
+			less = serviceAccounts[i].CreationTimestamp.Time.After(serviceAccounts[j].CreationTimestamp.Time)
+		default:
+			less = serviceAccounts[i].Name < serviceAccounts[j].Name
+		}
+
+		if order == "desc" {
+			return !less
+		}
+		return less
+	})
+}
+
 // FilterSecrets filters a list of secrets based on the given options
 func FilterSecrets(secrets []v1.Secret, options SecretFilterOptions) ([]v1.Secret, error) {
 	var filtered []v1.Secret
@@ -1880,9 +2029,9 @@ func FilterSecrets(secrets []v1.Secret, options SecretFilterOptions) ([]v1.Secre
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, SecretToFieldSet(&v1.Secret{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -1904,12 +2053,8 @@ func FilterSecrets(secrets []v1.Secret, options SecretFilterOptions) ([]v1.Secre
 
 		// Apply field selector
 		if fieldSelector != nil {
-			fields := fields.Set{
-				"metadata.name":      secret.Name,
-				"metadata.namespace": secret.Namespace,
-				"type":               string(secret.Type),
-			}
-			if !fieldSelector.Matches(fields) {
+			fieldSet := SecretToFieldSet(&secret)
+			if !fieldSelector.Matches(fieldSet) {
 				continue
 			}
 		}
@@ -2044,9 +2189,9 @@ func FilterEvents(events []v1.Event, options EventFilterOptions) ([]v1.Event, er
 	var fieldSelector fields.Selector
 	if options.FieldSelector != "" {
 		var err error
-		fieldSelector, err = fields.ParseSelector(options.FieldSelector)
+		fieldSelector, err = parseFieldSelector(options.FieldSelector, EventToFieldSet(&v1.Event{}))
 		if err != nil {
-			return nil, fmt.Errorf("invalid field selector: %w", err)
+			return nil, err
 		}
 	}
 
@@ -2073,12 +2218,7 @@ func FilterEvents(events []v1.Event, options EventFilterOptions) ([]v1.Event, er
 
 		// Apply field selector
 		if fieldSelector != nil {
-			fieldSet := fields.Set{
-				"metadata.name":      event.Name,
-				"metadata.namespace": event.Namespace,
-				"type":               event.Type,
-				"reason":             event.Reason,
-			}
+			fieldSet := EventToFieldSet(&event)
 			if !fieldSelector.Matches(fieldSet) {
 				continue
 			}
@@ -3,6 +3,7 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"go.uber.org/zap"
 	authorizationv1 "k8s.io/api/authorization/v1"
@@ -22,17 +23,21 @@ func NewSSARHelper(logger *zap.Logger) *SSARHelper {
 	}
 }
 
-// CanPerformAction checks if the user (via impersonated client) can perform the specified action
+// CanPerformAction checks if the user (via impersonated client) can perform the specified action.
+// A subresource is written after the resource, as in pods/exec.
 func (s *SSARHelper) CanPerformAction(ctx context.Context, client kubernetes.Interface, verb, group, resource, namespace, name string) (bool, error) {
+	resource, subresource, _ := strings.Cut(resource, "/")
+
 	// Create SelfSubjectAccessReview request
 	sar := &authorizationv1.SelfSubjectAccessReview{
 		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
 			ResourceAttributes: &authorizationv1.ResourceAttributes{
-				Verb:      verb,
-				Group:     group,
-				Resource:  resource,
-				Namespace: namespace,
-				Name:      name,
+				Verb:        verb,
+				Group:       group,
+				Resource:    resource,
+				Subresource: subresource,
+				Namespace:   namespace,
+				Name:        name,
 			},
 		},
 	}
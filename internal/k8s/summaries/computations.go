@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aaronlmathis/kaptn/internal/k8s/units"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -112,7 +113,7 @@ func (s *SummaryService) computePodSummary(ctx context.Context, namespace string
 		},
 		{
 			Title:    "Avg. CPU / Memory",
-			Value:    fmt.Sprintf("%.0fm/%s", avgCPU*1000, formatMemory(avgMemory)),
+			Value:    fmt.Sprintf("%.0fm/%s", avgCPU*1000, units.HumanizeBytes(avgMemory)),
 			Subtitle: "Average resource requests",
 			Footer:   "Per pod resource allocation",
 			Status:   getResourceUsageStatus(avgCPU, float64(avgMemory)),
@@ -205,7 +206,7 @@ func (s *SummaryService) computeNodeSummary(ctx context.Context, namespace strin
 			Title:    "Memory Allocatable vs Used",
 			Value:    fmt.Sprintf("%.1f%%", memUtilization),
 			Subtitle: "Memory capacity utilization",
-			Footer:   fmt.Sprintf("%s allocatable", formatMemory(totalAllocatableMem)),
+			Footer:   fmt.Sprintf("%s allocatable", units.HumanizeBytes(totalAllocatableMem)),
 			Status:   getUtilizationStatus(memUtilization),
 		},
 	}, nil
@@ -426,26 +427,6 @@ func extractPodResourceRequests(pod *v1.Pod) (cpu float64, memory int64) {
 	return cpu, memory
 }
 
-func formatMemory(bytes int64) string {
-	if bytes == 0 {
-		return "0Mi"
-	}
-
-	const (
-		Ki = 1024
-		Mi = Ki * 1024
-		Gi = Mi * 1024
-	)
-
-	if bytes >= Gi {
-		return fmt.Sprintf("%.1fGi", float64(bytes)/float64(Gi))
-	} else if bytes >= Mi {
-		return fmt.Sprintf("%.0fMi", float64(bytes)/float64(Mi))
-	} else {
-		return fmt.Sprintf("%.0fKi", float64(bytes)/float64(Ki))
-	}
-}
-
 func formatPercentage(numerator, denominator int) string {
 	if denominator == 0 {
 		return "0%"
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/aaronlmathis/kaptn/internal/k8s/informers"
+	"github.com/aaronlmathis/kaptn/internal/k8s/units"
 	"github.com/aaronlmathis/kaptn/internal/k8s/ws"
 	"go.uber.org/zap"
 	"k8s.io/client-go/kubernetes"
@@ -634,24 +635,15 @@ func extractCPUFromText(text string) float64 {
 	return 0
 }
 
-// extractMemoryFromText extracts memory value from text (in bytes)
+// extractMemoryFromText extracts a memory size in bytes from text such as
+// "1.5 GiB", "512Mi" or a "250m/1.5 GiB" CPU/memory pair
 func extractMemoryFromText(text string) float64 {
-	// Look for patterns like "1Gi", "512Mi", etc.
-	re := regexp.MustCompile(`(\d+(?:\.\d+)?)(Gi|Mi|Ki|G|M|K)?`)
-	if matches := re.FindStringSubmatch(text); len(matches) > 2 {
-		if value, err := strconv.ParseFloat(matches[1], 64); err == nil {
-			unit := strings.ToLower(matches[2])
-			switch unit {
-			case "gi", "g":
-				return value * 1024 * 1024 * 1024
-			case "mi", "m":
-				return value * 1024 * 1024
-			case "ki", "k":
-				return value * 1024
-			default:
-				return value
-			}
-		}
+	if slash := strings.LastIndex(text, "/"); slash >= 0 {
+		text = text[slash+1:]
 	}
-	return 0
+	bytes, err := units.ParseBytes(text)
+	if err != nil {
+		return 0
+	}
+	return float64(bytes)
 }
@@ -0,0 +1,77 @@
+package units
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Memory and storage sizes are reported in binary units throughout the API,
+// matching how Kubernetes users write them (Mi, Gi). A decimal quantity such
+// as 1G is 10^9 bytes and is displayed as 954 MiB, never as 1 GiB.
+
+// binaryUnits are the display suffixes for successive powers of 1024
+var binaryUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// ParseQuantityBytes returns the number of bytes in a memory or storage
+// quantity. Binary (Gi) and decimal (G) suffixes are honoured; a fractional
+// byte count, such as the milli-byte values metrics-server occasionally
+// reports, is rounded up to the next whole byte.
+func ParseQuantityBytes(quantity resource.Quantity) int64 {
+	if bytes, ok := quantity.AsInt64(); ok {
+		return bytes
+	}
+	return quantity.Value()
+}
+
+// ParseBytes parses a byte size written as a Kubernetes quantity ("512Mi",
+// "1G") or as HumanizeBytes output ("1.5 GiB"). Whitespace is ignored.
+func ParseBytes(text string) (int64, error) {
+	// HumanizeBytes suffixes, and the KB/MB/GB people write, are quantity
+	// suffixes followed by "B"; decimal kilo is a lowercase k in quantities
+	compact := strings.TrimSuffix(strings.Join(strings.Fields(text), ""), "B")
+	if strings.HasSuffix(compact, "K") {
+		compact = strings.TrimSuffix(compact, "K") + "k"
+	}
+
+	quantity, err := resource.ParseQuantity(compact)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q: %w", text, err)
+	}
+	return ParseQuantityBytes(quantity), nil
+}
+
+// HumanizeBytes formats a byte count with the largest binary unit that keeps
+// the value at or above 1, with at most one decimal: 1 GiB, 1.5 GiB, 512 KiB.
+// Counts below 1 KiB are shown in bytes.
+func HumanizeBytes(bytes int64) string {
+	sign := ""
+	value := float64(bytes)
+	if bytes < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	unit := 0
+	for value >= 1024 && unit < len(binaryUnits)-1 {
+		value /= 1024
+		unit++
+	}
+	// Rounding can carry into the next unit, e.g. 1023.96 KiB
+	if unit < len(binaryUnits)-1 && strconv.FormatFloat(value, 'f', 1, 64) == "1024.0" {
+		value /= 1024
+		unit++
+	}
+
+	formatted := strconv.FormatFloat(value, 'f', 1, 64)
+	formatted = strings.TrimSuffix(formatted, ".0")
+	return sign + formatted + " " + binaryUnits[unit]
+}
+
+// HumanizeQuantityBytes formats a memory or storage quantity with
+// HumanizeBytes
+func HumanizeQuantityBytes(quantity resource.Quantity) string {
+	return HumanizeBytes(ParseQuantityBytes(quantity))
+}
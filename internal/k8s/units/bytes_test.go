@@ -0,0 +1,82 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestParseQuantityBytes(t *testing.T) {
+	cases := map[string]int64{
+		"1Gi":    1 << 30,
+		"1.5Gi":  3 << 29,
+		"1G":     1000000000,
+		"512Ki":  512 << 10,
+		"100Mi":  100 << 20,
+		"1536":   1536,
+		"1500m":  2, // fractional bytes round up
+		"16Gi":   16 << 30,
+		"0":      0,
+		"2.5e3":  2500,
+		"0.5Mi":  1 << 19,
+		"768Mi":  768 << 20,
+		"3.75Gi": 4026531840,
+	}
+	for raw, expected := range cases {
+		assert.Equal(t, expected, ParseQuantityBytes(resource.MustParse(raw)), raw)
+	}
+}
+
+func TestHumanizeBytes(t *testing.T) {
+	cases := map[int64]string{
+		0:                 "0 B",
+		1023:              "1023 B",
+		1 << 10:           "1 KiB",
+		512 << 10:         "512 KiB",
+		1 << 30:           "1 GiB",
+		3 << 29:           "1.5 GiB",
+		1000000000:        "953.7 MiB",
+		(1 << 20) - 1:     "1 MiB",
+		1536:              "1.5 KiB",
+		5 << 40:           "5 TiB",
+		-(2 << 20):        "-2 MiB",
+		(1 << 20) + 52429: "1.1 MiB",
+	}
+	for bytes, expected := range cases {
+		assert.Equal(t, expected, HumanizeBytes(bytes), bytes)
+	}
+	assert.Equal(t, "1.5 GiB", HumanizeQuantityBytes(resource.MustParse("1.5Gi")))
+	assert.Equal(t, "300 KiB", HumanizeQuantityBytes(resource.MustParse("300Ki")))
+}
+
+func TestParseBytes(t *testing.T) {
+	cases := map[string]int64{
+		"1.5 GiB": 3 << 29,
+		"1Gi":     1 << 30,
+		"1G":      1000000000,
+		"1 GB":    1000000000,
+		"2 KB":    2000,
+		"512 KiB": 512 << 10,
+		"42 B":    42,
+		" 64Mi ":  64 << 20,
+	}
+	for raw, expected := range cases {
+		bytes, err := ParseBytes(raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, expected, bytes, raw)
+	}
+
+	// HumanizeBytes output parses back to the same size when it is exact
+	for _, bytes := range []int64{1 << 30, 3 << 29, 512 << 10} {
+		parsed, err := ParseBytes(HumanizeBytes(bytes))
+		require.NoError(t, err)
+		assert.Equal(t, bytes, parsed)
+	}
+
+	for _, raw := range []string{"", "B", "lots", "1 XB"} {
+		_, err := ParseBytes(raw)
+		assert.Error(t, err, raw)
+	}
+}
@@ -0,0 +1,133 @@
+package ws
+
+import (
+	"sync"
+	"time"
+)
+
+// Watch event types emitted on resource watch streams
+const (
+	WatchEventAdded    = "ADDED"
+	WatchEventModified = "MODIFIED"
+	WatchEventDeleted  = "DELETED"
+)
+
+// BatchFrameType is the frame type of a coalesced batch of watch events
+const BatchFrameType = "batch"
+
+// SyncFrameType is the frame type of the initial snapshot sent on a watch stream
+const SyncFrameType = "sync"
+
+// DefaultCoalesceWindow is how long events are held for coalescing before a batch is flushed
+const DefaultCoalesceWindow = 250 * time.Millisecond
+
+// WatchEvent is a single object change on a watch stream
+type WatchEvent struct {
+	Type   string      `json:"type"`
+	Key    string      `json:"key"`
+	Object interface{} `json:"object"`
+}
+
+// BatchFrame carries every coalesced event from one flush window
+type BatchFrame struct {
+	Type   string       `json:"type"`
+	Events []WatchEvent `json:"events"`
+}
+
+// SyncFrame carries the current state of every watched object, sent once
+// before any batch frame
+type SyncFrame struct {
+	Type  string        `json:"type"`
+	Items []interface{} `json:"items"`
+}
+
+// Coalescer collects watch events for a flush window, keeping only the latest
+// state per object key, and emits them as a single batch frame. A rollout
+// that updates the same pod many times per second produces one event per pod
+// per window instead of one frame per update.
+type Coalescer struct {
+	window time.Duration
+	emit   func(BatchFrame)
+
+	mu      sync.Mutex
+	events  []WatchEvent
+	indexOf map[string]int
+	timer   *time.Timer
+	stopped bool
+}
+
+// NewCoalescer creates a coalescer that calls emit with each flushed batch.
+// A non-positive window uses DefaultCoalesceWindow.
+func NewCoalescer(window time.Duration, emit func(BatchFrame)) *Coalescer {
+	if window <= 0 {
+		window = DefaultCoalesceWindow
+	}
+	return &Coalescer{
+		window:  window,
+		emit:    emit,
+		indexOf: make(map[string]int),
+	}
+}
+
+// Add queues an event, replacing any pending event for the same key. The
+// first event of a window schedules the flush. Events without a key are never
+// merged.
+func (c *Coalescer) Add(event WatchEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stopped {
+		return
+	}
+
+	if idx, exists := c.indexOf[event.Key]; exists && event.Key != "" {
+		c.events[idx] = mergeWatchEvents(c.events[idx], event)
+	} else {
+		if event.Key != "" {
+			c.indexOf[event.Key] = len(c.events)
+		}
+		c.events = append(c.events, event)
+	}
+
+	if c.timer == nil {
+		c.timer = time.AfterFunc(c.window, c.Flush)
+	}
+}
+
+// Flush emits the pending events immediately, if there are any
+func (c *Coalescer) Flush() {
+	c.mu.Lock()
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	if len(c.events) == 0 || c.stopped {
+		c.mu.Unlock()
+		return
+	}
+	events := c.events
+	c.events = nil
+	c.indexOf = make(map[string]int)
+	c.mu.Unlock()
+
+	c.emit(BatchFrame{Type: BatchFrameType, Events: events})
+}
+
+// Stop flushes any pending events and discards further ones
+func (c *Coalescer) Stop() {
+	c.Flush()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+}
+
+// mergeWatchEvents combines a pending event with a newer one for the same
+// object. The newer object always wins; an object added within the window is
+// still reported as added so clients that never saw it insert it.
+func mergeWatchEvents(pending, next WatchEvent) WatchEvent {
+	if pending.Type == WatchEventAdded && next.Type == WatchEventModified {
+		next.Type = WatchEventAdded
+	}
+	return next
+}
@@ -0,0 +1,87 @@
+package ws
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frameRecorder collects emitted batches for assertions
+type frameRecorder struct {
+	mu     sync.Mutex
+	frames []BatchFrame
+}
+
+func (r *frameRecorder) emit(frame BatchFrame) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.frames = append(r.frames, frame)
+}
+
+func (r *frameRecorder) snapshot() []BatchFrame {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]BatchFrame(nil), r.frames...)
+}
+
+func TestCoalescerCollapsesRapidUpdates(t *testing.T) {
+	recorder := &frameRecorder{}
+	c := NewCoalescer(time.Hour, recorder.emit)
+
+	c.Add(WatchEvent{Type: WatchEventModified, Key: "default/web-1", Object: map[string]interface{}{"phase": "Pending"}})
+	for i := 0; i < 100; i++ {
+		c.Add(WatchEvent{Type: WatchEventModified, Key: "default/web-1", Object: map[string]interface{}{"generation": i}})
+	}
+	c.Add(WatchEvent{Type: WatchEventModified, Key: "default/web-1", Object: map[string]interface{}{"phase": "Running"}})
+	c.Flush()
+
+	frames := recorder.snapshot()
+	require.Len(t, frames, 1)
+	assert.Equal(t, BatchFrameType, frames[0].Type)
+	require.Len(t, frames[0].Events, 1)
+	assert.Equal(t, WatchEventModified, frames[0].Events[0].Type)
+	assert.Equal(t, map[string]interface{}{"phase": "Running"}, frames[0].Events[0].Object)
+}
+
+func TestCoalescerBatchesDistinctObjects(t *testing.T) {
+	recorder := &frameRecorder{}
+	c := NewCoalescer(time.Hour, recorder.emit)
+
+	c.Add(WatchEvent{Type: WatchEventAdded, Key: "default/web-1", Object: "v1"})
+	c.Add(WatchEvent{Type: WatchEventModified, Key: "default/web-2", Object: "v1"})
+	c.Add(WatchEvent{Type: WatchEventModified, Key: "default/web-1", Object: "v2"})
+	c.Add(WatchEvent{Type: WatchEventDeleted, Key: "default/web-3", Object: "v1"})
+	c.Flush()
+
+	frames := recorder.snapshot()
+	require.Len(t, frames, 1)
+	require.Len(t, frames[0].Events, 3)
+
+	// First-seen order is kept and an add followed by updates stays an add
+	assert.Equal(t, WatchEvent{Type: WatchEventAdded, Key: "default/web-1", Object: "v2"}, frames[0].Events[0])
+	assert.Equal(t, "default/web-2", frames[0].Events[1].Key)
+	assert.Equal(t, WatchEventDeleted, frames[0].Events[2].Type)
+
+	// Nothing pending means nothing to emit
+	c.Flush()
+	assert.Len(t, recorder.snapshot(), 1)
+}
+
+func TestCoalescerFlushesAfterWindow(t *testing.T) {
+	recorder := &frameRecorder{}
+	c := NewCoalescer(10*time.Millisecond, recorder.emit)
+	defer c.Stop()
+
+	c.Add(WatchEvent{Type: WatchEventModified, Key: "default/web-1", Object: "v1"})
+	c.Add(WatchEvent{Type: WatchEventModified, Key: "default/web-2", Object: "v1"})
+
+	require.Eventually(t, func() bool { return len(recorder.snapshot()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Len(t, recorder.snapshot()[0].Events, 2)
+
+	// A later event starts a new window
+	c.Add(WatchEvent{Type: WatchEventDeleted, Key: "default/web-1"})
+	require.Eventually(t, func() bool { return len(recorder.snapshot()) == 2 }, time.Second, 5*time.Millisecond)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/aaronlmathis/kaptn/internal/auth"
 	"github.com/aaronlmathis/kaptn/internal/metrics"
+	"github.com/aaronlmathis/kaptn/internal/middleware"
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
 )
@@ -371,6 +372,7 @@ func (nw *noopResponseWriter) WriteHeader(statusCode int) {
 
 // readPump pumps messages from the websocket connection to the hub
 func (c *Client) readPump() {
+	defer middleware.RecoverGoroutine(c.hub.logger, "ws-read-pump")
 	defer func() {
 		c.hub.unregister <- c
 		c.conn.Close()
@@ -396,6 +398,7 @@ func (c *Client) readPump() {
 
 // writePump pumps messages from the hub to the websocket connection
 func (c *Client) writePump() {
+	defer middleware.RecoverGoroutine(c.hub.logger, "ws-write-pump")
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
 		ticker.Stop()
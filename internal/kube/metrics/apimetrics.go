@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aaronlmathis/kaptn/internal/k8s/units"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -142,12 +143,12 @@ func (ama *APIMetricsAdapter) ListNodeMemoryUsage(ctx context.Context) (map[stri
 
 	for _, nodeMetric := range nodeMetrics.Items {
 		// Get memory usage in bytes
-		memoryBytes := nodeMetric.Usage.Memory().Value()
+		memoryBytes := units.ParseQuantityBytes(*nodeMetric.Usage.Memory())
 		usage[nodeMetric.Name] = float64(memoryBytes)
 
 		ama.logger.Debug("Node memory usage collected",
 			zap.String("node", nodeMetric.Name),
-			zap.Float64("memoryGiB", float64(memoryBytes)/(1024*1024*1024)),
+			zap.String("memory", units.HumanizeBytes(memoryBytes)),
 			zap.Int64("memoryBytes", memoryBytes),
 		)
 	}
@@ -0,0 +1,254 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	cmclient "k8s.io/metrics/pkg/client/custom_metrics"
+	emclient "k8s.io/metrics/pkg/client/external_metrics"
+)
+
+// API groups served by custom and external metrics adapters
+const (
+	CustomMetricsGroup   = "custom.metrics.k8s.io"
+	ExternalMetricsGroup = "external.metrics.k8s.io"
+)
+
+// DefaultExternalMetricsNamespace is queried for external metrics without a namespace
+const DefaultExternalMetricsNamespace = "default"
+
+// Retry delays for API group discovery after a failure, doubling up to the maximum
+const (
+	discoveryRetryInitialDelay = 30 * time.Second
+	discoveryRetryMaxDelay     = 10 * time.Minute
+)
+
+// CustomMetricQuery selects a custom metric describing Kubernetes objects.
+// An empty Namespace queries root-scoped objects such as nodes and namespaces.
+type CustomMetricQuery struct {
+	Metric    string `yaml:"metric" json:"metric"`
+	Kind      string `yaml:"kind" json:"kind"`   // e.g. Pod, Deployment, Node
+	Group     string `yaml:"group" json:"group"` // API group of Kind, empty for core
+	Namespace string `yaml:"namespace" json:"namespace"`
+	Selector  string `yaml:"selector" json:"selector"` // Label selector for the described objects
+}
+
+// ExternalMetricQuery selects an external metric in a namespace, defaulting to
+// DefaultExternalMetricsNamespace
+type ExternalMetricQuery struct {
+	Metric    string `yaml:"metric" json:"metric"`
+	Namespace string `yaml:"namespace" json:"namespace"`
+	Selector  string `yaml:"selector" json:"selector"` // Label selector on the metric's labels
+}
+
+// CustomMetricValue is one value returned by the custom or external metrics API
+type CustomMetricValue struct {
+	Metric    string            `json:"metric"`
+	Kind      string            `json:"kind,omitempty"` // Described object kind; empty for external metrics
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"` // External metric labels
+	Value     float64           `json:"value"`
+}
+
+// CustomMetricsAdapter queries custom.metrics.k8s.io and external.metrics.k8s.io.
+// Availability of each API is detected once through discovery; a failed
+// discovery is retried with backoff.
+type CustomMetricsAdapter struct {
+	logger         *zap.Logger
+	kubeClient     kubernetes.Interface
+	customClient   cmclient.CustomMetricsClient
+	externalClient emclient.ExternalMetricsClient
+
+	mu               sync.Mutex
+	hasCustomAPI     bool
+	hasExternalAPI   bool
+	apiCheckComplete bool
+	nextAPICheck     time.Time     // Earliest retry after a failed discovery
+	apiCheckDelay    time.Duration // Delay before the next retry after a failure
+	now              func() time.Time
+}
+
+// NewCustomMetricsAdapter creates a custom metrics adapter from existing clients
+func NewCustomMetricsAdapter(logger *zap.Logger, kubeClient kubernetes.Interface, customClient cmclient.CustomMetricsClient, externalClient emclient.ExternalMetricsClient) *CustomMetricsAdapter {
+	return &CustomMetricsAdapter{
+		logger:         logger,
+		kubeClient:     kubeClient,
+		customClient:   customClient,
+		externalClient: externalClient,
+		now:            time.Now,
+	}
+}
+
+// NewCustomMetricsAdapterForConfig creates a custom metrics adapter whose
+// clients talk to the API server described by restConfig
+func NewCustomMetricsAdapterForConfig(logger *zap.Logger, kubeClient kubernetes.Interface, restConfig *rest.Config) (*CustomMetricsAdapter, error) {
+	discoveryClient := kubeClient.Discovery()
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	customClient := cmclient.NewForConfig(restConfig, mapper, cmclient.NewAvailableAPIsGetter(discoveryClient))
+
+	externalClient, err := emclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create external metrics client: %w", err)
+	}
+
+	return NewCustomMetricsAdapter(logger, kubeClient, customClient, externalClient), nil
+}
+
+// HasCustomMetricsAPI returns true if custom.metrics.k8s.io is served
+func (cma *CustomMetricsAdapter) HasCustomMetricsAPI(ctx context.Context) bool {
+	cma.detectAPIs()
+	cma.mu.Lock()
+	defer cma.mu.Unlock()
+	return cma.hasCustomAPI && cma.customClient != nil
+}
+
+// HasExternalMetricsAPI returns true if external.metrics.k8s.io is served
+func (cma *CustomMetricsAdapter) HasExternalMetricsAPI(ctx context.Context) bool {
+	cma.detectAPIs()
+	cma.mu.Lock()
+	defer cma.mu.Unlock()
+	return cma.hasExternalAPI && cma.externalClient != nil
+}
+
+// detectAPIs checks discovery for the custom and external metrics API groups.
+// The result is kept once discovery succeeds; after a failure the APIs are
+// reported unavailable until a retry, delayed with exponential backoff.
+func (cma *CustomMetricsAdapter) detectAPIs() {
+	cma.mu.Lock()
+	defer cma.mu.Unlock()
+	if cma.apiCheckComplete {
+		return
+	}
+	now := cma.now()
+	if now.Before(cma.nextAPICheck) {
+		return
+	}
+
+	apiGroupList, err := cma.kubeClient.Discovery().ServerGroups()
+	if err != nil {
+		if cma.apiCheckDelay == 0 {
+			cma.apiCheckDelay = discoveryRetryInitialDelay
+		} else if cma.apiCheckDelay *= 2; cma.apiCheckDelay > discoveryRetryMaxDelay {
+			cma.apiCheckDelay = discoveryRetryMaxDelay
+		}
+		cma.nextAPICheck = now.Add(cma.apiCheckDelay)
+		cma.logger.Warn("Failed to discover API groups for custom metrics",
+			zap.Duration("retryIn", cma.apiCheckDelay),
+			zap.Error(err))
+		return
+	}
+	cma.apiCheckComplete = true
+
+	for _, group := range apiGroupList.Groups {
+		switch group.Name {
+		case CustomMetricsGroup:
+			cma.hasCustomAPI = true
+		case ExternalMetricsGroup:
+			cma.hasExternalAPI = true
+		}
+	}
+
+	cma.logger.Info("Custom metrics API availability",
+		zap.Bool("customMetricsAPI", cma.hasCustomAPI),
+		zap.Bool("externalMetricsAPI", cma.hasExternalAPI))
+}
+
+// ListCustomMetric returns the values of a custom metric for every object
+// matched by the query
+func (cma *CustomMetricsAdapter) ListCustomMetric(ctx context.Context, query CustomMetricQuery) ([]CustomMetricValue, error) {
+	if !cma.HasCustomMetricsAPI(ctx) {
+		return nil, fmt.Errorf("custom metrics API (%s) is not available", CustomMetricsGroup)
+	}
+	if query.Metric == "" || query.Kind == "" {
+		return nil, fmt.Errorf("custom metric query requires a metric and kind")
+	}
+
+	selector, err := parseMetricSelector(query.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector for custom metric %s: %w", query.Metric, err)
+	}
+
+	var getter cmclient.MetricsInterface
+	if query.Namespace == "" {
+		getter = cma.customClient.RootScopedMetrics()
+	} else {
+		getter = cma.customClient.NamespacedMetrics(query.Namespace)
+	}
+
+	groupKind := schema.GroupKind{Group: query.Group, Kind: query.Kind}
+	list, err := getter.GetForObjects(groupKind, selector, query.Metric, labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom metric %s for %s: %w", query.Metric, groupKind.String(), err)
+	}
+
+	values := make([]CustomMetricValue, 0, len(list.Items))
+	for _, item := range list.Items {
+		kind := item.DescribedObject.Kind
+		if kind == "" {
+			kind = query.Kind
+		}
+		values = append(values, CustomMetricValue{
+			Metric:    query.Metric,
+			Kind:      kind,
+			Namespace: item.DescribedObject.Namespace,
+			Name:      item.DescribedObject.Name,
+			Value:     item.Value.AsApproximateFloat64(),
+		})
+	}
+	return values, nil
+}
+
+// ListExternalMetric returns the values of an external metric in the query's namespace
+func (cma *CustomMetricsAdapter) ListExternalMetric(ctx context.Context, query ExternalMetricQuery) ([]CustomMetricValue, error) {
+	if !cma.HasExternalMetricsAPI(ctx) {
+		return nil, fmt.Errorf("external metrics API (%s) is not available", ExternalMetricsGroup)
+	}
+	if query.Metric == "" {
+		return nil, fmt.Errorf("external metric query requires a metric")
+	}
+
+	selector, err := parseMetricSelector(query.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector for external metric %s: %w", query.Metric, err)
+	}
+
+	namespace := query.Namespace
+	if namespace == "" {
+		namespace = DefaultExternalMetricsNamespace
+	}
+
+	list, err := cma.externalClient.NamespacedMetrics(namespace).List(query.Metric, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get external metric %s: %w", query.Metric, err)
+	}
+
+	values := make([]CustomMetricValue, 0, len(list.Items))
+	for _, item := range list.Items {
+		values = append(values, CustomMetricValue{
+			Metric:    query.Metric,
+			Namespace: namespace,
+			Labels:    item.MetricLabels,
+			Value:     item.Value.AsApproximateFloat64(),
+		})
+	}
+	return values, nil
+}
+
+// parseMetricSelector parses a label selector, treating an empty string as everything
+func parseMetricSelector(selector string) (labels.Selector, error) {
+	if strings.TrimSpace(selector) == "" {
+		return labels.Everything(), nil
+	}
+	return labels.Parse(selector)
+}
@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	cmv1beta2 "k8s.io/metrics/pkg/apis/custom_metrics/v1beta2"
+	emv1beta1 "k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+	cmfake "k8s.io/metrics/pkg/client/custom_metrics/fake"
+	emfake "k8s.io/metrics/pkg/client/external_metrics/fake"
+)
+
+// kubeClientWithGroups returns a fake clientset whose discovery serves the given group versions
+func kubeClientWithGroups(groupVersions ...string) *fake.Clientset {
+	kubeClient := fake.NewSimpleClientset()
+	discovery := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+	for _, gv := range groupVersions {
+		discovery.Resources = append(discovery.Resources, &metav1.APIResourceList{GroupVersion: gv})
+	}
+	return kubeClient
+}
+
+func TestCustomMetricsAdapter_DetectsAPIs(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	adapter := NewCustomMetricsAdapter(logger, kubeClientWithGroups("custom.metrics.k8s.io/v1beta2"), &cmfake.FakeCustomMetricsClient{}, &emfake.FakeExternalMetricsClient{})
+	assert.True(t, adapter.HasCustomMetricsAPI(context.Background()))
+	assert.False(t, adapter.HasExternalMetricsAPI(context.Background()))
+
+	// Without clients the APIs are reported unavailable even when served
+	adapter = NewCustomMetricsAdapter(logger, kubeClientWithGroups("custom.metrics.k8s.io/v1beta2", "external.metrics.k8s.io/v1beta1"), nil, nil)
+	assert.False(t, adapter.HasCustomMetricsAPI(context.Background()))
+	assert.False(t, adapter.HasExternalMetricsAPI(context.Background()))
+}
+
+func TestCustomMetricsAdapter_RetriesFailedDiscovery(t *testing.T) {
+	// The fake discovery ignores reactor errors, so the first two lookups
+	// fail by serving a malformed group version
+	kubeClient := fake.NewSimpleClientset()
+	discovery := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+	failures := 2
+	kubeClient.PrependReactor("get", "group", func(k8stesting.Action) (bool, runtime.Object, error) {
+		groupVersion := "custom.metrics.k8s.io/v1beta2"
+		if failures > 0 {
+			failures--
+			groupVersion = "not/a/group/version"
+		}
+		discovery.Resources = []*metav1.APIResourceList{{GroupVersion: groupVersion}}
+		return false, nil, nil
+	})
+
+	now := time.Now()
+	adapter := NewCustomMetricsAdapter(zaptest.NewLogger(t), kubeClient, &cmfake.FakeCustomMetricsClient{}, nil)
+	adapter.now = func() time.Time { return now }
+
+	assert.False(t, adapter.HasCustomMetricsAPI(context.Background()))
+
+	// No retry before the backoff elapses
+	now = now.Add(discoveryRetryInitialDelay - time.Second)
+	assert.False(t, adapter.HasCustomMetricsAPI(context.Background()))
+	assert.Equal(t, 1, failures)
+
+	// The second failure doubles the delay
+	now = now.Add(time.Second)
+	assert.False(t, adapter.HasCustomMetricsAPI(context.Background()))
+	assert.Equal(t, 0, failures)
+	now = now.Add(discoveryRetryInitialDelay)
+	assert.False(t, adapter.HasCustomMetricsAPI(context.Background()))
+
+	now = now.Add(discoveryRetryInitialDelay)
+	assert.True(t, adapter.HasCustomMetricsAPI(context.Background()))
+}
+
+func TestCustomMetricsAdapter_ListCustomMetric(t *testing.T) {
+	customClient := &cmfake.FakeCustomMetricsClient{}
+	customClient.AddReactor("get", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		assert.Equal(t, "shop", action.GetNamespace())
+		return true, &cmv1beta2.MetricValueList{Items: []cmv1beta2.MetricValue{
+			{DescribedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "web-1"}, Value: resource.MustParse("12")},
+			{DescribedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "web-2"}, Value: resource.MustParse("500m")},
+		}}, nil
+	})
+
+	adapter := NewCustomMetricsAdapter(zaptest.NewLogger(t), kubeClientWithGroups("custom.metrics.k8s.io/v1beta2"), customClient, nil)
+	values, err := adapter.ListCustomMetric(context.Background(), CustomMetricQuery{
+		Metric:    "http_requests_per_second",
+		Kind:      "Pod",
+		Namespace: "shop",
+		Selector:  "app=web",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []CustomMetricValue{
+		{Metric: "http_requests_per_second", Kind: "Pod", Namespace: "shop", Name: "web-1", Value: 12},
+		{Metric: "http_requests_per_second", Kind: "Pod", Namespace: "shop", Name: "web-2", Value: 0.5},
+	}, values)
+}
+
+func TestCustomMetricsAdapter_ListCustomMetricUnavailable(t *testing.T) {
+	adapter := NewCustomMetricsAdapter(zaptest.NewLogger(t), fake.NewSimpleClientset(), &cmfake.FakeCustomMetricsClient{}, nil)
+
+	_, err := adapter.ListCustomMetric(context.Background(), CustomMetricQuery{Metric: "qps", Kind: "Pod", Namespace: "shop"})
+	assert.Error(t, err)
+}
+
+func TestCustomMetricsAdapter_ListExternalMetric(t *testing.T) {
+	externalClient := &emfake.FakeExternalMetricsClient{}
+	externalClient.AddReactor("list", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		assert.Equal(t, DefaultExternalMetricsNamespace, action.GetNamespace())
+		return true, &emv1beta1.ExternalMetricValueList{Items: []emv1beta1.ExternalMetricValue{
+			{MetricName: "queue_messages_ready", MetricLabels: map[string]string{"queue": "orders"}, Value: resource.MustParse("42")},
+		}}, nil
+	})
+
+	adapter := NewCustomMetricsAdapter(zaptest.NewLogger(t), kubeClientWithGroups("external.metrics.k8s.io/v1beta1"), nil, externalClient)
+	values, err := adapter.ListExternalMetric(context.Background(), ExternalMetricQuery{Metric: "queue_messages_ready", Selector: "queue=orders"})
+	require.NoError(t, err)
+
+	require.Len(t, values, 1)
+	assert.Equal(t, 42.0, values[0].Value)
+	assert.Equal(t, DefaultExternalMetricsNamespace, values[0].Namespace)
+	assert.Equal(t, map[string]string{"queue": "orders"}, values[0].Labels)
+}
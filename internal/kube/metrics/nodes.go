@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aaronlmathis/kaptn/internal/k8s/units"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -54,7 +55,7 @@ func (na *NodesAdapter) ListNodes(ctx context.Context) ([]NodeCapacity, error) {
 		allocatablePods := int(allocatablePodsQuantity.Value())
 
 		memoryQuantity := node.Status.Capacity[corev1.ResourceMemory]
-		memoryBytes := float64(memoryQuantity.Value()) // Memory in bytes
+		memoryBytes := float64(units.ParseQuantityBytes(memoryQuantity))
 
 		nodeCapacity := NodeCapacity{
 			Name:        node.Name,
@@ -69,7 +70,7 @@ func (na *NodesAdapter) ListNodes(ctx context.Context) ([]NodeCapacity, error) {
 		na.logger.Debug("Node capacity collected",
 			zap.String("node", node.Name),
 			zap.Float64("cpuCores", cpuCores),
-			zap.Float64("memoryGiB", memoryBytes/(1024*1024*1024)),
+			zap.String("memory", units.HumanizeBytes(int64(memoryBytes))),
 		)
 	}
 
@@ -112,7 +113,7 @@ func (na *NodesAdapter) GetTotalClusterMemoryCapacity(ctx context.Context) (floa
 	}
 
 	na.logger.Debug("Total cluster memory capacity calculated",
-		zap.Float64("totalGiB", totalBytes/(1024*1024*1024)),
+		zap.String("total", units.HumanizeBytes(int64(totalBytes))),
 	)
 
 	return totalBytes, nil
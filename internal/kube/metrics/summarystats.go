@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -82,6 +86,7 @@ type SummaryStatsResponse struct {
 		Memory struct {
 			UsageBytes      uint64 `json:"usageBytes"`
 			WorkingSetBytes uint64 `json:"workingSetBytes"`
+			AvailableBytes  uint64 `json:"availableBytes"` // Unset when the kubelet has no memory limit to report against
 		} `json:"memory"`
 		SystemContainers []struct {
 			Name string `json:"name"`
@@ -97,8 +102,19 @@ type SummaryStatsResponse struct {
 			TxBytes uint64 `json:"txBytes"`
 		} `json:"network"`
 		EphemeralStorage struct {
-			UsedBytes uint64 `json:"usedBytes"`
+			UsedBytes     uint64 `json:"usedBytes"`
+			CapacityBytes uint64 `json:"capacityBytes"`
 		} `json:"ephemeral-storage"`
+		Containers []struct {
+			Name   string `json:"name"`
+			Rootfs struct {
+				UsedBytes     uint64 `json:"usedBytes"`
+				CapacityBytes uint64 `json:"capacityBytes"`
+			} `json:"rootfs"`
+			Logs struct {
+				UsedBytes uint64 `json:"usedBytes"`
+			} `json:"logs"`
+		} `json:"containers"`
 	} `json:"pods"`
 }
 
@@ -115,29 +131,109 @@ type DetailedNodeStats struct {
 	Timestamp        time.Time `json:"timestamp"`
 }
 
+// NodeMemoryStats represents node-level memory statistics from the Summary API
+type NodeMemoryStats struct {
+	NodeName        string    `json:"nodeName"`
+	UsageBytes      uint64    `json:"usageBytes"`      // Includes page cache
+	WorkingSetBytes uint64    `json:"workingSetBytes"` // Memory the kubelet uses for eviction decisions
+	AvailableBytes  uint64    `json:"availableBytes"`  // Capacity minus working set; 0 if not reported
+	Timestamp       time.Time `json:"timestamp"`
+}
+
 // PodNetworkStats represents network statistics for a pod
 type PodNetworkStats struct {
-	PodName       string    `json:"podName"`
-	PodNamespace  string    `json:"podNamespace"`
-	NodeName      string    `json:"nodeName"`
-	RxBytes       uint64    `json:"rxBytes"`
-	TxBytes       uint64    `json:"txBytes"`
-	EphemeralUsed uint64    `json:"ephemeralUsedBytes"`
-	Timestamp     time.Time `json:"timestamp"`
+	PodName           string    `json:"podName"`
+	PodNamespace      string    `json:"podNamespace"`
+	NodeName          string    `json:"nodeName"`
+	RxBytes           uint64    `json:"rxBytes"` // Monotonic since the pod sandbox started
+	TxBytes           uint64    `json:"txBytes"` // Monotonic since the pod sandbox started
+	EphemeralUsed     uint64    `json:"ephemeralUsedBytes"`
+	EphemeralCapacity uint64    `json:"ephemeralCapacityBytes"` // Capacity of the backing filesystem; 0 if not reported
+	Timestamp         time.Time `json:"timestamp"`
+}
+
+// ContainerStorageStats represents the writable layer and log usage of a container.
+// Together they make up the container's ephemeral storage usage.
+type ContainerStorageStats struct {
+	NodeName        string    `json:"nodeName"`
+	PodName         string    `json:"podName"`
+	PodNamespace    string    `json:"podNamespace"`
+	ContainerName   string    `json:"containerName"`
+	RootfsUsedBytes uint64    `json:"rootfsUsedBytes"`
+	LogsUsedBytes   uint64    `json:"logsUsedBytes"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// NodeImageFsStats represents a node's container image filesystem usage from
+// the Summary API runtime stats
+type NodeImageFsStats struct {
+	NodeName             string    `json:"nodeName"`
+	ImageFsUsedBytes     uint64    `json:"imageFsUsedBytes"`
+	ImageFsCapacityBytes uint64    `json:"imageFsCapacityBytes"`
+	Timestamp            time.Time `json:"timestamp"`
+}
+
+// Summary API access modes
+const (
+	// SummaryModeProxy reaches kubelets through the API server node proxy
+	SummaryModeProxy = "proxy"
+	// SummaryModeDirect contacts each kubelet on its own address and port
+	SummaryModeDirect = "direct"
+)
+
+const (
+	// DefaultKubeletPort is the kubelet's authenticated HTTPS port
+	DefaultKubeletPort = 10250
+	// DefaultSummaryPath is the kubelet Summary API path
+	DefaultSummaryPath = "/stats/summary"
+)
+
+// SummaryAPIConfig controls how the kubelet Summary API is reached
+type SummaryAPIConfig struct {
+	Mode   string `yaml:"mode"`   // "proxy" (default) or "direct"
+	Scheme string `yaml:"scheme"` // http or https, direct mode only (default https)
+	Port   int    `yaml:"port"`   // direct mode only; 0 uses the node's advertised kubelet port
+	Path   string `yaml:"path"`   // Summary API path on the kubelet (default /stats/summary)
+}
+
+// withDefaults fills unset fields with the defaults for proxy access on the standard path
+func (c SummaryAPIConfig) withDefaults() SummaryAPIConfig {
+	if c.Mode == "" {
+		c.Mode = SummaryModeProxy
+	}
+	if c.Scheme == "" {
+		c.Scheme = "https"
+	}
+	if c.Path == "" {
+		c.Path = DefaultSummaryPath
+	}
+	if !strings.HasPrefix(c.Path, "/") {
+		c.Path = "/" + c.Path
+	}
+	return c
 }
 
 // SummaryStatsAdapter provides Kubelet Summary API integration for network statistics
 type SummaryStatsAdapter struct {
-	logger     *zap.Logger
-	kubeClient kubernetes.Interface
-	restConfig *rest.Config
-	httpClient *http.Client
+	logger        *zap.Logger
+	kubeClient    kubernetes.Interface
+	restConfig    *rest.Config
+	httpClient    *http.Client
+	summaryConfig SummaryAPIConfig
 }
 
-// NewSummaryStatsAdapter creates a new summary stats adapter
+// NewSummaryStatsAdapter creates a new summary stats adapter that reaches
+// kubelets through the API server proxy
 func NewSummaryStatsAdapter(logger *zap.Logger, kubeClient kubernetes.Interface, restConfig *rest.Config, insecureTLS bool) *SummaryStatsAdapter {
+	return NewSummaryStatsAdapterWithConfig(logger, kubeClient, restConfig, insecureTLS, SummaryAPIConfig{})
+}
+
+// NewSummaryStatsAdapterWithConfig creates a summary stats adapter using the
+// given Summary API access settings
+func NewSummaryStatsAdapterWithConfig(logger *zap.Logger, kubeClient kubernetes.Interface, restConfig *rest.Config, insecureTLS bool, summaryConfig SummaryAPIConfig) *SummaryStatsAdapter {
 	// Clone the rest config to avoid modifying the original
 	configCopy := rest.CopyConfig(restConfig)
+	summaryConfig = summaryConfig.withDefaults()
 
 	// Apply insecure TLS if requested
 	if insecureTLS {
@@ -147,11 +243,17 @@ func NewSummaryStatsAdapter(logger *zap.Logger, kubeClient kubernetes.Interface,
 		logger.Warn("Summary API configured with insecure TLS - certificate verification disabled")
 	}
 
+	// Kubelets serve their own certificates, so an API server name override does not apply
+	if summaryConfig.Mode == SummaryModeDirect {
+		configCopy.TLSClientConfig.ServerName = ""
+	}
+
 	return &SummaryStatsAdapter{
-		logger:     logger,
-		kubeClient: kubeClient,
-		restConfig: configCopy,
-		httpClient: &http.Client{Timeout: 30 * time.Second}, // Will be replaced by transport-based client
+		logger:        logger,
+		kubeClient:    kubeClient,
+		restConfig:    configCopy,
+		httpClient:    &http.Client{Timeout: 30 * time.Second}, // Will be replaced by transport-based client
+		summaryConfig: summaryConfig,
 	}
 }
 
@@ -166,95 +268,129 @@ func (ssa *SummaryStatsAdapter) HasSummaryAPI(ctx context.Context) bool {
 
 	// Test the Summary API on the first node
 	nodeName := nodes.Items[0].Name
-	_, err = ssa.getNodeSummaryStats(ctx, nodeName)
+	_, err = ssa.getNodeSummaryStats(ctx, &nodes.Items[0])
 	if err != nil {
-		ssa.logger.Info("Summary API not available", zap.String("testedNode", nodeName), zap.Error(err))
+		ssa.logger.Debug("Summary API not available", zap.String("testedNode", nodeName), zap.Error(err))
 		return false
 	}
 
-	ssa.logger.Info("Summary API confirmed available")
+	ssa.logger.Debug("Summary API confirmed available")
 	return true
 }
 
-// ListNodeNetworkStats returns network statistics for all nodes
-// Returns empty slice if Summary API is not available
-func (ssa *SummaryStatsAdapter) ListNodeNetworkStats(ctx context.Context) ([]NetworkStats, error) {
+// NodeSummary is one node's Summary API response
+type NodeSummary struct {
+	NodeName string
+	Summary  *SummaryStatsResponse
+}
+
+// SummarySnapshot holds the Summary API responses of every node that could be
+// read in one pass, so several consumers can share a single kubelet fan-out
+type SummarySnapshot struct {
+	Nodes      []NodeSummary
+	TotalNodes int // Nodes listed, including those whose kubelet could not be read
+	Timestamp  time.Time
+}
+
+// Available reports whether at least one kubelet returned its summary
+func (s *SummarySnapshot) Available() bool {
+	return s != nil && len(s.Nodes) > 0
+}
+
+// FetchSummaries lists the nodes once and reads each kubelet's Summary API.
+// Nodes whose Summary API cannot be read are skipped.
+func (ssa *SummaryStatsAdapter) FetchSummaries(ctx context.Context) (*SummarySnapshot, error) {
 	nodes, err := ssa.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
 	if err != nil {
-		ssa.logger.Error("Failed to list nodes for network stats", zap.Error(err))
+		ssa.logger.Error("Failed to list nodes for summary stats", zap.Error(err))
 		return nil, fmt.Errorf("failed to list nodes: %w", err)
 	}
 
-	stats := make([]NetworkStats, 0, len(nodes.Items))
-	timestamp := time.Now()
+	snapshot := &SummarySnapshot{
+		Nodes:      make([]NodeSummary, 0, len(nodes.Items)),
+		TotalNodes: len(nodes.Items),
+		Timestamp:  time.Now(),
+	}
 
-	for _, node := range nodes.Items {
-		nodeName := node.Name
-		summaryStats, err := ssa.getNodeSummaryStats(ctx, nodeName)
+	for i := range nodes.Items {
+		nodeName := nodes.Items[i].Name
+		summaryStats, err := ssa.getNodeSummaryStats(ctx, &nodes.Items[i])
 		if err != nil {
 			ssa.logger.Warn("Failed to get summary stats for node",
 				zap.String("node", nodeName),
 				zap.Error(err))
 			continue
 		}
+		snapshot.Nodes = append(snapshot.Nodes, NodeSummary{NodeName: nodeName, Summary: summaryStats})
+	}
 
-		var rxBytes, txBytes, rxPackets, txPackets uint64
-
-		// Kubelet can return network stats aggregated at the node level or
-		// as a list of interfaces. We prioritize the list of interfaces if present.
-		if len(summaryStats.Node.Network.Interfaces) > 0 {
-			// Sum byte and packet counts from all interfaces.
-			for _, iface := range summaryStats.Node.Network.Interfaces {
-				rxBytes += iface.RxBytes
-				txBytes += iface.TxBytes
-				rxPackets += iface.RxPackets
-				txPackets += iface.TxPackets
-			}
-			// If packet counts were not found on interfaces (sum is 0),
-			// check for a node-level aggregate. This handles cases where Kubelet
-			// provides per-interface byte counts but only node-level packet counts.
-			if rxPackets == 0 && summaryStats.Node.Network.RxPackets > 0 {
-				ssa.logger.Debug("Falling back to node-level rxPackets", zap.String("node", nodeName))
-				rxPackets = summaryStats.Node.Network.RxPackets
-			}
-			if txPackets == 0 && summaryStats.Node.Network.TxPackets > 0 {
-				ssa.logger.Debug("Falling back to node-level txPackets", zap.String("node", nodeName))
-				txPackets = summaryStats.Node.Network.TxPackets
-			}
-		} else {
-			// Fallback to top-level stats if interfaces array is empty.
-			rxBytes = summaryStats.Node.Network.RxBytes
-			txBytes = summaryStats.Node.Network.TxBytes
-			rxPackets = summaryStats.Node.Network.RxPackets
-			txPackets = summaryStats.Node.Network.TxPackets
-		}
+	ssa.logger.Debug("Fetched node summaries",
+		zap.Int("nodeCount", len(snapshot.Nodes)),
+		zap.Int("totalNodes", snapshot.TotalNodes),
+	)
 
-		nodeStats := NetworkStats{
-			NodeName:  nodeName,
-			RxBytes:   rxBytes,
-			TxBytes:   txBytes,
-			RxPackets: rxPackets,
-			TxPackets: txPackets,
-			Timestamp: timestamp,
-		}
+	return snapshot, nil
+}
 
-		stats = append(stats, nodeStats)
+// ListNodeNetworkStats returns network statistics for all nodes
+// Returns empty slice if Summary API is not available
+func (ssa *SummaryStatsAdapter) ListNodeNetworkStats(ctx context.Context) ([]NetworkStats, error) {
+	snapshot, err := ssa.FetchSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.NetworkStats(), nil
+}
 
-		ssa.logger.Debug("Node network stats collected",
-			zap.String("node", nodeName),
-			zap.Uint64("rxBytes", nodeStats.RxBytes),
-			zap.Uint64("rxPackets", nodeStats.RxPackets),
-			zap.Uint64("txBytes", nodeStats.TxBytes),
-			zap.Uint64("txPackets", nodeStats.TxPackets),
-		)
+// NetworkStats returns the network counters of every node in the snapshot
+func (s *SummarySnapshot) NetworkStats() []NetworkStats {
+	stats := make([]NetworkStats, 0, len(s.Nodes))
+	for _, node := range s.Nodes {
+		stats = append(stats, nodeNetworkStatsFromSummary(node.NodeName, node.Summary, s.Timestamp))
 	}
+	return stats
+}
 
-	ssa.logger.Debug("Collected network stats for nodes",
-		zap.Int("nodeCount", len(stats)),
-		zap.Int("totalNodes", len(nodes.Items)),
-	)
+// nodeNetworkStatsFromSummary extracts node network counters from a node's
+// Summary API response
+func nodeNetworkStatsFromSummary(nodeName string, summary *SummaryStatsResponse, timestamp time.Time) NetworkStats {
+	var rxBytes, txBytes, rxPackets, txPackets uint64
+
+	// Kubelet can return network stats aggregated at the node level or
+	// as a list of interfaces. We prioritize the list of interfaces if present.
+	if len(summary.Node.Network.Interfaces) > 0 {
+		// Sum byte and packet counts from all interfaces.
+		for _, iface := range summary.Node.Network.Interfaces {
+			rxBytes += iface.RxBytes
+			txBytes += iface.TxBytes
+			rxPackets += iface.RxPackets
+			txPackets += iface.TxPackets
+		}
+		// If packet counts were not found on interfaces (sum is 0),
+		// check for a node-level aggregate. This handles cases where Kubelet
+		// provides per-interface byte counts but only node-level packet counts.
+		if rxPackets == 0 && summary.Node.Network.RxPackets > 0 {
+			rxPackets = summary.Node.Network.RxPackets
+		}
+		if txPackets == 0 && summary.Node.Network.TxPackets > 0 {
+			txPackets = summary.Node.Network.TxPackets
+		}
+	} else {
+		// Fallback to top-level stats if interfaces array is empty.
+		rxBytes = summary.Node.Network.RxBytes
+		txBytes = summary.Node.Network.TxBytes
+		rxPackets = summary.Node.Network.RxPackets
+		txPackets = summary.Node.Network.TxPackets
+	}
 
-	return stats, nil
+	return NetworkStats{
+		NodeName:  nodeName,
+		RxBytes:   rxBytes,
+		TxBytes:   txBytes,
+		RxPackets: rxPackets,
+		TxPackets: txPackets,
+		Timestamp: timestamp,
+	}
 }
 
 // GetClusterNetworkStats returns aggregated network statistics for the entire cluster
@@ -296,68 +432,256 @@ func (ssa *SummaryStatsAdapter) GetClusterNetworkStats(ctx context.Context) (Net
 // ListNodeFilesystemStats returns filesystem statistics for all nodes
 // Returns empty slice if Summary API is not available
 func (ssa *SummaryStatsAdapter) ListNodeFilesystemStats(ctx context.Context) ([]FilesystemStats, error) {
-	nodes, err := ssa.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	snapshot, err := ssa.FetchSummaries(ctx)
 	if err != nil {
-		ssa.logger.Error("Failed to list nodes for filesystem stats", zap.Error(err))
-		return nil, fmt.Errorf("failed to list nodes: %w", err)
+		return nil, err
 	}
+	return snapshot.FilesystemStats(), nil
+}
 
-	stats := make([]FilesystemStats, 0, len(nodes.Items))
-	timestamp := time.Now()
+// FilesystemStats returns the root and image filesystem usage of every node
+// in the snapshot
+func (s *SummarySnapshot) FilesystemStats() []FilesystemStats {
+	stats := make([]FilesystemStats, 0, len(s.Nodes))
+	for _, node := range s.Nodes {
+		summary := node.Summary
+		stats = append(stats, FilesystemStats{
+			NodeName:              node.NodeName,
+			FsCapacityBytes:       summary.Node.Fs.CapacityBytes,
+			FsAvailableBytes:      summary.Node.Fs.AvailableBytes,
+			FsUsedBytes:           summary.Node.Fs.UsedBytes,
+			FsInodesTotal:         summary.Node.Fs.Inodes,
+			FsInodesFree:          summary.Node.Fs.InodesFree,
+			ImageFsCapacityBytes:  summary.Node.Runtime.ImageFs.CapacityBytes,
+			ImageFsAvailableBytes: summary.Node.Runtime.ImageFs.AvailableBytes,
+			ImageFsUsedBytes:      summary.Node.Runtime.ImageFs.UsedBytes,
+			ImageFsInodesTotal:    summary.Node.Runtime.ImageFs.Inodes,
+			ImageFsInodesFree:     summary.Node.Runtime.ImageFs.InodesFree,
+			Timestamp:             s.Timestamp,
+		})
+	}
+	return stats
+}
 
-	for _, node := range nodes.Items {
-		nodeName := node.Name
-		summaryStats, err := ssa.getNodeSummaryStats(ctx, nodeName)
-		if err != nil {
-			ssa.logger.Warn("Failed to get summary stats for node (filesystem)",
-				zap.String("node", nodeName),
-				zap.Error(err))
-			continue
+// ListNodeMemoryStats returns node-level memory statistics for every node
+// whose Summary API can be read. Unreachable nodes are skipped.
+func (ssa *SummaryStatsAdapter) ListNodeMemoryStats(ctx context.Context) ([]NodeMemoryStats, error) {
+	snapshot, err := ssa.FetchSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.MemoryStats(), nil
+}
+
+// MemoryStats returns the node-level memory usage of every node in the snapshot
+func (s *SummarySnapshot) MemoryStats() []NodeMemoryStats {
+	stats := make([]NodeMemoryStats, 0, len(s.Nodes))
+	for _, node := range s.Nodes {
+		stats = append(stats, nodeMemoryStatsFromSummary(node.NodeName, node.Summary, s.Timestamp))
+	}
+	return stats
+}
+
+// nodeMemoryStatsFromSummary extracts node-level memory usage from a node's
+// Summary API response
+func nodeMemoryStatsFromSummary(nodeName string, summary *SummaryStatsResponse, timestamp time.Time) NodeMemoryStats {
+	return NodeMemoryStats{
+		NodeName:        nodeName,
+		UsageBytes:      summary.Node.Memory.UsageBytes,
+		WorkingSetBytes: summary.Node.Memory.WorkingSetBytes,
+		AvailableBytes:  summary.Node.Memory.AvailableBytes,
+		Timestamp:       timestamp,
+	}
+}
+
+// ListContainerStorageStats returns rootfs and log usage for every container
+// reported by the kubelets. Nodes whose Summary API cannot be read are skipped.
+func (ssa *SummaryStatsAdapter) ListContainerStorageStats(ctx context.Context) ([]ContainerStorageStats, error) {
+	snapshot, err := ssa.FetchSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.ContainerStorageStats(), nil
+}
+
+// ContainerStorageStats returns the rootfs and log usage of every container
+// in the snapshot
+func (s *SummarySnapshot) ContainerStorageStats() []ContainerStorageStats {
+	var stats []ContainerStorageStats
+	for _, node := range s.Nodes {
+		stats = append(stats, containerStorageStatsFromSummary(node.NodeName, node.Summary, s.Timestamp)...)
+	}
+	return stats
+}
+
+// containerStorageStatsFromSummary extracts per-container storage usage from a
+// node's Summary API response
+func containerStorageStatsFromSummary(nodeName string, summary *SummaryStatsResponse, timestamp time.Time) []ContainerStorageStats {
+	var stats []ContainerStorageStats
+	for _, pod := range summary.Pods {
+		for _, container := range pod.Containers {
+			stats = append(stats, ContainerStorageStats{
+				NodeName:        nodeName,
+				PodName:         pod.PodRef.Name,
+				PodNamespace:    pod.PodRef.Namespace,
+				ContainerName:   container.Name,
+				RootfsUsedBytes: container.Rootfs.UsedBytes,
+				LogsUsedBytes:   container.Logs.UsedBytes,
+				Timestamp:       timestamp,
+			})
 		}
+	}
+	return stats
+}
+
+// ListPodNetworkStats returns network counters and ephemeral storage usage for
+// every pod reported by the kubelets. Nodes whose Summary API cannot be read
+// are skipped.
+func (ssa *SummaryStatsAdapter) ListPodNetworkStats(ctx context.Context) ([]PodNetworkStats, error) {
+	snapshot, err := ssa.FetchSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.PodNetworkStats(), nil
+}
+
+// PodNetworkStats returns the network counters and ephemeral storage usage of
+// every pod in the snapshot
+func (s *SummarySnapshot) PodNetworkStats() []PodNetworkStats {
+	var stats []PodNetworkStats
+	for _, node := range s.Nodes {
+		stats = append(stats, podNetworkStatsFromSummary(node.NodeName, node.Summary, s.Timestamp)...)
+	}
+	return stats
+}
+
+// podNetworkStatsFromSummary extracts per-pod network and ephemeral storage
+// usage from a node's Summary API response
+func podNetworkStatsFromSummary(nodeName string, summary *SummaryStatsResponse, timestamp time.Time) []PodNetworkStats {
+	stats := make([]PodNetworkStats, 0, len(summary.Pods))
+	for _, pod := range summary.Pods {
+		stats = append(stats, PodNetworkStats{
+			PodName:           pod.PodRef.Name,
+			PodNamespace:      pod.PodRef.Namespace,
+			NodeName:          nodeName,
+			RxBytes:           pod.Network.RxBytes,
+			TxBytes:           pod.Network.TxBytes,
+			EphemeralUsed:     pod.EphemeralStorage.UsedBytes,
+			EphemeralCapacity: pod.EphemeralStorage.CapacityBytes,
+			Timestamp:         timestamp,
+		})
+	}
+	return stats
+}
+
+// ListNodeImageFsStats returns image filesystem usage for every node whose
+// Summary API can be read. Unreachable nodes are skipped.
+func (ssa *SummaryStatsAdapter) ListNodeImageFsStats(ctx context.Context) ([]NodeImageFsStats, error) {
+	snapshot, err := ssa.FetchSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.ImageFsStats(), nil
+}
 
-		fsStats := FilesystemStats{
-			NodeName:            nodeName,
-			FsCapacityBytes:     summaryStats.Node.Fs.CapacityBytes,
-			FsAvailableBytes:    summaryStats.Node.Fs.AvailableBytes,
-			FsUsedBytes:         summaryStats.Node.Fs.UsedBytes,
-			FsInodesTotal:       summaryStats.Node.Fs.Inodes,
-			FsInodesFree:        summaryStats.Node.Fs.InodesFree,
-			ImageFsCapacityBytes: summaryStats.Node.Runtime.ImageFs.CapacityBytes,
-			ImageFsAvailableBytes: summaryStats.Node.Runtime.ImageFs.AvailableBytes,
-			ImageFsUsedBytes:    summaryStats.Node.Runtime.ImageFs.UsedBytes,
-			ImageFsInodesTotal:  summaryStats.Node.Runtime.ImageFs.Inodes,
-			ImageFsInodesFree:   summaryStats.Node.Runtime.ImageFs.InodesFree,
-			Timestamp:           timestamp,
+// ImageFsStats returns the image filesystem usage of every node in the snapshot
+func (s *SummarySnapshot) ImageFsStats() []NodeImageFsStats {
+	stats := make([]NodeImageFsStats, 0, len(s.Nodes))
+	for _, node := range s.Nodes {
+		stats = append(stats, nodeImageFsStatsFromSummary(node.NodeName, node.Summary, s.Timestamp))
+	}
+	return stats
+}
+
+// nodeImageFsStatsFromSummary extracts image filesystem usage from a node's
+// Summary API response
+func nodeImageFsStatsFromSummary(nodeName string, summary *SummaryStatsResponse, timestamp time.Time) NodeImageFsStats {
+	return NodeImageFsStats{
+		NodeName:             nodeName,
+		ImageFsUsedBytes:     summary.Node.Runtime.ImageFs.UsedBytes,
+		ImageFsCapacityBytes: summary.Node.Runtime.ImageFs.CapacityBytes,
+		Timestamp:            timestamp,
+	}
+}
+
+// summaryURL builds the Summary API URL for a node according to the access mode
+func (ssa *SummaryStatsAdapter) summaryURL(node *v1.Node) (string, error) {
+	cfg := ssa.summaryConfig
+
+	if cfg.Mode != SummaryModeDirect {
+		host := strings.TrimRight(ssa.restConfig.Host, "/")
+		return fmt.Sprintf("%s/api/v1/nodes/%s/proxy%s", host, node.Name, cfg.Path), nil
+	}
+
+	address := kubeletAddress(node)
+	if address == "" {
+		return "", fmt.Errorf("node %s has no usable address", node.Name)
+	}
+
+	port := cfg.Port
+	if port == 0 {
+		port = int(node.Status.DaemonEndpoints.KubeletEndpoint.Port)
+	}
+	if port == 0 {
+		port = DefaultKubeletPort
+	}
+
+	return fmt.Sprintf("%s://%s%s", cfg.Scheme, net.JoinHostPort(address, strconv.Itoa(port)), cfg.Path), nil
+}
+
+// kubeletAddress picks the address used to contact a node's kubelet directly,
+// preferring the internal IP
+func kubeletAddress(node *v1.Node) string {
+	for _, addressType := range []v1.NodeAddressType{v1.NodeInternalIP, v1.NodeHostName, v1.NodeExternalIP} {
+		for _, address := range node.Status.Addresses {
+			if address.Type == addressType && address.Address != "" {
+				return address.Address
+			}
 		}
-		stats = append(stats, fsStats)
 	}
+	return ""
+}
 
-	ssa.logger.Debug("Collected filesystem stats for nodes",
-		zap.Int("nodeCount", len(stats)),
-	)
+// summaryTransport returns the round tripper for Summary API requests. Plain
+// HTTP kubelet ports are unauthenticated, so credentials are only attached
+// when the request goes over TLS or through the API server.
+func (ssa *SummaryStatsAdapter) summaryTransport() (http.RoundTripper, error) {
+	if ssa.summaryConfig.Mode == SummaryModeDirect && ssa.summaryConfig.Scheme == "http" {
+		return http.DefaultTransport, nil
+	}
 
-	return stats, nil
+	// Use the rest config's transport for proper authentication
+	// This handles both kubeconfig and in-cluster service account authentication
+	if ssa.restConfig.Transport != nil {
+		return ssa.restConfig.Transport, nil
+	}
+
+	// Fallback to creating transport from config
+	transport, err := rest.TransportFor(ssa.restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transport: %w", err)
+	}
+	return transport, nil
 }
 
 // getNodeSummaryStats fetches summary statistics from a specific node's kubelet
-func (ssa *SummaryStatsAdapter) getNodeSummaryStats(ctx context.Context, nodeName string) (*SummaryStatsResponse, error) {
+func (ssa *SummaryStatsAdapter) getNodeSummaryStats(ctx context.Context, node *v1.Node) (*SummaryStatsResponse, error) {
+	nodeName := node.Name
+
 	// Construct the URL for the node's summary stats endpoint
-	url := fmt.Sprintf("%s/api/v1/nodes/%s/proxy/stats/summary", ssa.restConfig.Host, nodeName)
+	url, err := ssa.summaryURL(node)
+	if err != nil {
+		return nil, err
+	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Use the rest config's transport for proper authentication
-	// This handles both kubeconfig and in-cluster service account authentication
-	transport := ssa.restConfig.Transport
-	if transport == nil {
-		// Fallback to creating transport from config
-		transport, err = rest.TransportFor(ssa.restConfig)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create transport: %w", err)
-		}
+	transport, err := ssa.summaryTransport()
+	if err != nil {
+		return nil, err
 	}
 
 	// Create a temporary client with the proper transport
@@ -2,7 +2,14 @@ package metrics
 
 import (
 	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -112,3 +119,184 @@ func TestSummaryStatsAdapter_GetClusterNetworkStats_NoNodes(t *testing.T) {
 	assert.Equal(t, uint64(0), result.RxBytes)
 	assert.Equal(t, uint64(0), result.TxBytes)
 }
+
+// summaryTestServer serves a fixed Summary API response on path and records the requested paths
+func summaryTestServer(t *testing.T, path string) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"node":{"nodeName":"node-a","network":{"rxBytes":1000,"txBytes":2000,"rxPackets":10,"txPackets":20}}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requested
+}
+
+func TestSummaryStatsAdapter_ProxyMode(t *testing.T) {
+	server, requested := summaryTestServer(t, "/api/v1/nodes/node-a/proxy/stats/summary")
+	kubeClient := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}})
+
+	adapter := NewSummaryStatsAdapterWithConfig(zaptest.NewLogger(t), kubeClient, &rest.Config{Host: server.URL}, false,
+		SummaryAPIConfig{Mode: SummaryModeProxy})
+
+	stats, err := adapter.ListNodeNetworkStats(context.Background())
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "node-a", stats[0].NodeName)
+	assert.Equal(t, uint64(1000), stats[0].RxBytes)
+	assert.Equal(t, uint64(20), stats[0].TxPackets)
+	assert.Equal(t, []string{"/api/v1/nodes/node-a/proxy/stats/summary"}, *requested)
+}
+
+func TestSummaryStatsAdapter_DirectMode(t *testing.T) {
+	server, requested := summaryTestServer(t, "/custom/summary")
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	host, portStr, err := net.SplitHostPort(serverURL.Host)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeExternalIP, Address: "203.0.113.10"},
+				{Type: corev1.NodeInternalIP, Address: host},
+			},
+		},
+	}
+	kubeClient := fake.NewSimpleClientset(node)
+
+	// The API server host is unreachable; direct mode must not use it
+	adapter := NewSummaryStatsAdapterWithConfig(zaptest.NewLogger(t), kubeClient, &rest.Config{Host: "https://unreachable.invalid"}, false,
+		SummaryAPIConfig{Mode: SummaryModeDirect, Scheme: "http", Port: port, Path: "custom/summary"})
+
+	stats, err := adapter.ListNodeNetworkStats(context.Background())
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, uint64(2000), stats[0].TxBytes)
+	assert.Equal(t, []string{"/custom/summary"}, *requested)
+}
+
+func TestSummaryStatsAdapter_DirectModeURL(t *testing.T) {
+	adapter := NewSummaryStatsAdapterWithConfig(zaptest.NewLogger(t), fake.NewSimpleClientset(), &rest.Config{}, false,
+		SummaryAPIConfig{Mode: SummaryModeDirect})
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{{Type: corev1.NodeHostName, Address: "node-a.internal"}},
+		},
+	}
+
+	summaryURL, err := adapter.summaryURL(node)
+	require.NoError(t, err)
+	assert.Equal(t, "https://node-a.internal:10250/stats/summary", summaryURL)
+
+	// The kubelet's advertised port is used when none is configured
+	node.Status.DaemonEndpoints.KubeletEndpoint.Port = 10260
+	summaryURL, err = adapter.summaryURL(node)
+	require.NoError(t, err)
+	assert.Equal(t, "https://node-a.internal:10260/stats/summary", summaryURL)
+
+	_, err = adapter.summaryURL(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "no-address"}})
+	assert.Error(t, err)
+}
+
+func TestContainerStorageStatsFromSummary(t *testing.T) {
+	body := `{
+		"node": {"nodeName": "node-a"},
+		"pods": [{
+			"podRef": {"name": "web-0", "namespace": "shop"},
+			"ephemeral-storage": {"usedBytes": 7340032},
+			"containers": [
+				{"name": "app", "rootfs": {"usedBytes": 4194304, "capacityBytes": 100000000}, "logs": {"usedBytes": 1048576}},
+				{"name": "sidecar", "rootfs": {"usedBytes": 2097152}, "logs": {"usedBytes": 0}}
+			]
+		}]
+	}`
+
+	var summary SummaryStatsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &summary))
+
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	stats := containerStorageStatsFromSummary("node-a", &summary, timestamp)
+
+	require.Len(t, stats, 2)
+	assert.Equal(t, ContainerStorageStats{
+		NodeName:        "node-a",
+		PodName:         "web-0",
+		PodNamespace:    "shop",
+		ContainerName:   "app",
+		RootfsUsedBytes: 4194304,
+		LogsUsedBytes:   1048576,
+		Timestamp:       timestamp,
+	}, stats[0])
+	assert.Equal(t, "sidecar", stats[1].ContainerName)
+	assert.Equal(t, uint64(2097152), stats[1].RootfsUsedBytes)
+	assert.Equal(t, uint64(0), stats[1].LogsUsedBytes)
+}
+
+func TestNodeMemoryStatsFromSummary(t *testing.T) {
+	body := `{"node": {"nodeName": "node-a", "memory": {"usageBytes": 6442450944, "workingSetBytes": 4294967296, "availableBytes": 12884901888}}}`
+
+	var summary SummaryStatsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &summary))
+
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, NodeMemoryStats{
+		NodeName:        "node-a",
+		UsageBytes:      6 << 30,
+		WorkingSetBytes: 4 << 30,
+		AvailableBytes:  12 << 30,
+		Timestamp:       timestamp,
+	}, nodeMemoryStatsFromSummary("node-a", &summary, timestamp))
+}
+
+func TestNodeImageFsStatsFromSummary(t *testing.T) {
+	body := `{"node": {"nodeName": "node-a", "runtime": {"imageFs": {"usedBytes": 3221225472, "capacityBytes": 53687091200, "availableBytes": 50465865728}}}}`
+
+	var summary SummaryStatsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &summary))
+
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, NodeImageFsStats{
+		NodeName:             "node-a",
+		ImageFsUsedBytes:     3 << 30,
+		ImageFsCapacityBytes: 50 << 30,
+		Timestamp:            timestamp,
+	}, nodeImageFsStatsFromSummary("node-a", &summary, timestamp))
+}
+
+func TestPodNetworkStatsFromSummary(t *testing.T) {
+	body := `{
+		"node": {"nodeName": "node-a"},
+		"pods": [{
+			"podRef": {"name": "web-0", "namespace": "shop"},
+			"network": {"rxBytes": 123456, "txBytes": 654321},
+			"ephemeral-storage": {"usedBytes": 7340032, "capacityBytes": 104857600}
+		}]
+	}`
+
+	var summary SummaryStatsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &summary))
+
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, []PodNetworkStats{{
+		PodName:           "web-0",
+		PodNamespace:      "shop",
+		NodeName:          "node-a",
+		RxBytes:           123456,
+		TxBytes:           654321,
+		EphemeralUsed:     7340032,
+		EphemeralCapacity: 104857600,
+		Timestamp:         timestamp,
+	}}, podNetworkStatsFromSummary("node-a", &summary, timestamp))
+}
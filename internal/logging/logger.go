@@ -9,19 +9,14 @@ import (
 
 // NewLogger creates a new structured logger with the specified level, format, and file path.
 func NewLogger(level, format, filePath string) (*zap.Logger, error) {
-	var zapLevel zapcore.Level
-	switch level {
-	case "debug":
-		zapLevel = zapcore.DebugLevel
-	case "info":
-		zapLevel = zapcore.InfoLevel
-	case "warn":
-		zapLevel = zapcore.WarnLevel
-	case "error":
-		zapLevel = zapcore.ErrorLevel
-	default:
-		zapLevel = zapcore.InfoLevel
-	}
+	logger, _, err := NewLoggerWithLevel(level, format, filePath)
+	return logger, err
+}
+
+// NewLoggerWithLevel creates a logger like NewLogger and also returns the
+// atomic level controlling it, so the level can be changed at runtime.
+func NewLoggerWithLevel(level, format, filePath string) (*zap.Logger, zap.AtomicLevel, error) {
+	atomicLevel := zap.NewAtomicLevelAt(ParseLevel(level))
 
 	encoding := "json"
 	if format == "console" {
@@ -34,7 +29,7 @@ func NewLogger(level, format, filePath string) (*zap.Logger, error) {
 	}
 
 	config := zap.Config{
-		Level:       zap.NewAtomicLevelAt(zapLevel),
+		Level:       atomicLevel,
 		Development: false,
 		Sampling: &zap.SamplingConfig{
 			Initial:    100,
@@ -59,7 +54,24 @@ func NewLogger(level, format, filePath string) (*zap.Logger, error) {
 		ErrorOutputPaths: []string{"stderr"},
 	}
 
-	return config.Build()
+	logger, err := config.Build()
+	return logger, atomicLevel, err
+}
+
+// ParseLevel converts a configured level name to a zap level, defaulting to info
+func ParseLevel(level string) zapcore.Level {
+	switch level {
+	case "debug":
+		return zapcore.DebugLevel
+	case "info":
+		return zapcore.InfoLevel
+	case "warn":
+		return zapcore.WarnLevel
+	case "error":
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
 }
 
 // twelveHourTimeEncoder formats timestamps in a human-readable 12-hour clock with AM/PM.
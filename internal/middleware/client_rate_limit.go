@@ -0,0 +1,278 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/auth"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// ClientRateLimitConfig configures per-client request throttling. A zero
+// per-minute limit disables throttling for that route class.
+type ClientRateLimitConfig struct {
+	ReadsPerMinute  int
+	ReadBurst       int
+	WritesPerMinute int
+	WriteBurst      int
+	ExemptPaths     []string
+	// TrustedProxies lists the CIDRs or addresses of reverse proxies whose
+	// X-Forwarded-For and X-Real-IP headers identify the client. Requests from
+	// any other peer are keyed on the peer address, so the headers cannot be
+	// spoofed to obtain a fresh bucket.
+	TrustedProxies []string
+}
+
+// DefaultClientRateLimitExemptPaths are never throttled so probes keep working
+var DefaultClientRateLimitExemptPaths = []string{"/healthz", "/readyz", "/metrics"}
+
+// clientLimiter tracks a token bucket and when it was last used
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// ClientRateLimiter throttles requests per authenticated identity, or per
+// client IP when unauthenticated, using separate token buckets for reads and writes
+type ClientRateLimiter struct {
+	logger   *zap.Logger
+	config   ClientRateLimitConfig
+	exempt   map[string]bool
+	trusted  []*net.IPNet
+	limiters map[string]*clientLimiter
+	mutex    sync.Mutex
+	now      func() time.Time
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// NewClientRateLimiter creates a new per-client rate limiter
+func NewClientRateLimiter(logger *zap.Logger, config ClientRateLimitConfig) *ClientRateLimiter {
+	exempt := make(map[string]bool, len(config.ExemptPaths))
+	for _, path := range config.ExemptPaths {
+		exempt[path] = true
+	}
+
+	trusted, err := ParseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		logger.Warn("Ignoring invalid trusted proxies for client rate limiting", zap.Error(err))
+		trusted = nil
+	}
+
+	return &ClientRateLimiter{
+		logger:   logger,
+		config:   config,
+		exempt:   exempt,
+		trusted:  trusted,
+		limiters: make(map[string]*clientLimiter),
+		now:      time.Now,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// ParseTrustedProxies parses CIDRs and bare IP addresses into networks
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if ip := net.ParseIP(proxy); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// Middleware returns the rate limiting middleware handler
+func (l *ClientRateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.exempt[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		class, perMinute, burst := "read", l.config.ReadsPerMinute, l.config.ReadBurst
+		if isWriteMethod(r.Method) {
+			class, perMinute, burst = "write", l.config.WritesPerMinute, l.config.WriteBurst
+		}
+		if perMinute <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		identity := l.clientIdentity(r)
+		now := l.now()
+		limiter := l.getLimiter(class+"|"+identity, perMinute, burst, now)
+
+		reservation := limiter.ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); !reservation.OK() || delay > 0 {
+			reservation.CancelAt(now)
+
+			retryAfter := int(math.Ceil(delay.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+
+			l.logger.Debug("Client rate limit exceeded",
+				zap.String("client", identity),
+				zap.String("class", class),
+				zap.String("path", r.URL.Path),
+				zap.Int("retryAfterSeconds", retryAfter))
+
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"Rate limit exceeded","code":"RATE_LIMIT_EXCEEDED"}`))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Cleanup removes limiters that have been idle longer than maxIdle
+func (l *ClientRateLimiter) Cleanup(maxIdle time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	cutoff := l.now().Add(-maxIdle)
+	for key, entry := range l.limiters {
+		if entry.lastSeen.Before(cutoff) {
+			delete(l.limiters, key)
+		}
+	}
+}
+
+// StartCleanup periodically removes limiters idle for longer than interval
+// until Stop is called
+func (l *ClientRateLimiter) StartCleanup(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				l.Cleanup(interval)
+			case <-l.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the cleanup loop started by StartCleanup
+func (l *ClientRateLimiter) Stop() {
+	l.stopOnce.Do(func() {
+		close(l.stopCh)
+	})
+}
+
+// getLimiter gets or creates the token bucket for a client and route class
+func (l *ClientRateLimiter) getLimiter(key string, perMinute, burst int, now time.Time) *rate.Limiter {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if entry, exists := l.limiters[key]; exists {
+		entry.lastSeen = now
+		return entry.limiter
+	}
+
+	if burst <= 0 {
+		burst = 1
+	}
+	limiter := rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), burst)
+	l.limiters[key] = &clientLimiter{limiter: limiter, lastSeen: now}
+
+	return limiter
+}
+
+// clientIdentity returns the authenticated user ID, or the client IP otherwise.
+// The forwarded client IP is only used when the connection comes from a
+// trusted proxy; any other peer is identified by its own address.
+func (l *ClientRateLimiter) clientIdentity(r *http.Request) string {
+	if user, ok := auth.UserFromContext(r.Context()); ok && user != nil {
+		if user.ID != "" {
+			return "user:" + user.ID
+		}
+		if user.Email != "" {
+			return "user:" + user.Email
+		}
+	}
+
+	peer := hostOnly(r.RemoteAddr)
+	if addr, ok := PeerAddressFromContext(r.Context()); ok {
+		peer = hostOnly(addr)
+		if l.isTrustedProxy(peer) {
+			return "ip:" + hostOnly(r.RemoteAddr)
+		}
+	}
+	return "ip:" + peer
+}
+
+// isTrustedProxy reports whether a peer address belongs to a trusted proxy
+func (l *ClientRateLimiter) isTrustedProxy(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range l.trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// peerAddressKey is the context key for the connection's peer address
+type peerAddressKey struct{}
+
+// PeerAddress records the connection's peer address in the request context.
+// It must run before middleware such as chi's RealIP rewrites RemoteAddr from
+// client-supplied headers.
+func PeerAddress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), peerAddressKey{}, r.RemoteAddr)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// PeerAddressFromContext returns the peer address recorded by PeerAddress
+func PeerAddressFromContext(ctx context.Context) (string, bool) {
+	addr, ok := ctx.Value(peerAddressKey{}).(string)
+	return addr, ok
+}
+
+// hostOnly strips the port from an address, if present
+func hostOnly(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// isWriteMethod reports whether the HTTP method changes state
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/auth"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newTestClientRateLimiter(config ClientRateLimitConfig, now *time.Time) (*ClientRateLimiter, http.Handler) {
+	limiter := NewClientRateLimiter(zap.NewNop(), config)
+	limiter.now = func() time.Time { return *now }
+
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	return limiter, handler
+}
+
+func serve(handler http.Handler, method, path, remoteAddr string, user *auth.User) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = remoteAddr
+	if user != nil {
+		req = req.WithContext(auth.WithUser(context.Background(), user))
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestClientRateLimiterBurstAndRecovery(t *testing.T) {
+	now := time.Now()
+	_, handler := newTestClientRateLimiter(ClientRateLimitConfig{
+		ReadsPerMinute: 60, // one token per second
+		ReadBurst:      3,
+	}, &now)
+
+	for i := 0; i < 3; i++ {
+		rec := serve(handler, http.MethodGet, "/api/v1/pods", "10.0.0.1:1234", nil)
+		assert.Equal(t, http.StatusOK, rec.Code, "request %d within burst", i)
+	}
+
+	rec := serve(handler, http.MethodGet, "/api/v1/pods", "10.0.0.1:1234", nil)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	// A different client is unaffected
+	rec = serve(handler, http.MethodGet, "/api/v1/pods", "10.0.0.2:1234", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// After the refill window the original client recovers
+	now = now.Add(time.Second)
+	rec = serve(handler, http.MethodGet, "/api/v1/pods", "10.0.0.1:1234", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestClientRateLimiterSeparatesReadsAndWrites(t *testing.T) {
+	now := time.Now()
+	_, handler := newTestClientRateLimiter(ClientRateLimitConfig{
+		ReadsPerMinute:  60,
+		ReadBurst:       10,
+		WritesPerMinute: 6,
+		WriteBurst:      1,
+	}, &now)
+	user := &auth.User{ID: "alice"}
+
+	assert.Equal(t, http.StatusOK, serve(handler, http.MethodPost, "/api/v1/scale", "10.0.0.1:1", user).Code)
+
+	rec := serve(handler, http.MethodPost, "/api/v1/scale", "10.0.0.9:1", user)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "limits follow the identity, not the IP")
+	assert.Equal(t, "10", rec.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, serve(handler, http.MethodGet, "/api/v1/pods", "10.0.0.1:1", user).Code)
+}
+
+func TestClientRateLimiterExemptPaths(t *testing.T) {
+	now := time.Now()
+	_, handler := newTestClientRateLimiter(ClientRateLimitConfig{
+		ReadsPerMinute: 1,
+		ReadBurst:      1,
+		ExemptPaths:    DefaultClientRateLimitExemptPaths,
+	}, &now)
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, serve(handler, http.MethodGet, "/healthz", "10.0.0.1:1", nil).Code)
+	}
+}
+
+func TestClientRateLimiterCleanup(t *testing.T) {
+	now := time.Now()
+	limiter, handler := newTestClientRateLimiter(ClientRateLimitConfig{ReadsPerMinute: 60, ReadBurst: 1}, &now)
+
+	serve(handler, http.MethodGet, "/api/v1/pods", "10.0.0.1:1", nil)
+	assert.Len(t, limiter.limiters, 1)
+
+	now = now.Add(time.Hour)
+	limiter.Cleanup(10 * time.Minute)
+	assert.Empty(t, limiter.limiters)
+}
+
+func TestClientRateLimiterIgnoresForwardedHeadersFromUntrustedPeers(t *testing.T) {
+	now := time.Now()
+	limiter := NewClientRateLimiter(zap.NewNop(), ClientRateLimitConfig{
+		ReadsPerMinute: 60,
+		ReadBurst:      1,
+		TrustedProxies: []string{"10.1.0.0/16"},
+	})
+	limiter.now = func() time.Time { return now }
+	handler := PeerAddress(chimiddleware.RealIP(limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))))
+
+	forwarded := func(peer, clientIP string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+		req.RemoteAddr = peer
+		req.Header.Set("X-Forwarded-For", clientIP)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// A direct client cannot escape its bucket by rotating X-Forwarded-For
+	assert.Equal(t, http.StatusOK, forwarded("203.0.113.5:1000", "192.0.2.1"))
+	assert.Equal(t, http.StatusTooManyRequests, forwarded("203.0.113.5:1000", "192.0.2.2"))
+
+	// Behind a trusted proxy each forwarded client gets its own bucket
+	assert.Equal(t, http.StatusOK, forwarded("10.1.2.3:1000", "192.0.2.1"))
+	assert.Equal(t, http.StatusOK, forwarded("10.1.2.3:1000", "192.0.2.2"))
+	assert.Equal(t, http.StatusTooManyRequests, forwarded("10.1.2.3:1000", "192.0.2.2"))
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	networks, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.7", "::1"})
+	assert.NoError(t, err)
+	assert.Len(t, networks, 3)
+	assert.True(t, networks[1].Contains(net.ParseIP("192.0.2.7")))
+	assert.False(t, networks[1].Contains(net.ParseIP("192.0.2.8")))
+
+	_, err = ParseTrustedProxies([]string{"not-an-ip"})
+	assert.Error(t, err)
+}
+
+func TestClientRateLimiterStopEndsCleanup(t *testing.T) {
+	limiter := NewClientRateLimiter(zap.NewNop(), ClientRateLimitConfig{})
+	limiter.StartCleanup(time.Millisecond)
+	limiter.Stop()
+	limiter.Stop() // idempotent
+
+	select {
+	case <-limiter.stopCh:
+	default:
+		t.Fatal("stop channel not closed")
+	}
+}
@@ -182,6 +182,13 @@ func (ecw *errorCapturingWriter) Write(data []byte) (int, error) {
 	return ecw.ResponseWriter.Write(data)
 }
 
+// Flush implements http.Flusher for streaming responses
+func (ecw *errorCapturingWriter) Flush() {
+	if f, ok := ecw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 // Hijack implements http.Hijacker for WebSocket support
 func (ecw *errorCapturingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	if h, ok := ecw.ResponseWriter.(http.Hijacker); ok {
@@ -29,8 +29,9 @@ func NewETagMiddleware(logger *zap.Logger) *ETagMiddleware {
 // Middleware returns the ETag middleware handler
 func (em *ETagMiddleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Skip WebSocket upgrade requests early (before any response wrapper)
-		if r.Header.Get("Upgrade") == "websocket" {
+		// Skip WebSocket upgrades and other streams early (before any response
+		// wrapper), since the recorder holds the whole body in memory
+		if IsStreamingRequest(r) {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -132,10 +133,11 @@ func (em *ETagMiddleware) shouldSkipETag(path string) bool {
 		return true
 	}
 
-	// Skip for any WebSocket-related paths
+	// Skip for any WebSocket or Server-Sent Events paths
 	if strings.Contains(strings.ToLower(path), "websocket") ||
 		strings.Contains(strings.ToLower(path), "/ws") ||
-		strings.Contains(path, "/stream/") {
+		strings.Contains(path, "/stream/") ||
+		strings.HasSuffix(path, "/sse") {
 		return true
 	}
 
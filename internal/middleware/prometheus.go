@@ -29,16 +29,6 @@ func PrometheusMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// RequestIDResponseMiddleware adds the request ID to response headers
-func RequestIDResponseMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if reqID := middleware.GetReqID(r.Context()); reqID != "" {
-			w.Header().Set("X-Request-ID", reqID)
-		}
-		next.ServeHTTP(w, r)
-	})
-}
-
 // sanitizePath normalizes URL paths for metrics to prevent cardinality explosion
 func sanitizePath(path string) string {
 	// Replace dynamic path segments with placeholders
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/aaronlmathis/kaptn/internal/logging"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// ReadOnlyBlockedVerbs are the kinds of operation rejected in read-only mode
+var ReadOnlyBlockedVerbs = []string{"create", "update", "patch", "delete", "scale", "apply", "exec", "cordon", "drain"}
+
+// DefaultReadOnlyExemptRoutes are routes of the write groups that do not
+// change the cluster: log streams and RBAC YAML generation and dry runs
+var DefaultReadOnlyExemptRoutes = []string{
+	"/logs/stream",
+	"/logs/stream/{streamId}",
+	"/rbac/generate",
+	"/rbac/dry-run",
+}
+
+// ReadOnly rejects every request with 403 before it reaches a handler, so the
+// routes it guards cannot change the cluster regardless of the caller's RBAC.
+// It is meant for the write route groups, where chi has already matched the
+// route; routes whose pattern ends with one of exemptRoutes are let through.
+func ReadOnly(logger *zap.Logger, exemptRoutes []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pattern := r.URL.Path
+			if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+				pattern = routeCtx.RoutePattern()
+			}
+			for _, exempt := range exemptRoutes {
+				if strings.HasSuffix(pattern, exempt) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			logging.FromContext(r.Context(), logger).Info("Rejected mutating request in read-only mode",
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":        "Kaptn is running in read-only mode; " + strings.Join(ReadOnlyBlockedVerbs, ", ") + " operations are disabled",
+				"status":       "error",
+				"blockedVerbs": ReadOnlyBlockedVerbs,
+			})
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// readOnlyRouter mirrors the server's layout: reads outside the write group,
+// mutations inside it behind the read-only guard
+func readOnlyRouter() http.Handler {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	r := chi.NewRouter()
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Get("/pods", ok)
+		r.Group(func(r chi.Router) {
+			r.Use(ReadOnly(zap.NewNop(), DefaultReadOnlyExemptRoutes))
+			r.Delete("/resources", ok)
+			r.Post("/scale", ok)
+			r.Get("/exec/{sessionId}", ok)
+			r.Post("/logs/stream", ok)
+			r.Delete("/logs/stream/{streamId}", ok)
+		})
+	})
+	return r
+}
+
+func TestReadOnlyRejectsMutations(t *testing.T) {
+	router := readOnlyRouter()
+
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodDelete, "/api/v1/resources"},
+		{http.MethodPost, "/api/v1/scale"},
+		{http.MethodGet, "/api/v1/exec/abc"},
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+		require.Equal(t, http.StatusForbidden, rec.Code, "%s %s", tc.method, tc.path)
+
+		var body struct {
+			Error        string   `json:"error"`
+			Status       string   `json:"status"`
+			BlockedVerbs []string `json:"blockedVerbs"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "error", body.Status)
+		assert.Contains(t, body.Error, "read-only mode")
+		assert.Contains(t, body.BlockedVerbs, "delete")
+		assert.Contains(t, body.BlockedVerbs, "exec")
+	}
+}
+
+func TestReadOnlyAllowsReadsAndStreams(t *testing.T) {
+	router := readOnlyRouter()
+
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/pods"},
+		{http.MethodPost, "/api/v1/logs/stream"},
+		{http.MethodDelete, "/api/v1/logs/stream/s-1"},
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, "%s %s", tc.method, tc.path)
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	"github.com/aaronlmathis/kaptn/internal/logging"
+	"go.uber.org/zap"
+)
+
+// Recoverer converts a panic in a downstream handler into a logged 500 with the
+// API error envelope. The log entry carries the panic value, the stack and the
+// request ID assigned by TracingMiddleware, so it must be installed after it.
+// http.ErrAbortHandler is re-panicked so net/http can abort the response.
+func Recoverer(logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rvr := recover()
+				if rvr == nil {
+					return
+				}
+				if rvr == http.ErrAbortHandler {
+					panic(rvr)
+				}
+
+				requestID := logging.RequestIDFromContext(r.Context())
+				logging.FromContext(r.Context(), logger).Error("Panic recovered in HTTP handler",
+					zap.String("panic", fmt.Sprint(rvr)),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.ByteString("stack", debug.Stack()))
+
+				// An upgraded connection has been hijacked and cannot carry a response
+				if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"error":     "internal server error",
+					"status":    "error",
+					"requestId": requestID,
+				})
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RecoverGoroutine logs and swallows a panic in a background goroutine, such as
+// a WebSocket pump, so it cannot crash the server. It must be deferred directly:
+//
+//	defer middleware.RecoverGoroutine(logger, "timeseries-ws-writer")
+func RecoverGoroutine(logger *zap.Logger, name string) {
+	rvr := recover()
+	if rvr == nil {
+		return
+	}
+	logger.Error("Panic recovered in goroutine",
+		zap.String("goroutine", name),
+		zap.String("panic", fmt.Sprint(rvr)),
+		zap.ByteString("stack", debug.Stack()))
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRecovererReturnsStructured500(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		var m map[string]interface{}
+		_ = m["missing"].(map[string]interface{})
+	})
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(TracingMiddleware(logger)(Recoverer(logger)(mux)))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/panic", nil)
+	require.NoError(t, err)
+	req.Header.Set(RequestIDHeader, "panic-req-1")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "error", body["status"])
+	assert.Equal(t, "internal server error", body["error"])
+	assert.Equal(t, "panic-req-1", body["requestId"])
+
+	entries := logs.FilterMessage("Panic recovered in HTTP handler").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "panic-req-1", fields["requestId"])
+	assert.Equal(t, "/panic", fields["path"])
+	assert.Contains(t, fields["panic"], "interface conversion")
+	assert.Contains(t, fields["stack"], "recover_test.go")
+
+	// The server keeps serving after the panic
+	okResp, err := http.Get(server.URL + "/ok")
+	require.NoError(t, err)
+	okResp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, okResp.StatusCode)
+}
+
+func TestRecovererRepanicsAbortHandler(t *testing.T) {
+	handler := Recoverer(zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+func TestRecoverGoroutine(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer RecoverGoroutine(logger, "test-pump")
+		panic("pump failed")
+	}()
+	<-done
+
+	entries := logs.FilterMessage("Panic recovered in goroutine").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "test-pump", entries[0].ContextMap()["goroutine"])
+	assert.Equal(t, "pump failed", entries[0].ContextMap()["panic"])
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// IsWatchRequest reports whether a request asks for a Kubernetes-style watch
+// stream rather than a list
+func IsWatchRequest(r *http.Request) bool {
+	watch := r.URL.Query().Get("watch")
+	return watch == "true" || watch == "1"
+}
+
+// isDownloadPath reports whether a path streams a generated archive, such as
+// a namespace backup, whose size and duration are unbounded
+func isDownloadPath(path string) bool {
+	return strings.HasPrefix(path, "/api/v1/namespaces/") && strings.HasSuffix(path, "/backup")
+}
+
+// IsStreamingRequest reports whether a request holds its response open as a
+// long-lived stream: WebSocket upgrades, Server-Sent Events, watches and
+// archive downloads. Such responses must be neither cut off by the request
+// timeout nor buffered.
+func IsStreamingRequest(r *http.Request) bool {
+	if r.Header.Get("Upgrade") == "websocket" || strings.HasSuffix(r.URL.Path, "/sse") {
+		return true
+	}
+	return r.Method == http.MethodGet && (IsWatchRequest(r) || isDownloadPath(r.URL.Path))
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestIsStreamingRequest(t *testing.T) {
+	upgrade := httptest.NewRequest(http.MethodGet, "/api/v1/pods/exec", nil)
+	upgrade.Header.Set("Upgrade", "websocket")
+	assert.True(t, IsStreamingRequest(upgrade))
+
+	for target, streaming := range map[string]bool{
+		"/api/v1/events/sse":                            true,
+		"/api/v1/resources/core/v1/pods?watch=true":     true,
+		"/api/v1/resources/core/v1/pods?watch=1":        true,
+		"/api/v1/resources/core/v1/pods?watch=false":    false,
+		"/api/v1/resources/core/v1/pods":                false,
+		"/api/v1/resources/core/v1/pods?labelSelector=": false,
+		"/api/v1/namespaces/team-a/backup":              true,
+		"/api/v1/namespaces/team-a":                     false,
+	} {
+		assert.Equal(t, streaming, IsStreamingRequest(httptest.NewRequest(http.MethodGet, target, nil)), target)
+	}
+}
+
+func TestETagMiddlewareDoesNotBufferWatches(t *testing.T) {
+	rec := httptest.NewRecorder()
+	handler := NewETagMiddleware(zap.NewNop()).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, buffered := w.(*ETagResponseRecorder)
+		assert.False(t, buffered, "watch responses must stream unbuffered")
+		w.Write([]byte(`{"type":"ADDED"}`))
+	}))
+
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/resources/core/v1/pods?watch=true", nil))
+	assert.Empty(t, rec.Header().Get("ETag"))
+}
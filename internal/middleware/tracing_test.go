@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaronlmathis/kaptn/internal/logging"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestTracingMiddlewareReusesProvidedID(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+
+	var seenID, chiID string
+	handler := TracingMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenID = logging.RequestIDFromContext(r.Context())
+		chiID = middleware.GetReqID(r.Context())
+		logging.FromContext(r.Context(), zap.NewNop()).Info("handling request")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	req.Header.Set(RequestIDHeader, "client-supplied-123")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "client-supplied-123", seenID)
+	assert.Equal(t, "client-supplied-123", chiID)
+	assert.Equal(t, "client-supplied-123", rec.Header().Get(RequestIDHeader))
+
+	entries := logs.FilterMessage("handling request").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "client-supplied-123", entries[0].ContextMap()["requestId"])
+}
+
+func TestTracingMiddlewareGeneratesID(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+
+	var seenID string
+	handler := TracingMiddleware(zap.New(core))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenID = logging.RequestIDFromContext(r.Context())
+		logging.FromContext(r.Context(), zap.NewNop()).Info("handling request")
+	}))
+
+	for _, incoming := range []string{"", "bad id\nwith newline"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if incoming != "" {
+			req.Header.Set(RequestIDHeader, incoming)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Len(t, seenID, 32)
+		assert.NotEqual(t, incoming, seenID)
+		assert.Equal(t, seenID, rec.Header().Get(RequestIDHeader))
+	}
+
+	for _, entry := range logs.All() {
+		assert.NotEmpty(t, entry.ContextMap()["requestId"])
+	}
+}
@@ -0,0 +1,47 @@
+package aggregator
+
+import "github.com/aaronlmathis/kaptn/internal/timeseries"
+
+// seriesAggregations maps series key prefixes to the reducer used when their
+// points are folded into low resolution steps. Rates keep the peak of each
+// step so transient bursts survive downsampling; capacities change rarely, so
+// the latest value is the truthful one. Everything else uses the store's
+// default aggregation.
+var seriesAggregations = map[string]timeseries.Reducer{
+	// Rates
+	timeseries.ClusterNetRxBps:               timeseries.ReducerMax,
+	timeseries.ClusterNetTxBps:               timeseries.ReducerMax,
+	timeseries.ClusterPodsRestartsRate:       timeseries.ReducerMax,
+	timeseries.NodeNetRxBase:                 timeseries.ReducerMax,
+	timeseries.NodeNetTxBase:                 timeseries.ReducerMax,
+	timeseries.NodeNetRxPpsBase:              timeseries.ReducerMax,
+	timeseries.NodeNetTxPpsBase:              timeseries.ReducerMax,
+	timeseries.PodNetRxBase:                  timeseries.ReducerMax,
+	timeseries.PodNetTxBase:                  timeseries.ReducerMax,
+	timeseries.PodRestartsRateBase:           timeseries.ReducerMax,
+	timeseries.NamespacePodsRestartsRateBase: timeseries.ReducerMax,
+
+	// Capacities
+	timeseries.ClusterCPUCapacityCores:     timeseries.ReducerLast,
+	timeseries.ClusterMemCapacityBytes:     timeseries.ReducerLast,
+	timeseries.ClusterCPUAllocatableCores:  timeseries.ReducerLast,
+	timeseries.ClusterMemAllocatableBytes:  timeseries.ReducerLast,
+	timeseries.ClusterFsImageCapacityBytes: timeseries.ReducerLast,
+	timeseries.NodeCapacityCPUBase:         timeseries.ReducerLast,
+	timeseries.NodeCapacityMemBase:         timeseries.ReducerLast,
+	timeseries.NodeCapacityPodsBase:        timeseries.ReducerLast,
+	timeseries.NodeAllocatableCPUBase:      timeseries.ReducerLast,
+	timeseries.NodeAllocatableMemBase:      timeseries.ReducerLast,
+	timeseries.NodeAllocatablePodsBase:     timeseries.ReducerLast,
+	timeseries.NodeFsCapacityBase:          timeseries.ReducerLast,
+	timeseries.NodeImageFsCapacityBase:     timeseries.ReducerLast,
+}
+
+// RegisterAggregations tells the store how to downsample the series the
+// aggregator writes. Series pick their reducer when created, so this must run
+// before a snapshot is restored into the store.
+func RegisterAggregations(store timeseries.Store) {
+	for prefix, reducer := range seriesAggregations {
+		store.SetAggregation(prefix, reducer)
+	}
+}
@@ -0,0 +1,47 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func TestAggregatorRegistersLoResAggregations(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	start := time.Now().Truncate(5 * time.Second)
+	keys := []string{
+		timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxBase, "node-a"),
+		timeseries.GenerateNodeSeriesKey(timeseries.NodeCapacityMemBase, "node-a"),
+		timeseries.ClusterCPUUsedCores,
+	}
+	for _, key := range keys {
+		series := store.Upsert(key)
+		for i, v := range []float64{1, 9, 2} {
+			series.Add(timeseries.NewPoint(start.Add(time.Duration(i)*time.Second), v))
+		}
+		series.Add(timeseries.NewPoint(start.Add(5*time.Second), 0))
+	}
+
+	want := map[string]float64{
+		keys[0]: 9, // A burst in a rate keeps its peak
+		keys[1]: 2, // Capacity keeps its latest value
+		keys[2]: 4, // Everything else is averaged
+	}
+	for key, value := range want {
+		series, ok := store.Get(key)
+		require.True(t, ok)
+		points := series.GetAll(timeseries.Lo)
+		require.Len(t, points, 1, key)
+		assert.Equal(t, value, points[0].V, key)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	metricsv1beta1types "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
 
+	"github.com/aaronlmathis/kaptn/internal/k8s/units"
 	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
 	"github.com/aaronlmathis/kaptn/internal/metrics"
 	"github.com/aaronlmathis/kaptn/internal/timeseries"
@@ -33,7 +34,16 @@ type hostSnap struct {
 	LastTs        time.Time // Timestamp of last measurement
 }
 
-type nsRestartState struct {
+// podNetSnap holds a pod's previous network counters for rate calculation
+type podNetSnap struct {
+	LastRx uint64    // Last received bytes
+	LastTx uint64    // Last transmitted bytes
+	LastTs time.Time // Timestamp of last measurement
+}
+
+// restartState holds the previous restart total of a namespace or pod for
+// rate calculation
+type restartState struct {
 	lastTotal int64
 	lastTime  time.Time
 }
@@ -48,10 +58,27 @@ type Aggregator struct {
 	nodesAdapter      *kubemetrics.NodesAdapter
 	apiMetricsAdapter *kubemetrics.APIMetricsAdapter
 	summaryAdapter    *kubemetrics.SummaryStatsAdapter
+	customMetrics     *kubemetrics.CustomMetricsAdapter
+
+	// Source of per-kind object counts, typically the informer caches
+	objectCounter ObjectCounter
+
+	// Source of Pods for filtering terminated pods, typically the informer caches
+	pods PodLister
+
+	// Source of EndpointSlices for service readiness, typically the informer caches
+	endpointSlices EndpointSliceLister
+
+	// Source of PersistentVolumeClaims for storage phase counts, typically the informer caches
+	pvcs PersistentVolumeClaimLister
+
+	// Source of Deployments and DaemonSets for replica availability, typically the informer caches
+	workloads WorkloadLister
 
 	// State management
 	mu                  sync.RWMutex
 	hostSnapshots       map[string]*hostSnap
+	podNetSnapshots     map[string]*podNetSnap
 	lastCapacityRefresh time.Time
 
 	// New: poll interval tracking for gating expensive operations
@@ -62,15 +89,32 @@ type Aggregator struct {
 	// New: restart tracking for rate calculation
 	lastRestartsTotal int64
 	lastRestartsTime  time.Time
-	nsRestartsState   map[string]*nsRestartState
+	nsRestartsState   map[string]*restartState
+	podRestartsState  map[string]*restartState
+
+	// Restart storm detection state
+	restartSamples     []restartSample
+	stormActive        bool
+	stormSince         time.Time
+	stormRatePerMinute float64
+	nsStormRestarts    map[string]float64
 
 	// Configuration
 	config                  Config
 	capacityRefreshInterval time.Duration
 
+	// Deduplicates repeated collector failure logs
+	failureLogs collectorLogThrottle
+
+	// Time source, replaceable in tests
+	clock Clock
+
 	// Shutdown management
 	stopCh chan struct{}
 	done   chan struct{}
+
+	// Signals the run loop that the tick interval changed
+	reconfigureCh chan struct{}
 }
 
 // Config holds configuration for the aggregator
@@ -85,29 +129,169 @@ type Config struct {
 	StateReconcileInterval time.Duration `yaml:"state_reconcile_interval"` // Core API counts
 	PruneInterval          time.Duration `yaml:"prune_interval"`           // Background pruning
 
+	// Restart storm detection
+	RestartStormThreshold float64       `yaml:"restart_storm_threshold"` // Restarts per minute
+	RestartStormWindow    time.Duration `yaml:"restart_storm_window"`
+
+	// Identical collector failures are logged at most once per interval
+	ErrorLogInterval time.Duration `yaml:"error_log_interval"`
+
+	// Namespaces scanned by the per-namespace and per-pod collectors. An empty
+	// allow list admits every namespace; the deny list always wins.
+	NamespaceAllowList []string `yaml:"namespace_allow_list"`
+	NamespaceDenyList  []string `yaml:"namespace_deny_list"`
+
 	// Feature flags
 	Enabled                     bool `yaml:"enabled"`
 	DisableNetworkIfUnavailable bool `yaml:"disable_network_if_unavailable"`
 
 	// TLS configuration
 	InsecureTLS bool `yaml:"insecure_tls"`
+
+	// Kubelet Summary API access (API server proxy or direct to kubelets)
+	SummaryAPI kubemetrics.SummaryAPIConfig `yaml:"summary_api"`
+
+	// Metrics recorded from custom.metrics.k8s.io and external.metrics.k8s.io
+	CustomMetrics   []kubemetrics.CustomMetricQuery   `yaml:"custom_metrics"`
+	ExternalMetrics []kubemetrics.ExternalMetricQuery `yaml:"external_metrics"`
 }
 
-// DefaultConfig returns the default aggregator configuration
+// DefaultConfig returns the fast aggregator configuration used by examples
+// and tests. Its short poll intervals make charts fill quickly but put more
+// load on the API server than a long-running deployment needs; servers use
+// ProductionConfig.
 func DefaultConfig() Config {
 	return Config{
 		TickInterval:                1 * time.Second,
 		CapacityRefreshInterval:     30 * time.Second,
-		ResourcePollInterval:        5 * time.Second,  // Reduced from 15s for faster testing
-		SummaryPollInterval:         10 * time.Second, // Reduced from 30s for faster testing
-		StateReconcileInterval:      10 * time.Second, // Reduced from 60s for faster testing
+		ResourcePollInterval:        5 * time.Second,
+		SummaryPollInterval:         10 * time.Second,
+		StateReconcileInterval:      10 * time.Second,
 		PruneInterval:               30 * time.Second, // Background pruning
+		RestartStormThreshold:       10,               // Restarts per minute across the cluster
+		RestartStormWindow:          2 * time.Minute,
+		ErrorLogInterval:            5 * time.Minute,
 		Enabled:                     true,
 		DisableNetworkIfUnavailable: true,
 	}
 }
 
-// NewAggregator creates a new metrics aggregator
+// ProductionConfig returns DefaultConfig with conservative poll intervals for
+// long-running servers. The tick stays at 1s so live charts keep moving, but
+// the Metrics API, kubelet Summary API and object listings are polled less
+// often.
+func ProductionConfig() Config {
+	config := DefaultConfig()
+	config.CapacityRefreshInterval = 60 * time.Second
+	config.ResourcePollInterval = 15 * time.Second
+	config.SummaryPollInterval = 30 * time.Second
+	config.StateReconcileInterval = 60 * time.Second
+	return config
+}
+
+const (
+	// minTickInterval is the shortest tick; anything faster spins the
+	// collectors without producing more useful points
+	minTickInterval = 100 * time.Millisecond
+	// maxPollInterval is the longest poll interval; slower polls leave the
+	// default one-hour window without data
+	maxPollInterval = time.Hour
+)
+
+// Validate applies defaults to zero-valued intervals, rejects negative
+// settings, ticks faster than 100ms and polls slower than an hour, and raises
+// poll intervals that are shorter than the tick interval, since a poll can
+// never run more often than the tick that drives it.
+func (c *Config) Validate() error {
+	defaults := DefaultConfig()
+
+	intervals := []struct {
+		name     string
+		value    *time.Duration
+		fallback time.Duration
+		poll     bool
+	}{
+		{"tick_interval", &c.TickInterval, defaults.TickInterval, false},
+		{"capacity_refresh_interval", &c.CapacityRefreshInterval, defaults.CapacityRefreshInterval, true},
+		{"resource_poll_interval", &c.ResourcePollInterval, defaults.ResourcePollInterval, true},
+		{"summary_poll_interval", &c.SummaryPollInterval, defaults.SummaryPollInterval, true},
+		{"state_reconcile_interval", &c.StateReconcileInterval, defaults.StateReconcileInterval, true},
+		{"prune_interval", &c.PruneInterval, defaults.PruneInterval, false},
+		{"error_log_interval", &c.ErrorLogInterval, defaults.ErrorLogInterval, false},
+	}
+	for _, interval := range intervals {
+		if *interval.value < 0 {
+			return fmt.Errorf("aggregator %s must be positive, got %s", interval.name, *interval.value)
+		}
+		if *interval.value == 0 {
+			*interval.value = interval.fallback
+		}
+		if interval.poll && *interval.value > maxPollInterval {
+			return fmt.Errorf("aggregator %s must be at most %s, got %s", interval.name, maxPollInterval, *interval.value)
+		}
+	}
+	if c.TickInterval < minTickInterval {
+		return fmt.Errorf("aggregator tick_interval must be at least %s, got %s", minTickInterval, c.TickInterval)
+	}
+
+	// A zero restart storm threshold or window disables detection
+	if c.RestartStormThreshold < 0 {
+		return fmt.Errorf("aggregator restart_storm_threshold must not be negative, got %g", c.RestartStormThreshold)
+	}
+	if c.RestartStormWindow < 0 {
+		return fmt.Errorf("aggregator restart_storm_window must not be negative, got %s", c.RestartStormWindow)
+	}
+
+	for _, list := range []struct {
+		name       string
+		namespaces []string
+	}{
+		{"namespace_allow_list", c.NamespaceAllowList},
+		{"namespace_deny_list", c.NamespaceDenyList},
+	} {
+		for i, namespace := range list.namespaces {
+			if namespace == "" {
+				return fmt.Errorf("aggregator %s[%d] must not be empty", list.name, i)
+			}
+		}
+	}
+
+	c.clampPollIntervals()
+	return nil
+}
+
+// clampPollIntervals raises poll intervals to at least the tick interval
+func (c *Config) clampPollIntervals() {
+	for _, interval := range []*time.Duration{
+		&c.CapacityRefreshInterval,
+		&c.ResourcePollInterval,
+		&c.SummaryPollInterval,
+		&c.StateReconcileInterval,
+	} {
+		if *interval < c.TickInterval {
+			*interval = c.TickInterval
+		}
+	}
+}
+
+// withDefaultIntervals returns c with every interval and restart storm
+// setting replaced by its default, keeping feature flags and API settings
+func (c Config) withDefaultIntervals() Config {
+	defaults := DefaultConfig()
+	c.TickInterval = defaults.TickInterval
+	c.CapacityRefreshInterval = defaults.CapacityRefreshInterval
+	c.ResourcePollInterval = defaults.ResourcePollInterval
+	c.SummaryPollInterval = defaults.SummaryPollInterval
+	c.StateReconcileInterval = defaults.StateReconcileInterval
+	c.PruneInterval = defaults.PruneInterval
+	c.RestartStormThreshold = defaults.RestartStormThreshold
+	c.RestartStormWindow = defaults.RestartStormWindow
+	c.ErrorLogInterval = defaults.ErrorLogInterval
+	return c
+}
+
+// NewAggregator creates a new metrics aggregator. The config is validated
+// first; an invalid config is logged and its intervals replaced by defaults.
 func NewAggregator(
 	logger *zap.Logger,
 	store timeseries.Store,
@@ -116,21 +300,56 @@ func NewAggregator(
 	restConfig *rest.Config,
 	config Config,
 ) *Aggregator {
+	if err := config.Validate(); err != nil {
+		logger.Error("Invalid aggregator configuration, using default intervals", zap.Error(err))
+		config = config.withDefaultIntervals()
+	}
+
+	logger.Info("Aggregator configuration",
+		zap.Duration("tickInterval", config.TickInterval),
+		zap.Duration("capacityRefreshInterval", config.CapacityRefreshInterval),
+		zap.Duration("resourcePollInterval", config.ResourcePollInterval),
+		zap.Duration("summaryPollInterval", config.SummaryPollInterval),
+		zap.Duration("stateReconcileInterval", config.StateReconcileInterval),
+		zap.Duration("pruneInterval", config.PruneInterval),
+		zap.Float64("restartStormThreshold", config.RestartStormThreshold),
+		zap.Duration("restartStormWindow", config.RestartStormWindow),
+		zap.Duration("errorLogInterval", config.ErrorLogInterval),
+	)
+
+	RegisterAggregations(store)
+
+	customMetrics := kubemetrics.NewCustomMetricsAdapter(logger, kubeClient, nil, nil)
+	if restConfig != nil {
+		adapter, err := kubemetrics.NewCustomMetricsAdapterForConfig(logger, kubeClient, restConfig)
+		if err != nil {
+			logger.Warn("Custom metrics clients unavailable", zap.Error(err))
+		} else {
+			customMetrics = adapter
+		}
+	}
+
 	return &Aggregator{
 		logger:                  logger,
 		store:                   store,
 		kubeClient:              kubeClient,
 		hostSnapshots:           make(map[string]*hostSnap),
+		podNetSnapshots:         make(map[string]*podNetSnap),
 		config:                  config,
 		capacityRefreshInterval: config.CapacityRefreshInterval,
+		clock:                   realClock{},
 		stopCh:                  make(chan struct{}),
 		done:                    make(chan struct{}),
-		nsRestartsState:         make(map[string]*nsRestartState),
+		reconfigureCh:           make(chan struct{}, 1),
+		nsRestartsState:         make(map[string]*restartState),
+		podRestartsState:        make(map[string]*restartState),
+		nsStormRestarts:         make(map[string]float64),
 
 		// Initialize adapters
 		nodesAdapter:      kubemetrics.NewNodesAdapter(logger, kubeClient),
 		apiMetricsAdapter: kubemetrics.NewAPIMetricsAdapter(logger, kubeClient, metricsClient),
-		summaryAdapter:    kubemetrics.NewSummaryStatsAdapter(logger, kubeClient, restConfig, config.InsecureTLS),
+		summaryAdapter:    kubemetrics.NewSummaryStatsAdapterWithConfig(logger, kubeClient, restConfig, config.InsecureTLS, config.SummaryAPI),
+		customMetrics:     customMetrics,
 	}
 }
 
@@ -178,7 +397,7 @@ func (a *Aggregator) Stop() {
 func (a *Aggregator) run(ctx context.Context) {
 	defer close(a.done)
 
-	ticker := time.NewTicker(a.config.TickInterval)
+	ticker := time.NewTicker(a.TickInterval())
 	defer ticker.Stop()
 
 	for {
@@ -189,6 +408,8 @@ func (a *Aggregator) run(ctx context.Context) {
 		case <-a.stopCh:
 			a.logger.Info("Aggregator stopped gracefully")
 			return
+		case <-a.reconfigureCh:
+			ticker.Reset(a.TickInterval())
 		case <-ticker.C:
 			a.tick(ctx)
 		}
@@ -197,7 +418,9 @@ func (a *Aggregator) run(ctx context.Context) {
 
 // tick performs one collection cycle
 func (a *Aggregator) tick(ctx context.Context) {
-	now := time.Now()
+	now := a.clock.Now()
+	start := time.Now()
+	defer func() { a.recordSelfMetrics(now, time.Since(start)) }()
 
 	// Refresh node capacities periodically
 	a.mu.RLock()
@@ -211,11 +434,17 @@ func (a *Aggregator) tick(ctx context.Context) {
 		a.refreshNodeCapacities(ctx, now)
 	}
 
+	// Read every kubelet's Summary API once and share it between collectors
+	var summary *summaryFetch
+	if shouldCollectResource || shouldCollectSummary {
+		summary = a.fetchSummaries(ctx)
+	}
+
 	// Gate expensive resource metrics collection
 	// These metrics are typically available from Kubelet /metrics/resource or /stats/summary
 	if shouldCollectResource {
 		a.collectCPUMetrics(ctx, now)
-		a.collectMemoryUsageMetrics(ctx, now)
+		a.collectMemoryUsageMetrics(ctx, now, summary)
 		a.collectNodeResourceCapacityMetrics(ctx, now) // Collects CPU, Mem, Pods capacity/allocatable
 		a.collectResourceRequests(ctx, now)            // Cluster-wide requests
 		a.collectResourceLimits(ctx, now)
@@ -224,9 +453,10 @@ func (a *Aggregator) tick(ctx context.Context) {
 		a.collectNamespaceMetrics(ctx, now)
 		a.collectClusterRestartMetrics(ctx, now)
 		a.collectClusterNodeReadiness(ctx, now)
-		a.collectClusterImageFsMetrics(ctx, now)
+		a.collectClusterImageFsMetrics(now, summary)
 		a.collectPodMetrics(ctx, now)
 		a.collectContainerMetrics(ctx, now)
+		a.collectCustomMetrics(ctx, now)
 		a.mu.Lock()
 		a.lastResourcePoll = now
 		a.mu.Unlock()
@@ -234,11 +464,12 @@ func (a *Aggregator) tick(ctx context.Context) {
 
 	// Gate expensive network/summary metrics collection
 	if shouldCollectSummary {
-		a.collectNetworkMetrics(ctx, now) // Includes PPS calculation now
-		a.collectNodeFilesystemMetrics(ctx, now)
-		a.collectNodeDetailedMetrics(ctx, now)
+		a.collectNetworkMetrics(now, summary) // Includes PPS calculation now
+		a.collectNodeFilesystemMetrics(now, summary)
+		a.collectNodeDetailedMetrics(ctx, now, summary)
+		a.collectContainerStorageMetrics(now, summary)
 		a.collectBasicNodeMetrics(ctx, now)
-		a.collectBasicPodNetworkMetrics(ctx, now)
+		a.collectPodNetworkMetrics(now, summary)
 		a.mu.Lock()
 		a.lastSummaryPoll = now
 		a.mu.Unlock()
@@ -248,12 +479,36 @@ func (a *Aggregator) tick(ctx context.Context) {
 	if shouldReconcileState {
 		a.collectNodeConditionMetrics(ctx, now) // Collects node ready/pressure conditions
 		a.collectStateMetrics(ctx, now)
+		a.collectObjectCounts(now)
+		a.collectServiceEndpoints(now)
+		a.collectPVCPhases(now)
+		a.collectWorkloadReplicas(now)
+		a.collectNodePodCounts(ctx, now)
+		a.collectControlPlaneHealth(ctx, now)
 		a.mu.Lock()
 		a.lastStateRecon = now
 		a.mu.Unlock()
 	}
 }
 
+// summaryFetch is the kubelet Summary API data read once per tick and shared
+// by every collector that needs it
+type summaryFetch struct {
+	snapshot *kubemetrics.SummarySnapshot
+	err      error
+}
+
+// available reports whether the fetch succeeded and reached at least one kubelet
+func (f *summaryFetch) available() bool {
+	return f != nil && f.err == nil && f.snapshot.Available()
+}
+
+// fetchSummaries reads the Summary API of every node in a single fan-out
+func (a *Aggregator) fetchSummaries(ctx context.Context) *summaryFetch {
+	snapshot, err := a.summaryAdapter.FetchSummaries(ctx)
+	return &summaryFetch{snapshot: snapshot, err: err}
+}
+
 // refreshNodeCapacities updates node capacity information
 func (a *Aggregator) refreshNodeCapacities(ctx context.Context, now time.Time) {
 	nodeCapacities, err := a.nodesAdapter.ListNodes(ctx)
@@ -292,67 +547,62 @@ func (a *Aggregator) refreshNodeCapacities(ctx context.Context, now time.Time) {
 	)
 }
 
-// collectMemoryUsageMetrics collects and aggregates memory usage metrics from the Metrics API
-func (a *Aggregator) collectMemoryUsageMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+// collectMemoryUsageMetrics collects node memory usage. The kubelet Summary API
+// is preferred: its working set and available bytes come straight from the
+// node's cgroup accounting, which the eviction manager also uses. Nodes the
+// Summary API does not cover fall back to metrics-server, which reports the
+// working set only, so node.mem.available.bytes is recorded for Summary nodes
+// alone.
+func (a *Aggregator) collectMemoryUsageMetrics(ctx context.Context, now time.Time, summary *summaryFetch) {
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
 		// Using "resource" as the collector name to group with CPU
-		metrics.RecordCollectorScrape("resource_memory", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("resource_memory", a.clock.Since(start), hasError)
+		if !hasError {
+			a.collectorRecovered("resource_memory")
+		}
 	}()
 
-	if !a.apiMetricsAdapter.HasMetricsAPI(ctx) {
-		a.logger.Debug("Metrics API not available, skipping memory usage metrics")
-		return
+	var summarySamples map[string]nodeMemorySample
+	if summary.available() {
+		summarySamples = nodeMemoryFromSummary(summary.snapshot.MemoryStats())
+	} else if summary != nil && summary.err != nil {
+		a.logger.Debug("Summary API memory stats unavailable, falling back to Metrics API", zap.Error(summary.err))
 	}
 
-	// Get individual node usage for node-level metrics.
-	// This assumes APIMetricsAdapter has a ListNodeMemoryUsage method similar to ListNodeCPUUsage.
-	nodeUsageMap, err := a.apiMetricsAdapter.ListNodeMemoryUsage(ctx)
-	if err != nil {
-		hasError = true
-		a.logger.Warn("Failed to collect node memory usage", zap.Error(err))
+	if a.summaryCoversAllNodes(summarySamples) {
+		a.recordNodeMemory(summarySamples, now)
 		return
 	}
 
-	var totalUsage float64
-
-	// Store individual node usage metrics
-	for nodeName, usage := range nodeUsageMap {
-		nodeEntity := map[string]string{"node": nodeName}
-		totalUsage += usage
-
-		// Store node.mem.usage.bytes
-		nodeUsageSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeMemUsageBase, nodeName))
-		if nodeUsageSeries != nil {
-			nodeUsageSeries.Add(timeseries.NewPointWithEntity(now, usage, nodeEntity))
-		}
-
-		// Store node.mem.working_set.bytes. For metrics-server, this is often the same value as usage.
-		nodeWorkingSetSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeMemWorkingSetBase, nodeName))
-		if nodeWorkingSetSeries != nil {
-			nodeWorkingSetSeries.Add(timeseries.NewPointWithEntity(now, usage, nodeEntity))
+	var metricsServerSamples map[string]nodeMemorySample
+	if a.apiMetricsAdapter.HasMetricsAPI(ctx) {
+		nodeUsageMap, err := a.apiMetricsAdapter.ListNodeMemoryUsage(ctx)
+		if err != nil {
+			hasError = true
+			a.logCollectorFailure("resource_memory", "Failed to collect node memory usage", err)
+		} else {
+			metricsServerSamples = nodeMemoryFromMetricsServer(nodeUsageMap)
 		}
 	}
 
-	// Store cluster total usage, which is required for the headroom chart
-	usageSeries := a.store.Upsert(timeseries.ClusterMemUsedBytes)
-	if usageSeries != nil {
-		usageSeries.Add(timeseries.Point{T: now, V: totalUsage})
+	if len(summarySamples) == 0 && len(metricsServerSamples) == 0 {
+		a.logger.Debug("No node memory source available, skipping memory usage metrics")
+		return
 	}
-
-	a.logger.Debug("Collected memory usage metrics",
-		zap.Float64("total_usage_gb", totalUsage/(1024*1024*1024)),
-		zap.Int("nodes", len(nodeUsageMap)),
-	)
+	a.recordNodeMemory(mergeNodeMemory(summarySamples, metricsServerSamples), now)
 }
 
 // collectCPUMetrics collects and aggregates CPU metrics
 func (a *Aggregator) collectCPUMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("resource", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("resource", a.clock.Since(start), hasError)
+		if !hasError {
+			a.collectorRecovered("resource")
+		}
 	}()
 
 	// Collect CPU capacity (sum of all nodes)
@@ -383,7 +633,7 @@ func (a *Aggregator) collectCPUMetrics(ctx context.Context, now time.Time) {
 		nodeUsageMap, err := a.apiMetricsAdapter.ListNodeCPUUsage(ctx)
 		if err != nil {
 			hasError = true
-			a.logger.Warn("Failed to collect node CPU usage", zap.Error(err))
+			a.logCollectorFailure("resource", "Failed to collect node CPU usage", err)
 		} else {
 			var totalUsage float64
 
@@ -414,33 +664,35 @@ func (a *Aggregator) collectCPUMetrics(ctx context.Context, now time.Time) {
 }
 
 // collectNetworkMetrics collects and aggregates network metrics
-func (a *Aggregator) collectNetworkMetrics(ctx context.Context, now time.Time) { // Renamed from collectNetworkMetrics
-	start := time.Now()
+func (a *Aggregator) collectNetworkMetrics(now time.Time, summary *summaryFetch) {
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("summary", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("summary", a.clock.Since(start), hasError)
+		if !hasError {
+			a.collectorRecovered("summary")
+		}
 	}()
 
-	hasSummaryAPI := a.summaryAdapter.HasSummaryAPI(ctx)
-
-	// If network is disabled when unavailable and we don't have Summary API, skip
-	if a.config.DisableNetworkIfUnavailable && !hasSummaryAPI {
+	if summary.err != nil {
+		hasError = true
+		a.logCollectorFailure("summary", "Failed to collect network stats", summary.err)
 		return
 	}
 
-	if !hasSummaryAPI {
+	// Without a reachable Summary API there are no network counters to record
+	if !summary.available() {
 		return
 	}
 
-	networkStats, err := a.summaryAdapter.ListNodeNetworkStats(ctx)
-
-	if err != nil {
-		hasError = true
-		a.logger.Warn("Failed to collect network stats", zap.Error(err))
-		return
-	}
+	a.recordNetworkRates(summary.snapshot.NetworkStats(), now)
+}
 
+// recordNetworkRates converts monotonic per-node network counters into byte and
+// packet rates against the previous snapshot and stores node and cluster series
+func (a *Aggregator) recordNetworkRates(networkStats []kubemetrics.NetworkStats, now time.Time) {
 	var totalRxRate, totalTxRate float64
+	var skipped int
 
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -449,57 +701,60 @@ func (a *Aggregator) collectNetworkMetrics(ctx context.Context, now time.Time) {
 		nodeEntity := map[string]string{"node": stat.NodeName}
 		snap, exists := a.hostSnapshots[stat.NodeName]
 		if !exists {
-			// Initialize new snapshot
-			snap = &hostSnap{
-				LastRx:        stat.RxBytes,
-				LastTx:        stat.TxBytes,
-				LastRxPackets: stat.RxPackets,
-				LastTxPackets: stat.TxPackets,
-				LastTs:        now,
-			}
+			snap = &hostSnap{}
 			a.hostSnapshots[stat.NodeName] = snap
+		}
+		// The first sample of a node, including one whose snapshot was
+		// created by refreshNodeCapacities, only seeds the counters
+		if snap.LastTs.IsZero() {
+			snap.LastRx = stat.RxBytes
+			snap.LastTx = stat.TxBytes
+			snap.LastRxPackets = stat.RxPackets
+			snap.LastTxPackets = stat.TxPackets
+			snap.LastTs = now
 			continue
 		}
 
-		// Calculate rates if we have previous data
-		if !snap.LastTs.IsZero() {
-			dt := now.Sub(snap.LastTs).Seconds()
-			if dt > 0 {
-				// Handle counter resets (new value less than old value)
-				// Calculate BPS
-				if stat.RxBytes >= snap.LastRx {
-					rxRate := float64(stat.RxBytes-snap.LastRx) / dt
-					totalRxRate += rxRate
-					nodeRxSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxBase, stat.NodeName))
-					if nodeRxSeries != nil {
-						nodeRxSeries.Add(timeseries.NewPointWithEntity(now, rxRate, nodeEntity))
-					}
-				}
+		// Calculate rates against the previous snapshot. Samples arriving out of
+		// order or too soon after it are dropped and leave the snapshot as is.
+		dt, ok := rateInterval(snap.LastTs, now)
+		if !ok {
+			skipped++
+			continue
+		}
+		// Handle counter resets (new value less than old value)
+		// Calculate BPS
+		if stat.RxBytes >= snap.LastRx {
+			rxRate := float64(stat.RxBytes-snap.LastRx) / dt
+			totalRxRate += rxRate
+			nodeRxSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxBase, stat.NodeName))
+			if nodeRxSeries != nil {
+				nodeRxSeries.Add(timeseries.NewPointWithEntity(now, rxRate, nodeEntity))
+			}
+		}
 
-				if stat.TxBytes >= snap.LastTx {
-					txRate := float64(stat.TxBytes-snap.LastTx) / dt
-					totalTxRate += txRate
-					nodeTxSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetTxBase, stat.NodeName))
-					if nodeTxSeries != nil {
-						nodeTxSeries.Add(timeseries.NewPointWithEntity(now, txRate, nodeEntity))
-					}
-				}
+		if stat.TxBytes >= snap.LastTx {
+			txRate := float64(stat.TxBytes-snap.LastTx) / dt
+			totalTxRate += txRate
+			nodeTxSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetTxBase, stat.NodeName))
+			if nodeTxSeries != nil {
+				nodeTxSeries.Add(timeseries.NewPointWithEntity(now, txRate, nodeEntity))
+			}
+		}
 
-				// Calculate PPS (packets per second)
-				if stat.RxPackets >= snap.LastRxPackets {
-					nodeRxPps := float64(stat.RxPackets-snap.LastRxPackets) / dt
-					ppsSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxPpsBase, stat.NodeName))
-					if ppsSeries != nil {
-						ppsSeries.Add(timeseries.NewPointWithEntity(now, nodeRxPps, nodeEntity))
-					}
-				}
-				if stat.TxPackets >= snap.LastTxPackets {
-					nodeTxPps := float64(stat.TxPackets-snap.LastTxPackets) / dt
-					ppsSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetTxPpsBase, stat.NodeName))
-					if ppsSeries != nil {
-						ppsSeries.Add(timeseries.NewPointWithEntity(now, nodeTxPps, nodeEntity))
-					}
-				}
+		// Calculate PPS (packets per second)
+		if stat.RxPackets >= snap.LastRxPackets {
+			nodeRxPps := float64(stat.RxPackets-snap.LastRxPackets) / dt
+			ppsSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxPpsBase, stat.NodeName))
+			if ppsSeries != nil {
+				ppsSeries.Add(timeseries.NewPointWithEntity(now, nodeRxPps, nodeEntity))
+			}
+		}
+		if stat.TxPackets >= snap.LastTxPackets {
+			nodeTxPps := float64(stat.TxPackets-snap.LastTxPackets) / dt
+			ppsSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetTxPpsBase, stat.NodeName))
+			if ppsSeries != nil {
+				ppsSeries.Add(timeseries.NewPointWithEntity(now, nodeTxPps, nodeEntity))
 			}
 		}
 
@@ -511,6 +766,13 @@ func (a *Aggregator) collectNetworkMetrics(ctx context.Context, now time.Time) {
 		snap.LastTs = now
 	}
 
+	// Cluster totals would undercount when a node's sample was dropped
+	if skipped > 0 {
+		a.logger.Debug("Skipped network rate samples with invalid interval",
+			zap.Int("skipped_nodes", skipped))
+		return
+	}
+
 	// Store network rates
 	rxSeries := a.store.Upsert(timeseries.ClusterNetRxBps)
 	if rxSeries != nil {
@@ -534,6 +796,9 @@ func (a *Aggregator) GetCapabilities(ctx context.Context) map[string]bool {
 	return map[string]bool{
 		"metricsAPI": a.apiMetricsAdapter.HasMetricsAPI(ctx),
 		"summaryAPI": a.summaryAdapter.HasSummaryAPI(ctx),
+
+		"customMetricsAPI":   a.customMetrics.HasCustomMetricsAPI(ctx),
+		"externalMetricsAPI": a.customMetrics.HasExternalMetricsAPI(ctx),
 	}
 }
 
@@ -637,16 +902,16 @@ func (a *Aggregator) collectNodeResourceCapacityMetrics(ctx context.Context, now
 	}
 
 	a.logger.Debug("Collected node resource capacity metrics",
-		zap.Float64("cluster_allocatable_gb", totalMemoryCapacity/(1024*1024*1024)),
+		zap.String("cluster_allocatable_memory", units.HumanizeBytes(int64(totalMemoryCapacity))),
 		zap.Int("node_count", len(nodeList)))
 }
 
 // collectNodeConditionMetrics collects node condition metrics (ready, pressure)
 func (a *Aggregator) collectNodeConditionMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("node_conditions", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("node_conditions", a.clock.Since(start), hasError)
 	}()
 
 	nodeList, err := a.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
@@ -656,6 +921,7 @@ func (a *Aggregator) collectNodeConditionMetrics(ctx context.Context, now time.T
 		return
 	}
 
+	var nodesUnderPressure int
 	for _, node := range nodeList.Items {
 		nodeEntity := map[string]string{"node": node.Name}
 
@@ -672,6 +938,18 @@ func (a *Aggregator) collectNodeConditionMetrics(ctx context.Context, now time.T
 			readySeries.Add(timeseries.NewPointWithEntity(now, readyStatus, nodeEntity))
 		}
 
+		// Whether new pods can be placed on the node
+		schedulable := readyStatus
+		if node.Spec.Unschedulable {
+			schedulable = 0
+		}
+		for _, taint := range node.Spec.Taints {
+			if taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute {
+				schedulable = 0
+			}
+		}
+		a.storeMetric(timeseries.GenerateNodeSeriesKey(timeseries.NodeSchedulableBase, node.Name), now, schedulable, nodeEntity)
+
 		// Node Disk Pressure
 		diskPressure := 0.0
 		for _, condition := range node.Status.Conditions {
@@ -710,37 +988,59 @@ func (a *Aggregator) collectNodeConditionMetrics(ctx context.Context, now time.T
 		if pidPressureSeries != nil {
 			pidPressureSeries.Add(timeseries.NewPointWithEntity(now, pidPressure, nodeEntity))
 		}
+
+		// Composite of the conditions above
+		score := nodePressureScore(readyStatus, diskPressure, memPressure, pidPressure)
+		if score > 0 {
+			nodesUnderPressure++
+		}
+		scoreSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodePressureScoreBase, node.Name))
+		if scoreSeries != nil {
+			scoreSeries.Add(timeseries.NewPointWithEntity(now, score, nodeEntity))
+		}
+	}
+
+	underPressureSeries := a.store.Upsert(timeseries.ClusterNodesUnderPressure)
+	if underPressureSeries != nil {
+		underPressureSeries.Add(timeseries.Point{T: now, V: float64(nodesUnderPressure)})
 	}
 
 	a.logger.Debug("Collected node condition metrics",
 		zap.Int("node_count", len(nodeList.Items)),
+		zap.Int("nodes_under_pressure", nodesUnderPressure),
 	)
 }
 
+// nodePressureScore combines a node's condition values into one score: the
+// number of DiskPressure, MemoryPressure and PIDPressure conditions it
+// reports, plus one when it is not Ready. 0 means healthy.
+func nodePressureScore(ready, diskPressure, memPressure, pidPressure float64) float64 {
+	return (1 - ready) + diskPressure + memPressure + pidPressure
+}
+
 // collectNodeFilesystemMetrics collects node filesystem and image filesystem metrics
-func (a *Aggregator) collectNodeFilesystemMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+func (a *Aggregator) collectNodeFilesystemMetrics(now time.Time, summary *summaryFetch) {
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("node_filesystem", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("node_filesystem", a.clock.Since(start), hasError)
+		if !hasError {
+			a.collectorRecovered("node_filesystem")
+		}
 	}()
 
-	if !a.summaryAdapter.HasSummaryAPI(ctx) {
-		a.logger.Debug("Summary API not available, skipping node filesystem metrics")
+	if summary.err != nil {
+		hasError = true
+		a.logCollectorFailure("node_filesystem", "Failed to collect node filesystem stats", summary.err)
 		return
 	}
-
-	// NOTE: This assumes kubemetrics.SummaryStatsAdapter has a method ListNodeFilesystemStats
-	// that returns a slice of a struct containing:
-	// NodeName, FsCapacityBytes, FsAvailableBytes, FsInodesTotal, FsInodesFree,
-	// ImageFsCapacityBytes, ImageFsAvailableBytes, ImageFsInodesTotal, ImageFsInodesFree.
-	fsStats, err := a.summaryAdapter.ListNodeFilesystemStats(ctx) // Assumed new method
-	if err != nil {
-		hasError = true
-		a.logger.Warn("Failed to collect node filesystem stats", zap.Error(err))
+	if !summary.available() {
+		a.logger.Debug("Summary API not available, skipping node filesystem metrics")
 		return
 	}
 
+	fsStats := summary.snapshot.FilesystemStats()
+
 	for _, stat := range fsStats {
 		nodeEntity := map[string]string{"node": stat.NodeName}
 
@@ -823,11 +1123,14 @@ func (a *Aggregator) collectStateMetrics(ctx context.Context, now time.Time) {
 	}
 
 	// Count pods by phase and check for unschedulable conditions
-	var running, pending, failed, succeeded, unschedulable float64
+	var running, pending, failed, succeeded, unschedulable, runningNotReady float64
 	for _, pod := range pods.Items {
 		switch pod.Status.Phase {
 		case corev1.PodRunning:
 			running++
+			if isRunningNotReady(&pod) {
+				runningNotReady++
+			}
 		case corev1.PodPending:
 			pending++
 			// Check if pod is unschedulable
@@ -872,6 +1175,11 @@ func (a *Aggregator) collectStateMetrics(ctx context.Context, now time.Time) {
 		unschedulablePodsSeries.Add(timeseries.Point{T: now, V: unschedulable})
 	}
 
+	runningNotReadySeries := a.store.Upsert(timeseries.ClusterPodsRunningNotReady)
+	if runningNotReadySeries != nil {
+		runningNotReadySeries.Add(timeseries.Point{T: now, V: runningNotReady})
+	}
+
 	a.logger.Debug("Collected state metrics",
 		zap.Float64("nodes", nodeCount),
 		zap.Float64("running_pods", running),
@@ -879,9 +1187,24 @@ func (a *Aggregator) collectStateMetrics(ctx context.Context, now time.Time) {
 		zap.Float64("failed_pods", failed),
 		zap.Float64("succeeded_pods", succeeded),
 		zap.Float64("unschedulable_pods", unschedulable),
+		zap.Float64("running_not_ready_pods", runningNotReady),
 	)
 }
 
+// isRunningNotReady reports whether a Running pod's Ready condition is false,
+// typically because a readiness probe is failing
+func isRunningNotReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionFalse
+		}
+	}
+	return false
+}
+
 // storePodPlaceholders stores zero values for pod metrics when collection fails
 func (a *Aggregator) storePodPlaceholders(now time.Time) {
 	runningPodsSeries := a.store.Upsert(timeseries.ClusterPodsRunning)
@@ -907,10 +1230,10 @@ func (a *Aggregator) storePodPlaceholders(now time.Time) {
 
 // collectResourceRequests collects cluster-level resource requests from pod specs
 func (a *Aggregator) collectResourceRequests(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("requests", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("requests", a.clock.Since(start), hasError)
 	}()
 
 	// Get all pods to sum up resource requests
@@ -922,6 +1245,8 @@ func (a *Aggregator) collectResourceRequests(ctx context.Context, now time.Time)
 	}
 
 	var totalCPURequests, totalMemoryRequests float64
+	nodeCPURequests := make(map[string]float64)
+	nodeMemoryRequests := make(map[string]float64)
 
 	for _, pod := range pods.Items {
 		// Skip completed pods for resource requests calculation
@@ -929,19 +1254,32 @@ func (a *Aggregator) collectResourceRequests(ctx context.Context, now time.Time)
 			continue
 		}
 
+		var podCPURequests, podMemoryRequests float64
 		for _, container := range pod.Spec.Containers {
 			// Sum CPU requests
 			if cpuRequest, exists := container.Resources.Requests[corev1.ResourceCPU]; exists {
-				cpuCores := float64(cpuRequest.MilliValue()) / 1000.0 // Convert millicores to cores
-				totalCPURequests += cpuCores
+				podCPURequests += float64(cpuRequest.MilliValue()) / 1000.0 // Convert millicores to cores
 			}
 
 			// Sum memory requests
 			if memRequest, exists := container.Resources.Requests[corev1.ResourceMemory]; exists {
-				memoryBytes := float64(memRequest.Value())
-				totalMemoryRequests += memoryBytes
+				podMemoryRequests += float64(units.ParseQuantityBytes(memRequest))
 			}
 		}
+
+		totalCPURequests += podCPURequests
+		totalMemoryRequests += podMemoryRequests
+		if pod.Spec.NodeName != "" {
+			nodeCPURequests[pod.Spec.NodeName] += podCPURequests
+			nodeMemoryRequests[pod.Spec.NodeName] += podMemoryRequests
+		}
+	}
+
+	// Store per-node requests, which drain simulations place pods against
+	for nodeName, cpuCores := range nodeCPURequests {
+		nodeEntity := map[string]string{"node": nodeName}
+		a.storeMetric(timeseries.GenerateNodeSeriesKey(timeseries.NodeRequestedCPUBase, nodeName), now, cpuCores, nodeEntity)
+		a.storeMetric(timeseries.GenerateNodeSeriesKey(timeseries.NodeRequestedMemBase, nodeName), now, nodeMemoryRequests[nodeName], nodeEntity)
 	}
 
 	// Store cluster-level resource requests
@@ -957,17 +1295,17 @@ func (a *Aggregator) collectResourceRequests(ctx context.Context, now time.Time)
 
 	a.logger.Debug("Collected resource requests",
 		zap.Float64("cpu_requests_cores", totalCPURequests),
-		zap.Float64("memory_requests_gb", totalMemoryRequests/(1024*1024*1024)),
+		zap.String("memory_requests", units.HumanizeBytes(int64(totalMemoryRequests))),
 		zap.Int("total_pods", len(pods.Items)),
 	)
 }
 
 // collectResourceLimits collects cluster-level resource limits from pod specs
 func (a *Aggregator) collectResourceLimits(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("limits", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("limits", a.clock.Since(start), hasError)
 	}()
 
 	// Get all pods to sum up resource limits
@@ -995,7 +1333,7 @@ func (a *Aggregator) collectResourceLimits(ctx context.Context, now time.Time) {
 
 			// Sum memory limits
 			if memLimit, exists := container.Resources.Limits[corev1.ResourceMemory]; exists {
-				memoryBytes := float64(memLimit.Value())
+				memoryBytes := float64(units.ParseQuantityBytes(memLimit))
 				totalMemoryLimits += memoryBytes
 			}
 		}
@@ -1014,17 +1352,17 @@ func (a *Aggregator) collectResourceLimits(ctx context.Context, now time.Time) {
 
 	a.logger.Debug("Collected resource limits",
 		zap.Float64("cpu_limits_cores", totalCPULimits),
-		zap.Float64("memory_limits_gb", totalMemoryLimits/(1024*1024*1024)),
+		zap.String("memory_limits", units.HumanizeBytes(int64(totalMemoryLimits))),
 		zap.Int("total_pods", len(pods.Items)),
 	)
 }
 
 // collectClusterRestartMetrics collects cluster-level pod restart metrics
 func (a *Aggregator) collectClusterRestartMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("restarts", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("restarts", a.clock.Since(start), hasError)
 	}()
 
 	// Get all pods to sum up restart counts
@@ -1046,15 +1384,21 @@ func (a *Aggregator) collectClusterRestartMetrics(ctx context.Context, now time.
 	// Calculate restart rate (change per second)
 	a.mu.Lock()
 	var restartRate float64
-	if a.lastRestartsTotal > 0 && !a.lastRestartsTime.IsZero() {
-		deltaRestarts := totalRestarts - a.lastRestartsTotal
-		deltaTime := now.Sub(a.lastRestartsTime).Seconds()
-		if deltaTime > 0 {
-			restartRate = float64(deltaRestarts) / deltaTime
+	storeRate := true
+	if !a.lastRestartsTime.IsZero() {
+		// An out-of-order or too-short interval keeps the previous sample as
+		// the baseline and stores no rate point for this tick
+		if deltaTime, ok := rateInterval(a.lastRestartsTime, now); !ok {
+			storeRate = false
+		} else if a.lastRestartsTotal > 0 {
+			restartRate = float64(totalRestarts-a.lastRestartsTotal) / deltaTime
 		}
 	}
-	a.lastRestartsTotal = totalRestarts
-	a.lastRestartsTime = now
+	if storeRate {
+		a.lastRestartsTotal = totalRestarts
+		a.lastRestartsTime = now
+	}
+	stormActive := a.evaluateRestartStorm(now, totalRestarts)
 	a.mu.Unlock()
 
 	// Store cluster-level restart metrics
@@ -1063,31 +1407,40 @@ func (a *Aggregator) collectClusterRestartMetrics(ctx context.Context, now time.
 		restartsTotalSeries.Add(timeseries.Point{T: now, V: float64(totalRestarts)})
 	}
 
-	restartsRateSeries := a.store.Upsert(timeseries.ClusterPodsRestartsRate)
-	if restartsRateSeries != nil {
-		restartsRateSeries.Add(timeseries.Point{T: now, V: restartRate})
+	if storeRate {
+		restartsRateSeries := a.store.Upsert(timeseries.ClusterPodsRestartsRate)
+		if restartsRateSeries != nil {
+			restartsRateSeries.Add(timeseries.Point{T: now, V: restartRate})
+		}
 	}
 
 	// Calculate 1-hour restart count using sliding window
 	restarts1hSeries := a.store.Upsert(timeseries.ClusterPodsRestarts1h)
 	if restarts1hSeries != nil && restartsTotalSeries != nil {
-		restarts1h := calculateRestartsInWindow(restartsTotalSeries, float64(totalRestarts), time.Hour)
+		restarts1h := calculateRestartsInWindow(restartsTotalSeries, float64(totalRestarts), now, time.Hour)
 		restarts1hSeries.Add(timeseries.Point{T: now, V: restarts1h})
 	}
 
+	stormValue := 0.0
+	if stormActive {
+		stormValue = 1.0
+	}
+	a.storeMetric(timeseries.ClusterPodsRestartStorm, now, stormValue, nil)
+
 	a.logger.Debug("Collected restart metrics",
 		zap.Int64("total_restarts", totalRestarts),
 		zap.Float64("restart_rate_per_sec", restartRate),
+		zap.Bool("restart_storm", stormActive),
 		zap.Int("total_pods", len(pods.Items)),
 	)
 }
 
 // collectClusterNodeReadiness collects cluster-level node readiness metrics
 func (a *Aggregator) collectClusterNodeReadiness(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("node_readiness", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("node_readiness", a.clock.Since(start), hasError)
 	}()
 
 	// Get all nodes to check readiness
@@ -1135,32 +1488,43 @@ func (a *Aggregator) collectClusterNodeReadiness(ctx context.Context, now time.T
 }
 
 // collectClusterImageFsMetrics collects cluster-level image filesystem metrics
-func (a *Aggregator) collectClusterImageFsMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+func (a *Aggregator) collectClusterImageFsMetrics(now time.Time, summary *summaryFetch) {
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("imagefs", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("imagefs", a.clock.Since(start), hasError)
+		if !hasError {
+			a.collectorRecovered("imagefs")
+		}
 	}()
 
-	if !a.summaryAdapter.HasSummaryAPI(ctx) {
+	if summary.err != nil {
+		hasError = true
+		a.logCollectorFailure("imagefs", "Failed to get image filesystem stats", summary.err)
+		return
+	}
+	if !summary.available() {
 		a.logger.Debug("Summary API not available, skipping image filesystem metrics")
 		return
 	}
 
-	// TODO: Implement proper image filesystem collection via Summary API
-	// For now, use placeholder values
+	a.recordClusterImageFs(summary.snapshot.ImageFsStats(), now)
+}
 
-	// Get all nodes for estimating
-	nodes, err := a.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		hasError = true
-		a.logger.Error("Failed to list nodes for image filesystem metrics", zap.Error(err))
+// recordClusterImageFs sums per-node image filesystem usage into the cluster
+// series. Nothing is stored when no kubelet reported, so charts show no data
+// rather than a misleading zero.
+func (a *Aggregator) recordClusterImageFs(stats []kubemetrics.NodeImageFsStats, now time.Time) {
+	if len(stats) == 0 {
+		a.logger.Debug("No image filesystem stats reported, skipping")
 		return
 	}
 
-	// Placeholder: estimate 10GB used and 50GB capacity per node
-	var totalImageFsUsed = uint64(len(nodes.Items)) * 10 * 1024 * 1024 * 1024     // 10GB per node
-	var totalImageFsCapacity = uint64(len(nodes.Items)) * 50 * 1024 * 1024 * 1024 // 50GB per node
+	var totalImageFsUsed, totalImageFsCapacity uint64
+	for _, node := range stats {
+		totalImageFsUsed += node.ImageFsUsedBytes
+		totalImageFsCapacity += node.ImageFsCapacityBytes
+	}
 
 	// Store cluster-level image filesystem metrics
 	imageFsUsedSeries := a.store.Upsert(timeseries.ClusterFsImageUsedBytes)
@@ -1173,19 +1537,19 @@ func (a *Aggregator) collectClusterImageFsMetrics(ctx context.Context, now time.
 		imageFsCapacitySeries.Add(timeseries.Point{T: now, V: float64(totalImageFsCapacity)})
 	}
 
-	a.logger.Debug("Collected image filesystem metrics (placeholder)",
+	a.logger.Debug("Collected image filesystem metrics",
 		zap.Uint64("total_used_bytes", totalImageFsUsed),
 		zap.Uint64("total_capacity_bytes", totalImageFsCapacity),
-		zap.Int("total_nodes", len(nodes.Items)),
+		zap.Int("reporting_nodes", len(stats)),
 	)
 }
 
 // collectPodResourceMetrics collects per-pod resource requests and limits
 func (a *Aggregator) collectPodResourceMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("pod_resources", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("pod_resources", a.clock.Since(start), hasError)
 	}()
 
 	// Get all pods to collect individual resource metrics
@@ -1197,7 +1561,7 @@ func (a *Aggregator) collectPodResourceMetrics(ctx context.Context, now time.Tim
 	}
 
 	podCount := 0
-	for _, pod := range pods.Items {
+	for _, pod := range a.namespaceScope().filterPods(pods.Items) {
 		// Skip completed pods
 		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
 			continue
@@ -1222,11 +1586,11 @@ func (a *Aggregator) collectPodResourceMetrics(ctx context.Context, now time.Tim
 			}
 			// Memory requests
 			if memRequest, exists := container.Resources.Requests[corev1.ResourceMemory]; exists {
-				totalMemRequest += float64(memRequest.Value())
+				totalMemRequest += float64(units.ParseQuantityBytes(memRequest))
 			}
 			// Memory limits
 			if memLimit, exists := container.Resources.Limits[corev1.ResourceMemory]; exists {
-				totalMemLimit += float64(memLimit.Value())
+				totalMemLimit += float64(units.ParseQuantityBytes(memLimit))
 			}
 		}
 
@@ -1263,10 +1627,10 @@ func (a *Aggregator) collectPodResourceMetrics(ctx context.Context, now time.Tim
 
 // collectPodRestartMetrics collects per-pod restart counts and rates
 func (a *Aggregator) collectPodRestartMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("pod_restarts", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("pod_restarts", a.clock.Since(start), hasError)
 	}()
 
 	// Get all pods to collect restart metrics
@@ -1277,16 +1641,28 @@ func (a *Aggregator) collectPodRestartMetrics(ctx context.Context, now time.Time
 		return
 	}
 
-	podCount := 0
-	for _, pod := range pods.Items {
+	a.recordPodRestarts(a.namespaceScope().filterPods(pods.Items), now)
+}
+
+// recordPodRestarts stores each pod's restart total, its rate in restarts per
+// second against the previous poll, and its restarts in the last hour. State
+// of pods that no longer exist is dropped.
+func (a *Aggregator) recordPodRestarts(pods []corev1.Pod, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	seen := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		podKey := pod.Namespace + "/" + pod.Name
+		seen[podKey] = true
 		podEntity := map[string]string{
 			"namespace": pod.Namespace,
 			"pod":       pod.Name,
 		}
 
-		var totalRestarts int32
+		var totalRestarts int64
 		for _, containerStatus := range pod.Status.ContainerStatuses {
-			totalRestarts += containerStatus.RestartCount
+			totalRestarts += int64(containerStatus.RestartCount)
 		}
 
 		// Store restart count
@@ -1295,27 +1671,47 @@ func (a *Aggregator) collectPodRestartMetrics(ctx context.Context, now time.Time
 			restartTotalSeries.Add(timeseries.NewPointWithEntity(now, float64(totalRestarts), podEntity))
 		}
 
-		// TODO: Calculate restart rate (need to track previous values per pod)
-		// For now, store 0 as placeholder
-		restartRateSeries := a.store.Upsert(timeseries.GeneratePodSeriesKey(timeseries.PodRestartsRateBase, pod.Namespace, pod.Name))
-		if restartRateSeries != nil {
-			restartRateSeries.Add(timeseries.NewPointWithEntity(now, 0.0, podEntity))
+		// Calculate restart rate (restarts/sec). The first poll of a pod only
+		// records the baseline.
+		state, exists := a.podRestartsState[podKey]
+		if !exists {
+			a.podRestartsState[podKey] = &restartState{lastTotal: totalRestarts, lastTime: now}
+		} else if deltaTime, ok := rateInterval(state.lastTime, now); ok {
+			var restartRate float64
+			// A lower total means the pod was recreated under the same name
+			if deltaRestarts := totalRestarts - state.lastTotal; deltaRestarts >= 0 {
+				restartRate = float64(deltaRestarts) / deltaTime
+			}
+			state.lastTotal = totalRestarts
+			state.lastTime = now
+			a.storeMetric(timeseries.GeneratePodSeriesKey(timeseries.PodRestartsRateBase, pod.Namespace, pod.Name), now, restartRate, podEntity)
+		}
+
+		// Calculate restarts in the last hour
+		if restartTotalSeries != nil {
+			restarts1h := calculateRestartsInWindow(restartTotalSeries, float64(totalRestarts), now, time.Hour)
+			a.storeMetric(timeseries.GeneratePodSeriesKey(timeseries.PodRestarts1hBase, pod.Namespace, pod.Name), now, restarts1h, podEntity)
 		}
+	}
 
-		podCount++
+	// Forget pods that no longer exist
+	for podKey := range a.podRestartsState {
+		if !seen[podKey] {
+			delete(a.podRestartsState, podKey)
+		}
 	}
 
 	a.logger.Debug("Collected pod restart metrics",
-		zap.Int("total_pods", podCount),
+		zap.Int("total_pods", len(seen)),
 	)
 }
 
 // collectNamespaceMetrics collects namespace-level aggregated metrics
 func (a *Aggregator) collectNamespaceMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("namespace_metrics", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("namespace_metrics", a.clock.Since(start), hasError)
 	}()
 
 	// Get all pods to aggregate by namespace
@@ -1348,7 +1744,8 @@ func (a *Aggregator) collectNamespaceMetrics(ctx context.Context, now time.Time)
 		totalRestarts                 int64
 	})
 
-	for _, pod := range pods.Items {
+	// a.mu is held for the whole collection, so read the lists directly
+	for _, pod := range a.config.filterPods(pods.Items) {
 		namespace := pod.Namespace
 
 		// Initialize namespace data if not exists
@@ -1383,10 +1780,10 @@ func (a *Aggregator) collectNamespaceMetrics(ctx context.Context, now time.Time)
 				data.cpuLimit += float64(cpuLimit.MilliValue()) / 1000.0
 			}
 			if memRequest, exists := container.Resources.Requests[corev1.ResourceMemory]; exists {
-				data.memRequest += float64(memRequest.Value())
+				data.memRequest += float64(units.ParseQuantityBytes(memRequest))
 			}
 			if memLimit, exists := container.Resources.Limits[corev1.ResourceMemory]; exists {
-				data.memLimit += float64(memLimit.Value())
+				data.memLimit += float64(units.ParseQuantityBytes(memLimit))
 			}
 		}
 
@@ -1456,33 +1853,49 @@ func (a *Aggregator) collectNamespaceMetrics(ctx context.Context, now time.Time)
 
 		// Calculate restart rate (restarts/sec)
 		var restartRate float64
+		storeRate := true
 		state, exists := a.nsRestartsState[namespace]
 		if exists && !state.lastTime.IsZero() {
 			deltaRestarts := data.totalRestarts - state.lastTotal
-			deltaTime := now.Sub(state.lastTime).Seconds()
-			if deltaTime > 0 && deltaRestarts >= 0 { // handle counter resets gracefully
+			if deltaTime, ok := rateInterval(state.lastTime, now); !ok {
+				storeRate = false
+			} else if deltaRestarts >= 0 { // handle counter resets gracefully
 				restartRate = float64(deltaRestarts) / deltaTime
 			}
 		}
 		// Update state for next calculation
 		if !exists {
-			state = &nsRestartState{}
+			state = &restartState{}
 			a.nsRestartsState[namespace] = state
 		}
-		state.lastTotal = data.totalRestarts
-		state.lastTime = now
+		if storeRate {
+			state.lastTotal = data.totalRestarts
+			state.lastTime = now
 
-		restartsRateSeries := a.store.Upsert(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePodsRestartsRateBase, namespace))
-		if restartsRateSeries != nil {
-			restartsRateSeries.Add(timeseries.NewPointWithEntity(now, restartRate, nsEntity))
+			restartsRateSeries := a.store.Upsert(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePodsRestartsRateBase, namespace))
+			if restartsRateSeries != nil {
+				restartsRateSeries.Add(timeseries.NewPointWithEntity(now, restartRate, nsEntity))
+			}
 		}
 
 		// Calculate restarts in the last hour
-		restarts1h := calculateRestartsInWindow(restartsTotalSeries, float64(data.totalRestarts), time.Hour)
+		restarts1h := calculateRestartsInWindow(restartsTotalSeries, float64(data.totalRestarts), now, time.Hour)
 		restarts1hSeries := a.store.Upsert(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePodsRestarts1hBase, namespace))
 		if restarts1hSeries != nil {
 			restarts1hSeries.Add(timeseries.NewPointWithEntity(now, restarts1h, nsEntity))
 		}
+
+		// Track restarts within the storm window for top-contributor reporting
+		if a.config.RestartStormWindow > 0 {
+			a.nsStormRestarts[namespace] = calculateRestartsInWindow(restartsTotalSeries, float64(data.totalRestarts), now, a.config.RestartStormWindow)
+		}
+	}
+
+	// Forget namespaces that no longer have pods
+	for namespace := range a.nsStormRestarts {
+		if _, exists := namespaceData[namespace]; !exists {
+			delete(a.nsStormRestarts, namespace)
+		}
 	}
 
 	a.logger.Debug("Collected namespace metrics",
@@ -1492,13 +1905,13 @@ func (a *Aggregator) collectNamespaceMetrics(ctx context.Context, now time.Time)
 }
 
 // calculateRestartsInWindow calculates the number of restarts in a given time window
-// by looking at the historical total restart count.
-func calculateRestartsInWindow(series *timeseries.Series, currentTotal float64, window time.Duration) float64 {
+// ending at now by looking at the historical total restart count.
+func calculateRestartsInWindow(series *timeseries.Series, currentTotal float64, now time.Time, window time.Duration) float64 {
 	if series == nil {
 		return 0
 	}
 
-	windowAgo := time.Now().Add(-window)
+	windowAgo := now.Add(-window)
 	var totalAtWindowStart float64 = -1
 
 	// Find the oldest point within the window to get the starting count
@@ -1517,10 +1930,13 @@ func calculateRestartsInWindow(series *timeseries.Series, currentTotal float64,
 
 // collectPodMetrics collects basic pod-level metrics
 func (a *Aggregator) collectPodMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("pods", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("pods", a.clock.Since(start), hasError)
+		if !hasError {
+			a.collectorRecovered("pods")
+		}
 	}()
 
 	if !a.apiMetricsAdapter.HasMetricsAPI(ctx) {
@@ -1532,63 +1948,135 @@ func (a *Aggregator) collectPodMetrics(ctx context.Context, now time.Time) {
 	podMetricsRaw, err := a.apiMetricsAdapter.ListPodMetrics(ctx)
 	if err != nil {
 		hasError = true
-		a.logger.Warn("Failed to collect pod metrics", zap.Error(err))
+		a.logCollectorFailure("pods", "Failed to collect pod metrics", err)
 		return
 	}
 
-	// For each pod, store metrics with real pod names
+	// Metrics-server can still report pods that have just completed
+	terminated := a.terminatedPods(ctx)
+
+	scope := a.namespaceScope()
+	recorded := 0
 	for _, podMetricInterface := range podMetricsRaw {
-		// Type assert to get real pod metrics data
 		podMetric, ok := podMetricInterface.(metricsv1beta1types.PodMetrics)
 		if !ok {
 			a.logger.Debug("Unable to extract pod metrics object, skipping")
 			continue
 		}
+		if !scope.namespaceAllowed(podMetric.Namespace) {
+			continue
+		}
+		if terminated[podMetric.Namespace+"/"+podMetric.Name] {
+			continue
+		}
 
-		// Extract real pod information
 		podEntity := map[string]string{
 			"namespace": podMetric.Namespace,
 			"pod":       podMetric.Name,
 		}
+		cpuCores, memBytes := podUsage(podMetric)
 
-		// Generate series keys with real pod names
-		podSeriesKey := timeseries.GeneratePodSeriesKey(timeseries.PodCPUUsageBase, podEntity["namespace"], podEntity["pod"])
-		podCPUSeries := a.store.Upsert(podSeriesKey)
-		if podCPUSeries != nil {
-			// TODO: Extract actual CPU usage from podMetric instead of using sample value
-			// Sample: 0.1 cores per pod
-			podCPUSeries.Add(timeseries.NewPointWithEntity(now, 0.1, podEntity))
-		}
+		// Metrics-server reports the working set as memory usage, so both
+		// series carry the same value
+		a.storeMetric(timeseries.GeneratePodSeriesKey(timeseries.PodCPUUsageBase, podMetric.Namespace, podMetric.Name), now, cpuCores, podEntity)
+		a.storeMetric(timeseries.GeneratePodSeriesKey(timeseries.PodMemUsageBase, podMetric.Namespace, podMetric.Name), now, memBytes, podEntity)
+		a.storeMetric(timeseries.GeneratePodSeriesKey(timeseries.PodMemWorkingSetBase, podMetric.Namespace, podMetric.Name), now, memBytes, podEntity)
+		recorded++
+	}
+
+	a.logger.Debug("Collected pod metrics",
+		zap.Int("pod_count", len(podMetricsRaw)),
+		zap.Int("recorded", recorded),
+	)
+}
+
+// podUsage sums the CPU (cores) and memory (bytes) usage of a pod's containers
+func podUsage(podMetric metricsv1beta1types.PodMetrics) (float64, float64) {
+	var cpuCores, memBytes float64
+	for _, container := range podMetric.Containers {
+		cpu, mem := containerUsage(container)
+		cpuCores += cpu
+		memBytes += mem
+	}
+	return cpuCores, memBytes
+}
+
+// containerUsage returns a container's CPU (cores) and memory working set
+// (bytes) usage; a missing quantity counts as zero
+func containerUsage(container metricsv1beta1types.ContainerMetrics) (float64, float64) {
+	var cpuCores, memBytes float64
+	if cpu, ok := container.Usage[corev1.ResourceCPU]; ok {
+		cpuCores = float64(cpu.MilliValue()) / 1000
+	}
+	if mem, ok := container.Usage[corev1.ResourceMemory]; ok {
+		memBytes = float64(units.ParseQuantityBytes(mem))
+	}
+	return cpuCores, memBytes
+}
 
-		podMemSeriesKey := timeseries.GeneratePodSeriesKey(timeseries.PodMemUsageBase, podEntity["namespace"], podEntity["pod"])
-		podMemSeries := a.store.Upsert(podMemSeriesKey)
-		if podMemSeries != nil {
-			// TODO: Extract actual memory usage from podMetric instead of using sample value
-			// Sample: 128MB per pod
-			podMemSeries.Add(timeseries.NewPointWithEntity(now, 128*1024*1024, podEntity))
+// PodLister lists cached Pods, such as the informer manager's ListPods. ok is
+// false while the cache has not synced.
+type PodLister interface {
+	ListPods() ([]*corev1.Pod, bool)
+}
+
+// SetPodLister sets the cached source of Pods. Without one, or while it has
+// not synced, terminated pods are found by listing pods from the API server.
+func (a *Aggregator) SetPodLister(lister PodLister) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pods = lister
+}
+
+// terminatedPods returns the namespace/name keys of Succeeded and Failed
+// pods. When pods cannot be listed no pod is treated as terminated.
+func (a *Aggregator) terminatedPods(ctx context.Context) map[string]bool {
+	terminated := make(map[string]bool)
+	for _, pod := range a.listPods(ctx) {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			terminated[pod.Namespace+"/"+pod.Name] = true
 		}
+	}
+	return terminated
+}
 
-		podWorkingSetSeriesKey := timeseries.GeneratePodSeriesKey(timeseries.PodMemWorkingSetBase, podEntity["namespace"], podEntity["pod"])
-		podWorkingSetSeries := a.store.Upsert(podWorkingSetSeriesKey)
-		if podWorkingSetSeries != nil {
-			// TODO: Extract actual working set from podMetric instead of using sample value
-			// Sample: 120MB working set per pod
-			podWorkingSetSeries.Add(timeseries.NewPointWithEntity(now, 120*1024*1024, podEntity))
+// listPods returns every pod, from the pod cache when one has synced and from
+// the API server otherwise. It returns nil when pods cannot be listed.
+func (a *Aggregator) listPods(ctx context.Context) []*corev1.Pod {
+	a.mu.RLock()
+	lister := a.pods
+	a.mu.RUnlock()
+	if lister != nil {
+		if pods, ok := lister.ListPods(); ok {
+			return pods
 		}
 	}
 
-	a.logger.Debug("Collected pod metrics",
-		zap.Int("pod_count", len(podMetricsRaw)),
-		zap.String("note", "using real pod names with sample values - full metrics parsing needed"),
-	)
+	if a.kubeClient == nil {
+		return nil
+	}
+	list, err := a.kubeClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		a.logger.Debug("Failed to list pods for pod metrics filtering", zap.Error(err))
+		return nil
+	}
+	pods := make([]*corev1.Pod, 0, len(list.Items))
+	for i := range list.Items {
+		pods = append(pods, &list.Items[i])
+	}
+	return pods
 }
 
-// collectContainerMetrics collects basic container-level metrics
+// collectContainerMetrics stores the CPU and working set usage of every
+// container reported by the Metrics API
 func (a *Aggregator) collectContainerMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("containers", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("containers", a.clock.Since(start), hasError)
+		if !hasError {
+			a.collectorRecovered("containers")
+		}
 	}()
 
 	if !a.apiMetricsAdapter.HasMetricsAPI(ctx) {
@@ -1596,67 +2084,105 @@ func (a *Aggregator) collectContainerMetrics(ctx context.Context, now time.Time)
 		return
 	}
 
-	// Get pod metrics to estimate container count
 	podMetricsRaw, err := a.apiMetricsAdapter.ListPodMetrics(ctx)
 	if err != nil {
 		hasError = true
-		a.logger.Warn("Failed to collect container metrics", zap.Error(err))
+		a.logCollectorFailure("containers", "Failed to collect container metrics", err)
 		return
 	}
 
-	// Estimate 2 containers per pod on average
-	estimatedContainers := len(podMetricsRaw) * 2
+	terminated := a.terminatedPods(ctx)
 
-	for i := 0; i < estimatedContainers; i++ {
-		// Create synthetic container entity
-		containerEntity := map[string]string{
-			"namespace": "default",
-			"pod":       fmt.Sprintf("pod-%d", i/2),
-			"container": fmt.Sprintf("container-%d", i%2),
+	// Rootfs and log usage are not in the Metrics API; they come from the
+	// Summary API in collectContainerStorageMetrics
+	scope := a.namespaceScope()
+	recorded := 0
+	for _, podMetricInterface := range podMetricsRaw {
+		podMetric, ok := podMetricInterface.(metricsv1beta1types.PodMetrics)
+		if !ok || !scope.namespaceAllowed(podMetric.Namespace) {
+			continue
+		}
+		if terminated[podMetric.Namespace+"/"+podMetric.Name] {
+			continue
 		}
 
-		ctrCPUSeriesKey := timeseries.GenerateContainerSeriesKey(timeseries.ContainerCPUUsageBase, containerEntity["namespace"], containerEntity["pod"], containerEntity["container"])
-		ctrCPUSeries := a.store.Upsert(ctrCPUSeriesKey)
-		if ctrCPUSeries != nil {
-			// Sample: 0.05 cores per container
-			ctrCPUSeries.Add(timeseries.NewPointWithEntity(now, 0.05, containerEntity))
+		for _, container := range podMetric.Containers {
+			containerEntity := map[string]string{
+				"namespace": podMetric.Namespace,
+				"pod":       podMetric.Name,
+				"container": container.Name,
+			}
+			cpuCores, memBytes := containerUsage(container)
+			a.storeMetric(timeseries.GenerateContainerSeriesKey(timeseries.ContainerCPUUsageBase, podMetric.Namespace, podMetric.Name, container.Name), now, cpuCores, containerEntity)
+			a.storeMetric(timeseries.GenerateContainerSeriesKey(timeseries.ContainerMemWorkingSetBase, podMetric.Namespace, podMetric.Name, container.Name), now, memBytes, containerEntity)
+			recorded++
 		}
+	}
+
+	a.logger.Debug("Collected container metrics", zap.Int("containers", recorded))
+}
+
+// collectContainerStorageMetrics stores per-container rootfs and log usage
+// reported by the kubelet Summary API
+func (a *Aggregator) collectContainerStorageMetrics(now time.Time, summary *summaryFetch) {
+	start := a.clock.Now()
+	var hasError bool
+	defer func() {
+		metrics.RecordCollectorScrape("container_storage", a.clock.Since(start), hasError)
+		if !hasError {
+			a.collectorRecovered("container_storage")
+		}
+	}()
+
+	if summary.err != nil {
+		hasError = true
+		a.logCollectorFailure("container_storage", "Failed to collect container storage metrics", summary.err)
+		return
+	}
+	if !summary.available() {
+		a.logger.Debug("Summary API not available, skipping container storage metrics")
+		return
+	}
 
-		ctrMemSeriesKey := timeseries.GenerateContainerSeriesKey(timeseries.ContainerMemWorkingSetBase, containerEntity["namespace"], containerEntity["pod"], containerEntity["container"])
-		ctrMemSeries := a.store.Upsert(ctrMemSeriesKey)
-		if ctrMemSeries != nil {
-			// Sample: 64MB per container
-			ctrMemSeries.Add(timeseries.NewPointWithEntity(now, 64*1024*1024, containerEntity))
+	a.recordContainerStorage(summary.snapshot.ContainerStorageStats(), now)
+}
+
+// recordContainerStorage stores the rootfs and log usage series of each container
+func (a *Aggregator) recordContainerStorage(storageStats []kubemetrics.ContainerStorageStats, now time.Time) {
+	scope := a.namespaceScope()
+	for _, stat := range storageStats {
+		if !scope.namespaceAllowed(stat.PodNamespace) {
+			continue
+		}
+		containerEntity := map[string]string{
+			"namespace": stat.PodNamespace,
+			"pod":       stat.PodName,
+			"container": stat.ContainerName,
+			"node":      stat.NodeName,
 		}
 
-		// Add the missing container metrics you actually need!
-		ctrRootFsSeriesKey := timeseries.GenerateContainerSeriesKey(timeseries.ContainerRootFsUsedBase, containerEntity["namespace"], containerEntity["pod"], containerEntity["container"])
-		ctrRootFsSeries := a.store.Upsert(ctrRootFsSeriesKey)
-		if ctrRootFsSeries != nil {
-			// Sample: 500MB rootfs per container
-			ctrRootFsSeries.Add(timeseries.NewPointWithEntity(now, 500*1024*1024, containerEntity))
+		rootFsSeries := a.store.Upsert(timeseries.GenerateContainerSeriesKey(timeseries.ContainerRootFsUsedBase, stat.PodNamespace, stat.PodName, stat.ContainerName))
+		if rootFsSeries != nil {
+			rootFsSeries.Add(timeseries.NewPointWithEntity(now, float64(stat.RootfsUsedBytes), containerEntity))
 		}
 
-		ctrLogsSeriesKey := timeseries.GenerateContainerSeriesKey(timeseries.ContainerLogsUsedBase, containerEntity["namespace"], containerEntity["pod"], containerEntity["container"])
-		ctrLogsSeries := a.store.Upsert(ctrLogsSeriesKey)
-		if ctrLogsSeries != nil {
-			// Sample: 50MB logs per container
-			ctrLogsSeries.Add(timeseries.NewPointWithEntity(now, 50*1024*1024, containerEntity))
+		logsSeries := a.store.Upsert(timeseries.GenerateContainerSeriesKey(timeseries.ContainerLogsUsedBase, stat.PodNamespace, stat.PodName, stat.ContainerName))
+		if logsSeries != nil {
+			logsSeries.Add(timeseries.NewPointWithEntity(now, float64(stat.LogsUsedBytes), containerEntity))
 		}
 	}
 
-	a.logger.Debug("Collected container metrics",
-		zap.Int("estimated_containers", estimatedContainers),
-		zap.String("note", "using sample values - full metrics parsing needed"),
+	a.logger.Debug("Collected container storage metrics",
+		zap.Int("container_count", len(storageStats)),
 	)
 }
 
 // collectNodeDetailedMetrics collects detailed node-level metrics
-func (a *Aggregator) collectNodeDetailedMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+func (a *Aggregator) collectNodeDetailedMetrics(ctx context.Context, now time.Time, summary *summaryFetch) {
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("node_details", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("node_details", a.clock.Since(start), hasError)
 	}()
 
 	// Get node list
@@ -1668,38 +2194,35 @@ func (a *Aggregator) collectNodeDetailedMetrics(ctx context.Context, now time.Ti
 	}
 
 	// Collect per-node network rates if Summary API is available
-	if a.summaryAdapter.HasSummaryAPI(ctx) {
-		networkStats, err := a.summaryAdapter.ListNodeNetworkStats(ctx)
-		if err == nil {
-			a.mu.Lock()
-			for _, stat := range networkStats {
-				snap, exists := a.hostSnapshots[stat.NodeName]
-				if exists && !snap.LastTs.IsZero() {
-					dt := now.Sub(snap.LastTs).Seconds()
-					if dt > 0 {
-						nodeEntity := map[string]string{"node": stat.NodeName}
-
-						// Calculate per-node network rates
-						if stat.RxBytes >= snap.LastRx {
-							rxRate := float64(stat.RxBytes-snap.LastRx) / dt
-							nodeRxSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxBase, stat.NodeName))
-							if nodeRxSeries != nil {
-								nodeRxSeries.Add(timeseries.NewPointWithEntity(now, rxRate, nodeEntity))
-							}
+	if summary.available() {
+		networkStats := summary.snapshot.NetworkStats()
+		a.mu.Lock()
+		for _, stat := range networkStats {
+			snap, exists := a.hostSnapshots[stat.NodeName]
+			if exists {
+				if dt, ok := rateInterval(snap.LastTs, now); ok {
+					nodeEntity := map[string]string{"node": stat.NodeName}
+
+					// Calculate per-node network rates
+					if stat.RxBytes >= snap.LastRx {
+						rxRate := float64(stat.RxBytes-snap.LastRx) / dt
+						nodeRxSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxBase, stat.NodeName))
+						if nodeRxSeries != nil {
+							nodeRxSeries.Add(timeseries.NewPointWithEntity(now, rxRate, nodeEntity))
 						}
+					}
 
-						if stat.TxBytes >= snap.LastTx {
-							txRate := float64(stat.TxBytes-snap.LastTx) / dt
-							nodeTxSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetTxBase, stat.NodeName))
-							if nodeTxSeries != nil {
-								nodeTxSeries.Add(timeseries.NewPointWithEntity(now, txRate, nodeEntity))
-							}
+					if stat.TxBytes >= snap.LastTx {
+						txRate := float64(stat.TxBytes-snap.LastTx) / dt
+						nodeTxSeries := a.store.Upsert(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetTxBase, stat.NodeName))
+						if nodeTxSeries != nil {
+							nodeTxSeries.Add(timeseries.NewPointWithEntity(now, txRate, nodeEntity))
 						}
 					}
 				}
 			}
-			a.mu.Unlock()
 		}
+		a.mu.Unlock()
 	}
 
 	a.logger.Debug("Collected detailed node metrics",
@@ -1710,10 +2233,10 @@ func (a *Aggregator) collectNodeDetailedMetrics(ctx context.Context, now time.Ti
 
 // collectBasicNodeMetrics collects basic node metrics that don't require Summary API
 func (a *Aggregator) collectBasicNodeMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("basic_nodes", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("basic_nodes", a.clock.Since(start), hasError)
 	}()
 
 	// Get node list
@@ -1742,12 +2265,12 @@ func (a *Aggregator) collectBasicNodeMetrics(ctx context.Context, now time.Time)
 	)
 }
 
-// collectNodePodCounts collects pod counts per node
+// collectNodePodCounts collects pod counts and the pod phase breakdown per node
 func (a *Aggregator) collectNodePodCounts(ctx context.Context, now time.Time) {
-	start := time.Now()
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("node_pod_counts", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("node_pod_counts", a.clock.Since(start), hasError)
 	}()
 
 	// Get all pods to count per node
@@ -1758,10 +2281,20 @@ func (a *Aggregator) collectNodePodCounts(ctx context.Context, now time.Time) {
 		return
 	}
 
-	// Count pods per node
+	// Count active pods and pods per phase for each node
 	podCountPerNode := make(map[string]int)
+	phaseCountsPerNode := make(map[string]map[corev1.PodPhase]int)
 	for _, pod := range pods.Items {
-		if pod.Spec.NodeName != "" && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+
+		if _, exists := phaseCountsPerNode[pod.Spec.NodeName]; !exists {
+			phaseCountsPerNode[pod.Spec.NodeName] = make(map[corev1.PodPhase]int)
+		}
+		phaseCountsPerNode[pod.Spec.NodeName][pod.Status.Phase]++
+
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
 			podCountPerNode[pod.Spec.NodeName]++
 		}
 	}
@@ -1775,6 +2308,19 @@ func (a *Aggregator) collectNodePodCounts(ctx context.Context, now time.Time) {
 		}
 	}
 
+	// Store the phase breakdown, including zeros so a cleared phase is visible
+	phaseBases := map[corev1.PodPhase]string{
+		corev1.PodRunning: timeseries.NodePodsPhaseRunningBase,
+		corev1.PodPending: timeseries.NodePodsPhasePendingBase,
+		corev1.PodFailed:  timeseries.NodePodsPhaseFailedBase,
+	}
+	for nodeName, phaseCounts := range phaseCountsPerNode {
+		nodeEntity := map[string]string{"node": nodeName}
+		for phase, base := range phaseBases {
+			a.storeMetric(timeseries.GenerateNodeSeriesKey(base, nodeName), now, float64(phaseCounts[phase]), nodeEntity)
+		}
+	}
+
 	a.logger.Debug("Collected node pod counts",
 		zap.Int("total_nodes_with_pods", len(podCountPerNode)),
 		zap.Int("total_pods", len(pods.Items)),
@@ -1782,14 +2328,14 @@ func (a *Aggregator) collectNodePodCounts(ctx context.Context, now time.Time) {
 }
 
 // collectNodePacketStats collects packet-per-second metrics for nodes
-func (a *Aggregator) collectNodePacketStats(ctx context.Context, now time.Time) {
-	start := time.Now()
+func (a *Aggregator) collectNodePacketStats(ctx context.Context, now time.Time, summary *summaryFetch) {
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("node_packet_stats", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("node_packet_stats", a.clock.Since(start), hasError)
 	}()
 
-	if !a.summaryAdapter.HasSummaryAPI(ctx) {
+	if !summary.available() {
 		a.logger.Debug("Summary API not available, using placeholder packet stats")
 
 		// Get node list for placeholder stats
@@ -1828,76 +2374,95 @@ func (a *Aggregator) collectNodePacketStats(ctx context.Context, now time.Time)
 	a.logger.Debug("Packet stats collection from Summary API not yet implemented")
 }
 
-// collectBasicPodNetworkMetrics collects basic pod network placeholder metrics
-func (a *Aggregator) collectBasicPodNetworkMetrics(ctx context.Context, now time.Time) {
-	start := time.Now()
+// collectPodNetworkMetrics collects per-pod network rates and ephemeral
+// storage usage from the Summary API
+func (a *Aggregator) collectPodNetworkMetrics(now time.Time, summary *summaryFetch) {
+	start := a.clock.Now()
 	var hasError bool
 	defer func() {
-		metrics.RecordCollectorScrape("pod_network", time.Since(start), hasError)
+		metrics.RecordCollectorScrape("pod_network", a.clock.Since(start), hasError)
+		if !hasError {
+			a.collectorRecovered("pod_network")
+		}
 	}()
 
-	// Get running pods to estimate network activity
-	pods, err := a.kubeClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
-	if err != nil {
+	if summary.err != nil {
 		hasError = true
-		a.logger.Error("Failed to collect pods for network metrics", zap.Error(err))
+		a.logCollectorFailure("pod_network", "Failed to collect pod network stats", summary.err)
 		return
 	}
-
-	var runningPods int
-	for _, pod := range pods.Items {
-		if pod.Status.Phase == corev1.PodRunning {
-			runningPods++
-		}
+	if !summary.available() {
+		a.logger.Debug("Summary API not available, skipping pod network metrics")
+		return
 	}
 
-	// Add placeholder pod network metrics for running pods
-	podIndex := 0
-	for i := 0; i < runningPods; i++ {
-		// Create synthetic pod entity
+	a.recordPodNetwork(summary.snapshot.PodNetworkStats(), now)
+}
+
+// recordPodNetwork converts monotonic per-pod network counters into byte rates
+// against the previous snapshot and stores the pod network and ephemeral
+// storage series. Snapshots of pods no longer reported are dropped.
+func (a *Aggregator) recordPodNetwork(podStats []kubemetrics.PodNetworkStats, now time.Time) {
+	scope := a.namespaceScope()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	seen := make(map[string]bool, len(podStats))
+	var rated int
+	for _, stat := range podStats {
+		if !scope.namespaceAllowed(stat.PodNamespace) {
+			continue
+		}
+		podKey := stat.PodNamespace + "/" + stat.PodName
+		seen[podKey] = true
 		podEntity := map[string]string{
-			"namespace": "default",
-			"pod":       fmt.Sprintf("running-pod-%d", podIndex),
+			"namespace": stat.PodNamespace,
+			"pod":       stat.PodName,
+			"node":      stat.NodeName,
 		}
 
-		podNetRxSeriesKey := timeseries.GeneratePodSeriesKey(timeseries.PodNetRxBase, podEntity["namespace"], podEntity["pod"])
-		podNetRxSeries := a.store.Upsert(podNetRxSeriesKey)
-		if podNetRxSeries != nil {
-			// Placeholder: 1KB/s per pod
-			podNetRxSeries.Add(timeseries.NewPointWithEntity(now, 1024, podEntity))
+		a.storeMetric(timeseries.GeneratePodSeriesKey(timeseries.PodEphemeralUsedBase, stat.PodNamespace, stat.PodName), now, float64(stat.EphemeralUsed), podEntity)
+		if stat.EphemeralCapacity > 0 {
+			percentUsed := float64(stat.EphemeralUsed) / float64(stat.EphemeralCapacity) * 100
+			a.storeMetric(timeseries.GeneratePodSeriesKey(timeseries.PodEphemeralPercentBase, stat.PodNamespace, stat.PodName), now, percentUsed, podEntity)
 		}
 
-		podNetTxSeriesKey := timeseries.GeneratePodSeriesKey(timeseries.PodNetTxBase, podEntity["namespace"], podEntity["pod"])
-		podNetTxSeries := a.store.Upsert(podNetTxSeriesKey)
-		if podNetTxSeries != nil {
-			// Placeholder: 1KB/s per pod
-			podNetTxSeries.Add(timeseries.NewPointWithEntity(now, 1024, podEntity))
+		snap, exists := a.podNetSnapshots[podKey]
+		if !exists {
+			a.podNetSnapshots[podKey] = &podNetSnap{LastRx: stat.RxBytes, LastTx: stat.TxBytes, LastTs: now}
+			continue
 		}
 
-		podEphemeralSeriesKey := timeseries.GeneratePodSeriesKey(timeseries.PodEphemeralUsedBase, podEntity["namespace"], podEntity["pod"])
-		podEphemeralSeries := a.store.Upsert(podEphemeralSeriesKey)
-		if podEphemeralSeries != nil {
-			// Placeholder: 100MB ephemeral storage per pod
-			podEphemeralSeries.Add(timeseries.NewPointWithEntity(now, 100*1024*1024, podEntity))
+		// Samples arriving out of order or too soon after the previous one are
+		// dropped and leave the snapshot as is
+		dt, ok := rateInterval(snap.LastTs, now)
+		if !ok {
+			continue
 		}
-
-		// Add ephemeral storage percentage
-		podEphemeralPercentKey := timeseries.GeneratePodSeriesKey(timeseries.PodEphemeralPercentBase, podEntity["namespace"], podEntity["pod"])
-		podEphemeralPercentSeries := a.store.Upsert(podEphemeralPercentKey)
-		if podEphemeralPercentSeries != nil {
-			// Calculate percentage based on placeholder values
-			// Assume ephemeral capacity of 1GB per pod for percentage calculation
-			ephemeralUsed := float64(100 * 1024 * 1024)              // 100MB used (same as above)
-			ephemeralCapacity := float64(1024 * 1024 * 1024)         // 1GB capacity assumption
-			percentUsed := (ephemeralUsed / ephemeralCapacity) * 100 // Should be ~9.77%
-			podEphemeralPercentSeries.Add(timeseries.NewPointWithEntity(now, percentUsed, podEntity))
+		// A counter lower than the previous one means the pod sandbox was
+		// recreated; the sample only re-baselines the snapshot
+		if stat.RxBytes >= snap.LastRx {
+			a.storeMetric(timeseries.GeneratePodSeriesKey(timeseries.PodNetRxBase, stat.PodNamespace, stat.PodName), now, float64(stat.RxBytes-snap.LastRx)/dt, podEntity)
+		}
+		if stat.TxBytes >= snap.LastTx {
+			a.storeMetric(timeseries.GeneratePodSeriesKey(timeseries.PodNetTxBase, stat.PodNamespace, stat.PodName), now, float64(stat.TxBytes-snap.LastTx)/dt, podEntity)
 		}
+		rated++
 
-		podIndex++
+		snap.LastRx = stat.RxBytes
+		snap.LastTx = stat.TxBytes
+		snap.LastTs = now
+	}
+
+	for podKey := range a.podNetSnapshots {
+		if !seen[podKey] {
+			delete(a.podNetSnapshots, podKey)
+		}
 	}
 
-	a.logger.Debug("Collected basic pod network metrics",
-		zap.Int("running_pods", runningPods),
-		zap.String("note", "using placeholder values - Summary API needed for real data"),
+	a.logger.Debug("Collected pod network metrics",
+		zap.Int("pods", len(seen)),
+		zap.Int("rated_pods", rated),
 	)
 }
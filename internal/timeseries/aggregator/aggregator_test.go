@@ -94,3 +94,98 @@ func TestHostSnapUpdate(t *testing.T) {
 	assert.Equal(t, uint64(1000), snap.LastRx)
 	assert.Equal(t, uint64(2000), snap.LastTx)
 }
+
+func TestConfigValidateDefaultsZeroIntervals(t *testing.T) {
+	config := Config{Enabled: true}
+
+	assert.NoError(t, config.Validate())
+
+	defaults := DefaultConfig()
+	assert.Equal(t, defaults.TickInterval, config.TickInterval)
+	assert.Equal(t, defaults.CapacityRefreshInterval, config.CapacityRefreshInterval)
+	assert.Equal(t, defaults.ResourcePollInterval, config.ResourcePollInterval)
+	assert.Equal(t, defaults.SummaryPollInterval, config.SummaryPollInterval)
+	assert.Equal(t, defaults.StateReconcileInterval, config.StateReconcileInterval)
+	assert.Equal(t, defaults.PruneInterval, config.PruneInterval)
+	// Zero restart storm settings keep detection disabled
+	assert.Zero(t, config.RestartStormThreshold)
+	assert.Zero(t, config.RestartStormWindow)
+}
+
+func TestConfigValidateClampsPollIntervalsToTick(t *testing.T) {
+	config := DefaultConfig()
+	config.TickInterval = 20 * time.Second
+	config.ResourcePollInterval = 5 * time.Second
+	config.SummaryPollInterval = time.Minute
+
+	assert.NoError(t, config.Validate())
+
+	assert.Equal(t, 20*time.Second, config.ResourcePollInterval)
+	assert.Equal(t, time.Minute, config.SummaryPollInterval)
+	assert.Equal(t, 20*time.Second, config.StateReconcileInterval)
+	assert.Equal(t, 30*time.Second, config.CapacityRefreshInterval)
+}
+
+func TestConfigValidateRejectsNegativeIntervals(t *testing.T) {
+	config := DefaultConfig()
+	config.TickInterval = -time.Second
+	assert.ErrorContains(t, config.Validate(), "tick_interval")
+
+	config = DefaultConfig()
+	config.SummaryPollInterval = -time.Second
+	assert.ErrorContains(t, config.Validate(), "summary_poll_interval")
+
+	config = DefaultConfig()
+	config.RestartStormThreshold = -1
+	assert.ErrorContains(t, config.Validate(), "restart_storm_threshold")
+}
+
+func TestConfigValidateRejectsUnsaneIntervals(t *testing.T) {
+	config := DefaultConfig()
+	config.TickInterval = 10 * time.Millisecond
+	assert.ErrorContains(t, config.Validate(), "tick_interval must be at least 100ms")
+
+	config = DefaultConfig()
+	config.StateReconcileInterval = 2 * time.Hour
+	assert.ErrorContains(t, config.Validate(), "state_reconcile_interval must be at most 1h0m0s")
+
+	config = ProductionConfig()
+	assert.NoError(t, config.Validate())
+}
+
+func TestProductionConfigIsMoreConservative(t *testing.T) {
+	fast := DefaultConfig()
+	production := ProductionConfig()
+
+	assert.Equal(t, 15*time.Second, production.ResourcePollInterval)
+	assert.Equal(t, 30*time.Second, production.SummaryPollInterval)
+	assert.Equal(t, 60*time.Second, production.StateReconcileInterval)
+	assert.NotEqual(t, fast, production)
+
+	for _, interval := range []struct {
+		name             string
+		fast, production time.Duration
+	}{
+		{"tick", fast.TickInterval, production.TickInterval},
+		{"capacity refresh", fast.CapacityRefreshInterval, production.CapacityRefreshInterval},
+		{"resource poll", fast.ResourcePollInterval, production.ResourcePollInterval},
+		{"summary poll", fast.SummaryPollInterval, production.SummaryPollInterval},
+		{"state reconcile", fast.StateReconcileInterval, production.StateReconcileInterval},
+		{"prune", fast.PruneInterval, production.PruneInterval},
+	} {
+		assert.GreaterOrEqual(t, interval.production, interval.fast, interval.name)
+	}
+}
+
+func TestNewAggregatorCorrectsInvalidConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.TickInterval = -time.Second
+	config.ResourcePollInterval = 0
+	config.Enabled = false
+
+	aggregator := NewAggregator(zap.NewNop(), timeseries.NewMemStore(timeseries.DefaultConfig()), fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, config)
+
+	assert.Equal(t, DefaultConfig().TickInterval, aggregator.config.TickInterval)
+	assert.Equal(t, DefaultConfig().ResourcePollInterval, aggregator.config.ResourcePollInterval)
+	assert.False(t, aggregator.config.Enabled)
+}
@@ -0,0 +1,44 @@
+package aggregator
+
+import "time"
+
+// Clock is the aggregator's time source. Collection timestamps, rate
+// calculations and sliding windows all read time through it so they can be
+// driven deterministically in tests.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+}
+
+// realClock reads the system clock
+type realClock struct{}
+
+func (realClock) Now() time.Time                  { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration { return time.Since(t) }
+
+// SetClock replaces the aggregator's time source. It must be called before Start.
+func (a *Aggregator) SetClock(clock Clock) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.clock = clock
+}
+
+// minRateInterval is the shortest interval a counter rate is computed over.
+// Clock adjustments, reordered ticks and collectors reading a snapshot that
+// was just refreshed yield zero, negative or tiny intervals that would turn
+// into absurd rates, so those samples are skipped rather than stored.
+const minRateInterval = 500 * time.Millisecond
+
+// rateInterval returns the seconds between a previous counter sample and now,
+// and false when there is no previous sample or the interval is below
+// minRateInterval
+func rateInterval(last, now time.Time) (float64, bool) {
+	if last.IsZero() {
+		return 0, false
+	}
+	dt := now.Sub(last)
+	if dt < minRateInterval {
+		return 0, false
+	}
+	return dt.Seconds(), true
+}
@@ -0,0 +1,256 @@
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+// fakeClock is a manually advanced Clock for deterministic tests
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func restartingPod(name string, restarts int32) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app", RestartCount: restarts}},
+		},
+	}
+}
+
+func latestValue(t *testing.T, store timeseries.Store, key string) float64 {
+	t.Helper()
+
+	series, ok := store.Get(key)
+	require.True(t, ok, "series %s not found", key)
+	points := series.GetSince(time.Unix(0, 0), timeseries.Hi)
+	require.NotEmpty(t, points, "series %s has no points", key)
+	return points[len(points)-1].V
+}
+
+func TestClusterRestartRateUsesClock(t *testing.T) {
+	client := fake.NewSimpleClientset(restartingPod("web", 5))
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, client, metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	a.SetClock(clock)
+	ctx := context.Background()
+
+	a.collectClusterRestartMetrics(ctx, clock.Now())
+	assert.Equal(t, 0.0, latestValue(t, store, timeseries.ClusterPodsRestartsRate))
+
+	// 20 more restarts over exactly 10 seconds is 2 restarts per second
+	clock.Advance(10 * time.Second)
+	_, err := client.CoreV1().Pods("default").UpdateStatus(ctx, restartingPod("web", 25), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	a.collectClusterRestartMetrics(ctx, clock.Now())
+	assert.Equal(t, 2.0, latestValue(t, store, timeseries.ClusterPodsRestartsRate))
+	assert.Equal(t, 25.0, latestValue(t, store, timeseries.ClusterPodsRestartsTotal))
+	assert.Equal(t, 20.0, latestValue(t, store, timeseries.ClusterPodsRestarts1h))
+}
+
+func TestCalculateRestartsInWindowUsesProvidedTime(t *testing.T) {
+	series := timeseries.NewSeries(timeseries.DefaultConfig())
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	series.Add(timeseries.NewPoint(start, 10))
+	series.Add(timeseries.NewPoint(start.Add(30*time.Second), 14))
+	series.Add(timeseries.NewPoint(start.Add(90*time.Second), 20))
+
+	// The one-minute window ending at start+90s begins at start+30s
+	assert.Equal(t, 6.0, calculateRestartsInWindow(series, 20, start.Add(90*time.Second), time.Minute))
+	// The full window covers every sample
+	assert.Equal(t, 10.0, calculateRestartsInWindow(series, 20, start.Add(90*time.Second), time.Hour))
+}
+
+func TestNetworkRatesUseClock(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	a.SetClock(clock)
+
+	a.recordNetworkRates([]kubemetrics.NetworkStats{
+		{NodeName: "node-a", RxBytes: 1000, TxBytes: 500, RxPackets: 10, TxPackets: 5},
+		{NodeName: "node-b", RxBytes: 2000, TxBytes: 1000, RxPackets: 20, TxPackets: 10},
+	}, clock.Now())
+
+	clock.Advance(4 * time.Second)
+	a.recordNetworkRates([]kubemetrics.NetworkStats{
+		{NodeName: "node-a", RxBytes: 5000, TxBytes: 2500, RxPackets: 50, TxPackets: 25},
+		{NodeName: "node-b", RxBytes: 6000, TxBytes: 1400, RxPackets: 60, TxPackets: 14},
+	}, clock.Now())
+
+	assert.Equal(t, 1000.0, latestValue(t, store, timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxBase, "node-a")))
+	assert.Equal(t, 500.0, latestValue(t, store, timeseries.GenerateNodeSeriesKey(timeseries.NodeNetTxBase, "node-a")))
+	assert.Equal(t, 10.0, latestValue(t, store, timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxPpsBase, "node-a")))
+	assert.Equal(t, 1.0, latestValue(t, store, timeseries.GenerateNodeSeriesKey(timeseries.NodeNetTxPpsBase, "node-b")))
+	assert.Equal(t, 2000.0, latestValue(t, store, timeseries.ClusterNetRxBps))
+	assert.Equal(t, 600.0, latestValue(t, store, timeseries.ClusterNetTxBps))
+}
+
+func TestTickSeedsNetworkSnapshotsFromCapacityRefresh(t *testing.T) {
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	start := clock.Now()
+
+	// A fake kubelet whose counters grow by 1000 bytes and 10 packets per second
+	kubelet := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/nodes/node-a/proxy/stats/summary" {
+			http.NotFound(w, r)
+			return
+		}
+		elapsed := uint64(clock.Since(start) / time.Second)
+		var summary kubemetrics.SummaryStatsResponse
+		summary.Node.Network.RxBytes = 1000 * elapsed
+		summary.Node.Network.TxBytes = 500 * elapsed
+		summary.Node.Network.RxPackets = 10 * elapsed
+		summary.Node.Network.TxPackets = 5 * elapsed
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(summary)
+	}))
+	defer kubelet.Close()
+
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	config := DefaultConfig()
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(node), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{Host: kubelet.URL}, config)
+	a.SetClock(clock)
+	ctx := context.Background()
+
+	// The first tick refreshes capacities before collecting network stats, so
+	// the snapshot already exists when the first counters arrive
+	a.tick(ctx)
+	_, ok := store.Get(timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxBase, "node-a"))
+	assert.False(t, ok, "no rate without a previous sample")
+
+	clock.Advance(config.SummaryPollInterval)
+	a.tick(ctx)
+	assert.Equal(t, 1000.0, latestValue(t, store, timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxBase, "node-a")))
+	assert.Equal(t, 5.0, latestValue(t, store, timeseries.GenerateNodeSeriesKey(timeseries.NodeNetTxPpsBase, "node-a")))
+	assert.Equal(t, 1000.0, latestValue(t, store, timeseries.ClusterNetRxBps))
+}
+
+func seriesLen(store timeseries.Store, key string) int {
+	series, ok := store.Get(key)
+	if !ok {
+		return 0
+	}
+	return len(series.GetSince(time.Unix(0, 0), timeseries.Hi))
+}
+
+func TestRateInterval(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	dt, ok := rateInterval(now.Add(-2*time.Second), now)
+	assert.True(t, ok)
+	assert.Equal(t, 2.0, dt)
+
+	_, ok = rateInterval(time.Time{}, now)
+	assert.False(t, ok, "no previous sample")
+	_, ok = rateInterval(now, now)
+	assert.False(t, ok, "zero interval")
+	_, ok = rateInterval(now.Add(time.Second), now)
+	assert.False(t, ok, "negative interval")
+	_, ok = rateInterval(now.Add(-100*time.Millisecond), now)
+	assert.False(t, ok, "interval below the floor")
+}
+
+func TestNetworkRatesSkipOutOfOrderSamples(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rxKey := timeseries.GenerateNodeSeriesKey(timeseries.NodeNetRxBase, "node-a")
+
+	a.recordNetworkRates([]kubemetrics.NetworkStats{{NodeName: "node-a", RxBytes: 1000}}, start)
+	a.recordNetworkRates([]kubemetrics.NetworkStats{{NodeName: "node-a", RxBytes: 2000}}, start.Add(10*time.Second))
+	require.Equal(t, 100.0, latestValue(t, store, rxKey))
+	clusterPoints := seriesLen(store, timeseries.ClusterNetRxBps)
+
+	// A tick stamped before the previous one and a tick a few milliseconds
+	// after it must not produce rate points
+	a.recordNetworkRates([]kubemetrics.NetworkStats{{NodeName: "node-a", RxBytes: 9000}}, start.Add(5*time.Second))
+	a.recordNetworkRates([]kubemetrics.NetworkStats{{NodeName: "node-a", RxBytes: 9000}}, start.Add(10*time.Second+time.Millisecond))
+	assert.Equal(t, 1, seriesLen(store, rxKey))
+	assert.Equal(t, clusterPoints, seriesLen(store, timeseries.ClusterNetRxBps))
+
+	// The snapshot still holds the last in-order sample
+	a.recordNetworkRates([]kubemetrics.NetworkStats{{NodeName: "node-a", RxBytes: 4000}}, start.Add(20*time.Second))
+	assert.Equal(t, 200.0, latestValue(t, store, rxKey))
+	assert.Equal(t, 200.0, latestValue(t, store, timeseries.ClusterNetRxBps))
+}
+
+func TestRestartRatesSkipClockSkew(t *testing.T) {
+	client := fake.NewSimpleClientset(restartingPod("web", 5))
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, client, metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	a.SetClock(clock)
+	ctx := context.Background()
+	nsRateKey := timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePodsRestartsRateBase, "default")
+
+	a.collectClusterRestartMetrics(ctx, clock.Now())
+	a.collectNamespaceMetrics(ctx, clock.Now())
+	require.Equal(t, 1, seriesLen(store, timeseries.ClusterPodsRestartsRate))
+	require.Equal(t, 1, seriesLen(store, nsRateKey))
+
+	// The clock steps back 30 seconds while restarts keep climbing
+	clock.Advance(-30 * time.Second)
+	_, err := client.CoreV1().Pods("default").UpdateStatus(ctx, restartingPod("web", 65), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	a.collectClusterRestartMetrics(ctx, clock.Now())
+	a.collectNamespaceMetrics(ctx, clock.Now())
+	assert.Equal(t, 1, seriesLen(store, timeseries.ClusterPodsRestartsRate))
+	assert.Equal(t, 1, seriesLen(store, nsRateKey))
+
+	// Once time moves past the last good sample the rate covers the full interval
+	clock.Advance(90 * time.Second)
+	a.collectClusterRestartMetrics(ctx, clock.Now())
+	a.collectNamespaceMetrics(ctx, clock.Now())
+	assert.Equal(t, 1.0, latestValue(t, store, timeseries.ClusterPodsRestartsRate))
+	assert.Equal(t, 1.0, latestValue(t, store, nsRateKey))
+}
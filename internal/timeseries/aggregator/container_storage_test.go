@@ -0,0 +1,31 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func TestRecordContainerStorageUsesSummaryValues(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	a.recordContainerStorage([]kubemetrics.ContainerStorageStats{
+		{NodeName: "node-a", PodNamespace: "shop", PodName: "web-0", ContainerName: "app", RootfsUsedBytes: 4 << 20, LogsUsedBytes: 1 << 20},
+		{NodeName: "node-a", PodNamespace: "shop", PodName: "web-0", ContainerName: "sidecar", RootfsUsedBytes: 2 << 20},
+	}, now)
+
+	assert.Equal(t, float64(4<<20), latestValue(t, store, timeseries.GenerateContainerSeriesKey(timeseries.ContainerRootFsUsedBase, "shop", "web-0", "app")))
+	assert.Equal(t, float64(1<<20), latestValue(t, store, timeseries.GenerateContainerSeriesKey(timeseries.ContainerLogsUsedBase, "shop", "web-0", "app")))
+	assert.Equal(t, float64(2<<20), latestValue(t, store, timeseries.GenerateContainerSeriesKey(timeseries.ContainerRootFsUsedBase, "shop", "web-0", "sidecar")))
+	assert.Equal(t, 0.0, latestValue(t, store, timeseries.GenerateContainerSeriesKey(timeseries.ContainerLogsUsedBase, "shop", "web-0", "sidecar")))
+}
@@ -0,0 +1,78 @@
+package aggregator
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aaronlmathis/kaptn/internal/metrics"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+// controlPlaneLeaseNamespace is where kube-scheduler and kube-controller-manager
+// hold their leader election leases
+const controlPlaneLeaseNamespace = "kube-system"
+
+// controlPlaneComponents maps leader election lease names to health series keys
+var controlPlaneComponents = []struct {
+	lease string
+	key   string
+}{
+	{lease: "kube-scheduler", key: timeseries.ClusterSchedulerHealthy},
+	{lease: "kube-controller-manager", key: timeseries.ClusterControllerManagerHealthy},
+}
+
+// collectControlPlaneHealth records scheduler and controller-manager health based
+// on the freshness of their leader election leases. ComponentStatus is deprecated,
+// so a component is considered healthy while its lease has a holder that keeps
+// renewing it within the lease duration.
+func (a *Aggregator) collectControlPlaneHealth(ctx context.Context, now time.Time) {
+	start := a.clock.Now()
+	var hasError bool
+	defer func() {
+		metrics.RecordCollectorScrape("control_plane_health", a.clock.Since(start), hasError)
+	}()
+
+	for _, component := range controlPlaneComponents {
+		lease, err := a.kubeClient.CoordinationV1().Leases(controlPlaneLeaseNamespace).Get(ctx, component.lease, metav1.GetOptions{})
+		if err != nil {
+			// Managed control planes often hide these leases; record nothing rather than a false negative
+			if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+				a.logger.Debug("Control plane lease not visible",
+					zap.String("lease", component.lease),
+					zap.Error(err))
+				continue
+			}
+			hasError = true
+			a.logger.Error("Failed to get control plane lease",
+				zap.String("lease", component.lease),
+				zap.Error(err))
+			continue
+		}
+
+		healthy := 0.0
+		if leaseHealthy(lease, now) {
+			healthy = 1.0
+		}
+		a.storeMetric(component.key, now, healthy, nil)
+	}
+}
+
+// leaseHealthy reports whether a lease has a holder whose last renewal is
+// within the lease duration
+func leaseHealthy(lease *coordinationv1.Lease, now time.Time) bool {
+	spec := lease.Spec
+	if spec.HolderIdentity == nil || *spec.HolderIdentity == "" {
+		return false
+	}
+	if spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+		return false
+	}
+
+	expiry := spec.RenewTime.Time.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second)
+	return !now.After(expiry)
+}
@@ -0,0 +1,73 @@
+package aggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func controlPlaneLease(name, holder string, renewTime time.Time, durationSeconds int32) *coordinationv1.Lease {
+	renew := metav1.NewMicroTime(renewTime)
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: controlPlaneLeaseNamespace},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			RenewTime:            &renew,
+			LeaseDurationSeconds: &durationSeconds,
+		},
+	}
+}
+
+func TestLeaseHealthy(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, leaseHealthy(controlPlaneLease("kube-scheduler", "master-1", now.Add(-5*time.Second), 15), now))
+	assert.False(t, leaseHealthy(controlPlaneLease("kube-scheduler", "master-1", now.Add(-30*time.Second), 15), now))
+	assert.False(t, leaseHealthy(controlPlaneLease("kube-scheduler", "", now, 15), now))
+	assert.False(t, leaseHealthy(&coordinationv1.Lease{}, now))
+}
+
+func TestCollectControlPlaneHealth(t *testing.T) {
+	now := time.Now()
+	kubeClient := fake.NewSimpleClientset(
+		controlPlaneLease("kube-scheduler", "master-1", now.Add(-2*time.Second), 15),
+		controlPlaneLease("kube-controller-manager", "master-2", now.Add(-2*time.Minute), 15),
+	)
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, kubeClient, metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	a.collectControlPlaneHealth(context.Background(), now)
+
+	scheduler, ok := store.Get(timeseries.ClusterSchedulerHealthy)
+	require.True(t, ok)
+	points := scheduler.GetAll(timeseries.Hi)
+	require.Len(t, points, 1)
+	assert.Equal(t, 1.0, points[0].V)
+
+	controllerManager, ok := store.Get(timeseries.ClusterControllerManagerHealthy)
+	require.True(t, ok)
+	points = controllerManager.GetAll(timeseries.Hi)
+	require.Len(t, points, 1)
+	assert.Equal(t, 0.0, points[0].V)
+}
+
+func TestCollectControlPlaneHealthMissingLeases(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	a.collectControlPlaneHealth(context.Background(), time.Now())
+
+	_, ok := store.Get(timeseries.ClusterSchedulerHealthy)
+	assert.False(t, ok, "missing leases should not be reported as unhealthy")
+}
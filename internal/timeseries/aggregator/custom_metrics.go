@@ -0,0 +1,105 @@
+package aggregator
+
+import (
+	"context"
+	"time"
+
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/aaronlmathis/kaptn/internal/metrics"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+// collectCustomMetrics records the configured custom metrics per described
+// object and the configured external metrics per namespace. Each API is
+// skipped when discovery does not report it.
+func (a *Aggregator) collectCustomMetrics(ctx context.Context, now time.Time) {
+	a.mu.RLock()
+	customQueries := a.config.CustomMetrics
+	externalQueries := a.config.ExternalMetrics
+	a.mu.RUnlock()
+
+	if len(customQueries) == 0 && len(externalQueries) == 0 {
+		return
+	}
+
+	start := a.clock.Now()
+	var hasError bool
+	defer func() {
+		metrics.RecordCollectorScrape("custom_metrics", a.clock.Since(start), hasError)
+		if !hasError {
+			a.collectorRecovered("custom_metrics")
+		}
+	}()
+
+	if len(customQueries) > 0 && a.customMetrics.HasCustomMetricsAPI(ctx) {
+		for _, query := range customQueries {
+			values, err := a.customMetrics.ListCustomMetric(ctx, query)
+			if err != nil {
+				hasError = true
+				a.logCollectorFailure("custom_metrics", "Failed to collect custom metric", err)
+				continue
+			}
+			for _, value := range values {
+				a.storeCustomMetricValue(now, value)
+			}
+		}
+	}
+
+	if len(externalQueries) > 0 && a.customMetrics.HasExternalMetricsAPI(ctx) {
+		for _, query := range externalQueries {
+			values, err := a.customMetrics.ListExternalMetric(ctx, query)
+			if err != nil {
+				hasError = true
+				a.logCollectorFailure("custom_metrics", "Failed to collect external metric", err)
+				continue
+			}
+			a.storeExternalMetricValues(now, query, values)
+		}
+	}
+}
+
+// storeCustomMetricValue records a custom metric under the object it describes
+func (a *Aggregator) storeCustomMetricValue(now time.Time, value kubemetrics.CustomMetricValue) {
+	key := timeseries.GenerateCustomMetricSeriesKey(value.Metric, value.Kind, value.Namespace, value.Name)
+	series := a.store.Upsert(key)
+	if series == nil {
+		return
+	}
+
+	entity := map[string]string{
+		"metric": value.Metric,
+		"kind":   value.Kind,
+		"name":   value.Name,
+	}
+	if value.Namespace != "" {
+		entity["namespace"] = value.Namespace
+	}
+	series.Add(timeseries.NewPointWithEntity(now, value.Value, entity))
+}
+
+// storeExternalMetricValues records the sum of an external metric's values,
+// which is the quantity an HPA compares against a Value target
+func (a *Aggregator) storeExternalMetricValues(now time.Time, query kubemetrics.ExternalMetricQuery, values []kubemetrics.CustomMetricValue) {
+	if len(values) == 0 {
+		return
+	}
+
+	namespace := query.Namespace
+	if namespace == "" {
+		namespace = kubemetrics.DefaultExternalMetricsNamespace
+	}
+
+	var total float64
+	for _, value := range values {
+		total += value.Value
+	}
+
+	series := a.store.Upsert(timeseries.GenerateExternalMetricSeriesKey(query.Metric, namespace))
+	if series == nil {
+		return
+	}
+	series.Add(timeseries.NewPointWithEntity(now, total, map[string]string{
+		"metric":    query.Metric,
+		"namespace": namespace,
+	}))
+}
@@ -0,0 +1,84 @@
+package aggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	cmv1beta2 "k8s.io/metrics/pkg/apis/custom_metrics/v1beta2"
+	emv1beta1 "k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+	cmfake "k8s.io/metrics/pkg/client/custom_metrics/fake"
+	emfake "k8s.io/metrics/pkg/client/external_metrics/fake"
+
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func TestCollectCustomMetricsRecordsSeries(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "custom.metrics.k8s.io/v1beta2"},
+		{GroupVersion: "external.metrics.k8s.io/v1beta1"},
+	}
+
+	customClient := &cmfake.FakeCustomMetricsClient{}
+	customClient.AddReactor("get", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &cmv1beta2.MetricValueList{Items: []cmv1beta2.MetricValue{
+			{DescribedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "web-1"}, Value: resource.MustParse("7")},
+		}}, nil
+	})
+	externalClient := &emfake.FakeExternalMetricsClient{}
+	externalClient.AddReactor("list", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &emv1beta1.ExternalMetricValueList{Items: []emv1beta1.ExternalMetricValue{
+			{MetricName: "queue_messages_ready", Value: resource.MustParse("30")},
+			{MetricName: "queue_messages_ready", Value: resource.MustParse("12")},
+		}}, nil
+	})
+
+	config := DefaultConfig()
+	config.CustomMetrics = []kubemetrics.CustomMetricQuery{{Metric: "http_requests", Kind: "Pod", Namespace: "shop"}}
+	config.ExternalMetrics = []kubemetrics.ExternalMetricQuery{{Metric: "queue_messages_ready", Namespace: "shop"}}
+
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, client, metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, config)
+	a.customMetrics = kubemetrics.NewCustomMetricsAdapter(zap.NewNop(), client, customClient, externalClient)
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	a.SetClock(clock)
+	a.collectCustomMetrics(context.Background(), clock.Now())
+
+	assert.Equal(t, 7.0, latestValue(t, store, "custom.http_requests.pod.shop.web-1"))
+	assert.Equal(t, 42.0, latestValue(t, store, "external.queue_messages_ready.shop"))
+
+	capabilities := a.GetCapabilities(context.Background())
+	assert.True(t, capabilities["customMetricsAPI"])
+	assert.True(t, capabilities["externalMetricsAPI"])
+}
+
+func TestCollectCustomMetricsSkipsUnavailableAPI(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	customClient := &cmfake.FakeCustomMetricsClient{}
+
+	config := DefaultConfig()
+	config.CustomMetrics = []kubemetrics.CustomMetricQuery{{Metric: "http_requests", Kind: "Pod", Namespace: "shop"}}
+
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, client, metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, config)
+	a.customMetrics = kubemetrics.NewCustomMetricsAdapter(zap.NewNop(), client, customClient, nil)
+
+	a.collectCustomMetrics(context.Background(), time.Now())
+
+	assert.Empty(t, customClient.Actions(), "custom metrics must not be queried when discovery does not serve the API")
+	assert.False(t, a.GetCapabilities(context.Background())["customMetricsAPI"])
+}
@@ -0,0 +1,41 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func TestRecordClusterImageFsSumsNodes(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	a.recordClusterImageFs([]kubemetrics.NodeImageFsStats{
+		{NodeName: "node-a", ImageFsUsedBytes: 3 << 30, ImageFsCapacityBytes: 50 << 30},
+		{NodeName: "node-b", ImageFsUsedBytes: 5 << 30, ImageFsCapacityBytes: 100 << 30},
+	}, now)
+
+	assert.Equal(t, float64(8<<30), latestValue(t, store, timeseries.ClusterFsImageUsedBytes))
+	assert.Equal(t, float64(150<<30), latestValue(t, store, timeseries.ClusterFsImageCapacityBytes))
+}
+
+func TestRecordClusterImageFsSkipsWithoutStats(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	a.recordClusterImageFs(nil, time.Now())
+
+	for _, key := range []string{timeseries.ClusterFsImageUsedBytes, timeseries.ClusterFsImageCapacityBytes} {
+		_, ok := store.Get(key)
+		assert.False(t, ok, "no placeholder should be stored for %s", key)
+	}
+}
@@ -0,0 +1,85 @@
+package aggregator
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// collectorFailure is the last failure logged for a collector
+type collectorFailure struct {
+	message    string
+	since      time.Time // first failure of the current outage
+	lastLogged time.Time
+	suppressed int // identical failures skipped since lastLogged
+}
+
+// collectorLogThrottle deduplicates collector failure logs so an API that is
+// down for a long time is not reported on every poll
+type collectorLogThrottle struct {
+	mu       sync.Mutex
+	failures map[string]*collectorFailure
+}
+
+// logCollectorFailure logs a collector failure at warn level unless the same
+// message was already logged for the collector within ErrorLogInterval. A
+// changed message is always logged, and a repeated one reports how many times
+// it was suppressed.
+func (a *Aggregator) logCollectorFailure(collector, msg string, err error) {
+	now := a.clock.Now()
+	message := msg
+	if err != nil {
+		message += ": " + err.Error()
+	}
+
+	a.mu.RLock()
+	interval := a.config.ErrorLogInterval
+	a.mu.RUnlock()
+
+	t := &a.failureLogs
+	t.mu.Lock()
+	if t.failures == nil {
+		t.failures = make(map[string]*collectorFailure)
+	}
+	failure, failing := t.failures[collector]
+	if failing && failure.message == message && now.Sub(failure.lastLogged) < interval {
+		failure.suppressed++
+		t.mu.Unlock()
+		return
+	}
+
+	fields := []zap.Field{zap.String("collector", collector), zap.Error(err)}
+	if !failing {
+		failure = &collectorFailure{since: now}
+		t.failures[collector] = failure
+	} else if failure.message == message {
+		fields = append(fields,
+			zap.Int("suppressed", failure.suppressed),
+			zap.Duration("failingFor", now.Sub(failure.since)))
+	}
+	failure.message = message
+	failure.lastLogged = now
+	failure.suppressed = 0
+	t.mu.Unlock()
+
+	a.logger.Warn(msg, fields...)
+}
+
+// collectorRecovered logs once when a collector that was failing succeeds again
+func (a *Aggregator) collectorRecovered(collector string) {
+	t := &a.failureLogs
+	t.mu.Lock()
+	failure, failing := t.failures[collector]
+	if !failing {
+		t.mu.Unlock()
+		return
+	}
+	delete(t.failures, collector)
+	t.mu.Unlock()
+
+	a.logger.Info("Collector recovered",
+		zap.String("collector", collector),
+		zap.Duration("failedFor", a.clock.Since(failure.since)),
+		zap.String("lastError", failure.message))
+}
@@ -0,0 +1,126 @@
+package aggregator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func newThrottleTestAggregator(t *testing.T) (*Aggregator, *observer.ObservedLogs, *fakeClock) {
+	t.Helper()
+	config := DefaultConfig()
+	config.ErrorLogInterval = 5 * time.Minute
+
+	a := NewAggregator(zap.NewNop(), timeseries.NewMemStore(timeseries.DefaultConfig()), fake.NewSimpleClientset(),
+		metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, config)
+	core, logs := observer.New(zapcore.InfoLevel)
+	a.logger = zap.New(core)
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	a.SetClock(clock)
+	return a, logs, clock
+}
+
+func TestLogCollectorFailureThrottlesIdenticalWarnings(t *testing.T) {
+	a, logs, clock := newThrottleTestAggregator(t)
+	apiDown := errors.New("the server is currently unable to handle the request")
+
+	for i := 0; i < 10; i++ {
+		a.logCollectorFailure("pods", "Failed to collect pod metrics", apiDown)
+		clock.Advance(10 * time.Second)
+	}
+	assert.Equal(t, 1, logs.FilterMessage("Failed to collect pod metrics").Len())
+
+	// Once the interval has passed the failure is logged again with the skipped count
+	clock.Advance(5 * time.Minute)
+	a.logCollectorFailure("pods", "Failed to collect pod metrics", apiDown)
+
+	entries := logs.FilterMessage("Failed to collect pod metrics").All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	assert.Equal(t, int64(9), entries[1].ContextMap()["suppressed"])
+}
+
+func TestLogCollectorFailureLogsChangedMessage(t *testing.T) {
+	a, logs, _ := newThrottleTestAggregator(t)
+
+	a.logCollectorFailure("summary", "Failed to collect network stats", errors.New("connection refused"))
+	a.logCollectorFailure("summary", "Failed to collect network stats", errors.New("connection refused"))
+	a.logCollectorFailure("summary", "Failed to collect network stats", errors.New("context deadline exceeded"))
+
+	assert.Equal(t, 2, logs.FilterMessage("Failed to collect network stats").Len())
+}
+
+func TestLogCollectorFailureTracksCollectorsSeparately(t *testing.T) {
+	a, logs, _ := newThrottleTestAggregator(t)
+	apiDown := errors.New("metrics API unavailable")
+
+	a.logCollectorFailure("pods", "Failed to collect pod metrics", apiDown)
+	a.logCollectorFailure("containers", "Failed to collect container metrics", apiDown)
+
+	assert.Equal(t, 2, logs.FilterLevelExact(zapcore.WarnLevel).Len())
+}
+
+func TestCollectorRecoveredLogsOnce(t *testing.T) {
+	a, logs, clock := newThrottleTestAggregator(t)
+
+	a.collectorRecovered("pods")
+	assert.Zero(t, logs.FilterMessage("Collector recovered").Len(), "a healthy collector must not log recovery")
+
+	a.logCollectorFailure("pods", "Failed to collect pod metrics", errors.New("metrics API unavailable"))
+	clock.Advance(2 * time.Minute)
+	a.collectorRecovered("pods")
+	a.collectorRecovered("pods")
+
+	recovered := logs.FilterMessage("Collector recovered").All()
+	require.Len(t, recovered, 1)
+	assert.Equal(t, "pods", recovered[0].ContextMap()["collector"])
+	assert.Equal(t, 2*time.Minute, recovered[0].ContextMap()["failedFor"])
+
+	// A new outage after recovery is logged immediately
+	a.logCollectorFailure("pods", "Failed to collect pod metrics", errors.New("metrics API unavailable"))
+	assert.Equal(t, 2, logs.FilterMessage("Failed to collect pod metrics").Len())
+}
+
+func TestImageFsCollectorThrottlesFailures(t *testing.T) {
+	a, logs, clock := newThrottleTestAggregator(t)
+	failed := &summaryFetch{err: errors.New("connection refused")}
+
+	for i := 0; i < 3; i++ {
+		a.collectClusterImageFsMetrics(clock.Now(), failed)
+		clock.Advance(10 * time.Second)
+	}
+	assert.Equal(t, 1, logs.FilterMessage("Failed to get image filesystem stats").Len())
+
+	a.collectClusterImageFsMetrics(clock.Now(), &summaryFetch{})
+	recovered := logs.FilterMessage("Collector recovered").All()
+	require.Len(t, recovered, 1)
+	assert.Equal(t, "imagefs", recovered[0].ContextMap()["collector"])
+}
+
+func TestPodNetworkCollectorThrottlesFailures(t *testing.T) {
+	a, logs, clock := newThrottleTestAggregator(t)
+	failed := &summaryFetch{err: errors.New("connection refused")}
+
+	for i := 0; i < 3; i++ {
+		a.collectPodNetworkMetrics(clock.Now(), failed)
+		clock.Advance(10 * time.Second)
+	}
+	assert.Equal(t, 1, logs.FilterMessage("Failed to collect pod network stats").Len())
+
+	a.collectPodNetworkMetrics(clock.Now(), &summaryFetch{})
+	recovered := logs.FilterMessage("Collector recovered").All()
+	require.Len(t, recovered, 1)
+	assert.Equal(t, "pod_network", recovered[0].ContextMap()["collector"])
+}
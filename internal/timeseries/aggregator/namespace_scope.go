@@ -0,0 +1,55 @@
+package aggregator
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// namespaceAllowed reports whether namespace is covered by the allow and deny
+// lists. The deny list wins; an empty allow list admits every namespace.
+func (c Config) namespaceAllowed(namespace string) bool {
+	for _, denied := range c.NamespaceDenyList {
+		if namespace == denied {
+			return false
+		}
+	}
+	if len(c.NamespaceAllowList) == 0 {
+		return true
+	}
+	for _, allowed := range c.NamespaceAllowList {
+		if namespace == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// scoped reports whether the config restricts the namespaces scanned
+func (c Config) scoped() bool {
+	return len(c.NamespaceAllowList) > 0 || len(c.NamespaceDenyList) > 0
+}
+
+// filterPods returns the pods in allowed namespaces, reusing pods when the
+// config does not restrict namespaces
+func (c Config) filterPods(pods []corev1.Pod) []corev1.Pod {
+	if !c.scoped() {
+		return pods
+	}
+	filtered := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if c.namespaceAllowed(pod.Namespace) {
+			filtered = append(filtered, pod)
+		}
+	}
+	return filtered
+}
+
+// namespaceScope returns a copy of the config for namespace filtering by
+// collectors that do not hold a.mu
+func (a *Aggregator) namespaceScope() Config {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return Config{
+		NamespaceAllowList: a.config.NamespaceAllowList,
+		NamespaceDenyList:  a.config.NamespaceDenyList,
+	}
+}
@@ -0,0 +1,94 @@
+package aggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func namespacedPod(namespace, name string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       v1.PodSpec{NodeName: "node-a"},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+func TestNamespaceAllowed(t *testing.T) {
+	unscoped := Config{}
+	assert.True(t, unscoped.namespaceAllowed("anything"))
+
+	cfg := Config{
+		NamespaceAllowList: []string{"team-a", "team-b"},
+		NamespaceDenyList:  []string{"team-b"},
+	}
+	assert.True(t, cfg.namespaceAllowed("team-a"))
+	assert.False(t, cfg.namespaceAllowed("team-b"), "deny list wins over allow list")
+	assert.False(t, cfg.namespaceAllowed("kube-system"))
+
+	denyOnly := Config{NamespaceDenyList: []string{"kube-system"}}
+	assert.True(t, denyOnly.namespaceAllowed("default"))
+	assert.False(t, denyOnly.namespaceAllowed("kube-system"))
+}
+
+func TestValidateRejectsEmptyNamespaceEntries(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.NamespaceDenyList = []string{"kube-system", ""}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestDeniedNamespacesProduceNoPodOrNamespaceSeries(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		namespacedPod("team-a", "web"),
+		namespacedPod("kube-system", "coredns"),
+	)
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	cfg := DefaultConfig()
+	cfg.NamespaceDenyList = []string{"kube-system"}
+	a := NewAggregator(zap.NewNop(), store, client, metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, cfg)
+
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	a.collectPodResourceMetrics(ctx, now)
+	a.collectPodRestartMetrics(ctx, now)
+	a.collectNamespaceMetrics(ctx, now)
+	a.recordContainerStorage([]kubemetrics.ContainerStorageStats{
+		{PodNamespace: "team-a", PodName: "web", ContainerName: "app", RootfsUsedBytes: 1},
+		{PodNamespace: "kube-system", PodName: "coredns", ContainerName: "coredns", RootfsUsedBytes: 1},
+	}, now)
+
+	for _, key := range []string{
+		timeseries.GeneratePodSeriesKey(timeseries.PodRestartsTotalBase, "team-a", "web"),
+		timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePodsRunningBase, "team-a"),
+		timeseries.GenerateContainerSeriesKey(timeseries.ContainerRootFsUsedBase, "team-a", "web", "app"),
+	} {
+		_, ok := store.Get(key)
+		assert.True(t, ok, "expected series %s", key)
+	}
+
+	for _, key := range store.Keys() {
+		assert.NotContains(t, key, "kube-system")
+	}
+}
+
+func TestUpdateConfigReloadsNamespaceLists(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	updated := a.Config()
+	updated.NamespaceAllowList = []string{"team-a"}
+	a.UpdateConfig(updated)
+
+	assert.False(t, a.namespaceScope().namespaceAllowed("team-b"))
+}
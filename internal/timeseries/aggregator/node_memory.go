@@ -0,0 +1,105 @@
+package aggregator
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/units"
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+// nodeMemorySample is one node's memory reading from either source
+type nodeMemorySample struct {
+	workingSet   float64
+	available    float64
+	hasAvailable bool   // Only the Summary API reports available memory
+	source       string // "summary" or "metrics-server"
+}
+
+// nodeMemoryFromSummary converts Summary API node stats into samples. Nodes
+// reporting no working set are left out so metrics-server can cover them.
+func nodeMemoryFromSummary(stats []kubemetrics.NodeMemoryStats) map[string]nodeMemorySample {
+	samples := make(map[string]nodeMemorySample, len(stats))
+	for _, stat := range stats {
+		if stat.WorkingSetBytes == 0 {
+			continue
+		}
+		samples[stat.NodeName] = nodeMemorySample{
+			workingSet:   float64(stat.WorkingSetBytes),
+			available:    float64(stat.AvailableBytes),
+			hasAvailable: stat.AvailableBytes > 0,
+			source:       "summary",
+		}
+	}
+	return samples
+}
+
+// nodeMemoryFromMetricsServer converts metrics-server node usage into samples
+func nodeMemoryFromMetricsServer(usage map[string]float64) map[string]nodeMemorySample {
+	samples := make(map[string]nodeMemorySample, len(usage))
+	for nodeName, bytes := range usage {
+		samples[nodeName] = nodeMemorySample{workingSet: bytes, source: "metrics-server"}
+	}
+	return samples
+}
+
+// mergeNodeMemory takes each node's Summary API sample when there is one and
+// its metrics-server sample otherwise
+func mergeNodeMemory(summary, metricsServer map[string]nodeMemorySample) map[string]nodeMemorySample {
+	merged := make(map[string]nodeMemorySample, len(metricsServer)+len(summary))
+	for nodeName, sample := range metricsServer {
+		merged[nodeName] = sample
+	}
+	for nodeName, sample := range summary {
+		merged[nodeName] = sample
+	}
+	return merged
+}
+
+// summaryCoversAllNodes reports whether the Summary API returned a sample for
+// every node known from the last capacity refresh, so metrics-server need not
+// be queried
+func (a *Aggregator) summaryCoversAllNodes(samples map[string]nodeMemorySample) bool {
+	if len(samples) == 0 {
+		return false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for nodeName := range a.hostSnapshots {
+		if _, ok := samples[nodeName]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// recordNodeMemory stores per-node usage, working set and available memory and
+// the cluster total used by the headroom chart
+func (a *Aggregator) recordNodeMemory(samples map[string]nodeMemorySample, now time.Time) {
+	var totalUsage float64
+	var fromSummary int
+	for nodeName, sample := range samples {
+		nodeEntity := map[string]string{"node": nodeName}
+		totalUsage += sample.workingSet
+
+		// Both sources report the working set, which is what node usage means here
+		a.storeMetric(timeseries.GenerateNodeSeriesKey(timeseries.NodeMemUsageBase, nodeName), now, sample.workingSet, nodeEntity)
+		a.storeMetric(timeseries.GenerateNodeSeriesKey(timeseries.NodeMemWorkingSetBase, nodeName), now, sample.workingSet, nodeEntity)
+		if sample.hasAvailable {
+			a.storeMetric(timeseries.GenerateNodeSeriesKey(timeseries.NodeMemAvailableBase, nodeName), now, sample.available, nodeEntity)
+		}
+		if sample.source == "summary" {
+			fromSummary++
+		}
+	}
+
+	a.storeMetric(timeseries.ClusterMemUsedBytes, now, totalUsage, nil)
+
+	a.logger.Debug("Collected memory usage metrics",
+		zap.String("total_usage", units.HumanizeBytes(int64(totalUsage))),
+		zap.Int("nodes", len(samples)),
+		zap.Int("nodes_from_summary", fromSummary),
+	)
+}
@@ -0,0 +1,61 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func TestNodeMemoryPrefersSummaryAPI(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	// metrics-server and the kubelet disagree about node-a; node-b's kubelet
+	// did not answer and node-c reported no working set
+	summary := nodeMemoryFromSummary([]kubemetrics.NodeMemoryStats{
+		{NodeName: "node-a", UsageBytes: 5 << 30, WorkingSetBytes: 3 << 30, AvailableBytes: 13 << 30},
+		{NodeName: "node-c"},
+	})
+	metricsServer := nodeMemoryFromMetricsServer(map[string]float64{
+		"node-a": 4 << 30,
+		"node-b": 2 << 30,
+		"node-c": 1 << 30,
+	})
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	a.recordNodeMemory(mergeNodeMemory(summary, metricsServer), now)
+
+	assert.Equal(t, float64(3<<30), latestValue(t, store, timeseries.GenerateNodeSeriesKey(timeseries.NodeMemUsageBase, "node-a")))
+	assert.Equal(t, float64(3<<30), latestValue(t, store, timeseries.GenerateNodeSeriesKey(timeseries.NodeMemWorkingSetBase, "node-a")))
+	assert.Equal(t, float64(13<<30), latestValue(t, store, timeseries.GenerateNodeSeriesKey(timeseries.NodeMemAvailableBase, "node-a")))
+
+	assert.Equal(t, float64(2<<30), latestValue(t, store, timeseries.GenerateNodeSeriesKey(timeseries.NodeMemUsageBase, "node-b")))
+	assert.Equal(t, float64(1<<30), latestValue(t, store, timeseries.GenerateNodeSeriesKey(timeseries.NodeMemUsageBase, "node-c")))
+	for _, node := range []string{"node-b", "node-c"} {
+		_, ok := store.Get(timeseries.GenerateNodeSeriesKey(timeseries.NodeMemAvailableBase, node))
+		assert.False(t, ok, "metrics-server cannot report available memory for %s", node)
+	}
+
+	assert.Equal(t, float64(6<<30), latestValue(t, store, timeseries.ClusterMemUsedBytes))
+}
+
+func TestSummaryCoversAllNodes(t *testing.T) {
+	a := NewAggregator(zap.NewNop(), timeseries.NewMemStore(timeseries.DefaultConfig()), fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+	a.hostSnapshots = map[string]*hostSnap{"node-a": {}, "node-b": {}}
+
+	samples := map[string]nodeMemorySample{"node-a": {workingSet: 1, source: "summary"}}
+	assert.False(t, a.summaryCoversAllNodes(samples))
+
+	samples["node-b"] = nodeMemorySample{workingSet: 1, source: "summary"}
+	assert.True(t, a.summaryCoversAllNodes(samples))
+
+	assert.False(t, a.summaryCoversAllNodes(nil))
+}
@@ -0,0 +1,90 @@
+package aggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func scheduledPod(name, nodeName string, phase v1.PodPhase) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1.PodSpec{NodeName: nodeName},
+		Status:     v1.PodStatus{Phase: phase},
+	}
+}
+
+func TestCollectNodePodCountsPhaseBreakdown(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		scheduledPod("a-running-1", "node-a", v1.PodRunning),
+		scheduledPod("a-running-2", "node-a", v1.PodRunning),
+		scheduledPod("a-pending", "node-a", v1.PodPending),
+		scheduledPod("a-succeeded", "node-a", v1.PodSucceeded),
+		scheduledPod("b-running", "node-b", v1.PodRunning),
+		scheduledPod("b-failed-1", "node-b", v1.PodFailed),
+		scheduledPod("b-failed-2", "node-b", v1.PodFailed),
+		scheduledPod("unscheduled", "", v1.PodPending),
+	)
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, client, metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	a.SetClock(clock)
+	a.collectNodePodCounts(context.Background(), clock.Now())
+
+	nodeValue := func(base, node string) float64 {
+		return latestValue(t, store, timeseries.GenerateNodeSeriesKey(base, node))
+	}
+
+	assert.Equal(t, 2.0, nodeValue(timeseries.NodePodsPhaseRunningBase, "node-a"))
+	assert.Equal(t, 1.0, nodeValue(timeseries.NodePodsPhasePendingBase, "node-a"))
+	assert.Equal(t, 0.0, nodeValue(timeseries.NodePodsPhaseFailedBase, "node-a"))
+	assert.Equal(t, 3.0, nodeValue(timeseries.NodePodsCountBase, "node-a"))
+
+	assert.Equal(t, 1.0, nodeValue(timeseries.NodePodsPhaseRunningBase, "node-b"))
+	assert.Equal(t, 0.0, nodeValue(timeseries.NodePodsPhasePendingBase, "node-b"))
+	assert.Equal(t, 2.0, nodeValue(timeseries.NodePodsPhaseFailedBase, "node-b"))
+	assert.Equal(t, 1.0, nodeValue(timeseries.NodePodsCountBase, "node-b"))
+}
+
+func TestCollectResourceRequestsPerNode(t *testing.T) {
+	requesting := func(name, nodeName, cpu, memory string, phase v1.PodPhase) *v1.Pod {
+		pod := scheduledPod(name, nodeName, phase)
+		pod.Spec.Containers = []v1.Container{{Name: "app", Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(cpu),
+			v1.ResourceMemory: resource.MustParse(memory),
+		}}}}
+		return pod
+	}
+	client := fake.NewSimpleClientset(
+		requesting("a-1", "node-a", "500m", "1Gi", v1.PodRunning),
+		requesting("a-2", "node-a", "250m", "512Mi", v1.PodPending),
+		requesting("a-done", "node-a", "4", "8Gi", v1.PodSucceeded),
+		scheduledPod("b-besteffort", "node-b", v1.PodRunning),
+		requesting("unscheduled", "", "1", "1Gi", v1.PodPending),
+	)
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, client, metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	a.collectResourceRequests(context.Background(), time.Now())
+
+	nodeValue := func(base, node string) float64 {
+		return latestValue(t, store, timeseries.GenerateNodeSeriesKey(base, node))
+	}
+	assert.Equal(t, 0.75, nodeValue(timeseries.NodeRequestedCPUBase, "node-a"))
+	assert.Equal(t, float64(1536<<20), nodeValue(timeseries.NodeRequestedMemBase, "node-a"))
+	// Nodes whose pods request nothing report 0 rather than no data
+	assert.Equal(t, 0.0, nodeValue(timeseries.NodeRequestedCPUBase, "node-b"))
+	assert.Equal(t, 1.75, latestValue(t, store, timeseries.ClusterCPURequestedCores))
+}
@@ -0,0 +1,98 @@
+package aggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+// conditionNode returns a node that is Ready unless notReady is set and that
+// reports the given pressure conditions
+func conditionNode(name string, notReady bool, pressures ...v1.NodeConditionType) *v1.Node {
+	ready := v1.ConditionTrue
+	if notReady {
+		ready = v1.ConditionFalse
+	}
+	conditions := []v1.NodeCondition{{Type: v1.NodeReady, Status: ready}}
+	for _, pressure := range pressures {
+		conditions = append(conditions, v1.NodeCondition{Type: pressure, Status: v1.ConditionTrue})
+	}
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     v1.NodeStatus{Conditions: conditions},
+	}
+}
+
+func TestCollectNodeConditionMetricsPressureScore(t *testing.T) {
+	tests := []struct {
+		node  *v1.Node
+		score float64
+	}{
+		{conditionNode("healthy", false), 0},
+		{conditionNode("disk", false, v1.NodeDiskPressure), 1},
+		{conditionNode("memory-pid", false, v1.NodeMemoryPressure, v1.NodePIDPressure), 2},
+		{conditionNode("all-pressures", false, v1.NodeDiskPressure, v1.NodeMemoryPressure, v1.NodePIDPressure), 3},
+		{conditionNode("not-ready", true), 1},
+		{conditionNode("not-ready-disk", true, v1.NodeDiskPressure), 2},
+		// A node without conditions has not reported Ready
+		{&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "unknown"}}, 1},
+	}
+
+	objects := make([]runtime.Object, 0, len(tests))
+	for _, tt := range tests {
+		objects = append(objects, tt.node)
+	}
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(objects...), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	a.SetClock(clock)
+	a.collectNodeConditionMetrics(context.Background(), clock.Now())
+
+	for _, tt := range tests {
+		key := timeseries.GenerateNodeSeriesKey(timeseries.NodePressureScoreBase, tt.node.Name)
+		assert.Equal(t, tt.score, latestValue(t, store, key), tt.node.Name)
+	}
+	assert.Equal(t, 6.0, latestValue(t, store, timeseries.ClusterNodesUnderPressure))
+}
+
+func TestCollectNodeConditionMetricsNoPressure(t *testing.T) {
+	client := fake.NewSimpleClientset(conditionNode("node-a", false), conditionNode("node-b", false))
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, client, metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	a.collectNodeConditionMetrics(context.Background(), time.Now())
+
+	assert.Equal(t, 0.0, latestValue(t, store, timeseries.ClusterNodesUnderPressure))
+}
+
+func TestCollectNodeConditionMetricsSchedulable(t *testing.T) {
+	cordoned := conditionNode("cordoned", false)
+	cordoned.Spec.Unschedulable = true
+	tainted := conditionNode("tainted", false)
+	tainted.Spec.Taints = []v1.Taint{{Key: "dedicated", Effect: v1.TaintEffectNoSchedule}}
+	preferred := conditionNode("preferred", false)
+	preferred.Spec.Taints = []v1.Taint{{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule}}
+
+	client := fake.NewSimpleClientset(conditionNode("ready", false), conditionNode("not-ready", true), cordoned, tainted, preferred)
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, client, metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	a.collectNodeConditionMetrics(context.Background(), time.Now())
+
+	for node, schedulable := range map[string]float64{"ready": 1, "not-ready": 0, "cordoned": 0, "tainted": 0, "preferred": 1} {
+		key := timeseries.GenerateNodeSeriesKey(timeseries.NodeSchedulableBase, node)
+		assert.Equal(t, schedulable, latestValue(t, store, key), node)
+	}
+}
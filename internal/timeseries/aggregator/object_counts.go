@@ -0,0 +1,58 @@
+package aggregator
+
+import (
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+// ObjectCounter reports cached object counts keyed by lower-case plural
+// resource name, such as the informer manager's ObjectCounts
+type ObjectCounter interface {
+	ObjectCounts() map[string]int
+}
+
+// objectCountSeries maps the resources reported by an ObjectCounter to their series
+var objectCountSeries = map[string]string{
+	"namespaces":             timeseries.ClusterNamespacesCount,
+	"deployments":            timeseries.ClusterDeploymentsCount,
+	"statefulsets":           timeseries.ClusterStatefulSetsCount,
+	"daemonsets":             timeseries.ClusterDaemonSetsCount,
+	"replicasets":            timeseries.ClusterReplicaSetsCount,
+	"jobs":                   timeseries.ClusterJobsCount,
+	"cronjobs":               timeseries.ClusterCronJobsCount,
+	"services":               timeseries.ClusterServicesCount,
+	"configmaps":             timeseries.ClusterConfigMapsCount,
+	"secrets":                timeseries.ClusterSecretsCount,
+	"ingresses":              timeseries.ClusterIngressesCount,
+	"persistentvolumeclaims": timeseries.ClusterPVCsCount,
+}
+
+// SetObjectCounter sets the source of per-kind object counts. Without one the
+// object count series are not recorded.
+func (a *Aggregator) SetObjectCounter(counter ObjectCounter) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.objectCounter = counter
+}
+
+// collectObjectCounts records the number of objects of each major resource
+// kind from the informer caches, so it costs no API calls
+func (a *Aggregator) collectObjectCounts(now time.Time) {
+	a.mu.RLock()
+	counter := a.objectCounter
+	a.mu.RUnlock()
+	if counter == nil {
+		return
+	}
+
+	for resource, count := range counter.ObjectCounts() {
+		key, ok := objectCountSeries[resource]
+		if !ok {
+			continue
+		}
+		if series := a.store.Upsert(key); series != nil {
+			series.Add(timeseries.NewPoint(now, float64(count)))
+		}
+	}
+}
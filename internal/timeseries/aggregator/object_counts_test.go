@@ -0,0 +1,43 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+type staticObjectCounter map[string]int
+
+func (c staticObjectCounter) ObjectCounts() map[string]int { return c }
+
+func TestCollectObjectCounts(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Without a counter nothing is recorded
+	a.collectObjectCounts(now)
+	_, ok := store.Get(timeseries.ClusterDeploymentsCount)
+	assert.False(t, ok)
+
+	a.SetObjectCounter(staticObjectCounter{
+		"deployments": 12,
+		"secrets":     40,
+		"configmaps":  0,
+		"widgets":     3, // unknown resources are ignored
+	})
+	a.collectObjectCounts(now)
+
+	assert.Equal(t, 12.0, latestValue(t, store, timeseries.ClusterDeploymentsCount))
+	assert.Equal(t, 40.0, latestValue(t, store, timeseries.ClusterSecretsCount))
+	assert.Equal(t, 0.0, latestValue(t, store, timeseries.ClusterConfigMapsCount))
+	_, ok = store.Get(timeseries.ClusterServicesCount)
+	assert.False(t, ok, "resources missing from the counts are not recorded")
+}
@@ -0,0 +1,128 @@
+package aggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1types "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func podMetricsFor(namespace, name string, usage ...v1.ResourceList) metricsv1beta1types.PodMetrics {
+	pm := metricsv1beta1types.PodMetrics{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	for i, u := range usage {
+		pm.Containers = append(pm.Containers, metricsv1beta1types.ContainerMetrics{
+			Name:  string(rune('a' + i)),
+			Usage: u,
+		})
+	}
+	return pm
+}
+
+// podMetricsAggregator returns an aggregator whose kube client holds a
+// running web pod and a completed migrate pod, both reported by metrics-server
+func podMetricsAggregator(store timeseries.Store) *Aggregator {
+	kubeClient := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "migrate"}, Status: v1.PodStatus{Phase: v1.PodSucceeded}},
+	)
+	metricsClient := metricsfake.NewSimpleClientset()
+	metricsClient.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsv1beta1types.PodMetricsList{Items: []metricsv1beta1types.PodMetrics{
+			podMetricsFor("shop", "web",
+				v1.ResourceList{v1.ResourceCPU: resource.MustParse("250m"), v1.ResourceMemory: resource.MustParse("64Mi")},
+				v1.ResourceList{v1.ResourceCPU: resource.MustParse("50m"), v1.ResourceMemory: resource.MustParse("16Mi")},
+			),
+			podMetricsFor("shop", "migrate",
+				v1.ResourceList{v1.ResourceCPU: resource.MustParse("1"), v1.ResourceMemory: resource.MustParse("1Gi")},
+			),
+		}}, nil
+	})
+
+	return NewAggregator(zap.NewNop(), store, kubeClient, metricsClient.MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+}
+
+func TestCollectPodMetricsParsesUsage(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	podMetricsAggregator(store).collectPodMetrics(context.Background(), time.Now())
+
+	assert.InDelta(t, 0.3, latestValue(t, store, timeseries.GeneratePodSeriesKey(timeseries.PodCPUUsageBase, "shop", "web")), 1e-9)
+	assert.Equal(t, float64(80<<20), latestValue(t, store, timeseries.GeneratePodSeriesKey(timeseries.PodMemUsageBase, "shop", "web")))
+	assert.Equal(t, float64(80<<20), latestValue(t, store, timeseries.GeneratePodSeriesKey(timeseries.PodMemWorkingSetBase, "shop", "web")))
+
+	_, ok := store.Get(timeseries.GeneratePodSeriesKey(timeseries.PodCPUUsageBase, "shop", "migrate"))
+	assert.False(t, ok, "completed pods are skipped")
+}
+
+func TestCollectContainerMetricsUsesRealContainers(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	podMetricsAggregator(store).collectContainerMetrics(context.Background(), time.Now())
+
+	assert.InDelta(t, 0.25, latestValue(t, store, timeseries.GenerateContainerSeriesKey(timeseries.ContainerCPUUsageBase, "shop", "web", "a")), 1e-9)
+	assert.Equal(t, float64(64<<20), latestValue(t, store, timeseries.GenerateContainerSeriesKey(timeseries.ContainerMemWorkingSetBase, "shop", "web", "a")))
+	assert.InDelta(t, 0.05, latestValue(t, store, timeseries.GenerateContainerSeriesKey(timeseries.ContainerCPUUsageBase, "shop", "web", "b")), 1e-9)
+	assert.Equal(t, float64(16<<20), latestValue(t, store, timeseries.GenerateContainerSeriesKey(timeseries.ContainerMemWorkingSetBase, "shop", "web", "b")))
+
+	series, ok := store.Get(timeseries.GenerateContainerSeriesKey(timeseries.ContainerCPUUsageBase, "shop", "web", "a"))
+	assert.True(t, ok)
+	point, _ := series.Latest()
+	assert.Equal(t, map[string]string{"namespace": "shop", "pod": "web", "container": "a"}, point.Entity)
+
+	// Only the web pod's two containers are recorded: no synthetic entities,
+	// and nothing for the completed pod
+	assert.Len(t, store.Keys(), 4)
+}
+
+// fakePodLister serves pods from memory, standing in for the informer cache
+type fakePodLister struct {
+	pods   []*v1.Pod
+	synced bool
+}
+
+func (f fakePodLister) ListPods() ([]*v1.Pod, bool) {
+	return f.pods, f.synced
+}
+
+func TestTerminatedPodsPrefersPodCache(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := podMetricsAggregator(store)
+	kubeClient := a.kubeClient.(*fake.Clientset)
+
+	a.SetPodLister(fakePodLister{pods: []*v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "migrate"}, Status: v1.PodStatus{Phase: v1.PodSucceeded}},
+	}, synced: true})
+	kubeClient.ClearActions()
+	a.collectPodMetrics(context.Background(), time.Now())
+	a.collectContainerMetrics(context.Background(), time.Now())
+
+	assert.Equal(t, 0, countPodLists(kubeClient), "a synced cache avoids listing pods from the API server")
+	_, ok := store.Get(timeseries.GeneratePodSeriesKey(timeseries.PodCPUUsageBase, "shop", "migrate"))
+	assert.False(t, ok, "completed pods are skipped")
+
+	// An unsynced cache falls back to the API server
+	a.SetPodLister(fakePodLister{})
+	assert.Equal(t, map[string]bool{"shop/migrate": true}, a.terminatedPods(context.Background()))
+	assert.Equal(t, 1, countPodLists(kubeClient))
+}
+
+func countPodLists(client *fake.Clientset) int {
+	lists := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "list" && action.GetResource().Resource == "pods" {
+			lists++
+		}
+	}
+	return lists
+}
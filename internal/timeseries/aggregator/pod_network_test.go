@@ -0,0 +1,58 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func TestRecordPodNetworkRates(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	rxKey := timeseries.GeneratePodSeriesKey(timeseries.PodNetRxBase, "shop", "web-0")
+	txKey := timeseries.GeneratePodSeriesKey(timeseries.PodNetTxBase, "shop", "web-0")
+	stat := kubemetrics.PodNetworkStats{
+		PodName: "web-0", PodNamespace: "shop", NodeName: "node-a",
+		RxBytes: 10000, TxBytes: 5000,
+		EphemeralUsed: 25 << 20, EphemeralCapacity: 100 << 20,
+	}
+
+	// The first sample only records the baseline counters
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	a.recordPodNetwork([]kubemetrics.PodNetworkStats{stat}, now)
+	_, ok := store.Get(rxKey)
+	assert.False(t, ok, "no rate without a previous sample")
+	assert.Equal(t, float64(25<<20), latestValue(t, store, timeseries.GeneratePodSeriesKey(timeseries.PodEphemeralUsedBase, "shop", "web-0")))
+	assert.Equal(t, 25.0, latestValue(t, store, timeseries.GeneratePodSeriesKey(timeseries.PodEphemeralPercentBase, "shop", "web-0")))
+
+	now = now.Add(10 * time.Second)
+	stat.RxBytes, stat.TxBytes = 30000, 6000
+	a.recordPodNetwork([]kubemetrics.PodNetworkStats{stat}, now)
+	assert.Equal(t, 2000.0, latestValue(t, store, rxKey))
+	assert.Equal(t, 100.0, latestValue(t, store, txKey))
+
+	// A recreated sandbox resets the counters; the sample only re-baselines
+	now = now.Add(10 * time.Second)
+	stat.RxBytes, stat.TxBytes = 100, 7000
+	a.recordPodNetwork([]kubemetrics.PodNetworkStats{stat}, now)
+	assert.Equal(t, 2000.0, latestValue(t, store, rxKey), "no rate across a counter reset")
+	assert.Equal(t, 100.0, latestValue(t, store, txKey))
+
+	now = now.Add(10 * time.Second)
+	stat.RxBytes = 1100
+	a.recordPodNetwork([]kubemetrics.PodNetworkStats{stat}, now)
+	assert.Equal(t, 100.0, latestValue(t, store, rxKey))
+
+	// Pods no longer reported lose their snapshot
+	a.recordPodNetwork(nil, now.Add(10*time.Second))
+	assert.Empty(t, a.podNetSnapshots)
+}
@@ -0,0 +1,50 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func TestRecordPodRestarts(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	rateKey := timeseries.GeneratePodSeriesKey(timeseries.PodRestartsRateBase, "default", "web")
+	windowKey := timeseries.GeneratePodSeriesKey(timeseries.PodRestarts1hBase, "default", "web")
+
+	// The first poll only records the baseline
+	now := time.Now().Add(-time.Minute)
+	a.recordPodRestarts([]v1.Pod{*restartingPod("web", 2)}, now)
+	_, ok := store.Get(rateKey)
+	assert.False(t, ok, "no rate without a previous poll")
+	assert.Equal(t, 0.0, latestValue(t, store, windowKey))
+
+	now = now.Add(10 * time.Second)
+	a.recordPodRestarts([]v1.Pod{*restartingPod("web", 7)}, now)
+	assert.Equal(t, 0.5, latestValue(t, store, rateKey))
+	assert.Equal(t, 5.0, latestValue(t, store, windowKey))
+
+	// A pod recreated under the same name starts counting from zero again
+	now = now.Add(10 * time.Second)
+	a.recordPodRestarts([]v1.Pod{*restartingPod("web", 1)}, now)
+	assert.Equal(t, 0.0, latestValue(t, store, rateKey))
+	assert.Equal(t, 0.0, latestValue(t, store, windowKey))
+
+	now = now.Add(10 * time.Second)
+	a.recordPodRestarts([]v1.Pod{*restartingPod("web", 3)}, now)
+	assert.Equal(t, 0.2, latestValue(t, store, rateKey))
+
+	// State of deleted pods is dropped
+	a.recordPodRestarts([]v1.Pod{*restartingPod("api", 0)}, now.Add(10*time.Second))
+	assert.Len(t, a.podRestartsState, 1)
+	assert.Contains(t, a.podRestartsState, "default/api")
+}
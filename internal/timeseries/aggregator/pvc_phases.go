@@ -0,0 +1,86 @@
+package aggregator
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aaronlmathis/kaptn/internal/k8s/analysis"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+// PersistentVolumeClaimLister lists cached PersistentVolumeClaims, such as the
+// informer manager's ListPersistentVolumeClaims. ok is false while the cache
+// has not synced.
+type PersistentVolumeClaimLister interface {
+	ListPersistentVolumeClaims() ([]*v1.PersistentVolumeClaim, bool)
+}
+
+// SetPersistentVolumeClaimLister sets the source of PersistentVolumeClaims.
+// Without one the namespace PVC phase series are not recorded.
+func (a *Aggregator) SetPersistentVolumeClaimLister(lister PersistentVolumeClaimLister) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pvcs = lister
+}
+
+// namespacePVCPhases counts the claims of one namespace by phase
+type namespacePVCPhases struct {
+	pending, bound, lost, pendingStuck int
+}
+
+// collectPVCPhases records per-namespace PVC phase counts from the claim
+// cache, so it costs no API calls
+func (a *Aggregator) collectPVCPhases(now time.Time) {
+	a.mu.RLock()
+	lister := a.pvcs
+	a.mu.RUnlock()
+	if lister == nil {
+		return
+	}
+
+	pvcs, ok := lister.ListPersistentVolumeClaims()
+	if !ok {
+		return
+	}
+	a.recordPVCPhases(pvcs, now)
+}
+
+// recordPVCPhases stores the Pending, Bound and Lost claim counts of every
+// namespace with claims, and how many of the Pending ones have waited longer
+// than analysis.DefaultPVCPendingThreshold
+func (a *Aggregator) recordPVCPhases(pvcs []*v1.PersistentVolumeClaim, now time.Time) {
+	scope := a.namespaceScope()
+
+	namespaces := make(map[string]*namespacePVCPhases)
+	for _, pvc := range pvcs {
+		if !scope.namespaceAllowed(pvc.Namespace) {
+			continue
+		}
+		counts, exists := namespaces[pvc.Namespace]
+		if !exists {
+			counts = &namespacePVCPhases{}
+			namespaces[pvc.Namespace] = counts
+		}
+
+		switch pvc.Status.Phase {
+		case v1.ClaimPending:
+			counts.pending++
+			if analysis.PVCPendingTooLong(pvc, analysis.DefaultPVCPendingThreshold, now) {
+				counts.pendingStuck++
+			}
+		case v1.ClaimBound:
+			counts.bound++
+		case v1.ClaimLost:
+			counts.lost++
+		}
+	}
+
+	for namespace, counts := range namespaces {
+		namespaceEntity := map[string]string{"namespace": namespace}
+		a.storeMetric(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePVCsPendingBase, namespace), now, float64(counts.pending), namespaceEntity)
+		a.storeMetric(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePVCsBoundBase, namespace), now, float64(counts.bound), namespaceEntity)
+		a.storeMetric(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePVCsLostBase, namespace), now, float64(counts.lost), namespaceEntity)
+		a.storeMetric(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePVCsPendingStuckBase, namespace), now, float64(counts.pendingStuck), namespaceEntity)
+	}
+}
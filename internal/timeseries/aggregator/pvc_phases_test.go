@@ -0,0 +1,81 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+type staticPVCs []*v1.PersistentVolumeClaim
+
+func (s *staticPVCs) ListPersistentVolumeClaims() ([]*v1.PersistentVolumeClaim, bool) {
+	return *s, true
+}
+
+func phasedClaim(namespace, name string, phase v1.PersistentVolumeClaimPhase, created time.Time) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: metav1.NewTime(created)},
+		Status:     v1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func TestCollectPVCPhases(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	config := DefaultConfig()
+	config.NamespaceDenyList = []string{"kube-system"}
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, config)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	pendingKey := timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePVCsPendingBase, "shop")
+	stuckKey := timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePVCsPendingStuckBase, "shop")
+
+	// Without a lister nothing is recorded
+	a.collectPVCPhases(now)
+	_, ok := store.Get(pendingKey)
+	assert.False(t, ok)
+
+	pvcs := staticPVCs{
+		// Pending for an hour: no volume matches the claim
+		phasedClaim("shop", "data-db-0", v1.ClaimPending, now.Add(-time.Hour)),
+		// Just created, still being provisioned
+		phasedClaim("shop", "data-db-1", v1.ClaimPending, now.Add(-30*time.Second)),
+		phasedClaim("shop", "cache", v1.ClaimBound, now.Add(-24*time.Hour)),
+		phasedClaim("shop", "orders", v1.ClaimLost, now.Add(-24*time.Hour)),
+		phasedClaim("staging", "scratch", v1.ClaimBound, now.Add(-time.Hour)),
+		phasedClaim("kube-system", "etcd-backup", v1.ClaimPending, now.Add(-time.Hour)),
+	}
+	a.SetPersistentVolumeClaimLister(&pvcs)
+	a.collectPVCPhases(now)
+
+	assert.Equal(t, 2.0, latestValue(t, store, pendingKey))
+	assert.Equal(t, 1.0, latestValue(t, store, stuckKey))
+	assert.Equal(t, 1.0, latestValue(t, store, timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePVCsBoundBase, "shop")))
+	assert.Equal(t, 1.0, latestValue(t, store, timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePVCsLostBase, "shop")))
+	assert.Equal(t, 0.0, latestValue(t, store, timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePVCsPendingBase, "staging")))
+	assert.Equal(t, 1.0, latestValue(t, store, timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePVCsBoundBase, "staging")))
+
+	// Denied namespaces are skipped
+	_, ok = store.Get(timeseries.GenerateNamespaceSeriesKey(timeseries.NamespacePVCsPendingBase, "kube-system"))
+	assert.False(t, ok)
+
+	// Ten minutes later the second claim is stuck too
+	later := now.Add(10 * time.Minute)
+	a.collectPVCPhases(later)
+	assert.Equal(t, 2.0, latestValue(t, store, stuckKey))
+
+	series, ok := store.Get(stuckKey)
+	assert.True(t, ok)
+	points := series.GetSince(now.Add(-time.Minute), timeseries.Hi)
+	if assert.Len(t, points, 2) {
+		assert.Equal(t, "shop", points[1].Entity["namespace"])
+	}
+}
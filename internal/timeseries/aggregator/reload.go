@@ -0,0 +1,80 @@
+package aggregator
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TickInterval returns the current collection tick interval
+func (a *Aggregator) TickInterval() time.Duration {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.config.TickInterval
+}
+
+// Config returns a copy of the current aggregator configuration
+func (a *Aggregator) Config() Config {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.config
+}
+
+// UpdateConfig applies the hot-reloadable fields of config (collection and poll
+// intervals, restart storm tuning, the collector error log interval, the
+// namespace allow and deny lists and the custom and external metrics to
+// collect) to a running aggregator. Other settings only take effect at startup
+// and are left unchanged.
+func (a *Aggregator) UpdateConfig(config Config) {
+	a.mu.Lock()
+	previousTick := a.config.TickInterval
+
+	if config.TickInterval > 0 {
+		a.config.TickInterval = config.TickInterval
+	}
+	if config.CapacityRefreshInterval > 0 {
+		a.config.CapacityRefreshInterval = config.CapacityRefreshInterval
+		a.capacityRefreshInterval = config.CapacityRefreshInterval
+	}
+	if config.ResourcePollInterval > 0 {
+		a.config.ResourcePollInterval = config.ResourcePollInterval
+	}
+	if config.SummaryPollInterval > 0 {
+		a.config.SummaryPollInterval = config.SummaryPollInterval
+	}
+	if config.StateReconcileInterval > 0 {
+		a.config.StateReconcileInterval = config.StateReconcileInterval
+	}
+	a.config.RestartStormThreshold = config.RestartStormThreshold
+	a.config.RestartStormWindow = config.RestartStormWindow
+	if config.ErrorLogInterval > 0 {
+		a.config.ErrorLogInterval = config.ErrorLogInterval
+	}
+	a.config.NamespaceAllowList = config.NamespaceAllowList
+	a.config.NamespaceDenyList = config.NamespaceDenyList
+	a.config.CustomMetrics = config.CustomMetrics
+	a.config.ExternalMetrics = config.ExternalMetrics
+	a.config.clampPollIntervals()
+	a.capacityRefreshInterval = a.config.CapacityRefreshInterval
+
+	updated := a.config
+	a.mu.Unlock()
+
+	// Wake the run loop so the ticker picks up the new interval
+	if updated.TickInterval != previousTick {
+		select {
+		case a.reconfigureCh <- struct{}{}:
+		default:
+		}
+	}
+
+	a.logger.Info("Aggregator configuration reloaded",
+		zap.Duration("tickInterval", updated.TickInterval),
+		zap.Duration("capacityRefreshInterval", updated.CapacityRefreshInterval),
+		zap.Duration("resourcePollInterval", updated.ResourcePollInterval),
+		zap.Duration("summaryPollInterval", updated.SummaryPollInterval),
+		zap.Duration("stateReconcileInterval", updated.StateReconcileInterval),
+		zap.Duration("errorLogInterval", updated.ErrorLogInterval),
+		zap.Int("customMetrics", len(updated.CustomMetrics)),
+		zap.Int("externalMetrics", len(updated.ExternalMetrics)))
+}
@@ -0,0 +1,121 @@
+package aggregator
+
+import (
+	"sort"
+	"time"
+)
+
+// restartSample records the cluster-wide restart total at a point in time
+type restartSample struct {
+	total int64
+	t     time.Time
+}
+
+// NamespaceRestartContribution describes how many restarts a namespace
+// contributed within the restart storm window
+type NamespaceRestartContribution struct {
+	Namespace string  `json:"namespace"`
+	Restarts  float64 `json:"restarts"`
+}
+
+// RestartStormStatus is a snapshot of the restart storm detector
+type RestartStormStatus struct {
+	Active        bool                           `json:"active"`
+	RatePerMinute float64                        `json:"ratePerMinute"`
+	Threshold     float64                        `json:"threshold"`
+	Window        string                         `json:"window"`
+	Since         *time.Time                     `json:"since,omitempty"`
+	TopNamespaces []NamespaceRestartContribution `json:"topNamespaces"`
+}
+
+// maxStormNamespaces limits how many contributing namespaces are reported
+const maxStormNamespaces = 5
+
+// evaluateRestartStorm records the latest restart total and reports whether the
+// restart rate over the configured window exceeds the storm threshold.
+// Callers must hold a.mu.
+func (a *Aggregator) evaluateRestartStorm(now time.Time, totalRestarts int64) bool {
+	window := a.config.RestartStormWindow
+	if window <= 0 || a.config.RestartStormThreshold <= 0 {
+		a.stormActive = false
+		return false
+	}
+
+	a.restartSamples = append(a.restartSamples, restartSample{total: totalRestarts, t: now})
+
+	// Drop samples that fell out of the window, but keep the newest one at or
+	// before the window start so the rate spans the full window
+	cutoff := now.Add(-window)
+	first := 0
+	for i := 1; i < len(a.restartSamples); i++ {
+		if a.restartSamples[i].t.After(cutoff) {
+			break
+		}
+		first = i
+	}
+	a.restartSamples = a.restartSamples[first:]
+
+	// A counter reset (e.g. pods deleted) restarts the window from scratch
+	oldest := a.restartSamples[0]
+	if totalRestarts < oldest.total {
+		a.restartSamples = a.restartSamples[len(a.restartSamples)-1:]
+		oldest = a.restartSamples[0]
+	}
+
+	var ratePerMinute float64
+	if dt := now.Sub(oldest.t).Minutes(); dt > 0 {
+		ratePerMinute = float64(totalRestarts-oldest.total) / dt
+	}
+	a.stormRatePerMinute = ratePerMinute
+
+	active := ratePerMinute > a.config.RestartStormThreshold
+	if active && !a.stormActive {
+		a.stormSince = now
+	}
+	a.stormActive = active
+
+	return active
+}
+
+// RestartStormStatus returns the current restart storm state along with the
+// namespaces contributing the most restarts within the storm window
+func (a *Aggregator) RestartStormStatus() RestartStormStatus {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	status := RestartStormStatus{
+		Active:        a.stormActive,
+		RatePerMinute: a.stormRatePerMinute,
+		Threshold:     a.config.RestartStormThreshold,
+		Window:        a.config.RestartStormWindow.String(),
+		TopNamespaces: []NamespaceRestartContribution{},
+	}
+
+	if a.stormActive {
+		since := a.stormSince
+		status.Since = &since
+	}
+
+	for namespace, restarts := range a.nsStormRestarts {
+		if restarts <= 0 {
+			continue
+		}
+		status.TopNamespaces = append(status.TopNamespaces, NamespaceRestartContribution{
+			Namespace: namespace,
+			Restarts:  restarts,
+		})
+	}
+
+	sort.Slice(status.TopNamespaces, func(i, j int) bool {
+		if status.TopNamespaces[i].Restarts == status.TopNamespaces[j].Restarts {
+			return status.TopNamespaces[i].Namespace < status.TopNamespaces[j].Namespace
+		}
+		return status.TopNamespaces[i].Restarts > status.TopNamespaces[j].Restarts
+	})
+
+	if len(status.TopNamespaces) > maxStormNamespaces {
+		status.TopNamespaces = status.TopNamespaces[:maxStormNamespaces]
+	}
+
+	return status
+}
@@ -0,0 +1,113 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func newStormTestAggregator(threshold float64, window time.Duration) *Aggregator {
+	config := DefaultConfig()
+	config.RestartStormThreshold = threshold
+	config.RestartStormWindow = window
+
+	return NewAggregator(
+		zap.NewNop(),
+		timeseries.NewMemStore(timeseries.DefaultConfig()),
+		fake.NewSimpleClientset(),
+		metricsfake.NewSimpleClientset().MetricsV1beta1(),
+		&rest.Config{},
+		config,
+	)
+}
+
+func TestRestartStormBurstFlipsFlag(t *testing.T) {
+	a := newStormTestAggregator(10, 2*time.Minute)
+	start := time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	assert.False(t, a.evaluateRestartStorm(start, 100))
+
+	// 30 restarts in 30 seconds is 60/min, well above the threshold
+	assert.True(t, a.evaluateRestartStorm(start.Add(30*time.Second), 130))
+	assert.True(t, a.stormActive)
+	assert.Equal(t, start.Add(30*time.Second), a.stormSince)
+}
+
+func TestRestartStormCalmPeriodClears(t *testing.T) {
+	a := newStormTestAggregator(10, 2*time.Minute)
+	start := time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.evaluateRestartStorm(start, 100)
+	assert.True(t, a.evaluateRestartStorm(start.Add(30*time.Second), 130))
+
+	// No further restarts; once the burst leaves the window the flag clears
+	now := start.Add(30 * time.Second)
+	for i := 0; i < 20; i++ {
+		now = now.Add(15 * time.Second)
+		a.evaluateRestartStorm(now, 130)
+	}
+	assert.False(t, a.stormActive)
+	assert.Zero(t, a.stormRatePerMinute)
+}
+
+func TestRestartStormCounterReset(t *testing.T) {
+	a := newStormTestAggregator(10, 2*time.Minute)
+	start := time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.evaluateRestartStorm(start, 500)
+	assert.False(t, a.evaluateRestartStorm(start.Add(10*time.Second), 20))
+	assert.Len(t, a.restartSamples, 1)
+}
+
+func TestRestartStormDisabled(t *testing.T) {
+	a := newStormTestAggregator(0, 2*time.Minute)
+	start := time.Now()
+
+	a.mu.Lock()
+	a.evaluateRestartStorm(start, 0)
+	active := a.evaluateRestartStorm(start.Add(10*time.Second), 1000)
+	a.mu.Unlock()
+
+	assert.False(t, active)
+}
+
+func TestRestartStormStatusTopNamespaces(t *testing.T) {
+	a := newStormTestAggregator(10, 2*time.Minute)
+	start := time.Now()
+
+	a.mu.Lock()
+	a.evaluateRestartStorm(start, 0)
+	a.evaluateRestartStorm(start.Add(30*time.Second), 60)
+	a.nsStormRestarts = map[string]float64{
+		"a": 5, "b": 40, "c": 0, "d": 10, "e": 1, "f": 2, "g": 3,
+	}
+	a.mu.Unlock()
+
+	status := a.RestartStormStatus()
+
+	assert.True(t, status.Active)
+	assert.NotNil(t, status.Since)
+	assert.Equal(t, "2m0s", status.Window)
+	assert.Len(t, status.TopNamespaces, maxStormNamespaces)
+	assert.Equal(t, "b", status.TopNamespaces[0].Namespace)
+	assert.Equal(t, "d", status.TopNamespaces[1].Namespace)
+	for _, ns := range status.TopNamespaces {
+		assert.NotEqual(t, "c", ns.Namespace)
+	}
+}
@@ -0,0 +1,53 @@
+package aggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func readinessPod(name string, phase v1.PodPhase, ready v1.ConditionStatus) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1.PodSpec{NodeName: "node-a"},
+		Status: v1.PodStatus{
+			Phase:      phase,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: ready}},
+		},
+	}
+}
+
+func TestCollectStateMetricsRunningNotReady(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		readinessPod("ready-1", v1.PodRunning, v1.ConditionTrue),
+		readinessPod("ready-2", v1.PodRunning, v1.ConditionTrue),
+		readinessPod("not-ready", v1.PodRunning, v1.ConditionFalse),
+		readinessPod("pending", v1.PodPending, v1.ConditionFalse),
+	)
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, client, metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	a.SetClock(clock)
+	a.collectStateMetrics(context.Background(), clock.Now())
+
+	assert.Equal(t, 3.0, latestValue(t, store, timeseries.ClusterPodsRunning))
+	assert.Equal(t, 1.0, latestValue(t, store, timeseries.ClusterPodsRunningNotReady))
+}
+
+func TestIsRunningNotReady(t *testing.T) {
+	assert.False(t, isRunningNotReady(readinessPod("ready", v1.PodRunning, v1.ConditionTrue)))
+	assert.True(t, isRunningNotReady(readinessPod("not-ready", v1.PodRunning, v1.ConditionFalse)))
+	assert.False(t, isRunningNotReady(readinessPod("pending", v1.PodPending, v1.ConditionFalse)))
+	assert.False(t, isRunningNotReady(scheduledPod("no-conditions", "node-a", v1.PodRunning)))
+}
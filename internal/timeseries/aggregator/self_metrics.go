@@ -0,0 +1,19 @@
+package aggregator
+
+import (
+	"time"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+// recordSelfMetrics stores the aggregator's own diagnostics for a tick. These
+// series live under timeseries.SelfSeriesPrefix so the store can retain them
+// longer than cluster series.
+func (a *Aggregator) recordSelfMetrics(now time.Time, tickDuration time.Duration) {
+	if series := a.store.Upsert(timeseries.AggregatorTickDurationSeconds); series != nil {
+		series.Add(timeseries.NewPoint(now, tickDuration.Seconds()))
+	}
+	if series := a.store.Upsert(timeseries.AggregatorSeriesCount); series != nil {
+		series.Add(timeseries.NewPoint(now, float64(len(a.store.Keys()))))
+	}
+}
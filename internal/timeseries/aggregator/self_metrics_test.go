@@ -0,0 +1,26 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func TestRecordSelfMetrics(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	store.Upsert(timeseries.ClusterCPUUsedCores)
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+
+	a.recordSelfMetrics(time.Now(), 250*time.Millisecond)
+
+	assert.Equal(t, 0.25, latestValue(t, store, timeseries.AggregatorTickDurationSeconds))
+	// The cluster series plus the two self series
+	assert.Equal(t, 3.0, latestValue(t, store, timeseries.AggregatorSeriesCount))
+}
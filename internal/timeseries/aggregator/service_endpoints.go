@@ -0,0 +1,110 @@
+package aggregator
+
+import (
+	"time"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+// EndpointSliceLister lists cached EndpointSlices, such as the informer
+// manager's ListEndpointSlices. ok is false while the cache has not synced.
+type EndpointSliceLister interface {
+	ListEndpointSlices() ([]*discoveryv1.EndpointSlice, bool)
+}
+
+// SetEndpointSliceLister sets the source of EndpointSlices. Without one the
+// service endpoint series are not recorded.
+func (a *Aggregator) SetEndpointSliceLister(lister EndpointSliceLister) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.endpointSlices = lister
+}
+
+// serviceEndpointCounts are the ready and not-ready endpoints of one service
+type serviceEndpointCounts struct {
+	namespace string
+	name      string
+	ready     map[string]bool
+}
+
+// collectServiceEndpoints records the ready and not-ready endpoint counts of
+// every service from the EndpointSlice cache, so it costs no API calls
+func (a *Aggregator) collectServiceEndpoints(now time.Time) {
+	a.mu.RLock()
+	lister := a.endpointSlices
+	a.mu.RUnlock()
+	if lister == nil {
+		return
+	}
+
+	slices, ok := lister.ListEndpointSlices()
+	if !ok {
+		return
+	}
+	a.recordServiceEndpoints(slices, now)
+}
+
+// recordServiceEndpoints groups EndpointSlices by the service they belong to
+// and stores its ready and not-ready endpoint counts. An endpoint listed in
+// several slices, such as the IPv4 and IPv6 slices of a dual-stack service,
+// is counted once. A service whose slices list no endpoints is recorded with
+// zero ready endpoints.
+func (a *Aggregator) recordServiceEndpoints(slices []*discoveryv1.EndpointSlice, now time.Time) {
+	scope := a.namespaceScope()
+
+	services := make(map[string]*serviceEndpointCounts)
+	for _, slice := range slices {
+		serviceName := slice.Labels[discoveryv1.LabelServiceName]
+		if serviceName == "" || !scope.namespaceAllowed(slice.Namespace) {
+			continue
+		}
+		serviceKey := slice.Namespace + "/" + serviceName
+		counts, exists := services[serviceKey]
+		if !exists {
+			counts = &serviceEndpointCounts{namespace: slice.Namespace, name: serviceName, ready: make(map[string]bool)}
+			services[serviceKey] = counts
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			id := endpointIdentity(endpoint)
+			if id == "" {
+				continue
+			}
+			// A nil ready condition means unknown, which consumers treat as ready
+			ready := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+			counts.ready[id] = counts.ready[id] || ready
+		}
+	}
+
+	for _, counts := range services {
+		var ready, notReady int
+		for _, isReady := range counts.ready {
+			if isReady {
+				ready++
+			} else {
+				notReady++
+			}
+		}
+
+		serviceEntity := map[string]string{
+			"namespace": counts.namespace,
+			"service":   counts.name,
+		}
+		a.storeMetric(timeseries.GenerateServiceSeriesKey(timeseries.ServiceReadyEndpointsBase, counts.namespace, counts.name), now, float64(ready), serviceEntity)
+		a.storeMetric(timeseries.GenerateServiceSeriesKey(timeseries.ServiceNotReadyEndpointsBase, counts.namespace, counts.name), now, float64(notReady), serviceEntity)
+	}
+}
+
+// endpointIdentity identifies an endpoint across the slices of a service by
+// its target object, falling back to its first address
+func endpointIdentity(endpoint discoveryv1.Endpoint) string {
+	if ref := endpoint.TargetRef; ref != nil && ref.Name != "" {
+		return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+	}
+	if len(endpoint.Addresses) > 0 {
+		return endpoint.Addresses[0]
+	}
+	return ""
+}
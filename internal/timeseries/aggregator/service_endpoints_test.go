@@ -0,0 +1,97 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+type staticEndpointSlices []*discoveryv1.EndpointSlice
+
+func (s *staticEndpointSlices) ListEndpointSlices() ([]*discoveryv1.EndpointSlice, bool) {
+	return *s, true
+}
+
+func endpointSlice(name, service string, addressType discoveryv1.AddressType, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "shop",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: addressType,
+		Endpoints:   endpoints,
+	}
+}
+
+func podEndpoint(pod, address string, ready bool) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses:  []string{address},
+		Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		TargetRef:  &v1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: pod},
+	}
+}
+
+func TestCollectServiceEndpoints(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	readyKey := timeseries.GenerateServiceSeriesKey(timeseries.ServiceReadyEndpointsBase, "shop", "web")
+	notReadyKey := timeseries.GenerateServiceSeriesKey(timeseries.ServiceNotReadyEndpointsBase, "shop", "web")
+
+	// Without a lister nothing is recorded
+	a.collectServiceEndpoints(now)
+	_, ok := store.Get(readyKey)
+	assert.False(t, ok)
+
+	slices := staticEndpointSlices{
+		// The same pods appear in the IPv4 and IPv6 slices of a dual-stack service
+		endpointSlice("web-v4", "web", discoveryv1.AddressTypeIPv4,
+			podEndpoint("web-0", "10.0.0.1", true),
+			podEndpoint("web-1", "10.0.0.2", true),
+			podEndpoint("web-2", "10.0.0.3", false)),
+		endpointSlice("web-v6", "web", discoveryv1.AddressTypeIPv6,
+			podEndpoint("web-0", "fd00::1", true),
+			podEndpoint("web-1", "fd00::2", true)),
+		endpointSlice("api-v4", "api", discoveryv1.AddressTypeIPv4,
+			discoveryv1.Endpoint{Addresses: []string{"10.0.1.1"}}),
+	}
+	a.SetEndpointSliceLister(&slices)
+	a.collectServiceEndpoints(now)
+
+	assert.Equal(t, 2.0, latestValue(t, store, readyKey))
+	assert.Equal(t, 1.0, latestValue(t, store, notReadyKey))
+	// An endpoint without a ready condition counts as ready
+	assert.Equal(t, 1.0, latestValue(t, store, timeseries.GenerateServiceSeriesKey(timeseries.ServiceReadyEndpointsBase, "shop", "api")))
+
+	// The service loses all ready endpoints
+	later := now.Add(10 * time.Second)
+	slices = staticEndpointSlices{
+		endpointSlice("web-v4", "web", discoveryv1.AddressTypeIPv4,
+			podEndpoint("web-0", "10.0.0.1", false),
+			podEndpoint("web-1", "10.0.0.2", false)),
+		endpointSlice("web-v6", "web", discoveryv1.AddressTypeIPv6),
+	}
+	a.collectServiceEndpoints(later)
+
+	assert.Equal(t, 0.0, latestValue(t, store, readyKey))
+	assert.Equal(t, 2.0, latestValue(t, store, notReadyKey))
+
+	series, ok := store.Get(readyKey)
+	assert.True(t, ok)
+	points := series.GetSince(now.Add(-time.Minute), timeseries.Hi)
+	if assert.Len(t, points, 2) {
+		assert.Equal(t, "web", points[1].Entity["service"])
+	}
+}
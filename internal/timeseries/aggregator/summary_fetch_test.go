@@ -0,0 +1,60 @@
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	kubemetrics "github.com/aaronlmathis/kaptn/internal/kube/metrics"
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+func TestTickFetchesEachNodeSummaryOnce(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	kubelet := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+
+		var summary kubemetrics.SummaryStatsResponse
+		summary.Node.Runtime.ImageFs.UsedBytes = 1 << 30
+		summary.Node.Runtime.ImageFs.CapacityBytes = 10 << 30
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(summary)
+	}))
+	defer kubelet.Close()
+
+	nodeA := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	nodeB := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}}
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(nodeA, nodeB), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{Host: kubelet.URL}, DefaultConfig())
+	a.SetClock(newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+
+	// The first tick runs both the resource and the summary collectors
+	a.tick(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, node := range []string{"node-a", "node-b"} {
+		path := "/api/v1/nodes/" + node + "/proxy/stats/summary"
+		assert.Equal(t, 1, requests[path], "summary of %s fetched once per tick", node)
+	}
+	for path := range requests {
+		assert.True(t, strings.HasSuffix(path, "/proxy/stats/summary"), "unexpected request %s", path)
+	}
+	assert.Equal(t, float64(2<<30), latestValue(t, store, timeseries.ClusterFsImageUsedBytes))
+}
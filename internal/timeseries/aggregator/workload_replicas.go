@@ -0,0 +1,111 @@
+package aggregator
+
+import (
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+// WorkloadLister lists cached Deployments and DaemonSets, such as the
+// informer manager's ListDeployments and ListDaemonSets. ok is false while
+// the respective cache has not synced.
+type WorkloadLister interface {
+	ListDeployments() ([]*appsv1.Deployment, bool)
+	ListDaemonSets() ([]*appsv1.DaemonSet, bool)
+}
+
+// SetWorkloadLister sets the source of Deployments and DaemonSets. Without
+// one the workload replica series are not recorded.
+func (a *Aggregator) SetWorkloadLister(lister WorkloadLister) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.workloads = lister
+}
+
+// workloadReplicas are the replica counts of one workload. scheduled is only
+// set for DaemonSets.
+type workloadReplicas struct {
+	desired, ready, available, unavailable int32
+	scheduled                              *int32
+}
+
+// collectWorkloadReplicas records the desired, ready and unavailable replicas
+// of every Deployment and DaemonSet from the workload caches, so it costs no
+// API calls. Each kind is skipped until its cache has synced.
+func (a *Aggregator) collectWorkloadReplicas(now time.Time) {
+	a.mu.RLock()
+	lister := a.workloads
+	a.mu.RUnlock()
+	if lister == nil {
+		return
+	}
+
+	if deployments, ok := lister.ListDeployments(); ok {
+		a.recordDeploymentReplicas(deployments, now)
+	}
+	if daemonSets, ok := lister.ListDaemonSets(); ok {
+		a.recordDaemonSetReplicas(daemonSets, now)
+	}
+}
+
+// recordDeploymentReplicas stores the replica counts of every Deployment. An
+// unset spec.replicas means the API server default of 1.
+func (a *Aggregator) recordDeploymentReplicas(deployments []*appsv1.Deployment, now time.Time) {
+	scope := a.namespaceScope()
+	for _, deployment := range deployments {
+		if !scope.namespaceAllowed(deployment.Namespace) {
+			continue
+		}
+		desired := int32(1)
+		if deployment.Spec.Replicas != nil {
+			desired = *deployment.Spec.Replicas
+		}
+		a.storeWorkloadReplicas("Deployment", deployment.Namespace, deployment.Name, workloadReplicas{
+			desired:     desired,
+			ready:       deployment.Status.ReadyReplicas,
+			available:   deployment.Status.AvailableReplicas,
+			unavailable: deployment.Status.UnavailableReplicas,
+		}, now)
+	}
+}
+
+// recordDaemonSetReplicas stores the replica counts of every DaemonSet, where
+// desired is the number of nodes that should run its pod and scheduled the
+// number that do
+func (a *Aggregator) recordDaemonSetReplicas(daemonSets []*appsv1.DaemonSet, now time.Time) {
+	scope := a.namespaceScope()
+	for _, daemonSet := range daemonSets {
+		if !scope.namespaceAllowed(daemonSet.Namespace) {
+			continue
+		}
+		scheduled := daemonSet.Status.CurrentNumberScheduled
+		a.storeWorkloadReplicas("DaemonSet", daemonSet.Namespace, daemonSet.Name, workloadReplicas{
+			desired:     daemonSet.Status.DesiredNumberScheduled,
+			ready:       daemonSet.Status.NumberReady,
+			available:   daemonSet.Status.NumberAvailable,
+			unavailable: daemonSet.Status.NumberUnavailable,
+			scheduled:   &scheduled,
+		}, now)
+	}
+}
+
+// storeWorkloadReplicas stores the replica series of one workload
+func (a *Aggregator) storeWorkloadReplicas(kind, namespace, name string, replicas workloadReplicas, now time.Time) {
+	workloadEntity := map[string]string{
+		"namespace": namespace,
+		"kind":      kind,
+		"workload":  name,
+	}
+	key := func(base string) string {
+		return timeseries.GenerateWorkloadSeriesKey(base, namespace, kind, name)
+	}
+	a.storeMetric(key(timeseries.WorkloadDesiredReplicasBase), now, float64(replicas.desired), workloadEntity)
+	a.storeMetric(key(timeseries.WorkloadReadyReplicasBase), now, float64(replicas.ready), workloadEntity)
+	a.storeMetric(key(timeseries.WorkloadAvailableReplicasBase), now, float64(replicas.available), workloadEntity)
+	a.storeMetric(key(timeseries.WorkloadUnavailableReplicasBase), now, float64(replicas.unavailable), workloadEntity)
+	if replicas.scheduled != nil {
+		a.storeMetric(key(timeseries.WorkloadScheduledReplicasBase), now, float64(*replicas.scheduled), workloadEntity)
+	}
+}
@@ -0,0 +1,99 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/aaronlmathis/kaptn/internal/timeseries"
+)
+
+type staticWorkloads struct {
+	deployments []*appsv1.Deployment
+	daemonSets  []*appsv1.DaemonSet
+	synced      bool
+}
+
+func (s *staticWorkloads) ListDeployments() ([]*appsv1.Deployment, bool) {
+	return s.deployments, s.synced
+}
+
+func (s *staticWorkloads) ListDaemonSets() ([]*appsv1.DaemonSet, bool) {
+	return s.daemonSets, s.synced
+}
+
+func TestCollectWorkloadReplicas(t *testing.T) {
+	store := timeseries.NewMemStore(timeseries.DefaultConfig())
+	a := NewAggregator(zap.NewNop(), store, fake.NewSimpleClientset(), metricsfake.NewSimpleClientset().MetricsV1beta1(), &rest.Config{}, DefaultConfig())
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	replicas := int32(5)
+	workloads := &staticWorkloads{
+		deployments: []*appsv1.Deployment{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsv1.DeploymentStatus{ReadyReplicas: 3, AvailableReplicas: 2, UnavailableReplicas: 3},
+			},
+			// No replicas set defaults to one
+			{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "shop"}},
+		},
+		daemonSets: []*appsv1.DaemonSet{{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-exporter", Namespace: "monitoring"},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: 4,
+				CurrentNumberScheduled: 3,
+				NumberReady:            3,
+				NumberAvailable:        3,
+				NumberUnavailable:      1,
+			},
+		}},
+	}
+
+	// Without a lister, or before the caches sync, nothing is recorded
+	webReady := timeseries.GenerateWorkloadSeriesKey(timeseries.WorkloadReadyReplicasBase, "shop", "Deployment", "web")
+	a.collectWorkloadReplicas(now)
+	a.SetWorkloadLister(workloads)
+	a.collectWorkloadReplicas(now)
+	_, ok := store.Get(webReady)
+	assert.False(t, ok)
+
+	workloads.synced = true
+	a.collectWorkloadReplicas(now)
+
+	deployment := func(base, name string) string {
+		return timeseries.GenerateWorkloadSeriesKey(base, "shop", "Deployment", name)
+	}
+	assert.Equal(t, 5.0, latestValue(t, store, deployment(timeseries.WorkloadDesiredReplicasBase, "web")))
+	assert.Equal(t, 3.0, latestValue(t, store, webReady))
+	assert.Equal(t, 2.0, latestValue(t, store, deployment(timeseries.WorkloadAvailableReplicasBase, "web")))
+	assert.Equal(t, 3.0, latestValue(t, store, deployment(timeseries.WorkloadUnavailableReplicasBase, "web")))
+	assert.Equal(t, 1.0, latestValue(t, store, deployment(timeseries.WorkloadDesiredReplicasBase, "worker")))
+	assert.Equal(t, 0.0, latestValue(t, store, deployment(timeseries.WorkloadReadyReplicasBase, "worker")))
+	_, ok = store.Get(deployment(timeseries.WorkloadScheduledReplicasBase, "web"))
+	assert.False(t, ok, "scheduled is only recorded for DaemonSets")
+
+	daemonSet := func(base string) string {
+		return timeseries.GenerateWorkloadSeriesKey(base, "monitoring", "DaemonSet", "node-exporter")
+	}
+	assert.Equal(t, 4.0, latestValue(t, store, daemonSet(timeseries.WorkloadDesiredReplicasBase)))
+	assert.Equal(t, 3.0, latestValue(t, store, daemonSet(timeseries.WorkloadScheduledReplicasBase)))
+	assert.Equal(t, 3.0, latestValue(t, store, daemonSet(timeseries.WorkloadReadyReplicasBase)))
+	assert.Equal(t, 3.0, latestValue(t, store, daemonSet(timeseries.WorkloadAvailableReplicasBase)))
+	assert.Equal(t, 1.0, latestValue(t, store, daemonSet(timeseries.WorkloadUnavailableReplicasBase)))
+
+	series, ok := store.Get(webReady)
+	if assert.True(t, ok) {
+		points := series.GetSince(now.Add(-time.Minute), timeseries.Hi)
+		if assert.Len(t, points, 1) {
+			assert.Equal(t, map[string]string{"namespace": "shop", "kind": "Deployment", "workload": "web"}, points[0].Entity)
+		}
+	}
+}
@@ -1,20 +1,42 @@
 package timeseries
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Resolution defines the resolution of time series data
 type Resolution int
 
 const (
-	Hi Resolution = iota // High resolution (1 second)
-	Lo                   // Low resolution (5 second bins)
+	Hi  Resolution = iota // High resolution (1 second)
+	Lo                    // Low resolution (5 second bins)
+	Med                   // Rollup resolution (1 minute bins) for long windows
 )
 
+// ParseResolution returns the resolution named by a query's res parameter
+func ParseResolution(name string) (Resolution, bool) {
+	switch name {
+	case "hi":
+		return Hi, true
+	case "lo":
+		return Lo, true
+	case "med":
+		return Med, true
+	default:
+		return 0, false
+	}
+}
+
 // Config holds configuration for time series storage
 type Config struct {
 	// Maximum time window to keep data
 	MaxWindow time.Duration
 
+	// Retention for the aggregator's own diagnostic series (keys under
+	// SelfSeriesPrefix). Zero keeps them for MaxWindow like other series.
+	SelfMetricsWindow time.Duration
+
 	// High resolution settings
 	HiResStep   time.Duration // Step size for high resolution data
 	HiResPoints int           // Maximum points for high resolution
@@ -23,6 +45,24 @@ type Config struct {
 	LoResStep   time.Duration // Step size for low resolution data
 	LoResPoints int           // Maximum points for low resolution
 
+	// How hi-res points are folded into each low resolution step. Aggregation
+	// applies to every key without an entry in Aggregations, which maps key
+	// prefixes to reducers; the longest matching prefix wins. Empty means mean.
+	Aggregation  Reducer
+	Aggregations map[string]Reducer
+
+	// Keep the min, max and mean of every low resolution step alongside the
+	// reduced point, so long windows still show the peaks a single reducer
+	// flattens. Off by default; it adds a ring of LoResPoints per series.
+	LoResAggregates bool
+
+	// Rollup settings. The rollup tier keeps MedResStep * MedResPoints of
+	// history, independent of MaxWindow when that is longer; zero points
+	// disables it.
+	MedResStep    time.Duration // Step size for rollup data
+	MedResPoints  int           // Maximum points for rollup data
+	MedResReducer Reducer       // How each rollup step is reduced; empty means mean
+
 	// Health and guardrails
 	MaxSeries          int // Maximum number of series
 	MaxPointsPerSeries int // Maximum points per series
@@ -37,8 +77,52 @@ func DefaultConfig() Config {
 		HiResPoints:        3600,             // 60 minutes * 60 seconds
 		LoResStep:          5 * time.Second,  // 5 seconds
 		LoResPoints:        720,              // 60 minutes / 5 seconds
+		MedResStep:         1 * time.Minute,  // 1 minute
+		MedResPoints:       1440,             // 24 hours / 1 minute
+		MedResReducer:      ReducerMean,      // Average of each minute
 		MaxSeries:          1000,             // Maximum 1000 series
 		MaxPointsPerSeries: 10000,            // Maximum 10k points per series
 		MaxWSClients:       500,              // Maximum 500 WebSocket clients
 	}
 }
+
+// medWindow returns how long rollup points are kept: the span of the rollup
+// ring, or MaxWindow if that is longer
+func (c Config) medWindow() time.Duration {
+	window := c.MedResStep * time.Duration(c.MedResPoints)
+	if window < c.MaxWindow {
+		return c.MaxWindow
+	}
+	return window
+}
+
+// aggregationForKey returns the low resolution reducer for key
+func (c Config) aggregationForKey(key string) Reducer {
+	aggregation, longest := c.Aggregation, ""
+	for prefix, reducer := range c.Aggregations {
+		if strings.HasPrefix(key, prefix) && len(prefix) > len(longest) {
+			aggregation, longest = reducer, prefix
+		}
+	}
+	return aggregation
+}
+
+// configForKey returns the configuration a new series for key is created
+// with, resolving its low resolution aggregation. Self series get their own
+// retention window; their low resolution step widens as needed so the window
+// fits in the same number of points.
+func (c Config) configForKey(key string) Config {
+	c.Aggregation = c.aggregationForKey(key)
+	if c.SelfMetricsWindow <= 0 || !strings.HasPrefix(key, SelfSeriesPrefix) {
+		return c
+	}
+
+	self := c
+	self.MaxWindow = c.SelfMetricsWindow
+	if c.LoResPoints > 0 {
+		if step := c.SelfMetricsWindow / time.Duration(c.LoResPoints); step > self.LoResStep {
+			self.LoResStep = step
+		}
+	}
+	return self
+}
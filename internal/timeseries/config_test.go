@@ -28,6 +28,10 @@ func TestConfig(t *testing.T) {
 		if config.LoResPoints != 720 {
 			t.Errorf("Expected LoResPoints 720, got %d", config.LoResPoints)
 		}
+
+		if config.MedResStep != time.Minute || config.MedResPoints != 1440 || config.MedResReducer != ReducerMean {
+			t.Errorf("Expected 1m x 1440 mean rollup, got %v x %d %s", config.MedResStep, config.MedResPoints, config.MedResReducer)
+		}
 	})
 
 	t.Run("ResolutionConstants", func(t *testing.T) {
@@ -38,5 +42,62 @@ func TestConfig(t *testing.T) {
 		if Lo != 1 {
 			t.Errorf("Expected Lo to be 1, got %d", Lo)
 		}
+
+		if Med != 2 {
+			t.Errorf("Expected Med to be 2, got %d", Med)
+		}
+	})
+
+	t.Run("ParseResolution", func(t *testing.T) {
+		for name, want := range map[string]Resolution{"hi": Hi, "lo": Lo, "med": Med} {
+			if got, ok := ParseResolution(name); !ok || got != want {
+				t.Errorf("Expected %q to parse as %d, got %d (ok=%v)", name, want, got, ok)
+			}
+		}
+		for _, name := range []string{"", "daily", "HI"} {
+			if _, ok := ParseResolution(name); ok {
+				t.Errorf("Expected %q to be rejected", name)
+			}
+		}
 	})
 }
+
+func TestConfigForKey(t *testing.T) {
+	config := DefaultConfig()
+	config.SelfMetricsWindow = 24 * time.Hour
+
+	if got := config.configForKey(ClusterCPUUsedCores); got.MaxWindow != config.MaxWindow || got.LoResStep != config.LoResStep {
+		t.Errorf("Expected cluster series to keep the store config, got window %v step %v", got.MaxWindow, got.LoResStep)
+	}
+
+	self := config.configForKey(AggregatorTickDurationSeconds)
+	if self.MaxWindow != 24*time.Hour {
+		t.Errorf("Expected self series window 24h, got %v", self.MaxWindow)
+	}
+	if want := 2 * time.Minute; self.LoResStep != want {
+		t.Errorf("Expected self series lo-res step %v to fit %d points, got %v", want, config.LoResPoints, self.LoResStep)
+	}
+	if self.LoResPoints != config.LoResPoints {
+		t.Errorf("Expected self series to keep %d lo-res points, got %d", config.LoResPoints, self.LoResPoints)
+	}
+}
+
+func TestConfigForKeyAggregation(t *testing.T) {
+	config := DefaultConfig()
+	config.Aggregation = ReducerMin
+	config.Aggregations = map[string]Reducer{
+		"node.net.":       ReducerSum,
+		"node.net.rx.bps": ReducerMax,
+	}
+
+	tests := map[string]Reducer{
+		"node.net.rx.bps.node-a": ReducerMax,
+		"node.net.tx.bps.node-a": ReducerSum,
+		ClusterCPUUsedCores:      ReducerMin,
+	}
+	for key, want := range tests {
+		if got := config.configForKey(key).Aggregation; got != want {
+			t.Errorf("Expected %s to aggregate with %q, got %q", key, want, got)
+		}
+	}
+}
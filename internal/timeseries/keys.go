@@ -1,6 +1,9 @@
 package timeseries
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Series key constants for the cluster-level metrics
 const (
@@ -33,11 +36,49 @@ const (
 	ClusterPodsRestartsTotal    = "cluster.pods.restarts.total"
 	ClusterPodsRestartsRate     = "cluster.pods.restarts.rate"
 	ClusterPodsRestarts1h       = "cluster.pods.restarts.1h"
+	ClusterPodsRestartStorm     = "cluster.pods.restarts.storm" // 1 while a restart storm is detected, 0 otherwise
 	ClusterNodesReady           = "cluster.nodes.ready"
 	ClusterNodesNotReady        = "cluster.nodes.notready"
+	ClusterNodesUnderPressure   = "cluster.nodes.under_pressure" // Nodes with a non-zero pressure score
 	ClusterPodsUnschedulable    = "cluster.pods.unschedulable"
+	ClusterPodsRunningNotReady  = "cluster.pods.running.notready" // Running pods whose Ready condition is false
 	ClusterFsImageUsedBytes     = "cluster.fs.image.used.bytes"
 	ClusterFsImageCapacityBytes = "cluster.fs.image.capacity.bytes"
+
+	// Control-plane component health from leader election leases (1 healthy, 0 unhealthy)
+	ClusterSchedulerHealthy         = "cluster.controlplane.scheduler.healthy"
+	ClusterControllerManagerHealthy = "cluster.controlplane.controller_manager.healthy"
+
+	// Object counts per resource kind, read from the informer caches
+	ClusterNamespacesCount   = "cluster.namespaces.count"
+	ClusterDeploymentsCount  = "cluster.deployments.count"
+	ClusterStatefulSetsCount = "cluster.statefulsets.count"
+	ClusterDaemonSetsCount   = "cluster.daemonsets.count"
+	ClusterReplicaSetsCount  = "cluster.replicasets.count"
+	ClusterJobsCount         = "cluster.jobs.count"
+	ClusterCronJobsCount     = "cluster.cronjobs.count"
+	ClusterServicesCount     = "cluster.services.count"
+	ClusterConfigMapsCount   = "cluster.configmaps.count"
+	ClusterSecretsCount      = "cluster.secrets.count"
+	ClusterIngressesCount    = "cluster.ingresses.count"
+	ClusterPVCsCount         = "cluster.pvcs.count"
+)
+
+// SelfSeriesPrefix marks the aggregator's own diagnostic series, which are
+// retained for Config.SelfMetricsWindow rather than MaxWindow
+const SelfSeriesPrefix = "kaptn."
+
+// Aggregator self-metrics
+const (
+	AggregatorTickDurationSeconds = "kaptn.aggregator.tick.duration.seconds"
+	AggregatorSeriesCount         = "kaptn.aggregator.series.count"
+)
+
+// Custom and external metric base keys, combined with the configured metric
+// name and the described object
+const (
+	CustomMetricBase   = "custom"
+	ExternalMetricBase = "external"
 )
 
 // Node-level metric base keys (will be combined with node names)
@@ -45,6 +86,7 @@ const (
 	NodeCPUUsageBase       = "node.cpu.usage.cores"
 	NodeMemUsageBase       = "node.mem.usage.bytes"
 	NodeMemWorkingSetBase  = "node.mem.working_set.bytes"
+	NodeMemAvailableBase   = "node.mem.available.bytes" // Summary API only
 	NodeNetRxBase          = "node.net.rx.bps"
 	NodeNetTxBase          = "node.net.tx.bps"
 	NodeFsUsedBase         = "node.fs.used.bytes"
@@ -68,10 +110,18 @@ const (
 	NodeCapacityPodsBase    = "node.capacity.pods"
 	NodeAllocatablePodsBase = "node.allocatable.pods"
 
+	NodeRequestedCPUBase = "node.requested.cpu.cores"
+	NodeRequestedMemBase = "node.requested.mem.bytes"
+	NodeSchedulableBase  = "node.schedulable" // 1 while Ready, not cordoned and without NoSchedule or NoExecute taints
+
 	NodeConditionReadyBase = "node.condition.ready"
 
 	NodePodsCountBase = "node.pods.count"
 
+	NodePodsPhaseRunningBase = "node.pods.phase.running"
+	NodePodsPhasePendingBase = "node.pods.phase.pending"
+	NodePodsPhaseFailedBase  = "node.pods.phase.failed"
+
 	NodeImageFsUsedPercentBase       = "node.imagefs.used.percent"
 	NodeImageFsInodesUsedPercentBase = "node.imagefs.inodes.used.percent"
 	NodeFsInodesUsedPercentBase      = "node.fs.inodes.used.percent"
@@ -80,6 +130,7 @@ const (
 	NodeConditionDiskPressureBase    = "node.condition.disk_pressure"
 	NodeConditionMemoryPressureBase  = "node.condition.memory_pressure"
 	NodeConditionPIDPressureBase     = "node.condition.pid_pressure"
+	NodePressureScoreBase            = "node.pressure.score" // Pressure conditions reported plus 1 if not Ready; 0 is healthy
 )
 
 // Pod-level metric base keys (will be combined with namespace and pod names)
@@ -96,6 +147,7 @@ const (
 	PodMemLimitBase         = "pod.mem.limit.bytes"
 	PodRestartsTotalBase    = "pod.restarts.total"
 	PodRestartsRateBase     = "pod.restarts.rate"
+	PodRestarts1hBase       = "pod.restarts.1h"
 	PodEphemeralPercentBase = "pod.ephemeral.used.percent"
 )
 
@@ -111,6 +163,26 @@ const (
 	NamespacePodsRestartsRateBase = "ns.pods.restarts.rate"
 	NamespacePodsRestartsTotalBase = "ns.pods.restarts.total"
 	NamespacePodsRestarts1hBase    = "ns.pods.restarts.1h"
+	NamespacePVCsPendingBase       = "ns.pvcs.pending"
+	NamespacePVCsBoundBase         = "ns.pvcs.bound"
+	NamespacePVCsLostBase          = "ns.pvcs.lost"
+	NamespacePVCsPendingStuckBase  = "ns.pvcs.pending_stuck"
+)
+
+// Service-level metric base keys (will be combined with namespace and service names)
+const (
+	ServiceReadyEndpointsBase    = "svc.endpoints.ready"
+	ServiceNotReadyEndpointsBase = "svc.endpoints.not_ready"
+)
+
+// Workload-level metric base keys (will be combined with namespace, kind, and workload names).
+// For DaemonSets desired is desiredNumberScheduled and scheduled is currentNumberScheduled.
+const (
+	WorkloadDesiredReplicasBase     = "workload.replicas.desired"
+	WorkloadReadyReplicasBase       = "workload.replicas.ready"
+	WorkloadAvailableReplicasBase   = "workload.replicas.available"
+	WorkloadUnavailableReplicasBase = "workload.replicas.unavailable"
+	WorkloadScheduledReplicasBase   = "workload.replicas.scheduled" // DaemonSets only
 )
 
 // Container-level metric base keys (will be combined with namespace, pod, and container names)
@@ -172,6 +244,32 @@ func GenerateNamespaceSeriesKey(metricBase, namespace string) string {
 	return fmt.Sprintf("%s.%s", metricBase, namespace)
 }
 
+// GenerateServiceSeriesKey creates a service-specific series key
+func GenerateServiceSeriesKey(metricBase, namespace, serviceName string) string {
+	return fmt.Sprintf("%s.%s.%s", metricBase, namespace, serviceName)
+}
+
+// GenerateWorkloadSeriesKey creates a workload-specific series key; kind is
+// lower-cased, e.g. deployment or daemonset
+func GenerateWorkloadSeriesKey(metricBase, namespace, kind, name string) string {
+	return fmt.Sprintf("%s.%s.%s.%s", metricBase, namespace, strings.ToLower(kind), name)
+}
+
+// GenerateCustomMetricSeriesKey creates a series key for a custom metric
+// describing an object; namespace is empty for root-scoped objects
+func GenerateCustomMetricSeriesKey(metric, kind, namespace, name string) string {
+	kind = strings.ToLower(kind)
+	if namespace == "" {
+		return fmt.Sprintf("%s.%s.%s.%s", CustomMetricBase, metric, kind, name)
+	}
+	return fmt.Sprintf("%s.%s.%s.%s.%s", CustomMetricBase, metric, kind, namespace, name)
+}
+
+// GenerateExternalMetricSeriesKey creates a series key for an external metric in a namespace
+func GenerateExternalMetricSeriesKey(metric, namespace string) string {
+	return fmt.Sprintf("%s.%s.%s", ExternalMetricBase, metric, namespace)
+}
+
 // ParseNodeSeriesKey extracts node name from a node series key
 func ParseNodeSeriesKey(seriesKey string) (metricBase, nodeName string, ok bool) {
 	// Find the last dot separator
@@ -192,6 +290,22 @@ func ParseNodeSeriesKey(seriesKey string) (metricBase, nodeName string, ok bool)
 	return metricBase, nodeName, true
 }
 
+// ParseEntitySeriesKey resolves a node or namespace series key against the
+// metric bases it may belong to, returning the longest matching base and the
+// entity after it. Unlike ParseNodeSeriesKey it does not split at the last
+// dot, so node names containing dots, such as ip-10-0-0-1.ec2.internal, are
+// returned whole.
+func ParseEntitySeriesKey(seriesKey string, metricBases []string) (metricBase, entity string, ok bool) {
+	for _, base := range metricBases {
+		rest, found := strings.CutPrefix(seriesKey, base+".")
+		if !found || rest == "" || len(base) <= len(metricBase) {
+			continue
+		}
+		metricBase, entity, ok = base, rest, true
+	}
+	return metricBase, entity, ok
+}
+
 // ParsePodSeriesKey extracts namespace and pod name from a pod series key
 func ParsePodSeriesKey(seriesKey string) (metricBase, namespace, podName string, ok bool) {
 	// Find the last two dot separators
@@ -257,11 +371,29 @@ func AllSeriesKeys() []string {
 		ClusterPodsRestartsTotal,
 		ClusterPodsRestartsRate,
 		ClusterPodsRestarts1h,
+		ClusterPodsRestartStorm,
 		ClusterNodesReady,
 		ClusterNodesNotReady,
+		ClusterNodesUnderPressure,
 		ClusterPodsUnschedulable,
+		ClusterPodsRunningNotReady,
 		ClusterFsImageUsedBytes,
 		ClusterFsImageCapacityBytes,
+		ClusterSchedulerHealthy,
+		ClusterControllerManagerHealthy,
+		// Object counts
+		ClusterNamespacesCount,
+		ClusterDeploymentsCount,
+		ClusterStatefulSetsCount,
+		ClusterDaemonSetsCount,
+		ClusterReplicaSetsCount,
+		ClusterJobsCount,
+		ClusterCronJobsCount,
+		ClusterServicesCount,
+		ClusterConfigMapsCount,
+		ClusterSecretsCount,
+		ClusterIngressesCount,
+		ClusterPVCsCount,
 		// Namespace base keys
 		NamespaceCPUUsedBase,
 		NamespaceCPURequestBase,
@@ -273,6 +405,13 @@ func AllSeriesKeys() []string {
 		NamespacePodsRestartsRateBase,
 		NamespacePodsRestartsTotalBase,
 		NamespacePodsRestarts1hBase,
+		NamespacePVCsPendingBase,
+		NamespacePVCsBoundBase,
+		NamespacePVCsLostBase,
+		NamespacePVCsPendingStuckBase,
+		// Aggregator self-metrics
+		AggregatorTickDurationSeconds,
+		AggregatorSeriesCount,
 	}
 }
 
@@ -282,6 +421,7 @@ func GetNodeMetricBases() []string {
 		NodeCPUUsageBase,
 		NodeMemUsageBase,
 		NodeMemWorkingSetBase,
+		NodeMemAvailableBase,
 		NodeNetRxBase,
 		NodeNetTxBase,
 		NodeFsUsedBase,
@@ -304,8 +444,14 @@ func GetNodeMetricBases() []string {
 		// New node-level pod capacity metrics
 		NodeCapacityPodsBase,
 		NodeAllocatablePodsBase,
+		NodeRequestedCPUBase,
+		NodeRequestedMemBase,
+		NodeSchedulableBase,
 		// New node metrics
 		NodePodsCountBase,
+		NodePodsPhaseRunningBase,
+		NodePodsPhasePendingBase,
+		NodePodsPhaseFailedBase,
 		NodeImageFsUsedPercentBase,
 		NodeImageFsInodesUsedPercentBase,
 		NodeFsInodesUsedPercentBase,
@@ -316,6 +462,7 @@ func GetNodeMetricBases() []string {
 		NodeConditionDiskPressureBase,
 		NodeConditionMemoryPressureBase,
 		NodeConditionPIDPressureBase,
+		NodePressureScoreBase,
 	}
 }
 
@@ -335,6 +482,7 @@ func GetPodMetricBases() []string {
 		PodMemLimitBase,
 		PodRestartsTotalBase,
 		PodRestartsRateBase,
+		PodRestarts1hBase,
 		PodEphemeralPercentBase,
 	}
 }
@@ -349,6 +497,25 @@ func GetContainerMetricBases() []string {
 	}
 }
 
+// GetServiceMetricBases returns all service-level metric base keys
+func GetServiceMetricBases() []string {
+	return []string{
+		ServiceReadyEndpointsBase,
+		ServiceNotReadyEndpointsBase,
+	}
+}
+
+// GetWorkloadMetricBases returns all workload-level metric base keys
+func GetWorkloadMetricBases() []string {
+	return []string{
+		WorkloadDesiredReplicasBase,
+		WorkloadReadyReplicasBase,
+		WorkloadAvailableReplicasBase,
+		WorkloadUnavailableReplicasBase,
+		WorkloadScheduledReplicasBase,
+	}
+}
+
 // GetNamespaceMetricBases returns all namespace-level metric base keys
 func GetNamespaceMetricBases() []string {
 	return []string{
@@ -360,5 +527,11 @@ func GetNamespaceMetricBases() []string {
 		NamespaceMemLimitBase,
 		NamespacePodsRunningBase,
 		NamespacePodsRestartsRateBase,
+		NamespacePodsRestartsTotalBase,
+		NamespacePodsRestarts1hBase,
+		NamespacePVCsPendingBase,
+		NamespacePVCsBoundBase,
+		NamespacePVCsLostBase,
+		NamespacePVCsPendingStuckBase,
 	}
 }
@@ -107,3 +107,37 @@ func TestKeys(t *testing.T) {
 		}
 	})
 }
+
+func TestGenerateCustomMetricSeriesKey(t *testing.T) {
+	if got := GenerateCustomMetricSeriesKey("http_requests", "Pod", "shop", "web-1"); got != "custom.http_requests.pod.shop.web-1" {
+		t.Errorf("unexpected namespaced key %q", got)
+	}
+	if got := GenerateCustomMetricSeriesKey("node_temperature", "Node", "", "worker-1"); got != "custom.node_temperature.node.worker-1" {
+		t.Errorf("unexpected root-scoped key %q", got)
+	}
+	if got := GenerateExternalMetricSeriesKey("queue_messages_ready", "shop"); got != "external.queue_messages_ready.shop" {
+		t.Errorf("unexpected external key %q", got)
+	}
+}
+
+func TestParseEntitySeriesKey(t *testing.T) {
+	bases := GetNodeMetricBases()
+
+	key := GenerateNodeSeriesKey(NodeCPUUsageBase, "ip-10-0-0-1.ec2.internal")
+	base, node, ok := ParseEntitySeriesKey(key, bases)
+	if !ok || base != NodeCPUUsageBase || node != "ip-10-0-0-1.ec2.internal" {
+		t.Errorf("ParseEntitySeriesKey(%q) = %q, %q, %v", key, base, node, ok)
+	}
+
+	for _, key := range []string{ClusterCPUUsedCores, NodeCPUUsageBase, NodeCPUUsageBase + ".", "pod.cpu.usage.cores.shop.web"} {
+		if _, _, ok := ParseEntitySeriesKey(key, bases); ok {
+			t.Errorf("ParseEntitySeriesKey(%q) matched a node series", key)
+		}
+	}
+
+	// The longest base wins when one base extends another
+	base, entity, ok := ParseEntitySeriesKey("a.b.c.x", []string{"a.b", "a.b.c"})
+	if !ok || base != "a.b.c" || entity != "x" {
+		t.Errorf("ParseEntitySeriesKey picked %q, %q, %v", base, entity, ok)
+	}
+}
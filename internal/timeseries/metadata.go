@@ -0,0 +1,221 @@
+package timeseries
+
+import "strings"
+
+// MetricType is the exposition type of a series
+type MetricType string
+
+const (
+	MetricTypeGauge   MetricType = "gauge"
+	MetricTypeCounter MetricType = "counter" // Only ever increases for the labelled entity
+)
+
+// SeriesMetadata describes a series base key for export
+type SeriesMetadata struct {
+	Type MetricType
+	Help string
+}
+
+// seriesMetadata describes every exported series base key. Keys of per-entity
+// series extend their base with the entity names, see the Generate*SeriesKey
+// functions. Series without an entry here, such as custom and external
+// metrics, are not exported.
+var seriesMetadata = map[string]SeriesMetadata{
+	// Cluster
+	ClusterCPUUsedCores:             {MetricTypeGauge, "CPU cores in use across all nodes"},
+	ClusterCPUCapacityCores:         {MetricTypeGauge, "CPU capacity of all nodes in cores"},
+	ClusterCPUAllocatableCores:      {MetricTypeGauge, "Allocatable CPU of all nodes in cores"},
+	ClusterCPURequestedCores:        {MetricTypeGauge, "CPU requested by all pods in cores"},
+	ClusterCPULimitsCores:           {MetricTypeGauge, "CPU limits of all pods in cores"},
+	ClusterMemUsedBytes:             {MetricTypeGauge, "Memory working set across all nodes in bytes"},
+	ClusterMemCapacityBytes:         {MetricTypeGauge, "Memory capacity of all nodes in bytes"},
+	ClusterMemAllocatableBytes:      {MetricTypeGauge, "Allocatable memory of all nodes in bytes"},
+	ClusterMemRequestedBytes:        {MetricTypeGauge, "Memory requested by all pods in bytes"},
+	ClusterMemLimitsBytes:           {MetricTypeGauge, "Memory limits of all pods in bytes"},
+	ClusterNetRxBps:                 {MetricTypeGauge, "Network bytes received per second across all nodes"},
+	ClusterNetTxBps:                 {MetricTypeGauge, "Network bytes transmitted per second across all nodes"},
+	ClusterNodesCount:               {MetricTypeGauge, "Number of nodes"},
+	ClusterNodesReady:               {MetricTypeGauge, "Number of Ready nodes"},
+	ClusterNodesNotReady:            {MetricTypeGauge, "Number of nodes that are not Ready"},
+	ClusterNodesUnderPressure:       {MetricTypeGauge, "Number of nodes that are not Ready or report a pressure condition"},
+	ClusterPodsRunning:              {MetricTypeGauge, "Number of Running pods"},
+	ClusterPodsPending:              {MetricTypeGauge, "Number of Pending pods"},
+	ClusterPodsFailed:               {MetricTypeGauge, "Number of Failed pods"},
+	ClusterPodsSucceeded:            {MetricTypeGauge, "Number of Succeeded pods"},
+	ClusterPodsUnschedulable:        {MetricTypeGauge, "Number of pods that cannot be scheduled"},
+	ClusterPodsRunningNotReady:      {MetricTypeGauge, "Number of Running pods that are not Ready"},
+	ClusterPodsRestartsTotal:        {MetricTypeGauge, "Container restarts of all current pods"},
+	ClusterPodsRestartsRate:         {MetricTypeGauge, "Container restarts per second across the cluster"},
+	ClusterPodsRestarts1h:           {MetricTypeGauge, "Container restarts across the cluster in the last hour"},
+	ClusterPodsRestartStorm:         {MetricTypeGauge, "1 while a restart storm is detected, 0 otherwise"},
+	ClusterFsImageUsedBytes:         {MetricTypeGauge, "Image filesystem usage across all nodes in bytes"},
+	ClusterFsImageCapacityBytes:     {MetricTypeGauge, "Image filesystem capacity of all nodes in bytes"},
+	ClusterSchedulerHealthy:         {MetricTypeGauge, "1 while the scheduler holds a current leader lease, 0 otherwise"},
+	ClusterControllerManagerHealthy: {MetricTypeGauge, "1 while the controller manager holds a current leader lease, 0 otherwise"},
+	ClusterNamespacesCount:          {MetricTypeGauge, "Number of namespaces"},
+	ClusterDeploymentsCount:         {MetricTypeGauge, "Number of deployments"},
+	ClusterStatefulSetsCount:        {MetricTypeGauge, "Number of statefulsets"},
+	ClusterDaemonSetsCount:          {MetricTypeGauge, "Number of daemonsets"},
+	ClusterReplicaSetsCount:         {MetricTypeGauge, "Number of replicasets"},
+	ClusterJobsCount:                {MetricTypeGauge, "Number of jobs"},
+	ClusterCronJobsCount:            {MetricTypeGauge, "Number of cronjobs"},
+	ClusterServicesCount:            {MetricTypeGauge, "Number of services"},
+	ClusterConfigMapsCount:          {MetricTypeGauge, "Number of configmaps"},
+	ClusterSecretsCount:             {MetricTypeGauge, "Number of secrets"},
+	ClusterIngressesCount:           {MetricTypeGauge, "Number of ingresses"},
+	ClusterPVCsCount:                {MetricTypeGauge, "Number of persistent volume claims"},
+
+	// Aggregator
+	AggregatorTickDurationSeconds: {MetricTypeGauge, "Duration of the last aggregator collection cycle in seconds"},
+	AggregatorSeriesCount:         {MetricTypeGauge, "Number of series held by the timeseries store"},
+
+	// Node
+	NodeCPUUsageBase:                 {MetricTypeGauge, "CPU cores in use on the node"},
+	NodeMemUsageBase:                 {MetricTypeGauge, "Memory in use on the node in bytes"},
+	NodeMemWorkingSetBase:            {MetricTypeGauge, "Memory working set of the node in bytes"},
+	NodeMemAvailableBase:             {MetricTypeGauge, "Memory available on the node in bytes"},
+	NodeNetRxBase:                    {MetricTypeGauge, "Network bytes received per second by the node"},
+	NodeNetTxBase:                    {MetricTypeGauge, "Network bytes transmitted per second by the node"},
+	NodeNetRxPpsBase:                 {MetricTypeGauge, "Network packets received per second by the node"},
+	NodeNetTxPpsBase:                 {MetricTypeGauge, "Network packets transmitted per second by the node"},
+	NodeFsUsedBase:                   {MetricTypeGauge, "Root filesystem usage of the node in bytes"},
+	NodeFsUsedPercentBase:            {MetricTypeGauge, "Root filesystem usage of the node in percent"},
+	NodeFsCapacityBase:               {MetricTypeGauge, "Root filesystem capacity of the node in bytes"},
+	NodeFsAvailableBase:              {MetricTypeGauge, "Root filesystem space available on the node in bytes"},
+	NodeFsInodesTotalBase:            {MetricTypeGauge, "Root filesystem inodes of the node"},
+	NodeFsInodesFreeBase:             {MetricTypeGauge, "Free root filesystem inodes of the node"},
+	NodeFsInodesUsedPercentBase:      {MetricTypeGauge, "Root filesystem inode usage of the node in percent"},
+	NodeImageFsUsedBase:              {MetricTypeGauge, "Image filesystem usage of the node in bytes"},
+	NodeImageFsUsedPercentBase:       {MetricTypeGauge, "Image filesystem usage of the node in percent"},
+	NodeImageFsCapacityBase:          {MetricTypeGauge, "Image filesystem capacity of the node in bytes"},
+	NodeImageFsAvailableBase:         {MetricTypeGauge, "Image filesystem space available on the node in bytes"},
+	NodeImageFsInodesTotalBase:       {MetricTypeGauge, "Image filesystem inodes of the node"},
+	NodeImageFsInodesFreeBase:        {MetricTypeGauge, "Free image filesystem inodes of the node"},
+	NodeImageFsInodesUsedPercentBase: {MetricTypeGauge, "Image filesystem inode usage of the node in percent"},
+	NodeProcessCountBase:             {MetricTypeGauge, "Number of processes on the node"},
+	NodeCapacityCPUBase:              {MetricTypeGauge, "CPU capacity of the node in cores"},
+	NodeCapacityMemBase:              {MetricTypeGauge, "Memory capacity of the node in bytes"},
+	NodeCapacityPodsBase:             {MetricTypeGauge, "Pod capacity of the node"},
+	NodeAllocatableCPUBase:           {MetricTypeGauge, "Allocatable CPU of the node in cores"},
+	NodeAllocatableMemBase:           {MetricTypeGauge, "Allocatable memory of the node in bytes"},
+	NodeAllocatablePodsBase:          {MetricTypeGauge, "Allocatable pods of the node"},
+	NodeRequestedCPUBase:             {MetricTypeGauge, "CPU requested by the node's active pods in cores"},
+	NodeRequestedMemBase:             {MetricTypeGauge, "Memory requested by the node's active pods in bytes"},
+	NodeSchedulableBase:              {MetricTypeGauge, "1 while new pods can be scheduled on the node, 0 otherwise"},
+	NodePodsCountBase:                {MetricTypeGauge, "Number of pods on the node"},
+	NodePodsPhaseRunningBase:         {MetricTypeGauge, "Number of Running pods on the node"},
+	NodePodsPhasePendingBase:         {MetricTypeGauge, "Number of Pending pods on the node"},
+	NodePodsPhaseFailedBase:          {MetricTypeGauge, "Number of Failed pods on the node"},
+	NodeConditionReadyBase:           {MetricTypeGauge, "1 while the node is Ready, 0 otherwise"},
+	NodeConditionDiskPressureBase:    {MetricTypeGauge, "1 while the node reports DiskPressure, 0 otherwise"},
+	NodeConditionMemoryPressureBase:  {MetricTypeGauge, "1 while the node reports MemoryPressure, 0 otherwise"},
+	NodeConditionPIDPressureBase:     {MetricTypeGauge, "1 while the node reports PIDPressure, 0 otherwise"},
+	NodePressureScoreBase:            {MetricTypeGauge, "Number of pressure conditions the node reports, plus 1 if not Ready; 0 is healthy"},
+
+	// Pod
+	PodCPUUsageBase:         {MetricTypeGauge, "CPU cores in use by the pod"},
+	PodMemUsageBase:         {MetricTypeGauge, "Memory in use by the pod in bytes"},
+	PodMemWorkingSetBase:    {MetricTypeGauge, "Memory working set of the pod in bytes"},
+	PodNetRxBase:            {MetricTypeGauge, "Network bytes received per second by the pod"},
+	PodNetTxBase:            {MetricTypeGauge, "Network bytes transmitted per second by the pod"},
+	PodEphemeralUsedBase:    {MetricTypeGauge, "Ephemeral storage used by the pod in bytes"},
+	PodEphemeralPercentBase: {MetricTypeGauge, "Ephemeral storage used by the pod in percent of its limit"},
+	PodCPURequestBase:       {MetricTypeGauge, "CPU requested by the pod in cores"},
+	PodCPULimitBase:         {MetricTypeGauge, "CPU limit of the pod in cores"},
+	PodMemRequestBase:       {MetricTypeGauge, "Memory requested by the pod in bytes"},
+	PodMemLimitBase:         {MetricTypeGauge, "Memory limit of the pod in bytes"},
+	PodRestartsTotalBase:    {MetricTypeCounter, "Container restarts of the pod"},
+	PodRestartsRateBase:     {MetricTypeGauge, "Container restarts of the pod per second"},
+	PodRestarts1hBase:       {MetricTypeGauge, "Container restarts of the pod in the last hour"},
+
+	// Namespace
+	NamespaceCPUUsedBase:           {MetricTypeGauge, "CPU cores in use in the namespace"},
+	NamespaceCPURequestBase:        {MetricTypeGauge, "CPU requested in the namespace in cores"},
+	NamespaceCPULimitBase:          {MetricTypeGauge, "CPU limits in the namespace in cores"},
+	NamespaceMemUsedBase:           {MetricTypeGauge, "Memory in use in the namespace in bytes"},
+	NamespaceMemRequestBase:        {MetricTypeGauge, "Memory requested in the namespace in bytes"},
+	NamespaceMemLimitBase:          {MetricTypeGauge, "Memory limits in the namespace in bytes"},
+	NamespacePodsRunningBase:       {MetricTypeGauge, "Number of Running pods in the namespace"},
+	NamespacePodsRestartsTotalBase: {MetricTypeGauge, "Container restarts of current pods in the namespace"},
+	NamespacePodsRestartsRateBase:  {MetricTypeGauge, "Container restarts in the namespace per second"},
+	NamespacePodsRestarts1hBase:    {MetricTypeGauge, "Container restarts in the namespace in the last hour"},
+	NamespacePVCsPendingBase:       {MetricTypeGauge, "PersistentVolumeClaims in the namespace waiting to be bound"},
+	NamespacePVCsBoundBase:         {MetricTypeGauge, "PersistentVolumeClaims in the namespace bound to a volume"},
+	NamespacePVCsLostBase:          {MetricTypeGauge, "PersistentVolumeClaims in the namespace whose volume is gone"},
+	NamespacePVCsPendingStuckBase:  {MetricTypeGauge, "PersistentVolumeClaims in the namespace Pending beyond the stuck threshold"},
+
+	// Service
+	ServiceReadyEndpointsBase:    {MetricTypeGauge, "Ready endpoints backing the service"},
+	ServiceNotReadyEndpointsBase: {MetricTypeGauge, "Endpoints of the service that are not ready"},
+
+	// Workload
+	WorkloadDesiredReplicasBase:     {MetricTypeGauge, "Replicas the workload should run; scheduled pods desired for a DaemonSet"},
+	WorkloadReadyReplicasBase:       {MetricTypeGauge, "Ready replicas of the workload"},
+	WorkloadAvailableReplicasBase:   {MetricTypeGauge, "Replicas of the workload ready for at least minReadySeconds"},
+	WorkloadUnavailableReplicasBase: {MetricTypeGauge, "Replicas of the workload that are not available"},
+	WorkloadScheduledReplicasBase:   {MetricTypeGauge, "Nodes running a DaemonSet pod that should run one"},
+
+	// Container
+	ContainerCPUUsageBase:      {MetricTypeGauge, "CPU cores in use by the container"},
+	ContainerMemWorkingSetBase: {MetricTypeGauge, "Memory working set of the container in bytes"},
+	ContainerRootFsUsedBase:    {MetricTypeGauge, "Root filesystem usage of the container in bytes"},
+	ContainerLogsUsedBase:      {MetricTypeGauge, "Log usage of the container in bytes"},
+}
+
+// DescribeSeries splits a series key into its base key and the entity labels
+// encoded in it, and returns the base's metadata. Namespace and container
+// names cannot contain dots, so pod and node names, which can, are taken as
+// the remaining middle or tail of the key. ok is false for keys without
+// metadata.
+func DescribeSeries(key string) (base string, labels map[string]string, meta SeriesMetadata, ok bool) {
+	if m, found := seriesMetadata[key]; found && !strings.HasPrefix(key, "ns.") {
+		return key, map[string]string{}, m, true
+	}
+
+	for candidate := range seriesMetadata {
+		if strings.HasPrefix(key, candidate+".") && len(candidate) > len(base) {
+			base = candidate
+		}
+	}
+	if base == "" {
+		return "", nil, SeriesMetadata{}, false
+	}
+
+	rest := key[len(base)+1:]
+	switch {
+	case strings.HasPrefix(base, "node."):
+		labels = map[string]string{"node": rest}
+	case strings.HasPrefix(base, "ns."):
+		labels = map[string]string{"namespace": rest}
+	case strings.HasPrefix(base, "pod."):
+		namespace, pod, found := strings.Cut(rest, ".")
+		if !found {
+			return "", nil, SeriesMetadata{}, false
+		}
+		labels = map[string]string{"namespace": namespace, "pod": pod}
+	case strings.HasPrefix(base, "svc."):
+		namespace, service, found := strings.Cut(rest, ".")
+		if !found {
+			return "", nil, SeriesMetadata{}, false
+		}
+		labels = map[string]string{"namespace": namespace, "service": service}
+	case strings.HasPrefix(base, "workload."):
+		namespace, kindName, found := strings.Cut(rest, ".")
+		kind, name, kindFound := strings.Cut(kindName, ".")
+		if !found || !kindFound {
+			return "", nil, SeriesMetadata{}, false
+		}
+		labels = map[string]string{"namespace": namespace, "kind": kind, "workload": name}
+	case strings.HasPrefix(base, "ctr."):
+		namespace, podContainer, found := strings.Cut(rest, ".")
+		lastDot := strings.LastIndex(podContainer, ".")
+		if !found || lastDot < 0 {
+			return "", nil, SeriesMetadata{}, false
+		}
+		labels = map[string]string{"namespace": namespace, "pod": podContainer[:lastDot], "container": podContainer[lastDot+1:]}
+	default:
+		// Cluster and aggregator series carry no entity
+		return "", nil, SeriesMetadata{}, false
+	}
+	return base, labels, seriesMetadata[base], true
+}
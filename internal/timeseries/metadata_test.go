@@ -0,0 +1,63 @@
+package timeseries
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDescribeSeries(t *testing.T) {
+	tests := []struct {
+		key    string
+		base   string
+		labels map[string]string
+	}{
+		{ClusterCPUUsedCores, ClusterCPUUsedCores, map[string]string{}},
+		{ClusterPodsRunningNotReady, ClusterPodsRunningNotReady, map[string]string{}},
+		{GenerateNodeSeriesKey(NodeCPUUsageBase, "ip-10-0-0-1.ec2.internal"), NodeCPUUsageBase, map[string]string{"node": "ip-10-0-0-1.ec2.internal"}},
+		{GenerateNodeSeriesKey(NodeFsInodesUsedPercentBase, "node-a"), NodeFsInodesUsedPercentBase, map[string]string{"node": "node-a"}},
+		{GenerateNamespaceSeriesKey(NamespaceCPUUsedBase, "shop"), NamespaceCPUUsedBase, map[string]string{"namespace": "shop"}},
+		{GeneratePodSeriesKey(PodRestartsTotalBase, "shop", "web.v2-0"), PodRestartsTotalBase, map[string]string{"namespace": "shop", "pod": "web.v2-0"}},
+		{GenerateServiceSeriesKey(ServiceReadyEndpointsBase, "shop", "web"), ServiceReadyEndpointsBase, map[string]string{"namespace": "shop", "service": "web"}},
+		{GenerateWorkloadSeriesKey(WorkloadReadyReplicasBase, "shop", "Deployment", "web.v2"), WorkloadReadyReplicasBase, map[string]string{"namespace": "shop", "kind": "deployment", "workload": "web.v2"}},
+		{GenerateContainerSeriesKey(ContainerCPUUsageBase, "shop", "web.v2-0", "app"), ContainerCPUUsageBase, map[string]string{"namespace": "shop", "pod": "web.v2-0", "container": "app"}},
+	}
+	for _, tt := range tests {
+		base, labels, meta, ok := DescribeSeries(tt.key)
+		if !ok {
+			t.Errorf("Expected %s to be described", tt.key)
+			continue
+		}
+		if base != tt.base || !reflect.DeepEqual(labels, tt.labels) {
+			t.Errorf("%s: expected %s %v, got %s %v", tt.key, tt.base, tt.labels, base, labels)
+		}
+		if meta.Help == "" || meta.Type == "" {
+			t.Errorf("%s: expected help and type, got %+v", tt.key, meta)
+		}
+	}
+
+	for _, key := range []string{
+		NamespaceCPUUsedBase, // A namespace base without a namespace
+		GenerateCustomMetricSeriesKey("qps", "Pod", "shop", "web"),
+		"unknown.series",
+	} {
+		if _, _, _, ok := DescribeSeries(key); ok {
+			t.Errorf("Expected %s not to be described", key)
+		}
+	}
+}
+
+func TestSeriesMetadataCoversKnownKeys(t *testing.T) {
+	var keys []string
+	keys = append(keys, AllSeriesKeys()...)
+	keys = append(keys, GetNodeMetricBases()...)
+	keys = append(keys, GetPodMetricBases()...)
+	keys = append(keys, GetContainerMetricBases()...)
+	keys = append(keys, GetServiceMetricBases()...)
+	keys = append(keys, GetWorkloadMetricBases()...)
+	keys = append(keys, GetNamespaceMetricBases()...)
+	for _, key := range keys {
+		if _, ok := seriesMetadata[key]; !ok {
+			t.Errorf("Expected metadata for %s", key)
+		}
+	}
+}
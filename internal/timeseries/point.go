@@ -19,6 +19,14 @@ func NewPointWithEntity(t time.Time, v float64, entity map[string]string) Point
 	return Point{T: t, V: v, Entity: entity}
 }
 
+// AggPoint summarizes the hi-res points folded into one low resolution step
+type AggPoint struct {
+	T   time.Time `json:"t"`   // Start of the step
+	Min float64   `json:"min"` // Smallest value in the step
+	Max float64   `json:"max"` // Largest value in the step
+	Avg float64   `json:"avg"` // Mean of the step
+}
+
 // IsZero returns true if the point is the zero value
 func (p Point) IsZero() bool {
 	return p.T.IsZero() && p.V == 0 && (p.Entity == nil || len(p.Entity) == 0)
@@ -0,0 +1,81 @@
+package timeseries
+
+import (
+	"math"
+	"time"
+)
+
+// Reducer selects how the points falling in one downsampling bucket are
+// combined into a single point
+type Reducer string
+
+const (
+	ReducerMean Reducer = "mean" // Average of the bucket (default)
+	ReducerMin  Reducer = "min"  // Smallest value in the bucket
+	ReducerMax  Reducer = "max"  // Largest value in the bucket
+	ReducerSum  Reducer = "sum"  // Sum of the bucket
+	ReducerLast Reducer = "last" // Most recent value in the bucket
+)
+
+// ParseReducer returns the reducer named by name. An empty name selects the
+// mean.
+func ParseReducer(name string) (Reducer, bool) {
+	switch Reducer(name) {
+	case "":
+		return ReducerMean, true
+	case ReducerMean, ReducerMin, ReducerMax, ReducerSum, ReducerLast:
+		return Reducer(name), true
+	default:
+		return "", false
+	}
+}
+
+// bucket accumulates the points of one downsampling step
+type bucket struct {
+	start time.Time
+	count int
+	sum   float64
+	min   float64
+	max   float64
+	last  float64
+}
+
+// reset starts a new bucket at start holding v
+func (b *bucket) reset(start time.Time, v float64) {
+	b.start = start
+	b.count = 1
+	b.sum = v
+	b.min = v
+	b.max = v
+	b.last = v
+}
+
+// add folds v into the bucket
+func (b *bucket) add(v float64) {
+	b.count++
+	b.sum += v
+	b.min = math.Min(b.min, v)
+	b.max = math.Max(b.max, v)
+	b.last = v
+}
+
+// aggregate summarizes the bucket
+func (b *bucket) aggregate() AggPoint {
+	return AggPoint{T: b.start, Min: b.min, Max: b.max, Avg: b.sum / float64(b.count)}
+}
+
+// value reduces the bucket to a single value
+func (b *bucket) value(reducer Reducer) float64 {
+	switch reducer {
+	case ReducerMin:
+		return b.min
+	case ReducerMax:
+		return b.max
+	case ReducerSum:
+		return b.sum
+	case ReducerLast:
+		return b.last
+	default:
+		return b.sum / float64(b.count)
+	}
+}
@@ -5,7 +5,8 @@ import (
 	"time"
 )
 
-// Series represents a time series with both high and low resolution ring buffers
+// Series represents a time series with high resolution, low resolution and
+// rollup ring buffers
 type Series struct {
 	mu     sync.RWMutex
 	config Config
@@ -21,10 +22,18 @@ type Series struct {
 	headLo int
 	fullLo bool
 
-	// Downsampling state
-	lastBin  time.Time
-	binSum   float64
-	binCount int
+	// Downsampling state, reduced with config.Aggregation
+	loBin bucket
+
+	// Per-step aggregates, index-aligned with lo; nil unless
+	// config.LoResAggregates is set
+	loAgg []AggPoint
+
+	// Rollup ring buffer, reduced with config.MedResReducer
+	med     []Point
+	headMed int
+	fullMed bool
+	medBin  bucket
 }
 
 // NewSeries creates a new Series with the given configuration
@@ -33,6 +42,8 @@ func NewSeries(config Config) *Series {
 		config: config,
 		hi:     make([]Point, config.HiResPoints),
 		lo:     make([]Point, config.LoResPoints),
+		loAgg:  loAggregates(config),
+		med:    make([]Point, medResPoints(config)),
 	}
 }
 
@@ -43,6 +54,8 @@ func NewSeriesWithHealth(config Config, health *HealthMetrics) *Series {
 		health: health,
 		hi:     make([]Point, config.HiResPoints),
 		lo:     make([]Point, config.LoResPoints),
+		loAgg:  loAggregates(config),
+		med:    make([]Point, medResPoints(config)),
 	}
 }
 
@@ -66,6 +79,27 @@ func (s *Series) Add(p Point) {
 
 	// Add to low resolution buffer (with downsampling)
 	s.addToLo(p)
+
+	// Add to rollup buffer
+	s.addToMed(p)
+}
+
+// medResPoints returns the size of the rollup buffer; a tier without a
+// positive step is disabled
+func medResPoints(config Config) int {
+	if config.MedResStep <= 0 || config.MedResPoints <= 0 {
+		return 0
+	}
+	return config.MedResPoints
+}
+
+// loAggregates returns the aggregate ring of a new series, or nil when
+// low resolution aggregates are disabled
+func loAggregates(config Config) []AggPoint {
+	if !config.LoResAggregates {
+		return nil
+	}
+	return make([]AggPoint, config.LoResPoints)
 }
 
 // addToHi adds a point to the high resolution ring buffer
@@ -82,35 +116,50 @@ func (s *Series) addToLo(p Point) {
 	// Determine which bin this point belongs to
 	binStart := p.T.Truncate(s.config.LoResStep)
 
-	if s.lastBin.IsZero() {
+	switch {
+	case s.loBin.count == 0:
 		// First point
-		s.lastBin = binStart
-		s.binSum = p.V
-		s.binCount = 1
-		return
-	}
-
-	if binStart.Equal(s.lastBin) {
+		s.loBin.reset(binStart, p.V)
+	case binStart.Equal(s.loBin.start):
 		// Same bin, accumulate
-		s.binSum += p.V
-		s.binCount++
-	} else {
+		s.loBin.add(p.V)
+	default:
 		// New bin, finalize previous bin
-		if s.binCount > 0 {
-			avgValue := s.binSum / float64(s.binCount)
-			binPoint := Point{T: s.lastBin, V: avgValue}
-
-			s.lo[s.headLo] = binPoint
-			s.headLo = (s.headLo + 1) % len(s.lo)
-			if s.headLo == 0 {
-				s.fullLo = true
-			}
+		s.lo[s.headLo] = Point{T: s.loBin.start, V: s.loBin.value(s.config.Aggregation)}
+		if s.loAgg != nil {
+			s.loAgg[s.headLo] = s.loBin.aggregate()
+		}
+		s.headLo = (s.headLo + 1) % len(s.lo)
+		if s.headLo == 0 {
+			s.fullLo = true
 		}
 
 		// Start new bin
-		s.lastBin = binStart
-		s.binSum = p.V
-		s.binCount = 1
+		s.loBin.reset(binStart, p.V)
+	}
+}
+
+// addToMed reduces points into one rollup point per MedResStep. A bucket is
+// written when the first point of a later bucket arrives, so steps without
+// points leave a gap rather than a zero.
+func (s *Series) addToMed(p Point) {
+	if len(s.med) == 0 {
+		return
+	}
+
+	binStart := p.T.Truncate(s.config.MedResStep)
+	switch {
+	case s.medBin.count == 0:
+		s.medBin.reset(binStart, p.V)
+	case binStart.Equal(s.medBin.start):
+		s.medBin.add(p.V)
+	default:
+		s.med[s.headMed] = Point{T: s.medBin.start, V: s.medBin.value(s.config.MedResReducer)}
+		s.headMed = (s.headMed + 1) % len(s.med)
+		if s.headMed == 0 {
+			s.fullMed = true
+		}
+		s.medBin.reset(binStart, p.V)
 	}
 }
 
@@ -121,16 +170,61 @@ func (s *Series) GetSince(since time.Time, res Resolution) []Point {
 
 	switch res {
 	case Hi:
-		return s.getFromRing(s.hi, s.headHi, s.fullHi, since)
+		return s.getFromRing(s.hi, s.headHi, s.fullHi, since, s.config.MaxWindow)
 	case Lo:
-		return s.getFromRing(s.lo, s.headLo, s.fullLo, since)
+		return s.getFromRing(s.lo, s.headLo, s.fullLo, since, s.config.MaxWindow)
+	case Med:
+		return s.getFromRing(s.med, s.headMed, s.fullMed, since, s.config.medWindow())
 	default:
 		return nil
 	}
 }
 
-// getFromRing extracts points from a ring buffer since the given time
-func (s *Series) getFromRing(ring []Point, head int, full bool, since time.Time) []Point {
+// GetAggregatesSince returns the min, max and mean of the low resolution steps
+// since the given time, or within MaxWindow of now when since is zero. It
+// returns nil unless the series keeps low resolution aggregates.
+func (s *Series) GetAggregatesSince(since time.Time) []AggPoint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.loAgg == nil {
+		return nil
+	}
+
+	var result []AggPoint
+	for _, idx := range ringIndexes(len(s.lo), s.headLo, s.fullLo) {
+		point, agg := s.lo[idx], s.loAgg[idx]
+		// Steps pruned from lo, or restored without aggregates, are skipped
+		if point.IsZero() || agg.T.IsZero() {
+			continue
+		}
+		if !since.IsZero() && agg.T.Before(since) {
+			continue
+		}
+		if since.IsZero() && time.Since(agg.T) > s.config.MaxWindow {
+			continue
+		}
+		result = append(result, agg)
+	}
+	return result
+}
+
+// ringIndexes returns the occupied indexes of a ring buffer, oldest first
+func ringIndexes(length, head int, full bool) []int {
+	size, start := head, 0
+	if full {
+		size, start = length, head
+	}
+	indexes := make([]int, size)
+	for i := range indexes {
+		indexes[i] = (start + i) % length
+	}
+	return indexes
+}
+
+// getFromRing extracts points from a ring buffer since the given time, or
+// within window of now when since is zero
+func (s *Series) getFromRing(ring []Point, head int, full bool, since time.Time, window time.Duration) []Point {
 	if len(ring) == 0 {
 		return nil
 	}
@@ -165,7 +259,7 @@ func (s *Series) getFromRing(ring []Point, head int, full bool, since time.Time)
 		}
 
 		// Also check max window if since is not specified
-		if since.IsZero() && time.Since(point.T) > s.config.MaxWindow {
+		if since.IsZero() && time.Since(point.T) > window {
 			continue
 		}
 
@@ -175,6 +269,22 @@ func (s *Series) getFromRing(ring []Point, head int, full bool, since time.Time)
 	return result
 }
 
+// Latest returns the most recent high resolution point without copying the
+// buffer. ok is false when the series holds no points.
+func (s *Series) Latest() (Point, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.hi) == 0 || (s.headHi == 0 && !s.fullHi) {
+		return Point{}, false
+	}
+	p := s.hi[(s.headHi-1+len(s.hi))%len(s.hi)]
+	if p.IsZero() {
+		return Point{}, false
+	}
+	return p, true
+}
+
 // GetAll returns all points for the specified resolution
 func (s *Series) GetAll(res Resolution) []Point {
 	return s.GetSince(time.Time{}, res)
@@ -192,6 +302,9 @@ func (s *Series) Prune() {
 
 	// Prune low resolution
 	s.pruneRing(s.lo, &s.headLo, &s.fullLo, cutoff)
+
+	// Prune rollup, which has its own retention
+	s.pruneRing(s.med, &s.headMed, &s.fullMed, time.Now().Add(-s.config.medWindow()))
 }
 
 // pruneRing removes old points from a ring buffer
@@ -256,5 +369,10 @@ func (s *Series) getPointCount() int {
 		loCount = len(s.lo)
 	}
 
-	return hiCount + loCount
+	medCount := s.headMed
+	if s.fullMed {
+		medCount = len(s.med)
+	}
+
+	return hiCount + loCount + medCount
 }
@@ -161,3 +161,199 @@ func TestSeries(t *testing.T) {
 		}
 	})
 }
+
+func TestSeriesRollup(t *testing.T) {
+	config := Config{
+		MaxWindow:    10 * time.Minute,
+		HiResStep:    time.Second,
+		HiResPoints:  10,
+		LoResStep:    5 * time.Second,
+		LoResPoints:  5,
+		MedResStep:   time.Minute,
+		MedResPoints: 1440,
+	}
+	start := time.Now().Add(-3 * time.Hour).Truncate(time.Minute)
+
+	t.Run("MeanByDefault", func(t *testing.T) {
+		s := NewSeries(config)
+		for i, v := range []float64{1, 2, 3, 4} {
+			s.Add(NewPoint(start.Add(time.Duration(i)*10*time.Second), v))
+		}
+		s.Add(NewPoint(start.Add(time.Minute), 100))
+
+		points := s.GetSince(start, Med)
+		if len(points) != 1 {
+			t.Fatalf("Expected 1 rollup point, got %d", len(points))
+		}
+		if !points[0].T.Equal(start) || points[0].V != 2.5 {
+			t.Errorf("Expected mean 2.5 at %v, got %v at %v", start, points[0].V, points[0].T)
+		}
+	})
+
+	t.Run("GapsAreNotFilled", func(t *testing.T) {
+		s := NewSeries(config)
+		s.Add(NewPoint(start, 1))
+		s.Add(NewPoint(start.Add(5*time.Minute), 2))
+		s.Add(NewPoint(start.Add(6*time.Minute), 3))
+
+		points := s.GetSince(start, Med)
+		if len(points) != 2 {
+			t.Fatalf("Expected 2 rollup points around the gap, got %d: %v", len(points), points)
+		}
+		if !points[1].T.Equal(start.Add(5*time.Minute)) || points[1].V != 2 {
+			t.Errorf("Expected value 2 at minute 5, got %v at %v", points[1].V, points[1].T)
+		}
+	})
+
+	t.Run("ConfiguredReducer", func(t *testing.T) {
+		maxConfig := config
+		maxConfig.MedResReducer = ReducerMax
+		s := NewSeries(maxConfig)
+		for i, v := range []float64{3, 9, 1} {
+			s.Add(NewPoint(start.Add(time.Duration(i)*time.Second), v))
+		}
+		s.Add(NewPoint(start.Add(time.Minute), 0))
+
+		points := s.GetSince(start, Med)
+		if len(points) != 1 || points[0].V != 9 {
+			t.Errorf("Expected max 9, got %v", points)
+		}
+	})
+
+	t.Run("OutlivesMaxWindow", func(t *testing.T) {
+		s := NewSeries(config)
+		for i := 0; i < 3; i++ {
+			s.Add(NewPoint(start.Add(time.Duration(i)*time.Minute), float64(i)))
+		}
+		s.Prune()
+
+		if points := s.GetAll(Med); len(points) != 2 {
+			t.Errorf("Expected rollup points older than MaxWindow to be kept, got %d", len(points))
+		}
+		if points := s.GetAll(Lo); len(points) != 0 {
+			t.Errorf("Expected lo-res points older than MaxWindow to be pruned, got %d", len(points))
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		disabled := config
+		disabled.MedResPoints = 0
+		s := NewSeries(disabled)
+		s.Add(NewPoint(start, 1))
+		s.Add(NewPoint(start.Add(time.Minute), 2))
+
+		if points := s.GetAll(Med); points != nil {
+			t.Errorf("Expected no rollup points when disabled, got %v", points)
+		}
+	})
+}
+
+func TestParseReducer(t *testing.T) {
+	if reducer, ok := ParseReducer(""); !ok || reducer != ReducerMean {
+		t.Errorf("Expected empty reducer to default to mean, got %q", reducer)
+	}
+	for _, name := range []string{"mean", "min", "max", "sum", "last"} {
+		if reducer, ok := ParseReducer(name); !ok || string(reducer) != name {
+			t.Errorf("Expected %q to parse, got %q", name, reducer)
+		}
+	}
+	if _, ok := ParseReducer("median"); ok {
+		t.Error("Expected median to be rejected")
+	}
+}
+
+func TestSeriesLoResAggregation(t *testing.T) {
+	start := time.Now().Truncate(5 * time.Second)
+	values := []float64{2, 8, 4, 6}
+
+	for aggregation, want := range map[Reducer]float64{
+		"":          5,
+		ReducerMean: 5,
+		ReducerMin:  2,
+		ReducerMax:  8,
+		ReducerSum:  20,
+		ReducerLast: 6,
+	} {
+		s := NewSeries(Config{
+			MaxWindow:   10 * time.Minute,
+			HiResPoints: 10,
+			LoResStep:   5 * time.Second,
+			LoResPoints: 5,
+			Aggregation: aggregation,
+		})
+		for i, v := range values {
+			s.Add(NewPoint(start.Add(time.Duration(i)*time.Second), v))
+		}
+		s.Add(NewPoint(start.Add(5*time.Second), 100))
+
+		points := s.GetAll(Lo)
+		if len(points) != 1 || points[0].V != want {
+			t.Errorf("Aggregation %q: expected %v, got %v", aggregation, want, points)
+		}
+	}
+}
+
+func TestSeriesLoResAggregates(t *testing.T) {
+	start := time.Now().Add(-time.Minute).Truncate(5 * time.Second)
+	config := Config{
+		MaxWindow:   10 * time.Minute,
+		HiResPoints: 20,
+		LoResStep:   5 * time.Second,
+		LoResPoints: 5,
+	}
+
+	// A one-second spike in an otherwise flat series
+	fill := func(s *Series) {
+		for i := 0; i < 11; i++ {
+			v := 10.0
+			if i == 7 {
+				v = 500
+			}
+			s.Add(NewPoint(start.Add(time.Duration(i)*time.Second), v))
+		}
+	}
+
+	plain := NewSeries(config)
+	fill(plain)
+	if aggregates := plain.GetAggregatesSince(time.Time{}); aggregates != nil {
+		t.Fatalf("expected no aggregates by default, got %v", aggregates)
+	}
+
+	config.LoResAggregates = true
+	s := NewSeries(config)
+	fill(s)
+
+	points := s.GetAll(Lo)
+	aggregates := s.GetAggregatesSince(time.Time{})
+	if len(points) != 2 || len(aggregates) != 2 {
+		t.Fatalf("expected 2 lo-res steps, got %d points and %d aggregates", len(points), len(aggregates))
+	}
+	// The mean flattens the spike; the aggregate keeps it
+	if points[1].V != 108 {
+		t.Errorf("expected mean 108, got %v", points[1].V)
+	}
+	spike := aggregates[1]
+	if !spike.T.Equal(points[1].T) || spike.Max != 500 || spike.Min != 10 || spike.Avg != 108 {
+		t.Errorf("expected the spike step to keep min 10, max 500 and avg 108, got %+v", spike)
+	}
+
+	if since := s.GetAggregatesSince(points[1].T); len(since) != 1 || since[0].Max != 500 {
+		t.Errorf("expected only the spike step since its start, got %v", since)
+	}
+}
+
+func TestSeriesLatest(t *testing.T) {
+	s := NewSeries(Config{MaxWindow: time.Minute, HiResPoints: 3, LoResStep: 5 * time.Second, LoResPoints: 2})
+	if _, ok := s.Latest(); ok {
+		t.Error("Expected no latest point in an empty series")
+	}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		s.Add(NewPoint(start.Add(time.Duration(i)*time.Second), float64(i)))
+	}
+	latest, ok := s.Latest()
+	if !ok || latest.V != 4 {
+		t.Errorf("Expected latest value 4 after the ring wrapped, got %v (ok=%v)", latest.V, ok)
+	}
+}
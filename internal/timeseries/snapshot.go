@@ -0,0 +1,232 @@
+package timeseries
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// SnapshotVersion is the version of the snapshot format written by Snapshot.
+// Snapshots of any other version are discarded on restore.
+const SnapshotVersion = 1
+
+// ErrIncompatibleSnapshot is returned when a snapshot was written by another
+// format version or with a different ring layout, and cannot be restored
+var ErrIncompatibleSnapshot = errors.New("incompatible timeseries snapshot")
+
+// snapshot is the serialized form of a store
+type snapshot struct {
+	Version int              `json:"version"`
+	TakenAt time.Time        `json:"takenAt"`
+	Layout  snapshotLayout   `json:"layout"`
+	Series  []seriesSnapshot `json:"series"`
+}
+
+// snapshotLayout is the part of the configuration the ring contents depend
+// on. Points are only meaningful to a store with the same steps.
+type snapshotLayout struct {
+	HiResStep  time.Duration `json:"hiResStep"`
+	LoResStep  time.Duration `json:"loResStep"`
+	MedResStep time.Duration `json:"medResStep"`
+}
+
+// seriesSnapshot holds one series' ring contents, oldest first, and its
+// partially filled downsampling buckets. LoAgg, when present, holds the
+// aggregate of each Lo point.
+type seriesSnapshot struct {
+	Key    string          `json:"key"`
+	Hi     []Point         `json:"hi"`
+	Lo     []Point         `json:"lo"`
+	LoAgg  []AggPoint      `json:"loAgg,omitempty"`
+	Med    []Point         `json:"med,omitempty"`
+	LoBin  *bucketSnapshot `json:"loBin,omitempty"`
+	MedBin *bucketSnapshot `json:"medBin,omitempty"`
+}
+
+// bucketSnapshot is the serialized form of a bucket
+type bucketSnapshot struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+	Sum   float64   `json:"sum"`
+	Min   float64   `json:"min"`
+	Max   float64   `json:"max"`
+	Last  float64   `json:"last"`
+}
+
+// layout returns the snapshot layout of the configuration
+func (c Config) layout() snapshotLayout {
+	return snapshotLayout{HiResStep: c.HiResStep, LoResStep: c.LoResStep, MedResStep: c.MedResStep}
+}
+
+// MemStoreOption configures a MemStore at construction
+type MemStoreOption func(*MemStore)
+
+// WithSnapshot restores the store from a snapshot written by Snapshot. A
+// snapshot that cannot be restored leaves the store empty and is reported to
+// onError, which may be nil.
+func WithSnapshot(r io.Reader, onError func(error)) MemStoreOption {
+	return func(m *MemStore) {
+		if err := m.Restore(r); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// Snapshot writes every series, with its ring buffers and pending
+// downsampling state, to w as gzip-compressed JSON
+func (m *MemStore) Snapshot(w io.Writer) error {
+	m.mu.RLock()
+	snap := snapshot{
+		Version: SnapshotVersion,
+		TakenAt: time.Now().UTC(),
+		Layout:  m.config.layout(),
+		Series:  make([]seriesSnapshot, 0, len(m.series)),
+	}
+	for key, series := range m.series {
+		snap.Series = append(snap.Series, series.snapshot(key))
+	}
+	m.mu.RUnlock()
+
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(snap); err != nil {
+		return fmt.Errorf("failed to encode timeseries snapshot: %w", err)
+	}
+	return zw.Close()
+}
+
+// Restore loads the series of a snapshot written by Snapshot into the store,
+// replacing series with the same key. Nothing is restored from a snapshot of
+// another format version or ring layout; ErrIncompatibleSnapshot is returned.
+func (m *MemStore) Restore(r io.Reader) error {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrIncompatibleSnapshot, err)
+	}
+	defer zr.Close()
+
+	var snap snapshot
+	if err := json.NewDecoder(zr).Decode(&snap); err != nil {
+		return fmt.Errorf("%w: %v", ErrIncompatibleSnapshot, err)
+	}
+	if snap.Version != SnapshotVersion {
+		return fmt.Errorf("%w: version %d, expected %d", ErrIncompatibleSnapshot, snap.Version, SnapshotVersion)
+	}
+	if snap.Layout != m.config.layout() {
+		return fmt.Errorf("%w: written with steps hi=%s lo=%s med=%s", ErrIncompatibleSnapshot,
+			snap.Layout.HiResStep, snap.Layout.LoResStep, snap.Layout.MedResStep)
+	}
+
+	for _, s := range snap.Series {
+		series := m.Upsert(s.Key)
+		if series == nil {
+			// Series limit reached
+			continue
+		}
+		series.restore(s)
+	}
+	return nil
+}
+
+// snapshot copies the series' state, oldest point first
+func (s *Series) snapshot(key string) seriesSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return seriesSnapshot{
+		Key:    key,
+		Hi:     ringPoints(s.hi, s.headHi, s.fullHi),
+		Lo:     ringPoints(s.lo, s.headLo, s.fullLo),
+		LoAgg:  s.loAggPoints(),
+		Med:    ringPoints(s.med, s.headMed, s.fullMed),
+		LoBin:  s.loBin.snapshot(),
+		MedBin: s.medBin.snapshot(),
+	}
+}
+
+// restore replaces the series' state with a snapshot. When a ring is smaller
+// than the snapshot's, only the newest points are kept.
+func (s *Series) restore(snap seriesSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.headHi, s.fullHi = fillRing(s.hi, snap.Hi)
+	s.headLo, s.fullLo = fillRing(s.lo, snap.Lo)
+	if s.loAgg != nil {
+		// Aggregates only line up with Lo when the snapshot kept one per point
+		aggregates := snap.LoAgg
+		if len(aggregates) != len(snap.Lo) {
+			aggregates = nil
+		}
+		fillRing(s.loAgg, aggregates)
+	}
+	s.headMed, s.fullMed = fillRing(s.med, snap.Med)
+	s.loBin.restore(snap.LoBin)
+	s.medBin.restore(snap.MedBin)
+}
+
+// ringPoints returns the non-zero points of a ring buffer, oldest first
+func ringPoints(ring []Point, head int, full bool) []Point {
+	size, start := head, 0
+	if full {
+		size, start = len(ring), head
+	}
+	points := make([]Point, 0, size)
+	for i := 0; i < size; i++ {
+		if p := ring[(start+i)%len(ring)]; !p.IsZero() {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// loAggPoints returns the aggregates of the non-zero Lo points, oldest first,
+// or nil when the series keeps none
+func (s *Series) loAggPoints() []AggPoint {
+	if s.loAgg == nil {
+		return nil
+	}
+	aggregates := make([]AggPoint, 0, len(s.loAgg))
+	for _, idx := range ringIndexes(len(s.lo), s.headLo, s.fullLo) {
+		if !s.lo[idx].IsZero() {
+			aggregates = append(aggregates, s.loAgg[idx])
+		}
+	}
+	return aggregates
+}
+
+// fillRing writes the newest points that fit into ring, oldest first, and
+// returns the resulting head and full flag
+func fillRing[T any](ring []T, points []T) (int, bool) {
+	var zero T
+	for i := range ring {
+		ring[i] = zero
+	}
+	if len(ring) == 0 {
+		return 0, false
+	}
+	if len(points) > len(ring) {
+		points = points[len(points)-len(ring):]
+	}
+	copy(ring, points)
+	return len(points) % len(ring), len(points) == len(ring)
+}
+
+// snapshot returns the bucket's state, or nil when it is empty
+func (b *bucket) snapshot() *bucketSnapshot {
+	if b.count == 0 {
+		return nil
+	}
+	return &bucketSnapshot{Start: b.start, Count: b.count, Sum: b.sum, Min: b.min, Max: b.max, Last: b.last}
+}
+
+// restore replaces the bucket's state; nil empties it
+func (b *bucket) restore(snap *bucketSnapshot) {
+	if snap == nil {
+		*b = bucket{}
+		return
+	}
+	*b = bucket{start: snap.Start, count: snap.Count, sum: snap.Sum, min: snap.Min, max: snap.Max, last: snap.Last}
+}
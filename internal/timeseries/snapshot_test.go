@@ -0,0 +1,194 @@
+package timeseries
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fillStore adds count points one second apart, ending now, to key
+func fillStore(store *MemStore, key string, count int) {
+	series := store.Upsert(key)
+	start := time.Now().Add(-time.Duration(count) * time.Second).Truncate(time.Second)
+	for i := 0; i < count; i++ {
+		series.Add(NewPointWithEntity(start.Add(time.Duration(i)*time.Second), float64(i), map[string]string{"node": "a"}))
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	config := DefaultConfig()
+	original := NewMemStore(config)
+	fillStore(original, "node.cpu.usage.cores.a", 200)
+	fillStore(original, "cluster.nodes.count", 3)
+
+	var buf bytes.Buffer
+	if err := original.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	restored := NewMemStore(config, WithSnapshot(&buf, func(err error) {
+		t.Fatalf("restore error = %v", err)
+	}))
+	if len(restored.Keys()) != 2 {
+		t.Fatalf("expected 2 series, got %d", len(restored.Keys()))
+	}
+
+	for _, key := range original.Keys() {
+		want, _ := original.Get(key)
+		got, ok := restored.Get(key)
+		if !ok {
+			t.Fatalf("series %s not restored", key)
+		}
+		for _, res := range []Resolution{Hi, Lo, Med} {
+			wantPoints, gotPoints := want.GetAll(res), got.GetAll(res)
+			if len(wantPoints) != len(gotPoints) {
+				t.Fatalf("%s res %d: expected %d points, got %d", key, res, len(wantPoints), len(gotPoints))
+			}
+			for i := range wantPoints {
+				if !wantPoints[i].T.Equal(gotPoints[i].T) || wantPoints[i].V != gotPoints[i].V {
+					t.Errorf("%s res %d point %d: expected %v, got %v", key, res, i, wantPoints[i], gotPoints[i])
+				}
+			}
+		}
+		if gotPoints := got.GetAll(Hi); gotPoints[0].Entity["node"] != "a" {
+			t.Errorf("expected entity metadata to be restored, got %v", gotPoints[0].Entity)
+		}
+	}
+
+	// Pending downsampling buckets carry on where they left off
+	series, _ := restored.Get("node.cpu.usage.cores.a")
+	if series.loBin.count == 0 {
+		t.Error("expected the pending lo-res bucket to be restored")
+	}
+	latest, _ := series.Latest()
+	series.Add(NewPoint(latest.T.Add(config.LoResStep), 1000))
+	lo := series.GetAll(Lo)
+	if last := lo[len(lo)-1]; !last.T.After(latest.T.Add(-config.LoResStep)) {
+		t.Errorf("expected the restored bucket to be flushed as the newest lo-res point, got %v", last)
+	}
+}
+
+func TestSnapshotLoResAggregates(t *testing.T) {
+	config := DefaultConfig()
+	config.LoResAggregates = true
+	original := NewMemStore(config)
+	fillStore(original, "node.net.rx.a", 30)
+
+	var buf bytes.Buffer
+	if err := original.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	data := buf.Bytes()
+
+	restored := NewMemStore(config)
+	if err := restored.Restore(bytes.NewReader(data)); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	want, _ := original.Get("node.net.rx.a")
+	got, _ := restored.Get("node.net.rx.a")
+	wantAggs, gotAggs := want.GetAggregatesSince(time.Time{}), got.GetAggregatesSince(time.Time{})
+	if len(wantAggs) == 0 || len(wantAggs) != len(gotAggs) {
+		t.Fatalf("expected %d aggregates, got %d", len(wantAggs), len(gotAggs))
+	}
+	for i := range wantAggs {
+		if !wantAggs[i].T.Equal(gotAggs[i].T) || wantAggs[i].Max != gotAggs[i].Max {
+			t.Errorf("aggregate %d: expected %v, got %v", i, wantAggs[i], gotAggs[i])
+		}
+	}
+
+	// A store without aggregates restores the same snapshot, and one with
+	// aggregates restores a snapshot written without them
+	if err := NewMemStore(DefaultConfig()).Restore(bytes.NewReader(data)); err != nil {
+		t.Fatalf("Restore() without aggregates error = %v", err)
+	}
+	plain := NewMemStore(DefaultConfig())
+	fillStore(plain, "node.net.rx.a", 30)
+	buf.Reset()
+	if err := plain.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	upgraded := NewMemStore(config)
+	if err := upgraded.Restore(&buf); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	series, _ := upgraded.Get("node.net.rx.a")
+	if len(series.GetAll(Lo)) == 0 || len(series.GetAggregatesSince(time.Time{})) != 0 {
+		t.Error("expected lo points without aggregates")
+	}
+}
+
+func TestSnapshotRestoreIntoSmallerRing(t *testing.T) {
+	original := NewMemStore(DefaultConfig())
+	fillStore(original, "cluster.pods.running", 50)
+
+	var buf bytes.Buffer
+	if err := original.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	config := DefaultConfig()
+	config.HiResPoints = 10
+	restored := NewMemStore(config)
+	if err := restored.Restore(&buf); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	series, _ := restored.Get("cluster.pods.running")
+	points := series.GetAll(Hi)
+	if len(points) != 10 {
+		t.Fatalf("expected the newest 10 points, got %d", len(points))
+	}
+	if points[0].V != 40 || points[9].V != 49 {
+		t.Errorf("expected values 40..49, got %v..%v", points[0].V, points[9].V)
+	}
+}
+
+func TestSnapshotIncompatible(t *testing.T) {
+	original := NewMemStore(DefaultConfig())
+	fillStore(original, "cluster.pods.running", 5)
+
+	var buf bytes.Buffer
+	if err := original.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	data := buf.Bytes()
+
+	t.Run("different layout", func(t *testing.T) {
+		config := DefaultConfig()
+		config.LoResStep = 10 * time.Second
+		store := NewMemStore(config)
+		err := store.Restore(bytes.NewReader(data))
+		if !errors.Is(err, ErrIncompatibleSnapshot) {
+			t.Fatalf("expected ErrIncompatibleSnapshot, got %v", err)
+		}
+		if len(store.Keys()) != 0 {
+			t.Errorf("expected no series from an incompatible snapshot, got %d", len(store.Keys()))
+		}
+	})
+
+	t.Run("different version", func(t *testing.T) {
+		var other bytes.Buffer
+		zw := gzip.NewWriter(&other)
+		json.NewEncoder(zw).Encode(snapshot{Version: SnapshotVersion + 1, Layout: DefaultConfig().layout()})
+		zw.Close()
+
+		var reported error
+		store := NewMemStore(DefaultConfig(), WithSnapshot(&other, func(err error) { reported = err }))
+		if !errors.Is(reported, ErrIncompatibleSnapshot) {
+			t.Fatalf("expected ErrIncompatibleSnapshot, got %v", reported)
+		}
+		if len(store.Keys()) != 0 {
+			t.Errorf("expected an empty store, got %d series", len(store.Keys()))
+		}
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		store := NewMemStore(DefaultConfig())
+		if err := store.Restore(bytes.NewReader([]byte("not a snapshot"))); !errors.Is(err, ErrIncompatibleSnapshot) {
+			t.Fatalf("expected ErrIncompatibleSnapshot, got %v", err)
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package timeseries
 
-import "sync"
+import (
+	"io"
+	"sync"
+)
 
 // Store defines the interface for storing time series
 type Store interface {
@@ -18,6 +21,13 @@ type Store interface {
 
 	// Prune removes old data from all series
 	Prune()
+
+	// SetAggregation sets how series whose key starts with prefix fold points
+	// into low resolution steps. It applies to series created afterwards.
+	SetAggregation(prefix string, reducer Reducer)
+
+	// Snapshot serializes every series so it can be restored after a restart
+	Snapshot(w io.Writer) error
 }
 
 // MemStore is an in-memory implementation of Store
@@ -29,16 +39,20 @@ type MemStore struct {
 }
 
 // NewMemStore creates a new in-memory store with the given configuration
-func NewMemStore(config Config) *MemStore {
+func NewMemStore(config Config, opts ...MemStoreOption) *MemStore {
 	health := NewHealthMetrics()
 	// Set health limits from config
 	health.SetLimits(config.MaxSeries, config.MaxPointsPerSeries, config.MaxWSClients)
 
-	return &MemStore{
+	m := &MemStore{
 		series: make(map[string]*Series),
 		config: config,
 		health: health,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 // NewMemStoreWithHealth creates a new in-memory store with custom health metrics
@@ -70,7 +84,7 @@ func (m *MemStore) Upsert(key string) *Series {
 	}
 
 	// Create new series with health awareness
-	series := NewSeriesWithHealth(m.config, m.health)
+	series := NewSeriesWithHealth(m.config.configForKey(key), m.health)
 	m.series[key] = series
 	m.health.IncrementSeriesCount()
 	return series
@@ -127,6 +141,21 @@ func (m *MemStore) Prune() {
 	}
 }
 
+// SetAggregation sets the low resolution reducer for series created afterwards
+// whose key starts with prefix
+func (m *MemStore) SetAggregation(prefix string, reducer Reducer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Copy so a map shared with the caller's Config is never written
+	aggregations := make(map[string]Reducer, len(m.config.Aggregations)+1)
+	for p, r := range m.config.Aggregations {
+		aggregations[p] = r
+	}
+	aggregations[prefix] = reducer
+	m.config.Aggregations = aggregations
+}
+
 // GetHealth returns the health metrics for the store
 func (m *MemStore) GetHealth() *HealthMetrics {
 	return m.health
@@ -267,3 +267,62 @@ func TestMemStore_SeriesWithHealth_RecordsPoints(t *testing.T) {
 		t.Errorf("Expected total points added 2, got %d", snapshot.TotalPointsAdded)
 	}
 }
+
+func TestMemStoreSelfSeriesRetention(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxWindow = 60 * time.Minute
+	config.SelfMetricsWindow = 24 * time.Hour
+	store := NewMemStore(config)
+
+	cluster := store.Upsert(ClusterCPUUsedCores)
+	self := store.Upsert(AggregatorTickDurationSeconds)
+
+	// Two points in different lo-res bins so the first bin is flushed
+	for _, age := range []time.Duration{3 * time.Hour, 2 * time.Hour} {
+		ts := time.Now().Add(-age)
+		cluster.Add(NewPoint(ts, 1))
+		self.Add(NewPoint(ts, 0.25))
+	}
+
+	store.Prune()
+
+	if points := cluster.GetAll(Hi); len(points) != 0 {
+		t.Errorf("Expected cluster series to drop points older than %v, got %d", config.MaxWindow, len(points))
+	}
+	if points := self.GetAll(Hi); len(points) != 2 {
+		t.Errorf("Expected self series to keep 2 hi-res points within %v, got %d", config.SelfMetricsWindow, len(points))
+	}
+	if points := self.GetAll(Lo); len(points) != 1 {
+		t.Errorf("Expected self series to keep 1 lo-res point, got %d", len(points))
+	}
+}
+
+func TestMemStoreSelfSeriesDefaultRetention(t *testing.T) {
+	config := DefaultConfig()
+	store := NewMemStore(config)
+
+	self := store.Upsert(AggregatorSeriesCount)
+	self.Add(NewPoint(time.Now().Add(-2*time.Hour), 10))
+	store.Prune()
+
+	if points := self.GetAll(Hi); len(points) != 0 {
+		t.Errorf("Expected self series to follow MaxWindow when no self retention is set, got %d points", len(points))
+	}
+}
+
+func TestMemStoreSetAggregation(t *testing.T) {
+	config := DefaultConfig()
+	config.Aggregations = map[string]Reducer{"pod.": ReducerSum}
+	store := NewMemStore(config)
+	store.SetAggregation(ClusterNetRxBps, ReducerMax)
+
+	if got := store.Upsert(ClusterNetRxBps).config.Aggregation; got != ReducerMax {
+		t.Errorf("Expected registered aggregation max, got %q", got)
+	}
+	if got := store.Upsert("pod.cpu.usage.cores.default.web").config.Aggregation; got != ReducerSum {
+		t.Errorf("Expected configured aggregation sum to be kept, got %q", got)
+	}
+	if _, ok := config.Aggregations[ClusterNetRxBps]; ok {
+		t.Error("Expected SetAggregation not to modify the caller's config")
+	}
+}